@@ -20,6 +20,10 @@ const (
 	channelPrefix = "channel."
 	channelUpdate = channelPrefix + "update"
 	channelRemove = channelPrefix + "remove"
+
+	// Remove events of the deleted things and channels carry
+	// the deleted status instead of the client status.
+	deletedStatus = "deleted"
 )
 
 type eventHandler struct {
@@ -62,6 +66,11 @@ func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
 
 func decodeRemoveThing(event map[string]interface{}) removeEvent {
 	status := read(event, "status", "")
+	if status == deletedStatus {
+		return removeEvent{
+			id: read(event, "id", ""),
+		}
+	}
 	st, err := clients.ToStatus(status)
 	if err != nil {
 		return removeEvent{}
@@ -96,6 +105,11 @@ func decodeUpdateChannel(event map[string]interface{}) updateChannelEvent {
 
 func decodeRemoveChannel(event map[string]interface{}) removeEvent {
 	status := read(event, "status", "")
+	if status == deletedStatus {
+		return removeEvent{
+			id: read(event, "id", ""),
+		}
+	}
 	st, err := clients.ToStatus(status)
 	if err != nil {
 		return removeEvent{}
@@ -107,3 +107,20 @@ func (svc *mainfluxChannels) DisableGroup(ctx context.Context, token, id string)
 	}
 	return mfgroups.Group{}, nil
 }
+
+func (svc *mainfluxChannels) DeleteGroup(ctx context.Context, token, id string) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	userID, err := svc.auth.Identify(ctx, &upolicies.IdentifyReq{Token: token})
+	if err != nil {
+		return errors.ErrAuthentication
+	}
+
+	if t, ok := svc.channels[id]; !ok || t.Owner != userID.GetId() {
+		return errors.ErrNotFound
+	}
+	delete(svc.channels, id)
+
+	return nil
+}
@@ -58,6 +58,10 @@ func (svc *mainfluxPolicies) DeletePolicy(ctx context.Context, token string, p t
 	return nil
 }
 
+func (svc *mainfluxPolicies) AddPolicies(context.Context, string, bool, ...tpolicies.Policy) ([]tpolicies.PolicyResult, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxPolicies) UpdatePolicy(context.Context, string, tpolicies.Policy) (tpolicies.Policy, error) {
 	panic("not implemented")
 }
@@ -370,7 +370,7 @@ func (bs bootstrapService) identify(ctx context.Context, token string) (string,
 		return "", errors.ErrAuthentication
 	}
 
-	return res.GetId(), nil
+	return res.GetOwner(), nil
 }
 
 // Method thing retrieves Mainflux Thing creating one if an empty ID is passed.
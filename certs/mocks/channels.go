@@ -43,7 +43,7 @@ func (svc *mainfluxChannels) CreateGroups(ctx context.Context, token string, chs
 	}
 	for i := range chs {
 		svc.counter++
-		chs[i].Owner = userID.GetId()
+		chs[i].Owner = userID.GetOwner()
 		chs[i].ID = strconv.FormatUint(svc.counter, 10)
 		svc.channels[chs[i].ID] = chs[i]
 	}
@@ -79,10 +79,10 @@ func (svc *mainfluxChannels) EnableGroup(ctx context.Context, token, id string)
 		return mfgroups.Group{}, errors.ErrAuthentication
 	}
 
-	if t, ok := svc.channels[id]; !ok || t.Owner != userID.GetId() {
+	if t, ok := svc.channels[id]; !ok || t.Owner != userID.GetOwner() {
 		return mfgroups.Group{}, errors.ErrNotFound
 	}
-	if t, ok := svc.channels[id]; ok && t.Owner == userID.GetId() {
+	if t, ok := svc.channels[id]; ok && t.Owner == userID.GetOwner() {
 		t.Status = mfclients.EnabledStatus
 		return t, nil
 	}
@@ -98,12 +98,29 @@ func (svc *mainfluxChannels) DisableGroup(ctx context.Context, token, id string)
 		return mfgroups.Group{}, errors.ErrAuthentication
 	}
 
-	if t, ok := svc.channels[id]; !ok || t.Owner != userID.GetId() {
+	if t, ok := svc.channels[id]; !ok || t.Owner != userID.GetOwner() {
 		return mfgroups.Group{}, errors.ErrNotFound
 	}
-	if t, ok := svc.channels[id]; ok && t.Owner == userID.GetId() {
+	if t, ok := svc.channels[id]; ok && t.Owner == userID.GetOwner() {
 		t.Status = mfclients.DisabledStatus
 		return t, nil
 	}
 	return mfgroups.Group{}, nil
 }
+
+func (svc *mainfluxChannels) DeleteGroup(ctx context.Context, token, id string) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	userID, err := svc.auth.Identify(ctx, &upolicies.IdentifyReq{Token: token})
+	if err != nil {
+		return errors.ErrAuthentication
+	}
+
+	if t, ok := svc.channels[id]; !ok || t.Owner != userID.GetOwner() {
+		return errors.ErrNotFound
+	}
+	delete(svc.channels, id)
+
+	return nil
+}
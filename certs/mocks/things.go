@@ -7,10 +7,12 @@ import (
 	"context"
 	"strconv"
 	"sync"
+	"time"
 
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things/clients"
+	cpostgres "github.com/mainflux/mainflux/things/clients/postgres"
 	upolicies "github.com/mainflux/mainflux/users/policies"
 )
 
@@ -42,7 +44,7 @@ func (svc *mainfluxThings) CreateThings(ctx context.Context, token string, ths .
 	}
 	for i := range ths {
 		svc.counter++
-		ths[i].Owner = userID.GetId()
+		ths[i].Owner = userID.GetOwner()
 		ths[i].ID = strconv.FormatUint(svc.counter, 10)
 		ths[i].Credentials.Secret = ths[i].ID
 		svc.things[ths[i].ID] = ths[i]
@@ -60,7 +62,7 @@ func (svc *mainfluxThings) ViewClient(ctx context.Context, token, id string) (mf
 		return mfclients.Client{}, errors.ErrAuthentication
 	}
 
-	if t, ok := svc.things[id]; ok && t.Owner == userID.GetId() {
+	if t, ok := svc.things[id]; ok && t.Owner == userID.GetOwner() {
 		return t, nil
 	}
 
@@ -76,10 +78,10 @@ func (svc *mainfluxThings) EnableClient(ctx context.Context, token, id string) (
 		return mfclients.Client{}, errors.ErrAuthentication
 	}
 
-	if t, ok := svc.things[id]; !ok || t.Owner != userID.GetId() {
+	if t, ok := svc.things[id]; !ok || t.Owner != userID.GetOwner() {
 		return mfclients.Client{}, errors.ErrNotFound
 	}
-	if t, ok := svc.things[id]; ok && t.Owner == userID.GetId() {
+	if t, ok := svc.things[id]; ok && t.Owner == userID.GetOwner() {
 		t.Status = mfclients.EnabledStatus
 		return t, nil
 	}
@@ -95,16 +97,33 @@ func (svc *mainfluxThings) DisableClient(ctx context.Context, token, id string)
 		return mfclients.Client{}, errors.ErrAuthentication
 	}
 
-	if t, ok := svc.things[id]; !ok || t.Owner != userID.GetId() {
+	if t, ok := svc.things[id]; !ok || t.Owner != userID.GetOwner() {
 		return mfclients.Client{}, errors.ErrNotFound
 	}
-	if t, ok := svc.things[id]; ok && t.Owner == userID.GetId() {
+	if t, ok := svc.things[id]; ok && t.Owner == userID.GetOwner() {
 		t.Status = mfclients.DisabledStatus
 		return t, nil
 	}
 	return mfclients.Client{}, nil
 }
 
+func (svc *mainfluxThings) DeleteClient(ctx context.Context, token, id string) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	userID, err := svc.auth.Identify(ctx, &upolicies.IdentifyReq{Token: token})
+	if err != nil {
+		return errors.ErrAuthentication
+	}
+
+	if t, ok := svc.things[id]; !ok || t.Owner != userID.GetOwner() {
+		return errors.ErrNotFound
+	}
+	delete(svc.things, id)
+
+	return nil
+}
+
 func (svc *mainfluxThings) UpdateClient(context.Context, string, mfclients.Client) (mfclients.Client, error) {
 	panic("not implemented")
 }
@@ -133,6 +152,26 @@ func (svc *mainfluxThings) Identify(context.Context, string) (string, error) {
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) AddClientSecret(context.Context, string, string, cpostgres.Secret) (cpostgres.Secret, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListClientSecrets(context.Context, string, string) ([]cpostgres.Secret, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdateClientSecretExpiry(context.Context, string, string, string, time.Time) (cpostgres.Secret, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveClientSecret(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ExecuteOperations(context.Context, string, ...cpostgres.Operation) ([]cpostgres.OperationResult, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ShareClient(ctx context.Context, token, userID, groupID, thingID string, actions []string) error {
 	panic("not implemented")
 }
@@ -97,7 +97,7 @@ func (cs *certsService) IssueCert(ctx context.Context, token, thingID string, tt
 
 	c := Cert{
 		ThingID:        thingID,
-		OwnerID:        owner.GetId(),
+		OwnerID:        owner.GetOwner(),
 		ClientCert:     cert.ClientCert,
 		IssuingCA:      cert.IssuingCA,
 		CAChain:        cert.CAChain,
@@ -124,7 +124,7 @@ func (cs *certsService) RevokeCert(ctx context.Context, token, thingID string) (
 
 	// TODO: Replace offset and limit
 	offset, limit := uint64(0), uint64(10000)
-	cp, err := cs.certsRepo.RetrieveByThing(ctx, u.GetId(), thing.ID, offset, limit)
+	cp, err := cs.certsRepo.RetrieveByThing(ctx, u.GetOwner(), thing.ID, offset, limit)
 	if err != nil {
 		return revoke, errors.Wrap(ErrFailedCertRevocation, err)
 	}
@@ -135,7 +135,7 @@ func (cs *certsService) RevokeCert(ctx context.Context, token, thingID string) (
 			return revoke, errors.Wrap(ErrFailedCertRevocation, err)
 		}
 		revoke.RevocationTime = revTime
-		if err = cs.certsRepo.Remove(ctx, u.GetId(), c.Serial); err != nil {
+		if err = cs.certsRepo.Remove(ctx, u.GetOwner(), c.Serial); err != nil {
 			return revoke, errors.Wrap(ErrFailedToRemoveCertFromDB, err)
 		}
 	}
@@ -149,7 +149,7 @@ func (cs *certsService) ListCerts(ctx context.Context, token, thingID string, of
 		return Page{}, err
 	}
 
-	cp, err := cs.certsRepo.RetrieveByThing(ctx, u.GetId(), thingID, offset, limit)
+	cp, err := cs.certsRepo.RetrieveByThing(ctx, u.GetOwner(), thingID, offset, limit)
 	if err != nil {
 		return Page{}, err
 	}
@@ -172,7 +172,7 @@ func (cs *certsService) ListSerials(ctx context.Context, token, thingID string,
 		return Page{}, err
 	}
 
-	return cs.certsRepo.RetrieveByThing(ctx, u.GetId(), thingID, offset, limit)
+	return cs.certsRepo.RetrieveByThing(ctx, u.GetOwner(), thingID, offset, limit)
 }
 
 func (cs *certsService) ViewCert(ctx context.Context, token, serialID string) (Cert, error) {
@@ -181,7 +181,7 @@ func (cs *certsService) ViewCert(ctx context.Context, token, serialID string) (C
 		return Cert{}, err
 	}
 
-	cert, err := cs.certsRepo.RetrieveBySerial(ctx, u.GetId(), serialID)
+	cert, err := cs.certsRepo.RetrieveBySerial(ctx, u.GetOwner(), serialID)
 	if err != nil {
 		return Cert{}, err
 	}
@@ -60,6 +60,7 @@ var cmdChannels = []cobra.Command{
 				Offset:   Offset,
 				Limit:    Limit,
 				Metadata: metadata,
+				Filter:   Filter,
 			}
 
 			if args[0] == all {
@@ -118,6 +119,7 @@ var cmdChannels = []cobra.Command{
 			pm := mfxsdk.PageMetadata{
 				Offset: Offset,
 				Limit:  Limit,
+				Filter: Filter,
 			}
 			cl, err := sdk.ThingsByChannel(args[0], pm, args[1])
 			if err != nil {
@@ -166,6 +168,24 @@ var cmdChannels = []cobra.Command{
 			logJSON(channel)
 		},
 	},
+	{
+		Use:   "delete <channel_id> <user_auth_token>",
+		Short: "Delete channel",
+		Long:  `Removes channel with provided id together with its connections`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteChannel(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
 }
 
 // NewChannelsCmd returns channels command.
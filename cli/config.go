@@ -24,6 +24,7 @@ type remotes struct {
 	HTTPAdapterURL  string `toml:"http_adapter_url"`
 	BootstrapURL    string `toml:"bootstrap_url"`
 	CertsURL        string `toml:"certs_url"`
+	NotifierURL     string `toml:"notifier_url"`
 	TLSVerification bool   `toml:"tls_verification"`
 }
 
@@ -91,6 +92,7 @@ func ParseConfig(sdkConf mfxsdk.Config) (mfxsdk.Config, error) {
 				HTTPAdapterURL:  "http://localhost/http:9016",
 				BootstrapURL:    "http://localhost",
 				CertsURL:        "https://localhost:9019",
+				NotifierURL:     "http://localhost:9015",
 				TLSVerification: false,
 			},
 		}
@@ -144,6 +146,11 @@ func ParseConfig(sdkConf mfxsdk.Config) (mfxsdk.Config, error) {
 	sdkConf.HTTPAdapterURL = config.Remotes.HTTPAdapterURL
 	sdkConf.BootstrapURL = config.Remotes.BootstrapURL
 	sdkConf.CertsURL = config.Remotes.CertsURL
+	// Config files created before the notifier URL was introduced don't
+	// contain it, so the default notifier URL is kept in that case.
+	if config.Remotes.NotifierURL != "" {
+		sdkConf.NotifierURL = config.Remotes.NotifierURL
+	}
 
 	return sdkConf, nil
 }
@@ -196,6 +203,7 @@ func setConfigValue(key string, value string) error {
 		"http_adapter_url": &config.Remotes.HTTPAdapterURL,
 		"bootstrap_url":    &config.Remotes.BootstrapURL,
 		"certs_url":        &config.Remotes.CertsURL,
+		"notifier_url":     &config.Remotes.NotifierURL,
 		"tls_verification": &config.Remotes.TLSVerification,
 		"offset":           &config.Filter.Offset,
 		"limit":            &config.Filter.Limit,
@@ -0,0 +1,109 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+
+	mfxsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var cmdKeys = []cobra.Command{
+	{
+		Use:   "issue <JSON_key> <user_auth_token>",
+		Short: "Issue API key",
+		Long: "Issues new API key with provided name, duration and scope\n" +
+			"The key value is shown only once, store it safely\n" +
+			"Usage:\n" +
+			"\tmainflux-cli keys issue '{\"name\":\"ci\", \"duration\":\"720h\"}' $USERTOKEN\n" +
+			"\tmainflux-cli keys issue '{\"name\":\"reader\", \"scope\":{\"actions\":[\"m_read\"], \"objects\":[\"<channel_id>\"]}}' $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var key mfxsdk.Key
+			if err := json.Unmarshal([]byte(args[0]), &key); err != nil {
+				logError(err)
+				return
+			}
+			key, err := sdk.IssueKey(key, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(key)
+		},
+	},
+	{
+		Use:   "get [all | <key_id>] <user_auth_token>",
+		Short: "Get API key",
+		Long: `Get API key.
+				all - lists all API keys
+				<key_id> - view API key of <key_id>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+			pageMetadata := mfxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+			}
+			if args[0] == all {
+				kp, err := sdk.ListKeys(pageMetadata, args[1])
+				if err != nil {
+					logError(err)
+					return
+				}
+				logJSON(kp)
+				return
+			}
+
+			k, err := sdk.ViewKey(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(k)
+		},
+	},
+	{
+		Use:   "revoke <key_id> <user_auth_token>",
+		Short: "Revoke API key",
+		Long:  `Revokes API key with the provided id`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.RevokeKey(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+// NewKeysCmd returns API keys command.
+func NewKeysCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "keys [issue | get | revoke]",
+		Short: "API keys management",
+		Long:  `API keys management: issue, get, or revoke long-lived user API keys`,
+	}
+
+	for i := range cmdKeys {
+		cmd.AddCommand(&cmdKeys[i])
+	}
+
+	return &cmd
+}
@@ -3,7 +3,17 @@
 
 package cli
 
-import "github.com/spf13/cobra"
+import (
+	"os"
+
+	mfxsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var exportContentTypes = map[string]mfxsdk.ContentType{
+	"csv":    mfxsdk.CTCSV,
+	"ndjson": mfxsdk.CTNDJSON,
+}
 
 var cmdMessages = []cobra.Command{
 	{
@@ -27,14 +37,19 @@ var cmdMessages = []cobra.Command{
 	{
 		Use:   "read <channel_id.subtopic> <user_token>",
 		Short: "Read messages",
-		Long:  `Reads all channel messages`,
+		Long:  `Reads channel messages page. Use the returned next_cursor value as --cursor to read the following page`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) != 2 {
 				logUsage(cmd.Use)
 				return
 			}
 
-			m, err := sdk.ReadMessages(args[0], args[1])
+			pm := mfxsdk.MessagePageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+				Cursor: Cursor,
+			}
+			m, err := sdk.ReadMessages(args[0], pm, args[1])
 			if err != nil {
 				logError(err)
 				return
@@ -43,14 +58,45 @@ var cmdMessages = []cobra.Command{
 			logJSON(m)
 		},
 	},
+	{
+		Use:   "export <channel_id.subtopic> <csv | ndjson> <file_path> <user_token>",
+		Short: "Export messages",
+		Long:  `Exports all channel messages to the file as CSV or newline-delimited JSON`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			ct, ok := exportContentTypes[args[1]]
+			if !ok {
+				logUsage(cmd.Use)
+				return
+			}
+
+			file, err := os.Create(args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+			defer file.Close()
+
+			if err := sdk.ExportMessages(args[0], ct, file, args[3]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
 }
 
 // NewMessagesCmd returns messages command.
 func NewMessagesCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "messages [send | read]",
-		Short: "Send or read messages",
-		Long:  `Send or read messages using the http-adapter and the configured database reader`,
+		Use:   "messages [send | read | export]",
+		Short: "Send, read or export messages",
+		Long:  `Send, read or export messages using the http-adapter and the configured database reader`,
 	}
 
 	for i := range cmdMessages {
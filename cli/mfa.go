@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import "github.com/spf13/cobra"
+
+var cmdMFA = []cobra.Command{
+	{
+		Use:   "enroll <user_auth_token>",
+		Short: "Enroll MFA",
+		Long: "Starts two-factor authentication enrollment\n" +
+			"Add the printed URI or secret to the authenticator app and store the recovery codes safely\n" +
+			"Usage:\n" +
+			"\tmainflux-cli mfa enroll $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			p, err := sdk.EnrollMFA(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(p)
+		},
+	},
+	{
+		Use:   "confirm <code> <user_auth_token>",
+		Short: "Confirm MFA",
+		Long:  `Enables two-factor authentication using the one-time password generated by the authenticator app`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.ConfirmMFA(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	{
+		Use:   "verify <code> <challenge_token>",
+		Short: "Verify MFA",
+		Long: "Exchanges the MFA challenge token and a one-time password or recovery code for the user token\n" +
+			"Usage:\n" +
+			"\tmainflux-cli mfa verify 123456 $CHALLENGETOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			token, err := sdk.VerifyMFA(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(token)
+		},
+	},
+	{
+		Use:   "unenroll <code> <user_auth_token>",
+		Short: "Unenroll MFA",
+		Long:  `Disables two-factor authentication using a one-time password or recovery code`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DisableMFA(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	{
+		Use:   "reset <user_id> <user_auth_token>",
+		Short: "Reset MFA",
+		Long:  `Resets two-factor authentication of the user, which is allowed to admins only`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.ResetMFA(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+// NewMFACmd returns MFA command.
+func NewMFACmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "mfa [enroll | confirm | verify | unenroll | reset]",
+		Short: "Two-factor authentication",
+		Long:  `Two-factor authentication: enroll, confirm, verify, unenroll or reset TOTP two-factor authentication`,
+	}
+
+	for i := range cmdMFA {
+		cmd.AddCommand(&cmdMFA[i])
+	}
+
+	return &cmd
+}
@@ -215,14 +215,15 @@ var cmdPolicies = []cobra.Command{
 // NewPolicyCmd returns policies command.
 func NewPolicyCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "policies [create | update | list | remove | authorize ]",
+		Use:   "policies [create | update | list | remove | authorize | roles ]",
 		Short: "Policies management",
-		Long:  `Policies management: create or update or list or delete or check policies`,
+		Long:  `Policies management: create or update or list or delete or check policies, and manage roles`,
 	}
 
 	for i := range cmdPolicies {
 		cmd.AddCommand(&cmdPolicies[i])
 	}
+	cmd.AddCommand(newRolesCmd())
 
 	return &cmd
 }
@@ -24,6 +24,8 @@ const (
 	csvExt  = ".csv"
 )
 
+var errUnsupportedFile = errors.New("unsupported file extension, use .json or .csv")
+
 var cmdProvision = []cobra.Command{
 	{
 		Use:   "things <things_file> <user_token>",
@@ -104,6 +106,84 @@ var cmdProvision = []cobra.Command{
 			logOK()
 		},
 	},
+	{
+		Use:   "operations <operations_file> <user_token>",
+		Short: "Provision thing operations",
+		Long: `Bulk create, update, enable and disable things, reporting the outcome of each operation.
+				CSV files need a header row naming the columns: op, id, name, secret, tags, metadata and status.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			var report mfxsdk.OperationsReport
+			switch filepath.Ext(args[0]) {
+			case csvExt:
+				report, err = sdk.ExecuteThingOperationsCSV(data, args[1])
+			case jsonExt:
+				var ops []mfxsdk.ThingOperation
+				if err = json.Unmarshal(data, &ops); err != nil {
+					logError(err)
+					return
+				}
+				report, err = sdk.ExecuteThingOperations(ops, args[1])
+			default:
+				err = errUnsupportedFile
+			}
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+	{
+		Use:   "policies <policies_file> <user_token>",
+		Short: "Provision policies",
+		Long: `Bulk connect things to channels, reporting the outcome of each connection.
+				CSV files need a header row naming the columns: subject, object and actions.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			var report mfxsdk.PoliciesReport
+			switch filepath.Ext(args[0]) {
+			case csvExt:
+				report, err = sdk.CreateThingPoliciesCSV(data, args[1])
+			case jsonExt:
+				var policies []mfxsdk.Policy
+				if err = json.Unmarshal(data, &policies); err != nil {
+					logError(err)
+					return
+				}
+				report, err = sdk.CreateThingPolicies(policies, false, args[1])
+			default:
+				err = errUnsupportedFile
+			}
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
 	{
 		Use:   "test",
 		Short: "test",
@@ -205,7 +285,7 @@ var cmdProvision = []cobra.Command{
 // NewProvisionCmd returns provision command.
 func NewProvisionCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "provision [things | channels | connect | test]",
+		Use:   "provision [things | channels | connect | operations | policies | test]",
 		Short: "Provision things and channels from a config file",
 		Long:  `Provision things and channels: use json or csv file to bulk provision things and channels`,
 	}
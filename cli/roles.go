@@ -0,0 +1,209 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+
+	mfxsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var cmdRoles = []cobra.Command{
+	{
+		Use:   "create <JSON_role> <user_auth_token>",
+		Short: "Create role",
+		Long: "Creates new role with provided name, description and actions\n" +
+			"Usage:\n" +
+			"\tmainflux-cli policies roles create '{\"name\":\"operator\", \"actions\":[\"c_update\", \"g_list\"]}' $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var role mfxsdk.Role
+			if err := json.Unmarshal([]byte(args[0]), &role); err != nil {
+				logError(err)
+				return
+			}
+			role, err := sdk.CreateRole(role, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(role)
+		},
+	},
+	{
+		Use:   "get [all | <role_id>] <user_auth_token>",
+		Short: "Get role",
+		Long: `Get role.
+				all - lists all roles
+				<role_id> - view role of <role_id>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+			pageMetadata := mfxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+			}
+			if args[0] == all {
+				rp, err := sdk.ListRoles(pageMetadata, args[1])
+				if err != nil {
+					logError(err)
+					return
+				}
+				logJSON(rp)
+				return
+			}
+
+			r, err := sdk.ViewRole(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(r)
+		},
+	},
+	{
+		Use:   "update <role_id> <JSON_role> <user_auth_token>",
+		Short: "Update role",
+		Long: "Updates role name, description and actions\n" +
+			"Usage:\n" +
+			"\tmainflux-cli policies roles update <role_id> '{\"name\":\"operator\", \"actions\":[\"c_update\", \"c_delete\"]}' $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var role mfxsdk.Role
+			if err := json.Unmarshal([]byte(args[1]), &role); err != nil {
+				logError(err)
+				return
+			}
+			role.ID = args[0]
+			role, err := sdk.UpdateRole(role, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(role)
+		},
+	},
+	{
+		Use:   "remove <role_id> <user_auth_token>",
+		Short: "Remove role",
+		Long:  `Removes role with the provided id together with its assignments`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.RemoveRole(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	{
+		Use:   "assign <role_id> <user_id> <group_id> <user_auth_token>",
+		Short: "Assign role",
+		Long: "Assigns role to the user on the users group or things group and its descendants\n" +
+			"Usage:\n" +
+			"\tmainflux-cli policies roles assign <role_id> <user_id> <group_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			assignment := mfxsdk.RoleAssignment{
+				RoleID:  args[0],
+				Subject: args[1],
+				Object:  args[2],
+			}
+			if err := sdk.AssignRole(assignment, args[3]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	{
+		Use:   "unassign <role_id> <user_id> <group_id> <user_auth_token>",
+		Short: "Unassign role",
+		Long: "Removes the role assignment\n" +
+			"Usage:\n" +
+			"\tmainflux-cli policies roles unassign <role_id> <user_id> <group_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			assignment := mfxsdk.RoleAssignment{
+				RoleID:  args[0],
+				Subject: args[1],
+				Object:  args[2],
+			}
+			if err := sdk.UnassignRole(assignment, args[3]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	{
+		Use:   "assignments <role_id> <user_auth_token>",
+		Short: "List role assignments",
+		Long: "Lists the assignments of the role\n" +
+			"Usage:\n" +
+			"\tmainflux-cli policies roles assignments <role_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+			pageMetadata := mfxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+			}
+
+			ap, err := sdk.ListRoleAssignments(args[0], pageMetadata, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(ap)
+		},
+	},
+}
+
+// newRolesCmd returns roles command.
+func newRolesCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "roles [create | get | update | remove | assign | unassign | assignments]",
+		Short: "Roles management",
+		Long:  `Roles management: create, get, update or remove roles, and assign them to users on groups`,
+	}
+
+	for i := range cmdRoles {
+		cmd.AddCommand(&cmdRoles[i])
+	}
+
+	return &cmd
+}
@@ -62,6 +62,7 @@ var cmdThings = []cobra.Command{
 				Offset:   Offset,
 				Limit:    Limit,
 				Metadata: metadata,
+				Filter:   Filter,
 			}
 			if args[0] == all {
 				l, err := sdk.Things(pageMetadata, args[1])
@@ -214,6 +215,99 @@ var cmdThings = []cobra.Command{
 			logJSON(thing)
 		},
 	},
+	{
+		Use:   "delete <thing_id> <user_auth_token>",
+		Short: "Delete thing",
+		Long: "Removes thing with provided id together with its connections\n" +
+			"Usage:\n" +
+			"\tmainflux-cli things delete <thing_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteThing(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	{
+		Use:   "presence <thing_id> <user_auth_token>",
+		Short: "Get thing presence",
+		Long: "Gets whether the thing is online, when it was last seen, and over which protocol and remote address\n" +
+			"Usage:\n" +
+			"\tmainflux-cli things presence <thing_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			p, err := sdk.ThingPresence(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(p)
+		},
+	},
+	{
+		Use:   "secrets [add <thing_id> <name> | get <thing_id> | expire <thing_id> <name> <duration> | remove <thing_id> <name>] <user_auth_token>",
+		Short: "Manage thing secrets",
+		Long: "Adds, lists, expires or removes named thing secrets used for key rotation\n" +
+			"Usage:\n" +
+			"\tmainflux-cli things secrets add <thing_id> <name> $USERTOKEN\n" +
+			"\tmainflux-cli things secrets get <thing_id> $USERTOKEN\n" +
+			"\tmainflux-cli things secrets expire <thing_id> <name> 24h $USERTOKEN\n" +
+			"\tmainflux-cli things secrets remove <thing_id> <name> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			switch {
+			case args[0] == "add" && len(args) == 4:
+				secret, err := sdk.AddThingSecret(args[1], mfxsdk.ThingSecret{Name: args[2]}, args[3])
+				if err != nil {
+					logError(err)
+					return
+				}
+
+				logJSON(secret)
+			case args[0] == "get" && len(args) == 3:
+				secrets, err := sdk.ThingSecrets(args[1], args[2])
+				if err != nil {
+					logError(err)
+					return
+				}
+
+				logJSON(secrets)
+			case args[0] == "expire" && len(args) == 5:
+				secret, err := sdk.UpdateThingSecretExpiry(args[1], args[2], args[3], args[4])
+				if err != nil {
+					logError(err)
+					return
+				}
+
+				logJSON(secret)
+			case args[0] == "remove" && len(args) == 4:
+				if err := sdk.RemoveThingSecret(args[1], args[2], args[3]); err != nil {
+					logError(err)
+					return
+				}
+
+				logOK()
+			default:
+				logUsage(cmd.Use)
+			}
+		},
+	},
 	{
 		Use:   "share <channel_id> <user_id> <allowed_actions> <user_auth_token>",
 		Short: "Share thing with a user",
@@ -317,9 +411,9 @@ var cmdThings = []cobra.Command{
 // NewThingsCmd returns things command.
 func NewThingsCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "things [create | get | update | delete | share | connect | disconnect | connections | not-connected]",
+		Use:   "things [create | get | update | delete | presence | secrets | share | connect | disconnect | connections | not-connected]",
 		Short: "Things management",
-		Long:  `Things management: create, get, update, delete or share Thing, connect or disconnect Thing from Channel and get the list of Channels connected or disconnected from a Thing`,
+		Long:  `Things management: create, get, update, delete or share Thing, get Thing presence, manage Thing secrets, connect or disconnect Thing from Channel and get the list of Channels connected or disconnected from a Thing`,
 	}
 
 	for i := range cmdThings {
@@ -135,6 +135,43 @@ var cmdUsers = []cobra.Command{
 			logJSON(token)
 		},
 	},
+	{
+		Use:   "revoketoken [all] <token>",
+		Short: "Revoke token",
+		Long: "Revoke token, or all the user tokens to log out everywhere\n" +
+			"For example:\n" +
+			"\tmainflux-cli users revoketoken <token> - revokes the token and the token issued alongside it\n" +
+			"\tmainflux-cli users revoketoken all <user_auth_token> - revokes all the user tokens\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 || len(args) > 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if args[0] == all {
+				if len(args) != 2 {
+					logUsage(cmd.Use)
+					return
+				}
+				if err := sdk.RevokeAllTokens(args[1]); err != nil {
+					logError(err)
+					return
+				}
+				logOK()
+				return
+			}
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+			if err := sdk.RevokeToken(args[0]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
 	{
 		Use:   "update [<user_id> <JSON_string> | tags <user_id> <tags> | identity <user_id> <identity> | owner <user_id> <owner>] <user_auth_token>",
 		Short: "Update user",
@@ -330,6 +367,47 @@ var cmdUsers = []cobra.Command{
 				return
 			}
 
+			logJSON(user)
+		},
+	},
+	{
+		Use:   "unlock <user_id> <user_auth_token>",
+		Short: "Unlock user locked out by failed logins",
+		Long: "Remove the lockout caused by too many failed login attempts\n" +
+			"Usage:\n" +
+			"\tmainflux-cli users unlock <user_id> <user_auth_token>\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.UnlockUser(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	{
+		Use:   "verify <verification_token>",
+		Short: "Verify self-registered user",
+		Long: "Verify the identity of the self-registered user using the token sent by e-mail\n" +
+			"Usage:\n" +
+			"\tmainflux-cli users verify $VERIFICATION_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			user, err := sdk.VerifyUser(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
 			logJSON(user)
 		},
 	},
@@ -338,7 +416,7 @@ var cmdUsers = []cobra.Command{
 // NewUsersCmd returns users command.
 func NewUsersCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "users [create | get | update | token | password | enable | disable]",
+		Use:   "users [create | get | update | token | refreshtoken | revoketoken | password | enable | disable | unlock | verify]",
 		Short: "Users management",
 		Long:  `Users management: create accounts and tokens"`,
 	}
@@ -24,6 +24,8 @@ var (
 	Email string = ""
 	// Metadata query parameter.
 	Metadata string = ""
+	// Filter query parameter.
+	Filter string = ""
 	// Status query parameter.
 	Status string = ""
 	// ConfigPath config path parameter.
@@ -34,6 +36,8 @@ var (
 	Topic string = ""
 	// Contact query parameter.
 	Contact string = ""
+	// Cursor query parameter.
+	Cursor string = ""
 	// RawOutput raw output mode.
 	RawOutput bool = false
 )
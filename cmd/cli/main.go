@@ -19,6 +19,7 @@ const (
 	defThingsURL    string = defURL + ":9000"
 	defBootstrapURL string = defURL + ":9013"
 	defCertsURL     string = defURL + ":9019"
+	defNotifierURL  string = defURL + ":9015"
 )
 
 func main() {
@@ -30,6 +31,7 @@ func main() {
 		HTTPAdapterURL:  fmt.Sprintf("%s/http", defURL),
 		BootstrapURL:    defBootstrapURL,
 		CertsURL:        defCertsURL,
+		NotifierURL:     defNotifierURL,
 		MsgContentType:  sdk.ContentType(msgContentType),
 		TLSVerification: false,
 		HostURL:         defURL,
@@ -62,6 +64,8 @@ func main() {
 	certsCmd := cli.NewCertsCmd()
 	subscriptionsCmd := cli.NewSubscriptionCmd()
 	policiesCmd := cli.NewPolicyCmd()
+	keysCmd := cli.NewKeysCmd()
+	mfaCmd := cli.NewMFACmd()
 	configCmd := cli.NewConfigCmd()
 
 	// Root Commands
@@ -76,6 +80,8 @@ func main() {
 	rootCmd.AddCommand(certsCmd)
 	rootCmd.AddCommand(subscriptionsCmd)
 	rootCmd.AddCommand(policiesCmd)
+	rootCmd.AddCommand(keysCmd)
+	rootCmd.AddCommand(mfaCmd)
 	rootCmd.AddCommand(configCmd)
 
 	// Root Flags
@@ -119,6 +125,14 @@ func main() {
 		"HTTP adapter URL",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.NotifierURL,
+		"notifier-url",
+		"N",
+		sdkConf.NotifierURL,
+		"Notifier service URL",
+	)
+
 	rootCmd.PersistentFlags().StringVarP(
 		&sdkConf.ReaderURL,
 		"reader-url",
@@ -208,6 +222,14 @@ func main() {
 		"Metadata query parameter",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&cli.Filter,
+		"filter",
+		"F",
+		"",
+		"Things and channels filter expression query parameter",
+	)
+
 	rootCmd.PersistentFlags().StringVarP(
 		&cli.Status,
 		"status",
@@ -239,6 +261,14 @@ func main() {
 		"",
 		"Subscription contact query parameter",
 	)
+
+	rootCmd.PersistentFlags().StringVarP(
+		&cli.Cursor,
+		"cursor",
+		"",
+		"",
+		"Messages page cursor query parameter",
+	)
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,174 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains dlq main function to start the dead-letter service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	chclient "github.com/mainflux/callhome/pkg/client"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/consumers/dlq/api"
+	dlqpg "github.com/mainflux/mainflux/consumers/dlq/postgres"
+	"github.com/mainflux/mainflux/consumers/dlq/tracing"
+	"github.com/mainflux/mainflux/internal"
+	authclient "github.com/mainflux/mainflux/internal/clients/grpc/auth"
+	jaegerclient "github.com/mainflux/mainflux/internal/clients/jaeger"
+	pgclient "github.com/mainflux/mainflux/internal/clients/postgres"
+	"github.com/mainflux/mainflux/internal/env"
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/internal/server"
+	httpserver "github.com/mainflux/mainflux/internal/server/http"
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/brokers"
+	brokerstracing "github.com/mainflux/mainflux/pkg/messaging/brokers/tracing"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/users/policies"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName        = "dlq"
+	envPrefixDB    = "MF_DLQ_DB_"
+	envPrefixHTTP  = "MF_DLQ_HTTP_"
+	defDB          = "dlq"
+	defSvcHTTPPort = "9020"
+	chansPrefix    = "channels"
+)
+
+type config struct {
+	LogLevel      string `env:"MF_DLQ_LOG_LEVEL"    envDefault:"info"`
+	Topic         string `env:"MF_DLQ_TOPIC"        envDefault:"dlq"`
+	BrokerURL     string `env:"MF_BROKER_URL"       envDefault:"nats://localhost:4222"`
+	JaegerURL     string `env:"MF_JAEGER_URL"       envDefault:"http://jaeger:14268/api/traces"`
+	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"   envDefault:"true"`
+	InstanceID    string `env:"MF_DLQ_INSTANCE_ID"  envDefault:""`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mflog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err)
+	}
+
+	var exitCode int
+	defer mflog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := dbConfig.LoadEnv(envPrefixDB); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.SetupWithConfig(envPrefixDB, *dlqpg.Migration(), dbConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.Parse(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	tp, err := jaegerclient.NewProvider(svcName, cfg.JaegerURL, cfg.InstanceID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Error shutting down tracer provider: %v", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pubSub, err := brokers.NewPubSub(cfg.BrokerURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	auth, authHandler, err := authclient.Setup(svcName)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authHandler.Close()
+
+	logger.Info("Successfully connected to auth grpc server " + authHandler.Secure())
+
+	svc := newService(db, dbConfig, tracer, auth, pubSub, logger)
+
+	subject := fmt.Sprintf("%s.%s", chansPrefix, cfg.Topic)
+	if err := pubSub.Subscribe(ctx, svcName, subject, dlq.NewHandler(ctx, svc)); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to dead-letter topic: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, logger, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, mainflux.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("DLQ service terminated: %s", err))
+	}
+}
+
+func newService(db *sqlx.DB, dbConfig pgclient.Config, tracer trace.Tracer, auth policies.AuthServiceClient, pub messaging.Publisher, logger mflog.Logger) dlq.Service {
+	database := postgres.NewDatabase(db, dbConfig, tracer)
+	repo := tracing.New(tracer, dlqpg.New(database))
+	idp := uuid.New()
+
+	svc := dlq.New(auth, repo, idp, pub)
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := internal.MakeMetrics("dlq", "api")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+
+	return svc
+}
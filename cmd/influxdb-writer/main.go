@@ -10,18 +10,22 @@ import (
 	"log"
 	"os"
 
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	chclient "github.com/mainflux/callhome/pkg/client"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/consumers"
 	consumertracing "github.com/mainflux/mainflux/consumers/tracing"
 	"github.com/mainflux/mainflux/consumers/writers/api"
 	"github.com/mainflux/mainflux/consumers/writers/influxdb"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
+	"github.com/mainflux/mainflux/internal"
 	influxdbclient "github.com/mainflux/mainflux/internal/clients/influxdb"
 	"github.com/mainflux/mainflux/internal/clients/jaeger"
 	"github.com/mainflux/mainflux/internal/env"
 	"github.com/mainflux/mainflux/internal/server"
 	httpserver "github.com/mainflux/mainflux/internal/server/http"
 	mflog "github.com/mainflux/mainflux/logger"
+	mfredis "github.com/mainflux/mainflux/pkg/events/redis"
 	"github.com/mainflux/mainflux/pkg/messaging/brokers"
 	brokerstracing "github.com/mainflux/mainflux/pkg/messaging/brokers/tracing"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -33,15 +37,18 @@ const (
 	envPrefixHTTP  = "MF_INFLUX_WRITER_HTTP_"
 	envPrefixDB    = "MF_INFLUXDB_"
 	defSvcHTTPPort = "9006"
+	thingsStream   = "mainflux.things"
 )
 
 type config struct {
-	LogLevel      string `env:"MF_INFLUX_WRITER_LOG_LEVEL"     envDefault:"info"`
-	ConfigPath    string `env:"MF_INFLUX_WRITER_CONFIG_PATH"   envDefault:"/config.toml"`
-	BrokerURL     string `env:"MF_BROKER_URL"                  envDefault:"nats://localhost:4222"`
-	JaegerURL     string `env:"MF_JAEGER_URL"                  envDefault:"http://jaeger:14268/api/traces"`
-	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"              envDefault:"true"`
-	InstanceID    string `env:"MF_INFLUX_WRITER_INSTANCE_ID"   envDefault:""`
+	LogLevel      string `env:"MF_INFLUX_WRITER_LOG_LEVEL"        envDefault:"info"`
+	ConfigPath    string `env:"MF_INFLUX_WRITER_CONFIG_PATH"      envDefault:"/config.toml"`
+	BrokerURL     string `env:"MF_BROKER_URL"                     envDefault:"nats://localhost:4222"`
+	JaegerURL     string `env:"MF_JAEGER_URL"                     envDefault:"http://jaeger:14268/api/traces"`
+	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"                 envDefault:"true"`
+	InstanceID    string `env:"MF_INFLUX_WRITER_INSTANCE_ID"      envDefault:""`
+	ESURL         string `env:"MF_INFLUX_WRITER_ES_URL"           envDefault:""`
+	ESConsumer    string `env:"MF_INFLUX_WRITER_EVENT_CONSUMER"   envDefault:"influxdb-writer"`
 }
 
 func main() {
@@ -119,6 +126,31 @@ func main() {
 	}
 	defer client.Close()
 
+	retentionCfg, err := retention.LoadConfig(cfg.ConfigPath)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to load retention config: %s", err))
+	}
+	policy, err := retentionCfg.Policy()
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load retention policy: %s", err))
+		exitCode = 1
+		return
+	}
+	policies := retention.NewPolicies(policy)
+	purgeInterval, err := retentionCfg.PurgeInterval()
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load retention purge interval: %s", err))
+		exitCode = 1
+		return
+	}
+	if cfg.ESURL != "" {
+		if err := subscribeToThingsES(ctx, policies, cfg, logger); err != nil {
+			logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
 	repo := influxdb.NewAsync(client, repocfg)
 	repo = consumertracing.NewAsync(tracer, repo, httpServerConfig)
 
@@ -148,6 +180,10 @@ func main() {
 		return hs.Start()
 	})
 
+	g.Go(func() error {
+		return retention.Run(ctx, newPurger(client, repocfg, logger), policies, purgeInterval)
+	})
+
 	g.Go(func() error {
 		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
 	})
@@ -156,3 +192,23 @@ func main() {
 		logger.Error(fmt.Sprintf("InfluxDB reader service terminated: %s", err))
 	}
 }
+
+func newPurger(client influxdb2.Client, repocfg influxdb.RepoConfig, logger mflog.Logger) retention.Purger {
+	purger := influxdb.NewPurger(client, repocfg)
+	purger = retention.LoggingMiddleware(purger, logger)
+	counter, latency := internal.MakeMetrics("influxdb", "message_retention")
+	purged := retention.MakePurgedCounter("influxdb", "message_retention")
+	purger = retention.MetricsMiddleware(purger, counter, latency, purged)
+	return purger
+}
+
+func subscribeToThingsES(ctx context.Context, policies retention.Policies, cfg config, logger mflog.Logger) error {
+	subscriber, err := mfredis.NewSubscriber(cfg.ESURL, thingsStream, cfg.ESConsumer, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Subscribed to Redis Event Store")
+
+	return subscriber.Subscribe(ctx, retention.NewEventHandler(policies))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/mainflux/mainflux/internal/server"
 	httpserver "github.com/mainflux/mainflux/internal/server/http"
 	mflog "github.com/mainflux/mainflux/logger"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/readers"
 	"github.com/mainflux/mainflux/readers/api"
@@ -39,6 +40,7 @@ type config struct {
 	LogLevel      string `env:"MF_MONGO_READER_LOG_LEVEL"     envDefault:"info"`
 	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"             envDefault:"true"`
 	InstanceID    string `env:"MF_MONGO_READER_INSTANCE_ID"   envDefault:""`
+	ThingsURL     string `env:"MF_THINGS_URL"                 envDefault:"http://localhost:9000"`
 }
 
 func main() {
@@ -95,13 +97,16 @@ func main() {
 
 	logger.Info("Successfully connected to auth grpc server " + authHandler.Secure())
 
+	sdk := mfsdk.NewSDK(mfsdk.Config{ThingsURL: cfg.ThingsURL})
+	groups := readers.NewChannelGroups(sdk)
+
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.Parse(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
 		exitCode = 1
 		return
 	}
-	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(repo, tc, auth, svcName, cfg.InstanceID), logger)
+	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(repo, groups, tc, auth, svcName, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, mainflux.Version, logger, cancel)
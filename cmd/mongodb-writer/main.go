@@ -16,6 +16,7 @@ import (
 	consumertracing "github.com/mainflux/mainflux/consumers/tracing"
 	"github.com/mainflux/mainflux/consumers/writers/api"
 	"github.com/mainflux/mainflux/consumers/writers/mongodb"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
 	"github.com/mainflux/mainflux/internal"
 	jaegerclient "github.com/mainflux/mainflux/internal/clients/jaeger"
 	mongoclient "github.com/mainflux/mainflux/internal/clients/mongo"
@@ -23,6 +24,7 @@ import (
 	"github.com/mainflux/mainflux/internal/server"
 	httpserver "github.com/mainflux/mainflux/internal/server/http"
 	mflog "github.com/mainflux/mainflux/logger"
+	mfredis "github.com/mainflux/mainflux/pkg/events/redis"
 	"github.com/mainflux/mainflux/pkg/messaging/brokers"
 	brokerstracing "github.com/mainflux/mainflux/pkg/messaging/brokers/tracing"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -35,15 +37,18 @@ const (
 	envPrefixDB    = "MF_MONGO_"
 	envPrefixHTTP  = "MF_MONGO_WRITER_HTTP_"
 	defSvcHTTPPort = "9008"
+	thingsStream   = "mainflux.things"
 )
 
 type config struct {
-	LogLevel      string `env:"MF_MONGO_WRITER_LOG_LEVEL"     envDefault:"info"`
-	ConfigPath    string `env:"MF_MONGO_WRITER_CONFIG_PATH"   envDefault:"/config.toml"`
-	BrokerURL     string `env:"MF_BROKER_URL"                 envDefault:"nats://localhost:4222"`
-	JaegerURL     string `env:"MF_JAEGER_URL"                 envDefault:"http://jaeger:14268/api/traces"`
-	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"             envDefault:"true"`
-	InstanceID    string `env:"MF_MONGO_WRITER_INSTANCE_ID"   envDefault:""`
+	LogLevel      string `env:"MF_MONGO_WRITER_LOG_LEVEL"        envDefault:"info"`
+	ConfigPath    string `env:"MF_MONGO_WRITER_CONFIG_PATH"      envDefault:"/config.toml"`
+	BrokerURL     string `env:"MF_BROKER_URL"                    envDefault:"nats://localhost:4222"`
+	JaegerURL     string `env:"MF_JAEGER_URL"                    envDefault:"http://jaeger:14268/api/traces"`
+	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"                envDefault:"true"`
+	InstanceID    string `env:"MF_MONGO_WRITER_INSTANCE_ID"      envDefault:""`
+	ESURL         string `env:"MF_MONGO_WRITER_ES_URL"           envDefault:""`
+	ESConsumer    string `env:"MF_MONGO_WRITER_EVENT_CONSUMER"   envDefault:"mongodb-writer"`
 }
 
 func main() {
@@ -107,7 +112,26 @@ func main() {
 		return
 	}
 
-	repo := newService(db, logger)
+	retentionCfg, err := retention.LoadConfig(cfg.ConfigPath)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to load retention config: %s", err))
+	}
+	policy, err := retentionCfg.Policy()
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load retention policy: %s", err))
+		exitCode = 1
+		return
+	}
+	policies := retention.NewPolicies(policy)
+	if cfg.ESURL != "" {
+		if err := subscribeToThingsES(ctx, policies, cfg, logger); err != nil {
+			logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	repo := newService(db, policies, logger)
 	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
 
 	if err := consumers.Start(ctx, svcName, pubSub, repo, cfg.ConfigPath, logger); err != nil {
@@ -136,10 +160,21 @@ func main() {
 	}
 }
 
-func newService(db *mongo.Database, logger mflog.Logger) consumers.BlockingConsumer {
-	repo := mongodb.New(db)
+func newService(db *mongo.Database, policies retention.Policies, logger mflog.Logger) consumers.BlockingConsumer {
+	repo := mongodb.New(db, policies)
 	repo = api.LoggingMiddleware(repo, logger)
 	counter, latency := internal.MakeMetrics("mongodb", "message_writer")
 	repo = api.MetricsMiddleware(repo, counter, latency)
 	return repo
 }
+
+func subscribeToThingsES(ctx context.Context, policies retention.Policies, cfg config, logger mflog.Logger) error {
+	subscriber, err := mfredis.NewSubscriber(cfg.ESURL, thingsStream, cfg.ESConsumer, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Subscribed to Redis Event Store")
+
+	return subscriber.Subscribe(ctx, retention.NewEventHandler(policies))
+}
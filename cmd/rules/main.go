@@ -0,0 +1,197 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains rules main function to start the rules engine service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	chclient "github.com/mainflux/callhome/pkg/client"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/consumers/rules/api"
+	rulespg "github.com/mainflux/mainflux/consumers/rules/postgres"
+	"github.com/mainflux/mainflux/consumers/rules/tracing"
+	"github.com/mainflux/mainflux/internal"
+	authclient "github.com/mainflux/mainflux/internal/clients/grpc/auth"
+	thingsclient "github.com/mainflux/mainflux/internal/clients/grpc/things"
+	jaegerclient "github.com/mainflux/mainflux/internal/clients/jaeger"
+	pgclient "github.com/mainflux/mainflux/internal/clients/postgres"
+	"github.com/mainflux/mainflux/internal/env"
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/internal/server"
+	httpserver "github.com/mainflux/mainflux/internal/server/http"
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/events"
+	"github.com/mainflux/mainflux/pkg/events/redis"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/brokers"
+	brokerstracing "github.com/mainflux/mainflux/pkg/messaging/brokers/tracing"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	tpolicies "github.com/mainflux/mainflux/things/policies"
+	"github.com/mainflux/mainflux/users/policies"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName        = "rules"
+	envPrefixDB    = "MF_RULES_DB_"
+	envPrefixHTTP  = "MF_RULES_HTTP_"
+	defDB          = "rules"
+	defSvcHTTPPort = "9021"
+	streamID       = "mainflux.rules"
+)
+
+type config struct {
+	LogLevel      string `env:"MF_RULES_LOG_LEVEL"    envDefault:"info"`
+	ConfigPath    string `env:"MF_RULES_CONFIG_PATH"  envDefault:"/config.toml"`
+	ESURL         string `env:"MF_RULES_ES_URL"       envDefault:"redis://localhost:6379/0"`
+	BrokerURL     string `env:"MF_BROKER_URL"         envDefault:"nats://localhost:4222"`
+	JaegerURL     string `env:"MF_JAEGER_URL"         envDefault:"http://jaeger:14268/api/traces"`
+	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"     envDefault:"true"`
+	InstanceID    string `env:"MF_RULES_INSTANCE_ID"  envDefault:""`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mflog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err)
+	}
+
+	var exitCode int
+	defer mflog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := dbConfig.LoadEnv(envPrefixDB); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.SetupWithConfig(envPrefixDB, *rulespg.Migration(), dbConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.Parse(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	tp, err := jaegerclient.NewProvider(svcName, cfg.JaegerURL, cfg.InstanceID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Error shutting down tracer provider: %v", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pubSub, err := brokers.NewPubSub(cfg.BrokerURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	auth, authHandler, err := authclient.Setup(svcName)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authHandler.Close()
+
+	logger.Info("Successfully connected to auth grpc server " + authHandler.Secure())
+
+	tc, tcHandler, err := thingsclient.Setup()
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer tcHandler.Close()
+
+	logger.Info("Successfully connected to things grpc server " + tcHandler.Secure())
+
+	es, err := redis.NewPublisher(ctx, cfg.ESURL, streamID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to event store: %s", err))
+		exitCode = 1
+		return
+	}
+	defer es.Close()
+
+	svc := newService(db, dbConfig, tracer, auth, tc, pubSub, es, logger)
+
+	if err = consumers.Start(ctx, svcName, pubSub, svc, cfg.ConfigPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create Rules consumer: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, logger, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, mainflux.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Rules service terminated: %s", err))
+	}
+}
+
+func newService(db *sqlx.DB, dbConfig pgclient.Config, tracer trace.Tracer, auth policies.AuthServiceClient, tc tpolicies.AuthServiceClient, pub messaging.Publisher, es events.Publisher, logger mflog.Logger) rules.Service {
+	database := postgres.NewDatabase(db, dbConfig, tracer)
+	repo := tracing.New(tracer, rulespg.New(database))
+	idp := uuid.New()
+
+	svc := rules.New(auth, tc, repo, idp, pub, es)
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := internal.MakeMetrics("rules", "api")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+
+	return svc
+}
@@ -10,6 +10,7 @@ import (
 	"log"
 	"os"
 
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/jmoiron/sqlx"
 	chclient "github.com/mainflux/callhome/pkg/client"
 	"github.com/mainflux/mainflux"
@@ -32,6 +33,7 @@ import (
 	"github.com/mainflux/mainflux/pkg/ulid"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/users/policies"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
@@ -163,7 +165,14 @@ func newService(db *sqlx.DB, tracer trace.Tracer, auth policies.AuthServiceClien
 	repo := tracing.New(tracer, notifierpg.New(database))
 	idp := ulid.New()
 	notifier := mfsmpp.New(sc)
-	svc := notifiers.New(auth, repo, idp, notifier, c.From)
+	suppressed := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "notifier",
+		Subsystem: "smpp",
+		Name:      "suppressed_notifications",
+		Help:      "Number of notifications suppressed by the subscription limits.",
+	}, []string{"reason"})
+	limiter := api.LimiterMetricsMiddleware(notifiers.NewLimiter(), suppressed)
+	svc := notifiers.New(auth, repo, idp, notifier, limiter, c.From)
 	svc = api.LoggingMiddleware(svc, logger)
 	counter, latency := internal.MakeMetrics("notifier", "smpp")
 	svc = api.MetricsMiddleware(svc, counter, latency)
@@ -10,6 +10,7 @@ import (
 	"log"
 	"os"
 
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/jmoiron/sqlx"
 	chclient "github.com/mainflux/callhome/pkg/client"
 	"github.com/mainflux/mainflux"
@@ -33,6 +34,7 @@ import (
 	"github.com/mainflux/mainflux/pkg/ulid"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/users/policies"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
@@ -179,7 +181,14 @@ func newService(db *sqlx.DB, tracer trace.Tracer, auth policies.AuthServiceClien
 	}
 
 	notifier := smtp.New(agent)
-	svc := notifiers.New(auth, repo, idp, notifier, c.From)
+	suppressed := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "notifier",
+		Subsystem: "smtp",
+		Name:      "suppressed_notifications",
+		Help:      "Number of notifications suppressed by the subscription limits.",
+	}, []string{"reason"})
+	limiter := api.LimiterMetricsMiddleware(notifiers.NewLimiter(), suppressed)
+	svc := notifiers.New(auth, repo, idp, notifier, limiter, c.From)
 	svc = api.LoggingMiddleware(svc, logger)
 	counter, latency := internal.MakeMetrics("notifier", "smtp")
 	svc = api.MetricsMiddleware(svc, counter, latency)
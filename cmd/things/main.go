@@ -27,6 +27,7 @@ import (
 	grpcserver "github.com/mainflux/mainflux/internal/server/grpc"
 	httpserver "github.com/mainflux/mainflux/internal/server/http"
 	mflog "github.com/mainflux/mainflux/logger"
+	mfredis "github.com/mainflux/mainflux/pkg/events/redis"
 	gpostgres "github.com/mainflux/mainflux/pkg/groups/postgres"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things/clients"
@@ -49,6 +50,12 @@ import (
 	ppostgres "github.com/mainflux/mainflux/things/policies/postgres"
 	ppracing "github.com/mainflux/mainflux/things/policies/tracing"
 	thingspg "github.com/mainflux/mainflux/things/postgres"
+	"github.com/mainflux/mainflux/things/presence"
+	prapi "github.com/mainflux/mainflux/things/presence/api"
+	prcache "github.com/mainflux/mainflux/things/presence/cache"
+	prevents "github.com/mainflux/mainflux/things/presence/events"
+	prpostgres "github.com/mainflux/mainflux/things/presence/postgres"
+	prtracing "github.com/mainflux/mainflux/things/presence/tracing"
 	upolicies "github.com/mainflux/mainflux/users/policies"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
@@ -76,6 +83,9 @@ type config struct {
 	SendTelemetry    bool   `env:"MF_SEND_TELEMETRY"             envDefault:"true"`
 	InstanceID       string `env:"MF_THINGS_INSTANCE_ID"         envDefault:""`
 	ESURL            string `env:"MF_THINGS_ES_URL"              envDefault:"redis://localhost:6379/0"`
+	ESConsumerName   string `env:"MF_THINGS_EVENT_CONSUMER"      envDefault:"things"`
+	PresenceSync     string `env:"MF_THINGS_PRESENCE_SYNC"       envDefault:"1m"`
+	PresenceTimeout  string `env:"MF_THINGS_PRESENCE_TIMEOUT"    envDefault:"5m"`
 }
 
 func main() {
@@ -156,13 +166,39 @@ func main() {
 		logger.Info("Successfully connected to auth grpc server " + authHandler.Secure())
 	}
 
-	csvc, gsvc, psvc, err := newService(ctx, db, dbConfig, auth, cacheclient, cfg, tracer, logger)
+	presenceSync, err := time.ParseDuration(cfg.PresenceSync)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse presence sync interval: %s", err))
+		exitCode = 1
+		return
+	}
+	presenceTimeout, err := time.ParseDuration(cfg.PresenceTimeout)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse presence timeout: %s", err))
+		exitCode = 1
+		return
+	}
+	// The last seen time is persisted once per sync interval, so a shorter
+	// timeout would expire the things which are still online.
+	if presenceTimeout <= presenceSync {
+		logger.Error("presence timeout must be longer than the presence sync interval")
+		exitCode = 1
+		return
+	}
+
+	csvc, gsvc, psvc, prsvc, err := newService(ctx, db, dbConfig, auth, cacheclient, cfg, presenceSync, tracer, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create %s service: %s", svcName, err))
 		exitCode = 1
 		return
 	}
 
+	if err := subscribeToMQTTES(ctx, prsvc, cfg, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to MQTT event store: %s", err))
+		exitCode = 1
+		return
+	}
+
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.Parse(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
@@ -173,6 +209,7 @@ func main() {
 	hsp := httpserver.New(ctx, cancel, "things-policies", httpServerConfig, httpapi.MakeHandler(csvc, psvc, mux, logger), logger)
 	hsc := httpserver.New(ctx, cancel, "things-clients", httpServerConfig, capi.MakeHandler(csvc, mux, logger, cfg.InstanceID), logger)
 	hsg := httpserver.New(ctx, cancel, "things-groups", httpServerConfig, gapi.MakeHandler(gsvc, mux, logger), logger)
+	hspr := httpserver.New(ctx, cancel, "things-presence", httpServerConfig, prapi.MakeHandler(prsvc, mux, logger), logger)
 
 	grpcServerConfig := server.Config{Port: defSvcAuthGRPCPort}
 	if err := env.Parse(&grpcServerConfig, env.Options{Prefix: envPrefixGRPC}); err != nil {
@@ -180,9 +217,10 @@ func main() {
 		exitCode = 1
 		return
 	}
+	rec := presence.NewRecorder(ctx, prsvc)
 	registerThingsServiceServer := func(srv *grpc.Server) {
 		reflection.Register(srv)
-		tpolicies.RegisterAuthServiceServer(srv, grpcapi.NewServer(csvc, psvc))
+		tpolicies.RegisterAuthServiceServer(srv, grpcapi.NewServer(csvc, psvc, rec))
 	}
 	gs := grpcserver.New(ctx, cancel, svcName, grpcServerConfig, registerThingsServiceServer, logger)
 
@@ -201,7 +239,11 @@ func main() {
 	})
 
 	g.Go(func() error {
-		return server.StopSignalHandler(ctx, cancel, logger, svcName, hsc, hsg, hsp, gs)
+		return expirePresence(ctx, prsvc, presenceSync, presenceTimeout, logger)
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hsc, hsg, hspr, hsp, gs)
 	})
 
 	if err := g.Wait(); err != nil {
@@ -209,11 +251,12 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth upolicies.AuthServiceClient, cacheClient *redis.Client, cfg config, tracer trace.Tracer, logger mflog.Logger) (clients.Service, groups.Service, tpolicies.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth upolicies.AuthServiceClient, cacheClient *redis.Client, cfg config, presenceSync time.Duration, tracer trace.Tracer, logger mflog.Logger) (clients.Service, groups.Service, tpolicies.Service, presence.Service, error) {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
 	cRepo := cpostgres.NewRepository(database)
 	gRepo := gpostgres.New(database)
 	pRepo := ppostgres.NewRepository(database)
+	prRepo := prpostgres.NewRepository(database)
 
 	idp := uuid.New()
 
@@ -224,24 +267,27 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 
 	policyCache := pcache.NewCache(cacheClient, kDuration)
 	thingCache := thcache.NewCache(cacheClient, kDuration)
+	// The cached presence must outlive the sync interval, which it spares
+	// from the database writes.
+	presenceCache := prcache.NewCache(cacheClient, 2*presenceSync)
 
 	psvc := tpolicies.NewService(auth, pRepo, policyCache, idp)
-	csvc := clients.NewService(auth, psvc, cRepo, gRepo, thingCache, idp)
-	gsvc := groups.NewService(auth, psvc, gRepo, idp)
+	csvc := clients.NewService(auth, psvc, cRepo, gRepo, thingCache, policyCache, idp)
+	gsvc := groups.NewService(auth, psvc, gRepo, policyCache, idp)
 
 	csvc, err = thevents.NewEventStoreMiddleware(ctx, csvc, cfg.ESURL)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	gsvc, err = chevents.NewEventStoreMiddleware(ctx, gsvc, cfg.ESURL)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	psvc, err = pevents.NewEventStoreMiddleware(ctx, psvc, cfg.ESURL)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	csvc = ctracing.New(csvc, tracer)
@@ -258,5 +304,46 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 	counter, latency = internal.MakeMetrics(fmt.Sprintf("%s_policies", svcName), "api")
 	psvc = papi.MetricsMiddleware(psvc, counter, latency)
 
-	return csvc, gsvc, psvc, nil
+	// The presence service views things through the decorated things
+	// service, so it's created after the things service decorators.
+	prsvc := presence.NewService(csvc, prRepo, presenceCache, presenceSync)
+	prsvc, err = prevents.NewEventStoreMiddleware(ctx, prsvc, cfg.ESURL)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	prsvc = prtracing.New(prsvc, tracer)
+	prsvc = prapi.LoggingMiddleware(prsvc, logger)
+	counter, latency = internal.MakeMetrics(fmt.Sprintf("%s_presence", svcName), "api")
+	prsvc = prapi.MetricsMiddleware(prsvc, counter, latency)
+
+	return csvc, gsvc, psvc, prsvc, nil
+}
+
+func subscribeToMQTTES(ctx context.Context, svc presence.Service, cfg config, logger mflog.Logger) error {
+	subscriber, err := mfredis.NewSubscriber(cfg.ESURL, prevents.MQTTStream, cfg.ESConsumerName, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Subscribed to MQTT Redis Event Store")
+
+	return subscriber.Subscribe(ctx, prevents.NewEventHandler(svc))
+}
+
+// expirePresence marks offline the things which haven't been seen for
+// longer than the timeout.
+func expirePresence(ctx context.Context, svc presence.Service, interval, timeout time.Duration, logger mflog.Logger) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := svc.Expire(ctx, time.Now().Add(-timeout)); err != nil {
+				logger.Warn(fmt.Sprintf("failed to expire things presence: %s", err))
+			}
+		}
+	}
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/mainflux/mainflux/internal/server"
 	httpserver "github.com/mainflux/mainflux/internal/server/http"
 	mflog "github.com/mainflux/mainflux/logger"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/readers"
 	"github.com/mainflux/mainflux/readers/api"
@@ -40,6 +41,7 @@ type config struct {
 	LogLevel      string `env:"MF_TIMESCALE_READER_LOG_LEVEL"    envDefault:"info"`
 	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"                envDefault:"true"`
 	InstanceID    string `env:"MF_TIMESCALE_READER_INSTANCE_ID"  envDefault:""`
+	ThingsURL     string `env:"MF_THINGS_URL"                    envDefault:"http://localhost:9000"`
 }
 
 func main() {
@@ -91,6 +93,9 @@ func main() {
 
 	logger.Info("Successfully connected to auth grpc server " + authHandler.Secure())
 
+	sdk := mfsdk.NewSDK(mfsdk.Config{ThingsURL: cfg.ThingsURL})
+	groups := readers.NewChannelGroups(sdk)
+
 	tc, tcHandler, err := thingsclient.Setup()
 	if err != nil {
 		logger.Error(err.Error())
@@ -107,7 +112,7 @@ func main() {
 		exitCode = 1
 		return
 	}
-	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(repo, tc, auth, svcName, cfg.InstanceID), logger)
+	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(repo, groups, tc, auth, svcName, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, mainflux.Version, logger, cancel)
@@ -16,6 +16,7 @@ import (
 	"github.com/mainflux/mainflux/consumers"
 	consumertracing "github.com/mainflux/mainflux/consumers/tracing"
 	"github.com/mainflux/mainflux/consumers/writers/api"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
 	"github.com/mainflux/mainflux/consumers/writers/timescale"
 	"github.com/mainflux/mainflux/internal"
 	jaegerclient "github.com/mainflux/mainflux/internal/clients/jaeger"
@@ -24,6 +25,7 @@ import (
 	"github.com/mainflux/mainflux/internal/server"
 	httpserver "github.com/mainflux/mainflux/internal/server/http"
 	mflog "github.com/mainflux/mainflux/logger"
+	mfredis "github.com/mainflux/mainflux/pkg/events/redis"
 	"github.com/mainflux/mainflux/pkg/messaging/brokers"
 	brokerstracing "github.com/mainflux/mainflux/pkg/messaging/brokers/tracing"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -36,15 +38,18 @@ const (
 	envPrefixHTTP  = "MF_TIMESCALE_WRITER_HTTP_"
 	defDB          = "messages"
 	defSvcHTTPPort = "9012"
+	thingsStream   = "mainflux.things"
 )
 
 type config struct {
-	LogLevel      string `env:"MF_TIMESCALE_WRITER_LOG_LEVEL"    envDefault:"info"`
-	ConfigPath    string `env:"MF_TIMESCALE_WRITER_CONFIG_PATH"  envDefault:"/config.toml"`
-	BrokerURL     string `env:"MF_BROKER_URL"                    envDefault:"nats://localhost:4222"`
-	JaegerURL     string `env:"MF_JAEGER_URL"                    envDefault:"http://jaeger:14268/api/traces"`
-	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"                envDefault:"true"`
-	InstanceID    string `env:"MF_TIMESCALE_WRITER_INSTANCE_ID"  envDefault:""`
+	LogLevel      string `env:"MF_TIMESCALE_WRITER_LOG_LEVEL"        envDefault:"info"`
+	ConfigPath    string `env:"MF_TIMESCALE_WRITER_CONFIG_PATH"      envDefault:"/config.toml"`
+	BrokerURL     string `env:"MF_BROKER_URL"                        envDefault:"nats://localhost:4222"`
+	JaegerURL     string `env:"MF_JAEGER_URL"                        envDefault:"http://jaeger:14268/api/traces"`
+	SendTelemetry bool   `env:"MF_SEND_TELEMETRY"                    envDefault:"true"`
+	InstanceID    string `env:"MF_TIMESCALE_WRITER_INSTANCE_ID"      envDefault:""`
+	ESURL         string `env:"MF_TIMESCALE_WRITER_ES_URL"           envDefault:""`
+	ESConsumer    string `env:"MF_TIMESCALE_WRITER_EVENT_CONSUMER"   envDefault:"timescale-writer"`
 }
 
 func main() {
@@ -101,6 +106,31 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
+	retentionCfg, err := retention.LoadConfig(cfg.ConfigPath)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to load retention config: %s", err))
+	}
+	policy, err := retentionCfg.Policy()
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load retention policy: %s", err))
+		exitCode = 1
+		return
+	}
+	policies := retention.NewPolicies(policy)
+	purgeInterval, err := retentionCfg.PurgeInterval()
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load retention purge interval: %s", err))
+		exitCode = 1
+		return
+	}
+	if cfg.ESURL != "" {
+		if err := subscribeToThingsES(ctx, policies, cfg, logger); err != nil {
+			logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
 	repo := newService(db, logger)
 	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
 
@@ -130,6 +160,10 @@ func main() {
 		return hs.Start()
 	})
 
+	g.Go(func() error {
+		return retention.Run(ctx, newPurger(db, retentionCfg.BatchSize, logger), policies, purgeInterval)
+	})
+
 	g.Go(func() error {
 		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
 	})
@@ -146,3 +180,23 @@ func newService(db *sqlx.DB, logger mflog.Logger) consumers.BlockingConsumer {
 	svc = api.MetricsMiddleware(svc, counter, latency)
 	return svc
 }
+
+func newPurger(db *sqlx.DB, batchSize uint64, logger mflog.Logger) retention.Purger {
+	purger := timescale.NewPurger(db, batchSize)
+	purger = retention.LoggingMiddleware(purger, logger)
+	counter, latency := internal.MakeMetrics("timescale", "message_retention")
+	purged := retention.MakePurgedCounter("timescale", "message_retention")
+	purger = retention.MetricsMiddleware(purger, counter, latency, purged)
+	return purger
+}
+
+func subscribeToThingsES(ctx context.Context, policies retention.Policies, cfg config, logger mflog.Logger) error {
+	subscriber, err := mfredis.NewSubscriber(cfg.ESURL, thingsStream, cfg.ESConsumer, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Subscribed to Redis Event Store")
+
+	return subscriber.Subscribe(ctx, retention.NewEventHandler(policies))
+}
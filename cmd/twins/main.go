@@ -22,6 +22,7 @@ import (
 	"github.com/mainflux/mainflux/internal/server"
 	httpserver "github.com/mainflux/mainflux/internal/server/http"
 	mflog "github.com/mainflux/mainflux/logger"
+	esredis "github.com/mainflux/mainflux/pkg/events/redis"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/messaging/brokers"
 	brokerstracing "github.com/mainflux/mainflux/pkg/messaging/brokers/tracing"
@@ -31,6 +32,7 @@ import (
 	"github.com/mainflux/mainflux/twins/api"
 	twapi "github.com/mainflux/mainflux/twins/api/http"
 	"github.com/mainflux/mainflux/twins/events"
+	"github.com/mainflux/mainflux/twins/events/consumer"
 	twmongodb "github.com/mainflux/mainflux/twins/mongodb"
 	"github.com/mainflux/mainflux/twins/tracing"
 	"github.com/mainflux/mainflux/users/policies"
@@ -46,6 +48,7 @@ const (
 	envPrefixHTTP  = "MF_TWINS_HTTP_"
 	envPrefixCache = "MF_TWINS_CACHE_"
 	defSvcHTTPPort = "9018"
+	thingsStream   = "mainflux.things"
 )
 
 type config struct {
@@ -58,6 +61,7 @@ type config struct {
 	SendTelemetry   bool   `env:"MF_SEND_TELEMETRY"           envDefault:"true"`
 	InstanceID      string `env:"MF_TWINS_INSTANCE_ID"        envDefault:""`
 	ESURL           string `env:"MF_TWINS_ES_URL"             envDefault:"redis://localhost:6379/0"`
+	ESConsumerName  string `env:"MF_TWINS_EVENT_CONSUMER"     envDefault:"twins"`
 }
 
 func main() {
@@ -152,6 +156,12 @@ func main() {
 		return
 	}
 
+	if err = subscribeToThingsES(ctx, svc, cfg, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
+		exitCode = 1
+		return
+	}
+
 	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, twapi.MakeHandler(svc, logger, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
@@ -202,6 +212,19 @@ func newService(ctx context.Context, id string, ps messaging.PubSub, cfg config,
 	return svc, nil
 }
 
+func subscribeToThingsES(ctx context.Context, svc twins.Service, cfg config, logger mflog.Logger) error {
+	subscriber, err := esredis.NewSubscriber(cfg.ESURL, thingsStream, cfg.ESConsumerName, logger)
+	if err != nil {
+		return err
+	}
+
+	handler := consumer.NewEventHandler(svc)
+
+	logger.Info("Subscribed to Redis Event Store")
+
+	return subscriber.Subscribe(ctx, handler)
+}
+
 func handle(ctx context.Context, logger mflog.Logger, chanID string, svc twins.Service) handlerFunc {
 	return func(msg *messaging.Message) error {
 		if msg.Channel == chanID {
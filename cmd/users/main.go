@@ -8,17 +8,21 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"regexp"
 	"time"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/go-zoo/bone"
 	"github.com/jmoiron/sqlx"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	chclient "github.com/mainflux/callhome/pkg/client"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/internal"
 	jaegerclient "github.com/mainflux/mainflux/internal/clients/jaeger"
 	pgclient "github.com/mainflux/mainflux/internal/clients/postgres"
+	redisclient "github.com/mainflux/mainflux/internal/clients/redis"
 	"github.com/mainflux/mainflux/internal/email"
 	"github.com/mainflux/mainflux/internal/env"
 	"github.com/mainflux/mainflux/internal/postgres"
@@ -41,6 +45,33 @@ import (
 	gtracing "github.com/mainflux/mainflux/users/groups/tracing"
 	"github.com/mainflux/mainflux/users/hasher"
 	"github.com/mainflux/mainflux/users/jwt"
+	jwtapi "github.com/mainflux/mainflux/users/jwt/api"
+	jcache "github.com/mainflux/mainflux/users/jwt/cache"
+	jpostgres "github.com/mainflux/mainflux/users/jwt/postgres"
+	"github.com/mainflux/mainflux/users/keys"
+	kapi "github.com/mainflux/mainflux/users/keys/api"
+	khttpapi "github.com/mainflux/mainflux/users/keys/api/http"
+	kpostgres "github.com/mainflux/mainflux/users/keys/postgres"
+	ktracing "github.com/mainflux/mainflux/users/keys/tracing"
+	"github.com/mainflux/mainflux/users/lockout"
+	lredis "github.com/mainflux/mainflux/users/lockout/redis"
+	"github.com/mainflux/mainflux/users/mfa"
+	mapi "github.com/mainflux/mainflux/users/mfa/api"
+	mhttpapi "github.com/mainflux/mainflux/users/mfa/api/http"
+	mpostgres "github.com/mainflux/mainflux/users/mfa/postgres"
+	mtracing "github.com/mainflux/mainflux/users/mfa/tracing"
+	"github.com/mainflux/mainflux/users/oidc"
+	oapi "github.com/mainflux/mainflux/users/oidc/api"
+	ohttpapi "github.com/mainflux/mainflux/users/oidc/api/http"
+	opostgres "github.com/mainflux/mainflux/users/oidc/postgres"
+	otracing "github.com/mainflux/mainflux/users/oidc/tracing"
+	"github.com/mainflux/mainflux/users/orgs"
+	orgapi "github.com/mainflux/mainflux/users/orgs/api"
+	orghttpapi "github.com/mainflux/mainflux/users/orgs/api/http"
+	orgpostgres "github.com/mainflux/mainflux/users/orgs/postgres"
+	orgtracing "github.com/mainflux/mainflux/users/orgs/tracing"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwpostgres "github.com/mainflux/mainflux/users/passwords/postgres"
 	"github.com/mainflux/mainflux/users/policies"
 	papi "github.com/mainflux/mainflux/users/policies/api"
 	grpcapi "github.com/mainflux/mainflux/users/policies/api/grpc"
@@ -60,24 +91,36 @@ const (
 	envPrefixDB    = "MF_USERS_DB_"
 	envPrefixHTTP  = "MF_USERS_HTTP_"
 	envPrefixGrpc  = "MF_USERS_GRPC_"
+	envPrefixCache = "MF_USERS_CACHE_"
+	envPrefixOIDC  = "MF_USERS_OIDC_"
+	envPrefixMFA   = "MF_USERS_MFA_"
+	envPrefixLock  = "MF_USERS_LOCKOUT_"
+	envPrefixReg   = "MF_USERS_REGISTRATION_"
+	envPrefixPass  = "MF_USERS_PASSWORD_"
+	envPrefixHash  = "MF_USERS_HASHER_"
+	envPrefixOrgs  = "MF_USERS_ORGS_"
 	defDB          = "users"
 	defSvcHTTPPort = "9002"
 	defSvcGRPCPort = "9192"
 )
 
 type config struct {
-	LogLevel        string `env:"MF_USERS_LOG_LEVEL"              envDefault:"info"`
-	SecretKey       string `env:"MF_USERS_SECRET_KEY"             envDefault:"secret"`
-	AdminEmail      string `env:"MF_USERS_ADMIN_EMAIL"            envDefault:""`
-	AdminPassword   string `env:"MF_USERS_ADMIN_PASSWORD"         envDefault:""`
-	PassRegexText   string `env:"MF_USERS_PASS_REGEX"             envDefault:"^.{8,}$"`
-	AccessDuration  string `env:"MF_USERS_ACCESS_TOKEN_DURATION"  envDefault:"15m"`
-	RefreshDuration string `env:"MF_USERS_REFRESH_TOKEN_DURATION" envDefault:"24h"`
-	ResetURL        string `env:"MF_TOKEN_RESET_ENDPOINT"         envDefault:"/reset-request"`
-	JaegerURL       string `env:"MF_JAEGER_URL"                   envDefault:"http://jaeger:14268/api/traces"`
-	SendTelemetry   bool   `env:"MF_SEND_TELEMETRY"               envDefault:"true"`
-	InstanceID      string `env:"MF_USERS_INSTANCE_ID"            envDefault:""`
-	ESURL           string `env:"MF_USERS_ES_URL"                 envDefault:"redis://localhost:6379/0"`
+	LogLevel        string   `env:"MF_USERS_LOG_LEVEL"              envDefault:"info"`
+	SecretKey       string   `env:"MF_USERS_SECRET_KEY"             envDefault:"secret"`
+	AdminEmail      string   `env:"MF_USERS_ADMIN_EMAIL"            envDefault:""`
+	AdminPassword   string   `env:"MF_USERS_ADMIN_PASSWORD"         envDefault:""`
+	PassRegexText   string   `env:"MF_USERS_PASS_REGEX"             envDefault:"^.{8,}$"`
+	AccessDuration  string   `env:"MF_USERS_ACCESS_TOKEN_DURATION"  envDefault:"15m"`
+	RefreshDuration string   `env:"MF_USERS_REFRESH_TOKEN_DURATION" envDefault:"24h"`
+	CacheDuration   string   `env:"MF_USERS_CACHE_KEY_DURATION"     envDefault:"10m"`
+	KeyFile         string   `env:"MF_USERS_JWT_KEY_FILE"           envDefault:""`
+	VerifyKeyFiles  []string `env:"MF_USERS_JWT_VERIFY_KEY_FILES"   envDefault:""`
+	ResetURL        string   `env:"MF_TOKEN_RESET_ENDPOINT"         envDefault:"/reset-request"`
+	JaegerURL       string   `env:"MF_JAEGER_URL"                   envDefault:"http://jaeger:14268/api/traces"`
+	SendTelemetry   bool     `env:"MF_SEND_TELEMETRY"               envDefault:"true"`
+	InstanceID      string   `env:"MF_USERS_INSTANCE_ID"            envDefault:""`
+	ESURL           string   `env:"MF_USERS_ES_URL"                 envDefault:"redis://localhost:6379/0"`
+	TrustedProxies  []string `env:"MF_USERS_TRUSTED_PROXIES"        envDefault:""`
 	PassRegex       *regexp.Regexp
 }
 
@@ -118,6 +161,62 @@ func main() {
 		return
 	}
 
+	oc := oidc.Config{}
+	if err := env.Parse(&oc, env.Options{Prefix: envPrefixOIDC}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load OpenID Connect configuration : %s", err.Error()))
+		exitCode = 1
+		return
+	}
+
+	mc := mfa.Config{}
+	if err := env.Parse(&mc, env.Options{Prefix: envPrefixMFA}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load MFA configuration : %s", err.Error()))
+		exitCode = 1
+		return
+	}
+
+	lc := lockout.Config{}
+	if err := env.Parse(&lc, env.Options{Prefix: envPrefixLock}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load lockout configuration : %s", err.Error()))
+		exitCode = 1
+		return
+	}
+
+	proxies, err := capi.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		logger.Error(fmt.Sprintf("invalid trusted proxies %s : %s", cfg.TrustedProxies, err.Error()))
+		exitCode = 1
+		return
+	}
+
+	rc := clients.RegistrationConfig{}
+	if err := env.Parse(&rc, env.Options{Prefix: envPrefixReg}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load registration configuration : %s", err.Error()))
+		exitCode = 1
+		return
+	}
+
+	pc := passwords.Config{}
+	if err := env.Parse(&pc, env.Options{Prefix: envPrefixPass}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load password policy configuration : %s", err.Error()))
+		exitCode = 1
+		return
+	}
+
+	hc := hasher.Config{}
+	if err := env.Parse(&hc, env.Options{Prefix: envPrefixHash}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load hasher configuration : %s", err.Error()))
+		exitCode = 1
+		return
+	}
+
+	orc := orgs.Config{}
+	if err := env.Parse(&orc, env.Options{Prefix: envPrefixOrgs}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load organizations configuration : %s", err.Error()))
+		exitCode = 1
+		return
+	}
+
 	dbConfig := pgclient.Config{Name: defDB}
 	if err := dbConfig.LoadEnv(envPrefixDB); err != nil {
 		logger.Fatal(err.Error())
@@ -143,7 +242,29 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
-	csvc, gsvc, psvc, err := newService(ctx, db, dbConfig, tracer, cfg, ec, logger)
+	// Setup new redis cache client
+	cacheClient, err := redisclient.Setup(envPrefixCache)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer cacheClient.Close()
+
+	// Tokens are signed with the shared secret unless the signing key is set.
+	var keySet *jwt.KeySet
+	jwks := jwk.NewSet()
+	if cfg.KeyFile != "" {
+		ks, err := loadKeySet(cfg.KeyFile, cfg.VerifyKeyFiles)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to load token signing keys: %s", err))
+			exitCode = 1
+			return
+		}
+		keySet, jwks = &ks, ks.Public()
+	}
+
+	csvc, gsvc, psvc, ksvc, osvc, msvc, orgsvc, err := newService(ctx, db, dbConfig, cacheClient, tracer, cfg, keySet, ec, oc, mc, lc, rc, pc, hc, orc, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create %s service: %s", svcName, err.Error()))
 		exitCode = 1
@@ -157,9 +278,17 @@ func main() {
 		return
 	}
 	mux := bone.New()
-	hsc := httpserver.New(ctx, cancel, svcName, httpServerConfig, capi.MakeHandler(csvc, mux, logger, cfg.InstanceID), logger)
+	hsc := httpserver.New(ctx, cancel, svcName, httpServerConfig, capi.MakeHandler(csvc, mux, logger, cfg.InstanceID, proxies), logger)
 	hsg := httpserver.New(ctx, cancel, svcName, httpServerConfig, gapi.MakeHandler(gsvc, mux, logger), logger)
 	hsp := httpserver.New(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(psvc, mux, logger), logger)
+	hsk := httpserver.New(ctx, cancel, svcName, httpServerConfig, khttpapi.MakeHandler(ksvc, mux, logger), logger)
+	hsm := httpserver.New(ctx, cancel, svcName, httpServerConfig, mhttpapi.MakeHandler(msvc, mux, logger), logger)
+	hso := httpserver.New(ctx, cancel, svcName, httpServerConfig, orghttpapi.MakeHandler(orgsvc, mux, logger), logger)
+	jwtapi.MakeHandler(jwks, mux)
+	// OpenID Connect login is available only when the IdP is configured.
+	if osvc != nil {
+		ohttpapi.MakeHandler(osvc, mux, logger)
+	}
 
 	grpcServerConfig := server.Config{Port: defSvcGRPCPort}
 	if err := env.Parse(&grpcServerConfig, env.Options{Prefix: envPrefixGrpc}); err != nil {
@@ -169,10 +298,16 @@ func main() {
 	}
 	registerAuthServiceServer := func(srv *grpc.Server) {
 		reflection.Register(srv)
-		policies.RegisterAuthServiceServer(srv, grpcapi.NewServer(csvc, psvc))
+		policies.RegisterAuthServiceServer(srv, grpcapi.NewServer(csvc, psvc, ksvc, orgsvc))
 	}
 	gs := grpcserver.New(ctx, cancel, svcName, grpcServerConfig, registerAuthServiceServer, logger)
 
+	if rc.Open {
+		g.Go(func() error {
+			return clients.RunCleanup(ctx, csvc, rc.CleanupInterval)
+		})
+	}
+
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, mainflux.Version, logger, cancel)
 		go chc.CallHome(ctx)
@@ -186,7 +321,7 @@ func main() {
 	})
 
 	g.Go(func() error {
-		return server.StopSignalHandler(ctx, cancel, logger, svcName, hsc, hsg, hsp, gs)
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hsc, hsg, hsp, hsk, hsm, hso, gs)
 	})
 
 	if err := g.Wait(); err != nil {
@@ -194,14 +329,22 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, tracer trace.Tracer, c config, ec email.Config, logger mflog.Logger) (clients.Service, groups.Service, policies.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, cacheClient *redis.Client, tracer trace.Tracer, c config, keySet *jwt.KeySet, ec email.Config, oc oidc.Config, mc mfa.Config, lc lockout.Config, rc clients.RegistrationConfig, pc passwords.Config, hc hasher.Config, orc orgs.Config, logger mflog.Logger) (clients.Service, groups.Service, policies.Service, keys.Service, oidc.Service, mfa.Service, orgs.Service, error) {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
 	cRepo := uclients.NewRepository(database)
 	gRepo := gpostgres.New(database)
 	pRepo := ppostgres.NewRepository(database)
+	rRepo := ppostgres.NewRoleRepository(database)
+	kRepo := kpostgres.NewRepository(database)
+	mRepo := mpostgres.NewRepository(database)
+	pwRepo := pwpostgres.NewRepository(database)
+	orgRepo := orgpostgres.NewRepository(database)
 
 	idp := uuid.New()
-	hsr := hasher.New()
+	hsr, err := hasher.NewFromConfig(hc)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
 
 	aDuration, err := time.ParseDuration(c.AccessDuration)
 	if err != nil {
@@ -211,27 +354,47 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, trac
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to parse refresh token duration: %s", err.Error()))
 	}
-	tokenizer := jwt.NewRepository([]byte(c.SecretKey), aDuration, rDuration)
+	cDuration, err := time.ParseDuration(c.CacheDuration)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse cache key duration: %s", err.Error()))
+	}
+	revocations := jcache.NewRevocations(cacheClient, jpostgres.NewRepository(database), cDuration)
+	tokenizer := jwt.NewRepository([]byte(c.SecretKey), aDuration, rDuration, revocations)
+	if keySet != nil {
+		tokenizer = jwt.NewKeySetRepository(*keySet, aDuration, rDuration, revocations)
+	}
 
-	emailer, err := emailer.New(c.ResetURL, &ec)
+	// Verification e-mails are sent only to the self-registered clients.
+	var verificationTemplate string
+	if rc.Open {
+		verificationTemplate = rc.EmailTemplate
+	}
+	emailer, err := emailer.New(c.ResetURL, rc.VerificationURL, mc.EnrollURL, verificationTemplate, &ec)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to configure e-mailing util: %s", err.Error()))
 	}
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, hsr, idp, c.PassRegex)
+	lRepo := lredis.NewRepository(cacheClient)
+	authenticator := mfa.NewAuthenticator(mRepo, mc.EnforceAdmins)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, hsr, idp, passwords.NewPolicy(pc, c.PassRegex, pwRepo, hsr), authenticator, lockout.NewLimiter(lRepo, lc), rc)
 	gsvc := groups.NewService(gRepo, pRepo, tokenizer, idp)
-	psvc := policies.NewService(pRepo, tokenizer, idp)
+	psvc := policies.NewService(pRepo, rRepo, tokenizer, idp)
+	ksvc := keys.NewService(kRepo, cRepo, tokenizer, idp)
+	// Failed MFA verifications are limited apart from the failed logins,
+	// since a successful login clears the failed login attempts.
+	msvc := mfa.NewService(mRepo, cRepo, pRepo, tokenizer, lockout.NewScopedLimiter(lRepo, lc, "mfa"), emailer, mc)
+	orgsvc := orgs.NewService(orgRepo, pRepo, tokenizer, idp, orc)
 
 	csvc, err = uevents.NewEventStoreMiddleware(ctx, csvc, c.ESURL)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, err
 	}
 	gsvc, err = gevents.NewEventStoreMiddleware(ctx, gsvc, c.ESURL)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, err
 	}
 	psvc, err = pevents.NewEventStoreMiddleware(ctx, psvc, c.ESURL)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	csvc = ctracing.New(csvc, tracer)
@@ -249,10 +412,34 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, trac
 	counter, latency = internal.MakeMetrics("policies", "api")
 	psvc = papi.MetricsMiddleware(psvc, counter, latency)
 
+	ksvc = ktracing.New(ksvc, tracer)
+	ksvc = kapi.LoggingMiddleware(ksvc, logger)
+	counter, latency = internal.MakeMetrics("keys", "api")
+	ksvc = kapi.MetricsMiddleware(ksvc, counter, latency)
+
+	msvc = mtracing.New(msvc, tracer)
+	msvc = mapi.LoggingMiddleware(msvc, logger)
+	counter, latency = internal.MakeMetrics("mfa", "api")
+	msvc = mapi.MetricsMiddleware(msvc, counter, latency)
+
+	orgsvc = orgtracing.New(orgsvc, tracer)
+	orgsvc = orgapi.LoggingMiddleware(orgsvc, logger)
+	counter, latency = internal.MakeMetrics("orgs", "api")
+	orgsvc = orgapi.MetricsMiddleware(orgsvc, counter, latency)
+
 	if err := createAdmin(ctx, c, cRepo, hsr, csvc); err != nil {
 		logger.Error(fmt.Sprintf("failed to create admin client: %s", err))
 	}
-	return csvc, gsvc, psvc, nil
+	var osvc oidc.Service
+	if oc.IssuerURL != "" {
+		osvc = oidc.NewService(oidc.NewProvider(oc, http.DefaultClient), cRepo, opostgres.NewRepository(database), pRepo, tokenizer, authenticator, lockout.NewLimiter(lRepo, lc), hsr, idp, oc)
+		osvc = otracing.New(osvc, tracer)
+		osvc = oapi.LoggingMiddleware(osvc, logger)
+		counter, latency = internal.MakeMetrics("oidc", "api")
+		osvc = oapi.MetricsMiddleware(osvc, counter, latency)
+	}
+
+	return csvc, gsvc, psvc, ksvc, osvc, msvc, orgsvc, nil
 }
 
 func createAdmin(ctx context.Context, c config, crepo uclients.Repository, hsr clients.Hasher, svc clients.Service) error {
@@ -295,3 +482,25 @@ func createAdmin(ctx context.Context, c config, crepo uclients.Repository, hsr c
 
 	return nil
 }
+
+// loadKeySet reads the PEM encoded token signing key and the keys previously
+// used for signing.
+func loadKeySet(keyFile string, verifyKeyFiles []string) (jwt.KeySet, error) {
+	signing, err := os.ReadFile(keyFile)
+	if err != nil {
+		return jwt.KeySet{}, err
+	}
+	var previous [][]byte
+	for _, f := range verifyKeyFiles {
+		if f == "" {
+			continue
+		}
+		key, err := os.ReadFile(f)
+		if err != nil {
+			return jwt.KeySet{}, err
+		}
+		previous = append(previous, key)
+	}
+
+	return jwt.ParseKeySet(signing, previous...)
+}
@@ -0,0 +1,201 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+var _ messaging.AckHandler = (*batcher)(nil)
+
+// consumeFunc consumes a batch of transformed messages.
+type consumeFunc func(ctx context.Context, messages interface{}) error
+
+// pending is a transformed message waiting to be flushed, together with
+// the function acknowledging the original broker message.
+type pending struct {
+	orig *messaging.Message
+	msg  interface{}
+	ack  func(err error)
+}
+
+// batcher collects transformed messages and passes them to the consumer in
+// batches. The batch is flushed once it reaches the maximum size or once
+// the linger time passes since the first message of the batch is received.
+// Messages are acknowledged only after the batch they belong to is consumed.
+// If the batch can't be consumed, its messages are consumed one by one, and
+// the ones which still fail are dead-lettered.
+// Batches are flushed one at a time, so a subscriber filling the batch while
+// the previous one is still being consumed is blocked until the flush ends.
+type batcher struct {
+	ctx         context.Context
+	transformer transformers.Transformer
+	consume     consumeFunc
+	dl          *deadLetters
+	size        int
+	linger      time.Duration
+	logger      logger.Logger
+
+	mu    sync.Mutex
+	batch []pending
+	gen   uint64
+	timer *time.Timer
+
+	// flushMu is always acquired while holding mu to preserve the order of batches.
+	flushMu sync.Mutex
+}
+
+func newBatcher(ctx context.Context, t transformers.Transformer, consume consumeFunc, dl *deadLetters, size int, linger time.Duration, logger logger.Logger) *batcher {
+	return &batcher{
+		ctx:         ctx,
+		transformer: t,
+		consume:     consume,
+		dl:          dl,
+		size:        size,
+		linger:      linger,
+		logger:      logger,
+	}
+}
+
+// Handle is used by the subscribers which don't support acknowledgement.
+// The message is added to the batch and the consuming errors are logged.
+func (b *batcher) Handle(msg *messaging.Message) error {
+	b.HandleAck(msg, func(err error) {
+		if err != nil {
+			b.logger.Warn(fmt.Sprintf("Failed to consume message batch: %s", err))
+		}
+	})
+
+	return nil
+}
+
+func (b *batcher) HandleAck(msg *messaging.Message, ack func(err error)) {
+	if msg.Protocol == DeadLetterProtocol {
+		ack(nil)
+		return
+	}
+	m := interface{}(msg)
+	if b.transformer != nil {
+		var err error
+		if m, err = b.transformer.Transform(msg); err != nil {
+			ack(b.dl.send(b.ctx, msg, 1, err))
+			return
+		}
+	}
+
+	b.mu.Lock()
+	b.batch = append(b.batch, pending{orig: msg, msg: m, ack: ack})
+	if len(b.batch) == 1 {
+		gen := b.gen
+		b.timer = time.AfterFunc(b.linger, func() {
+			b.mu.Lock()
+			if b.gen != gen {
+				b.mu.Unlock()
+				return
+			}
+			b.flushLocked()
+		})
+	}
+	if len(b.batch) < b.size {
+		b.mu.Unlock()
+		return
+	}
+	b.flushLocked()
+}
+
+// Cancel flushes pending messages.
+func (b *batcher) Cancel() error {
+	b.mu.Lock()
+	if len(b.batch) == 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	b.flushLocked()
+
+	return nil
+}
+
+// flushLocked takes the current batch and consumes it. It must be called
+// while holding mu, which is released once the batch is taken.
+func (b *batcher) flushLocked() {
+	batch := b.batch
+	b.batch = nil
+	b.gen++
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	b.mu.Unlock()
+
+	for _, g := range group(batch) {
+		if len(g.pending) > 1 {
+			if err := b.consume(b.ctx, g.msgs); err == nil {
+				for _, p := range g.pending {
+					p.ack(nil)
+				}
+				continue
+			}
+		}
+		// Consume messages one by one, so that a single invalid
+		// message doesn't fail the whole batch.
+		for _, p := range g.pending {
+			p.ack(b.dl.consume(b.ctx, p.orig, p.msg, b.consume))
+		}
+	}
+}
+
+// msgGroup is a set of pending messages merged into a single message batch.
+type msgGroup struct {
+	msgs    interface{}
+	pending []pending
+}
+
+// group merges pending messages of the same type into as few batches as
+// possible. SenML messages are merged together, while JSON messages are
+// merged by their format. Other messages are consumed individually.
+func group(batch []pending) []msgGroup {
+	var groups []msgGroup
+	senmlIdx := -1
+	jsonIdx := map[string]int{}
+	for _, p := range batch {
+		switch m := p.msg.(type) {
+		case []senml.Message:
+			if senmlIdx < 0 {
+				senmlIdx = len(groups)
+				groups = append(groups, msgGroup{msgs: []senml.Message{}})
+			}
+			g := &groups[senmlIdx]
+			g.msgs = append(g.msgs.([]senml.Message), m...)
+			g.pending = append(g.pending, p)
+		case json.Messages:
+			i, ok := jsonIdx[m.Format]
+			if !ok {
+				i = len(groups)
+				jsonIdx[m.Format] = i
+				groups = append(groups, msgGroup{msgs: json.Messages{Format: m.Format}})
+			}
+			g := &groups[i]
+			msgs := g.msgs.(json.Messages)
+			msgs.Data = append(msgs.Data, m.Data...)
+			g.msgs = msgs
+			g.pending = append(g.pending, p)
+		default:
+			groups = append(groups, msgGroup{msgs: p.msg, pending: []pending{p}})
+		}
+	}
+
+	return groups
+}
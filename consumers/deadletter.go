@@ -0,0 +1,130 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// DeadLetterProtocol is the protocol of the messages carrying dead letters.
+// Consumers ignore such messages, so dead letters published to the message
+// broker never reach the regular message pipeline.
+const DeadLetterProtocol = "dlq"
+
+var errDeadLetter = errors.New("failed to publish dead letter")
+
+// DeadLetter represents a message which the consumer failed to transform
+// or consume.
+type DeadLetter struct {
+	// Consumer is the name of the consumer which failed to handle the message.
+	Consumer string `json:"consumer"`
+
+	// Reason is the error returned by the last attempt to handle the message.
+	Reason string `json:"reason"`
+
+	// Attempts is the number of attempts to handle the message.
+	Attempts uint64 `json:"attempts"`
+
+	// Created is the time the message is dead-lettered in nanoseconds.
+	Created int64 `json:"created"`
+
+	// Message is the original message.
+	Message *messaging.Message `json:"message"`
+}
+
+// deadLetters retries consuming the messages with exponential backoff and
+// publishes the messages which can't be consumed to the dead-letter topic.
+type deadLetters struct {
+	consumer string
+	pub      messaging.Publisher
+	cfg      dlqConfig
+	backoff  time.Duration
+	max      time.Duration
+}
+
+func newDeadLetters(consumer string, pub messaging.Publisher, cfg dlqConfig) (*deadLetters, error) {
+	backoff, err := time.ParseDuration(cfg.Backoff)
+	if err != nil || backoff < 0 {
+		return nil, errInvalidDLQ
+	}
+	max, err := time.ParseDuration(cfg.MaxBackoff)
+	if err != nil || max < backoff {
+		return nil, errInvalidDLQ
+	}
+	if cfg.MaxAttempts < 1 {
+		return nil, errInvalidDLQ
+	}
+
+	return &deadLetters{
+		consumer: consumer,
+		pub:      pub,
+		cfg:      cfg,
+		backoff:  backoff,
+		max:      max,
+	}, nil
+}
+
+// consume consumes the message, retrying the failed attempts. If all the
+// attempts fail, the message is dead-lettered.
+func (dl *deadLetters) consume(ctx context.Context, msg *messaging.Message, m interface{}, consume consumeFunc) error {
+	backoff := dl.backoff
+	var err error
+	var attempts uint64
+	for attempts < dl.cfg.MaxAttempts {
+		if attempts > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return dl.send(ctx, msg, attempts, err)
+			}
+			if backoff *= 2; backoff > dl.max {
+				backoff = dl.max
+			}
+		}
+		attempts++
+		if err = consume(ctx, m); err == nil {
+			return nil
+		}
+	}
+
+	return dl.send(ctx, msg, attempts, err)
+}
+
+// send publishes the message which failed to be handled to the dead-letter
+// topic. If the dead-letter topic is not configured, or the message can't be
+// published, the original error is returned.
+func (dl *deadLetters) send(ctx context.Context, msg *messaging.Message, attempts uint64, reason error) error {
+	if dl.cfg.Topic == "" {
+		return reason
+	}
+
+	letter := DeadLetter{
+		Consumer: dl.consumer,
+		Reason:   reason.Error(),
+		Attempts: attempts,
+		Created:  time.Now().UnixNano(),
+		Message:  msg,
+	}
+	payload, err := json.Marshal(letter)
+	if err != nil {
+		return errors.Wrap(reason, errors.Wrap(errDeadLetter, err))
+	}
+	dlMsg := messaging.Message{
+		Channel:   dl.cfg.Topic,
+		Publisher: dl.consumer,
+		Protocol:  DeadLetterProtocol,
+		Payload:   payload,
+		Created:   letter.Created,
+	}
+	if err := dl.pub.Publish(ctx, dl.cfg.Topic, &dlMsg); err != nil {
+		return errors.Wrap(reason, errors.Wrap(errDeadLetter, err))
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+func listDeadLettersEndpoint(svc dlq.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listDeadLettersReq)
+		if err := req.validate(); err != nil {
+			return listDeadLettersRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		pm := dlq.PageMetadata{
+			Offset:   req.offset,
+			Limit:    req.limit,
+			Consumer: req.consumer,
+			Channel:  req.channel,
+		}
+		page, err := svc.ListDeadLetters(ctx, req.token, pm)
+		if err != nil {
+			return listDeadLettersRes{}, err
+		}
+		res := listDeadLettersRes{
+			Offset:      page.Offset,
+			Limit:       page.Limit,
+			Total:       page.Total,
+			DeadLetters: []viewDeadLetterRes{},
+		}
+		for _, dl := range page.DeadLetters {
+			res.DeadLetters = append(res.DeadLetters, toViewDeadLetterRes(dl))
+		}
+
+		return res, nil
+	}
+}
+
+func viewDeadLetterEndpoint(svc dlq.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return viewDeadLetterRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		dl, err := svc.ViewDeadLetter(ctx, req.token, req.id)
+		if err != nil {
+			return viewDeadLetterRes{}, err
+		}
+
+		return toViewDeadLetterRes(dl), nil
+	}
+}
+
+func replayDeadLetterEndpoint(svc dlq.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := svc.ReplayDeadLetter(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return replayDeadLetterRes{}, nil
+	}
+}
+
+func removeDeadLetterEndpoint(svc dlq.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := svc.RemoveDeadLetter(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeDeadLetterRes{}, nil
+	}
+}
+
+func toViewDeadLetterRes(dl dlq.DeadLetter) viewDeadLetterRes {
+	res := viewDeadLetterRes{
+		ID:        dl.ID,
+		Consumer:  dl.Consumer,
+		Reason:    dl.Reason,
+		Attempts:  dl.Attempts,
+		CreatedAt: dl.CreatedAt,
+	}
+	if dl.Message != nil {
+		res.Message = messageRes{
+			Channel:   dl.Message.Channel,
+			Subtopic:  dl.Message.Subtopic,
+			Publisher: dl.Message.Publisher,
+			Protocol:  dl.Message.Protocol,
+			Payload:   dl.Message.Payload,
+			Created:   dl.Message.Created,
+		}
+	}
+
+	return res
+}
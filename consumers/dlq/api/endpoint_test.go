@@ -0,0 +1,336 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	httpapi "github.com/mainflux/mainflux/consumers/dlq/api"
+	"github.com/mainflux/mainflux/consumers/dlq/mocks"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminToken = "admin-token"
+	userToken  = "user-token"
+	adminID    = "admin"
+	userID     = "user"
+	chanID     = "chan"
+	consumer   = "postgres-writer"
+	instanceID = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+type messageRes struct {
+	Channel  string `json:"channel"`
+	Protocol string `json:"protocol"`
+	Payload  []byte `json:"payload"`
+}
+
+type deadLetterRes struct {
+	ID       string     `json:"id"`
+	Consumer string     `json:"consumer"`
+	Attempts uint64     `json:"attempts"`
+	Message  messageRes `json:"message"`
+}
+
+type pageRes struct {
+	Total       uint64          `json:"total"`
+	DeadLetters []deadLetterRes `json:"dead_letters"`
+}
+
+func newService(t *testing.T, channels ...string) (dlq.Service, []string) {
+	auth := mocks.NewAuth(map[string]string{adminToken: adminID, userToken: userID}, adminID)
+	svc := dlq.New(auth, mocks.NewRepo(), uuid.NewMock(), mocks.NewPublisher(""))
+
+	var ids []string
+	for i, ch := range channels {
+		letter := consumers.DeadLetter{
+			Consumer: consumer,
+			Reason:   "failed to save message",
+			Attempts: 3,
+			Created:  time.Now().UnixNano(),
+			Message: &messaging.Message{
+				Channel:  ch,
+				Protocol: "http",
+				Payload:  []byte(`[{"n":"temperature","v":17}]`),
+			},
+		}
+		payload, err := json.Marshal(letter)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		msg := &messaging.Message{Protocol: consumers.DeadLetterProtocol, Payload: payload}
+		require.Nil(t, svc.ConsumeBlocking(context.Background(), msg), "consuming dead letter expected to succeed")
+		ids = append(ids, fmt.Sprintf("%s%012d", uuid.Prefix, i+1))
+	}
+
+	return svc, ids
+}
+
+func newServer(svc dlq.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, logger.NewMock(), instanceID)
+	return httptest.NewServer(mux)
+}
+
+func TestView(t *testing.T) {
+	svc, ids := newService(t, chanID)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "view dead letter",
+			id:     ids[0],
+			token:  adminToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view dead letter as non-admin user",
+			id:     ids[0],
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "view dead letter with invalid token",
+			id:     ids[0],
+			token:  "invalid",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view dead letter without token",
+			id:     ids[0],
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view non-existing dead letter",
+			id:     "non-existing",
+			token:  adminToken,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/deadletters/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body deadLetterRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.id, body.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, tc.id, body.ID))
+		assert.Equal(t, consumer, body.Consumer, fmt.Sprintf("%s: expected consumer %s got %s", tc.desc, consumer, body.Consumer))
+		assert.Equal(t, chanID, body.Message.Channel, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, chanID, body.Message.Channel))
+	}
+}
+
+func TestList(t *testing.T) {
+	svc, _ := newService(t, chanID, chanID, "other")
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		size   int
+		total  uint64
+	}{
+		{
+			desc:   "list dead letters",
+			query:  "",
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   3,
+			total:  3,
+		},
+		{
+			desc:   "list dead letters with offset and limit",
+			query:  "?offset=1&limit=1",
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   1,
+			total:  3,
+		},
+		{
+			desc:   "list dead letters of channel",
+			query:  fmt.Sprintf("?channel=%s", chanID),
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   2,
+			total:  2,
+		},
+		{
+			desc:   "list dead letters with invalid limit",
+			query:  "?limit=1000",
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with invalid offset",
+			query:  "?offset=invalid",
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters as non-admin user",
+			query:  "",
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/deadletters%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body pageRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(body.DeadLetters), fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.size, len(body.DeadLetters)))
+		assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+	}
+}
+
+func TestReplay(t *testing.T) {
+	svc, ids := newService(t, chanID)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "replay dead letter as non-admin user",
+			id:     ids[0],
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "replay dead letter",
+			id:     ids[0],
+			token:  adminToken,
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "replay replayed dead letter",
+			id:     ids[0],
+			token:  adminToken,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/deadletters/%s/replay", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		_, _ = io.Copy(io.Discard, res.Body)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	svc, ids := newService(t, chanID)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove dead letter as non-admin user",
+			id:     ids[0],
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove dead letter",
+			id:     ids[0],
+			token:  adminToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed dead letter",
+			id:     ids[0],
+			token:  adminToken,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/deadletters/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/dlq"
+	mflog "github.com/mainflux/mainflux/logger"
+)
+
+var _ dlq.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger mflog.Logger
+	svc    dlq.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc dlq.Service, logger mflog.Logger) dlq.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// ListDeadLetters logs the list_dead_letters request. It logs the consumer and channel filters and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListDeadLetters(ctx context.Context, token string, pm dlq.PageMetadata) (page dlq.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_dead_letters for consumer %s and channel %s took %s to complete", pm.Consumer, pm.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListDeadLetters(ctx, token, pm)
+}
+
+// ViewDeadLetter logs the view_dead_letter request. It logs dead letter ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewDeadLetter(ctx context.Context, token, id string) (dl dlq.DeadLetter, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_dead_letter for dead letter %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewDeadLetter(ctx, token, id)
+}
+
+// ReplayDeadLetter logs the replay_dead_letter request. It logs dead letter ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ReplayDeadLetter(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method replay_dead_letter for dead letter %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ReplayDeadLetter(ctx, token, id)
+}
+
+// RemoveDeadLetter logs the remove_dead_letter request. It logs dead letter ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveDeadLetter(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_dead_letter for dead letter %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveDeadLetter(ctx, token, id)
+}
+
+// ConsumeBlocking logs the consume request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method consume took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConsumeBlocking(ctx, msg)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/consumers/dlq"
+)
+
+var _ dlq.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     dlq.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc dlq.Service, counter metrics.Counter, latency metrics.Histogram) dlq.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+// ListDeadLetters instruments ListDeadLetters method with metrics.
+func (ms *metricsMiddleware) ListDeadLetters(ctx context.Context, token string, pm dlq.PageMetadata) (dlq.Page, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_dead_letters").Add(1)
+		ms.latency.With("method", "list_dead_letters").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListDeadLetters(ctx, token, pm)
+}
+
+// ViewDeadLetter instruments ViewDeadLetter method with metrics.
+func (ms *metricsMiddleware) ViewDeadLetter(ctx context.Context, token, id string) (dlq.DeadLetter, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_dead_letter").Add(1)
+		ms.latency.With("method", "view_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewDeadLetter(ctx, token, id)
+}
+
+// ReplayDeadLetter instruments ReplayDeadLetter method with metrics.
+func (ms *metricsMiddleware) ReplayDeadLetter(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "replay_dead_letter").Add(1)
+		ms.latency.With("method", "replay_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ReplayDeadLetter(ctx, token, id)
+}
+
+// RemoveDeadLetter instruments RemoveDeadLetter method with metrics.
+func (ms *metricsMiddleware) RemoveDeadLetter(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_dead_letter").Add(1)
+		ms.latency.With("method", "remove_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveDeadLetter(ctx, token, id)
+}
+
+// ConsumeBlocking instruments ConsumeBlocking method with metrics.
+func (ms *metricsMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "consume").Add(1)
+		ms.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ConsumeBlocking(ctx, msg)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*viewDeadLetterRes)(nil)
+	_ mainflux.Response = (*listDeadLettersRes)(nil)
+	_ mainflux.Response = (*replayDeadLetterRes)(nil)
+	_ mainflux.Response = (*removeDeadLetterRes)(nil)
+)
+
+type messageRes struct {
+	Channel   string `json:"channel"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Publisher string `json:"publisher"`
+	Protocol  string `json:"protocol"`
+	Payload   []byte `json:"payload"`
+	Created   int64  `json:"created"`
+}
+
+type viewDeadLetterRes struct {
+	ID        string     `json:"id"`
+	Consumer  string     `json:"consumer"`
+	Reason    string     `json:"reason"`
+	Attempts  uint64     `json:"attempts"`
+	Message   messageRes `json:"message"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (res viewDeadLetterRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewDeadLetterRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewDeadLetterRes) Empty() bool {
+	return false
+}
+
+type listDeadLettersRes struct {
+	Offset      uint64              `json:"offset"`
+	Limit       uint64              `json:"limit"`
+	Total       uint64              `json:"total"`
+	DeadLetters []viewDeadLetterRes `json:"dead_letters"`
+}
+
+func (res listDeadLettersRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listDeadLettersRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listDeadLettersRes) Empty() bool {
+	return false
+}
+
+type replayDeadLetterRes struct{}
+
+func (res replayDeadLetterRes) Code() int {
+	return http.StatusAccepted
+}
+
+func (res replayDeadLetterRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res replayDeadLetterRes) Empty() bool {
+	return true
+}
+
+type removeDeadLetterRes struct{}
+
+func (res removeDeadLetterRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeDeadLetterRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeDeadLetterRes) Empty() bool {
+	return true
+}
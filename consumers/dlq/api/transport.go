@@ -0,0 +1,163 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	contentType = "application/json"
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	consumerKey = "consumer"
+	channelKey  = "channel"
+	defOffset   = 0
+	defLimit    = 10
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc dlq.Service, logger logger.Logger, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	mux := bone.New()
+
+	mux.Get("/deadletters", otelhttp.NewHandler(kithttp.NewServer(
+		listDeadLettersEndpoint(svc),
+		decodeList,
+		encodeResponse,
+		opts...,
+	), "list"))
+
+	mux.Get("/deadletters/:id", otelhttp.NewHandler(kithttp.NewServer(
+		viewDeadLetterEndpoint(svc),
+		decodeDeadLetter,
+		encodeResponse,
+		opts...,
+	), "view"))
+
+	mux.Post("/deadletters/:id/replay", otelhttp.NewHandler(kithttp.NewServer(
+		replayDeadLetterEndpoint(svc),
+		decodeDeadLetter,
+		encodeResponse,
+		opts...,
+	), "replay"))
+
+	mux.Delete("/deadletters/:id", otelhttp.NewHandler(kithttp.NewServer(
+		removeDeadLetterEndpoint(svc),
+		decodeDeadLetter,
+		encodeResponse,
+		opts...,
+	), "delete"))
+
+	mux.GetFunc("/health", mainflux.Health("dlq", instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeDeadLetter(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deadLetterReq{
+		id:    bone.GetValue(r, "id"),
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	return req, nil
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	consumer, err := apiutil.ReadStringQuery(r, consumerKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	channel, err := apiutil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listDeadLettersReq{
+		token:    apiutil.ExtractBearerToken(r),
+		consumer: consumer,
+		channel:  channel,
+		offset:   offset,
+		limit:    limit,
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	var wrapper error
+	if errors.Contains(err, apiutil.ErrValidation) {
+		wrapper, err = errors.Unwrap(err)
+	}
+
+	switch {
+	case errors.Contains(err, errors.ErrMalformedEntity),
+		errors.Contains(err, apiutil.ErrMissingID),
+		errors.Contains(err, apiutil.ErrLimitSize),
+		errors.Contains(err, apiutil.ErrInvalidQueryParams):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, errors.ErrAuthentication),
+		errors.Contains(err, apiutil.ErrBearerToken):
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, dlq.ErrReplay):
+		w.WriteHeader(http.StatusServiceUnavailable)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if wrapper != nil {
+		err = errors.Wrap(wrapper, err)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(errorVal); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
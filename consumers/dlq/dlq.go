@@ -0,0 +1,52 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dlq
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// DeadLetter represents a message which the consumer failed to handle.
+type DeadLetter struct {
+	ID        string
+	Consumer  string
+	Reason    string
+	Attempts  uint64
+	Message   *messaging.Message
+	CreatedAt time.Time
+}
+
+// Page represents page metadata with content.
+type Page struct {
+	PageMetadata
+	Total       uint64
+	DeadLetters []DeadLetter
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset   uint64
+	Limit    uint64
+	Consumer string
+	Channel  string
+}
+
+// Repository specifies a dead letter persistence API.
+type Repository interface {
+	// Save persists the dead letter.
+	Save(ctx context.Context, dl DeadLetter) error
+
+	// RetrieveByID retrieves the dead letter having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (DeadLetter, error)
+
+	// RetrieveAll retrieves the dead letters for the given page metadata,
+	// starting from the most recent ones.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (Page, error)
+
+	// Remove removes the dead letter having the provided identifier.
+	Remove(ctx context.Context, id string) error
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dlq contains the domain concept definitions needed to support
+// Mainflux dead-letter functionality. Dead letters are the messages which
+// consumers failed to transform or consume.
+package dlq
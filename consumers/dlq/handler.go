@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dlq
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ messaging.MessageHandler = (*handler)(nil)
+
+type handler struct {
+	ctx      context.Context
+	consumer consumers.BlockingConsumer
+}
+
+// NewHandler returns the message handler which passes the messages received
+// from the dead-letter topic to the given consumer. Unlike the handlers
+// created by consumers.Start, it doesn't ignore dead-letter messages.
+func NewHandler(ctx context.Context, consumer consumers.BlockingConsumer) messaging.MessageHandler {
+	return &handler{
+		ctx:      ctx,
+		consumer: consumer,
+	}
+}
+
+func (h *handler) Handle(msg *messaging.Message) error {
+	return h.consumer.ConsumeBlocking(h.ctx, msg)
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/policies"
+	"google.golang.org/grpc"
+)
+
+var _ policies.AuthServiceClient = (*authServiceMock)(nil)
+
+type authServiceMock struct {
+	users  map[string]string
+	admins map[string]bool
+}
+
+// NewAuth creates mock of auth service. Only the users whose IDs are
+// in the admins list are authorized.
+func NewAuth(users map[string]string, admins ...string) policies.AuthServiceClient {
+	am := make(map[string]bool)
+	for _, id := range admins {
+		am[id] = true
+	}
+	return &authServiceMock{
+		users:  users,
+		admins: am,
+	}
+}
+
+func (svc authServiceMock) Identify(ctx context.Context, req *policies.IdentifyReq, opts ...grpc.CallOption) (*policies.IdentifyRes, error) {
+	if id, ok := svc.users[req.GetToken()]; ok {
+		return &policies.IdentifyRes{Id: id}, nil
+	}
+	return nil, errors.ErrAuthentication
+}
+
+func (svc authServiceMock) Authorize(ctx context.Context, req *policies.AuthorizeReq, _ ...grpc.CallOption) (*policies.AuthorizeRes, error) {
+	if svc.admins[req.GetSubject()] {
+		return &policies.AuthorizeRes{Authorized: true}, nil
+	}
+	return nil, errors.ErrAuthorization
+}
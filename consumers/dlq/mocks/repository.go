@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var _ dlq.Repository = (*repoMock)(nil)
+
+type repoMock struct {
+	mu          sync.Mutex
+	deadLetters map[string]dlq.DeadLetter
+}
+
+// NewRepo returns a new dead letters repository mock.
+func NewRepo() dlq.Repository {
+	return &repoMock{
+		deadLetters: make(map[string]dlq.DeadLetter),
+	}
+}
+
+func (rm *repoMock) Save(_ context.Context, dl dlq.DeadLetter) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if _, ok := rm.deadLetters[dl.ID]; ok {
+		return errors.ErrConflict
+	}
+	rm.deadLetters[dl.ID] = dl
+	return nil
+}
+
+func (rm *repoMock) RetrieveByID(_ context.Context, id string) (dlq.DeadLetter, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	dl, ok := rm.deadLetters[id]
+	if !ok {
+		return dlq.DeadLetter{}, errors.ErrNotFound
+	}
+	return dl, nil
+}
+
+func (rm *repoMock) RetrieveAll(_ context.Context, pm dlq.PageMetadata) (dlq.Page, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	var dls []dlq.DeadLetter
+	for _, dl := range rm.deadLetters {
+		if pm.Consumer != "" && dl.Consumer != pm.Consumer {
+			continue
+		}
+		if pm.Channel != "" && dl.Message.Channel != pm.Channel {
+			continue
+		}
+		dls = append(dls, dl)
+	}
+	sort.Slice(dls, func(i, j int) bool {
+		return dls[i].ID < dls[j].ID
+	})
+
+	page := dlq.Page{
+		PageMetadata: pm,
+		Total:        uint64(len(dls)),
+	}
+	if pm.Offset >= uint64(len(dls)) {
+		return page, nil
+	}
+	end := pm.Offset + pm.Limit
+	if end > uint64(len(dls)) {
+		end = uint64(len(dls))
+	}
+	page.DeadLetters = dls[pm.Offset:end]
+
+	return page, nil
+}
+
+func (rm *repoMock) Remove(_ context.Context, id string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if _, ok := rm.deadLetters[id]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(rm.deadLetters, id)
+	return nil
+}
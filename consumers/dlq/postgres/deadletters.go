@@ -0,0 +1,173 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ dlq.Repository = (*deadLettersRepo)(nil)
+
+type deadLettersRepo struct {
+	db postgres.Database
+}
+
+// New instantiates a PostgreSQL implementation of dead letters repository.
+func New(db postgres.Database) dlq.Repository {
+	return &deadLettersRepo{
+		db: db,
+	}
+}
+
+func (repo deadLettersRepo) Save(ctx context.Context, dl dlq.DeadLetter) error {
+	q := `INSERT INTO dead_letters (id, consumer, reason, attempts, channel, subtopic, publisher, protocol, payload, created, created_at)
+          VALUES (:id, :consumer, :reason, :attempts, :channel, :subtopic, :publisher, :protocol, :payload, :created, :created_at)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBDeadLetter(dl)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo deadLettersRepo) RetrieveByID(ctx context.Context, id string) (dlq.DeadLetter, error) {
+	q := `SELECT id, consumer, reason, attempts, channel, subtopic, publisher, protocol, payload, created, created_at
+          FROM dead_letters WHERE id = $1`
+
+	dbdl := dbDeadLetter{}
+	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&dbdl); err != nil {
+		if err == sql.ErrNoRows {
+			return dlq.DeadLetter{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return dlq.DeadLetter{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toDeadLetter(dbdl), nil
+}
+
+func (repo deadLettersRepo) RetrieveAll(ctx context.Context, pm dlq.PageMetadata) (dlq.Page, error) {
+	args := map[string]interface{}{
+		"offset": pm.Offset,
+		"limit":  pm.Limit,
+	}
+	var cond []string
+	if pm.Consumer != "" {
+		cond = append(cond, "consumer = :consumer")
+		args["consumer"] = pm.Consumer
+	}
+	if pm.Channel != "" {
+		cond = append(cond, "channel = :channel")
+		args["channel"] = pm.Channel
+	}
+	var where string
+	if len(cond) > 0 {
+		where = fmt.Sprintf("WHERE %s", strings.Join(cond, " AND "))
+	}
+
+	q := fmt.Sprintf(`SELECT id, consumer, reason, attempts, channel, subtopic, publisher, protocol, payload, created, created_at
+          FROM dead_letters %s ORDER BY created_at DESC, id LIMIT :limit OFFSET :offset`, where)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, args)
+	if err != nil {
+		return dlq.Page{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var dls []dlq.DeadLetter
+	for rows.Next() {
+		dbdl := dbDeadLetter{}
+		if err := rows.StructScan(&dbdl); err != nil {
+			return dlq.Page{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		dls = append(dls, toDeadLetter(dbdl))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM dead_letters %s`, where)
+	total, err := postgres.Total(ctx, repo.db, cq, args)
+	if err != nil {
+		return dlq.Page{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return dlq.Page{
+		PageMetadata: pm,
+		Total:        total,
+		DeadLetters:  dls,
+	}, nil
+}
+
+func (repo deadLettersRepo) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM dead_letters WHERE id = $1`
+
+	res, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbDeadLetter struct {
+	ID        string    `db:"id"`
+	Consumer  string    `db:"consumer"`
+	Reason    string    `db:"reason"`
+	Attempts  uint64    `db:"attempts"`
+	Channel   string    `db:"channel"`
+	Subtopic  string    `db:"subtopic"`
+	Publisher string    `db:"publisher"`
+	Protocol  string    `db:"protocol"`
+	Payload   []byte    `db:"payload"`
+	Created   int64     `db:"created"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBDeadLetter(dl dlq.DeadLetter) dbDeadLetter {
+	return dbDeadLetter{
+		ID:        dl.ID,
+		Consumer:  dl.Consumer,
+		Reason:    dl.Reason,
+		Attempts:  dl.Attempts,
+		Channel:   dl.Message.Channel,
+		Subtopic:  dl.Message.Subtopic,
+		Publisher: dl.Message.Publisher,
+		Protocol:  dl.Message.Protocol,
+		Payload:   dl.Message.Payload,
+		Created:   dl.Message.Created,
+		CreatedAt: dl.CreatedAt.UTC(),
+	}
+}
+
+func toDeadLetter(dbdl dbDeadLetter) dlq.DeadLetter {
+	return dlq.DeadLetter{
+		ID:       dbdl.ID,
+		Consumer: dbdl.Consumer,
+		Reason:   dbdl.Reason,
+		Attempts: dbdl.Attempts,
+		Message: &messaging.Message{
+			Channel:   dbdl.Channel,
+			Subtopic:  dbdl.Subtopic,
+			Publisher: dbdl.Publisher,
+			Protocol:  dbdl.Protocol,
+			Payload:   dbdl.Payload,
+			Created:   dbdl.Created,
+		},
+		CreatedAt: dbdl.CreatedAt,
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/dlq"
+	dlqpg "github.com/mainflux/mainflux/consumers/dlq/postgres"
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+var idProvider = uuid.New()
+
+func newDeadLetter(t *testing.T, consumer, channel string, created time.Time) dlq.DeadLetter {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return dlq.DeadLetter{
+		ID:       id,
+		Consumer: consumer,
+		Reason:   "failed to save message",
+		Attempts: 3,
+		Message: &messaging.Message{
+			Channel:   channel,
+			Publisher: "publisher",
+			Protocol:  "http",
+			Payload:   []byte(`[{"n":"temperature","v":17}]`),
+			Created:   created.UnixNano(),
+		},
+		CreatedAt: created.UTC().Round(time.Microsecond),
+	}
+}
+
+func TestSave(t *testing.T) {
+	repo := dlqpg.New(postgres.NewDatabase(db, dbConfig, otel.Tracer("dlq")))
+	dl := newDeadLetter(t, "writer", "chan", time.Now())
+
+	cases := []struct {
+		desc string
+		dl   dlq.DeadLetter
+		err  error
+	}{
+		{
+			desc: "save dead letter",
+			dl:   dl,
+			err:  nil,
+		},
+		{
+			desc: "save existing dead letter",
+			dl:   dl,
+			err:  errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.dl)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveByID(t *testing.T) {
+	repo := dlqpg.New(postgres.NewDatabase(db, dbConfig, otel.Tracer("dlq")))
+	dl := newDeadLetter(t, "writer", "chan", time.Now())
+	require.Nil(t, repo.Save(context.Background(), dl), "saving dead letter expected to succeed")
+
+	cases := []struct {
+		desc string
+		id   string
+		dl   dlq.DeadLetter
+		err  error
+	}{
+		{
+			desc: "retrieve dead letter",
+			id:   dl.ID,
+			dl:   dl,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing dead letter",
+			id:   "non-existing",
+			dl:   dlq.DeadLetter{},
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		dl, err := repo.RetrieveByID(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.dl, dl, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.dl, dl))
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	_, err := db.Exec("DELETE FROM dead_letters")
+	require.Nil(t, err, fmt.Sprintf("cleaning dead letters expected to succeed: %s", err))
+
+	repo := dlqpg.New(postgres.NewDatabase(db, dbConfig, otel.Tracer("dlq")))
+	now := time.Now()
+	var dls []dlq.DeadLetter
+	for i := 0; i < 10; i++ {
+		consumer := "postgres-writer"
+		if i%2 == 1 {
+			consumer = "influxdb-writer"
+		}
+		dl := newDeadLetter(t, consumer, fmt.Sprintf("chan-%d", i%3), now.Add(time.Duration(i)*time.Second))
+		require.Nil(t, repo.Save(context.Background(), dl), "saving dead letter expected to succeed")
+		dls = append(dls, dl)
+	}
+
+	cases := []struct {
+		desc  string
+		pm    dlq.PageMetadata
+		size  int
+		total uint64
+		first dlq.DeadLetter
+	}{
+		{
+			desc:  "retrieve all dead letters",
+			pm:    dlq.PageMetadata{Limit: 100},
+			size:  10,
+			total: 10,
+			first: dls[9],
+		},
+		{
+			desc:  "retrieve dead letters with offset and limit",
+			pm:    dlq.PageMetadata{Offset: 2, Limit: 3},
+			size:  3,
+			total: 10,
+			first: dls[7],
+		},
+		{
+			desc:  "retrieve dead letters of consumer",
+			pm:    dlq.PageMetadata{Limit: 100, Consumer: "influxdb-writer"},
+			size:  5,
+			total: 5,
+			first: dls[9],
+		},
+		{
+			desc:  "retrieve dead letters of consumer and channel",
+			pm:    dlq.PageMetadata{Limit: 100, Consumer: "postgres-writer", Channel: "chan-0"},
+			size:  2,
+			total: 2,
+			first: dls[6],
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.pm)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.DeadLetters), fmt.Sprintf("%s: expected %d dead letters got %d\n", tc.desc, tc.size, len(page.DeadLetters)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.Equal(t, tc.first, page.DeadLetters[0], fmt.Sprintf("%s: expected most recent dead letter %v got %v\n", tc.desc, tc.first, page.DeadLetters[0]))
+	}
+}
+
+func TestRemove(t *testing.T) {
+	repo := dlqpg.New(postgres.NewDatabase(db, dbConfig, otel.Tracer("dlq")))
+	dl := newDeadLetter(t, "writer", "chan", time.Now())
+	require.Nil(t, repo.Save(context.Background(), dl), "saving dead letter expected to succeed")
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove dead letter",
+			id:   dl.ID,
+			err:  nil,
+		},
+		{
+			desc: "remove removed dead letter",
+			id:   dl.ID,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
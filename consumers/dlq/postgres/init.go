@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Migration of dead-letter service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "dead_letters_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS dead_letters (
+                        id          VARCHAR(254) PRIMARY KEY,
+                        consumer    VARCHAR(254) NOT NULL,
+                        reason      TEXT,
+                        attempts    BIGINT,
+                        channel     VARCHAR(254),
+                        subtopic    VARCHAR(254),
+                        publisher   VARCHAR(254),
+                        protocol    TEXT,
+                        payload     BYTEA,
+                        created     BIGINT,
+                        created_at  TIMESTAMP NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS dead_letters_created_at_idx ON dead_letters (created_at)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS dead_letters",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/users/policies"
+)
+
+const (
+	// For checking admin rights policy object and action are not important.
+	deadLettersObject = "dead_letters"
+	listAction        = "c_list"
+	clientEntityType  = "client"
+)
+
+var (
+	// ErrMessage indicates that the message doesn't carry a dead letter.
+	ErrMessage = errors.New("failed to convert message to dead letter")
+
+	// ErrReplay indicates failure to publish the dead letter message back to the message broker.
+	ErrReplay = errors.New("failed to replay dead letter")
+)
+
+var _ consumers.BlockingConsumer = (*service)(nil)
+
+// Service represents a dead-letter service.
+type Service interface {
+	// ListDeadLetters lists the dead letters matching the provided page metadata.
+	ListDeadLetters(ctx context.Context, token string, pm PageMetadata) (Page, error)
+
+	// ViewDeadLetter retrieves the dead letter having the provided identifier.
+	ViewDeadLetter(ctx context.Context, token, id string) (DeadLetter, error)
+
+	// ReplayDeadLetter publishes the original message of the dead letter back
+	// to the message broker and removes the dead letter.
+	ReplayDeadLetter(ctx context.Context, token, id string) error
+
+	// RemoveDeadLetter removes the dead letter having the provided identifier.
+	RemoveDeadLetter(ctx context.Context, token, id string) error
+
+	consumers.BlockingConsumer
+}
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	auth      policies.AuthServiceClient
+	repo      Repository
+	idp       mainflux.IDProvider
+	publisher messaging.Publisher
+}
+
+// New instantiates the dead-letter service implementation.
+func New(auth policies.AuthServiceClient, repo Repository, idp mainflux.IDProvider, publisher messaging.Publisher) Service {
+	return &service{
+		auth:      auth,
+		repo:      repo,
+		idp:       idp,
+		publisher: publisher,
+	}
+}
+
+func (svc *service) ListDeadLetters(ctx context.Context, token string, pm PageMetadata) (Page, error) {
+	if err := svc.checkAdmin(ctx, token); err != nil {
+		return Page{}, err
+	}
+
+	return svc.repo.RetrieveAll(ctx, pm)
+}
+
+func (svc *service) ViewDeadLetter(ctx context.Context, token, id string) (DeadLetter, error) {
+	if err := svc.checkAdmin(ctx, token); err != nil {
+		return DeadLetter{}, err
+	}
+
+	return svc.repo.RetrieveByID(ctx, id)
+}
+
+func (svc *service) ReplayDeadLetter(ctx context.Context, token, id string) error {
+	if err := svc.checkAdmin(ctx, token); err != nil {
+		return err
+	}
+
+	dl, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := svc.publisher.Publish(ctx, dl.Message.Channel, dl.Message); err != nil {
+		return errors.Wrap(ErrReplay, err)
+	}
+
+	return svc.repo.Remove(ctx, id)
+}
+
+func (svc *service) RemoveDeadLetter(ctx context.Context, token, id string) error {
+	if err := svc.checkAdmin(ctx, token); err != nil {
+		return err
+	}
+
+	return svc.repo.Remove(ctx, id)
+}
+
+// ConsumeBlocking saves the dead letter carried by the message received
+// from the dead-letter topic.
+func (svc *service) ConsumeBlocking(ctx context.Context, message interface{}) error {
+	msg, ok := message.(*messaging.Message)
+	if !ok || msg.Protocol != consumers.DeadLetterProtocol {
+		return ErrMessage
+	}
+
+	var letter consumers.DeadLetter
+	if err := json.Unmarshal(msg.Payload, &letter); err != nil {
+		return errors.Wrap(ErrMessage, err)
+	}
+	if letter.Message == nil {
+		return ErrMessage
+	}
+
+	id, err := svc.idp.ID()
+	if err != nil {
+		return err
+	}
+	dl := DeadLetter{
+		ID:        id,
+		Consumer:  letter.Consumer,
+		Reason:    letter.Reason,
+		Attempts:  letter.Attempts,
+		Message:   letter.Message,
+		CreatedAt: time.Unix(0, letter.Created),
+	}
+
+	return svc.repo.Save(ctx, dl)
+}
+
+// checkAdmin verifies that the token belongs to the admin, since dead
+// letters contain messages of all the channels.
+func (svc *service) checkAdmin(ctx context.Context, token string) error {
+	res, err := svc.auth.Identify(ctx, &policies.IdentifyReq{Token: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+	req := &policies.AuthorizeReq{
+		Subject:    res.GetId(),
+		Object:     deadLettersObject,
+		Action:     listAction,
+		EntityType: clientEntityType,
+	}
+	ar, err := svc.auth.Authorize(ctx, req)
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+	if !ar.GetAuthorized() {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
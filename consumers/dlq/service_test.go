@@ -0,0 +1,299 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dlq_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/consumers/dlq/mocks"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminToken = "admin-token"
+	userToken  = "user-token"
+	adminID    = "admin"
+	userID     = "user"
+	consumer   = "postgres-writer"
+	chanID     = "chan"
+	failChan   = "fail"
+)
+
+func newService() (dlq.Service, *mocks.Publisher) {
+	auth := mocks.NewAuth(map[string]string{adminToken: adminID, userToken: userID}, adminID)
+	pub := mocks.NewPublisher(failChan)
+	return dlq.New(auth, mocks.NewRepo(), uuid.NewMock(), pub), pub
+}
+
+func deadLetterMessage(t *testing.T, channel string) *messaging.Message {
+	letter := consumers.DeadLetter{
+		Consumer: consumer,
+		Reason:   "failed to save message",
+		Attempts: 3,
+		Created:  time.Now().UnixNano(),
+		Message: &messaging.Message{
+			Channel:   channel,
+			Publisher: "publisher",
+			Protocol:  "http",
+			Payload:   []byte(`[{"n":"temperature","v":17}]`),
+		},
+	}
+	payload, err := json.Marshal(letter)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return &messaging.Message{
+		Channel:   "dlq",
+		Publisher: consumer,
+		Protocol:  consumers.DeadLetterProtocol,
+		Payload:   payload,
+	}
+}
+
+func saveDeadLetters(t *testing.T, svc dlq.Service, channels ...string) []string {
+	var ids []string
+	for _, ch := range channels {
+		err := svc.ConsumeBlocking(context.Background(), deadLetterMessage(t, ch))
+		require.Nil(t, err, fmt.Sprintf("consuming dead letter expected to succeed: %s", err))
+		ids = append(ids, uuid.Prefix+fmt.Sprintf("%012d", len(ids)+1))
+	}
+	return ids
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	svc, _ := newService()
+
+	invalid := deadLetterMessage(t, chanID)
+	invalid.Payload = []byte("invalid")
+	regular := deadLetterMessage(t, chanID)
+	regular.Protocol = "http"
+
+	cases := []struct {
+		desc string
+		msg  interface{}
+		err  error
+	}{
+		{
+			desc: "consume dead letter",
+			msg:  deadLetterMessage(t, chanID),
+			err:  nil,
+		},
+		{
+			desc: "consume dead letter with invalid payload",
+			msg:  invalid,
+			err:  dlq.ErrMessage,
+		},
+		{
+			desc: "consume message which is not a dead letter",
+			msg:  regular,
+			err:  dlq.ErrMessage,
+		},
+		{
+			desc: "consume invalid message type",
+			msg:  "message",
+			err:  dlq.ErrMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ConsumeBlocking(context.Background(), tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestViewDeadLetter(t *testing.T) {
+	svc, _ := newService()
+	ids := saveDeadLetters(t, svc, chanID)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "view dead letter",
+			token: adminToken,
+			id:    ids[0],
+			err:   nil,
+		},
+		{
+			desc:  "view dead letter as non-admin user",
+			token: userToken,
+			id:    ids[0],
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "view dead letter with invalid token",
+			token: "invalid",
+			id:    ids[0],
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "view non-existing dead letter",
+			token: adminToken,
+			id:    "non-existing",
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		dl, err := svc.ViewDeadLetter(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, consumer, dl.Consumer, fmt.Sprintf("%s: expected consumer %s got %s\n", tc.desc, consumer, dl.Consumer))
+			assert.Equal(t, uint64(3), dl.Attempts, fmt.Sprintf("%s: expected 3 attempts got %d\n", tc.desc, dl.Attempts))
+			assert.Equal(t, chanID, dl.Message.Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, chanID, dl.Message.Channel))
+		}
+	}
+}
+
+func TestListDeadLetters(t *testing.T) {
+	svc, _ := newService()
+	saveDeadLetters(t, svc, chanID, chanID, "other")
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    dlq.PageMetadata
+		size  int
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list all dead letters",
+			token: adminToken,
+			pm:    dlq.PageMetadata{Limit: 10},
+			size:  3,
+			total: 3,
+		},
+		{
+			desc:  "list dead letters with limit",
+			token: adminToken,
+			pm:    dlq.PageMetadata{Offset: 1, Limit: 1},
+			size:  1,
+			total: 3,
+		},
+		{
+			desc:  "list dead letters of channel",
+			token: adminToken,
+			pm:    dlq.PageMetadata{Limit: 10, Channel: chanID},
+			size:  2,
+			total: 2,
+		},
+		{
+			desc:  "list dead letters as non-admin user",
+			token: userToken,
+			pm:    dlq.PageMetadata{Limit: 10},
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListDeadLetters(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.DeadLetters), fmt.Sprintf("%s: expected %d dead letters got %d\n", tc.desc, tc.size, len(page.DeadLetters)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	svc, pub := newService()
+	ids := saveDeadLetters(t, svc, chanID, failChan)
+
+	cases := []struct {
+		desc      string
+		token     string
+		id        string
+		published int
+		err       error
+	}{
+		{
+			desc:      "replay dead letter as non-admin user",
+			token:     userToken,
+			id:        ids[0],
+			published: 0,
+			err:       errors.ErrAuthorization,
+		},
+		{
+			desc:      "replay dead letter",
+			token:     adminToken,
+			id:        ids[0],
+			published: 1,
+			err:       nil,
+		},
+		{
+			desc:      "replay already replayed dead letter",
+			token:     adminToken,
+			id:        ids[0],
+			published: 1,
+			err:       errors.ErrNotFound,
+		},
+		{
+			desc:      "replay dead letter which can't be published",
+			token:     adminToken,
+			id:        ids[1],
+			published: 1,
+			err:       dlq.ErrReplay,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ReplayDeadLetter(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Len(t, pub.Messages(), tc.published, fmt.Sprintf("%s: expected %d published messages got %d\n", tc.desc, tc.published, len(pub.Messages())))
+	}
+
+	msg := pub.Messages()[0]
+	assert.Equal(t, chanID, msg.Channel, fmt.Sprintf("expected replayed message on channel %s got %s\n", chanID, msg.Channel))
+	assert.Equal(t, "http", msg.Protocol, fmt.Sprintf("expected replayed message with original protocol got %s\n", msg.Protocol))
+
+	_, err := svc.ViewDeadLetter(context.Background(), adminToken, ids[1])
+	assert.Nil(t, err, "expected dead letter which can't be replayed to be kept")
+}
+
+func TestRemoveDeadLetter(t *testing.T) {
+	svc, _ := newService()
+	ids := saveDeadLetters(t, svc, chanID)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove dead letter as non-admin user",
+			token: userToken,
+			id:    ids[0],
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "remove dead letter",
+			token: adminToken,
+			id:    ids[0],
+			err:   nil,
+		},
+		{
+			desc:  "remove removed dead letter",
+			token: adminToken,
+			id:    ids[0],
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveDeadLetter(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
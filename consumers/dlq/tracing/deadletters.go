@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	saveOp         = "save_op"
+	retrieveByIDOp = "retrieve_by_id_op"
+	retrieveAllOp  = "retrieve_all_op"
+	removeOp       = "remove_op"
+)
+
+var _ dlq.Repository = (*repositoryMiddleware)(nil)
+
+type repositoryMiddleware struct {
+	tracer trace.Tracer
+	repo   dlq.Repository
+}
+
+// New instantiates a new dead letters repository that
+// tracks request and their latency, and adds spans to context.
+func New(tracer trace.Tracer, repo dlq.Repository) dlq.Repository {
+	return repositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+// Save traces the "Save" operation of the wrapped dead letters repository.
+func (rm repositoryMiddleware) Save(ctx context.Context, dl dlq.DeadLetter) error {
+	ctx, span := rm.tracer.Start(ctx, saveOp, trace.WithAttributes(
+		attribute.String("id", dl.ID),
+		attribute.String("consumer", dl.Consumer),
+	))
+	defer span.End()
+
+	return rm.repo.Save(ctx, dl)
+}
+
+// RetrieveByID traces the "RetrieveByID" operation of the wrapped dead letters repository.
+func (rm repositoryMiddleware) RetrieveByID(ctx context.Context, id string) (dlq.DeadLetter, error) {
+	ctx, span := rm.tracer.Start(ctx, retrieveByIDOp, trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return rm.repo.RetrieveByID(ctx, id)
+}
+
+// RetrieveAll traces the "RetrieveAll" operation of the wrapped dead letters repository.
+func (rm repositoryMiddleware) RetrieveAll(ctx context.Context, pm dlq.PageMetadata) (dlq.Page, error) {
+	ctx, span := rm.tracer.Start(ctx, retrieveAllOp)
+	defer span.End()
+
+	return rm.repo.RetrieveAll(ctx, pm)
+}
+
+// Remove traces the "Remove" operation of the wrapped dead letters repository.
+func (rm repositoryMiddleware) Remove(ctx context.Context, id string) error {
+	ctx, span := rm.tracer.Start(ctx, removeOp, trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return rm.repo.Remove(ctx, id)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/mainflux/mainflux/internal/apiutil"
 	"github.com/mainflux/mainflux/logger"
@@ -23,17 +24,25 @@ import (
 const (
 	defContentType = "application/senml+json"
 	defFormat      = "senml"
+	defBatchSize   = 1
+	defLinger      = "100ms"
+	defMaxAttempts = 3
+	defBackoff     = "100ms"
+	defMaxBackoff  = "5s"
 )
 
 var (
 	errOpenConfFile  = errors.New("unable to open configuration file")
 	errParseConfFile = errors.New("unable to parse configuration file")
+	errInvalidBatch  = errors.New("invalid batch configuration")
+	errInvalidDLQ    = errors.New("invalid dead-letter configuration")
 )
 
 // Start method starts consuming messages received from Message broker.
 // This method transforms messages to SenML format before
-// using MessageRepository to store them.
-func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer interface{}, configPath string, logger logger.Logger) error {
+// using MessageRepository to store them. Messages which can't be
+// transformed or consumed are published to the dead-letter topic.
+func Start(ctx context.Context, id string, pubsub messaging.PubSub, consumer interface{}, configPath string, logger logger.Logger) error {
 	cfg, err := loadConfig(configPath)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Failed to load consumer config: %s", err))
@@ -41,50 +50,55 @@ func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer in
 
 	transformer := makeTransformer(cfg.TransformerCfg, logger)
 
-	for _, subject := range cfg.SubscriberCfg.Subjects {
-		switch c := consumer.(type) {
-		case AsyncConsumer:
-			if err := sub.Subscribe(ctx, id, subject, handleAsync(ctx, transformer, c)); err != nil {
-				return err
-			}
-		case BlockingConsumer:
-			if err := sub.Subscribe(ctx, id, subject, handleSync(ctx, transformer, c)); err != nil {
-				return err
-			}
-		default:
-			return apiutil.ErrInvalidQueryParams
+	linger, err := time.ParseDuration(cfg.BatchCfg.Linger)
+	if err != nil || linger <= 0 || cfg.BatchCfg.Size < 1 {
+		return errInvalidBatch
+	}
+
+	dl, err := newDeadLetters(id, pubsub, cfg.DLQCfg)
+	if err != nil {
+		return err
+	}
+
+	var consume consumeFunc
+	switch c := consumer.(type) {
+	case AsyncConsumer:
+		consume = func(ctx context.Context, msgs interface{}) error {
+			c.ConsumeAsync(ctx, msgs)
+			return nil
 		}
+	case BlockingConsumer:
+		consume = c.ConsumeBlocking
+	default:
+		return apiutil.ErrInvalidQueryParams
 	}
-	return nil
-}
 
-func handleSync(ctx context.Context, t transformers.Transformer, sc BlockingConsumer) handleFunc {
-	return func(msg *messaging.Message) error {
-		m := interface{}(msg)
-		var err error
-		if t != nil {
-			m, err = t.Transform(msg)
-			if err != nil {
-				return err
-			}
+	for _, subject := range cfg.SubscriberCfg.Subjects {
+		var handler messaging.MessageHandler = handle(ctx, transformer, consume, dl)
+		if cfg.BatchCfg.Size > 1 {
+			handler = newBatcher(ctx, transformer, consume, dl, cfg.BatchCfg.Size, linger, logger)
+		}
+		if err := pubsub.Subscribe(ctx, id, subject, handler); err != nil {
+			return err
 		}
-		return sc.ConsumeBlocking(ctx, m)
 	}
+	return nil
 }
 
-func handleAsync(ctx context.Context, t transformers.Transformer, ac AsyncConsumer) handleFunc {
+func handle(ctx context.Context, t transformers.Transformer, consume consumeFunc, dl *deadLetters) handleFunc {
 	return func(msg *messaging.Message) error {
+		if msg.Protocol == DeadLetterProtocol {
+			return nil
+		}
 		m := interface{}(msg)
 		var err error
 		if t != nil {
 			m, err = t.Transform(msg)
 			if err != nil {
-				return err
+				return dl.send(ctx, msg, 1, err)
 			}
 		}
-
-		ac.ConsumeAsync(ctx, m)
-		return nil
+		return dl.consume(ctx, msg, m, consume)
 	}
 }
 
@@ -108,9 +122,28 @@ type transformerConfig struct {
 	TimeFields  []json.TimeField `toml:"time_fields"`
 }
 
+// batchConfig configures batching of the consumed messages. Batching is
+// disabled if the batch size is 1.
+type batchConfig struct {
+	Size   int    `toml:"size"`
+	Linger string `toml:"linger"`
+}
+
+// dlqConfig configures retries and dead-lettering of the messages which
+// can't be transformed or consumed. Dead-lettering is disabled if the topic
+// is empty.
+type dlqConfig struct {
+	Topic       string `toml:"topic"`
+	MaxAttempts uint64 `toml:"max_attempts"`
+	Backoff     string `toml:"backoff"`
+	MaxBackoff  string `toml:"max_backoff"`
+}
+
 type config struct {
 	SubscriberCfg  subscriberConfig  `toml:"subscriber"`
 	TransformerCfg transformerConfig `toml:"transformer"`
+	BatchCfg       batchConfig       `toml:"batch"`
+	DLQCfg         dlqConfig         `toml:"dlq"`
 }
 
 func loadConfig(configPath string) (config, error) {
@@ -122,6 +155,15 @@ func loadConfig(configPath string) (config, error) {
 			Format:      defFormat,
 			ContentType: defContentType,
 		},
+		BatchCfg: batchConfig{
+			Size:   defBatchSize,
+			Linger: defLinger,
+		},
+		DLQCfg: dlqConfig{
+			MaxAttempts: defMaxAttempts,
+			Backoff:     defBackoff,
+			MaxBackoff:  defMaxBackoff,
+		},
 	}
 
 	data, err := os.ReadFile(configPath)
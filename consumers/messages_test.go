@@ -0,0 +1,327 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subject = "channels.>"
+	chanID  = "chan"
+	payload = `[{"bn":"base-name","bt":100,"n":"temperature","v":17}]`
+)
+
+var errConsume = errors.New("failed to consume")
+
+type subscriber struct {
+	handler   messaging.MessageHandler
+	mu        sync.Mutex
+	published []*messaging.Message
+}
+
+func (s *subscriber) Publish(_ context.Context, _ string, msg *messaging.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.published = append(s.published, msg)
+	return nil
+}
+
+func (s *subscriber) deadLetters(t *testing.T) []consumers.DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ret []consumers.DeadLetter
+	for _, msg := range s.published {
+		var dl consumers.DeadLetter
+		require.Nil(t, json.Unmarshal(msg.Payload, &dl), "decoding dead letter expected to succeed")
+		assert.Equal(t, consumers.DeadLetterProtocol, msg.Protocol, fmt.Sprintf("expected dead letter protocol got %s", msg.Protocol))
+		ret = append(ret, dl)
+	}
+	return ret
+}
+
+func (s *subscriber) Subscribe(_ context.Context, _, _ string, handler messaging.MessageHandler) error {
+	s.handler = handler
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(context.Context, string, string) error {
+	return s.handler.Cancel()
+}
+
+func (s *subscriber) Close() error {
+	return nil
+}
+
+// consumer records consumed batches and fails batches containing a message
+// published by the invalid publisher.
+type consumer struct {
+	mu      sync.Mutex
+	batches [][]senml.Message
+}
+
+func (c *consumer) ConsumeBlocking(_ context.Context, messages interface{}) error {
+	msgs := messages.([]senml.Message)
+	for _, msg := range msgs {
+		if msg.Publisher == "invalid" {
+			return errConsume
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.batches = append(c.batches, msgs)
+	return nil
+}
+
+func (c *consumer) sizes() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ret []int
+	for _, b := range c.batches {
+		ret = append(ret, len(b))
+	}
+	return ret
+}
+
+// acks collects the acknowledgement results.
+type acks struct {
+	mu   sync.Mutex
+	errs []error
+	done chan struct{}
+}
+
+func newAcks() *acks {
+	return &acks{done: make(chan struct{}, 100)}
+}
+
+func (a *acks) ack(err error) {
+	a.mu.Lock()
+	a.errs = append(a.errs, err)
+	a.mu.Unlock()
+	a.done <- struct{}{}
+}
+
+func (a *acks) wait(t *testing.T, n int) []error {
+	for i := 0; i < n; i++ {
+		select {
+		case <-a.done:
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d acknowledgements, got %d", n, i)
+		}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.errs
+}
+
+func writeConfig(t *testing.T, batch string) string {
+	cfg := fmt.Sprintf(`[subscriber]
+subjects = ["%s"]
+
+[transformer]
+format = "senml"
+content_type = "application/senml+json"
+
+%s`, subject, batch)
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.Nil(t, os.WriteFile(path, []byte(cfg), 0o644), "writing config expected to succeed")
+	return path
+}
+
+func message(publisher string) *messaging.Message {
+	return &messaging.Message{
+		Channel:   chanID,
+		Publisher: publisher,
+		Protocol:  "http",
+		Payload:   []byte(payload),
+	}
+}
+
+func TestStartBatch(t *testing.T) {
+	cases := []struct {
+		desc       string
+		batch      string
+		publishers []string
+		sizes      []int
+		errs       []error
+	}{
+		{
+			desc:       "consume full batch",
+			batch:      "[batch]\nsize = 3\nlinger = \"1h\"",
+			publishers: []string{"pub", "pub", "pub"},
+			sizes:      []int{3},
+			errs:       []error{nil, nil, nil},
+		},
+		{
+			desc:       "consume batch after linger time",
+			batch:      "[batch]\nsize = 10\nlinger = \"10ms\"",
+			publishers: []string{"pub", "pub"},
+			sizes:      []int{2},
+			errs:       []error{nil, nil},
+		},
+		{
+			desc:       "consume batch with invalid message",
+			batch:      "[batch]\nsize = 3\nlinger = \"1h\"\n[dlq]\nmax_attempts = 1",
+			publishers: []string{"pub", "invalid", "pub"},
+			sizes:      []int{1, 1},
+			errs:       []error{nil, errConsume, nil},
+		},
+		{
+			desc:       "consume without batching",
+			batch:      "",
+			publishers: []string{"pub", "pub"},
+			sizes:      []int{1, 1},
+		},
+	}
+
+	for _, tc := range cases {
+		sub := &subscriber{}
+		c := &consumer{}
+		err := consumers.Start(context.Background(), "id", sub, c, writeConfig(t, tc.batch), logger.NewMock())
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		ah, ok := sub.handler.(messaging.AckHandler)
+		if tc.errs == nil {
+			assert.False(t, ok, fmt.Sprintf("%s: expected handler without acknowledgement", tc.desc))
+			for _, pub := range tc.publishers {
+				err := sub.handler.Handle(message(pub))
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			}
+			assert.Equal(t, tc.sizes, c.sizes(), fmt.Sprintf("%s: expected batch sizes %v got %v", tc.desc, tc.sizes, c.sizes()))
+			continue
+		}
+
+		require.True(t, ok, fmt.Sprintf("%s: expected handler with acknowledgement", tc.desc))
+		a := newAcks()
+		for _, pub := range tc.publishers {
+			ah.HandleAck(message(pub), a.ack)
+		}
+		errs := a.wait(t, len(tc.publishers))
+		assert.Equal(t, tc.errs, errs, fmt.Sprintf("%s: expected acknowledgements %v got %v", tc.desc, tc.errs, errs))
+		assert.Equal(t, tc.sizes, c.sizes(), fmt.Sprintf("%s: expected batch sizes %v got %v", tc.desc, tc.sizes, c.sizes()))
+	}
+}
+
+func TestStartBatchCancel(t *testing.T) {
+	sub := &subscriber{}
+	c := &consumer{}
+	err := consumers.Start(context.Background(), "id", sub, c, writeConfig(t, "[batch]\nsize = 10\nlinger = \"1h\""), logger.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	ah := sub.handler.(messaging.AckHandler)
+	a := newAcks()
+	ah.HandleAck(message("pub"), a.ack)
+	assert.Empty(t, c.sizes(), "expected no consumed batches before unsubscribing")
+
+	err = sub.Unsubscribe(context.Background(), "id", subject)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	errs := a.wait(t, 1)
+	assert.Equal(t, []error{nil}, errs, fmt.Sprintf("expected acknowledgement without error got %v", errs))
+	assert.Equal(t, []int{1}, c.sizes(), fmt.Sprintf("expected pending batch to be consumed got %v", c.sizes()))
+}
+
+func TestStartInvalidConfig(t *testing.T) {
+	cases := map[string]string{
+		"invalid batch size":    "[batch]\nsize = 0",
+		"invalid linger time":   "[batch]\nsize = 10\nlinger = \"soon\"",
+		"invalid max attempts":  "[dlq]\nmax_attempts = 0",
+		"invalid retry backoff": "[dlq]\nbackoff = \"1s\"\nmax_backoff = \"1ms\"",
+	}
+
+	for desc, batch := range cases {
+		err := consumers.Start(context.Background(), "id", &subscriber{}, &consumer{}, writeConfig(t, batch), logger.NewMock())
+		assert.NotNil(t, err, fmt.Sprintf("%s: expected error", desc))
+	}
+}
+
+func TestStartDeadLetters(t *testing.T) {
+	dlq := "[dlq]\ntopic = \"dlq\"\nmax_attempts = 2\nbackoff = \"1ms\"\nmax_backoff = \"2ms\""
+	cases := []struct {
+		desc       string
+		batch      string
+		publishers []string
+		payload    string
+		letters    []consumers.DeadLetter
+	}{
+		{
+			desc:       "dead-letter message which can't be consumed",
+			publishers: []string{"pub", "invalid"},
+			payload:    payload,
+			letters:    []consumers.DeadLetter{{Consumer: "id", Reason: errConsume.Error(), Attempts: 2}},
+		},
+		{
+			desc:       "dead-letter message from batch which can't be consumed",
+			batch:      "[batch]\nsize = 3\nlinger = \"1h\"",
+			publishers: []string{"pub", "invalid", "pub"},
+			payload:    payload,
+			letters:    []consumers.DeadLetter{{Consumer: "id", Reason: errConsume.Error(), Attempts: 2}},
+		},
+		{
+			desc:       "dead-letter message which can't be transformed",
+			publishers: []string{"pub"},
+			payload:    "invalid",
+			letters:    []consumers.DeadLetter{{Consumer: "id", Attempts: 1}},
+		},
+	}
+
+	for _, tc := range cases {
+		sub := &subscriber{}
+		err := consumers.Start(context.Background(), "id", sub, &consumer{}, writeConfig(t, tc.batch+"\n"+dlq), logger.NewMock())
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		a := newAcks()
+		for _, pub := range tc.publishers {
+			msg := message(pub)
+			msg.Payload = []byte(tc.payload)
+			if ah, ok := sub.handler.(messaging.AckHandler); ok {
+				ah.HandleAck(msg, a.ack)
+				continue
+			}
+			a.ack(sub.handler.Handle(msg))
+		}
+		for _, err := range a.wait(t, len(tc.publishers)) {
+			assert.Nil(t, err, fmt.Sprintf("%s: expected dead-lettered message to be acknowledged got %s", tc.desc, err))
+		}
+
+		letters := sub.deadLetters(t)
+		require.Len(t, letters, len(tc.letters), fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, len(tc.letters), len(letters)))
+		for i, letter := range letters {
+			assert.Equal(t, tc.letters[i].Consumer, letter.Consumer, fmt.Sprintf("%s: expected consumer %s got %s", tc.desc, tc.letters[i].Consumer, letter.Consumer))
+			assert.Equal(t, tc.letters[i].Attempts, letter.Attempts, fmt.Sprintf("%s: expected %d attempts got %d", tc.desc, tc.letters[i].Attempts, letter.Attempts))
+			assert.NotEmpty(t, letter.Reason, fmt.Sprintf("%s: expected dead letter reason", tc.desc))
+			if tc.letters[i].Reason != "" {
+				assert.Equal(t, tc.letters[i].Reason, letter.Reason, fmt.Sprintf("%s: expected reason %s got %s", tc.desc, tc.letters[i].Reason, letter.Reason))
+			}
+			assert.Equal(t, []byte(tc.payload), letter.Message.Payload, fmt.Sprintf("%s: expected original payload in dead letter", tc.desc))
+		}
+	}
+}
+
+func TestStartIgnoreDeadLetters(t *testing.T) {
+	sub := &subscriber{}
+	c := &consumer{}
+	err := consumers.Start(context.Background(), "id", sub, c, writeConfig(t, ""), logger.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	msg := message("pub")
+	msg.Protocol = consumers.DeadLetterProtocol
+	err = sub.handler.Handle(msg)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, c.sizes(), "expected dead letters not to be consumed")
+}
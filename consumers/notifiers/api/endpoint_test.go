@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/consumers/notifiers"
 	httpapi "github.com/mainflux/mainflux/consumers/notifiers/api"
@@ -70,7 +71,7 @@ func newService(tokens map[string]string) notifiers.Service {
 	idp := uuid.NewMock()
 	notif := mocks.NewNotifier()
 	from := "exampleFrom"
-	return notifiers.New(auth, repo, idp, notif, from)
+	return notifiers.New(auth, repo, idp, notif, notifiers.NewLimiter(), from)
 }
 
 func newServer(svc notifiers.Service) *httptest.Server {
@@ -89,15 +90,27 @@ func TestCreate(t *testing.T) {
 	ss := newServer(svc)
 	defer ss.Close()
 
-	sub := notifiers.Subscription{
+	sub := subReq{
 		Topic:   topic,
 		Contact: contact1,
 	}
 
 	data := toJSON(sub)
 
-	emptyTopic := toJSON(notifiers.Subscription{Contact: contact1})
-	emptyContact := toJSON(notifiers.Subscription{Topic: "topic123"})
+	emptyTopic := toJSON(subReq{Contact: contact1})
+	emptyContact := toJSON(subReq{Topic: "topic123"})
+	withLimits := toJSON(subReq{
+		Topic:       "limits",
+		Contact:     contact1,
+		Throttle:    &throttle{Limit: 5, Window: "1m"},
+		DedupWindow: "10m",
+		QuietHours:  &quietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Belgrade"},
+	})
+	invalidWindow := toJSON(subReq{Topic: "limits", Contact: contact2, Throttle: &throttle{Limit: 5, Window: "minute"}})
+	missingWindow := toJSON(subReq{Topic: "limits", Contact: contact2, Throttle: &throttle{Limit: 5}})
+	invalidDedup := toJSON(subReq{Topic: "limits", Contact: contact2, DedupWindow: "-1m"})
+	invalidQuietHours := toJSON(subReq{Topic: "limits", Contact: contact2, QuietHours: &quietHours{Start: "25:00", End: "07:00"}})
+	invalidTimezone := toJSON(subReq{Topic: "limits", Contact: contact2, QuietHours: &quietHours{Start: "22:00", End: "07:00", Timezone: "Mars/Olympus"}})
 
 	cases := []struct {
 		desc        string
@@ -123,6 +136,54 @@ func TestCreate(t *testing.T) {
 			status:      http.StatusConflict,
 			location:    "",
 		},
+		{
+			desc:        "add with notification limits",
+			req:         withLimits,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/subscriptions/%s%012d", uuid.Prefix, 3),
+		},
+		{
+			desc:        "add with invalid throttle window",
+			req:         invalidWindow,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with throttle limit without window",
+			req:         missingWindow,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with negative de-duplication window",
+			req:         invalidDedup,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with invalid quiet hours",
+			req:         invalidQuietHours,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with invalid quiet hours timezone",
+			req:         invalidTimezone,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
 		{
 			desc:        "add with empty topic",
 			req:         emptyTopic,
@@ -197,16 +258,25 @@ func TestView(t *testing.T) {
 	defer ss.Close()
 
 	sub := notifiers.Subscription{
-		Topic:   topic,
-		Contact: contact1,
+		Topic:    topic,
+		Contact:  contact1,
+		Throttle: notifiers.Throttle{Limit: 5, Window: time.Minute},
+		Dedup:    10 * time.Minute,
+		QuietHours: notifiers.QuietHours{
+			Start: "22:00",
+			End:   "07:00",
+		},
 	}
 	id, err := svc.CreateSubscription(context.Background(), token, sub)
 	assert.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
 	sr := subRes{
-		ID:      id,
-		OwnerID: email,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
+		ID:          id,
+		OwnerID:     email,
+		Contact:     sub.Contact,
+		Topic:       sub.Topic,
+		Throttle:    &throttle{Limit: 5, Window: "1m0s"},
+		DedupWindow: "10m0s",
+		QuietHours:  &quietHours{Start: "22:00", End: "07:00"},
 	}
 	data := toJSON(sr)
 
@@ -461,12 +531,35 @@ func makeQuery(m map[string]string) string {
 	return ""
 }
 
+type throttle struct {
+	Limit  uint   `json:"limit"`
+	Window string `json:"window"`
+}
+
+type quietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+type subReq struct {
+	Topic       string      `json:"topic,omitempty"`
+	Contact     string      `json:"contact,omitempty"`
+	Throttle    *throttle   `json:"throttle,omitempty"`
+	DedupWindow string      `json:"dedup_window,omitempty"`
+	QuietHours  *quietHours `json:"quiet_hours,omitempty"`
+}
+
 type subRes struct {
-	ID      string `json:"id"`
-	OwnerID string `json:"owner_id"`
-	Contact string `json:"contact"`
-	Topic   string `json:"topic"`
+	ID          string      `json:"id"`
+	OwnerID     string      `json:"owner_id"`
+	Contact     string      `json:"contact"`
+	Topic       string      `json:"topic"`
+	Throttle    *throttle   `json:"throttle,omitempty"`
+	DedupWindow string      `json:"dedup_window,omitempty"`
+	QuietHours  *quietHours `json:"quiet_hours,omitempty"`
 }
+
 type page struct {
 	Offset        uint     `json:"offset"`
 	Limit         int      `json:"limit"`
@@ -11,6 +11,8 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ notifiers.Service = (*metricsMiddleware)(nil)
@@ -79,3 +81,45 @@ func (ms *metricsMiddleware) ConsumeBlocking(ctx context.Context, msg interface{
 
 	return ms.svc.ConsumeBlocking(ctx, msg)
 }
+
+var _ notifiers.Limiter = (*limiterMetricsMiddleware)(nil)
+
+type limiterMetricsMiddleware struct {
+	suppressed metrics.Counter
+	limiter    notifiers.Limiter
+}
+
+// LimiterMetricsMiddleware instruments limiter by counting the suppressed
+// notifications per suppression reason.
+func LimiterMetricsMiddleware(limiter notifiers.Limiter, suppressed metrics.Counter) notifiers.Limiter {
+	return &limiterMetricsMiddleware{
+		suppressed: suppressed,
+		limiter:    limiter,
+	}
+}
+
+// Allow instruments Allow method with metrics.
+func (lm *limiterMetricsMiddleware) Allow(sub notifiers.Subscription, msg *messaging.Message, now time.Time) error {
+	err := lm.limiter.Allow(sub, msg, now)
+	switch {
+	case err == nil:
+	case errors.Contains(err, notifiers.ErrThrottled):
+		lm.suppressed.With("reason", "throttle").Add(1)
+	case errors.Contains(err, notifiers.ErrDuplicate):
+		lm.suppressed.With("reason", "duplicate").Add(1)
+	case errors.Contains(err, notifiers.ErrQuietHours):
+		lm.suppressed.With("reason", "quiet_hours").Add(1)
+	}
+
+	return err
+}
+
+// Cancel cancels the notification using the wrapped limiter.
+func (lm *limiterMetricsMiddleware) Cancel(sub notifiers.Subscription, msg *messaging.Message, at time.Time) {
+	lm.limiter.Cancel(sub, msg, at)
+}
+
+// Remove removes the subscription state using the wrapped limiter.
+func (lm *limiterMetricsMiddleware) Remove(subID string) {
+	lm.limiter.Remove(subID)
+}
@@ -3,12 +3,66 @@
 
 package api
 
-import "github.com/mainflux/mainflux/internal/apiutil"
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+type throttleReq struct {
+	Limit  uint   `json:"limit"`
+	Window string `json:"window"`
+}
+
+type quietHoursReq struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone,omitempty"`
+}
 
 type createSubReq struct {
-	token   string
-	Topic   string `json:"topic,omitempty"`
-	Contact string `json:"contact,omitempty"`
+	token       string
+	Topic       string         `json:"topic,omitempty"`
+	Contact     string         `json:"contact,omitempty"`
+	Secret      string         `json:"secret,omitempty"`
+	Throttle    *throttleReq   `json:"throttle,omitempty"`
+	DedupWindow string         `json:"dedup_window,omitempty"`
+	QuietHours  *quietHoursReq `json:"quiet_hours,omitempty"`
+}
+
+// subscription converts the request to the subscription, parsing the
+// notification limits.
+func (req createSubReq) subscription() (notifiers.Subscription, error) {
+	sub := notifiers.Subscription{
+		Topic:   req.Topic,
+		Contact: req.Contact,
+		Secret:  req.Secret,
+	}
+	if req.Throttle != nil {
+		window, err := time.ParseDuration(req.Throttle.Window)
+		if err != nil {
+			return notifiers.Subscription{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		sub.Throttle = notifiers.Throttle{Limit: req.Throttle.Limit, Window: window}
+	}
+	if req.DedupWindow != "" {
+		dedup, err := time.ParseDuration(req.DedupWindow)
+		if err != nil {
+			return notifiers.Subscription{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		sub.Dedup = dedup
+	}
+	if req.QuietHours != nil {
+		sub.QuietHours = notifiers.QuietHours{
+			Start:    req.QuietHours.Start,
+			End:      req.QuietHours.End,
+			Timezone: req.QuietHours.Timezone,
+		}
+	}
+
+	return sub, sub.Validate()
 }
 
 func (req createSubReq) validate() error {
@@ -21,6 +75,9 @@ func (req createSubReq) validate() error {
 	if req.Contact == "" {
 		return apiutil.ErrInvalidContact
 	}
+	if _, err := req.subscription(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/notifiers"
 )
 
 var (
@@ -35,11 +36,52 @@ func (res createSubRes) Empty() bool {
 	return true
 }
 
+type throttleRes struct {
+	Limit  uint   `json:"limit"`
+	Window string `json:"window"`
+}
+
+type quietHoursRes struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
 type viewSubRes struct {
-	ID      string `json:"id"`
-	OwnerID string `json:"owner_id"`
-	Contact string `json:"contact"`
-	Topic   string `json:"topic"`
+	ID          string         `json:"id"`
+	OwnerID     string         `json:"owner_id"`
+	Contact     string         `json:"contact"`
+	Topic       string         `json:"topic"`
+	Throttle    *throttleRes   `json:"throttle,omitempty"`
+	DedupWindow string         `json:"dedup_window,omitempty"`
+	QuietHours  *quietHoursRes `json:"quiet_hours,omitempty"`
+}
+
+func newViewSubRes(sub notifiers.Subscription) viewSubRes {
+	res := viewSubRes{
+		ID:      sub.ID,
+		OwnerID: sub.OwnerID,
+		Contact: sub.Contact,
+		Topic:   sub.Topic,
+	}
+	if sub.Throttle.Limit > 0 {
+		res.Throttle = &throttleRes{
+			Limit:  sub.Throttle.Limit,
+			Window: sub.Throttle.Window.String(),
+		}
+	}
+	if sub.Dedup > 0 {
+		res.DedupWindow = sub.Dedup.String()
+	}
+	if sub.QuietHours.Start != "" {
+		res.QuietHours = &quietHoursRes{
+			Start:    sub.QuietHours.Start,
+			End:      sub.QuietHours.End,
+			Timezone: sub.QuietHours.Timezone,
+		}
+	}
+
+	return res
 }
 
 func (res viewSubRes) Code() int {
@@ -0,0 +1,236 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+	// Embedded time zone database makes quiet hours time zones available
+	// in the minimal container images.
+	_ "time/tzdata"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const clockFormat = "15:04"
+
+var (
+	// ErrThrottled indicates that the subscription reached its notifications limit.
+	ErrThrottled = errors.New("notification throttled")
+
+	// ErrDuplicate indicates that the same payload was already notified.
+	ErrDuplicate = errors.New("duplicate notification")
+
+	// ErrQuietHours indicates that the subscription is in its quiet hours.
+	ErrQuietHours = errors.New("notification in quiet hours")
+
+	errThrottle   = errors.New("throttle limit and window must be set together")
+	errDedup      = errors.New("de-duplication window must not be negative")
+	errQuietHours = errors.New("invalid quiet hours")
+)
+
+// Limiter decides whether the subscription is notified about the message.
+type Limiter interface {
+	// Allow returns nil if the subscription should be notified about the
+	// message at the given time. Otherwise, it returns ErrThrottled,
+	// ErrDuplicate, or ErrQuietHours. Allowed notifications are counted
+	// towards the subscription limits.
+	Allow(sub Subscription, msg *messaging.Message, now time.Time) error
+
+	// Cancel stops counting the notification allowed at the given time,
+	// since it failed to be sent.
+	Cancel(sub Subscription, msg *messaging.Message, at time.Time)
+
+	// Remove drops the state of the removed subscription.
+	Remove(subID string)
+}
+
+// sweepInterval is the interval of dropping the state of the subscriptions
+// which no longer affects their notifications.
+const sweepInterval = time.Minute
+
+var _ Limiter = (*limiter)(nil)
+
+type limiter struct {
+	mu    sync.Mutex
+	subs  map[string]*subState
+	swept time.Time
+}
+
+// subState holds the recent notifications of a subscription, which affect
+// its notifications until the state expires.
+type subState struct {
+	sent     []time.Time
+	payloads map[[sha256.Size]byte]time.Time
+	expires  time.Time
+}
+
+// NewLimiter returns the Limiter which keeps the notifications state in memory.
+func NewLimiter() Limiter {
+	return &limiter{subs: make(map[string]*subState)}
+}
+
+func (l *limiter) Allow(sub Subscription, msg *messaging.Message, now time.Time) error {
+	if sub.QuietHours.active(now) {
+		return ErrQuietHours
+	}
+	if sub.Throttle.Limit == 0 && sub.Dedup == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+	st, ok := l.subs[sub.ID]
+	if !ok {
+		st = &subState{payloads: make(map[[sha256.Size]byte]time.Time)}
+		l.subs[sub.ID] = st
+	}
+
+	sum := sha256.Sum256(msg.Payload)
+	if sub.Dedup > 0 {
+		for k, t := range st.payloads {
+			if now.Sub(t) >= sub.Dedup {
+				delete(st.payloads, k)
+			}
+		}
+		if _, ok := st.payloads[sum]; ok {
+			return ErrDuplicate
+		}
+	}
+
+	if sub.Throttle.Limit > 0 {
+		i := 0
+		for i < len(st.sent) && now.Sub(st.sent[i]) >= sub.Throttle.Window {
+			i++
+		}
+		st.sent = st.sent[i:]
+		if uint(len(st.sent)) >= sub.Throttle.Limit {
+			return ErrThrottled
+		}
+		st.sent = append(st.sent, now)
+		if exp := now.Add(sub.Throttle.Window); exp.After(st.expires) {
+			st.expires = exp
+		}
+	}
+	if sub.Dedup > 0 {
+		st.payloads[sum] = now
+		if exp := now.Add(sub.Dedup); exp.After(st.expires) {
+			st.expires = exp
+		}
+	}
+
+	return nil
+}
+
+func (l *limiter) Cancel(sub Subscription, msg *messaging.Message, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	st, ok := l.subs[sub.ID]
+	if !ok {
+		return
+	}
+
+	for i := len(st.sent) - 1; i >= 0; i-- {
+		if st.sent[i].Equal(at) {
+			st.sent = append(st.sent[:i], st.sent[i+1:]...)
+			break
+		}
+	}
+	sum := sha256.Sum256(msg.Payload)
+	if t, ok := st.payloads[sum]; ok && t.Equal(at) {
+		delete(st.payloads, sum)
+	}
+}
+
+func (l *limiter) Remove(subID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.subs, subID)
+}
+
+// sweep drops the expired state of the subscriptions, at most once per
+// sweep interval.
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < sweepInterval {
+		return
+	}
+	for id, st := range l.subs {
+		if !now.Before(st.expires) {
+			delete(l.subs, id)
+		}
+	}
+	l.swept = now
+}
+
+// Validate returns an error if the throttle is invalid.
+func (t Throttle) Validate() error {
+	if (t.Limit == 0) != (t.Window == 0) || t.Window < 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, errThrottle)
+	}
+
+	return nil
+}
+
+// Validate returns an error if the quiet hours are invalid.
+func (qh QuietHours) Validate() error {
+	if qh.Start == "" && qh.End == "" && qh.Timezone == "" {
+		return nil
+	}
+	if _, err := time.Parse(clockFormat, qh.Start); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, errors.Wrap(errQuietHours, err))
+	}
+	if _, err := time.Parse(clockFormat, qh.End); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, errors.Wrap(errQuietHours, err))
+	}
+	if _, err := time.LoadLocation(qh.Timezone); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, errors.Wrap(errQuietHours, err))
+	}
+
+	return nil
+}
+
+// Validate returns an error if the subscription notification limits are
+// invalid.
+func (sub Subscription) Validate() error {
+	if err := sub.Throttle.Validate(); err != nil {
+		return err
+	}
+	if sub.Dedup < 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, errDedup)
+	}
+
+	return sub.QuietHours.Validate()
+}
+
+// active reports whether the time is within the quiet hours.
+func (qh QuietHours) active(now time.Time) bool {
+	if qh.Start == "" {
+		return false
+	}
+	start, err := time.Parse(clockFormat, qh.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(clockFormat, qh.End)
+	if err != nil {
+		return false
+	}
+	loc, err := time.LoadLocation(qh.Timezone)
+	if err != nil {
+		return false
+	}
+
+	now = now.In(loc)
+	minute := now.Hour()*60 + now.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from <= to {
+		return minute >= from && minute < to
+	}
+
+	return minute >= from || minute < to
+}
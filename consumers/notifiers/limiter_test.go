@@ -0,0 +1,203 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	msg := &messaging.Message{Payload: []byte(`{"v":1}`)}
+	other := &messaging.Message{Payload: []byte(`{"v":2}`)}
+
+	throttled := notifiers.Subscription{ID: "throttled", Throttle: notifiers.Throttle{Limit: 2, Window: time.Minute}}
+	dedup := notifiers.Subscription{ID: "dedup", Dedup: time.Minute}
+	quiet := notifiers.Subscription{ID: "quiet", QuietHours: notifiers.QuietHours{Start: "11:30", End: "12:30"}}
+	overnight := notifiers.Subscription{ID: "overnight", QuietHours: notifiers.QuietHours{Start: "22:00", End: "07:00", Timezone: "Asia/Tokyo"}}
+
+	cases := []struct {
+		desc string
+		sub  notifiers.Subscription
+		msg  *messaging.Message
+		time time.Time
+		err  error
+	}{
+		{
+			desc: "allow unlimited subscription",
+			sub:  notifiers.Subscription{ID: "unlimited"},
+			msg:  msg,
+			time: now,
+		},
+		{
+			desc: "allow first throttled notification",
+			sub:  throttled,
+			msg:  msg,
+			time: now,
+		},
+		{
+			desc: "allow second throttled notification",
+			sub:  throttled,
+			msg:  other,
+			time: now.Add(10 * time.Second),
+		},
+		{
+			desc: "throttle notification over the limit",
+			sub:  throttled,
+			msg:  msg,
+			time: now.Add(20 * time.Second),
+			err:  notifiers.ErrThrottled,
+		},
+		{
+			desc: "allow throttled notification after the window",
+			sub:  throttled,
+			msg:  msg,
+			time: now.Add(time.Minute),
+		},
+		{
+			desc: "allow first payload",
+			sub:  dedup,
+			msg:  msg,
+			time: now,
+		},
+		{
+			desc: "suppress duplicate payload",
+			sub:  dedup,
+			msg:  msg,
+			time: now.Add(30 * time.Second),
+			err:  notifiers.ErrDuplicate,
+		},
+		{
+			desc: "allow different payload",
+			sub:  dedup,
+			msg:  other,
+			time: now.Add(30 * time.Second),
+		},
+		{
+			desc: "allow duplicate payload after the window",
+			sub:  dedup,
+			msg:  msg,
+			time: now.Add(time.Minute),
+		},
+		{
+			desc: "suppress notification in quiet hours",
+			sub:  quiet,
+			msg:  msg,
+			time: now,
+			err:  notifiers.ErrQuietHours,
+		},
+		{
+			desc: "allow notification after quiet hours",
+			sub:  quiet,
+			msg:  msg,
+			time: now.Add(30 * time.Minute),
+		},
+		{
+			desc: "suppress notification in overnight quiet hours",
+			sub:  overnight,
+			msg:  msg,
+			// 12:00 UTC is 21:00 in Tokyo.
+			time: now.Add(2 * time.Hour),
+			err:  notifiers.ErrQuietHours,
+		},
+		{
+			desc: "allow notification outside overnight quiet hours",
+			sub:  overnight,
+			msg:  msg,
+			time: now,
+		},
+	}
+
+	limiter := notifiers.NewLimiter()
+	for _, tc := range cases {
+		err := limiter.Allow(tc.sub, tc.msg, tc.time)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestLimiterCancel(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	msg := &messaging.Message{Payload: []byte(`{"v":1}`)}
+	sub := notifiers.Subscription{ID: "limited", Throttle: notifiers.Throttle{Limit: 1, Window: time.Minute}, Dedup: time.Minute}
+
+	limiter := notifiers.NewLimiter()
+	err := limiter.Allow(sub, msg, now)
+	assert.Nil(t, err, fmt.Sprintf("allow notification: unexpected error %s\n", err))
+	limiter.Cancel(sub, msg, now)
+	err = limiter.Allow(sub, msg, now.Add(time.Second))
+	assert.Nil(t, err, fmt.Sprintf("allow notification after cancel: unexpected error %s\n", err))
+	err = limiter.Allow(sub, msg, now.Add(2*time.Second))
+	assert.True(t, errors.Contains(err, notifiers.ErrDuplicate), fmt.Sprintf("allow sent notification: expected %s got %s\n", notifiers.ErrDuplicate, err))
+}
+
+func TestLimiterRemove(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	msg := &messaging.Message{Payload: []byte(`{"v":1}`)}
+	sub := notifiers.Subscription{ID: "removed", Throttle: notifiers.Throttle{Limit: 1, Window: time.Hour}}
+
+	limiter := notifiers.NewLimiter()
+	err := limiter.Allow(sub, msg, now)
+	assert.Nil(t, err, fmt.Sprintf("allow notification: unexpected error %s\n", err))
+	limiter.Remove(sub.ID)
+	err = limiter.Allow(sub, msg, now.Add(time.Second))
+	assert.Nil(t, err, fmt.Sprintf("allow notification of recreated subscription: unexpected error %s\n", err))
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		sub  notifiers.Subscription
+		err  error
+	}{
+		{
+			desc: "validate subscription without limits",
+			sub:  notifiers.Subscription{},
+		},
+		{
+			desc: "validate subscription with limits",
+			sub: notifiers.Subscription{
+				Throttle:   notifiers.Throttle{Limit: 1, Window: time.Second},
+				Dedup:      time.Minute,
+				QuietHours: notifiers.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Belgrade"},
+			},
+		},
+		{
+			desc: "validate throttle without window",
+			sub:  notifiers.Subscription{Throttle: notifiers.Throttle{Limit: 1}},
+			err:  errors.ErrMalformedEntity,
+		},
+		{
+			desc: "validate throttle without limit",
+			sub:  notifiers.Subscription{Throttle: notifiers.Throttle{Window: time.Second}},
+			err:  errors.ErrMalformedEntity,
+		},
+		{
+			desc: "validate negative de-duplication window",
+			sub:  notifiers.Subscription{Dedup: -time.Second},
+			err:  errors.ErrMalformedEntity,
+		},
+		{
+			desc: "validate quiet hours without end",
+			sub:  notifiers.Subscription{QuietHours: notifiers.QuietHours{Start: "22:00"}},
+			err:  errors.ErrMalformedEntity,
+		},
+		{
+			desc: "validate quiet hours with invalid timezone",
+			sub:  notifiers.Subscription{QuietHours: notifiers.QuietHours{Start: "22:00", End: "07:00", Timezone: "invalid"}},
+			err:  errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.sub.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -5,12 +5,16 @@ package mocks
 
 import (
 	"github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ notifiers.Notifier = (*notifier)(nil)
 
-const invalidSender = "invalid@example.com"
+const (
+	invalidSender  = "invalid@example.com"
+	invalidContact = "invalid-contact"
+)
 
 type notifier struct{}
 
@@ -19,11 +23,18 @@ func NewNotifier() notifiers.Notifier {
 	return notifier{}
 }
 
-func (n notifier) Notify(from string, to []string, msg *messaging.Message) error {
+func (n notifier) Notify(from string, to []notifiers.Subscription, msg *messaging.Message) error {
 	for _, t := range to {
-		if t == invalidSender {
+		if t.Contact == invalidSender {
 			return notifiers.ErrNotify
 		}
 	}
 	return nil
 }
+
+func (n notifier) ValidateContact(contact string) error {
+	if contact == invalidContact {
+		return errors.ErrMalformedEntity
+	}
+	return nil
+}
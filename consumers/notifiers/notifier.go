@@ -15,6 +15,20 @@ var ErrNotify = errors.New("error sending notification")
 // Notifier represents an API for sending notification.
 type Notifier interface {
 	// Notify method is used to send notification for the
-	// received message to the provided list of receivers.
-	Notify(from string, to []string, msg *messaging.Message) error
+	// received message to the contacts of the provided subscriptions.
+	Notify(from string, to []Subscription, msg *messaging.Message) error
+
+	// ValidateContact returns an error if the notifier can't send
+	// notifications to the contact.
+	ValidateContact(contact string) error
+}
+
+// Contacts returns the contacts of the subscriptions.
+func Contacts(subs []Subscription) []string {
+	ret := make([]string, len(subs))
+	for i, sub := range subs {
+		ret[i] = sub.Contact
+	}
+
+	return ret
 }
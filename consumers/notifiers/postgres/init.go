@@ -23,6 +23,36 @@ func Migration() *migrate.MemoryMigrationSource {
 					"DROP TABLE IF EXISTS subscriptions",
 				},
 			},
+			{
+				Id: "subscriptions_2",
+				Up: []string{
+					`ALTER TABLE subscriptions
+                        ADD COLUMN IF NOT EXISTS throttle_limit  BIGINT NOT NULL DEFAULT 0,
+                        ADD COLUMN IF NOT EXISTS throttle_window BIGINT NOT NULL DEFAULT 0,
+                        ADD COLUMN IF NOT EXISTS dedup_window    BIGINT NOT NULL DEFAULT 0,
+                        ADD COLUMN IF NOT EXISTS quiet_start     VARCHAR(5) NOT NULL DEFAULT '',
+                        ADD COLUMN IF NOT EXISTS quiet_end       VARCHAR(5) NOT NULL DEFAULT '',
+                        ADD COLUMN IF NOT EXISTS quiet_timezone  VARCHAR(254) NOT NULL DEFAULT ''`,
+				},
+				Down: []string{
+					`ALTER TABLE subscriptions
+                        DROP COLUMN IF EXISTS throttle_limit,
+                        DROP COLUMN IF EXISTS throttle_window,
+                        DROP COLUMN IF EXISTS dedup_window,
+                        DROP COLUMN IF EXISTS quiet_start,
+                        DROP COLUMN IF EXISTS quiet_end,
+                        DROP COLUMN IF EXISTS quiet_timezone`,
+				},
+			},
+			{
+				Id: "subscriptions_3",
+				Up: []string{
+					`ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS secret TEXT NOT NULL DEFAULT ''`,
+				},
+				Down: []string{
+					`ALTER TABLE subscriptions DROP COLUMN IF EXISTS secret`,
+				},
+			},
 		},
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -29,14 +30,10 @@ func New(db Database) notifiers.SubscriptionsRepository {
 }
 
 func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscription) (string, error) {
-	q := `INSERT INTO subscriptions (id, owner_id, contact, topic) VALUES (:id, :owner_id, :contact, :topic) RETURNING id`
+	q := `INSERT INTO subscriptions (id, owner_id, contact, topic, secret, throttle_limit, throttle_window, dedup_window, quiet_start, quiet_end, quiet_timezone)
+		VALUES (:id, :owner_id, :contact, :topic, :secret, :throttle_limit, :throttle_window, :dedup_window, :quiet_start, :quiet_end, :quiet_timezone) RETURNING id`
 
-	dbSub := dbSubscription{
-		ID:      sub.ID,
-		OwnerID: sub.OwnerID,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
-	}
+	dbSub := toDBSub(sub)
 
 	row, err := repo.db.NamedQueryContext(ctx, q, dbSub)
 	if err != nil {
@@ -51,7 +48,7 @@ func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscripti
 }
 
 func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifiers.Subscription, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions WHERE id = $1`
+	q := `SELECT id, owner_id, contact, topic, secret, throttle_limit, throttle_window, dedup_window, quiet_start, quiet_end, quiet_timezone FROM subscriptions WHERE id = $1`
 	sub := dbSubscription{}
 	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&sub); err != nil {
 		if err == sql.ErrNoRows {
@@ -64,7 +61,7 @@ func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifier
 }
 
 func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.PageMetadata) (notifiers.Page, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions`
+	q := `SELECT id, owner_id, contact, topic, secret, throttle_limit, throttle_window, dedup_window, quiet_start, quiet_end, quiet_timezone FROM subscriptions`
 	args := make(map[string]interface{})
 	if pm.Topic != "" {
 		args["topic"] = pm.Topic
@@ -147,10 +144,33 @@ func total(ctx context.Context, db Database, query string, params interface{}) (
 }
 
 type dbSubscription struct {
-	ID      string `db:"id"`
-	OwnerID string `db:"owner_id"`
-	Contact string `db:"contact"`
-	Topic   string `db:"topic"`
+	ID             string `db:"id"`
+	OwnerID        string `db:"owner_id"`
+	Contact        string `db:"contact"`
+	Topic          string `db:"topic"`
+	Secret         string `db:"secret"`
+	ThrottleLimit  int64  `db:"throttle_limit"`
+	ThrottleWindow int64  `db:"throttle_window"`
+	DedupWindow    int64  `db:"dedup_window"`
+	QuietStart     string `db:"quiet_start"`
+	QuietEnd       string `db:"quiet_end"`
+	QuietTimezone  string `db:"quiet_timezone"`
+}
+
+func toDBSub(sub notifiers.Subscription) dbSubscription {
+	return dbSubscription{
+		ID:             sub.ID,
+		OwnerID:        sub.OwnerID,
+		Contact:        sub.Contact,
+		Topic:          sub.Topic,
+		Secret:         sub.Secret,
+		ThrottleLimit:  int64(sub.Throttle.Limit),
+		ThrottleWindow: int64(sub.Throttle.Window),
+		DedupWindow:    int64(sub.Dedup),
+		QuietStart:     sub.QuietHours.Start,
+		QuietEnd:       sub.QuietHours.End,
+		QuietTimezone:  sub.QuietHours.Timezone,
+	}
 }
 
 func fromDBSub(sub dbSubscription) notifiers.Subscription {
@@ -159,5 +179,16 @@ func fromDBSub(sub dbSubscription) notifiers.Subscription {
 		OwnerID: sub.OwnerID,
 		Contact: sub.Contact,
 		Topic:   sub.Topic,
+		Secret:  sub.Secret,
+		Throttle: notifiers.Throttle{
+			Limit:  uint(sub.ThrottleLimit),
+			Window: time.Duration(sub.ThrottleWindow),
+		},
+		Dedup: time.Duration(sub.DedupWindow),
+		QuietHours: notifiers.QuietHours{
+			Start:    sub.QuietStart,
+			End:      sub.QuietEnd,
+			Timezone: sub.QuietTimezone,
+		},
 	}
 }
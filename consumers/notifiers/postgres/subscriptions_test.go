@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/consumers/notifiers"
 	"github.com/mainflux/mainflux/consumers/notifiers/postgres"
@@ -83,6 +84,17 @@ func TestView(t *testing.T) {
 		ID:      id,
 		Contact: owner,
 		Topic:   "view.subtopic",
+		Secret:  "secret",
+		Throttle: notifiers.Throttle{
+			Limit:  5,
+			Window: time.Minute,
+		},
+		Dedup: 10 * time.Minute,
+		QuietHours: notifiers.QuietHours{
+			Start:    "22:00",
+			End:      "07:00",
+			Timezone: "Europe/Belgrade",
+		},
 	}
 
 	ret, err := repo.Save(context.Background(), sub)
@@ -6,6 +6,7 @@ package notifiers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/consumers"
@@ -44,17 +45,19 @@ type notifierService struct {
 	subs     SubscriptionsRepository
 	idp      mainflux.IDProvider
 	notifier Notifier
+	limiter  Limiter
 	errCh    chan error
 	from     string
 }
 
 // New instantiates the subscriptions service implementation.
-func New(auth policies.AuthServiceClient, subs SubscriptionsRepository, idp mainflux.IDProvider, notifier Notifier, from string) Service {
+func New(auth policies.AuthServiceClient, subs SubscriptionsRepository, idp mainflux.IDProvider, notifier Notifier, limiter Limiter, from string) Service {
 	return &notifierService{
 		auth:     auth,
 		subs:     subs,
 		idp:      idp,
 		notifier: notifier,
+		limiter:  limiter,
 		errCh:    make(chan error, 1),
 		from:     from,
 	}
@@ -65,12 +68,18 @@ func (ns *notifierService) CreateSubscription(ctx context.Context, token string,
 	if err != nil {
 		return "", err
 	}
+	if err := sub.Validate(); err != nil {
+		return "", err
+	}
+	if err := ns.notifier.ValidateContact(sub.Contact); err != nil {
+		return "", errors.Wrap(errors.ErrMalformedEntity, err)
+	}
 	sub.ID, err = ns.idp.ID()
 	if err != nil {
 		return "", err
 	}
 
-	sub.OwnerID = res.GetId()
+	sub.OwnerID = res.GetOwner()
 	return ns.subs.Save(ctx, sub)
 }
 
@@ -95,7 +104,12 @@ func (ns *notifierService) RemoveSubscription(ctx context.Context, token, id str
 		return err
 	}
 
-	return ns.subs.Remove(ctx, id)
+	if err := ns.subs.Remove(ctx, id); err != nil {
+		return err
+	}
+	ns.limiter.Remove(id)
+
+	return nil
 }
 
 func (ns *notifierService) ConsumeBlocking(ctx context.Context, message interface{}) error {
@@ -117,13 +131,11 @@ func (ns *notifierService) ConsumeBlocking(ctx context.Context, message interfac
 		return err
 	}
 
-	var to []string
-	for _, sub := range page.Subscriptions {
-		to = append(to, sub.Contact)
-	}
+	to, now := ns.allowed(page.Subscriptions, msg)
 	if len(to) > 0 {
 		err := ns.notifier.Notify(ns.from, to, msg)
 		if err != nil {
+			ns.cancel(to, msg, now)
 			return errors.Wrap(ErrNotify, err)
 		}
 	}
@@ -152,12 +164,10 @@ func (ns *notifierService) ConsumeAsync(ctx context.Context, message interface{}
 		return
 	}
 
-	var to []string
-	for _, sub := range page.Subscriptions {
-		to = append(to, sub.Contact)
-	}
+	to, now := ns.allowed(page.Subscriptions, msg)
 	if len(to) > 0 {
 		if err := ns.notifier.Notify(ns.from, to, msg); err != nil {
+			ns.cancel(to, msg, now)
 			ns.errCh <- errors.Wrap(ErrNotify, err)
 		}
 	}
@@ -166,3 +176,26 @@ func (ns *notifierService) ConsumeAsync(ctx context.Context, message interface{}
 func (ns *notifierService) Errors() <-chan error {
 	return ns.errCh
 }
+
+// allowed returns the subscriptions whose notification limits allow
+// notifying about the message, and the time they were allowed at.
+func (ns *notifierService) allowed(subs []Subscription, msg *messaging.Message) ([]Subscription, time.Time) {
+	now := time.Now()
+	var to []Subscription
+	for _, sub := range subs {
+		if err := ns.limiter.Allow(sub, msg, now); err != nil {
+			continue
+		}
+		to = append(to, sub)
+	}
+
+	return to, now
+}
+
+// cancel stops counting the notifications which failed to be sent towards
+// the subscription limits.
+func (ns *notifierService) cancel(subs []Subscription, msg *messaging.Message, at time.Time) {
+	for _, sub := range subs {
+		ns.limiter.Cancel(sub, msg, at)
+	}
+}
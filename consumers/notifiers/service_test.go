@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/consumers/notifiers"
 	"github.com/mainflux/mainflux/consumers/notifiers/mocks"
@@ -30,7 +31,7 @@ func newService() notifiers.Service {
 	notifier := mocks.NewNotifier()
 	idp := uuid.NewMock()
 	from := "exampleFrom"
-	return notifiers.New(auth, repo, idp, notifier, from)
+	return notifiers.New(auth, repo, idp, notifier, notifiers.NewLimiter(), from)
 }
 
 func TestCreateSubscription(t *testing.T) {
@@ -64,6 +65,13 @@ func TestCreateSubscription(t *testing.T) {
 			id:    "",
 			err:   errors.ErrAuthentication,
 		},
+		{
+			desc:  "test with invalid contact",
+			token: exampleUser1,
+			sub:   notifiers.Subscription{Contact: "invalid-contact", Topic: "valid.topic"},
+			id:    "",
+			err:   errors.ErrMalformedEntity,
+		},
 	}
 
 	for _, tc := range cases {
@@ -326,3 +334,23 @@ func TestConsume(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestConsumeThrottled(t *testing.T) {
+	svc := newService()
+	sub := notifiers.Subscription{
+		Contact:  invalidUser,
+		Topic:    "throttled",
+		Throttle: notifiers.Throttle{Limit: 1, Window: time.Hour},
+	}
+	_, err := svc.CreateSubscription(context.Background(), exampleUser1, sub)
+	require.Nil(t, err, "Saving a Subscription must succeed")
+
+	msg := messaging.Message{Channel: "throttled"}
+
+	// The notification fails since the contact is invalid, so the failed
+	// notification isn't counted towards the limit and is retried.
+	err = svc.ConsumeBlocking(context.TODO(), &msg)
+	assert.True(t, errors.Contains(err, notifiers.ErrNotify), fmt.Sprintf("first notification: expected %s got %s\n", notifiers.ErrNotify, err))
+	err = svc.ConsumeBlocking(context.TODO(), &msg)
+	assert.True(t, errors.Contains(err, notifiers.ErrNotify), fmt.Sprintf("retried notification: expected %s got %s\n", notifiers.ErrNotify, err))
+}
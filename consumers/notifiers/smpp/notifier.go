@@ -47,10 +47,10 @@ func New(cfg Config) notifiers.Notifier {
 	return ret
 }
 
-func (n *notifier) Notify(from string, to []string, msg *messaging.Message) error {
+func (n *notifier) Notify(from string, to []notifiers.Subscription, msg *messaging.Message) error {
 	send := &smpp.ShortMessage{
 		Src:           from,
-		DstList:       to,
+		DstList:       notifiers.Contacts(to),
 		Validity:      10 * time.Minute,
 		SourceAddrTON: n.sourceAddrTON,
 		DestAddrTON:   n.destAddrTON,
@@ -65,3 +65,7 @@ func (n *notifier) Notify(from string, to []string, msg *messaging.Message) erro
 	}
 	return nil
 }
+
+func (n *notifier) ValidateContact(contact string) error {
+	return nil
+}
@@ -3,14 +3,38 @@
 
 package notifiers
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Subscription represents a user Subscription.
 type Subscription struct {
-	ID      string
-	OwnerID string
-	Contact string
-	Topic   string
+	ID         string
+	OwnerID    string
+	Contact    string
+	Topic      string
+	Secret     string
+	Throttle   Throttle
+	Dedup      time.Duration
+	QuietHours QuietHours
+}
+
+// Throttle limits the number of notifications sent for the subscription
+// to Limit notifications per Window. Zero Limit disables throttling.
+type Throttle struct {
+	Limit  uint
+	Window time.Duration
+}
+
+// QuietHours represents the daily period during which no notifications are
+// sent for the subscription. Start and End are formatted as "15:04" and
+// interpreted in the Timezone, which defaults to UTC. The period wraps around
+// midnight if End is before Start. Empty Start disables quiet hours.
+type QuietHours struct {
+	Start    string
+	End      string
+	Timezone string
 }
 
 // Page represents page metadata with content.
@@ -0,0 +1,18 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import "time"
+
+// Config represents webhook notifier configuration.
+type Config struct {
+	Headers      map[string]string `env:"MF_WEBHOOK_HEADERS"        envDefault:""`
+	HeadersHosts []string          `env:"MF_WEBHOOK_HEADERS_HOSTS"  envDefault:""`
+	AllowPrivate bool              `env:"MF_WEBHOOK_ALLOW_PRIVATE"  envDefault:"false"`
+	Template     string            `env:"MF_WEBHOOK_TEMPLATE"       envDefault:""`
+	ContentType  string            `env:"MF_WEBHOOK_CONTENT_TYPE"   envDefault:"application/json"`
+	Timeout      time.Duration     `env:"MF_WEBHOOK_TIMEOUT"        envDefault:"5s"`
+	Retries      uint              `env:"MF_WEBHOOK_RETRIES"        envDefault:"3"`
+	Backoff      time.Duration     `env:"MF_WEBHOOK_BACKOFF"        envDefault:"500ms"`
+}
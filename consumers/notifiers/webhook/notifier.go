@@ -0,0 +1,311 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// SignatureHeader is the header carrying the HMAC-SHA256 signature of the
+// request body, formatted as "sha256=<hex encoded signature>".
+const SignatureHeader = "X-Mainflux-Signature"
+
+var (
+	// ErrTemplate indicates an invalid payload template.
+	ErrTemplate = errors.New("invalid webhook template")
+
+	// ErrContact indicates that the subscription contact is not a valid URL.
+	ErrContact = errors.New("webhook contact must be an HTTP URL")
+
+	// ErrStatus indicates that the webhook responded with an unsuccessful status.
+	ErrStatus = errors.New("webhook responded with unsuccessful status")
+
+	// ErrDestination indicates that the webhook address is private, loopback
+	// or link-local, which is not allowed unless configured otherwise.
+	ErrDestination = errors.New("webhook destination address is not allowed")
+)
+
+var _ notifiers.Notifier = (*notifier)(nil)
+
+type notifier struct {
+	client       *http.Client
+	headers      map[string]string
+	headersHosts map[string]bool
+	allowPrivate bool
+	tmpl         *template.Template
+	contentType  string
+	retries      uint
+	backoff      time.Duration
+}
+
+// data is the message representation passed to the payload template and,
+// if no template is configured, sent as the raw JSON payload.
+type data struct {
+	From      string  `json:"from,omitempty"`
+	Channel   string  `json:"channel"`
+	Subtopic  string  `json:"subtopic,omitempty"`
+	Publisher string  `json:"publisher"`
+	Protocol  string  `json:"protocol"`
+	Created   int64   `json:"created"`
+	Payload   rawJSON `json:"payload"`
+}
+
+// rawJSON is a JSON encoded value which is embedded as is into the JSON
+// payload and printed as a string by the payload template.
+type rawJSON []byte
+
+func (r rawJSON) MarshalJSON() ([]byte, error) {
+	return r, nil
+}
+
+func (r rawJSON) String() string {
+	return string(r)
+}
+
+// New instantiates webhook message notifier. Unless private destinations are
+// allowed, the resolved address of each connection is checked at dial time,
+// so a public host name resolving to a private address is rejected as well.
+// Redirects are not followed.
+func New(cfg Config) (notifiers.Notifier, error) {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivate {
+		dialer.Control = control
+	}
+	n := &notifier{
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			// The proxy isn't used since it would bypass the destination check.
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				ForceAttemptHTTP2:   true,
+				TLSHandshakeTimeout: cfg.Timeout,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		headers:      cfg.Headers,
+		headersHosts: make(map[string]bool),
+		allowPrivate: cfg.AllowPrivate,
+		contentType:  cfg.ContentType,
+		retries:      cfg.Retries,
+		backoff:      cfg.Backoff,
+	}
+	for _, host := range cfg.HeadersHosts {
+		n.headersHosts[strings.ToLower(host)] = true
+	}
+	if cfg.Template != "" {
+		tmpl, err := template.New("webhook").Funcs(template.FuncMap{"json": toJSON}).Parse(cfg.Template)
+		if err != nil {
+			return nil, errors.Wrap(ErrTemplate, err)
+		}
+		n.tmpl = tmpl
+	}
+
+	return n, nil
+}
+
+// Notify sends the message to each of the subscription webhook URLs, signed
+// with the subscription secret. A failure to notify one of the URLs doesn't
+// prevent notifying the others; the last failure is returned.
+func (n *notifier) Notify(from string, to []notifiers.Subscription, msg *messaging.Message) error {
+	body, err := n.body(from, msg)
+	if err != nil {
+		return err
+	}
+
+	var ret error
+	for _, sub := range to {
+		if err := n.send(sub.Contact, []byte(sub.Secret), body); err != nil {
+			ret = err
+		}
+	}
+
+	return ret
+}
+
+func (n *notifier) ValidateContact(contact string) error {
+	u, err := parse(contact)
+	if err != nil {
+		return err
+	}
+	if n.allowPrivate {
+		return nil
+	}
+	// Host names are resolved and checked at dial time.
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !public(ip) {
+		return ErrDestination
+	}
+	if strings.EqualFold(u.Hostname(), "localhost") {
+		return ErrDestination
+	}
+
+	return nil
+}
+
+func (n *notifier) body(from string, msg *messaging.Message) ([]byte, error) {
+	payload := rawJSON(msg.Payload)
+	if !json.Valid(msg.Payload) {
+		// Non-JSON payload is sent as a JSON string.
+		str, err := json.Marshal(string(msg.Payload))
+		if err != nil {
+			return nil, err
+		}
+		payload = str
+	}
+	d := data{
+		From:      from,
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Created:   msg.Created,
+		Payload:   payload,
+	}
+	if n.tmpl == nil {
+		return json.Marshal(d)
+	}
+
+	var buf bytes.Buffer
+	if err := n.tmpl.Execute(&buf, d); err != nil {
+		return nil, errors.Wrap(ErrTemplate, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// send posts the body to the URL, retrying the network errors and the
+// server side failures with exponential backoff.
+func (n *notifier) send(addr string, secret, body []byte) error {
+	u, err := parse(addr)
+	if err != nil {
+		return err
+	}
+
+	backoff := n.backoff
+	for attempt := uint(0); ; attempt++ {
+		retry, err := n.post(u, secret, body)
+		if err == nil || !retry || attempt >= n.retries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends the request, reporting whether the failure may be retried.
+// The configured headers are sent only to the allowed hosts.
+func (n *notifier) post(u *url.URL, secret, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	if n.headersHosts[strings.ToLower(u.Hostname())] {
+		for k, v := range n.headers {
+			req.Header.Set(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", n.contentType)
+	if len(secret) > 0 {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(secret, body))
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		if blocked(err) {
+			return false, ErrDestination
+		}
+		return true, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		err := errors.Wrap(ErrStatus, fmt.Errorf("%s", res.Status))
+		return res.StatusCode >= http.StatusInternalServerError || res.StatusCode == http.StatusTooManyRequests, err
+	}
+
+	return false, nil
+}
+
+// parse parses the webhook URL.
+func parse(addr string) (*url.URL, error) {
+	u, err := url.Parse(addr)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrContact
+	}
+
+	return u, nil
+}
+
+// control rejects the connections to non-public addresses.
+func control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrDestination
+	}
+	if ip := net.ParseIP(host); ip == nil || !public(ip) {
+		return ErrDestination
+	}
+
+	return nil
+}
+
+// blocked reports whether the request failed since the destination address
+// was rejected at dial time.
+func blocked(err error) bool {
+	uerr, ok := err.(*url.Error)
+	if !ok {
+		return false
+	}
+	oerr, ok := uerr.Err.(*net.OpError)
+
+	return ok && oerr.Err == ErrDestination
+}
+
+// public reports whether the IP address is neither private, loopback,
+// link-local, multicast nor unspecified.
+func public(ip net.IP) bool {
+	return !ip.IsPrivate() &&
+		!ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the body.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,281 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/consumers/notifiers/webhook"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+type request struct {
+	header http.Header
+	body   string
+}
+
+type server struct {
+	mu       sync.Mutex
+	requests []request
+	statuses []int
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, request{header: r.Header, body: string(body)})
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status = s.statuses[0]
+		s.statuses = s.statuses[1:]
+	}
+	if status >= http.StatusMultipleChoices && status < http.StatusBadRequest {
+		w.Header().Set("Location", "/redirected")
+	}
+	w.WriteHeader(status)
+}
+
+func newConfig() webhook.Config {
+	return webhook.Config{
+		Headers:      map[string]string{"X-Api-Key": "key"},
+		HeadersHosts: []string{"127.0.0.1"},
+		AllowPrivate: true,
+		ContentType:  "application/json",
+		Timeout:      time.Second,
+		Retries:      2,
+		Backoff:      time.Millisecond,
+	}
+}
+
+func newMessage() *messaging.Message {
+	return &messaging.Message{
+		Channel:   "chan",
+		Subtopic:  "temp",
+		Publisher: "pub",
+		Protocol:  "http",
+		Payload:   []byte(`{"v":80}`),
+		Created:   1,
+	}
+}
+
+func TestNotify(t *testing.T) {
+	rawBody := `{"from":"from","channel":"chan","subtopic":"temp","publisher":"pub","protocol":"http","created":1,"payload":{"v":80}}`
+
+	cases := []struct {
+		desc         string
+		template     string
+		secret       string
+		payload      []byte
+		statuses     []int
+		contact      string
+		headersHosts []string
+		denyPrivate  bool
+		body         string
+		requests     int
+		err          error
+	}{
+		{
+			desc:     "notify with raw payload",
+			body:     rawBody,
+			requests: 1,
+		},
+		{
+			desc:     "notify with non-JSON payload",
+			payload:  []byte("hot"),
+			body:     `{"from":"from","channel":"chan","subtopic":"temp","publisher":"pub","protocol":"http","created":1,"payload":"hot"}`,
+			requests: 1,
+		},
+		{
+			desc:     "notify with template",
+			template: `{"text":{{json (printf "%s reported %s" .Publisher .Payload)}},"channel":"{{.Channel}}"}`,
+			body:     `{"text":"pub reported {\"v\":80}","channel":"chan"}`,
+			requests: 1,
+		},
+		{
+			desc:     "notify with signature",
+			secret:   secret,
+			body:     rawBody,
+			requests: 1,
+		},
+		{
+			desc:     "notify with retried server failure",
+			statuses: []int{http.StatusInternalServerError, http.StatusTooManyRequests},
+			body:     rawBody,
+			requests: 3,
+		},
+		{
+			desc:     "notify with exhausted retries",
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			body:     rawBody,
+			requests: 3,
+			err:      webhook.ErrStatus,
+		},
+		{
+			desc:     "notify with client failure",
+			statuses: []int{http.StatusBadRequest},
+			body:     rawBody,
+			requests: 1,
+			err:      webhook.ErrStatus,
+		},
+		{
+			desc:     "notify with redirect",
+			statuses: []int{http.StatusFound},
+			body:     rawBody,
+			requests: 1,
+			err:      webhook.ErrStatus,
+		},
+		{
+			desc:         "notify host without configured headers",
+			headersHosts: []string{"example.com"},
+			body:         rawBody,
+			requests:     1,
+		},
+		{
+			desc:        "notify private destination",
+			denyPrivate: true,
+			err:         webhook.ErrDestination,
+		},
+		{
+			desc:    "notify invalid contact",
+			contact: "mailto:user@example.com",
+			err:     webhook.ErrContact,
+		},
+	}
+
+	for _, tc := range cases {
+		srv := &server{statuses: tc.statuses}
+		ts := httptest.NewServer(srv)
+
+		cfg := newConfig()
+		cfg.Template = tc.template
+		cfg.AllowPrivate = !tc.denyPrivate
+		if tc.headersHosts != nil {
+			cfg.HeadersHosts = tc.headersHosts
+		}
+		n, err := webhook.New(cfg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		msg := newMessage()
+		if tc.payload != nil {
+			msg.Payload = tc.payload
+		}
+		contact := ts.URL
+		if tc.contact != "" {
+			contact = tc.contact
+		}
+
+		err = n.Notify("from", []notifiers.Subscription{{Contact: contact, Secret: tc.secret}}, msg)
+		ts.Close()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		require.Len(t, srv.requests, tc.requests, fmt.Sprintf("%s: expected %d requests got %d\n", tc.desc, tc.requests, len(srv.requests)))
+		for _, req := range srv.requests {
+			assert.Equal(t, tc.body, req.body, fmt.Sprintf("%s: expected body %s got %s\n", tc.desc, tc.body, req.body))
+			header := "key"
+			if tc.headersHosts != nil {
+				header = ""
+			}
+			assert.Equal(t, header, req.header.Get("X-Api-Key"), fmt.Sprintf("%s: expected header %s\n", tc.desc, header))
+			assert.Equal(t, "application/json", req.header.Get("Content-Type"), fmt.Sprintf("%s: expected content type\n", tc.desc))
+			signature := ""
+			if tc.secret != "" {
+				signature = "sha256=" + webhook.Sign([]byte(tc.secret), []byte(tc.body))
+			}
+			assert.Equal(t, signature, req.header.Get(webhook.SignatureHeader), fmt.Sprintf("%s: expected signature %s\n", tc.desc, signature))
+		}
+	}
+}
+
+func TestNotifyMultipleContacts(t *testing.T) {
+	failing := &server{statuses: []int{http.StatusNotFound}}
+	fts := httptest.NewServer(failing)
+	defer fts.Close()
+	srv := &server{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	n, err := webhook.New(newConfig())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = n.Notify("from", []notifiers.Subscription{{Contact: fts.URL}, {Contact: ts.URL}}, newMessage())
+	assert.True(t, errors.Contains(err, webhook.ErrStatus), fmt.Sprintf("expected %s got %s\n", webhook.ErrStatus, err))
+	assert.Len(t, srv.requests, 1, "expected the second contact to be notified despite the first failure")
+}
+
+func TestNewInvalidTemplate(t *testing.T) {
+	cfg := newConfig()
+	cfg.Template = "{{.Payload"
+	_, err := webhook.New(cfg)
+	assert.True(t, errors.Contains(err, webhook.ErrTemplate), fmt.Sprintf("expected %s got %s\n", webhook.ErrTemplate, err))
+}
+
+func TestValidateContact(t *testing.T) {
+	cfg := newConfig()
+	cfg.AllowPrivate = false
+	n, err := webhook.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		contact string
+		err     error
+	}{
+		{
+			desc:    "validate public URL",
+			contact: "https://example.com/hook",
+		},
+		{
+			desc:    "validate non-HTTP URL",
+			contact: "mailto:user@example.com",
+			err:     webhook.ErrContact,
+		},
+		{
+			desc:    "validate URL without host",
+			contact: "https:///hook",
+			err:     webhook.ErrContact,
+		},
+		{
+			desc:    "validate loopback URL",
+			contact: "http://127.0.0.1:8080/hook",
+			err:     webhook.ErrDestination,
+		},
+		{
+			desc:    "validate localhost URL",
+			contact: "http://localhost/hook",
+			err:     webhook.ErrDestination,
+		},
+		{
+			desc:    "validate private URL",
+			contact: "http://10.0.0.1/hook",
+			err:     webhook.ErrDestination,
+		},
+		{
+			desc:    "validate link-local URL",
+			contact: "http://169.254.169.254/latest/meta-data",
+			err:     webhook.ErrDestination,
+		},
+		{
+			desc:    "validate IPv6 loopback URL",
+			contact: "http://[::1]/hook",
+			err:     webhook.ErrDestination,
+		},
+	}
+
+	for _, tc := range cases {
+		err := n.ValidateContact(tc.contact)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
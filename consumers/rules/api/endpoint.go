@@ -0,0 +1,152 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+func createRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createRuleReq)
+		if err := req.validate(); err != nil {
+			return ruleRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		window, err := toWindow(req.Window)
+		if err != nil {
+			return ruleRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		r := rules.Rule{
+			Name:      req.Name,
+			Channel:   req.Channel,
+			Subtopic:  req.Subtopic,
+			Condition: req.Condition,
+			Window:    window,
+			Actions:   toActions(req.Actions),
+		}
+		r, err = svc.CreateRule(ctx, req.token, r)
+		if err != nil {
+			return ruleRes{}, err
+		}
+		res := toRuleRes(r)
+		res.created = true
+
+		return res, nil
+	}
+}
+
+func viewRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return ruleRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		r, err := svc.ViewRule(ctx, req.token, req.id)
+		if err != nil {
+			return ruleRes{}, err
+		}
+
+		return toRuleRes(r), nil
+	}
+}
+
+func listRulesEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listRulesReq)
+		if err := req.validate(); err != nil {
+			return listRulesRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		pm := rules.PageMetadata{
+			Offset:  req.offset,
+			Limit:   req.limit,
+			Channel: req.channel,
+		}
+		page, err := svc.ListRules(ctx, req.token, pm)
+		if err != nil {
+			return listRulesRes{}, err
+		}
+		res := listRulesRes{
+			Offset: page.Offset,
+			Limit:  page.Limit,
+			Total:  page.Total,
+			Rules:  []ruleRes{},
+		}
+		for _, r := range page.Rules {
+			res.Rules = append(res.Rules, toRuleRes(r))
+		}
+
+		return res, nil
+	}
+}
+
+func updateRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateRuleReq)
+		if err := req.validate(); err != nil {
+			return ruleRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		window, err := toWindow(req.Window)
+		if err != nil {
+			return ruleRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		r := rules.Rule{
+			ID:        req.id,
+			Name:      req.Name,
+			Subtopic:  req.Subtopic,
+			Condition: req.Condition,
+			Window:    window,
+			Actions:   toActions(req.Actions),
+		}
+		r, err = svc.UpdateRule(ctx, req.token, r)
+		if err != nil {
+			return ruleRes{}, err
+		}
+
+		return toRuleRes(r), nil
+	}
+}
+
+func removeRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := svc.RemoveRule(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRuleRes{}, nil
+	}
+}
+
+func toRuleRes(r rules.Rule) ruleRes {
+	res := ruleRes{
+		ID:        r.ID,
+		OwnerID:   r.OwnerID,
+		Name:      r.Name,
+		Channel:   r.Channel,
+		Subtopic:  r.Subtopic,
+		Condition: r.Condition,
+		Actions:   []actionRes{},
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if r.Window.Duration > 0 {
+		res.Window = &windowRes{
+			Duration:  r.Window.Duration.String(),
+			Condition: r.Window.Condition,
+		}
+	}
+	for _, a := range r.Actions {
+		res.Actions = append(res.Actions, actionRes(a))
+	}
+
+	return res
+}
@@ -0,0 +1,453 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	httpapi "github.com/mainflux/mainflux/consumers/rules/api"
+	"github.com/mainflux/mainflux/consumers/rules/mocks"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token       = "token"
+	otherToken  = "other-token"
+	userID      = "user"
+	otherID     = "other"
+	chanID      = "chan"
+	contentType = "application/json"
+	instanceID  = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+type ruleRes struct {
+	ID        string `json:"id"`
+	OwnerID   string `json:"owner_id"`
+	Channel   string `json:"channel"`
+	Condition string `json:"condition"`
+	Window    *struct {
+		Duration  string `json:"duration"`
+		Condition string `json:"condition"`
+	} `json:"window"`
+	Actions []struct {
+		Type    string `json:"type"`
+		Channel string `json:"channel"`
+	} `json:"actions"`
+}
+
+type pageRes struct {
+	Total uint64    `json:"total"`
+	Rules []ruleRes `json:"rules"`
+}
+
+func newService() rules.Service {
+	auth := mocks.NewAuth(map[string]string{token: userID, otherToken: otherID})
+	return rules.New(auth, mocks.NewThings(map[string]string{}), mocks.NewRepo(), uuid.NewMock(), mocks.NewPublisher(""), mocks.NewEventPublisher())
+}
+
+func newServer(svc rules.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, logger.NewMock(), instanceID)
+	return httptest.NewServer(mux)
+}
+
+func createRule(t *testing.T, svc rules.Service, tkn string) rules.Rule {
+	r := rules.Rule{
+		Channel:   chanID,
+		Condition: "v > 80",
+		Actions:   []rules.Action{{Type: rules.EventAction}},
+	}
+	r, err := svc.CreateRule(context.Background(), tkn, r)
+	require.Nil(t, err, fmt.Sprintf("creating rule expected to succeed: %s", err))
+	return r
+}
+
+func TestCreate(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	valid := `{"name":"overheating","channel":"chan","condition":"name == \"temp\" && v > 80",
+		"window":{"duration":"5m","condition":"count() >= 3"},
+		"actions":[{"type":"publish","channel":"alarms"},{"type":"event"}]}`
+
+	cases := []struct {
+		desc        string
+		body        string
+		contentType string
+		token       string
+		status      int
+	}{
+		{
+			desc:        "create rule",
+			body:        valid,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create rule with invalid token",
+			body:        valid,
+			contentType: contentType,
+			token:       "invalid",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule without token",
+			body:        valid,
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule with invalid content type",
+			body:        valid,
+			contentType: "text/plain",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create rule with malformed JSON",
+			body:        `{"channel":`,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without channel",
+			body:        `{"condition":"v > 80","actions":[{"type":"event"}]}`,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without actions",
+			body:        `{"channel":"chan","condition":"v > 80"}`,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid window duration",
+			body:        `{"channel":"chan","window":{"duration":"soon","condition":"count() > 1"},"actions":[{"type":"event"}]}`,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid condition",
+			body:        `{"channel":"chan","condition":"v >","actions":[{"type":"event"}]}`,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/rules", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusCreated {
+			continue
+		}
+		var body ruleRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, fmt.Sprintf("/rules/%s", body.ID), res.Header.Get("Location"), fmt.Sprintf("%s: expected location of created rule", tc.desc))
+		assert.Equal(t, userID, body.OwnerID, fmt.Sprintf("%s: expected owner %s got %s", tc.desc, userID, body.OwnerID))
+		require.NotNil(t, body.Window, fmt.Sprintf("%s: expected rule window", tc.desc))
+		assert.Equal(t, "5m0s", body.Window.Duration, fmt.Sprintf("%s: expected window duration 5m0s got %s", tc.desc, body.Window.Duration))
+		assert.Len(t, body.Actions, 2, fmt.Sprintf("%s: expected 2 actions got %d", tc.desc, len(body.Actions)))
+	}
+}
+
+func TestView(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	r := createRule(t, svc, token)
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "view rule",
+			id:     r.ID,
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view rule of other user",
+			id:     r.ID,
+			token:  otherToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "view rule with invalid token",
+			id:     r.ID,
+			token:  "invalid",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view non-existing rule",
+			id:     "non-existing",
+			token:  token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body ruleRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, r.ID, body.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, r.ID, body.ID))
+		assert.Nil(t, body.Window, fmt.Sprintf("%s: expected rule without window", tc.desc))
+	}
+}
+
+func TestList(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	for i := 0; i < 3; i++ {
+		createRule(t, svc, token)
+	}
+	createRule(t, svc, otherToken)
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		size   int
+		total  uint64
+	}{
+		{
+			desc:   "list rules",
+			query:  "",
+			token:  token,
+			status: http.StatusOK,
+			size:   3,
+			total:  3,
+		},
+		{
+			desc:   "list rules with offset and limit",
+			query:  "?offset=1&limit=1",
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+			total:  3,
+		},
+		{
+			desc:   "list rules of channel",
+			query:  "?channel=other",
+			token:  token,
+			status: http.StatusOK,
+			size:   0,
+			total:  0,
+		},
+		{
+			desc:   "list rules with invalid limit",
+			query:  "?limit=1000",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list rules without token",
+			query:  "",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/rules%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body pageRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(body.Rules), fmt.Sprintf("%s: expected %d rules got %d", tc.desc, tc.size, len(body.Rules)))
+		assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	r := createRule(t, svc, token)
+
+	valid := `{"condition":"v > 90","actions":[{"type":"publish","channel":"alarms"}]}`
+
+	cases := []struct {
+		desc   string
+		id     string
+		body   string
+		token  string
+		status int
+	}{
+		{
+			desc:   "update rule",
+			id:     r.ID,
+			body:   valid,
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "update rule of other user",
+			id:     r.ID,
+			body:   valid,
+			token:  otherToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "update non-existing rule",
+			id:     "non-existing",
+			body:   valid,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "update rule with invalid action",
+			id:     r.ID,
+			body:   `{"condition":"v > 90","actions":[{"type":"unknown"}]}`,
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body ruleRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, "v > 90", body.Condition, fmt.Sprintf("%s: expected updated condition got %s", tc.desc, body.Condition))
+		assert.Equal(t, chanID, body.Channel, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, chanID, body.Channel))
+	}
+}
+
+func TestRemove(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	r := createRule(t, svc, token)
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove rule of other user",
+			id:     r.ID,
+			token:  otherToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove rule",
+			id:     r.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed rule",
+			id:     r.ID,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
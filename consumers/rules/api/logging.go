@@ -0,0 +1,117 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	mflog "github.com/mainflux/mainflux/logger"
+)
+
+var _ rules.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger mflog.Logger
+	svc    rules.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc rules.Service, logger mflog.Logger) rules.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// CreateRule logs the create_rule request. It logs rule channel and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) CreateRule(ctx context.Context, token string, r rules.Rule) (rule rules.Rule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_rule for channel %s took %s to complete", r.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateRule(ctx, token, r)
+}
+
+// ViewRule logs the view_rule request. It logs rule ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewRule(ctx context.Context, token, id string) (rule rules.Rule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_rule for rule %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewRule(ctx, token, id)
+}
+
+// ListRules logs the list_rules request. It logs the channel filter and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListRules(ctx context.Context, token string, pm rules.PageMetadata) (page rules.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_rules for channel %s took %s to complete", pm.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListRules(ctx, token, pm)
+}
+
+// UpdateRule logs the update_rule request. It logs rule ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) UpdateRule(ctx context.Context, token string, r rules.Rule) (rule rules.Rule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_rule for rule %s took %s to complete", r.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateRule(ctx, token, r)
+}
+
+// RemoveRule logs the remove_rule request. It logs rule ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveRule(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_rule for rule %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveRule(ctx, token, id)
+}
+
+// ConsumeBlocking logs the consume request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method consume took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConsumeBlocking(ctx, msg)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/internal/api"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const maxLimitSize = 100
+
+var (
+	errMissingChannel = errors.New("missing rule channel")
+	errMissingActions = errors.New("missing rule actions")
+)
+
+type windowReq struct {
+	Duration  string `json:"duration"`
+	Condition string `json:"condition"`
+}
+
+type actionReq struct {
+	Type     string `json:"type"`
+	Channel  string `json:"channel,omitempty"`
+	Subtopic string `json:"subtopic,omitempty"`
+}
+
+type createRuleReq struct {
+	token     string
+	Name      string      `json:"name,omitempty"`
+	Channel   string      `json:"channel"`
+	Subtopic  string      `json:"subtopic,omitempty"`
+	Condition string      `json:"condition,omitempty"`
+	Window    *windowReq  `json:"window,omitempty"`
+	Actions   []actionReq `json:"actions"`
+}
+
+func (req createRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Channel == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, errMissingChannel)
+	}
+
+	return validateRule(req.Name, req.Window, req.Actions)
+}
+
+type updateRuleReq struct {
+	token     string
+	id        string
+	Name      string      `json:"name,omitempty"`
+	Subtopic  string      `json:"subtopic,omitempty"`
+	Condition string      `json:"condition,omitempty"`
+	Window    *windowReq  `json:"window,omitempty"`
+	Actions   []actionReq `json:"actions"`
+}
+
+func (req updateRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return validateRule(req.Name, req.Window, req.Actions)
+}
+
+type ruleReq struct {
+	token string
+	id    string
+}
+
+func (req ruleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	return nil
+}
+
+type listRulesReq struct {
+	token   string
+	channel string
+	offset  uint64
+	limit   uint64
+}
+
+func (req listRulesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.limit < 1 || req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	return nil
+}
+
+// validateRule validates the fields shared by create and update requests.
+// Conditions are validated by the service.
+func validateRule(name string, w *windowReq, actions []actionReq) error {
+	if len(name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if len(actions) == 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, errMissingActions)
+	}
+	if _, err := toWindow(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func toWindow(w *windowReq) (rules.Window, error) {
+	if w == nil {
+		return rules.Window{}, nil
+	}
+	d, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return rules.Window{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return rules.Window{Duration: d, Condition: w.Condition}, nil
+}
+
+func toActions(actions []actionReq) []rules.Action {
+	ret := make([]rules.Action, 0, len(actions))
+	for _, a := range actions {
+		ret = append(ret, rules.Action(a))
+	}
+	return ret
+}
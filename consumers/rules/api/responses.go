@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*ruleRes)(nil)
+	_ mainflux.Response = (*listRulesRes)(nil)
+	_ mainflux.Response = (*removeRuleRes)(nil)
+)
+
+type windowRes struct {
+	Duration  string `json:"duration"`
+	Condition string `json:"condition"`
+}
+
+type actionRes struct {
+	Type     string `json:"type"`
+	Channel  string `json:"channel,omitempty"`
+	Subtopic string `json:"subtopic,omitempty"`
+}
+
+type ruleRes struct {
+	ID        string      `json:"id"`
+	OwnerID   string      `json:"owner_id"`
+	Name      string      `json:"name,omitempty"`
+	Channel   string      `json:"channel"`
+	Subtopic  string      `json:"subtopic,omitempty"`
+	Condition string      `json:"condition,omitempty"`
+	Window    *windowRes  `json:"window,omitempty"`
+	Actions   []actionRes `json:"actions"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	created   bool
+}
+
+func (res ruleRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res ruleRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/rules/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res ruleRes) Empty() bool {
+	return false
+}
+
+type listRulesRes struct {
+	Offset uint64    `json:"offset"`
+	Limit  uint64    `json:"limit"`
+	Total  uint64    `json:"total"`
+	Rules  []ruleRes `json:"rules"`
+}
+
+func (res listRulesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listRulesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listRulesRes) Empty() bool {
+	return false
+}
+
+type removeRuleRes struct{}
+
+func (res removeRuleRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRuleRes) Empty() bool {
+	return true
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rules contains the domain concept definitions needed to support
+// Mainflux rules engine functionality. Rules are evaluated against the
+// consumed messages and trigger actions when their conditions are met.
+package rules
@@ -0,0 +1,43 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"encoding/json"
+
+	"github.com/mainflux/mainflux/pkg/events"
+)
+
+const ruleTrigger = "rule.trigger"
+
+var _ events.Event = (*triggerEvent)(nil)
+
+// triggerEvent is emitted by the event action when the rule is triggered.
+type triggerEvent struct {
+	rule   Rule
+	record record
+}
+
+func (te triggerEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": ruleTrigger,
+		"id":        te.rule.ID,
+		"owner":     te.rule.OwnerID,
+		"channel":   te.record.channel,
+		"publisher": te.record.publisher,
+	}
+	if te.rule.Name != "" {
+		val["name"] = te.rule.Name
+	}
+	if te.record.subtopic != "" {
+		val["subtopic"] = te.record.subtopic
+	}
+	rec, err := json.Marshal(te.record.fields)
+	if err != nil {
+		return map[string]interface{}{}, err
+	}
+	val["record"] = string(rec)
+
+	return val, nil
+}
@@ -0,0 +1,321 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	countFunc = "count"
+	avgFunc   = "avg"
+)
+
+// ErrExpression indicates an invalid rule condition.
+var ErrExpression = errors.New("invalid rule condition")
+
+// CallFunc resolves the window function call having the provided name and
+// field argument. Nil value indicates that the function can't be resolved.
+type CallFunc func(name, field string) interface{}
+
+// Expression represents a parsed rule condition. Conditions are written
+// using Go expression syntax restricted to literals, record fields,
+// arithmetic, comparison and logical operators, and the window functions
+// count() and avg(field), e.g. `name == "temp" && v > 80`.
+type Expression struct {
+	root   ast.Expr
+	calls  bool
+	fields []string
+}
+
+// ParseExpression parses the rule condition.
+func ParseExpression(src string) (*Expression, error) {
+	root, err := parser.ParseExpr(src)
+	if err != nil {
+		return nil, errors.Wrap(ErrExpression, err)
+	}
+	e := &Expression{root: root}
+	if err := e.check(root); err != nil {
+		return nil, errors.Wrap(ErrExpression, err)
+	}
+
+	return e, nil
+}
+
+// Calls returns true if the expression calls the window functions.
+func (e *Expression) Calls() bool {
+	return e.calls
+}
+
+// Fields returns the record fields used as window function arguments.
+func (e *Expression) Fields() []string {
+	return e.fields
+}
+
+// Eval evaluates the expression against the record fields. Nested fields
+// are referenced using the dot notation. Comparison with a missing field,
+// or a field of a different type, is false.
+func (e *Expression) Eval(record map[string]interface{}, call CallFunc) bool {
+	b, ok := eval(e.root, record, call).(bool)
+	return ok && b
+}
+
+func (e *Expression) check(node ast.Expr) error {
+	switch n := node.(type) {
+	case *ast.BinaryExpr:
+		switch n.Op {
+		case token.LAND, token.LOR, token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ,
+			token.ADD, token.SUB, token.MUL, token.QUO:
+		default:
+			return fmt.Errorf("unsupported operator %s", n.Op)
+		}
+		if err := e.check(n.X); err != nil {
+			return err
+		}
+		return e.check(n.Y)
+	case *ast.UnaryExpr:
+		if n.Op != token.NOT && n.Op != token.SUB {
+			return fmt.Errorf("unsupported operator %s", n.Op)
+		}
+		return e.check(n.X)
+	case *ast.ParenExpr:
+		return e.check(n.X)
+	case *ast.BasicLit:
+		if n.Kind != token.INT && n.Kind != token.FLOAT && n.Kind != token.STRING {
+			return fmt.Errorf("unsupported literal %s", n.Value)
+		}
+		return nil
+	case *ast.Ident:
+		return nil
+	case *ast.SelectorExpr:
+		if _, ok := fieldName(n); !ok {
+			return fmt.Errorf("invalid field")
+		}
+		return nil
+	case *ast.CallExpr:
+		fn, ok := n.Fun.(*ast.Ident)
+		if !ok || (fn.Name != countFunc && fn.Name != avgFunc) {
+			return fmt.Errorf("unsupported function")
+		}
+		if len(n.Args) > 1 || (fn.Name == avgFunc && len(n.Args) == 0) {
+			return fmt.Errorf("invalid number of %s arguments", fn.Name)
+		}
+		if len(n.Args) == 1 {
+			field, ok := fieldName(n.Args[0])
+			if !ok {
+				return fmt.Errorf("%s argument must be a field", fn.Name)
+			}
+			e.fields = append(e.fields, field)
+		}
+		e.calls = true
+		return nil
+	default:
+		return fmt.Errorf("unsupported expression")
+	}
+}
+
+// fieldName returns the dot separated name of the field referenced by the node.
+func fieldName(node ast.Expr) (string, bool) {
+	switch n := node.(type) {
+	case *ast.Ident:
+		return n.Name, true
+	case *ast.SelectorExpr:
+		prefix, ok := fieldName(n.X)
+		if !ok {
+			return "", false
+		}
+		return prefix + "." + n.Sel.Name, true
+	default:
+		return "", false
+	}
+}
+
+// field returns the value of the dot separated field of the record.
+func field(record map[string]interface{}, name string) interface{} {
+	var val interface{} = record
+	for _, key := range strings.Split(name, ".") {
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if val, ok = m[key]; !ok {
+			return nil
+		}
+	}
+
+	return number(val)
+}
+
+// number converts the numeric value to float64, which is the only numeric
+// type used in evaluation.
+func number(val interface{}) interface{} {
+	switch v := val.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	default:
+		return val
+	}
+}
+
+func eval(node ast.Expr, record map[string]interface{}, call CallFunc) interface{} {
+	switch n := node.(type) {
+	case *ast.ParenExpr:
+		return eval(n.X, record, call)
+	case *ast.BasicLit:
+		if n.Kind == token.STRING {
+			s, err := strconv.Unquote(n.Value)
+			if err != nil {
+				return nil
+			}
+			return s
+		}
+		f, err := strconv.ParseFloat(n.Value, 64)
+		if err != nil {
+			return nil
+		}
+		return f
+	case *ast.Ident:
+		switch n.Name {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+		return field(record, n.Name)
+	case *ast.SelectorExpr:
+		name, _ := fieldName(n)
+		return field(record, name)
+	case *ast.CallExpr:
+		if call == nil {
+			return nil
+		}
+		var arg string
+		if len(n.Args) == 1 {
+			arg, _ = fieldName(n.Args[0])
+		}
+		return number(call(n.Fun.(*ast.Ident).Name, arg))
+	case *ast.UnaryExpr:
+		x := eval(n.X, record, call)
+		if n.Op == token.NOT {
+			b, _ := x.(bool)
+			return !b
+		}
+		if f, ok := x.(float64); ok {
+			return -f
+		}
+		return nil
+	case *ast.BinaryExpr:
+		return evalBinary(n, record, call)
+	default:
+		return nil
+	}
+}
+
+func evalBinary(n *ast.BinaryExpr, record map[string]interface{}, call CallFunc) interface{} {
+	x := eval(n.X, record, call)
+	switch n.Op {
+	case token.LAND:
+		if b, _ := x.(bool); !b {
+			return false
+		}
+		b, _ := eval(n.Y, record, call).(bool)
+		return b
+	case token.LOR:
+		if b, _ := x.(bool); b {
+			return true
+		}
+		b, _ := eval(n.Y, record, call).(bool)
+		return b
+	}
+
+	y := eval(n.Y, record, call)
+	if x == nil || y == nil {
+		if n.Op == token.ADD || n.Op == token.SUB || n.Op == token.MUL || n.Op == token.QUO {
+			return nil
+		}
+		return false
+	}
+
+	switch xv := x.(type) {
+	case float64:
+		yv, ok := y.(float64)
+		if !ok {
+			return compareMismatch(n.Op)
+		}
+		switch n.Op {
+		case token.ADD:
+			return xv + yv
+		case token.SUB:
+			return xv - yv
+		case token.MUL:
+			return xv * yv
+		case token.QUO:
+			if yv == 0 {
+				return nil
+			}
+			return xv / yv
+		}
+		return compare(n.Op, xv < yv, xv == yv)
+	case string:
+		yv, ok := y.(string)
+		if !ok {
+			return compareMismatch(n.Op)
+		}
+		if n.Op == token.ADD {
+			return xv + yv
+		}
+		return compare(n.Op, xv < yv, xv == yv)
+	case bool:
+		yv, ok := y.(bool)
+		if !ok || (n.Op != token.EQL && n.Op != token.NEQ) {
+			return compareMismatch(n.Op)
+		}
+		return compare(n.Op, false, xv == yv)
+	default:
+		return compareMismatch(n.Op)
+	}
+}
+
+// compareMismatch returns the result of the operation over operands of
+// different types.
+func compareMismatch(op token.Token) interface{} {
+	switch op {
+	case token.ADD, token.SUB, token.MUL, token.QUO:
+		return nil
+	default:
+		return false
+	}
+}
+
+func compare(op token.Token, less, equal bool) interface{} {
+	switch op {
+	case token.EQL:
+		return equal
+	case token.NEQ:
+		return !equal
+	case token.LSS:
+		return less
+	case token.LEQ:
+		return less || equal
+	case token.GTR:
+		return !less && !equal
+	case token.GEQ:
+		return !less
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpression(t *testing.T) {
+	cases := []struct {
+		desc   string
+		src    string
+		calls  bool
+		fields []string
+		err    error
+	}{
+		{
+			desc: "parse comparison",
+			src:  `name == "temp" && v > 80`,
+		},
+		{
+			desc:   "parse window functions",
+			src:    "count() > 3 && avg(v) >= 10.5 || count(data.temp) > 1",
+			calls:  true,
+			fields: []string{"v", "data.temp"},
+		},
+		{
+			desc: "parse invalid syntax",
+			src:  "v >",
+			err:  rules.ErrExpression,
+		},
+		{
+			desc: "parse unsupported operator",
+			src:  "v % 2 == 0",
+			err:  rules.ErrExpression,
+		},
+		{
+			desc: "parse unsupported function",
+			src:  "max(v) > 2",
+			err:  rules.ErrExpression,
+		},
+		{
+			desc: "parse avg without argument",
+			src:  "avg() > 2",
+			err:  rules.ErrExpression,
+		},
+		{
+			desc: "parse function with literal argument",
+			src:  "count(1) > 2",
+			err:  rules.ErrExpression,
+		},
+	}
+
+	for _, tc := range cases {
+		e, err := rules.ParseExpression(tc.src)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.calls, e.Calls(), fmt.Sprintf("%s: expected calls %t got %t\n", tc.desc, tc.calls, e.Calls()))
+			assert.Equal(t, tc.fields, e.Fields(), fmt.Sprintf("%s: expected fields %v got %v\n", tc.desc, tc.fields, e.Fields()))
+		}
+	}
+}
+
+func TestEvalExpression(t *testing.T) {
+	record := map[string]interface{}{
+		"name":  "temp",
+		"v":     81.0,
+		"vb":    true,
+		"count": 2,
+		"data": map[string]interface{}{
+			"temp": 20.5,
+		},
+	}
+	call := func(name, field string) interface{} {
+		switch name {
+		case "count":
+			return 3
+		case "avg":
+			if field == "v" {
+				return 50.0
+			}
+		}
+		return nil
+	}
+
+	cases := map[string]bool{
+		`name == "temp" && v > 80`:         true,
+		`name == "hum" || v > 80`:          true,
+		`name != "temp"`:                   false,
+		`v >= 81 && v <= 81`:               true,
+		`v * 2 - 2 == 160`:                 true,
+		`v / 0 > 0`:                        false,
+		`-v < 0`:                           true,
+		`!(v > 80)`:                        false,
+		`vb`:                               true,
+		`vb == false`:                      false,
+		`count == 2`:                       true,
+		`data.temp > 20 && data.temp < 21`: true,
+		`missing > 0`:                      false,
+		`missing != 0`:                     false,
+		`!(missing > 0)`:                   true,
+		`name > 10`:                        false,
+		`name + "s" == "temps"`:            true,
+		`count() == 3 && avg(v) == 50`:     true,
+		`avg(vs) > 0`:                      false,
+		`v`:                                false,
+	}
+
+	for src, expected := range cases {
+		e, err := rules.ParseExpression(src)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", src, err))
+		assert.Equal(t, expected, e.Eval(record, call), fmt.Sprintf("%s: expected %t", src, expected))
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/events"
+)
+
+var _ events.Publisher = (*EventPublisher)(nil)
+
+// EventPublisher is the event publisher mock, which records the encoded events.
+type EventPublisher struct {
+	mu     sync.Mutex
+	events []map[string]interface{}
+}
+
+// NewEventPublisher returns a new event publisher mock.
+func NewEventPublisher() *EventPublisher {
+	return &EventPublisher{}
+}
+
+func (ep *EventPublisher) Publish(_ context.Context, event events.Event) error {
+	val, err := event.Encode()
+	if err != nil {
+		return err
+	}
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	ep.events = append(ep.events, val)
+	return nil
+}
+
+// Events returns the published events.
+func (ep *EventPublisher) Events() []map[string]interface{} {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	return ep.events
+}
+
+func (ep *EventPublisher) Close() error {
+	return nil
+}
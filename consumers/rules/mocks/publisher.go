@@ -0,0 +1,51 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// ErrPublish is returned when publishing to the failing channel.
+var ErrPublish = errors.New("failed to publish")
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+// Publisher is the message publisher mock, which records the published
+// messages and fails to publish to the given channel.
+type Publisher struct {
+	mu       sync.Mutex
+	failChan string
+	msgs     []*messaging.Message
+}
+
+// NewPublisher returns a new message publisher mock.
+func NewPublisher(failChan string) *Publisher {
+	return &Publisher{failChan: failChan}
+}
+
+func (pub *Publisher) Publish(_ context.Context, topic string, msg *messaging.Message) error {
+	if topic == pub.failChan {
+		return ErrPublish
+	}
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	pub.msgs = append(pub.msgs, msg)
+	return nil
+}
+
+// Messages returns the published messages.
+func (pub *Publisher) Messages() []*messaging.Message {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	return pub.msgs
+}
+
+func (pub *Publisher) Close() error {
+	return nil
+}
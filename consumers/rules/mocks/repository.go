@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var _ rules.Repository = (*repoMock)(nil)
+
+type repoMock struct {
+	mu    sync.Mutex
+	rules map[string]rules.Rule
+}
+
+// NewRepo returns a new rules repository mock.
+func NewRepo() rules.Repository {
+	return &repoMock{
+		rules: make(map[string]rules.Rule),
+	}
+}
+
+func (rm *repoMock) Save(_ context.Context, r rules.Rule) (rules.Rule, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if _, ok := rm.rules[r.ID]; ok {
+		return rules.Rule{}, errors.ErrConflict
+	}
+	rm.rules[r.ID] = r
+	return r, nil
+}
+
+func (rm *repoMock) RetrieveByID(_ context.Context, id string) (rules.Rule, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	r, ok := rm.rules[id]
+	if !ok {
+		return rules.Rule{}, errors.ErrNotFound
+	}
+	return r, nil
+}
+
+func (rm *repoMock) RetrieveAll(_ context.Context, pm rules.PageMetadata) (rules.Page, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	var rs []rules.Rule
+	for _, r := range rm.rules {
+		if pm.OwnerID != "" && r.OwnerID != pm.OwnerID {
+			continue
+		}
+		if pm.Channel != "" && r.Channel != pm.Channel {
+			continue
+		}
+		rs = append(rs, r)
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].ID < rs[j].ID
+	})
+
+	page := rules.Page{
+		PageMetadata: pm,
+		Total:        uint64(len(rs)),
+	}
+	if pm.Offset >= uint64(len(rs)) {
+		return page, nil
+	}
+	end := pm.Offset + pm.Limit
+	if end > uint64(len(rs)) {
+		end = uint64(len(rs))
+	}
+	page.Rules = rs[pm.Offset:end]
+
+	return page, nil
+}
+
+func (rm *repoMock) RetrieveByChannel(_ context.Context, channel string) ([]rules.Rule, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	var rs []rules.Rule
+	for _, r := range rm.rules {
+		if r.Channel == channel {
+			rs = append(rs, r)
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].ID < rs[j].ID
+	})
+
+	return rs, nil
+}
+
+func (rm *repoMock) Update(_ context.Context, r rules.Rule) (rules.Rule, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if _, ok := rm.rules[r.ID]; !ok {
+		return rules.Rule{}, errors.ErrNotFound
+	}
+	rm.rules[r.ID] = r
+	return r, nil
+}
+
+func (rm *repoMock) Remove(_ context.Context, id string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if _, ok := rm.rules[id]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(rm.rules, id)
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things/policies"
+	"google.golang.org/grpc"
+)
+
+var _ policies.AuthServiceClient = (*thingsServiceMock)(nil)
+
+type thingsServiceMock struct {
+	channels map[string]string
+}
+
+// NewThings creates mock of things service. The channels map the channel ID
+// to the ID of the only user allowed to access the channel, while the other
+// channels are accessible by all the users.
+func NewThings(channels map[string]string) policies.AuthServiceClient {
+	return &thingsServiceMock{channels}
+}
+
+func (svc thingsServiceMock) Authorize(_ context.Context, req *policies.AuthorizeReq, _ ...grpc.CallOption) (*policies.AuthorizeRes, error) {
+	if owner, ok := svc.channels[req.GetObject()]; ok && owner != req.GetSubject() {
+		return nil, errors.ErrAuthorization
+	}
+
+	return &policies.AuthorizeRes{Authorized: true}, nil
+}
+
+func (svc thingsServiceMock) Identify(context.Context, *policies.IdentifyReq, ...grpc.CallOption) (*policies.IdentifyRes, error) {
+	return nil, errors.ErrAuthentication
+}
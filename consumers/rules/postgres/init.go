@@ -0,0 +1,37 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Migration of rules engine service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "rules_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS rules (
+                        id                VARCHAR(254) PRIMARY KEY,
+                        owner_id          VARCHAR(254) NOT NULL,
+                        name              VARCHAR(1024),
+                        channel           VARCHAR(254) NOT NULL,
+                        subtopic          VARCHAR(254),
+                        condition         TEXT,
+                        window_duration   BIGINT,
+                        window_condition  TEXT,
+                        actions           JSONB,
+                        created_at        TIMESTAMP NOT NULL,
+                        updated_at        TIMESTAMP NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS rules_owner_id_idx ON rules (owner_id)`,
+					`CREATE INDEX IF NOT EXISTS rules_channel_idx ON rules (channel)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS rules",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const columns = `id, owner_id, name, channel, subtopic, condition, window_duration, window_condition, actions, created_at, updated_at`
+
+var _ rules.Repository = (*rulesRepo)(nil)
+
+type rulesRepo struct {
+	db postgres.Database
+}
+
+// New instantiates a PostgreSQL implementation of rules repository.
+func New(db postgres.Database) rules.Repository {
+	return &rulesRepo{
+		db: db,
+	}
+}
+
+func (repo rulesRepo) Save(ctx context.Context, r rules.Rule) (rules.Rule, error) {
+	q := fmt.Sprintf(`INSERT INTO rules (%s)
+          VALUES (:id, :owner_id, :name, :channel, :subtopic, :condition, :window_duration, :window_condition, :actions, :created_at, :updated_at)
+          RETURNING %s`, columns, columns)
+
+	dbr, err := toDBRule(r)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	row, err := repo.db.NamedQueryContext(ctx, q, dbr)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return rules.Rule{}, errors.Wrap(errors.ErrConflict, err)
+		}
+		return rules.Rule{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	defer row.Close()
+
+	return scanRule(row, errors.ErrCreateEntity)
+}
+
+func (repo rulesRepo) RetrieveByID(ctx context.Context, id string) (rules.Rule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM rules WHERE id = $1`, columns)
+
+	dbr := dbRule{}
+	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&dbr); err != nil {
+		if err == sql.ErrNoRows {
+			return rules.Rule{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return rules.Rule{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toRule(dbr)
+}
+
+func (repo rulesRepo) RetrieveAll(ctx context.Context, pm rules.PageMetadata) (rules.Page, error) {
+	args := map[string]interface{}{
+		"offset": pm.Offset,
+		"limit":  pm.Limit,
+	}
+	var cond []string
+	if pm.OwnerID != "" {
+		cond = append(cond, "owner_id = :owner_id")
+		args["owner_id"] = pm.OwnerID
+	}
+	if pm.Channel != "" {
+		cond = append(cond, "channel = :channel")
+		args["channel"] = pm.Channel
+	}
+	var where string
+	if len(cond) > 0 {
+		where = fmt.Sprintf("WHERE %s", strings.Join(cond, " AND "))
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM rules %s ORDER BY created_at, id LIMIT :limit OFFSET :offset`, columns, where)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, args)
+	if err != nil {
+		return rules.Page{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var rs []rules.Rule
+	for rows.Next() {
+		dbr := dbRule{}
+		if err := rows.StructScan(&dbr); err != nil {
+			return rules.Page{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		r, err := toRule(dbr)
+		if err != nil {
+			return rules.Page{}, err
+		}
+		rs = append(rs, r)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM rules %s`, where)
+	total, err := postgres.Total(ctx, repo.db, cq, args)
+	if err != nil {
+		return rules.Page{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return rules.Page{
+		PageMetadata: pm,
+		Total:        total,
+		Rules:        rs,
+	}, nil
+}
+
+func (repo rulesRepo) RetrieveByChannel(ctx context.Context, channel string) ([]rules.Rule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM rules WHERE channel = $1`, columns)
+
+	rows, err := repo.db.QueryxContext(ctx, q, channel)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var rs []rules.Rule
+	for rows.Next() {
+		dbr := dbRule{}
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		r, err := toRule(dbr)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+
+	return rs, nil
+}
+
+func (repo rulesRepo) Update(ctx context.Context, r rules.Rule) (rules.Rule, error) {
+	q := fmt.Sprintf(`UPDATE rules SET name = :name, subtopic = :subtopic, condition = :condition,
+          window_duration = :window_duration, window_condition = :window_condition, actions = :actions, updated_at = :updated_at
+          WHERE id = :id RETURNING %s`, columns)
+
+	dbr, err := toDBRule(r)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	row, err := repo.db.NamedQueryContext(ctx, q, dbr)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	return scanRule(row, errors.ErrUpdateEntity)
+}
+
+func (repo rulesRepo) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM rules WHERE id = $1`
+
+	res, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Next() bool
+	StructScan(dest interface{}) error
+}
+
+// scanRule scans the rule returned by the statement, wrapping the failure
+// with the provided error.
+func scanRule(row scanner, wrapper error) (rules.Rule, error) {
+	if !row.Next() {
+		return rules.Rule{}, errors.Wrap(wrapper, errors.ErrNotFound)
+	}
+	dbr := dbRule{}
+	if err := row.StructScan(&dbr); err != nil {
+		return rules.Rule{}, errors.Wrap(wrapper, err)
+	}
+
+	return toRule(dbr)
+}
+
+type dbAction struct {
+	Type     string `json:"type"`
+	Channel  string `json:"channel,omitempty"`
+	Subtopic string `json:"subtopic,omitempty"`
+}
+
+type dbRule struct {
+	ID              string    `db:"id"`
+	OwnerID         string    `db:"owner_id"`
+	Name            string    `db:"name"`
+	Channel         string    `db:"channel"`
+	Subtopic        string    `db:"subtopic"`
+	Condition       string    `db:"condition"`
+	WindowDuration  int64     `db:"window_duration"`
+	WindowCondition string    `db:"window_condition"`
+	Actions         []byte    `db:"actions"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+func toDBRule(r rules.Rule) (dbRule, error) {
+	actions := make([]dbAction, 0, len(r.Actions))
+	for _, a := range r.Actions {
+		actions = append(actions, dbAction(a))
+	}
+	data, err := json.Marshal(actions)
+	if err != nil {
+		return dbRule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return dbRule{
+		ID:              r.ID,
+		OwnerID:         r.OwnerID,
+		Name:            r.Name,
+		Channel:         r.Channel,
+		Subtopic:        r.Subtopic,
+		Condition:       r.Condition,
+		WindowDuration:  int64(r.Window.Duration),
+		WindowCondition: r.Window.Condition,
+		Actions:         data,
+		CreatedAt:       r.CreatedAt.UTC(),
+		UpdatedAt:       r.UpdatedAt.UTC(),
+	}, nil
+}
+
+func toRule(dbr dbRule) (rules.Rule, error) {
+	var actions []dbAction
+	if err := json.Unmarshal(dbr.Actions, &actions); err != nil {
+		return rules.Rule{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	r := rules.Rule{
+		ID:        dbr.ID,
+		OwnerID:   dbr.OwnerID,
+		Name:      dbr.Name,
+		Channel:   dbr.Channel,
+		Subtopic:  dbr.Subtopic,
+		Condition: dbr.Condition,
+		Window: rules.Window{
+			Duration:  time.Duration(dbr.WindowDuration),
+			Condition: dbr.WindowCondition,
+		},
+		CreatedAt: dbr.CreatedAt,
+		UpdatedAt: dbr.UpdatedAt,
+	}
+	for _, a := range actions {
+		r.Actions = append(r.Actions, rules.Action(a))
+	}
+
+	return r, nil
+}
@@ -0,0 +1,243 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	rulespg "github.com/mainflux/mainflux/consumers/rules/postgres"
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+var idProvider = uuid.New()
+
+func newRule(t *testing.T, owner, channel string, created time.Time) rules.Rule {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return rules.Rule{
+		ID:        id,
+		OwnerID:   owner,
+		Name:      "overheating",
+		Channel:   channel,
+		Condition: `name == "temp" && v > 80`,
+		Window: rules.Window{
+			Duration:  5 * time.Minute,
+			Condition: "count() >= 3",
+		},
+		Actions: []rules.Action{
+			{Type: rules.PublishAction, Channel: "alarms", Subtopic: "temp"},
+			{Type: rules.EventAction},
+		},
+		CreatedAt: created.UTC().Round(time.Microsecond),
+		UpdatedAt: created.UTC().Round(time.Microsecond),
+	}
+}
+
+func TestSave(t *testing.T) {
+	repo := rulespg.New(postgres.NewDatabase(db, dbConfig, otel.Tracer("rules")))
+	r := newRule(t, "owner", "chan", time.Now())
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "save rule",
+			rule: r,
+			err:  nil,
+		},
+		{
+			desc: "save existing rule",
+			rule: r,
+			err:  errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := repo.Save(context.Background(), tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.rule, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rule, saved))
+		}
+	}
+}
+
+func TestRetrieveByID(t *testing.T) {
+	repo := rulespg.New(postgres.NewDatabase(db, dbConfig, otel.Tracer("rules")))
+	r, err := repo.Save(context.Background(), newRule(t, "owner", "chan", time.Now()))
+	require.Nil(t, err, fmt.Sprintf("saving rule expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "retrieve rule",
+			id:   r.ID,
+			rule: r,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing rule",
+			id:   "non-existing",
+			rule: rules.Rule{},
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := repo.RetrieveByID(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.rule, r, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rule, r))
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	_, err := db.Exec("DELETE FROM rules")
+	require.Nil(t, err, fmt.Sprintf("cleaning rules expected to succeed: %s", err))
+
+	repo := rulespg.New(postgres.NewDatabase(db, dbConfig, otel.Tracer("rules")))
+	now := time.Now()
+	var rs []rules.Rule
+	for i := 0; i < 10; i++ {
+		owner := "owner"
+		if i%2 == 1 {
+			owner = "other"
+		}
+		r, err := repo.Save(context.Background(), newRule(t, owner, fmt.Sprintf("chan-%d", i%3), now.Add(time.Duration(i)*time.Second)))
+		require.Nil(t, err, fmt.Sprintf("saving rule expected to succeed: %s", err))
+		rs = append(rs, r)
+	}
+
+	cases := []struct {
+		desc  string
+		pm    rules.PageMetadata
+		size  int
+		total uint64
+		first rules.Rule
+	}{
+		{
+			desc:  "retrieve all rules",
+			pm:    rules.PageMetadata{Limit: 100},
+			size:  10,
+			total: 10,
+			first: rs[0],
+		},
+		{
+			desc:  "retrieve rules with offset and limit",
+			pm:    rules.PageMetadata{Offset: 2, Limit: 3},
+			size:  3,
+			total: 10,
+			first: rs[2],
+		},
+		{
+			desc:  "retrieve rules of owner",
+			pm:    rules.PageMetadata{Limit: 100, OwnerID: "other"},
+			size:  5,
+			total: 5,
+			first: rs[1],
+		},
+		{
+			desc:  "retrieve rules of owner and channel",
+			pm:    rules.PageMetadata{Limit: 100, OwnerID: "owner", Channel: "chan-0"},
+			size:  2,
+			total: 2,
+			first: rs[0],
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.pm)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Rules), fmt.Sprintf("%s: expected %d rules got %d\n", tc.desc, tc.size, len(page.Rules)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.Equal(t, tc.first, page.Rules[0], fmt.Sprintf("%s: expected first rule %v got %v\n", tc.desc, tc.first, page.Rules[0]))
+	}
+
+	chRules, err := repo.RetrieveByChannel(context.Background(), "chan-1")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, chRules, 3, fmt.Sprintf("expected 3 rules of channel got %d\n", len(chRules)))
+}
+
+func TestUpdate(t *testing.T) {
+	repo := rulespg.New(postgres.NewDatabase(db, dbConfig, otel.Tracer("rules")))
+	r, err := repo.Save(context.Background(), newRule(t, "owner", "chan", time.Now()))
+	require.Nil(t, err, fmt.Sprintf("saving rule expected to succeed: %s", err))
+
+	updated := r
+	updated.Name = "updated"
+	updated.Condition = "v < 0"
+	updated.Window = rules.Window{}
+	updated.Actions = []rules.Action{{Type: rules.EventAction}}
+	updated.UpdatedAt = time.Now().UTC().Round(time.Microsecond)
+
+	nonExisting := updated
+	nonExisting.ID = "non-existing"
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "update rule",
+			rule: updated,
+			err:  nil,
+		},
+		{
+			desc: "update non-existing rule",
+			rule: nonExisting,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := repo.Update(context.Background(), tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.rule, r, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rule, r))
+		}
+	}
+}
+
+func TestRemove(t *testing.T) {
+	repo := rulespg.New(postgres.NewDatabase(db, dbConfig, otel.Tracer("rules")))
+	r, err := repo.Save(context.Background(), newRule(t, "owner", "chan", time.Now()))
+	require.Nil(t, err, fmt.Sprintf("saving rule expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove rule",
+			id:   r.ID,
+			err:  nil,
+		},
+		{
+			desc: "remove removed rule",
+			id:   r.ID,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"encoding/json"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	mfjson "github.com/mainflux/mainflux/pkg/transformers/json"
+	mfsenml "github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/senml"
+)
+
+// ErrMessage indicates that the message is neither SenML nor JSON.
+var ErrMessage = errors.New("failed to convert message to records")
+
+// record is a single SenML record or JSON message the rules are evaluated against.
+type record struct {
+	channel   string
+	subtopic  string
+	publisher string
+	protocol  string
+	// fields are the record fields available in the rule conditions.
+	fields map[string]interface{}
+	// payload encodes the record the same way it was published.
+	payload func() ([]byte, error)
+}
+
+// records converts the transformed message to records.
+func records(message interface{}) ([]record, error) {
+	switch msgs := message.(type) {
+	case []mfsenml.Message:
+		ret := make([]record, 0, len(msgs))
+		for _, msg := range msgs {
+			ret = append(ret, senmlRecord(msg))
+		}
+		return ret, nil
+	case mfjson.Messages:
+		ret := make([]record, 0, len(msgs.Data))
+		for _, msg := range msgs.Data {
+			ret = append(ret, jsonRecord(msg))
+		}
+		return ret, nil
+	default:
+		return nil, ErrMessage
+	}
+}
+
+func senmlRecord(msg mfsenml.Message) record {
+	fields := map[string]interface{}{
+		"channel":   msg.Channel,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"name":      msg.Name,
+		"unit":      msg.Unit,
+		"time":      msg.Time,
+	}
+	if msg.Value != nil {
+		fields["v"] = *msg.Value
+	}
+	if msg.StringValue != nil {
+		fields["vs"] = *msg.StringValue
+	}
+	if msg.DataValue != nil {
+		fields["vd"] = *msg.DataValue
+	}
+	if msg.BoolValue != nil {
+		fields["vb"] = *msg.BoolValue
+	}
+	if msg.Sum != nil {
+		fields["s"] = *msg.Sum
+	}
+
+	return record{
+		channel:   msg.Channel,
+		subtopic:  msg.Subtopic,
+		publisher: msg.Publisher,
+		protocol:  msg.Protocol,
+		fields:    fields,
+		payload: func() ([]byte, error) {
+			rec := senml.Record{
+				Name:        msg.Name,
+				Unit:        msg.Unit,
+				Time:        msg.Time,
+				UpdateTime:  msg.UpdateTime,
+				Value:       msg.Value,
+				StringValue: msg.StringValue,
+				DataValue:   msg.DataValue,
+				BoolValue:   msg.BoolValue,
+				Sum:         msg.Sum,
+			}
+			return senml.Encode(senml.Pack{Records: []senml.Record{rec}}, senml.JSON)
+		},
+	}
+}
+
+// jsonRecord converts the JSON message to record. Payload fields are
+// available at the top level, next to the message channel, subtopic,
+// publisher and protocol, which take precedence over payload fields.
+func jsonRecord(msg mfjson.Message) record {
+	fields := make(map[string]interface{}, len(msg.Payload)+4)
+	for k, v := range msg.Payload {
+		fields[k] = v
+	}
+	fields["channel"] = msg.Channel
+	fields["subtopic"] = msg.Subtopic
+	fields["publisher"] = msg.Publisher
+	fields["protocol"] = msg.Protocol
+
+	return record{
+		channel:   msg.Channel,
+		subtopic:  msg.Subtopic,
+		publisher: msg.Publisher,
+		protocol:  msg.Protocol,
+		fields:    fields,
+		payload: func() ([]byte, error) {
+			return json.Marshal(msg.Payload)
+		},
+	}
+}
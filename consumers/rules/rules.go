@@ -0,0 +1,90 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// PublishAction publishes the record which triggered the rule to another channel.
+	PublishAction = "publish"
+
+	// EventAction emits the rule trigger event to the event store.
+	EventAction = "event"
+)
+
+// Rule represents a user-defined rule evaluated against the records
+// published to the rule channel.
+type Rule struct {
+	ID       string
+	OwnerID  string
+	Name     string
+	Channel  string
+	Subtopic string
+	// Condition is evaluated against each record. Empty condition matches
+	// all the records.
+	Condition string
+	Window    Window
+	Actions   []Action
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Window aggregates the records matching the rule condition over a sliding
+// time window. The rule with a window is triggered once its condition
+// becomes true, and then again only after it has been false.
+type Window struct {
+	// Duration of the window. Zero duration disables the window, and the
+	// rule is triggered by each record matching its condition.
+	Duration time.Duration
+	// Condition is evaluated against the window aggregates, such as
+	// count() or avg(v).
+	Condition string
+}
+
+// Action represents an action executed when the rule is triggered.
+type Action struct {
+	Type     string
+	Channel  string
+	Subtopic string
+}
+
+// Page represents page metadata with content.
+type Page struct {
+	PageMetadata
+	Total uint64
+	Rules []Rule
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset  uint64
+	Limit   uint64
+	OwnerID string
+	Channel string
+}
+
+// Repository specifies a rule persistence API.
+type Repository interface {
+	// Save persists the rule.
+	Save(ctx context.Context, r Rule) (Rule, error)
+
+	// RetrieveByID retrieves the rule having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Rule, error)
+
+	// RetrieveAll retrieves the rules for the given page metadata.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (Page, error)
+
+	// RetrieveByChannel retrieves all the rules evaluated against the
+	// records published to the channel.
+	RetrieveByChannel(ctx context.Context, channel string) ([]Rule, error)
+
+	// Update updates the rule name, subtopic, conditions, window and actions.
+	Update(ctx context.Context, r Rule) (Rule, error)
+
+	// Remove removes the rule having the provided identifier.
+	Remove(ctx context.Context, id string) error
+}
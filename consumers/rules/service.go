@@ -0,0 +1,411 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/events"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	tpolicies "github.com/mainflux/mainflux/things/policies"
+	"github.com/mainflux/mainflux/users/policies"
+)
+
+// Protocol is the protocol of the messages published by the rules. Rules
+// are not evaluated against such messages to prevent rule loops.
+const Protocol = "rules"
+
+var (
+	// ErrAction indicates failure to execute the rule action.
+	ErrAction = errors.New("failed to execute rule action")
+
+	errNoActions      = errors.New("rule has no actions")
+	errActionType     = errors.New("unknown action type")
+	errActionChannel  = errors.New("publish action requires a channel")
+	errWindow         = errors.New("invalid rule window")
+	errCondCalls      = errors.New("window functions are allowed only in window condition")
+	errWindowNoCalls  = errors.New("window condition must use window functions")
+	errMissingChannel = errors.New("missing rule channel")
+)
+
+var _ consumers.BlockingConsumer = (*service)(nil)
+
+// Service represents a rules engine service.
+type Service interface {
+	// CreateRule creates the rule owned by the user identified by the token.
+	// The user must be allowed to read the messages of the rule channel and
+	// to publish to the channels of the rule publish actions.
+	CreateRule(ctx context.Context, token string, r Rule) (Rule, error)
+
+	// ViewRule retrieves the rule having the provided identifier.
+	ViewRule(ctx context.Context, token, id string) (Rule, error)
+
+	// ListRules lists the rules of the user identified by the token.
+	ListRules(ctx context.Context, token string, pm PageMetadata) (Page, error)
+
+	// UpdateRule updates the rule having the provided identifier. Channels
+	// are authorized the same way as on the rule creation.
+	UpdateRule(ctx context.Context, token string, r Rule) (Rule, error)
+
+	// RemoveRule removes the rule having the provided identifier.
+	RemoveRule(ctx context.Context, token, id string) error
+
+	consumers.BlockingConsumer
+}
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	auth      policies.AuthServiceClient
+	things    tpolicies.AuthServiceClient
+	repo      Repository
+	idp       mainflux.IDProvider
+	publisher messaging.Publisher
+	es        events.Publisher
+
+	mu      sync.Mutex
+	windows map[string]*window
+}
+
+// New instantiates the rules engine service implementation.
+func New(auth policies.AuthServiceClient, things tpolicies.AuthServiceClient, repo Repository, idp mainflux.IDProvider, publisher messaging.Publisher, es events.Publisher) Service {
+	return &service{
+		auth:      auth,
+		things:    things,
+		repo:      repo,
+		idp:       idp,
+		publisher: publisher,
+		es:        es,
+		windows:   make(map[string]*window),
+	}
+}
+
+func (svc *service) CreateRule(ctx context.Context, token string, r Rule) (Rule, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Rule{}, err
+	}
+	if _, err := compile(r); err != nil {
+		return Rule{}, err
+	}
+	if err := svc.authorizeChannels(ctx, userID, r); err != nil {
+		return Rule{}, err
+	}
+
+	if r.ID, err = svc.idp.ID(); err != nil {
+		return Rule{}, err
+	}
+	r.OwnerID = userID
+	r.CreatedAt = time.Now()
+	r.UpdatedAt = r.CreatedAt
+
+	return svc.repo.Save(ctx, r)
+}
+
+func (svc *service) ViewRule(ctx context.Context, token, id string) (Rule, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	return svc.retrieve(ctx, userID, id)
+}
+
+func (svc *service) ListRules(ctx context.Context, token string, pm PageMetadata) (Page, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Page{}, err
+	}
+	pm.OwnerID = userID
+
+	return svc.repo.RetrieveAll(ctx, pm)
+}
+
+func (svc *service) UpdateRule(ctx context.Context, token string, r Rule) (Rule, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Rule{}, err
+	}
+	current, err := svc.retrieve(ctx, userID, r.ID)
+	if err != nil {
+		return Rule{}, err
+	}
+	r.OwnerID = current.OwnerID
+	r.Channel = current.Channel
+	r.CreatedAt = current.CreatedAt
+	r.UpdatedAt = time.Now()
+	if _, err := compile(r); err != nil {
+		return Rule{}, err
+	}
+	if err := svc.authorizeChannels(ctx, userID, r); err != nil {
+		return Rule{}, err
+	}
+
+	r, err = svc.repo.Update(ctx, r)
+	if err != nil {
+		return Rule{}, err
+	}
+	svc.resetWindow(r.ID)
+
+	return r, nil
+}
+
+func (svc *service) RemoveRule(ctx context.Context, token, id string) error {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if _, err := svc.retrieve(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := svc.repo.Remove(ctx, id); err != nil {
+		return err
+	}
+	svc.resetWindow(id)
+
+	return nil
+}
+
+// ConsumeBlocking evaluates the rules against the records of the consumed
+// message and executes the actions of the triggered rules.
+func (svc *service) ConsumeBlocking(ctx context.Context, message interface{}) error {
+	recs, err := records(message)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	channels := make(map[string][]compiled)
+	var ret error
+	for _, rec := range recs {
+		if rec.protocol == Protocol {
+			continue
+		}
+		rs, ok := channels[rec.channel]
+		if !ok {
+			if rs, err = svc.channelRules(ctx, rec.channel); err != nil {
+				return err
+			}
+			channels[rec.channel] = rs
+		}
+		for _, c := range rs {
+			if !svc.triggered(c, rec, now) {
+				continue
+			}
+			if err := svc.execute(ctx, c.rule, rec); err != nil {
+				ret = err
+			}
+		}
+	}
+
+	return ret
+}
+
+func (svc *service) channelRules(ctx context.Context, channel string) ([]compiled, error) {
+	rs, err := svc.repo.RetrieveByChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]compiled, 0, len(rs))
+	for _, r := range rs {
+		// Rules are validated when saved, so a rule which can't be
+		// compiled is skipped instead of failing the whole message.
+		c, err := compile(r)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, c)
+	}
+
+	return ret, nil
+}
+
+// triggered evaluates the rule against the record. The rule without a
+// window is triggered by each matching record, while the rule with a window
+// is triggered only when its window condition becomes true.
+func (svc *service) triggered(c compiled, rec record, now time.Time) bool {
+	if c.rule.Subtopic != "" && c.rule.Subtopic != rec.subtopic {
+		return false
+	}
+	if c.cond != nil && !c.cond.Eval(rec.fields, nil) {
+		return false
+	}
+	if c.window == nil {
+		return true
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	w, ok := svc.windows[c.rule.ID]
+	if !ok {
+		w = &window{duration: c.rule.Window.Duration}
+		svc.windows[c.rule.ID] = w
+	}
+	w.add(now, rec.fields, c.window.Fields())
+	active := c.window.Eval(rec.fields, w.call)
+	ret := active && !w.active
+	w.active = active
+
+	return ret
+}
+
+// execute executes all the rule actions, returning the last failure.
+func (svc *service) execute(ctx context.Context, r Rule, rec record) error {
+	var ret error
+	for _, a := range r.Actions {
+		var err error
+		switch a.Type {
+		case PublishAction:
+			err = svc.publish(ctx, a, rec)
+		case EventAction:
+			err = svc.es.Publish(ctx, triggerEvent{rule: r, record: rec})
+		}
+		if err != nil {
+			ret = errors.Wrap(ErrAction, err)
+		}
+	}
+
+	return ret
+}
+
+// publish publishes the record to the action channel, keeping the original
+// subtopic unless the action specifies one.
+func (svc *service) publish(ctx context.Context, a Action, rec record) error {
+	payload, err := rec.payload()
+	if err != nil {
+		return err
+	}
+	subtopic := a.Subtopic
+	if subtopic == "" {
+		subtopic = rec.subtopic
+	}
+	msg := messaging.Message{
+		Channel:   a.Channel,
+		Subtopic:  subtopic,
+		Publisher: rec.publisher,
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+
+	return svc.publisher.Publish(ctx, a.Channel, &msg)
+}
+
+func (svc *service) resetWindow(id string) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	delete(svc.windows, id)
+}
+
+func (svc *service) identify(ctx context.Context, token string) (string, error) {
+	res, err := svc.auth.Identify(ctx, &policies.IdentifyReq{Token: token})
+	if err != nil {
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return res.GetOwner(), nil
+}
+
+// authorizeChannels checks that the user can read the messages of the rule
+// channel and publish to the channels of the rule publish actions.
+func (svc *service) authorizeChannels(ctx context.Context, userID string, r Rule) error {
+	if err := svc.authorize(ctx, userID, r.Channel, tpolicies.ReadAction); err != nil {
+		return err
+	}
+	for _, a := range r.Actions {
+		if a.Type != PublishAction {
+			continue
+		}
+		if err := svc.authorize(ctx, userID, a.Channel, tpolicies.WriteAction); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (svc *service) authorize(ctx context.Context, userID, chanID, action string) error {
+	req := &tpolicies.AuthorizeReq{Subject: userID, Object: chanID, Action: action, EntityType: tpolicies.GroupEntityType}
+	if _, err := svc.things.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// retrieve retrieves the rule, ensuring that it's owned by the user.
+func (svc *service) retrieve(ctx context.Context, userID, id string) (Rule, error) {
+	r, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Rule{}, err
+	}
+	if r.OwnerID != userID {
+		return Rule{}, errors.ErrAuthorization
+	}
+
+	return r, nil
+}
+
+// compiled is a rule with parsed conditions.
+type compiled struct {
+	rule   Rule
+	cond   *Expression
+	window *Expression
+}
+
+// compile validates the rule and parses its conditions.
+func compile(r Rule) (compiled, error) {
+	c := compiled{rule: r}
+	if r.Channel == "" {
+		return c, errors.Wrap(errors.ErrMalformedEntity, errMissingChannel)
+	}
+	if len(r.Actions) == 0 {
+		return c, errors.Wrap(errors.ErrMalformedEntity, errNoActions)
+	}
+	for _, a := range r.Actions {
+		switch a.Type {
+		case PublishAction:
+			if a.Channel == "" {
+				return c, errors.Wrap(errors.ErrMalformedEntity, errActionChannel)
+			}
+		case EventAction:
+		default:
+			return c, errors.Wrap(errors.ErrMalformedEntity, errActionType)
+		}
+	}
+
+	if r.Condition != "" {
+		cond, err := ParseExpression(r.Condition)
+		if err != nil {
+			return c, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		if cond.Calls() {
+			return c, errors.Wrap(errors.ErrMalformedEntity, errCondCalls)
+		}
+		c.cond = cond
+	}
+
+	switch {
+	case r.Window.Duration < 0,
+		r.Window.Duration == 0 && r.Window.Condition != "",
+		r.Window.Duration > 0 && r.Window.Condition == "":
+		return c, errors.Wrap(errors.ErrMalformedEntity, errWindow)
+	case r.Window.Duration > 0:
+		window, err := ParseExpression(r.Window.Condition)
+		if err != nil {
+			return c, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		if !window.Calls() {
+			return c, errors.Wrap(errors.ErrMalformedEntity, errWindowNoCalls)
+		}
+		c.window = window
+	}
+
+	return c, nil
+}
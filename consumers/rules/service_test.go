@@ -0,0 +1,523 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/consumers/rules/mocks"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token      = "token"
+	otherToken = "other-token"
+	userID     = "user"
+	otherID    = "other"
+	chanID     = "chan"
+	alarmsID   = "alarms"
+	failChan   = "fail"
+	otherChan  = "other-chan"
+)
+
+func newService() (rules.Service, *mocks.Publisher, *mocks.EventPublisher) {
+	auth := mocks.NewAuth(map[string]string{token: userID, otherToken: otherID})
+	pub := mocks.NewPublisher(failChan)
+	es := mocks.NewEventPublisher()
+	things := mocks.NewThings(map[string]string{otherChan: otherID})
+	return rules.New(auth, things, mocks.NewRepo(), uuid.NewMock(), pub, es), pub, es
+}
+
+func newRule(condition string, window rules.Window, actions ...rules.Action) rules.Rule {
+	if len(actions) == 0 {
+		actions = []rules.Action{{Type: rules.PublishAction, Channel: alarmsID}}
+	}
+	return rules.Rule{
+		Name:      "overheating",
+		Channel:   chanID,
+		Condition: condition,
+		Window:    window,
+		Actions:   actions,
+	}
+}
+
+func temperature(v float64) senml.Message {
+	return senml.Message{
+		Channel:   chanID,
+		Publisher: "thing",
+		Protocol:  "http",
+		Name:      "temp",
+		Unit:      "C",
+		Time:      1,
+		Value:     &v,
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	svc, _, _ := newService()
+
+	cases := []struct {
+		desc  string
+		token string
+		rule  rules.Rule
+		err   error
+	}{
+		{
+			desc:  "create rule",
+			token: token,
+			rule:  newRule(`name == "temp" && v > 80`, rules.Window{}),
+			err:   nil,
+		},
+		{
+			desc:  "create rule with window",
+			token: token,
+			rule:  newRule(`v > 80`, rules.Window{Duration: time.Minute, Condition: "count() >= 3 && avg(v) > 85"}),
+			err:   nil,
+		},
+		{
+			desc:  "create rule with invalid token",
+			token: "invalid",
+			rule:  newRule(`v > 80`, rules.Window{}),
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "create rule with invalid condition",
+			token: token,
+			rule:  newRule(`v >`, rules.Window{}),
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with unsupported condition",
+			token: token,
+			rule:  newRule(`v[0] > 80`, rules.Window{}),
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with window function in condition",
+			token: token,
+			rule:  newRule(`count() > 80`, rules.Window{}),
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with window condition without window functions",
+			token: token,
+			rule:  newRule(`v > 80`, rules.Window{Duration: time.Minute, Condition: "v > 80"}),
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with window without condition",
+			token: token,
+			rule:  newRule(`v > 80`, rules.Window{Duration: time.Minute}),
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule without actions",
+			token: token,
+			rule:  rules.Rule{Channel: chanID, Condition: "v > 80"},
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with unknown action",
+			token: token,
+			rule:  newRule(`v > 80`, rules.Window{}, rules.Action{Type: "unknown"}),
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with publish action without channel",
+			token: token,
+			rule:  newRule(`v > 80`, rules.Window{}, rules.Action{Type: rules.PublishAction}),
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule on channel without read access",
+			token: token,
+			rule:  rules.Rule{Channel: otherChan, Condition: "v > 80", Actions: []rules.Action{{Type: rules.EventAction}}},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "create rule publishing to channel without write access",
+			token: token,
+			rule:  newRule(`v > 80`, rules.Window{}, rules.Action{Type: rules.PublishAction, Channel: otherChan}),
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := svc.CreateRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, r.ID, fmt.Sprintf("%s: expected rule ID\n", tc.desc))
+			assert.Equal(t, userID, r.OwnerID, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, userID, r.OwnerID))
+		}
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	svc, _, _ := newService()
+	r, err := svc.CreateRule(context.Background(), token, newRule(`v > 80`, rules.Window{}))
+	require.Nil(t, err, fmt.Sprintf("creating rule expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "view rule",
+			token: token,
+			id:    r.ID,
+			err:   nil,
+		},
+		{
+			desc:  "view rule of other user",
+			token: otherToken,
+			id:    r.ID,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "view rule with invalid token",
+			token: "invalid",
+			id:    r.ID,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "view non-existing rule",
+			token: token,
+			id:    "non-existing",
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		rule, err := svc.ViewRule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, r, rule, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, r, rule))
+		}
+	}
+}
+
+func TestListRules(t *testing.T) {
+	svc, _, _ := newService()
+	for i := 0; i < 3; i++ {
+		_, err := svc.CreateRule(context.Background(), token, newRule(`v > 80`, rules.Window{}))
+		require.Nil(t, err, fmt.Sprintf("creating rule expected to succeed: %s", err))
+	}
+	_, err := svc.CreateRule(context.Background(), otherToken, newRule(`v > 80`, rules.Window{}))
+	require.Nil(t, err, fmt.Sprintf("creating rule expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    rules.PageMetadata
+		size  int
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list rules",
+			token: token,
+			pm:    rules.PageMetadata{Limit: 10},
+			size:  3,
+			total: 3,
+		},
+		{
+			desc:  "list rules with offset and limit",
+			token: token,
+			pm:    rules.PageMetadata{Offset: 1, Limit: 1},
+			size:  1,
+			total: 3,
+		},
+		{
+			desc:  "list rules of other user",
+			token: otherToken,
+			pm:    rules.PageMetadata{Limit: 10},
+			size:  1,
+			total: 1,
+		},
+		{
+			desc:  "list rules of channel without rules",
+			token: token,
+			pm:    rules.PageMetadata{Limit: 10, Channel: alarmsID},
+			size:  0,
+			total: 0,
+		},
+		{
+			desc:  "list rules with invalid token",
+			token: "invalid",
+			pm:    rules.PageMetadata{Limit: 10},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListRules(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Rules), fmt.Sprintf("%s: expected %d rules got %d\n", tc.desc, tc.size, len(page.Rules)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	svc, _, _ := newService()
+	r, err := svc.CreateRule(context.Background(), token, newRule(`v > 80`, rules.Window{}))
+	require.Nil(t, err, fmt.Sprintf("creating rule expected to succeed: %s", err))
+
+	updated := newRule(`v > 90`, rules.Window{}, rules.Action{Type: rules.EventAction})
+	updated.ID = r.ID
+	updated.Channel = "other"
+	invalid := updated
+	invalid.Condition = "v >"
+	nonExisting := updated
+	nonExisting.ID = "non-existing"
+	forbidden := updated
+	forbidden.Actions = []rules.Action{{Type: rules.PublishAction, Channel: otherChan}}
+
+	cases := []struct {
+		desc  string
+		token string
+		rule  rules.Rule
+		err   error
+	}{
+		{
+			desc:  "update rule of other user",
+			token: otherToken,
+			rule:  updated,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update rule with invalid condition",
+			token: token,
+			rule:  invalid,
+			err:   errors.ErrMalformedEntity,
+		},
+		{
+			desc:  "update non-existing rule",
+			token: token,
+			rule:  nonExisting,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "update rule publishing to channel without write access",
+			token: token,
+			rule:  forbidden,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update rule",
+			token: token,
+			rule:  updated,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		rule, err := svc.UpdateRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.rule.Condition, rule.Condition, fmt.Sprintf("%s: expected condition %s got %s\n", tc.desc, tc.rule.Condition, rule.Condition))
+			assert.Equal(t, tc.rule.Actions, rule.Actions, fmt.Sprintf("%s: expected actions %v got %v\n", tc.desc, tc.rule.Actions, rule.Actions))
+			assert.Equal(t, chanID, rule.Channel, fmt.Sprintf("%s: expected channel not to be updated\n", tc.desc))
+			assert.Equal(t, r.CreatedAt, rule.CreatedAt, fmt.Sprintf("%s: expected creation time not to be updated\n", tc.desc))
+		}
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	svc, _, _ := newService()
+	r, err := svc.CreateRule(context.Background(), token, newRule(`v > 80`, rules.Window{}))
+	require.Nil(t, err, fmt.Sprintf("creating rule expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove rule of other user",
+			token: otherToken,
+			id:    r.ID,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "remove rule",
+			token: token,
+			id:    r.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed rule",
+			token: token,
+			id:    r.ID,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveRule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	cases := []struct {
+		desc      string
+		rule      rules.Rule
+		msgs      interface{}
+		published int
+		events    int
+		err       error
+	}{
+		{
+			desc:      "consume record matching rule",
+			rule:      newRule(`name == "temp" && v > 80`, rules.Window{}),
+			msgs:      []senml.Message{temperature(81), temperature(79), temperature(90)},
+			published: 2,
+		},
+		{
+			desc:      "consume record matching rule without condition",
+			rule:      newRule("", rules.Window{}),
+			msgs:      []senml.Message{temperature(81), temperature(79)},
+			published: 2,
+		},
+		{
+			desc:      "consume record missing condition field",
+			rule:      newRule(`vs == "on"`, rules.Window{}),
+			msgs:      []senml.Message{temperature(81)},
+			published: 0,
+		},
+		{
+			desc:   "consume record matching rule with event action",
+			rule:   newRule(`v > 80`, rules.Window{}, rules.Action{Type: rules.EventAction}),
+			msgs:   []senml.Message{temperature(81)},
+			events: 1,
+		},
+		{
+			desc:      "consume records matching rule with count window",
+			rule:      newRule(`v > 80`, rules.Window{Duration: time.Hour, Condition: "count() >= 3"}),
+			msgs:      []senml.Message{temperature(81), temperature(82), temperature(10), temperature(83), temperature(84)},
+			published: 1,
+		},
+		{
+			desc:      "consume records matching rule with avg window",
+			rule:      newRule("", rules.Window{Duration: time.Hour, Condition: "avg(v) > 50"}),
+			msgs:      []senml.Message{temperature(40), temperature(70), temperature(80), temperature(0), temperature(0), temperature(200)},
+			published: 2,
+		},
+		{
+			desc: "consume JSON message matching rule",
+			rule: newRule(`temperature.value > 80`, rules.Window{}),
+			msgs: json.Messages{
+				Format: "thermostat",
+				Data: []json.Message{
+					{Channel: chanID, Subtopic: "thermostat", Payload: json.Payload{"temperature": map[string]interface{}{"value": 81.0}}},
+					{Channel: chanID, Subtopic: "thermostat", Payload: json.Payload{"temperature": map[string]interface{}{"value": 21.0}}},
+				},
+			},
+			published: 1,
+		},
+		{
+			desc: "consume record published by rule",
+			rule: newRule(`v > 80`, rules.Window{}),
+			msgs: func() []senml.Message {
+				msg := temperature(81)
+				msg.Protocol = rules.Protocol
+				return []senml.Message{msg}
+			}(),
+			published: 0,
+		},
+		{
+			desc: "consume record of other channel",
+			rule: newRule(`v > 80`, rules.Window{}),
+			msgs: func() []senml.Message {
+				msg := temperature(81)
+				msg.Channel = alarmsID
+				return []senml.Message{msg}
+			}(),
+			published: 0,
+		},
+		{
+			desc:      "consume record when publishing fails",
+			rule:      newRule(`v > 80`, rules.Window{}, rules.Action{Type: rules.PublishAction, Channel: failChan}, rules.Action{Type: rules.EventAction}),
+			msgs:      []senml.Message{temperature(81)},
+			published: 0,
+			events:    1,
+			err:       rules.ErrAction,
+		},
+		{
+			desc: "consume invalid message",
+			rule: newRule(`v > 80`, rules.Window{}),
+			msgs: "message",
+			err:  rules.ErrMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, pub, es := newService()
+		_, err := svc.CreateRule(context.Background(), token, tc.rule)
+		require.Nil(t, err, fmt.Sprintf("%s: creating rule expected to succeed: %s", tc.desc, err))
+
+		err = svc.ConsumeBlocking(context.Background(), tc.msgs)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Len(t, pub.Messages(), tc.published, fmt.Sprintf("%s: expected %d published messages got %d\n", tc.desc, tc.published, len(pub.Messages())))
+		assert.Len(t, es.Events(), tc.events, fmt.Sprintf("%s: expected %d events got %d\n", tc.desc, tc.events, len(es.Events())))
+		for _, msg := range pub.Messages() {
+			assert.Equal(t, alarmsID, msg.Channel, fmt.Sprintf("%s: expected message published to %s got %s\n", tc.desc, alarmsID, msg.Channel))
+			assert.Equal(t, rules.Protocol, msg.Protocol, fmt.Sprintf("%s: expected message with %s protocol got %s\n", tc.desc, rules.Protocol, msg.Protocol))
+		}
+	}
+}
+
+func TestConsumeBlockingPayload(t *testing.T) {
+	svc, pub, es := newService()
+	actions := []rules.Action{
+		{Type: rules.PublishAction, Channel: alarmsID, Subtopic: "overheating"},
+		{Type: rules.EventAction},
+	}
+	r, err := svc.CreateRule(context.Background(), token, newRule(`v > 80`, rules.Window{}, actions...))
+	require.Nil(t, err, fmt.Sprintf("creating rule expected to succeed: %s", err))
+
+	err = svc.ConsumeBlocking(context.Background(), []senml.Message{temperature(81)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	require.Len(t, pub.Messages(), 1, "expected published message")
+	msg := pub.Messages()[0]
+	assert.Equal(t, "overheating", msg.Subtopic, fmt.Sprintf("expected subtopic overheating got %s", msg.Subtopic))
+	assert.Equal(t, "thing", msg.Publisher, fmt.Sprintf("expected original publisher got %s", msg.Publisher))
+	assert.JSONEq(t, `[{"n":"temp","u":"C","t":1,"v":81}]`, string(msg.Payload), fmt.Sprintf("unexpected payload %s", msg.Payload))
+
+	require.Len(t, es.Events(), 1, "expected emitted event")
+	event := es.Events()[0]
+	assert.Equal(t, "rule.trigger", event["operation"], fmt.Sprintf("expected rule trigger event got %v", event["operation"]))
+	assert.Equal(t, r.ID, event["id"], fmt.Sprintf("expected rule id %s got %v", r.ID, event["id"]))
+	assert.Equal(t, userID, event["owner"], fmt.Sprintf("expected owner %s got %v", userID, event["owner"]))
+}
+
+func TestConsumeBlockingWindowExpiry(t *testing.T) {
+	svc, pub, _ := newService()
+	_, err := svc.CreateRule(context.Background(), token, newRule(`v > 80`, rules.Window{Duration: 50 * time.Millisecond, Condition: "count() >= 2"}))
+	require.Nil(t, err, fmt.Sprintf("creating rule expected to succeed: %s", err))
+
+	err = svc.ConsumeBlocking(context.Background(), []senml.Message{temperature(81)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	time.Sleep(100 * time.Millisecond)
+	err = svc.ConsumeBlocking(context.Background(), []senml.Message{temperature(82)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, pub.Messages(), "expected expired records not to be counted")
+
+	err = svc.ConsumeBlocking(context.Background(), []senml.Message{temperature(83)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, pub.Messages(), 1, fmt.Sprintf("expected 1 published message got %d", len(pub.Messages())))
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing provides tracing instrumentation for Mainflux rules engine
+// service repository.
+//
+// For more details about tracing instrumentation for Mainflux messaging refer
+// to the documentation at https://docs.mainflux.io/tracing/.
+package tracing
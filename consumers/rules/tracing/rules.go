@@ -0,0 +1,88 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	saveOp              = "save_op"
+	retrieveByIDOp      = "retrieve_by_id_op"
+	retrieveAllOp       = "retrieve_all_op"
+	retrieveByChannelOp = "retrieve_by_channel_op"
+	updateOp            = "update_op"
+	removeOp            = "remove_op"
+)
+
+var _ rules.Repository = (*repositoryMiddleware)(nil)
+
+type repositoryMiddleware struct {
+	tracer trace.Tracer
+	repo   rules.Repository
+}
+
+// New instantiates a new rules repository that
+// tracks request and their latency, and adds spans to context.
+func New(tracer trace.Tracer, repo rules.Repository) rules.Repository {
+	return repositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+// Save traces the "Save" operation of the wrapped rules repository.
+func (rm repositoryMiddleware) Save(ctx context.Context, r rules.Rule) (rules.Rule, error) {
+	ctx, span := rm.tracer.Start(ctx, saveOp, trace.WithAttributes(
+		attribute.String("id", r.ID),
+		attribute.String("channel", r.Channel),
+	))
+	defer span.End()
+
+	return rm.repo.Save(ctx, r)
+}
+
+// RetrieveByID traces the "RetrieveByID" operation of the wrapped rules repository.
+func (rm repositoryMiddleware) RetrieveByID(ctx context.Context, id string) (rules.Rule, error) {
+	ctx, span := rm.tracer.Start(ctx, retrieveByIDOp, trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return rm.repo.RetrieveByID(ctx, id)
+}
+
+// RetrieveAll traces the "RetrieveAll" operation of the wrapped rules repository.
+func (rm repositoryMiddleware) RetrieveAll(ctx context.Context, pm rules.PageMetadata) (rules.Page, error) {
+	ctx, span := rm.tracer.Start(ctx, retrieveAllOp)
+	defer span.End()
+
+	return rm.repo.RetrieveAll(ctx, pm)
+}
+
+// RetrieveByChannel traces the "RetrieveByChannel" operation of the wrapped rules repository.
+func (rm repositoryMiddleware) RetrieveByChannel(ctx context.Context, channel string) ([]rules.Rule, error) {
+	ctx, span := rm.tracer.Start(ctx, retrieveByChannelOp, trace.WithAttributes(attribute.String("channel", channel)))
+	defer span.End()
+
+	return rm.repo.RetrieveByChannel(ctx, channel)
+}
+
+// Update traces the "Update" operation of the wrapped rules repository.
+func (rm repositoryMiddleware) Update(ctx context.Context, r rules.Rule) (rules.Rule, error) {
+	ctx, span := rm.tracer.Start(ctx, updateOp, trace.WithAttributes(attribute.String("id", r.ID)))
+	defer span.End()
+
+	return rm.repo.Update(ctx, r)
+}
+
+// Remove traces the "Remove" operation of the wrapped rules repository.
+func (rm repositoryMiddleware) Remove(ctx context.Context, id string) error {
+	ctx, span := rm.tracer.Start(ctx, removeOp, trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return rm.repo.Remove(ctx, id)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import "time"
+
+// entry represents a record in the sliding window. Only the record fields
+// used as window function arguments are kept.
+type entry struct {
+	time   time.Time
+	values map[string]float64
+}
+
+// window keeps the records matching the rule condition which are received
+// during the window duration.
+type window struct {
+	duration time.Duration
+	entries  []entry
+	// active is the result of the last evaluation, used to trigger the rule
+	// only when its window condition becomes true.
+	active bool
+}
+
+// add adds the record to the window and removes the expired entries.
+func (w *window) add(now time.Time, record map[string]interface{}, fields []string) {
+	e := entry{time: now, values: map[string]float64{}}
+	for _, f := range fields {
+		if v, ok := field(record, f).(float64); ok {
+			e.values[f] = v
+		}
+	}
+	w.entries = append(w.entries, e)
+
+	start := now.Add(-w.duration)
+	i := 0
+	for i < len(w.entries) && !w.entries[i].time.After(start) {
+		i++
+	}
+	w.entries = w.entries[i:]
+}
+
+// call resolves the window functions. Call without the field argument
+// counts all the entries, otherwise only the entries having the field.
+func (w *window) call(name, f string) interface{} {
+	var count, sum float64
+	for _, e := range w.entries {
+		if f == "" {
+			count++
+			continue
+		}
+		if v, ok := e.values[f]; ok {
+			count++
+			sum += v
+		}
+	}
+
+	switch name {
+	case countFunc:
+		return count
+	case avgFunc:
+		if count == 0 {
+			return nil
+		}
+		return sum / count
+	default:
+		return nil
+	}
+}
@@ -7,14 +7,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
 	"github.com/mainflux/mainflux/pkg/errors"
 	mfjson "github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 )
 
+const (
+	// maxTTL is the maximum TTL supported by Cassandra (20 years).
+	maxTTL = 630720000
+
+	// maxBatchSize is the maximum number of statements in a single batch,
+	// which keeps the batch within the Cassandra batch size threshold.
+	maxBatchSize = 100
+)
+
 var (
 	errSaveMessage = errors.New("failed to save message to cassandra database")
 	errNoTable     = errors.New("table does not exist")
@@ -22,12 +34,18 @@ var (
 var _ consumers.BlockingConsumer = (*cassandraRepository)(nil)
 
 type cassandraRepository struct {
-	session *gocql.Session
+	session  *gocql.Session
+	policies retention.Policies
 }
 
-// New instantiates Cassandra message repository.
-func New(session *gocql.Session) consumers.BlockingConsumer {
-	return &cassandraRepository{session}
+// New instantiates Cassandra message repository. Messages are inserted with
+// the TTL derived from the retention period of their channel, so Cassandra
+// removes them once they expire.
+func New(session *gocql.Session, policies retention.Policies) consumers.BlockingConsumer {
+	return &cassandraRepository{
+		session:  session,
+		policies: policies,
+	}
 }
 
 func (cr *cassandraRepository) ConsumeBlocking(_ context.Context, message interface{}) error {
@@ -47,14 +65,29 @@ func (cr *cassandraRepository) saveSenml(messages interface{}) error {
 	cql := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
             name, unit, value, string_value, bool_value, data_value, sum,
             time, update_time)
-            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	id := gocql.TimeUUID()
-
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) USING TTL ?`
+	batch := cr.session.NewBatch(gocql.UnloggedBatch)
 	for _, msg := range msgs {
-		err := cr.session.Query(cql, id, msg.Channel, msg.Subtopic, msg.Publisher,
+		created := time.Unix(0, int64(msg.Time*float64(time.Second)))
+		ttl, ok := cr.ttl(msg.Channel, created)
+		if !ok {
+			continue
+		}
+		// Each message gets its own ID, since messages merged into
+		// a single batch may share the channel and the time.
+		id := gocql.TimeUUID()
+		batch.Query(cql, id, msg.Channel, msg.Subtopic, msg.Publisher,
 			msg.Protocol, msg.Name, msg.Unit, msg.Value, msg.StringValue,
-			msg.BoolValue, msg.DataValue, msg.Sum, msg.Time, msg.UpdateTime).Exec()
-		if err != nil {
+			msg.BoolValue, msg.DataValue, msg.Sum, msg.Time, msg.UpdateTime, ttl)
+		if batch.Size() == maxBatchSize {
+			if err := cr.session.ExecuteBatch(batch); err != nil {
+				return errors.Wrap(errSaveMessage, err)
+			}
+			batch = cr.session.NewBatch(gocql.UnloggedBatch)
+		}
+	}
+	if batch.Size() > 0 {
+		if err := cr.session.ExecuteBatch(batch); err != nil {
 			return errors.Wrap(errSaveMessage, err)
 		}
 	}
@@ -76,22 +109,40 @@ func (cr *cassandraRepository) saveJSON(msgs mfjson.Messages) error {
 }
 
 func (cr *cassandraRepository) insertJSON(msgs mfjson.Messages) error {
-	cql := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	cql := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload) VALUES (?, ?, ?, ?, ?, ?, ?) USING TTL ?`
 	cql = fmt.Sprintf(cql, msgs.Format)
+	batch := cr.session.NewBatch(gocql.UnloggedBatch)
 	for _, msg := range msgs.Data {
+		ttl, ok := cr.ttl(msg.Channel, time.Unix(0, msg.Created))
+		if !ok {
+			continue
+		}
 		pld, err := json.Marshal(msg.Payload)
 		if err != nil {
 			return err
 		}
 		id := gocql.TimeUUID()
 
-		err = cr.session.Query(cql, id, msg.Channel, msg.Created, msg.Subtopic, msg.Publisher, msg.Protocol, string(pld)).Exec()
-		if err != nil {
-			if err.Error() == fmt.Sprintf("unconfigured table %s", msgs.Format) {
-				return errNoTable
+		batch.Query(cql, id, msg.Channel, msg.Created, msg.Subtopic, msg.Publisher, msg.Protocol, string(pld), ttl)
+		if batch.Size() == maxBatchSize {
+			if err := cr.executeJSON(batch, msgs.Format); err != nil {
+				return err
 			}
-			return errors.Wrap(errSaveMessage, err)
+			batch = cr.session.NewBatch(gocql.UnloggedBatch)
+		}
+	}
+	if batch.Size() > 0 {
+		return cr.executeJSON(batch, msgs.Format)
+	}
+	return nil
+}
+
+func (cr *cassandraRepository) executeJSON(batch *gocql.Batch, table string) error {
+	if err := cr.session.ExecuteBatch(batch); err != nil {
+		if err.Error() == fmt.Sprintf("unconfigured table %s", table) {
+			return errNoTable
 		}
+		return errors.Wrap(errSaveMessage, err)
 	}
 	return nil
 }
@@ -100,3 +151,20 @@ func (cr *cassandraRepository) createTable(name string) error {
 	q := fmt.Sprintf(jsonTable, name)
 	return cr.session.Query(q).Exec()
 }
+
+// ttl returns the TTL in seconds of the message created at the given time.
+// Zero TTL means that the message never expires. If the message has already
+// expired, false is returned and the message shouldn't be saved.
+func (cr *cassandraRepository) ttl(chanID string, created time.Time) (int64, bool) {
+	period := cr.policies.Period(chanID)
+	if period == 0 {
+		return 0, true
+	}
+
+	left := time.Until(created.Add(period))
+	if left <= 0 {
+		return 0, false
+	}
+
+	return int64(math.Min(math.Ceil(left.Seconds()), maxTTL)), true
+}
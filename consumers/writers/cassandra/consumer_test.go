@@ -11,6 +11,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/mainflux/mainflux/consumers/writers/cassandra"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
 	casclient "github.com/mainflux/mainflux/internal/clients/cassandra"
 	"github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
@@ -43,7 +44,7 @@ func TestSaveSenml(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
 	err = casclient.InitDB(session, cassandra.Table)
 	require.Nil(t, err, fmt.Sprintf("failed to initialize to Cassandra: %s", err))
-	repo := cassandra.New(session)
+	repo := cassandra.New(session, retention.NewPolicies(retention.Policy{}))
 	now := time.Now().Unix()
 	msg := senml.Message{
 		Channel:   "1",
@@ -77,13 +78,55 @@ func TestSaveSenml(t *testing.T) {
 	assert.Nil(t, err, fmt.Sprintf("expected no error, got %s", err))
 }
 
+func TestSaveSenmlRetention(t *testing.T) {
+	session, err := casclient.Connect(casclient.Config{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	err = casclient.InitDB(session, cassandra.Table)
+	require.Nil(t, err, fmt.Sprintf("failed to initialize to Cassandra: %s", err))
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	policies := retention.NewPolicies(retention.Policy{
+		Channels: map[string]time.Duration{chid.String(): time.Hour},
+	})
+	repo := cassandra.New(session, policies)
+
+	now := time.Now()
+	msgs := []senml.Message{
+		{Channel: chid.String(), Publisher: "1", Protocol: "mqtt", Name: "fresh", Value: &v, Time: float64(now.Unix())},
+		{Channel: chid.String(), Publisher: "1", Protocol: "mqtt", Name: "expired", Value: &v, Time: float64(now.Add(-2 * time.Hour).Unix())},
+	}
+	err = repo.ConsumeBlocking(context.TODO(), msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error, got %s", err))
+
+	var names []string
+	var ttls []int
+	iter := session.Query(`SELECT name, TTL(value) FROM messages WHERE channel = ? ALLOW FILTERING`, chid.String()).Iter()
+	var name string
+	var ttl int
+	for iter.Scan(&name, &ttl) {
+		names = append(names, name)
+		ttls = append(ttls, ttl)
+	}
+	err = iter.Close()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	assert.Equal(t, []string{"fresh"}, names, "expected only the message which is not expired to be saved")
+	for _, ttl := range ttls {
+		assert.True(t, ttl > 0 && ttl <= int(time.Hour.Seconds()), fmt.Sprintf("expected TTL within retention period, got %d", ttl))
+	}
+}
+
 func TestSaveJSON(t *testing.T) {
 	session, err := casclient.Connect(casclient.Config{
 		Hosts:    []string{addr},
 		Keyspace: keyspace,
 	})
 	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
-	repo := cassandra.New(session)
+	repo := cassandra.New(session, retention.NewPolicies(retention.Policy{}))
 	chid, err := uuid.NewV4()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
 	pubid, err := uuid.NewV4()
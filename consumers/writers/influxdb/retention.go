@@ -0,0 +1,154 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// minBucketRetention is the shortest bucket retention period supported by InfluxDB.
+const minBucketRetention = time.Hour
+
+var errPurge = errors.New("failed to purge expired messages from influxdb database")
+
+var _ retention.Purger = (*influxPurger)(nil)
+
+type influxPurger struct {
+	client influxdb2.Client
+	cfg    RepoConfig
+}
+
+// NewPurger returns new InfluxDB purger. Bucket retention is set to the
+// longest retention period, while the messages of the channels with shorter
+// retention periods are removed using the delete API.
+func NewPurger(client influxdb2.Client, config RepoConfig) retention.Purger {
+	return &influxPurger{
+		client: client,
+		cfg:    config,
+	}
+}
+
+func (ip *influxPurger) Purge(ctx context.Context, policy retention.Policy) (uint64, error) {
+	bucketPeriod, err := ip.updateBucket(ctx, policy.Longest())
+	if err != nil {
+		return 0, errors.Wrap(errPurge, err)
+	}
+
+	channels, err := ip.channels(ctx)
+	if err != nil {
+		return 0, errors.Wrap(errPurge, err)
+	}
+
+	now := time.Now()
+	var total uint64
+	for _, chanID := range channels {
+		period := policy.ChannelPeriod(chanID)
+		if period == 0 || (bucketPeriod > 0 && period >= bucketPeriod) {
+			continue
+		}
+		stop := now.Add(-period)
+		n, err := ip.count(ctx, chanID, stop)
+		if err != nil {
+			return total, errors.Wrap(errPurge, err)
+		}
+		if n == 0 {
+			continue
+		}
+		predicate := fmt.Sprintf(`channel="%s"`, chanID)
+		if err := ip.client.DeleteAPI().DeleteWithName(ctx, ip.cfg.Org, ip.cfg.Bucket, time.Unix(0, 0), stop, predicate); err != nil {
+			return total, errors.Wrap(errPurge, err)
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+// updateBucket sets the bucket retention period to the given one and returns
+// the bucket retention period. If the given period is zero, the bucket is
+// left intact, so that its retention can be managed outside of Mainflux.
+func (ip *influxPurger) updateBucket(ctx context.Context, period time.Duration) (time.Duration, error) {
+	bucket, err := ip.client.BucketsAPI().FindBucketByName(ctx, ip.cfg.Bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	var current time.Duration
+	for _, rule := range bucket.RetentionRules {
+		current = time.Duration(rule.EverySeconds) * time.Second
+	}
+	if period == 0 {
+		return current, nil
+	}
+
+	if period < minBucketRetention {
+		period = minBucketRetention
+	}
+	if period == current {
+		return current, nil
+	}
+
+	expire := domain.RetentionRuleTypeExpire
+	bucket.RetentionRules = domain.RetentionRules{{
+		EverySeconds: int64(period / time.Second),
+		Type:         &expire,
+	}}
+	if _, err := ip.client.BucketsAPI().UpdateBucket(ctx, bucket); err != nil {
+		return 0, err
+	}
+
+	return period, nil
+}
+
+// channels returns IDs of all the channels which have messages in the bucket.
+func (ip *influxPurger) channels(ctx context.Context) ([]string, error) {
+	query := fmt.Sprintf(`import "influxdata/influxdb/schema"
+	schema.tagValues(bucket: "%s", tag: "channel", start: 0)`, ip.cfg.Bucket)
+
+	resp, err := ip.client.QueryAPI(ip.cfg.Org).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var channels []string
+	for resp.Next() {
+		if chanID, ok := resp.Record().Value().(string); ok {
+			channels = append(channels, chanID)
+		}
+	}
+
+	return channels, resp.Err()
+}
+
+// count returns the number of field values of the given channel written
+// before the given time.
+func (ip *influxPurger) count(ctx context.Context, chanID string, stop time.Time) (uint64, error) {
+	query := fmt.Sprintf(`from(bucket: "%s")
+	|> range(start: 0, stop: %s)
+	|> filter(fn: (r) => r["channel"] == "%s")
+	|> group()
+	|> count()`, ip.cfg.Bucket, stop.UTC().Format(time.RFC3339Nano), chanID)
+
+	resp, err := ip.client.QueryAPI(ip.cfg.Org).Query(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	if !resp.Next() {
+		return 0, resp.Err()
+	}
+	count, ok := resp.Record().Value().(int64)
+	if !ok {
+		return 0, nil
+	}
+
+	return uint64(count), nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	writer "github.com/mainflux/mainflux/consumers/writers/influxdb"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurge(t *testing.T) {
+	err := resetBucket()
+	require.Nil(t, err, fmt.Sprintf("Cleaning data from InfluxDB expected to succeed: %s.\n", err))
+
+	syncRepo := writer.NewSync(client, repoCfg)
+	purger := writer.NewPurger(client, repoCfg)
+
+	shortChan, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s\n", err))
+	defChan, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s\n", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s\n", err))
+
+	msgsNum := 10
+	now := time.Now()
+	var msgs []senml.Message
+	for _, chanID := range []string{shortChan, defChan} {
+		for i := 0; i < msgsNum; i++ {
+			created := now.Add(-time.Duration(i) * time.Second)
+			if i%2 == 0 {
+				created = created.Add(-3 * time.Hour)
+			}
+			msgs = append(msgs, senml.Message{
+				Channel:   chanID,
+				Publisher: pubID,
+				Protocol:  "http",
+				Name:      "test name",
+				Value:     &v,
+				Time:      float64(created.UnixNano()) / float64(1e9),
+			})
+		}
+	}
+	err = syncRepo.ConsumeBlocking(context.TODO(), msgs)
+	require.Nil(t, err, fmt.Sprintf("Save operation expected to succeed: %s.\n", err))
+
+	policy := retention.Policy{
+		Channels: map[string]time.Duration{shortChan: time.Hour},
+	}
+	purged, err := purger.Purge(context.Background(), policy)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, uint64(msgsNum/2), purged, fmt.Sprintf("expected %d purged messages got %d", msgsNum/2, purged))
+
+	cases := map[string]int{
+		shortChan: msgsNum / 2,
+		defChan:   msgsNum,
+	}
+	for chanID, expected := range cases {
+		count, err := queryDB(fmt.Sprintf(`from(bucket: "%s")
+		|> range(start: -1d, stop: 1h)
+		|> filter(fn: (r) => r["channel"] == "%s")
+		|> group()
+		|> count()`, repoCfg.Bucket, chanID))
+		assert.Nil(t, err, fmt.Sprintf("Querying InfluxDB to retrieve data expected to succeed: %s.\n", err))
+		assert.Equal(t, expected, count, fmt.Sprintf("Expected to have %d messages of channel %s, found %d instead.\n", expected, chanID, count))
+	}
+
+	policy.Period = 2 * time.Hour
+	_, err = purger.Purge(context.Background(), policy)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	bucket, err := client.BucketsAPI().FindBucketByName(context.Background(), repoCfg.Bucket)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s\n", err))
+	require.Len(t, bucket.RetentionRules, 1, "expected bucket retention rule to be set")
+	assert.Equal(t, int64(2*time.Hour/time.Second), bucket.RetentionRules[0].EverySeconds, "expected bucket retention to match the longest period")
+}
@@ -5,27 +5,58 @@ package mongodb
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-const senmlCollection string = "messages"
+const (
+	senmlCollection string = "messages"
 
-var errSaveMessage = errors.New("failed to save message to mongodb database")
+	// ExpireAtKey is the key of the document field holding the time when
+	// MongoDB TTL monitor removes the message.
+	ExpireAtKey = "expire_at"
+)
+
+var (
+	errSaveMessage = errors.New("failed to save message to mongodb database")
+	errCreateIndex = errors.New("failed to create mongodb TTL index")
+)
 
 var _ consumers.BlockingConsumer = (*mongoRepo)(nil)
 
 type mongoRepo struct {
-	db *mongo.Database
+	db       *mongo.Database
+	policies retention.Policies
+	indexed  sync.Map
+}
+
+type senmlMessage struct {
+	senml.Message `bson:",inline"`
+	ExpireAt      *time.Time `bson:"expire_at,omitempty"`
+}
+
+type jsonMessage struct {
+	json.Message `bson:",inline"`
+	ExpireAt     *time.Time `bson:"expire_at,omitempty"`
 }
 
-// New returns new MongoDB writer.
-func New(db *mongo.Database) consumers.BlockingConsumer {
-	return &mongoRepo{db}
+// New returns new MongoDB writer. Messages of the channels with the finite
+// retention period are saved with the expiration time, and the TTL index
+// is used to remove them once they expire.
+func New(db *mongo.Database, policies retention.Policies) consumers.BlockingConsumer {
+	return &mongoRepo{
+		db:       db,
+		policies: policies,
+	}
 }
 
 func (repo *mongoRepo) ConsumeBlocking(ctx context.Context, message interface{}) error {
@@ -44,8 +75,18 @@ func (repo *mongoRepo) saveSenml(ctx context.Context, messages interface{}) erro
 	}
 	coll := repo.db.Collection(senmlCollection)
 	var dbMsgs []interface{}
+	expires := false
 	for _, msg := range msgs {
-		dbMsgs = append(dbMsgs, msg)
+		created := time.Unix(0, int64(msg.Time*float64(time.Second)))
+		expireAt := repo.expireAt(msg.Channel, created)
+		expires = expires || expireAt != nil
+		dbMsgs = append(dbMsgs, senmlMessage{Message: msg, ExpireAt: expireAt})
+	}
+
+	if expires {
+		if err := repo.ensureIndex(ctx, coll); err != nil {
+			return err
+		}
 	}
 
 	_, err := coll.InsertMany(ctx, dbMsgs)
@@ -58,12 +99,21 @@ func (repo *mongoRepo) saveSenml(ctx context.Context, messages interface{}) erro
 
 func (repo *mongoRepo) saveJSON(ctx context.Context, msgs json.Messages) error {
 	m := []interface{}{}
+	expires := false
 	for _, msg := range msgs.Data {
-		m = append(m, msg)
+		expireAt := repo.expireAt(msg.Channel, time.Unix(0, msg.Created))
+		expires = expires || expireAt != nil
+		m = append(m, jsonMessage{Message: msg, ExpireAt: expireAt})
 	}
 
 	coll := repo.db.Collection(msgs.Format)
 
+	if expires {
+		if err := repo.ensureIndex(ctx, coll); err != nil {
+			return err
+		}
+	}
+
 	_, err := coll.InsertMany(ctx, m)
 	if err != nil {
 		return errors.Wrap(errSaveMessage, err)
@@ -71,3 +121,34 @@ func (repo *mongoRepo) saveJSON(ctx context.Context, msgs json.Messages) error {
 
 	return nil
 }
+
+// expireAt returns the expiration time of the message created at the given
+// time, or nil if the message never expires.
+func (repo *mongoRepo) expireAt(chanID string, created time.Time) *time.Time {
+	period := repo.policies.Period(chanID)
+	if period == 0 {
+		return nil
+	}
+	expireAt := created.Add(period)
+
+	return &expireAt
+}
+
+// ensureIndex creates the TTL index on the expiration time field of the
+// given collection, unless it's already been created by this writer.
+func (repo *mongoRepo) ensureIndex(ctx context.Context, coll *mongo.Collection) error {
+	if _, ok := repo.indexed.Load(coll.Name()); ok {
+		return nil
+	}
+
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: ExpireAtKey, Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, index); err != nil {
+		return errors.Wrap(errCreateIndex, err)
+	}
+	repo.indexed.Store(coll.Name(), true)
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/mainflux/mainflux/consumers/writers/mongodb"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
 	mflog "github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
@@ -46,7 +47,7 @@ func TestSaveSenml(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
 
 	db := client.Database(testDB)
-	repo := mongodb.New(db)
+	repo := mongodb.New(db, retention.NewPolicies(retention.Policy{}))
 
 	now := time.Now().Unix()
 	msg := senml.Message{
@@ -89,12 +90,55 @@ func TestSaveSenml(t *testing.T) {
 	assert.Equal(t, int64(msgsNum), count, fmt.Sprintf("Expected to have %d value, found %d instead.\n", msgsNum, count))
 }
 
+func TestSaveSenmlRetention(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	policies := retention.NewPolicies(retention.Policy{
+		Channels: map[string]time.Duration{chid.String(): time.Hour},
+	})
+	repo := mongodb.New(db, policies)
+
+	now := time.Now()
+	msgs := []senml.Message{
+		{Channel: chid.String(), Publisher: "2580", Protocol: "http", Name: "expiring", Value: &v, Time: float64(now.Unix())},
+		{Channel: "45", Publisher: "2580", Protocol: "http", Name: "kept", Value: &v, Time: float64(now.Unix())},
+	}
+	err = repo.ConsumeBlocking(context.TODO(), msgs)
+	require.Nil(t, err, fmt.Sprintf("Save operation expected to succeed: %s.\n", err))
+
+	var expiring bson.M
+	err = db.Collection(collection).FindOne(context.Background(), bson.M{"channel": chid.String()}).Decode(&expiring)
+	require.Nil(t, err, fmt.Sprintf("Querying database expected to succeed: %s.\n", err))
+	assert.Contains(t, expiring, mongodb.ExpireAtKey, "expected message of the channel with retention period to expire")
+
+	count, err := db.Collection(collection).CountDocuments(context.Background(), bson.M{"name": "kept", mongodb.ExpireAtKey: bson.M{"$exists": true}})
+	assert.Nil(t, err, fmt.Sprintf("Querying database expected to succeed: %s.\n", err))
+	assert.Equal(t, int64(0), count, "expected message of the channel without retention period not to expire")
+
+	cursor, err := db.Collection(collection).Indexes().List(context.Background())
+	require.Nil(t, err, fmt.Sprintf("Listing indexes expected to succeed: %s.\n", err))
+	var indexes []bson.M
+	err = cursor.All(context.Background(), &indexes)
+	require.Nil(t, err, fmt.Sprintf("Listing indexes expected to succeed: %s.\n", err))
+	ttl := false
+	for _, index := range indexes {
+		if _, ok := index["expireAfterSeconds"]; ok {
+			ttl = true
+		}
+	}
+	assert.True(t, ttl, "expected TTL index to be created")
+}
+
 func TestSaveJSON(t *testing.T) {
 	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
 	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
 
 	db := client.Database(testDB)
-	repo := mongodb.New(db)
+	repo := mongodb.New(db, retention.NewPolicies(retention.Policy{}))
 
 	chid, err := uuid.NewV4()
 	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
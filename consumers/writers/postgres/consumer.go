@@ -25,6 +25,10 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
+// maxBatchRows is the maximum number of rows inserted by a single statement,
+// which keeps the number of bind parameters within the PostgreSQL limit.
+const maxBatchRows = 4000
+
 var _ consumers.BlockingConsumer = (*postgresRepo)(nil)
 
 type postgresRepo struct {
@@ -74,13 +78,21 @@ func (pr postgresRepo) saveSenml(ctx context.Context, messages interface{}) (err
 		}
 	}()
 
+	dbMsgs := make([]senmlMessage, 0, len(msgs))
 	for _, msg := range msgs {
 		id, err := uuid.NewV4()
 		if err != nil {
 			return err
 		}
-		m := senmlMessage{Message: msg, ID: id.String()}
-		if _, err := tx.NamedExec(q, m); err != nil {
+		dbMsgs = append(dbMsgs, senmlMessage{Message: msg, ID: id.String()})
+	}
+
+	for len(dbMsgs) > 0 {
+		n := len(dbMsgs)
+		if n > maxBatchRows {
+			n = maxBatchRows
+		}
+		if _, err := tx.NamedExec(q, dbMsgs[:n]); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				if pgErr.Code == pgerrcode.InvalidTextRepresentation {
@@ -90,6 +102,7 @@ func (pr postgresRepo) saveSenml(ctx context.Context, messages interface{}) (err
 
 			return errors.Wrap(errSaveMessage, err)
 		}
+		dbMsgs = dbMsgs[n:]
 	}
 	return err
 }
@@ -129,14 +142,22 @@ func (pr postgresRepo) insertJSON(msgs mfjson.Messages) error {
           VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload);`
 	q = fmt.Sprintf(q, msgs.Format)
 
+	dbMsgs := make([]jsonMessage, 0, len(msgs.Data))
 	for _, m := range msgs.Data {
 		var dbmsg jsonMessage
 		dbmsg, err = toJSONMessage(m)
 		if err != nil {
 			return errors.Wrap(errSaveMessage, err)
 		}
+		dbMsgs = append(dbMsgs, dbmsg)
+	}
 
-		if _, err = tx.NamedExec(q, dbmsg); err != nil {
+	for len(dbMsgs) > 0 {
+		n := len(dbMsgs)
+		if n > maxBatchRows {
+			n = maxBatchRows
+		}
+		if _, err = tx.NamedExec(q, dbMsgs[:n]); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				switch pgErr.Code {
@@ -148,6 +169,7 @@ func (pr postgresRepo) insertJSON(msgs mfjson.Messages) error {
 			}
 			return err
 		}
+		dbMsgs = dbMsgs[n:]
 	}
 	return nil
 }
@@ -0,0 +1,143 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var errPurge = errors.New("failed to purge expired messages from postgres database")
+
+var _ retention.Purger = (*postgresPurger)(nil)
+
+// table describes the table holding messages and its time column.
+type table struct {
+	name   string
+	column string
+	senml  bool
+}
+
+type postgresPurger struct {
+	db        *sqlx.DB
+	batchSize uint64
+}
+
+// NewPurger returns new PostgreSQL purger which removes expired messages
+// in batches of the given size, so that long running deletes don't block
+// the writer.
+func NewPurger(db *sqlx.DB, batchSize uint64) retention.Purger {
+	if batchSize == 0 {
+		batchSize = retention.DefBatchSize
+	}
+	return &postgresPurger{
+		db:        db,
+		batchSize: batchSize,
+	}
+}
+
+func (pp *postgresPurger) Purge(ctx context.Context, policy retention.Policy) (uint64, error) {
+	tables, err := pp.tables(ctx)
+	if err != nil {
+		return 0, errors.Wrap(errPurge, err)
+	}
+
+	now := time.Now()
+	var total uint64
+	for _, t := range tables {
+		var overrides []interface{}
+		for id, period := range policy.Channels {
+			overrides = append(overrides, id)
+			if period == 0 {
+				continue
+			}
+			n, err := pp.delete(ctx, t, "channel = $3", t.cutoff(now.Add(-period)), id)
+			total += n
+			if err != nil {
+				return total, errors.Wrap(errPurge, err)
+			}
+		}
+
+		if policy.Period == 0 {
+			continue
+		}
+		cond := "TRUE"
+		if len(overrides) > 0 {
+			cond = fmt.Sprintf("channel NOT IN (%s)", placeholders(3, len(overrides)))
+		}
+		n, err := pp.delete(ctx, t, cond, t.cutoff(now.Add(-policy.Period)), overrides...)
+		total += n
+		if err != nil {
+			return total, errors.Wrap(errPurge, err)
+		}
+	}
+
+	return total, nil
+}
+
+// tables returns SenML messages table and all the tables created for JSON messages.
+func (pp *postgresPurger) tables(ctx context.Context) ([]table, error) {
+	q := `SELECT table_name FROM information_schema.columns
+          WHERE table_schema = current_schema() AND column_name = 'payload';`
+
+	var names []string
+	if err := pp.db.SelectContext(ctx, &names, q); err != nil {
+		return nil, err
+	}
+
+	tables := []table{{name: "messages", column: "time", senml: true}}
+	for _, name := range names {
+		tables = append(tables, table{name: name, column: "created"})
+	}
+
+	return tables, nil
+}
+
+// delete removes messages older than the cutoff which satisfy the given
+// condition. Messages are removed in batches until there are none left.
+func (pp *postgresPurger) delete(ctx context.Context, t table, cond string, cutoff interface{}, args ...interface{}) (uint64, error) {
+	q := fmt.Sprintf(`DELETE FROM %s WHERE ctid IN (
+          SELECT ctid FROM %s WHERE %s < $1 AND %s LIMIT $2);`, t.name, t.name, t.column, cond)
+	args = append([]interface{}{cutoff, pp.batchSize}, args...)
+
+	var total uint64
+	for {
+		res, err := pp.db.ExecContext(ctx, q, args...)
+		if err != nil {
+			return total, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += uint64(n)
+		if uint64(n) < pp.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// cutoff returns the given time in the units of the table's time column.
+func (t table) cutoff(tm time.Time) interface{} {
+	if t.senml {
+		return float64(tm.UnixNano()) / float64(time.Second)
+	}
+
+	return tm.UnixNano()
+}
+
+func placeholders(start, count int) string {
+	ret := make([]string, count)
+	for i := range ret {
+		ret[i] = fmt.Sprintf("$%d", start+i)
+	}
+
+	return strings.Join(ret, ", ")
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"os"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/pelletier/go-toml"
+)
+
+const defInterval = "1h"
+
+// DefBatchSize is the default maximum number of messages removed by
+// a single delete statement.
+const DefBatchSize = 10000
+
+var (
+	errOpenConfFile  = errors.New("unable to open configuration file")
+	errParseConfFile = errors.New("unable to parse configuration file")
+)
+
+// Config represents the retention section of the writer's config.toml.
+type Config struct {
+	// Period is the default retention period. Empty period means
+	// that messages are kept forever.
+	Period string `toml:"period"`
+
+	// Interval is the time between two consecutive purges.
+	Interval string `toml:"interval"`
+
+	// BatchSize is the maximum number of messages removed by a single
+	// delete statement.
+	BatchSize uint64 `toml:"batch_size"`
+
+	// Channels maps channel IDs to their retention periods.
+	Channels map[string]string `toml:"channels"`
+}
+
+type fileConfig struct {
+	Retention Config `toml:"retention"`
+}
+
+// LoadConfig loads the retention configuration from the given file. If the
+// file can't be read, the default configuration is returned together with
+// the error.
+func LoadConfig(configPath string) (Config, error) {
+	cfg := fileConfig{
+		Retention: Config{
+			Interval:  defInterval,
+			BatchSize: DefBatchSize,
+		},
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return cfg.Retention, errors.Wrap(errOpenConfFile, err)
+	}
+
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return cfg.Retention, errors.Wrap(errParseConfFile, err)
+	}
+
+	return cfg.Retention, nil
+}
+
+// Policy returns the retention policy described by the configuration.
+func (cfg Config) Policy() (Policy, error) {
+	period, err := ParsePeriod(cfg.Period)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	channels := make(map[string]time.Duration, len(cfg.Channels))
+	for id, p := range cfg.Channels {
+		period, err := ParsePeriod(p)
+		if err != nil {
+			return Policy{}, err
+		}
+		channels[id] = period
+	}
+
+	return Policy{
+		Period:   period,
+		Channels: channels,
+	}, nil
+}
+
+// PurgeInterval returns the time between two consecutive purges.
+func (cfg Config) PurgeInterval() (time.Duration, error) {
+	if cfg.Interval == "" {
+		cfg.Interval = defInterval
+	}
+
+	d, err := time.ParseDuration(cfg.Interval)
+	if err != nil || d <= 0 {
+		return 0, ErrInvalidPeriod
+	}
+
+	return d, nil
+}
@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package retention contains the domain concept definitions needed to
+// support message retention policies in Mainflux writer services.
+//
+// Retention period is configured in the writer's config.toml and can be
+// overridden per channel, either in the same file or using the "retention"
+// key of the channel metadata.
+package retention
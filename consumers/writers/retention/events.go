@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mainflux/mainflux/pkg/events"
+)
+
+const (
+	keyRetention = "retention"
+
+	channelPrefix = "channel."
+	channelCreate = channelPrefix + "create"
+	channelUpdate = channelPrefix + "update"
+)
+
+type eventHandler struct {
+	policies Policies
+}
+
+// NewEventHandler returns new event store handler which keeps retention
+// policies in sync with the "retention" key of the channel metadata.
+func NewEventHandler(ps Policies) events.EventHandler {
+	return &eventHandler{
+		policies: ps,
+	}
+}
+
+func (eh *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch msg["operation"] {
+	case channelCreate, channelUpdate:
+		return eh.handleChannel(msg)
+	}
+
+	return nil
+}
+
+func (eh *eventHandler) handleChannel(event map[string]interface{}) error {
+	id := read(event, "id", "")
+	if id == "" {
+		return nil
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal([]byte(read(event, "metadata", "{}")), &metadata); err != nil {
+		return err
+	}
+
+	val, ok := metadata[keyRetention]
+	if !ok {
+		eh.policies.Remove(id)
+		return nil
+	}
+
+	str, ok := val.(string)
+	if !ok {
+		return ErrInvalidPeriod
+	}
+	period, err := ParsePeriod(str)
+	if err != nil {
+		return err
+	}
+	eh.policies.Save(id, period)
+
+	return nil
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/writers/retention"
+	"github.com/stretchr/testify/assert"
+)
+
+type event map[string]interface{}
+
+func (e event) Encode() (map[string]interface{}, error) {
+	return e, nil
+}
+
+func TestHandle(t *testing.T) {
+	ps := retention.NewPolicies(retention.Policy{Period: time.Hour})
+	handler := retention.NewEventHandler(ps)
+
+	cases := []struct {
+		desc   string
+		event  event
+		period time.Duration
+		err    error
+	}{
+		{
+			desc:   "handle channel create with retention",
+			event:  event{"operation": "channel.create", "id": chanID, "metadata": `{"retention":"24h"}`},
+			period: 24 * time.Hour,
+		},
+		{
+			desc:   "handle channel update with retention in days",
+			event:  event{"operation": "channel.update", "id": chanID, "metadata": `{"retention":"7d"}`},
+			period: 7 * 24 * time.Hour,
+		},
+		{
+			desc:   "handle channel update with invalid retention",
+			event:  event{"operation": "channel.update", "id": chanID, "metadata": `{"retention":"soon"}`},
+			period: 7 * 24 * time.Hour,
+			err:    retention.ErrInvalidPeriod,
+		},
+		{
+			desc:   "handle channel update with non string retention",
+			event:  event{"operation": "channel.update", "id": chanID, "metadata": `{"retention":24}`},
+			period: 7 * 24 * time.Hour,
+			err:    retention.ErrInvalidPeriod,
+		},
+		{
+			desc:   "handle channel remove",
+			event:  event{"operation": "channel.remove", "id": chanID, "status": "disabled"},
+			period: 7 * 24 * time.Hour,
+		},
+		{
+			desc:   "handle thing update with retention",
+			event:  event{"operation": "thing.update", "id": chanID, "metadata": `{"retention":"1m"}`},
+			period: 7 * 24 * time.Hour,
+		},
+		{
+			desc:   "handle channel update with retention kept forever",
+			event:  event{"operation": "channel.update", "id": chanID, "metadata": `{"retention":"0"}`},
+			period: 0,
+		},
+		{
+			desc:   "handle channel update without retention",
+			event:  event{"operation": "channel.update", "id": chanID, "metadata": `{"name":"value"}`},
+			period: time.Hour,
+		},
+	}
+
+	for _, tc := range cases {
+		err := handler.Handle(context.Background(), tc.event)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %v got %v\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.period, ps.Period(chanID), fmt.Sprintf("%s: expected period %s got %s\n", tc.desc, tc.period, ps.Period(chanID)))
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mflog "github.com/mainflux/mainflux/logger"
+)
+
+var _ Purger = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger mflog.Logger
+	purger Purger
+}
+
+// LoggingMiddleware adds logging facilities to the purger.
+func LoggingMiddleware(purger Purger, logger mflog.Logger) Purger {
+	return &loggingMiddleware{
+		logger: logger,
+		purger: purger,
+	}
+}
+
+// Purge logs the purge request. It logs the number of purged messages and
+// the time it took to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) Purge(ctx context.Context, policy Policy) (n uint64, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method purge removed %d messages and took %s to complete", n, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.purger.Purge(ctx, policy)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+var _ Purger = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	purged  metrics.Counter
+	purger  Purger
+}
+
+// MetricsMiddleware returns new purger with Purge method wrapped to expose
+// metrics, including the number of purged messages.
+func MetricsMiddleware(purger Purger, counter metrics.Counter, latency metrics.Histogram, purged metrics.Counter) Purger {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		purged:  purged,
+		purger:  purger,
+	}
+}
+
+// MakePurgedCounter returns the counter of messages removed by the purger.
+func MakePurgedCounter(namespace, subsystem string) metrics.Counter {
+	return kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "purged_rows_count",
+		Help:      "Number of messages removed by the retention policy.",
+	}, []string{"method"})
+}
+
+// Purge instruments Purge method with metrics.
+func (mm *metricsMiddleware) Purge(ctx context.Context, policy Policy) (uint64, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "purge").Add(1)
+		mm.latency.With("method", "purge").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	n, err := mm.purger.Purge(ctx, policy)
+	mm.purged.With("method", "purge").Add(float64(n))
+
+	return n, err
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// ErrInvalidPeriod indicates malformed retention period.
+var ErrInvalidPeriod = errors.New("invalid retention period")
+
+// Policy represents the snapshot of retention periods. Zero period means
+// that messages are kept forever.
+type Policy struct {
+	// Period is the retention period of the channels without override.
+	Period time.Duration
+
+	// Channels contains per channel retention periods which override
+	// the default one.
+	Channels map[string]time.Duration
+}
+
+// ChannelPeriod returns the retention period of the given channel.
+func (p Policy) ChannelPeriod(chanID string) time.Duration {
+	if period, ok := p.Channels[chanID]; ok {
+		return period
+	}
+
+	return p.Period
+}
+
+// Longest returns the longest retention period of the policy. If messages
+// of any channel are kept forever, zero is returned.
+func (p Policy) Longest() time.Duration {
+	longest := p.Period
+	if longest == 0 {
+		return 0
+	}
+	for _, period := range p.Channels {
+		if period == 0 {
+			return 0
+		}
+		if period > longest {
+			longest = period
+		}
+	}
+
+	return longest
+}
+
+// Expires returns true if messages of any channel expire.
+func (p Policy) Expires() bool {
+	if p.Period > 0 {
+		return true
+	}
+	for _, period := range p.Channels {
+		if period > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Policies keeps retention policy which can be changed at runtime using
+// the channel metadata.
+type Policies interface {
+	// Policy returns the snapshot of the current retention policy.
+	Policy() Policy
+
+	// Period returns the retention period of the given channel.
+	Period(chanID string) time.Duration
+
+	// Save sets the retention period of the given channel, overriding
+	// the configured one.
+	Save(chanID string, period time.Duration)
+
+	// Remove removes the retention period set using Save.
+	Remove(chanID string)
+}
+
+// Purger specifies an API for removing expired messages from the database.
+type Purger interface {
+	// Purge removes messages which are older than the retention period
+	// of their channel and returns the number of removed messages.
+	Purge(ctx context.Context, policy Policy) (uint64, error)
+}
+
+var _ Policies = (*policies)(nil)
+
+type policies struct {
+	mu       sync.RWMutex
+	policy   Policy
+	channels map[string]time.Duration
+}
+
+// NewPolicies returns retention policies initialized with the given policy.
+func NewPolicies(policy Policy) Policies {
+	return &policies{
+		policy:   policy,
+		channels: make(map[string]time.Duration),
+	}
+}
+
+func (ps *policies) Policy() Policy {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	channels := make(map[string]time.Duration, len(ps.policy.Channels)+len(ps.channels))
+	for id, period := range ps.policy.Channels {
+		channels[id] = period
+	}
+	for id, period := range ps.channels {
+		channels[id] = period
+	}
+
+	return Policy{
+		Period:   ps.policy.Period,
+		Channels: channels,
+	}
+}
+
+func (ps *policies) Period(chanID string) time.Duration {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	if period, ok := ps.channels[chanID]; ok {
+		return period
+	}
+
+	return ps.policy.ChannelPeriod(chanID)
+}
+
+func (ps *policies) Save(chanID string, period time.Duration) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.channels[chanID] = period
+}
+
+func (ps *policies) Remove(chanID string) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	delete(ps.channels, chanID)
+}
+
+// ParsePeriod parses retention period. Besides the units supported by
+// time.ParseDuration, the period can be expressed in days (e.g. "30d").
+// Empty string and "0" mean that messages are kept forever.
+func ParsePeriod(period string) (time.Duration, error) {
+	period = strings.TrimSpace(period)
+	if period == "" || period == "0" {
+		return 0, nil
+	}
+
+	if strings.HasSuffix(period, "d") {
+		days, err := strconv.ParseUint(strings.TrimSuffix(period, "d"), 10, 32)
+		if err != nil {
+			return 0, ErrInvalidPeriod
+		}
+		return time.Duration(days) * day, nil
+	}
+
+	d, err := time.ParseDuration(period)
+	if err != nil || d < 0 {
+		return 0, ErrInvalidPeriod
+	}
+
+	return d, nil
+}
+
+// Run periodically purges expired messages until the context is canceled.
+// Purge errors don't stop the loop, so they should be reported by wrapping
+// the purger with LoggingMiddleware.
+func Run(ctx context.Context, purger Purger, ps Policies, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			policy := ps.Policy()
+			if !policy.Expires() {
+				continue
+			}
+			_, _ = purger.Purge(ctx, policy)
+		}
+	}
+}
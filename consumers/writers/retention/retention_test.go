@@ -0,0 +1,241 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/writers/retention"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID      = "50e6b371-60ff-45cf-bb52-8200e7cde536"
+	otherChanID = "7f4a2a45-5d7c-4b6c-a4d1-48f7ab1d5c3e"
+)
+
+func TestParsePeriod(t *testing.T) {
+	cases := []struct {
+		desc   string
+		period string
+		res    time.Duration
+		err    error
+	}{
+		{desc: "parse empty period", period: "", res: 0},
+		{desc: "parse zero period", period: "0", res: 0},
+		{desc: "parse period in hours", period: "720h", res: 720 * time.Hour},
+		{desc: "parse period in days", period: "30d", res: 30 * 24 * time.Hour},
+		{desc: "parse period with spaces", period: " 90m ", res: 90 * time.Minute},
+		{desc: "parse negative period", period: "-1h", err: retention.ErrInvalidPeriod},
+		{desc: "parse invalid days period", period: "xd", err: retention.ErrInvalidPeriod},
+		{desc: "parse invalid period", period: "forever", err: retention.ErrInvalidPeriod},
+	}
+
+	for _, tc := range cases {
+		res, err := retention.ParsePeriod(tc.period)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %v got %v\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.res, res))
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	cases := []struct {
+		desc    string
+		policy  retention.Policy
+		period  time.Duration
+		longest time.Duration
+		expires bool
+	}{
+		{
+			desc:    "policy without periods",
+			policy:  retention.Policy{},
+			period:  0,
+			longest: 0,
+			expires: false,
+		},
+		{
+			desc:    "policy with default period",
+			policy:  retention.Policy{Period: time.Hour},
+			period:  time.Hour,
+			longest: time.Hour,
+			expires: true,
+		},
+		{
+			desc: "policy with channel override",
+			policy: retention.Policy{
+				Period:   time.Hour,
+				Channels: map[string]time.Duration{chanID: 2 * time.Hour},
+			},
+			period:  2 * time.Hour,
+			longest: 2 * time.Hour,
+			expires: true,
+		},
+		{
+			desc: "policy with channel kept forever",
+			policy: retention.Policy{
+				Period:   time.Hour,
+				Channels: map[string]time.Duration{chanID: 0},
+			},
+			period:  0,
+			longest: 0,
+			expires: true,
+		},
+		{
+			desc: "policy with channel override only",
+			policy: retention.Policy{
+				Channels: map[string]time.Duration{chanID: time.Hour},
+			},
+			period:  time.Hour,
+			longest: 0,
+			expires: true,
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.period, tc.policy.ChannelPeriod(chanID), fmt.Sprintf("%s: unexpected channel period\n", tc.desc))
+		assert.Equal(t, tc.longest, tc.policy.Longest(), fmt.Sprintf("%s: unexpected longest period\n", tc.desc))
+		assert.Equal(t, tc.expires, tc.policy.Expires(), fmt.Sprintf("%s: unexpected expiration\n", tc.desc))
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	ps := retention.NewPolicies(retention.Policy{
+		Period:   time.Hour,
+		Channels: map[string]time.Duration{chanID: 2 * time.Hour},
+	})
+
+	assert.Equal(t, 2*time.Hour, ps.Period(chanID), "expected configured channel period")
+	assert.Equal(t, time.Hour, ps.Period(otherChanID), "expected default period")
+
+	ps.Save(chanID, 3*time.Hour)
+	ps.Save(otherChanID, 0)
+	assert.Equal(t, 3*time.Hour, ps.Period(chanID), "expected saved channel period to override configured one")
+	assert.Equal(t, time.Duration(0), ps.Period(otherChanID), "expected saved channel period to override default one")
+
+	policy := ps.Policy()
+	assert.Equal(t, retention.Policy{
+		Period:   time.Hour,
+		Channels: map[string]time.Duration{chanID: 3 * time.Hour, otherChanID: 0},
+	}, policy, "unexpected policy snapshot")
+
+	ps.Remove(chanID)
+	ps.Remove(otherChanID)
+	assert.Equal(t, 2*time.Hour, ps.Period(chanID), "expected configured channel period after removal")
+	assert.Equal(t, time.Hour, ps.Period(otherChanID), "expected default period after removal")
+
+	policy.Channels[chanID] = time.Minute
+	assert.Equal(t, 2*time.Hour, ps.Period(chanID), "expected policy snapshot not to change policies")
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.toml")
+	err := os.WriteFile(valid, []byte(`
+[subscriber]
+subjects = ["channels.>"]
+
+[retention]
+period = "30d"
+interval = "10m"
+batch_size = 500
+
+[retention.channels]
+"`+chanID+`" = "24h"
+"`+otherChanID+`" = ""
+`), 0o600)
+	require.Nil(t, err, fmt.Sprintf("failed to write config: %s", err))
+
+	invalid := filepath.Join(dir, "invalid.toml")
+	err = os.WriteFile(invalid, []byte(`
+[retention]
+period = "30d"
+
+[retention.channels]
+"`+chanID+`" = "soon"
+`), 0o600)
+	require.Nil(t, err, fmt.Sprintf("failed to write config: %s", err))
+
+	cfg, err := retention.LoadConfig(valid)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, uint64(500), cfg.BatchSize, "unexpected batch size")
+
+	policy, err := cfg.Policy()
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, retention.Policy{
+		Period:   30 * 24 * time.Hour,
+		Channels: map[string]time.Duration{chanID: 24 * time.Hour, otherChanID: 0},
+	}, policy, "unexpected policy")
+
+	interval, err := cfg.PurgeInterval()
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, 10*time.Minute, interval, "unexpected purge interval")
+
+	cfg, err = retention.LoadConfig(invalid)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, uint64(retention.DefBatchSize), cfg.BatchSize, "expected default batch size")
+	_, err = cfg.Policy()
+	assert.Equal(t, retention.ErrInvalidPeriod, err, fmt.Sprintf("expected error %s got %s", retention.ErrInvalidPeriod, err))
+
+	cfg, err = retention.LoadConfig(filepath.Join(dir, "missing.toml"))
+	assert.NotNil(t, err, "expected error loading missing config")
+	policy, err = cfg.Policy()
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.False(t, policy.Expires(), "expected messages to be kept forever by default")
+	interval, err = cfg.PurgeInterval()
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, time.Hour, interval, "expected default purge interval")
+}
+
+type purger struct {
+	mu       sync.Mutex
+	policies []retention.Policy
+}
+
+func (p *purger) Purge(_ context.Context, policy retention.Policy) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.policies = append(p.policies, policy)
+	return 0, nil
+}
+
+func (p *purger) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.policies)
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		desc   string
+		policy retention.Policy
+		purged bool
+	}{
+		{desc: "run with expiring messages", policy: retention.Policy{Period: time.Hour}, purged: true},
+		{desc: "run with messages kept forever", policy: retention.Policy{}, purged: false},
+	}
+
+	for _, tc := range cases {
+		p := &purger{}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- retention.Run(ctx, p, retention.NewPolicies(tc.policy), 10*time.Millisecond)
+		}()
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		err := <-done
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.purged, p.count() > 0, fmt.Sprintf("%s: unexpected purge\n", tc.desc))
+	}
+}
@@ -24,6 +24,10 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
+// maxBatchRows is the maximum number of rows inserted by a single statement,
+// which keeps the number of bind parameters within the PostgreSQL limit.
+const maxBatchRows = 4000
+
 var _ consumers.BlockingConsumer = (*timescaleRepo)(nil)
 
 type timescaleRepo struct {
@@ -73,9 +77,17 @@ func (tr timescaleRepo) saveSenml(ctx context.Context, messages interface{}) (er
 		}
 	}()
 
+	dbMsgs := make([]senmlMessage, 0, len(msgs))
 	for _, msg := range msgs {
-		m := senmlMessage{Message: msg}
-		if _, err := tx.NamedExec(q, m); err != nil {
+		dbMsgs = append(dbMsgs, senmlMessage{Message: msg})
+	}
+
+	for len(dbMsgs) > 0 {
+		n := len(dbMsgs)
+		if n > maxBatchRows {
+			n = maxBatchRows
+		}
+		if _, err := tx.NamedExec(q, dbMsgs[:n]); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				if pgErr.Code == pgerrcode.InvalidTextRepresentation {
@@ -85,6 +97,7 @@ func (tr timescaleRepo) saveSenml(ctx context.Context, messages interface{}) (er
 
 			return errors.Wrap(errSaveMessage, err)
 		}
+		dbMsgs = dbMsgs[n:]
 	}
 	return err
 }
@@ -124,13 +137,22 @@ func (tr timescaleRepo) insertJSON(ctx context.Context, msgs mfjson.Messages) er
           VALUES (:channel, :created, :subtopic, :publisher, :protocol, :payload);`
 	q = fmt.Sprintf(q, msgs.Format)
 
+	dbMsgs := make([]jsonMessage, 0, len(msgs.Data))
 	for _, m := range msgs.Data {
 		var dbmsg jsonMessage
 		dbmsg, err = toJSONMessage(m)
 		if err != nil {
 			return errors.Wrap(errSaveMessage, err)
 		}
-		if _, err = tx.NamedExec(q, dbmsg); err != nil {
+		dbMsgs = append(dbMsgs, dbmsg)
+	}
+
+	for len(dbMsgs) > 0 {
+		n := len(dbMsgs)
+		if n > maxBatchRows {
+			n = maxBatchRows
+		}
+		if _, err = tx.NamedExec(q, dbMsgs[:n]); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				switch pgErr.Code {
@@ -142,6 +164,7 @@ func (tr timescaleRepo) insertJSON(ctx context.Context, msgs mfjson.Messages) er
 			}
 			return err
 		}
+		dbMsgs = dbMsgs[n:]
 	}
 	return nil
 }
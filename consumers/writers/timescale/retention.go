@@ -0,0 +1,175 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const senmlTable = "messages"
+
+var errPurge = errors.New("failed to purge expired messages from timescale database")
+
+var _ retention.Purger = (*timescalePurger)(nil)
+
+// table describes the table holding messages, its time column and
+// the columns which uniquely identify the row.
+type table struct {
+	name   string
+	column string
+	key    string
+	senml  bool
+}
+
+type timescalePurger struct {
+	db        *sqlx.DB
+	batchSize uint64
+}
+
+// NewPurger returns new TimescaleDB purger. Chunks of the messages hypertable
+// which are older than the longest retention period are dropped, while
+// the remaining expired messages are removed in batches of the given size.
+func NewPurger(db *sqlx.DB, batchSize uint64) retention.Purger {
+	if batchSize == 0 {
+		batchSize = retention.DefBatchSize
+	}
+	return &timescalePurger{
+		db:        db,
+		batchSize: batchSize,
+	}
+}
+
+func (tp *timescalePurger) Purge(ctx context.Context, policy retention.Policy) (uint64, error) {
+	now := time.Now()
+
+	var total uint64
+	if longest := policy.Longest(); longest > 0 {
+		n, err := tp.dropChunks(ctx, now.Add(-longest).Unix())
+		if err != nil {
+			return 0, errors.Wrap(errPurge, err)
+		}
+		total += n
+	}
+
+	tables, err := tp.tables(ctx)
+	if err != nil {
+		return total, errors.Wrap(errPurge, err)
+	}
+
+	for _, t := range tables {
+		var overrides []interface{}
+		for id, period := range policy.Channels {
+			overrides = append(overrides, id)
+			if period == 0 {
+				continue
+			}
+			n, err := tp.delete(ctx, t, "channel = $3", t.cutoff(now.Add(-period)), id)
+			total += n
+			if err != nil {
+				return total, errors.Wrap(errPurge, err)
+			}
+		}
+
+		if policy.Period == 0 {
+			continue
+		}
+		cond := "TRUE"
+		if len(overrides) > 0 {
+			cond = fmt.Sprintf("channel NOT IN (%s)", placeholders(3, len(overrides)))
+		}
+		n, err := tp.delete(ctx, t, cond, t.cutoff(now.Add(-policy.Period)), overrides...)
+		total += n
+		if err != nil {
+			return total, errors.Wrap(errPurge, err)
+		}
+	}
+
+	return total, nil
+}
+
+// dropChunks drops the chunks of the messages hypertable which contain
+// only messages older than the cutoff and returns the approximate number
+// of dropped messages.
+func (tp *timescalePurger) dropChunks(ctx context.Context, cutoff int64) (uint64, error) {
+	q := `SELECT COALESCE(SUM(approximate_row_count(c)), 0)
+          FROM show_chunks('messages', older_than => $1::BIGINT) c;`
+
+	var count int64
+	if err := tp.db.GetContext(ctx, &count, q, cutoff); err != nil {
+		return 0, err
+	}
+	if _, err := tp.db.ExecContext(ctx, `SELECT drop_chunks('messages', older_than => $1::BIGINT);`, cutoff); err != nil {
+		return 0, err
+	}
+
+	return uint64(count), nil
+}
+
+// tables returns SenML messages table and all the tables created for JSON messages.
+func (tp *timescalePurger) tables(ctx context.Context) ([]table, error) {
+	q := `SELECT table_name FROM information_schema.columns
+          WHERE table_schema = current_schema() AND column_name = 'payload';`
+
+	var names []string
+	if err := tp.db.SelectContext(ctx, &names, q); err != nil {
+		return nil, err
+	}
+
+	// Row IDs are not unique across hypertable chunks, so the primary key is used instead.
+	tables := []table{{name: senmlTable, column: "time", key: "time, publisher, subtopic, name", senml: true}}
+	for _, name := range names {
+		tables = append(tables, table{name: name, column: "created", key: "ctid"})
+	}
+
+	return tables, nil
+}
+
+// delete removes messages older than the cutoff which satisfy the given
+// condition. Messages are removed in batches until there are none left.
+func (tp *timescalePurger) delete(ctx context.Context, t table, cond string, cutoff interface{}, args ...interface{}) (uint64, error) {
+	q := fmt.Sprintf(`DELETE FROM %s WHERE (%s) IN (
+          SELECT %s FROM %s WHERE %s < $1 AND %s LIMIT $2);`, t.name, t.key, t.key, t.name, t.column, cond)
+	args = append([]interface{}{cutoff, tp.batchSize}, args...)
+
+	var total uint64
+	for {
+		res, err := tp.db.ExecContext(ctx, q, args...)
+		if err != nil {
+			return total, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += uint64(n)
+		if uint64(n) < tp.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// cutoff returns the given time in the units of the table's time column.
+func (t table) cutoff(tm time.Time) interface{} {
+	if t.senml {
+		return tm.Unix()
+	}
+
+	return tm.UnixNano()
+}
+
+func placeholders(start, count int) string {
+	ret := make([]string, count)
+	for i := range ret {
+		ret[i] = fmt.Sprintf("$%d", start+i)
+	}
+
+	return strings.Join(ret, ", ")
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/mainflux/mainflux/consumers/writers/retention"
+	"github.com/mainflux/mainflux/consumers/writers/timescale"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurge(t *testing.T) {
+	// Messages saved by other tests would be purged as well.
+	_, err := db.Exec("DELETE FROM messages")
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = db.Exec("DROP TABLE IF EXISTS some_json")
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	repo := timescale.New(db)
+	purger := timescale.NewPurger(db, 3)
+
+	var chanIDs []string
+	for i := 0; i < 3; i++ {
+		chid, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		chanIDs = append(chanIDs, chid.String())
+	}
+	shortChan, foreverChan, defChan := chanIDs[0], chanIDs[1], chanIDs[2]
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now()
+	old := now.Add(-3 * time.Hour)
+	var msgs []senml.Message
+	jsonMsgs := json.Messages{Format: "retention_json"}
+	for j, chanID := range chanIDs {
+		for i := 0; i < msgsNum; i++ {
+			created := now
+			if i%2 == 0 {
+				created = old
+			}
+			// Keep primary keys unique across channels.
+			created = created.Add(-time.Duration(j*msgsNum+i) * time.Second)
+			msgs = append(msgs, senml.Message{
+				Channel:   chanID,
+				Publisher: pubid.String(),
+				Protocol:  "mqtt",
+				Name:      fmt.Sprintf("name-%d", i),
+				Value:     &v,
+				Time:      float64(created.Unix()),
+			})
+			jsonMsgs.Data = append(jsonMsgs.Data, json.Message{
+				Channel:   chanID,
+				Publisher: pubid.String(),
+				Subtopic:  fmt.Sprintf("subtopic-%d", i),
+				Protocol:  "mqtt",
+				Created:   created.UnixNano(),
+				Payload:   map[string]interface{}{"field": i},
+			})
+		}
+	}
+	err = repo.ConsumeBlocking(context.Background(), msgs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = repo.ConsumeBlocking(context.Background(), jsonMsgs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	policy := retention.Policy{
+		Period: 2 * time.Hour,
+		Channels: map[string]time.Duration{
+			shortChan:   time.Hour,
+			foreverChan: 0,
+		},
+	}
+
+	// Half of the messages of both the short and default retention channels
+	// in both SenML and JSON tables are expired.
+	expired := uint64(2 * 2 * msgsNum / 2)
+	purged, err := purger.Purge(context.Background(), policy)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, expired, purged, fmt.Sprintf("expected %d purged messages got %d", expired, purged))
+
+	cases := map[string]int{
+		shortChan:   msgsNum / 2,
+		foreverChan: msgsNum,
+		defChan:     msgsNum / 2,
+	}
+	for chanID, count := range cases {
+		var senmlCount, jsonCount int
+		err := db.Get(&senmlCount, "SELECT COUNT(*) FROM messages WHERE channel = $1", chanID)
+		assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		assert.Equal(t, count, senmlCount, fmt.Sprintf("expected %d SenML messages of channel %s got %d", count, chanID, senmlCount))
+		err = db.Get(&jsonCount, "SELECT COUNT(*) FROM retention_json WHERE channel = $1", chanID)
+		assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		assert.Equal(t, count, jsonCount, fmt.Sprintf("expected %d JSON messages of channel %s got %d", count, chanID, jsonCount))
+	}
+
+	purged, err = purger.Purge(context.Background(), policy)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, uint64(0), purged, fmt.Sprintf("expected no purged messages got %d", purged))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/mainflux/mainflux/internal/apiutil"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things/presence"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc/codes"
@@ -41,6 +42,7 @@ var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*
 func MakeHandler(svc adapter.Service, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(withSource),
 	}
 
 	r := bone.New()
@@ -93,6 +95,12 @@ func parseSubtopic(subtopic string) (string, error) {
 	return subtopic, nil
 }
 
+// withSource stores the connection of the publisher, so that the things
+// service can track its presence.
+func withSource(ctx context.Context, r *http.Request) context.Context {
+	return presence.NewContext(ctx, presence.Source{Protocol: protocol, RemoteAddr: r.RemoteAddr})
+}
+
 func decodeRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	ct := r.Header.Get("Content-Type")
 	if ct != ctSenmlJSON && ct != contentType && ct != ctSenmlCBOR {
@@ -38,6 +38,7 @@ const (
 	VisibilityKey    = "visibility"
 	SharedByKey      = "shared_by"
 	TokenKey         = "token"
+	FilterKey        = "filter"
 	DefTotal         = uint64(100)
 	DefOffset        = 0
 	DefLimit         = 10
@@ -50,6 +51,8 @@ const (
 	AllVisibility    = "all"
 	// ContentType represents JSON content type.
 	ContentType = "application/json"
+	// CSVContentType represents CSV content type.
+	CSVContentType = "text/csv"
 
 	// MaxNameSize limits name size to prevent making them too complex.
 	MaxLimitSize = 100
@@ -58,6 +61,9 @@ const (
 	IDOrder      = "id"
 	AscDir       = "asc"
 	DescDir      = "desc"
+
+	// MaxOperations limits the number of operations of a bulk request.
+	MaxOperations = 10000
 )
 
 // ValidateUUID validates UUID format.
@@ -101,6 +107,7 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrMissingID),
 		errors.Contains(err, apiutil.ErrEmptyList),
 		errors.Contains(err, apiutil.ErrMissingMemberType),
+		errors.Contains(err, apiutil.ErrInvalidFilter),
 		errors.Contains(err, apiutil.ErrNameSize):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrAuthentication):
@@ -115,6 +122,8 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusConflict)
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case errors.Contains(err, errors.ErrTooManyRequests):
+		w.WriteHeader(http.StatusTooManyRequests)
 	case errors.Contains(err, errors.ErrCreateEntity),
 		errors.Contains(err, errors.ErrUpdateEntity),
 		errors.Contains(err, errors.ErrViewEntity),
@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"golang.org/x/exp/slices"
+)
+
+// ReadCSV reads CSV records into maps keyed by the column names given in the
+// header row. Header columns must be among the given ones, and empty cells
+// are left out of the record. At most MaxOperations records are read.
+func ReadCSV(r io.Reader, columns ...string) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, apiutil.ErrEmptyList
+		}
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	for _, col := range header {
+		if !slices.Contains(columns, col) {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrUnknownColumn)
+		}
+	}
+
+	var records []map[string]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		if len(records) == MaxOperations {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrTooManyOperations)
+		}
+		rec := map[string]string{}
+		for i, val := range row {
+			if val != "" {
+				rec[header[i]] = val
+			}
+		}
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
@@ -51,6 +51,9 @@ var (
 	// ErrInvalidDirection indicates an invalid list direction.
 	ErrInvalidDirection = errors.New("invalid list direction provided")
 
+	// ErrInvalidFilter indicates an invalid list filter expression.
+	ErrInvalidFilter = errors.New("invalid filter expression provided")
+
 	// ErrEmptyList indicates that entity data is empty.
 	ErrEmptyList = errors.New("empty list provided")
 
@@ -96,6 +99,9 @@ var (
 	// ErrInvalidComparator indicates an invalid comparator.
 	ErrInvalidComparator = errors.New("invalid comparator")
 
+	// ErrInvalidAggregation indicates an invalid aggregation function.
+	ErrInvalidAggregation = errors.New("invalid aggregation")
+
 	// ErrMissingMemberType indicates missing group member type.
 	ErrMissingMemberType = errors.New("missing group member type")
 
@@ -143,4 +149,10 @@ var (
 
 	// ErrUnsupportedContentType indicates unacceptable or lack of Content-Type.
 	ErrUnsupportedContentType = errors.New("unsupported content type")
+
+	// ErrUnknownColumn indicates an unknown column in the CSV header.
+	ErrUnknownColumn = errors.New("unknown CSV column")
+
+	// ErrTooManyOperations indicates that the bulk request exceeds the maximum number of operations.
+	ErrTooManyOperations = errors.New("too many operations in bulk request")
 )
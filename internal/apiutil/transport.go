@@ -59,6 +59,21 @@ func ReadStringQuery(r *http.Request, key string, def string) (string, error) {
 	return vals[0], nil
 }
 
+// ReadRawStringQuery reads the value of string http query parameters for a given key
+// as is, without splitting it on commas.
+func ReadRawStringQuery(r *http.Request, key string, def string) (string, error) {
+	vals := r.URL.Query()[key]
+	if len(vals) > 1 {
+		return "", ErrInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return def, nil
+	}
+
+	return vals[0], nil
+}
+
 // ReadMetadataQuery reads the value of json http query parameters for a given key.
 func ReadMetadataQuery(r *http.Request, key string, def map[string]interface{}) (map[string]interface{}, error) {
 	vals := bone.GetQuery(r, key)
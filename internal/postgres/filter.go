@@ -0,0 +1,134 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+)
+
+const timestampLayout = "2006-01-02 15:04:05.999999"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// CreateFilterQuery creates the conditions of the filter predicates for the
+// given entity prefix (e.g. "c."). Values are never interpolated into the
+// conditions; they are marshalled into a JSON array which must be bound to
+// the :filter parameter, and each condition refers to its element.
+func CreateFilterQuery(entity string, f mfclients.Filter) ([]string, []byte, error) {
+	if len(f.Predicates) == 0 {
+		return nil, nil, nil
+	}
+
+	conds := make([]string, len(f.Predicates))
+	params := make([]interface{}, len(f.Predicates))
+	for i, p := range f.Predicates {
+		param := fmt.Sprintf("(CAST(:filter AS jsonb) -> %d)", i)
+		text := fmt.Sprintf("(CAST(:filter AS jsonb) ->> %d)", i)
+
+		switch p.Field {
+		case mfclients.NameField:
+			column := entity + "name"
+			switch p.Operator {
+			case mfclients.OpIn:
+				conds[i] = fmt.Sprintf("%s @> jsonb_build_array(%s)", param, column)
+				params[i] = p.Values
+			case mfclients.OpPrefix:
+				conds[i] = fmt.Sprintf(`%s LIKE %s ESCAPE '\'`, column, text)
+				params[i] = likeEscaper.Replace(p.Values[0].(string)) + "%"
+			default:
+				conds[i] = fmt.Sprintf("%s %s %s", column, operator(p.Operator), text)
+				params[i] = p.Values[0]
+			}
+		case mfclients.CreatedAtField, mfclients.UpdatedAtField:
+			conds[i] = fmt.Sprintf("%s%s %s CAST(%s AS TIMESTAMP)", entity, p.Field, operator(p.Operator), text)
+			params[i] = p.Values[0].(time.Time).UTC().Format(timestampLayout)
+		// Things which have never been seen are offline, and have no
+		// last seen time to compare.
+		case mfclients.OnlineField:
+			conds[i] = fmt.Sprintf("COALESCE((%s), false) %s CAST(%s AS BOOLEAN)",
+				presenceQuery(entity, "online"), operator(p.Operator), text)
+			params[i] = p.Values[0]
+		case mfclients.LastSeenField:
+			conds[i] = fmt.Sprintf("(%s) %s CAST(%s AS TIMESTAMP)",
+				presenceQuery(entity, "last_seen"), operator(p.Operator), text)
+			params[i] = p.Values[0].(time.Time).UTC().Format(timestampLayout)
+		case mfclients.MetadataField:
+			column := entity + "metadata"
+			path := fmt.Sprintf("'{%s}'", strings.Join(p.Path, ","))
+			switch p.Operator {
+			// Equality is checked with containment, so that it's served by
+			// the GIN index on metadata.
+			case mfclients.OpEqual:
+				conds[i] = fmt.Sprintf("%s @> %s", column, param)
+				params[i] = nest(p.Path, p.Values[0])
+			case mfclients.OpNotEqual:
+				conds[i] = fmt.Sprintf("NOT (%s @> %s)", column, param)
+				params[i] = nest(p.Path, p.Values[0])
+			case mfclients.OpIn:
+				conds[i] = fmt.Sprintf("%s @> jsonb_build_array(%s #> %s)", param, column, path)
+				params[i] = p.Values
+			case mfclients.OpPrefix:
+				conds[i] = fmt.Sprintf(`%s #>> %s LIKE %s ESCAPE '\'`, column, path, text)
+				params[i] = likeEscaper.Replace(p.Values[0].(string)) + "%"
+			default:
+				// JSONB orders values of different types by type, so only
+				// values of the same type as the operand are compared.
+				conds[i] = fmt.Sprintf("jsonb_typeof(%s #> %s) = jsonb_typeof(%s) AND %s #> %s %s %s",
+					column, path, param, column, path, operator(p.Operator), param)
+				params[i] = p.Values[0]
+			}
+		default:
+			return nil, nil, fmt.Errorf("unknown filter field %q", p.Field)
+		}
+		conds[i] = fmt.Sprintf("(%s)", conds[i])
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conds, data, nil
+}
+
+// CreateOrderQuery creates the ORDER BY clause of the filter for the given
+// entity prefix, falling back to the given column in ascending order.
+func CreateOrderQuery(entity string, f mfclients.Filter, def string) string {
+	column, dir := def, "ASC"
+	if f.Order != "" {
+		column = f.Order
+	}
+	if f.Dir == mfclients.DescDir {
+		dir = "DESC"
+	}
+
+	return fmt.Sprintf("ORDER BY %s%s %s", entity, column, dir)
+}
+
+// presenceQuery selects the presence column of the thing.
+func presenceQuery(entity, column string) string {
+	return fmt.Sprintf("SELECT p.%s FROM client_presence p WHERE p.client_id = %sid", column, entity)
+}
+
+func operator(op mfclients.Operator) string {
+	if op == mfclients.OpNotEqual {
+		return "<>"
+	}
+
+	return string(op)
+}
+
+// nest wraps the value in objects along the path, e.g. {"a": {"b": v}}.
+func nest(path []string, v interface{}) interface{} {
+	for i := len(path) - 1; i >= 0; i-- {
+		v = map[string]interface{}{path[i]: v}
+	}
+
+	return v
+}
@@ -3,20 +3,26 @@
 
 package events
 
-import "github.com/mainflux/mainflux/pkg/events"
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/events"
+)
 
 var _ events.Event = (*mqttEvent)(nil)
 
 type mqttEvent struct {
-	clientID  string
-	eventType string
-	instance  string
+	clientID   string
+	eventType  string
+	instance   string
+	occurredAt time.Time
 }
 
 func (me mqttEvent) Encode() (map[string]interface{}, error) {
 	return map[string]interface{}{
-		"thing_id":   me.clientID,
-		"event_type": me.eventType,
-		"instance":   me.instance,
+		"thing_id":    me.clientID,
+		"event_type":  me.eventType,
+		"instance":    me.instance,
+		"occurred_at": me.occurredAt,
 	}, nil
 }
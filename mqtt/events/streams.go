@@ -5,6 +5,7 @@ package events
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/events"
 	"github.com/mainflux/mainflux/pkg/events/redis"
@@ -12,6 +13,8 @@ import (
 
 const streamID = "mainflux.mqtt"
 
+// EventStore publishes the connect and disconnect events of the things
+// connected to the MQTT adapter.
 type EventStore interface {
 	Connect(ctx context.Context, clientID string) error
 	Disconnect(ctx context.Context, clientID string) error
@@ -40,20 +43,22 @@ func NewEventStore(ctx context.Context, url, instance string) (EventStore, error
 // Connect issues event on MQTT CONNECT.
 func (es *eventStore) Connect(ctx context.Context, clientID string) error {
 	ev := mqttEvent{
-		clientID:  clientID,
-		eventType: "connect",
-		instance:  es.instance,
+		clientID:   clientID,
+		eventType:  "connect",
+		instance:   es.instance,
+		occurredAt: time.Now(),
 	}
 
 	return es.Publish(ctx, ev)
 }
 
-// Disconnect issues event on MQTT CONNECT.
+// Disconnect issues event on MQTT DISCONNECT.
 func (es *eventStore) Disconnect(ctx context.Context, clientID string) error {
 	ev := mqttEvent{
-		clientID:  clientID,
-		eventType: "disconnect",
-		instance:  es.instance,
+		clientID:   clientID,
+		eventType:  "disconnect",
+		instance:   es.instance,
+		occurredAt: time.Now(),
 	}
 
 	return es.Publish(ctx, ev)
@@ -16,6 +16,7 @@ import (
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/things/policies"
+	"github.com/mainflux/mainflux/things/presence"
 	"github.com/mainflux/mproxy/pkg/session"
 )
 
@@ -97,7 +98,7 @@ func (h *handler) AuthConnect(ctx context.Context) error {
 		return errors.ErrAuthentication
 	}
 
-	if err := h.es.Connect(ctx, pwd); err != nil {
+	if err := h.es.Connect(ctx, thid.GetId()); err != nil {
 		h.logger.Error(errors.Wrap(ErrFailedPublishConnectEvent, err).Error())
 	}
 
@@ -214,8 +215,8 @@ func (h *handler) Disconnect(ctx context.Context) error {
 	if !ok {
 		return errors.Wrap(ErrFailedDisconnect, ErrClientNotInitialized)
 	}
-	h.logger.Error(fmt.Sprintf(LogInfoDisconnected, s.ID, s.Password))
-	if err := h.es.Disconnect(ctx, string(s.Password)); err != nil {
+	h.logger.Error(fmt.Sprintf(LogInfoDisconnected, s.ID, s.Username))
+	if err := h.es.Disconnect(ctx, s.Username); err != nil {
 		return errors.Wrap(ErrFailedPublishDisconnectEvent, err)
 	}
 	return nil
@@ -241,6 +242,7 @@ func (h *handler) authAccess(ctx context.Context, password, topic, action string
 		Action:     action,
 		EntityType: policies.ThingEntityType,
 	}
+	ctx = presence.NewContext(ctx, presence.Source{Protocol: presence.MQTTProtocol})
 	res, err := h.auth.Authorize(ctx, ar)
 	if err != nil {
 		return err
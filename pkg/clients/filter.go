@@ -0,0 +1,429 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// Fields that can be used in filter expressions.
+const (
+	NameField      = "name"
+	CreatedAtField = "created_at"
+	UpdatedAtField = "updated_at"
+	MetadataField  = "metadata"
+	// Presence fields are only known for things.
+	OnlineField   = "online"
+	LastSeenField = "last_seen"
+)
+
+// Operator represents a comparison operator of a filter predicate.
+type Operator string
+
+// Supported filter operators.
+const (
+	OpEqual        Operator = "="
+	OpNotEqual     Operator = "!="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+	OpIn           Operator = "in"
+	OpPrefix       Operator = "prefix"
+)
+
+// Sort directions of a filter expression.
+const (
+	AscDir  = "asc"
+	DescDir = "desc"
+)
+
+// MaxPredicates limits the number of predicates of a filter expression.
+const MaxPredicates = 20
+
+var metadataKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Predicate represents a single condition of a filter expression. Path is
+// set only for metadata predicates and holds the keys leading to the value.
+// Values are strings, float64 or bool, except for created_at, updated_at and
+// last_seen predicates whose values are time.Time.
+type Predicate struct {
+	Field    string
+	Path     []string
+	Operator Operator
+	Values   []interface{}
+}
+
+// Filter represents a parsed filter expression: predicates which must all
+// hold and an optional ordering.
+type Filter struct {
+	Predicates []Predicate
+	Order      string
+	Dir        string
+}
+
+// Uses reports whether any of the filter predicates refers to any of the fields.
+func (f Filter) Uses(fields ...string) bool {
+	for _, p := range f.Predicates {
+		for _, field := range fields {
+			if p.Field == field {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ParseFilter parses the filter expression of the form:
+//
+//	metadata.site = 'plant-3' and metadata.fw < 2.0 and name prefix 'sensor'
+//	and created_at >= '2023-01-01T00:00:00Z' order by created_at desc
+//
+// Metadata fields are referenced with dot-separated keys. Supported
+// operators are =, !=, <, <=, >, >=, in (followed by a parenthesized list
+// of values) and prefix. Values are quoted strings, numbers, true or false.
+// Timestamps are either RFC3339 or relative to the current time, such as
+// 'now-1h'.
+func ParseFilter(expr string) (Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return Filter{}, err
+	}
+	p := parser{tokens: tokens}
+
+	var f Filter
+	for !p.done() {
+		if p.keyword("order") {
+			if !p.keyword("by") {
+				return Filter{}, invalidFilter("expected 'by' after 'order'")
+			}
+			f.Order, f.Dir, err = p.order()
+			if err != nil {
+				return Filter{}, err
+			}
+			if !p.done() {
+				return Filter{}, invalidFilter("unexpected %q after ordering", p.peek().text)
+			}
+			break
+		}
+		if len(f.Predicates) > 0 && !p.keyword("and") {
+			return Filter{}, invalidFilter("expected 'and' or 'order', got %q", p.peek().text)
+		}
+		pr, err := p.predicate()
+		if err != nil {
+			return Filter{}, err
+		}
+		f.Predicates = append(f.Predicates, pr)
+		if len(f.Predicates) > MaxPredicates {
+			return Filter{}, invalidFilter("more than %d predicates", MaxPredicates)
+		}
+	}
+
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	stringToken
+	operatorToken
+	punctToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == ',':
+			tokens = append(tokens, token{punctToken, string(c)})
+			i++
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			op := string(c)
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, invalidFilter("unexpected '!'")
+			}
+			tokens = append(tokens, token{operatorToken, op})
+			i += len(op)
+		case c == '\'' || c == '"':
+			// A quote is escaped by doubling it, as in SQL.
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(expr); j++ {
+				if expr[j] == c {
+					if j+1 < len(expr) && expr[j+1] == c {
+						b.WriteByte(c)
+						j++
+						continue
+					}
+					break
+				}
+				b.WriteByte(expr[j])
+			}
+			if j == len(expr) {
+				return nil, invalidFilter("unterminated string")
+			}
+			tokens = append(tokens, token{stringToken, b.String()})
+			i = j + 1
+		default:
+			j := i
+			for j < len(expr) && strings.IndexByte(" \t\n\r(),=!<>'\"", expr[j]) < 0 {
+				j++
+			}
+			tokens = append(tokens, token{wordToken, expr[i:j]})
+			i = j
+		}
+	}
+
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos == len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() (token, error) {
+	if p.done() {
+		return token{}, invalidFilter("unexpected end of expression")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+
+	return t, nil
+}
+
+// keyword consumes the next token if it's the given case-insensitive keyword.
+func (p *parser) keyword(kw string) bool {
+	if t := p.peek(); t.kind == wordToken && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) punct(c string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.kind != punctToken || t.text != c {
+		return invalidFilter("expected %q, got %q", c, t.text)
+	}
+
+	return nil
+}
+
+func (p *parser) order() (string, string, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", "", err
+	}
+	switch t.text {
+	case NameField, CreatedAtField, UpdatedAtField:
+	default:
+		return "", "", invalidFilter("can't order by %q", t.text)
+	}
+	switch {
+	case p.keyword(AscDir):
+		return t.text, AscDir, nil
+	case p.keyword(DescDir):
+		return t.text, DescDir, nil
+	default:
+		return t.text, AscDir, nil
+	}
+}
+
+func (p *parser) predicate() (Predicate, error) {
+	t, err := p.next()
+	if err != nil {
+		return Predicate{}, err
+	}
+	if t.kind != wordToken {
+		return Predicate{}, invalidFilter("expected field, got %q", t.text)
+	}
+	pr := Predicate{Field: t.text}
+	if strings.HasPrefix(t.text, MetadataField+".") {
+		pr.Field = MetadataField
+		pr.Path = strings.Split(strings.TrimPrefix(t.text, MetadataField+"."), ".")
+		for _, key := range pr.Path {
+			if !metadataKey.MatchString(key) {
+				return Predicate{}, invalidFilter("invalid metadata key %q", key)
+			}
+		}
+	}
+
+	t, err = p.next()
+	if err != nil {
+		return Predicate{}, err
+	}
+	switch {
+	case t.kind == operatorToken:
+		pr.Operator = Operator(t.text)
+	case t.kind == wordToken && strings.EqualFold(t.text, string(OpIn)):
+		pr.Operator = OpIn
+	case t.kind == wordToken && strings.EqualFold(t.text, string(OpPrefix)):
+		pr.Operator = OpPrefix
+	default:
+		return Predicate{}, invalidFilter("expected operator, got %q", t.text)
+	}
+
+	if pr.Operator == OpIn {
+		if err := p.punct("("); err != nil {
+			return Predicate{}, err
+		}
+		for {
+			v, err := p.value()
+			if err != nil {
+				return Predicate{}, err
+			}
+			pr.Values = append(pr.Values, v)
+			if t := p.peek(); t.kind != punctToken || t.text != "," {
+				break
+			}
+			p.pos++
+		}
+		if err := p.punct(")"); err != nil {
+			return Predicate{}, err
+		}
+	} else {
+		v, err := p.value()
+		if err != nil {
+			return Predicate{}, err
+		}
+		pr.Values = []interface{}{v}
+	}
+
+	return pr, pr.validate()
+}
+
+func (p *parser) value() (interface{}, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch t.kind {
+	case stringToken:
+		return t.text, nil
+	case wordToken:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		if n, err := strconv.ParseFloat(t.text, 64); err == nil {
+			return n, nil
+		}
+	}
+
+	return nil, invalidFilter("invalid value %q", t.text)
+}
+
+// validate checks that the operator and the values fit the field, and
+// converts the timestamps.
+func (pr Predicate) validate() error {
+	switch pr.Field {
+	case NameField:
+		for _, v := range pr.Values {
+			if _, ok := v.(string); !ok {
+				return invalidFilter("name must be compared to strings")
+			}
+		}
+	case CreatedAtField, UpdatedAtField, LastSeenField:
+		if pr.Operator == OpIn || pr.Operator == OpPrefix {
+			return invalidFilter("%s doesn't support %q", pr.Field, pr.Operator)
+		}
+		s, ok := pr.Values[0].(string)
+		if !ok {
+			return invalidFilter("%s must be compared to timestamps", pr.Field)
+		}
+		t, err := parseTime(s)
+		if err != nil {
+			return invalidFilter("%s must be compared to timestamps", pr.Field)
+		}
+		pr.Values[0] = t
+	case OnlineField:
+		if pr.Operator != OpEqual && pr.Operator != OpNotEqual {
+			return invalidFilter("%s doesn't support %q", pr.Field, pr.Operator)
+		}
+		if _, ok := pr.Values[0].(bool); !ok {
+			return invalidFilter("%s must be compared to true or false", pr.Field)
+		}
+	case MetadataField:
+		if len(pr.Path) == 0 {
+			return invalidFilter("metadata must be followed by a key")
+		}
+		switch pr.Operator {
+		case OpPrefix:
+			if _, ok := pr.Values[0].(string); !ok {
+				return invalidFilter("prefix must be a string")
+			}
+		case OpLess, OpLessEqual, OpGreater, OpGreaterEqual:
+			if _, ok := pr.Values[0].(bool); ok {
+				return invalidFilter("booleans can't be compared with %q", pr.Operator)
+			}
+		}
+	default:
+		return invalidFilter("unknown field %q", pr.Field)
+	}
+
+	return nil
+}
+
+// parseTime parses the RFC3339 timestamp or the time relative to now, e.g.
+// "now", "now-1h" or "now+30m".
+func parseTime(s string) (time.Time, error) {
+	if !strings.HasPrefix(s, "now") {
+		return time.Parse(time.RFC3339, s)
+	}
+	offset := strings.TrimPrefix(s, "now")
+	if offset == "" {
+		return time.Now(), nil
+	}
+	if offset[0] != '-' && offset[0] != '+' {
+		return time.Time{}, fmt.Errorf("invalid relative time %q", s)
+	}
+	d, err := time.ParseDuration(offset)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Now().Add(d), nil
+}
+
+func invalidFilter(format string, args ...interface{}) error {
+	return errors.Wrap(apiutil.ErrInvalidFilter, errors.New(fmt.Sprintf(format, args...)))
+}
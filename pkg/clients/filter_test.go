@@ -0,0 +1,212 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	cases := []struct {
+		desc   string
+		expr   string
+		filter clients.Filter
+		err    error
+	}{
+		{
+			desc:   "parse empty expression",
+			expr:   "",
+			filter: clients.Filter{},
+		},
+		{
+			desc: "parse metadata predicates",
+			expr: "metadata.site = 'plant-3' and metadata.fw < 2.0 and metadata.location.indoor != true",
+			filter: clients.Filter{
+				Predicates: []clients.Predicate{
+					{Field: clients.MetadataField, Path: []string{"site"}, Operator: clients.OpEqual, Values: []interface{}{"plant-3"}},
+					{Field: clients.MetadataField, Path: []string{"fw"}, Operator: clients.OpLess, Values: []interface{}{2.0}},
+					{Field: clients.MetadataField, Path: []string{"location", "indoor"}, Operator: clients.OpNotEqual, Values: []interface{}{true}},
+				},
+			},
+		},
+		{
+			desc: "parse in list and prefix",
+			expr: `metadata.site IN ("plant-1", 'plant,2', 3) AND name PREFIX 'it''s'`,
+			filter: clients.Filter{
+				Predicates: []clients.Predicate{
+					{Field: clients.MetadataField, Path: []string{"site"}, Operator: clients.OpIn, Values: []interface{}{"plant-1", "plant,2", 3.0}},
+					{Field: clients.NameField, Operator: clients.OpPrefix, Values: []interface{}{"it's"}},
+				},
+			},
+		},
+		{
+			desc: "parse time range and ordering",
+			expr: "created_at>='2023-01-01T00:00:00Z' and created_at<'2023-02-01T00:00:00+01:00' order by updated_at desc",
+			filter: clients.Filter{
+				Predicates: []clients.Predicate{
+					{Field: clients.CreatedAtField, Operator: clients.OpGreaterEqual, Values: []interface{}{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}},
+					{Field: clients.CreatedAtField, Operator: clients.OpLess, Values: []interface{}{time.Date(2023, 2, 1, 0, 0, 0, 0, time.FixedZone("", 3600))}},
+				},
+				Order: clients.UpdatedAtField,
+				Dir:   clients.DescDir,
+			},
+		},
+		{
+			desc: "parse presence predicates",
+			expr: "online = false and last_seen < '2023-01-01T00:00:00Z'",
+			filter: clients.Filter{
+				Predicates: []clients.Predicate{
+					{Field: clients.OnlineField, Operator: clients.OpEqual, Values: []interface{}{false}},
+					{Field: clients.LastSeenField, Operator: clients.OpLess, Values: []interface{}{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}},
+				},
+			},
+		},
+		{
+			desc:   "parse ordering only",
+			expr:   "order by name",
+			filter: clients.Filter{Order: clients.NameField, Dir: clients.AscDir},
+		},
+		{
+			desc: "parse with missing conjunction",
+			expr: "name = 'a' name = 'b'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with unknown field",
+			expr: "owner = 'a'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with unknown operator",
+			expr: "name ~ 'a'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with invalid metadata key",
+			expr: "metadata.si'te = 'a'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with metadata without key",
+			expr: "metadata = 'a'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with unterminated string",
+			expr: "name = 'a",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with unterminated list",
+			expr: "name in ('a', 'b'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with number compared to name",
+			expr: "name = 1",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with invalid timestamp",
+			expr: "updated_at > '2023-01-01'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with invalid relative timestamp",
+			expr: "last_seen < 'now1h'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with online compared to string",
+			expr: "online = 'yes'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with online comparison",
+			expr: "online > false",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with prefix of timestamp",
+			expr: "updated_at prefix '2023'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with boolean comparison",
+			expr: "metadata.indoor > false",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with invalid ordering",
+			expr: "order by metadata.fw",
+			err:  apiutil.ErrInvalidFilter,
+		},
+		{
+			desc: "parse with predicate after ordering",
+			expr: "order by name and name = 'a'",
+			err:  apiutil.ErrInvalidFilter,
+		},
+	}
+
+	for _, tc := range cases {
+		filter, err := clients.ParseFilter(tc.expr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.filter, filter, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.filter, filter))
+		}
+	}
+}
+
+func TestParseFilterPredicatesLimit(t *testing.T) {
+	expr := "name = 'a'"
+	for i := 0; i < clients.MaxPredicates; i++ {
+		expr += " and name = 'a'"
+	}
+	_, err := clients.ParseFilter(expr)
+	assert.True(t, errors.Contains(err, apiutil.ErrInvalidFilter), fmt.Sprintf("expected error %s got %s\n", apiutil.ErrInvalidFilter, err))
+}
+
+func TestParseFilterRelativeTime(t *testing.T) {
+	cases := []struct {
+		desc   string
+		expr   string
+		offset time.Duration
+	}{
+		{
+			desc: "parse current time",
+			expr: "last_seen < 'now'",
+		},
+		{
+			desc:   "parse time in the past",
+			expr:   "last_seen < 'now-1h'",
+			offset: -time.Hour,
+		},
+		{
+			desc:   "parse time in the future",
+			expr:   "created_at < 'now+30m'",
+			offset: 30 * time.Minute,
+		},
+	}
+
+	for _, tc := range cases {
+		filter, err := clients.ParseFilter(tc.expr)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		got := filter.Predicates[0].Values[0].(time.Time)
+		assert.WithinDuration(t, time.Now().Add(tc.offset), got, time.Second, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, time.Now().Add(tc.offset), got))
+	}
+}
+
+func TestFilterUses(t *testing.T) {
+	filter, err := clients.ParseFilter("name = 'a' and online = true")
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	assert.True(t, filter.Uses(clients.OnlineField, clients.LastSeenField), "expected filter to use presence fields")
+	assert.False(t, filter.Uses(clients.MetadataField), "expected filter not to use metadata")
+}
@@ -20,4 +20,5 @@ type Page struct {
 	Subject  string   `json:"subject,omitempty"`
 	IDs      []string `json:"ids,omitempty"`
 	Identity string   `json:"identity,omitempty"`
+	Filter   Filter   `json:"-"`
 }
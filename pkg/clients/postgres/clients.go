@@ -140,7 +140,8 @@ func (repo ClientRepository) RetrieveAll(ctx context.Context, pm clients.Page) (
 	}
 
 	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, c.identity, c.metadata, COALESCE(c.owner_id, '') AS owner_id, c.status,
-					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, query)
+					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s %s LIMIT :limit OFFSET :offset;`,
+		query, postgres.CreateOrderQuery("c.", pm.Filter, "created_at"))
 
 	dbPage, err := toDBClientsPage(pm)
 	if err != nil {
@@ -201,7 +202,7 @@ func (repo ClientRepository) Members(ctx context.Context, groupID string, pm cli
 	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, c.metadata, c.identity, c.status,
 		c.created_at, c.updated_at FROM clients c
 		INNER JOIN policies ON c.id=policies.subject %s AND policies.object = :group_id %s
-	  	ORDER BY c.created_at LIMIT :limit OFFSET :offset;`, emq, aq)
+	  	%s LIMIT :limit OFFSET :offset;`, emq, aq, postgres.CreateOrderQuery("c.", pm.Filter, "created_at"))
 	dbPage, err := toDBClientsPage(pm)
 	if err != nil {
 		return clients.MembersPage{}, errors.Wrap(postgres.ErrFailedToRetrieveAll, err)
@@ -376,10 +377,15 @@ func toDBClientsPage(pm clients.Page) (dbClientsPage, error) {
 	if err != nil {
 		return dbClientsPage{}, errors.Wrap(errors.ErrViewEntity, err)
 	}
+	_, filter, err := postgres.CreateFilterQuery("c.", pm.Filter)
+	if err != nil {
+		return dbClientsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
 	return dbClientsPage{
 		Name:     pm.Name,
 		Identity: pm.Identity,
 		Metadata: data,
+		Filter:   filter,
 		Owner:    pm.Owner,
 		Total:    pm.Total,
 		Offset:   pm.Offset,
@@ -400,6 +406,7 @@ type dbClientsPage struct {
 	Owner    string         `db:"owner_id"`
 	Identity string         `db:"identity"`
 	Metadata []byte         `db:"metadata"`
+	Filter   []byte         `db:"filter"`
 	Tag      string         `db:"tag"`
 	Status   clients.Status `db:"status"`
 	GroupID  string         `db:"group_id"`
@@ -413,11 +420,16 @@ func pageQuery(pm clients.Page) (string, error) {
 	if err != nil {
 		return "", errors.Wrap(errors.ErrViewEntity, err)
 	}
+	fq, _, err := postgres.CreateFilterQuery("c.", pm.Filter)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrViewEntity, err)
+	}
 	var query []string
 	var emq string
 	if mq != "" {
 		query = append(query, mq)
 	}
+	query = append(query, fq...)
 	if len(pm.IDs) != 0 {
 		query = append(query, fmt.Sprintf("id IN ('%s')", strings.Join(pm.IDs, "','")))
 	}
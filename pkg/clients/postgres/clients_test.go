@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/internal/testsutil"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
@@ -398,6 +399,144 @@ func TestClientsRetrieveAll(t *testing.T) {
 	}
 }
 
+func TestClientsRetrieveAllWithFilter(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := cpostgres.NewRepository(database)
+
+	nClients := 30
+	clients := []mfclients.Client{}
+	for i := 0; i < nClients; i++ {
+		name := fmt.Sprintf("sensor-%d", i)
+		if i%2 == 0 {
+			name = fmt.Sprintf("gateway-%d", i)
+		}
+		client := mfclients.Client{
+			ID:   testsutil.GenerateUUID(t, idProvider),
+			Name: name,
+			Credentials: mfclients.Credentials{
+				Identity: fmt.Sprintf("TestRetrieveAllWithFilter%d@example.com", i),
+				Secret:   password,
+			},
+			Metadata: mfclients.Metadata{
+				"site": fmt.Sprintf("plant-%d", i%3),
+				"fw":   float64(i%5) * 0.5,
+				"location": map[string]interface{}{
+					"floor": float64(i % 4),
+				},
+			},
+			CreatedAt: time.Date(2023, 1, 1+i, 0, 0, 0, 0, time.UTC),
+			Status:    mfclients.EnabledStatus,
+		}
+		client, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		clients = append(clients, client)
+	}
+
+	// filter returns the clients matching the predicate, in creation order.
+	filter := func(match func(i int, c mfclients.Client) bool) []mfclients.Client {
+		ret := []mfclients.Client{}
+		for i, c := range clients {
+			if match(i, c) {
+				ret = append(ret, c)
+			}
+		}
+		return ret
+	}
+
+	cases := []struct {
+		desc     string
+		expr     string
+		response []mfclients.Client
+	}{
+		{
+			desc:     "retrieve clients by metadata equality",
+			expr:     "metadata.site = 'plant-1'",
+			response: filter(func(i int, _ mfclients.Client) bool { return i%3 == 1 }),
+		},
+		{
+			desc:     "retrieve clients by metadata inequality",
+			expr:     "metadata.site != 'plant-1'",
+			response: filter(func(i int, _ mfclients.Client) bool { return i%3 != 1 }),
+		},
+		{
+			desc:     "retrieve clients by metadata comparison",
+			expr:     "metadata.site = 'plant-0' and metadata.fw < 1.0",
+			response: filter(func(i int, _ mfclients.Client) bool { return i%3 == 0 && i%5 < 2 }),
+		},
+		{
+			desc:     "retrieve clients by nested metadata",
+			expr:     "metadata.location.floor >= 2",
+			response: filter(func(i int, _ mfclients.Client) bool { return i%4 >= 2 }),
+		},
+		{
+			desc:     "retrieve clients by metadata in list",
+			expr:     "metadata.location.floor in (0, 3)",
+			response: filter(func(i int, _ mfclients.Client) bool { return i%4 == 0 || i%4 == 3 }),
+		},
+		{
+			desc:     "retrieve clients by metadata prefix",
+			expr:     "metadata.site prefix 'plant-2'",
+			response: filter(func(i int, _ mfclients.Client) bool { return i%3 == 2 }),
+		},
+		{
+			desc:     "retrieve clients by metadata comparison of different type",
+			expr:     "metadata.site < 10",
+			response: []mfclients.Client{},
+		},
+		{
+			desc:     "retrieve clients by name prefix",
+			expr:     "name prefix 'sensor'",
+			response: filter(func(i int, _ mfclients.Client) bool { return i%2 == 1 }),
+		},
+		{
+			desc:     "retrieve clients by name prefix with wildcard",
+			expr:     "name prefix 'sensor%'",
+			response: []mfclients.Client{},
+		},
+		{
+			desc:     "retrieve clients by name in list",
+			expr:     "name in ('sensor-1', 'gateway-2', 'unknown')",
+			response: []mfclients.Client{clients[1], clients[2]},
+		},
+		{
+			desc: "retrieve clients by creation range",
+			expr: "created_at >= '2023-01-05T00:00:00Z' and created_at < '2023-01-10T00:00:00Z'",
+			response: filter(func(_ int, c mfclients.Client) bool {
+				return !c.CreatedAt.Before(time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC)) && c.CreatedAt.Before(time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC))
+			}),
+		},
+		{
+			desc:     "retrieve clients ordered by creation descending",
+			expr:     "name prefix 'gateway-1' order by created_at desc",
+			response: []mfclients.Client{clients[18], clients[16], clients[14], clients[12], clients[10]},
+		},
+		{
+			desc:     "retrieve clients ordered by name",
+			expr:     "metadata.site = 'plant-0' and metadata.fw = 0 order by name",
+			response: []mfclients.Client{clients[0], clients[15]},
+		},
+	}
+
+	for _, tc := range cases {
+		f, err := mfclients.ParseFilter(tc.expr)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		pm := mfclients.Page{
+			Offset: 0,
+			Limit:  uint64(nClients),
+			Status: mfclients.AllStatus,
+			Filter: f,
+		}
+		page, err := repo.RetrieveAll(context.Background(), pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, uint64(len(tc.response)), page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, len(tc.response), page.Total))
+		if len(tc.response) == 0 {
+			assert.Empty(t, page.Clients, fmt.Sprintf("%s: expected no clients got %v\n", tc.desc, page.Clients))
+			continue
+		}
+		assert.Equal(t, tc.response, page.Clients, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, page.Clients))
+	}
+}
+
 func TestGroupsMembers(t *testing.T) {
 	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
 	crepo := cpostgres.NewRepository(database)
@@ -17,11 +17,14 @@ const (
 	EnabledStatus Status = iota
 	// DisabledStatus represents disabled Client.
 	DisabledStatus
+	// PendingStatus represents Client which has registered itself and
+	// hasn't verified its identity yet.
+	PendingStatus
 
 	// AllStatus is used for querying purposes to list clients irrespective
-	// of their status - both enabled and disabled. It is never stored in the
-	// database as the actual Client status and should always be the largest
-	// value in this enumeration.
+	// of their status. It is never stored in the database as the actual
+	// Client status and should always be the largest value in this
+	// enumeration.
 	AllStatus
 )
 
@@ -29,6 +32,7 @@ const (
 const (
 	Disabled = "disabled"
 	Enabled  = "enabled"
+	Pending  = "pending"
 	All      = "all"
 	Unknown  = "unknown"
 )
@@ -43,6 +47,8 @@ func (s Status) String() string {
 		return Disabled
 	case EnabledStatus:
 		return Enabled
+	case PendingStatus:
+		return Pending
 	case AllStatus:
 		return All
 	default:
@@ -57,6 +63,8 @@ func ToStatus(status string) (Status, error) {
 		return EnabledStatus, nil
 	case Disabled:
 		return DisabledStatus, nil
+	case Pending:
+		return PendingStatus, nil
 	case All:
 		return AllStatus, nil
 	}
@@ -39,4 +39,10 @@ var (
 
 	// ErrLogin indicates wrong login credentials.
 	ErrLogin = New("invalid user id or secret")
+
+	// ErrTooManyRequests indicates that the request is rejected because of too many previous requests.
+	ErrTooManyRequests = New("too many requests")
+
+	// ErrQuotaExceeded indicates that the tenant quota for the entity has been reached.
+	ErrQuotaExceeded = New("quota exceeded")
 )
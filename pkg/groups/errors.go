@@ -17,4 +17,7 @@ var (
 
 	// ErrStatusAlreadyAssigned indicated that the group has already been assigned the status.
 	ErrStatusAlreadyAssigned = errors.New("status already assigned")
+
+	// ErrGroupNotEmpty indicates that the group can't be removed since it has child groups.
+	ErrGroupNotEmpty = errors.New("group has child groups")
 )
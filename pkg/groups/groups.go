@@ -60,6 +60,11 @@ type Repository interface {
 	// Save group.
 	Save(ctx context.Context, g Group) (Group, error)
 
+	// SaveWithQuota saves the groups of the owner in a single transaction,
+	// unless the owner would exceed the quota of groups. Zero quota leaves
+	// the number unlimited.
+	SaveWithQuota(ctx context.Context, owner string, quota uint64, gs ...Group) ([]Group, error)
+
 	// Update a group.
 	Update(ctx context.Context, g Group) (Group, error)
 
@@ -74,4 +79,8 @@ type Repository interface {
 
 	// ChangeStatus changes groups status to active or inactive
 	ChangeStatus(ctx context.Context, group Group) (Group, error)
+
+	// Delete removes the group together with its policies. Groups with
+	// child groups are not removed.
+	Delete(ctx context.Context, id string) error
 }
@@ -14,4 +14,5 @@ type Page struct {
 	Status   clients.Status   `json:"status,omitempty"`
 	Subject  string           `json:"subject,omitempty"`
 	Action   string           `json:"action,omitempty"`
+	Filter   clients.Filter   `json:"-"`
 }
@@ -53,6 +53,72 @@ func (repo groupRepository) Save(ctx context.Context, g mfgroups.Group) (mfgroup
 	return toGroup(dbg)
 }
 
+func (repo groupRepository) SaveWithQuota(ctx context.Context, owner string, quota uint64, gs ...mfgroups.Group) ([]mfgroups.Group, error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return []mfgroups.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	grps, err := saveWithQuota(ctx, tx, owner, quota, gs)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			return []mfgroups.Group{}, postgres.HandleError(err, errors.ErrCreateEntity)
+		}
+		return []mfgroups.Group{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return []mfgroups.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return grps, nil
+}
+
+// saveWithQuota locks the groups of the owner until the end of the
+// transaction, so concurrent transactions can't change their number between
+// counting the groups and saving the new ones.
+func saveWithQuota(ctx context.Context, tx *sqlx.Tx, owner string, quota uint64, gs []mfgroups.Group) ([]mfgroups.Group, error) {
+	if quota > 0 {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('groups'), hashtext($1))`, owner); err != nil {
+			return []mfgroups.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
+		}
+		var total uint64
+		if err := tx.GetContext(ctx, &total, `SELECT COUNT(*) FROM groups WHERE owner_id = $1`, owner); err != nil {
+			return []mfgroups.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
+		}
+		if total+uint64(len(gs)) > quota {
+			return []mfgroups.Group{}, errors.Wrap(errors.ErrAuthorization, errors.ErrQuotaExceeded)
+		}
+	}
+
+	q := `INSERT INTO groups (name, description, id, owner_id, parent_id, metadata, created_at, status)
+		VALUES (:name, :description, :id, :owner_id, :parent_id, :metadata, :created_at, :status)
+		RETURNING id, name, description, owner_id, COALESCE(parent_id, '') AS parent_id, metadata, created_at, status;`
+	var grps []mfgroups.Group
+	for _, g := range gs {
+		dbg, err := toDBGroup(g)
+		if err != nil {
+			return []mfgroups.Group{}, err
+		}
+		row, err := sqlx.NamedQueryContext(ctx, tx, q, dbg)
+		if err != nil {
+			return []mfgroups.Group{}, postgres.HandleError(err, errors.ErrCreateEntity)
+		}
+		row.Next()
+		dbg = dbGroup{}
+		err = row.StructScan(&dbg)
+		row.Close()
+		if err != nil {
+			return []mfgroups.Group{}, err
+		}
+		grp, err := toGroup(dbg)
+		if err != nil {
+			return []mfgroups.Group{}, err
+		}
+		grps = append(grps, grp)
+	}
+
+	return grps, nil
+}
+
 func (repo groupRepository) Memberships(ctx context.Context, clientID string, gm mfgroups.GroupsPage) (mfgroups.MembershipsPage, error) {
 	var q string
 	query, err := buildQuery(gm)
@@ -72,7 +138,7 @@ func (repo groupRepository) Memberships(ctx context.Context, clientID string, gm
 		aq = `AND policies.object IN (SELECT object FROM policies WHERE subject = :subject AND :action=ANY(actions)) OR g.owner_id = :subject`
 	}
 	q = fmt.Sprintf(`%s INNER JOIN policies ON g.id=policies.object %s AND policies.subject = :client_id %s
-			ORDER BY g.updated_at LIMIT :limit OFFSET :offset;`, q, query, aq)
+			%s LIMIT :limit OFFSET :offset;`, q, query, aq, postgres.CreateOrderQuery("g.", gm.Filter, "updated_at"))
 
 	dbPage, err := toDBGroupPage(gm)
 	if err != nil {
@@ -181,6 +247,48 @@ func (repo groupRepository) ChangeStatus(ctx context.Context, group mfgroups.Gro
 	return toGroup(dbg)
 }
 
+func (repo groupRepository) Delete(ctx context.Context, id string) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if err := deleteGroup(ctx, tx, id); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return postgres.HandleError(err, errors.ErrRemoveEntity)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// deleteGroup removes the group unless it has child groups. The group row is
+// locked first, so no child group can be added before the group is removed.
+func deleteGroup(ctx context.Context, tx *sqlx.Tx, id string) error {
+	var locked string
+	if err := tx.GetContext(ctx, &locked, `SELECT id FROM groups WHERE id = $1 FOR UPDATE`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Wrap(errors.ErrNotFound, err)
+		}
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	var children bool
+	if err := tx.GetContext(ctx, &children, `SELECT EXISTS (SELECT 1 FROM groups WHERE parent_id = $1)`, id); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if children {
+		return errors.Wrap(errors.ErrConflict, mfgroups.ErrGroupNotEmpty)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM groups WHERE id = $1`, id); err != nil {
+		return postgres.HandleError(err, errors.ErrRemoveEntity)
+	}
+
+	return nil
+}
+
 func (repo groupRepository) RetrieveByID(ctx context.Context, id string) (mfgroups.Group, error) {
 	q := `SELECT id, name, owner_id, COALESCE(parent_id, '') AS parent_id, description, metadata, created_at, updated_at, updated_by, status FROM groups
 	    WHERE id = :id`
@@ -221,7 +329,7 @@ func (repo groupRepository) RetrieveAll(ctx context.Context, gm mfgroups.GroupsP
 		q = `SELECT DISTINCT g.id, g.owner_id, COALESCE(g.parent_id, '') AS parent_id, g.name, g.description,
 		g.metadata, g.created_at, g.updated_at, g.updated_by, g.status FROM groups g`
 	}
-	q = fmt.Sprintf("%s %s ORDER BY g.updated_at LIMIT :limit OFFSET :offset;", q, query)
+	q = fmt.Sprintf("%s %s %s LIMIT :limit OFFSET :offset;", q, query, postgres.CreateOrderQuery("g.", gm.Filter, "updated_at"))
 
 	dbPage, err := toDBGroupPage(gm)
 	if err != nil {
@@ -294,8 +402,13 @@ func buildQuery(gm mfgroups.GroupsPage) (string, error) {
 		queries = append(queries, "(g.owner_id = :owner_id OR id IN (SELECT object as id FROM policies WHERE subject = :subject AND :action=ANY(actions)))")
 	}
 	if len(gm.Metadata) > 0 {
-		queries = append(queries, "'g.metadata @> :metadata'")
+		queries = append(queries, "g.metadata @> :metadata")
+	}
+	fq, _, err := postgres.CreateFilterQuery("g.", gm.Filter)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrMalformedEntity, err)
 	}
+	queries = append(queries, fq...)
 	if len(queries) > 0 {
 		return fmt.Sprintf("WHERE %s", strings.Join(queries, " AND ")), nil
 	}
@@ -402,10 +515,15 @@ func toDBGroupPage(pm mfgroups.GroupsPage) (dbGroupPage, error) {
 		}
 		data = b
 	}
+	_, filter, err := postgres.CreateFilterQuery("g.", pm.Filter)
+	if err != nil {
+		return dbGroupPage{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
 	return dbGroupPage{
 		ID:       pm.ID,
 		Name:     pm.Name,
 		Metadata: data,
+		Filter:   filter,
 		Path:     pm.Path,
 		Level:    level,
 		Total:    pm.Total,
@@ -426,6 +544,7 @@ type dbGroupPage struct {
 	ParentID string           `db:"parent_id"`
 	OwnerID  string           `db:"owner_id"`
 	Metadata []byte           `db:"metadata"`
+	Filter   []byte           `db:"filter"`
 	Path     string           `db:"path"`
 	Level    uint64           `db:"level"`
 	Total    uint64           `db:"total"`
@@ -591,3 +591,57 @@ func TestGroupChangeStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupDelete(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := gpostgres.New(database)
+
+	parent, err := repo.Save(context.Background(), mfgroups.Group{
+		ID:     testsutil.GenerateUUID(t, idProvider),
+		Name:   "parent-group",
+		Status: mfclients.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("add new group: expected nil got %s\n", err))
+	child, err := repo.Save(context.Background(), mfgroups.Group{
+		ID:     testsutil.GenerateUUID(t, idProvider),
+		Parent: parent.ID,
+		Name:   "child-group",
+		Status: mfclients.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("add new child group: expected nil got %s\n", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "delete group with child groups",
+			id:   parent.ID,
+			err:  errors.ErrConflict,
+		},
+		{
+			desc: "delete existing group",
+			id:   child.ID,
+			err:  nil,
+		},
+		{
+			desc: "delete group without child groups",
+			id:   parent.ID,
+			err:  nil,
+		},
+		{
+			desc: "delete non-existing group",
+			id:   wrongID,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Delete(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = repo.RetrieveByID(context.Background(), parent.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve deleted group: expected %s got %s\n", errors.ErrNotFound, err))
+}
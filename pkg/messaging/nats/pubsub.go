@@ -162,6 +162,15 @@ func (ps *pubsub) natsHandler(h messaging.MessageHandler) broker.MsgHandler {
 			return
 		}
 
+		if ah, ok := h.(messaging.AckHandler); ok {
+			ah.HandleAck(&msg, func(err error) {
+				if err != nil {
+					ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+				}
+			})
+			return
+		}
+
 		if err := h.Handle(&msg); err != nil {
 			ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
 		}
@@ -23,6 +23,19 @@ type MessageHandler interface {
 	Cancel() error
 }
 
+// AckHandler represents Message handler which processes messages
+// asynchronously. Subscribers which support acknowledgement pass the
+// messages to HandleAck instead of Handle and acknowledge them to the
+// broker only once the ack function is called.
+type AckHandler interface {
+	MessageHandler
+
+	// HandleAck handles the message and calls ack with the processing
+	// result once the message is processed. HandleAck may block in order
+	// to apply back-pressure to the subscriber.
+	HandleAck(msg *Message, ack func(err error))
+}
+
 // Subscriber specifies message subscription API.
 type Subscriber interface {
 	// Subscribe subscribes to the message stream and consumes messages.
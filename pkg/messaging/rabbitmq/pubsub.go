@@ -107,11 +107,18 @@ func (ps *pubsub) Subscribe(ctx context.Context, id, topic string, handler messa
 		return err
 	}
 	clientID := fmt.Sprintf("%s-%s", topic, id)
-	msgs, err := ps.ch.Consume(topic, clientID, true, false, false, false, nil)
+	// Handlers which acknowledge messages themselves are consumed
+	// with manual acknowledgement.
+	ah, manualAck := handler.(messaging.AckHandler)
+	msgs, err := ps.ch.Consume(topic, clientID, !manualAck, false, false, false, nil)
 	if err != nil {
 		return err
 	}
-	go ps.handle(msgs, handler)
+	if manualAck {
+		go ps.handleAck(msgs, ah)
+	} else {
+		go ps.handle(msgs, handler)
+	}
 	s[id] = subscription{
 		cancel: func() error {
 			if err := ps.ch.Cancel(clientID, false); err != nil {
@@ -174,3 +181,29 @@ func (ps *pubsub) handle(deliveries <-chan amqp.Delivery, h messaging.MessageHan
 		}
 	}
 }
+
+func (ps *pubsub) handleAck(deliveries <-chan amqp.Delivery, h messaging.AckHandler) {
+	for d := range deliveries {
+		var msg messaging.Message
+		if err := proto.Unmarshal(d.Body, &msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+			if err := d.Reject(false); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to reject message: %s", err))
+			}
+			continue
+		}
+		d := d
+		h.HandleAck(&msg, func(err error) {
+			if err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+				if err := d.Nack(false, false); err != nil {
+					ps.logger.Warn(fmt.Sprintf("Failed to nack message: %s", err))
+				}
+				return
+			}
+			if err := d.Ack(false); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to ack message: %s", err))
+			}
+		})
+	}
+}
@@ -57,6 +57,11 @@ func (pm *pubsubMiddleware) Subscribe(ctx context.Context, id string, topic stri
 		clientID: id,
 	}
 
+	// Preserve acknowledgement support of the wrapped handler.
+	if ah, ok := handler.(messaging.AckHandler); ok {
+		return pm.pubsub.Subscribe(ctx, id, topic, &traceAckHandler{traceHandler: h, handler: ah})
+	}
+
 	return pm.pubsub.Subscribe(ctx, id, topic, h)
 }
 
@@ -94,3 +99,25 @@ func (h *traceHandler) Handle(msg *messaging.Message) error {
 func (h *traceHandler) Cancel() error {
 	return h.handler.Cancel()
 }
+
+// traceAckHandler is used to trace the handling of the messages
+// which are acknowledged asynchronously.
+type traceAckHandler struct {
+	*traceHandler
+	handler messaging.AckHandler
+}
+
+// HandleAck instruments the message handling operation. The span ends
+// once the message is acknowledged.
+func (h *traceAckHandler) HandleAck(msg *messaging.Message, ack func(err error)) {
+	_, span := tracing.CreateSpan(h.ctx, processOp, h.clientID, h.topic, msg.Subtopic, len(msg.Payload), h.host, trace.SpanKindConsumer, h.tracer)
+	span.SetAttributes(defaultAttributes...)
+
+	h.handler.HandleAck(msg, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+		ack(err)
+	})
+}
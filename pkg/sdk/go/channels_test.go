@@ -49,8 +49,8 @@ func TestCreateChannel(t *testing.T) {
 
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
-	svc := groups.NewService(uauth, psvc, gRepo, idProvider)
+	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	svc := groups.NewService(uauth, psvc, gRepo, policiesCache, idProvider)
 
 	ts := newChannelsServer(csvc, svc, psvc)
 	defer ts.Close()
@@ -167,8 +167,8 @@ func TestCreateChannels(t *testing.T) {
 
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
-	svc := groups.NewService(uauth, psvc, gRepo, idProvider)
+	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	svc := groups.NewService(uauth, psvc, gRepo, policiesCache, idProvider)
 
 	ts := newChannelsServer(csvc, svc, psvc)
 	defer ts.Close()
@@ -249,8 +249,8 @@ func TestListChannels(t *testing.T) {
 
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
-	svc := groups.NewService(uauth, psvc, gRepo, idProvider)
+	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	svc := groups.NewService(uauth, psvc, gRepo, policiesCache, idProvider)
 
 	ts := newChannelsServer(csvc, svc, psvc)
 	defer ts.Close()
@@ -282,6 +282,7 @@ func TestListChannels(t *testing.T) {
 		name     string
 		ownerID  string
 		metadata sdk.Metadata
+		filter   string
 		err      errors.SDKError
 		response []sdk.Channel
 	}{
@@ -353,12 +354,39 @@ func TestListChannels(t *testing.T) {
 			metadata: sdk.Metadata{},
 			response: []sdk.Channel{chs[89]},
 		},
+		{
+			desc:     "get a list of channels with filter",
+			token:    token,
+			offset:   0,
+			limit:    1,
+			filter:   "metadata.floor in (1, 2) and updated_at >= '2023-01-01T00:00:00Z' order by name",
+			err:      nil,
+			response: []sdk.Channel{chs[89]},
+		},
+		{
+			desc:     "get a list of channels with invalid filter",
+			token:    token,
+			offset:   0,
+			limit:    1,
+			filter:   "updated_at >= 'yesterday'",
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidFilter), http.StatusBadRequest),
+			response: nil,
+		},
+		{
+			desc:     "get a list of channels with presence filter",
+			token:    token,
+			offset:   0,
+			limit:    1,
+			filter:   "online = false and last_seen < 'now-1h'",
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidFilter), http.StatusBadRequest),
+			response: nil,
+		},
 	}
 
 	for _, tc := range cases {
 		repoCall := pRepo.On("EvaluateGroupAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
 		repoCall1 := gRepo.On("RetrieveAll", mock.Anything, mock.Anything).Return(mfgroups.GroupsPage{Groups: convertChannels(tc.response)}, tc.err)
-		pm := sdk.PageMetadata{}
+		pm := sdk.PageMetadata{Filter: tc.filter}
 		page, err := mfsdk.Channels(pm, adminToken)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 		assert.Equal(t, len(tc.response), len(page.Channels), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, page))
@@ -381,8 +409,8 @@ func TestViewChannel(t *testing.T) {
 
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
-	svc := groups.NewService(uauth, psvc, gRepo, idProvider)
+	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	svc := groups.NewService(uauth, psvc, gRepo, policiesCache, idProvider)
 
 	ts := newChannelsServer(csvc, svc, psvc)
 	defer ts.Close()
@@ -464,8 +492,8 @@ func TestUpdateChannel(t *testing.T) {
 
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
-	svc := groups.NewService(uauth, psvc, gRepo, idProvider)
+	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	svc := groups.NewService(uauth, psvc, gRepo, policiesCache, idProvider)
 
 	ts := newChannelsServer(csvc, svc, psvc)
 	defer ts.Close()
@@ -638,8 +666,8 @@ func TestListChannelsByThing(t *testing.T) {
 
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
-	svc := groups.NewService(uauth, psvc, gRepo, idProvider)
+	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	svc := groups.NewService(uauth, psvc, gRepo, policiesCache, idProvider)
 
 	ts := newChannelsServer(csvc, svc, psvc)
 	defer ts.Close()
@@ -777,8 +805,8 @@ func TestEnableChannel(t *testing.T) {
 
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
-	svc := groups.NewService(uauth, psvc, gRepo, idProvider)
+	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	svc := groups.NewService(uauth, psvc, gRepo, policiesCache, idProvider)
 
 	ts := newChannelsServer(csvc, svc, psvc)
 	defer ts.Close()
@@ -847,8 +875,8 @@ func TestDisableChannel(t *testing.T) {
 
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
-	svc := groups.NewService(uauth, psvc, gRepo, idProvider)
+	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	svc := groups.NewService(uauth, psvc, gRepo, policiesCache, idProvider)
 
 	ts := newChannelsServer(csvc, svc, psvc)
 	defer ts.Close()
@@ -906,3 +934,44 @@ func TestDisableChannel(t *testing.T) {
 	repoCall1.Unset()
 	repoCall2.Unset()
 }
+
+func TestDeleteChannel(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	gRepo := new(gmocks.Repository)
+	pRepo := new(pmocks.Repository)
+	uauth := umocks.NewAuthService(users, map[string][]umocks.SubjectSet{adminID: {uadminPolicy}})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+
+	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
+
+	csvc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	svc := groups.NewService(uauth, psvc, gRepo, policiesCache, idProvider)
+
+	ts := newChannelsServer(csvc, svc, psvc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		ThingsURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	repoCall := pRepo.On("EvaluateGroupAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+	repoCall1 := gRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	err := mfsdk.DeleteChannel(gmocks.WrongID, adminToken)
+	assert.Equal(t, err, errors.NewSDKErrorWithStatus(errors.ErrNotFound, http.StatusNotFound), fmt.Sprintf("Delete channel with wrong id: expected %v got %v", errors.ErrNotFound, err))
+	repoCall.Unset()
+	repoCall1.Unset()
+
+	channelID := generateUUID(t)
+	repoCall = pRepo.On("EvaluateGroupAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+	repoCall1 = gRepo.On("Delete", mock.Anything, channelID).Return(nil)
+	err = mfsdk.DeleteChannel(channelID, adminToken)
+	assert.Nil(t, err, fmt.Sprintf("Delete channel with correct id: expected %v got %v", nil, err))
+	ok := repoCall.Parent.AssertCalled(t, "EvaluateGroupAccess", mock.Anything, mock.Anything)
+	assert.True(t, ok, "EvaluateGroupAccess was not called on deleting channel with correct id")
+	ok = repoCall1.Parent.AssertCalled(t, "Delete", mock.Anything, channelID)
+	assert.True(t, ok, "Delete was not called on deleting channel with correct id")
+	repoCall.Unset()
+	repoCall1.Unset()
+}
@@ -33,7 +33,7 @@ func (sdk mfSDK) CreateSubscription(topic, contact, token string) (string, error
 		return "", errors.NewSDKError(err)
 	}
 
-	url := fmt.Sprintf("%s/%s", sdk.usersURL, subscriptionEndpoint)
+	url := fmt.Sprintf("%s/%s", sdk.notifierURL, subscriptionEndpoint)
 
 	headers, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
 	if sdkerr != nil {
@@ -46,7 +46,7 @@ func (sdk mfSDK) CreateSubscription(topic, contact, token string) (string, error
 }
 
 func (sdk mfSDK) ListSubscriptions(pm PageMetadata, token string) (SubscriptionPage, errors.SDKError) {
-	url, err := sdk.withQueryParams(sdk.usersURL, subscriptionEndpoint, pm)
+	url, err := sdk.withQueryParams(sdk.notifierURL, subscriptionEndpoint, pm)
 	if err != nil {
 		return SubscriptionPage{}, errors.NewSDKError(err)
 	}
@@ -65,7 +65,7 @@ func (sdk mfSDK) ListSubscriptions(pm PageMetadata, token string) (SubscriptionP
 }
 
 func (sdk mfSDK) ViewSubscription(id, token string) (Subscription, errors.SDKError) {
-	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, subscriptionEndpoint, id)
+	url := fmt.Sprintf("%s/%s/%s", sdk.notifierURL, subscriptionEndpoint, id)
 
 	_, body, err := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
 	if err != nil {
@@ -81,7 +81,7 @@ func (sdk mfSDK) ViewSubscription(id, token string) (Subscription, errors.SDKErr
 }
 
 func (sdk mfSDK) DeleteSubscription(id, token string) errors.SDKError {
-	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, subscriptionEndpoint, id)
+	url := fmt.Sprintf("%s/%s/%s", sdk.notifierURL, subscriptionEndpoint, id)
 
 	_, _, err := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
 
@@ -41,7 +41,7 @@ func newSubscriptionService() notifiers.Service {
 	idp := uuid.NewMock()
 	from := "exampleFrom"
 
-	return notifiers.New(auth, repo, idp, notifier, from)
+	return notifiers.New(auth, repo, idp, notifier, notifiers.NewLimiter(), from)
 }
 
 func newSubscriptionServer(svc notifiers.Service) *httptest.Server {
@@ -57,7 +57,7 @@ func TestCreateSubscription(t *testing.T) {
 	defer ts.Close()
 
 	sdkConf := sdk.Config{
-		UsersURL:        ts.URL,
+		NotifierURL:     ts.URL,
 		MsgContentType:  contentType,
 		TLSVerification: false,
 	}
@@ -113,7 +113,7 @@ func TestViewSubscription(t *testing.T) {
 	ts := newSubscriptionServer(svc)
 	defer ts.Close()
 	sdkConf := sdk.Config{
-		UsersURL:        ts.URL,
+		NotifierURL:     ts.URL,
 		MsgContentType:  contentType,
 		TLSVerification: false,
 	}
@@ -167,7 +167,7 @@ func TestListSubscription(t *testing.T) {
 	ts := newSubscriptionServer(svc)
 	defer ts.Close()
 	sdkConf := sdk.Config{
-		UsersURL:        ts.URL,
+		NotifierURL:     ts.URL,
 		MsgContentType:  contentType,
 		TLSVerification: false,
 	}
@@ -225,7 +225,7 @@ func TestDeleteSubscription(t *testing.T) {
 	ts := newSubscriptionServer(svc)
 	defer ts.Close()
 	sdkConf := sdk.Config{
-		UsersURL:        ts.URL,
+		NotifierURL:     ts.URL,
 		MsgContentType:  contentType,
 		TLSVerification: false,
 	}
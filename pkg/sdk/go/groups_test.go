@@ -21,6 +21,13 @@ import (
 	"github.com/mainflux/mainflux/users/groups/api"
 	gmocks "github.com/mainflux/mainflux/users/groups/mocks"
 	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwmocks "github.com/mainflux/mainflux/users/passwords/mocks"
 	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -38,9 +45,9 @@ func TestCreateGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(gmocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 	ts := newGroupsServer(svc)
 	defer ts.Close()
@@ -152,9 +159,9 @@ func TestListGroups(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(gmocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 	ts := newGroupsServer(svc)
 	defer ts.Close()
@@ -279,9 +286,9 @@ func TestListParentGroups(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(gmocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 	ts := newGroupsServer(svc)
 	defer ts.Close()
@@ -409,9 +416,9 @@ func TestListChildrenGroups(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(gmocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 	ts := newGroupsServer(svc)
 	defer ts.Close()
@@ -540,9 +547,9 @@ func TestViewGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(gmocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 	ts := newGroupsServer(svc)
 	defer ts.Close()
@@ -618,9 +625,9 @@ func TestUpdateGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(gmocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 	ts := newGroupsServer(svc)
 	defer ts.Close()
@@ -787,9 +794,9 @@ func TestListMemberships(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(gmocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 	ts := newGroupsServer(svc)
 	defer ts.Close()
@@ -921,9 +928,9 @@ func TestEnableGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(gmocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 	ts := newGroupsServer(svc)
 	defer ts.Close()
@@ -986,9 +993,9 @@ func TestDisableGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(gmocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 	ts := newGroupsServer(svc)
 	defer ts.Close()
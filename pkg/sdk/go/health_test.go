@@ -18,6 +18,13 @@ import (
 	usersclients "github.com/mainflux/mainflux/users/clients"
 	cmocks "github.com/mainflux/mainflux/users/clients/mocks"
 	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwmocks "github.com/mainflux/mainflux/users/passwords/mocks"
 	userspmocks "github.com/mainflux/mainflux/users/policies/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,17 +37,17 @@ func TestHealth(t *testing.T) {
 	uauth := cmocks.NewAuthService(users, map[string][]cmocks.SubjectSet{adminID: {uadminPolicy}})
 	thingCache := thingsclientsmock.NewCache()
 	policiesCache := thingspmocks.NewCache()
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
 	thingspRepo := new(thingspmocks.Repository)
 	psvc := policies.NewService(uauth, thingspRepo, policiesCache, idProvider)
 
-	thsvc := thingsclients.NewService(uauth, psvc, thingcRepo, gRepo, thingCache, idProvider)
+	thsvc := thingsclients.NewService(uauth, psvc, thingcRepo, gRepo, thingCache, policiesCache, idProvider)
 	ths := newThingsServer(thsvc, psvc)
 	defer ths.Close()
 
 	userspRepo := new(userspmocks.Repository)
-	usSvc := usersclients.NewService(usercRepo, userspRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	usSvc := usersclients.NewService(usercRepo, userspRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), usersclients.RegistrationConfig{})
 	usclsv := newClientServer(usSvc)
 	defer usclsv.Close()
 
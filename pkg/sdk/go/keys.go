@@ -0,0 +1,100 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const keysEndpoint = "keys"
+
+// KeyScope restricts the actions an API key may perform and the objects it
+// may perform them on. Empty lists leave the key unrestricted.
+type KeyScope struct {
+	Actions []string `json:"actions,omitempty"`
+	Objects []string `json:"objects,omitempty"`
+}
+
+// Key represents a user API key. Value is only returned when the key is
+// issued. Duration is only used when issuing a key; a key without duration
+// never expires.
+type Key struct {
+	ID        string    `json:"id,omitempty"`
+	OwnerID   string    `json:"owner_id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Value     string    `json:"value,omitempty"`
+	Scope     KeyScope  `json:"scope,omitempty"`
+	Duration  string    `json:"duration,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func (sdk mfSDK) IssueKey(key Key, token string) (Key, errors.SDKError) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return Key{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.usersURL, keysEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Key{}, sdkerr
+	}
+
+	var k Key
+	if err := json.Unmarshal(body, &k); err != nil {
+		return Key{}, errors.NewSDKError(err)
+	}
+
+	return k, nil
+}
+
+func (sdk mfSDK) ViewKey(id, token string) (Key, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, keysEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Key{}, sdkerr
+	}
+
+	var k Key
+	if err := json.Unmarshal(body, &k); err != nil {
+		return Key{}, errors.NewSDKError(err)
+	}
+
+	return k, nil
+}
+
+func (sdk mfSDK) ListKeys(pm PageMetadata, token string) (KeysPage, errors.SDKError) {
+	url, err := sdk.withQueryParams(sdk.usersURL, keysEndpoint, pm)
+	if err != nil {
+		return KeysPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return KeysPage{}, sdkerr
+	}
+
+	var kp KeysPage
+	if err := json.Unmarshal(body, &kp); err != nil {
+		return KeysPage{}, errors.NewSDKError(err)
+	}
+
+	return kp, nil
+}
+
+func (sdk mfSDK) RevokeKey(id, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, keysEndpoint, id)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
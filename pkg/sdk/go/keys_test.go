@@ -0,0 +1,178 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/users/clients/mocks"
+	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/keys"
+	kapi "github.com/mainflux/mainflux/users/keys/api/http"
+	kmocks "github.com/mainflux/mainflux/users/keys/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeysServer(svc keys.Service) *httptest.Server {
+	logger := mflog.NewMock()
+	mux := bone.New()
+	kapi.MakeHandler(svc, mux, logger)
+
+	return httptest.NewServer(mux)
+}
+
+func newKeysSDK(t *testing.T) (sdk.SDK, *kmocks.Repository, string, func()) {
+	kRepo := new(kmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	svc := keys.NewService(kRepo, new(mocks.Repository), tokenizer, idProvider)
+	ts := newKeysServer(svc)
+
+	tkn, err := tokenizer.Issue(context.Background(), jwt.Claims{ClientID: generateUUID(t), Email: "user@example.com"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	mfsdk := sdk.NewSDK(sdk.Config{UsersURL: ts.URL})
+
+	return mfsdk, kRepo, tkn.AccessToken, ts.Close
+}
+
+func TestIssueKey(t *testing.T) {
+	mfsdk, kRepo, tkn, closeFn := newKeysSDK(t)
+	defer closeFn()
+
+	cases := []struct {
+		desc  string
+		key   sdk.Key
+		token string
+		err   errors.SDKError
+	}{
+		{
+			desc:  "issue key",
+			key:   sdk.Key{Name: "ci", Duration: "24h", Scope: sdk.KeyScope{Actions: []string{"m_read"}}},
+			token: tkn,
+			err:   nil,
+		},
+		{
+			desc:  "issue key without name",
+			key:   sdk.Key{Duration: "24h"},
+			token: tkn,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrNameSize), http.StatusBadRequest),
+		},
+		{
+			desc:  "issue key with invalid duration",
+			key:   sdk.Key{Name: "ci", Duration: "-1h"},
+			token: tkn,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, errors.ErrMalformedEntity), http.StatusBadRequest),
+		},
+		{
+			desc:  "issue key with invalid token",
+			key:   sdk.Key{Name: "ci"},
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, sdk.ErrInvalidJWT), http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := kRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		key, err := mfsdk.IssueKey(tc.key, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.True(t, strings.HasPrefix(key.Value, keys.Prefix), fmt.Sprintf("%s: expected key value, got %s", tc.desc, key.Value))
+			assert.Equal(t, tc.key.Scope, key.Scope, fmt.Sprintf("%s: expected scope %v, got %v", tc.desc, tc.key.Scope, key.Scope))
+			assert.False(t, key.ExpiresAt.IsZero(), fmt.Sprintf("%s: expected expiry to be set", tc.desc))
+		}
+		repoCall.Unset()
+	}
+}
+
+func TestListKeys(t *testing.T) {
+	mfsdk, kRepo, tkn, closeFn := newKeysSDK(t)
+	defer closeFn()
+
+	var ks []keys.Key
+	for i := 0; i < 3; i++ {
+		ks = append(ks, keys.Key{ID: generateUUID(t), Name: fmt.Sprintf("key-%d", i)})
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		page  keys.KeysPage
+		size  int
+		err   errors.SDKError
+	}{
+		{
+			desc:  "list keys",
+			token: tkn,
+			page:  keys.KeysPage{Page: keys.Page{Total: 3, Limit: 10}, Keys: ks},
+			size:  3,
+			err:   nil,
+		},
+		{
+			desc:  "list keys with invalid token",
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, sdk.ErrInvalidJWT), http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := kRepo.On("RetrieveAll", mock.Anything, mock.Anything).Return(tc.page, nil)
+		page, err := mfsdk.ListKeys(sdk.PageMetadata{Limit: 10}, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Keys), fmt.Sprintf("%s: expected %d keys, got %d", tc.desc, tc.size, len(page.Keys)))
+		repoCall.Unset()
+	}
+}
+
+func TestRevokeKey(t *testing.T) {
+	mfsdk, kRepo, tkn, closeFn := newKeysSDK(t)
+	defer closeFn()
+
+	cases := []struct {
+		desc    string
+		id      string
+		token   string
+		repoErr error
+		err     errors.SDKError
+	}{
+		{
+			desc:  "revoke key",
+			id:    generateUUID(t),
+			token: tkn,
+			err:   nil,
+		},
+		{
+			desc:    "revoke non-existing key",
+			id:      generateUUID(t),
+			token:   tkn,
+			repoErr: errors.ErrNotFound,
+			err:     errors.NewSDKErrorWithStatus(errors.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:  "revoke key with invalid token",
+			id:    generateUUID(t),
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, sdk.ErrInvalidJWT), http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := kRepo.On("Remove", mock.Anything, mock.Anything, tc.id).Return(tc.repoErr)
+		err := mfsdk.RevokeKey(tc.id, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		repoCall.Unset()
+	}
+}
@@ -6,7 +6,10 @@ package sdk
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/mainflux/mainflux/internal/apiutil"
@@ -30,15 +33,28 @@ func (sdk mfSDK) SendMessage(chanName, msg, key string) errors.SDKError {
 	return err
 }
 
-func (sdk mfSDK) ReadMessages(chanName, token string) (MessagesPage, errors.SDKError) {
+func (sdk mfSDK) ReadMessages(chanName string, pm MessagePageMetadata, token string) (MessagesPage, errors.SDKError) {
 	chanNameParts := strings.SplitN(chanName, ".", channelParts)
 	chanID := chanNameParts[0]
-	subtopicPart := ""
+
+	q := url.Values{}
 	if len(chanNameParts) == channelParts {
-		subtopicPart = fmt.Sprintf("?subtopic=%s", chanNameParts[1])
+		q.Add("subtopic", chanNameParts[1])
+	}
+	if pm.Offset != 0 {
+		q.Add("offset", strconv.FormatUint(pm.Offset, 10))
+	}
+	if pm.Limit != 0 {
+		q.Add("limit", strconv.FormatUint(pm.Limit, 10))
+	}
+	if pm.Cursor != "" {
+		q.Add("cursor", pm.Cursor)
+	}
+	if pm.SkipTotal {
+		q.Add("skip_total", strconv.FormatBool(pm.SkipTotal))
 	}
 
-	url := fmt.Sprintf("%s/channels/%s/messages%s", sdk.readerURL, chanID, subtopicPart)
+	url := fmt.Sprintf("%s/channels/%s/messages?%s", sdk.readerURL, chanID, q.Encode())
 
 	header := make(map[string]string)
 	header["Content-Type"] = string(sdk.msgContentType)
@@ -56,6 +72,49 @@ func (sdk mfSDK) ReadMessages(chanName, token string) (MessagesPage, errors.SDKE
 	return mp, nil
 }
 
+func (sdk mfSDK) ExportMessages(chanName string, ct ContentType, w io.Writer, token string) errors.SDKError {
+	if ct != CTCSV && ct != CTNDJSON {
+		return errors.NewSDKError(apiutil.ErrUnsupportedContentType)
+	}
+
+	chanNameParts := strings.SplitN(chanName, ".", channelParts)
+	chanID := chanNameParts[0]
+	subtopicPart := ""
+	if len(chanNameParts) == channelParts {
+		subtopicPart = fmt.Sprintf("?subtopic=%s", chanNameParts[1])
+	}
+
+	url := fmt.Sprintf("%s/channels/%s/export%s", sdk.readerURL, chanID, subtopicPart)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+	req.Header.Set("Accept", string(ct))
+	if token != "" {
+		if !strings.Contains(token, ThingPrefix) {
+			token = BearerPrefix + token
+		}
+		req.Header.Set("Authorization", token)
+	}
+
+	resp, err := sdk.client.Do(req)
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+	defer resp.Body.Close()
+
+	if sdkerr := errors.CheckError(resp, http.StatusOK); sdkerr != nil {
+		return sdkerr
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.NewSDKError(err)
+	}
+
+	return nil
+}
+
 func (sdk *mfSDK) SetContentType(ct ContentType) errors.SDKError {
 	if ct != CTJSON && ct != CTJSONSenML && ct != CTBinary {
 		return errors.NewSDKError(apiutil.ErrUnsupportedContentType)
@@ -4,9 +4,12 @@
 package sdk_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	adapter "github.com/mainflux/mainflux/http"
@@ -15,7 +18,13 @@ import (
 	"github.com/mainflux/mainflux/internal/apiutil"
 	"github.com/mainflux/mainflux/pkg/errors"
 	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+	rapi "github.com/mainflux/mainflux/readers/api"
+	rmocks "github.com/mainflux/mainflux/readers/mocks"
 	"github.com/mainflux/mainflux/things/policies"
+	umocks "github.com/mainflux/mainflux/users/clients/mocks"
+	upolicies "github.com/mainflux/mainflux/users/policies"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,6 +42,12 @@ func newMessageServer(svc adapter.Service) *httptest.Server {
 	return httptest.NewServer(mux)
 }
 
+func newReaderServer(repo readers.MessageRepository, tc policies.AuthServiceClient, ac upolicies.AuthServiceClient) *httptest.Server {
+	mux := rapi.MakeHandler(repo, rmocks.NewChannelGroups(map[string][]string{}), tc, ac, "reader", instanceID)
+
+	return httptest.NewServer(mux)
+}
+
 func TestSendMessage(t *testing.T) {
 	chanID := "1"
 	atoken := "auth_token"
@@ -142,3 +157,188 @@ func TestSetContentType(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 	}
 }
+
+func TestExportMessages(t *testing.T) {
+	chanID := generateUUID(t)
+	pubID := generateUUID(t)
+	value := 21.5
+
+	var msgs []readers.Message
+	for i := 0; i < 15; i++ {
+		msgs = append(msgs, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Name:      "temperature",
+			Time:      float64(1000 - i),
+			Value:     &value,
+		})
+	}
+
+	repo := rmocks.NewMessageRepository(chanID, msgs)
+	thingsClient := rmocks.NewThingsService(map[string]string{})
+	authClient := umocks.NewAuthService(map[string]string{token: Identity}, map[string][]umocks.SubjectSet{})
+	ts := newReaderServer(repo, thingsClient, authClient)
+	defer ts.Close()
+
+	sdkConf := sdk.Config{
+		ReaderURL:       ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+	mfsdk := sdk.NewSDK(sdkConf)
+
+	cases := []struct {
+		desc  string
+		ct    sdk.ContentType
+		token string
+		lines int
+		err   errors.SDKError
+	}{
+		{
+			desc:  "export messages as NDJSON",
+			ct:    sdk.CTNDJSON,
+			token: token,
+			lines: len(msgs),
+			err:   nil,
+		},
+		{
+			desc:  "export messages as CSV",
+			ct:    sdk.CTCSV,
+			token: token,
+			lines: len(msgs) + 1,
+			err:   nil,
+		},
+		{
+			desc:  "export messages with unsupported content type",
+			ct:    sdk.CTJSON,
+			token: token,
+			err:   errors.NewSDKError(apiutil.ErrUnsupportedContentType),
+		},
+		{
+			desc:  "export messages with invalid token",
+			ct:    sdk.CTNDJSON,
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, errors.ErrAuthorization), http.StatusUnauthorized),
+		},
+	}
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		err := mfsdk.ExportMessages(chanID, tc.ct, &buf, tc.token)
+		switch tc.err {
+		case nil:
+			assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			assert.Equal(t, tc.lines, len(lines), fmt.Sprintf("%s: expected %d lines got %d", tc.desc, tc.lines, len(lines)))
+			if tc.ct == sdk.CTNDJSON {
+				var msg senml.Message
+				err := json.Unmarshal([]byte(lines[0]), &msg)
+				assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+				assert.Equal(t, chanID, msg.Channel, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, chanID, msg.Channel))
+			}
+		default:
+			assert.Equal(t, tc.err.Error(), err.Error(), fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		}
+	}
+}
+
+func TestReadMessages(t *testing.T) {
+	chanID := generateUUID(t)
+	pubID := generateUUID(t)
+	value := 21.5
+
+	var msgs []readers.Message
+	for i := 0; i < 25; i++ {
+		msgs = append(msgs, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Name:      "temperature",
+			Time:      float64(1000 - i),
+			Value:     &value,
+		})
+	}
+
+	repo := rmocks.NewMessageRepository(chanID, msgs)
+	thingsClient := rmocks.NewThingsService(map[string]string{})
+	authClient := umocks.NewAuthService(map[string]string{token: Identity}, map[string][]umocks.SubjectSet{})
+	ts := newReaderServer(repo, thingsClient, authClient)
+	defer ts.Close()
+
+	sdkConf := sdk.Config{
+		ReaderURL:       ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+	mfsdk := sdk.NewSDK(sdkConf)
+
+	cases := []struct {
+		desc  string
+		pm    sdk.MessagePageMetadata
+		token string
+		size  int
+		total uint64
+		err   errors.SDKError
+	}{
+		{
+			desc:  "read messages",
+			pm:    sdk.MessagePageMetadata{Limit: 10},
+			token: token,
+			size:  10,
+			total: uint64(len(msgs)),
+			err:   nil,
+		},
+		{
+			desc:  "read messages skipping total",
+			pm:    sdk.MessagePageMetadata{Limit: 10, SkipTotal: true},
+			token: token,
+			size:  10,
+			total: 0,
+			err:   nil,
+		},
+		{
+			desc:  "read messages with invalid cursor",
+			pm:    sdk.MessagePageMetadata{Limit: 10, Cursor: "invalid"},
+			token: token,
+			err:   errors.NewSDKErrorWithStatus(unexpectedJSONEnd, http.StatusBadRequest),
+		},
+		{
+			desc:  "read messages with cursor and offset",
+			pm:    sdk.MessagePageMetadata{Offset: 1, Limit: 10, Cursor: "cursor"},
+			token: token,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidQueryParams), http.StatusBadRequest),
+		},
+		{
+			desc:  "read messages with invalid token",
+			pm:    sdk.MessagePageMetadata{Limit: 10},
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, errors.ErrAuthorization), http.StatusUnauthorized),
+		},
+	}
+	for _, tc := range cases {
+		page, err := mfsdk.ReadMessages(chanID, tc.pm, tc.token)
+		switch tc.err {
+		case nil:
+			assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.size, len(page.Messages), fmt.Sprintf("%s: expected %d messages got %d", tc.desc, tc.size, len(page.Messages)))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+		default:
+			assert.Equal(t, tc.err.Error(), err.Error(), fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		}
+	}
+
+	// Iterate over all the pages using cursors.
+	pm := sdk.MessagePageMetadata{Limit: 10, SkipTotal: true}
+	read := 0
+	for {
+		page, err := mfsdk.ReadMessages(chanID, pm, token)
+		assert.Nil(t, err, fmt.Sprintf("read messages by cursor: got unexpected error: %s", err))
+		if err != nil {
+			break
+		}
+		read += len(page.Messages)
+		if page.NextCursor == "" {
+			break
+		}
+		pm.Cursor = page.NextCursor
+	}
+	assert.Equal(t, len(msgs), read, fmt.Sprintf("read messages by cursor: expected %d messages got %d", len(msgs), read))
+}
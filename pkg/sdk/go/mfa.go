@@ -0,0 +1,100 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const mfaEndpoint = "mfa"
+
+// MFAProvisioning contains the data needed to set up the TOTP generator.
+// The URI is meant to be rendered as a QR code.
+type MFAProvisioning struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type mfaCodeReq struct {
+	Code string `json:"code"`
+}
+
+func (sdk mfSDK) EnrollMFA(token string) (MFAProvisioning, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/enroll", sdk.usersURL, usersEndpoint, mfaEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, []byte{}, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return MFAProvisioning{}, sdkerr
+	}
+
+	var p MFAProvisioning
+	if err := json.Unmarshal(body, &p); err != nil {
+		return MFAProvisioning{}, errors.NewSDKError(err)
+	}
+
+	return p, nil
+}
+
+func (sdk mfSDK) RequestMFAEnrollment(challenge string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s/enroll/request", sdk.usersURL, usersEndpoint, mfaEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, challenge, []byte{}, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mfSDK) ConfirmMFA(code, token string) errors.SDKError {
+	return sdk.mfaCode("confirm", code, token)
+}
+
+func (sdk mfSDK) VerifyMFA(code, challenge string) (Token, errors.SDKError) {
+	data, err := json.Marshal(mfaCodeReq{Code: code})
+	if err != nil {
+		return Token{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/verify", sdk.usersURL, usersEndpoint, mfaEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, challenge, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Token{}, sdkerr
+	}
+
+	var t Token
+	if err := json.Unmarshal(body, &t); err != nil {
+		return Token{}, errors.NewSDKError(err)
+	}
+
+	return t, nil
+}
+
+func (sdk mfSDK) DisableMFA(code, token string) errors.SDKError {
+	return sdk.mfaCode("unenroll", code, token)
+}
+
+func (sdk mfSDK) ResetMFA(id, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, usersEndpoint, id, mfaEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mfSDK) mfaCode(action, code, token string) errors.SDKError {
+	data, err := json.Marshal(mfaCodeReq{Code: code})
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, usersEndpoint, mfaEndpoint, action)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusNoContent)
+
+	return sdkerr
+}
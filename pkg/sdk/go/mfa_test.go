@@ -0,0 +1,311 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	mflog "github.com/mainflux/mainflux/logger"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/users/clients"
+	capi "github.com/mainflux/mainflux/users/clients/api"
+	cmocks "github.com/mainflux/mainflux/users/clients/mocks"
+	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mapi "github.com/mainflux/mainflux/users/mfa/api/http"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwmocks "github.com/mainflux/mainflux/users/passwords/mocks"
+	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMFAServer(csvc clients.Service, msvc mfa.Service) *httptest.Server {
+	logger := mflog.NewMock()
+	mux := bone.New()
+	capi.MakeHandler(csvc, mux, logger, instanceID, nil)
+	mapi.MakeHandler(msvc, mux, logger)
+
+	return httptest.NewServer(mux)
+}
+
+type mfaTestEnv struct {
+	sdk       sdk.SDK
+	cRepo     *cmocks.Repository
+	pRepo     *pmocks.Repository
+	tokenizer jwt.Repository
+	user      mfclients.Client
+	token     string
+}
+
+func newMFASDK(t *testing.T) (mfaTestEnv, func()) {
+	cRepo := new(cmocks.Repository)
+	pRepo := new(pmocks.Repository)
+	mRepo := mmocks.NewRepository()
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mRepo, false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	msvc := mfa.NewService(mRepo, cRepo, pRepo, tokenizer, lockout.NewScopedLimiter(lmocks.NewRepository(), lockout.Config{}, "mfa"), mmocks.NewEmailer(), mfa.Config{Issuer: "Mainflux"})
+	ts := newMFAServer(csvc, msvc)
+
+	user := mfclients.Client{
+		ID:          generateUUID(t),
+		Credentials: mfclients.Credentials{Identity: "mfa@example.com", Secret: "secret"},
+		Status:      mfclients.EnabledStatus,
+	}
+	tkn, err := tokenizer.Issue(context.Background(), jwt.Claims{ClientID: user.ID, Email: user.Credentials.Identity})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	env := mfaTestEnv{
+		sdk:       sdk.NewSDK(sdk.Config{UsersURL: ts.URL}),
+		cRepo:     cRepo,
+		pRepo:     pRepo,
+		tokenizer: tokenizer,
+		user:      user,
+		token:     tkn.AccessToken,
+	}
+
+	return env, ts.Close
+}
+
+func mfaCode(t *testing.T, secret string, offset time.Duration) string {
+	code, err := mfa.Code(secret, time.Now().Add(offset))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return code
+}
+
+func TestEnrollMFA(t *testing.T) {
+	env, closeFn := newMFASDK(t)
+	defer closeFn()
+
+	cases := []struct {
+		desc  string
+		token string
+		err   errors.SDKError
+	}{
+		{
+			desc:  "enroll MFA",
+			token: env.token,
+			err:   nil,
+		},
+		{
+			desc:  "enroll MFA with invalid token",
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, sdk.ErrInvalidJWT), http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		p, err := env.sdk.EnrollMFA(tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, p.Secret, fmt.Sprintf("%s: expected secret, got empty", tc.desc))
+			assert.NotEmpty(t, p.URI, fmt.Sprintf("%s: expected URI, got empty", tc.desc))
+			assert.Len(t, p.RecoveryCodes, 10, fmt.Sprintf("%s: expected 10 recovery codes, got %d", tc.desc, len(p.RecoveryCodes)))
+		}
+	}
+}
+
+func TestConfirmMFA(t *testing.T) {
+	env, closeFn := newMFASDK(t)
+	defer closeFn()
+
+	p, err := env.sdk.EnrollMFA(env.token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		code  string
+		token string
+		err   errors.SDKError
+	}{
+		{
+			desc:  "confirm MFA with invalid code",
+			code:  "000000",
+			token: env.token,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, mfa.ErrInvalidCode), http.StatusUnauthorized),
+		},
+		{
+			desc:  "confirm MFA without code",
+			code:  "",
+			token: env.token,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, errors.ErrMalformedEntity), http.StatusBadRequest),
+		},
+		{
+			desc:  "confirm MFA",
+			code:  mfaCode(t, p.Secret, 0),
+			token: env.token,
+			err:   nil,
+		},
+		{
+			desc:  "confirm confirmed MFA",
+			code:  mfaCode(t, p.Secret, 30*time.Second),
+			token: env.token,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrConflict, mfa.ErrEnrolled), http.StatusConflict),
+		},
+	}
+
+	for _, tc := range cases {
+		err := env.sdk.ConfirmMFA(tc.code, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestVerifyMFA(t *testing.T) {
+	env, closeFn := newMFASDK(t)
+	defer closeFn()
+
+	p, err := env.sdk.EnrollMFA(env.token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = env.sdk.ConfirmMFA(mfaCode(t, p.Secret, 0), env.token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	dbUser := env.user
+	dbUser.Credentials.Secret, _ = phasher.Hash(env.user.Credentials.Secret)
+	repoCall := env.cRepo.On("RetrieveByIdentity", mock.Anything, env.user.Credentials.Identity).Return(dbUser, nil)
+	repoCall1 := env.cRepo.On("RetrieveByID", mock.Anything, env.user.ID).Return(dbUser, nil)
+	defer repoCall.Unset()
+	defer repoCall1.Unset()
+
+	user := sdk.User{Credentials: sdk.Credentials{Identity: env.user.Credentials.Identity, Secret: env.user.Credentials.Secret}}
+	challenge, err := env.sdk.CreateToken(user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, jwt.MFAType, challenge.AccessType, fmt.Sprintf("expected access type %s, got %s", jwt.MFAType, challenge.AccessType))
+	assert.Empty(t, challenge.RefreshToken, "expected challenge without refresh token")
+
+	cases := []struct {
+		desc      string
+		code      string
+		challenge string
+		err       errors.SDKError
+	}{
+		{
+			desc:      "verify MFA with invalid code",
+			code:      "000000",
+			challenge: challenge.AccessToken,
+			err:       errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, mfa.ErrInvalidCode), http.StatusUnauthorized),
+		},
+		{
+			desc:      "verify MFA with access token",
+			code:      p.RecoveryCodes[0],
+			challenge: env.token,
+			err:       errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:      "verify MFA",
+			code:      mfaCode(t, p.Secret, 30*time.Second),
+			challenge: challenge.AccessToken,
+			err:       nil,
+		},
+		{
+			desc:      "verify MFA with used challenge",
+			code:      p.RecoveryCodes[0],
+			challenge: challenge.AccessToken,
+			err:       errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, jwt.ErrRevoked), http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		token, err := env.sdk.VerifyMFA(tc.code, tc.challenge)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, token.AccessToken, fmt.Sprintf("%s: expected access token, got empty", tc.desc))
+			assert.NotEmpty(t, token.RefreshToken, fmt.Sprintf("%s: expected refresh token, got empty", tc.desc))
+		}
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	env, closeFn := newMFASDK(t)
+	defer closeFn()
+
+	p, err := env.sdk.EnrollMFA(env.token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = env.sdk.ConfirmMFA(mfaCode(t, p.Secret, 0), env.token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		code  string
+		token string
+		err   errors.SDKError
+	}{
+		{
+			desc:  "disable MFA with invalid code",
+			code:  "000000",
+			token: env.token,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, mfa.ErrInvalidCode), http.StatusUnauthorized),
+		},
+		{
+			desc:  "disable MFA",
+			code:  p.RecoveryCodes[0],
+			token: env.token,
+			err:   nil,
+		},
+		{
+			desc:  "disable disabled MFA",
+			code:  p.RecoveryCodes[1],
+			token: env.token,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrNotFound, mfa.ErrNotEnrolled), http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range cases {
+		err := env.sdk.DisableMFA(tc.code, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestResetMFA(t *testing.T) {
+	env, closeFn := newMFASDK(t)
+	defer closeFn()
+
+	_, err := env.sdk.EnrollMFA(env.token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		adminErr error
+		err      errors.SDKError
+	}{
+		{
+			desc:     "reset MFA by non-admin",
+			id:       env.user.ID,
+			adminErr: errors.ErrAuthorization,
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthorization, errors.ErrAuthorization), http.StatusForbidden),
+		},
+		{
+			desc: "reset MFA",
+			id:   env.user.ID,
+			err:  nil,
+		},
+		{
+			desc: "reset MFA without enrollment",
+			id:   env.user.ID,
+			err:  errors.NewSDKErrorWithStatus(errors.ErrNotFound, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := env.pRepo.On("CheckAdmin", mock.Anything, mock.Anything).Return(tc.adminErr)
+		err := env.sdk.ResetMFA(tc.id, env.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		repoCall.Unset()
+	}
+}
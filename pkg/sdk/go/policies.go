@@ -26,6 +26,23 @@ type Policy struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// PolicyResult represents the outcome of adding a single policy of a bulk
+// request. Rows are numbered from 1 in the order of the request.
+type PolicyResult struct {
+	Row     int      `json:"row"`
+	Subject string   `json:"subject"`
+	Object  string   `json:"object"`
+	Actions []string `json:"actions,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// PoliciesReport contains the outcome of every policy of a bulk request.
+type PoliciesReport struct {
+	Total   int            `json:"total"`
+	Failed  int            `json:"failed"`
+	Results []PolicyResult `json:"results"`
+}
+
 type AccessRequest struct {
 	Subject    string `json:"subject,omitempty"`
 	Object     string `json:"object,omitempty"`
@@ -211,6 +228,48 @@ func (sdk mfSDK) Disconnect(connIDs ConnectionIDs, token string) errors.SDKError
 	return sdkerr
 }
 
+func (sdk mfSDK) CreateThingPolicies(policies []Policy, external bool, token string) (PoliciesReport, errors.SDKError) {
+	type policyReq struct {
+		Subject string   `json:"subject"`
+		Object  string   `json:"object"`
+		Actions []string `json:"actions,omitempty"`
+	}
+	req := struct {
+		Policies []policyReq `json:"policies"`
+		External bool        `json:"external,omitempty"`
+	}{External: external}
+	for _, p := range policies {
+		req.Policies = append(req.Policies, policyReq{Subject: p.Subject, Object: p.Object, Actions: p.Actions})
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return PoliciesReport{}, errors.NewSDKError(err)
+	}
+
+	return sdk.createThingPolicies(data, CTJSON, token)
+}
+
+func (sdk mfSDK) CreateThingPoliciesCSV(csv []byte, token string) (PoliciesReport, errors.SDKError) {
+	return sdk.createThingPolicies(csv, CTCSV, token)
+}
+
+func (sdk mfSDK) createThingPolicies(data []byte, ct ContentType, token string) (PoliciesReport, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/bulk", sdk.thingsURL, policyEndpoint)
+	headers := map[string]string{"Content-Type": string(ct)}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, headers, http.StatusOK)
+	if sdkerr != nil {
+		return PoliciesReport{}, sdkerr
+	}
+
+	var report PoliciesReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return PoliciesReport{}, errors.NewSDKError(err)
+	}
+
+	return report, nil
+}
+
 func (sdk mfSDK) ConnectThing(thingID, channelID, token string) errors.SDKError {
 	policy := Policy{
 		Subject: thingID,
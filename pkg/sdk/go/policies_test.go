@@ -21,6 +21,13 @@ import (
 	uclients "github.com/mainflux/mainflux/users/clients"
 	umocks "github.com/mainflux/mainflux/users/clients/mocks"
 	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwmocks "github.com/mainflux/mainflux/users/passwords/mocks"
 	upolicies "github.com/mainflux/mainflux/users/policies"
 	uapi "github.com/mainflux/mainflux/users/policies/api/http"
 	upmocks "github.com/mainflux/mainflux/users/policies/mocks"
@@ -49,10 +56,11 @@ func newThingsPolicyServer(svc tclients.Service, psvc tpolicies.Service) *httpte
 func TestCreatePolicyUser(t *testing.T) {
 	cRepo := new(umocks.Repository)
 	pRepo := new(upmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(upmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
-	svc := upolicies.NewService(pRepo, tokenizer, idProvider)
+	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), uclients.RegistrationConfig{})
+	svc := upolicies.NewService(pRepo, rRepo, tokenizer, idProvider)
 	ts := newUsersPolicyServer(svc)
 	defer ts.Close()
 	conf := sdk.Config{
@@ -174,10 +182,11 @@ func TestCreatePolicyUser(t *testing.T) {
 func TestAuthorizeUser(t *testing.T) {
 	cRepo := new(umocks.Repository)
 	pRepo := new(upmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(upmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
-	svc := upolicies.NewService(pRepo, tokenizer, idProvider)
+	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), uclients.RegistrationConfig{})
+	svc := upolicies.NewService(pRepo, rRepo, tokenizer, idProvider)
 	ts := newUsersPolicyServer(svc)
 	defer ts.Close()
 	conf := sdk.Config{
@@ -279,10 +288,11 @@ func TestAuthorizeUser(t *testing.T) {
 func TestAssign(t *testing.T) {
 	cRepo := new(umocks.Repository)
 	pRepo := new(upmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(upmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
-	svc := upolicies.NewService(pRepo, tokenizer, idProvider)
+	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), uclients.RegistrationConfig{})
+	svc := upolicies.NewService(pRepo, rRepo, tokenizer, idProvider)
 	ts := newUsersPolicyServer(svc)
 	defer ts.Close()
 	conf := sdk.Config{
@@ -404,10 +414,11 @@ func TestAssign(t *testing.T) {
 func TestUpdatePolicy(t *testing.T) {
 	cRepo := new(umocks.Repository)
 	pRepo := new(upmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(upmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
-	svc := upolicies.NewService(pRepo, tokenizer, idProvider)
+	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), uclients.RegistrationConfig{})
+	svc := upolicies.NewService(pRepo, rRepo, tokenizer, idProvider)
 	ts := newUsersPolicyServer(svc)
 	defer ts.Close()
 
@@ -474,7 +485,7 @@ func TestUpdateThingsPolicy(t *testing.T) {
 	pRepo := new(tpmocks.Repository)
 	psvc := tpolicies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsPolicyServer(svc, psvc)
 	defer ts.Close()
 
@@ -532,10 +543,11 @@ func TestUpdateThingsPolicy(t *testing.T) {
 func TestListPolicies(t *testing.T) {
 	cRepo := new(umocks.Repository)
 	pRepo := new(upmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(upmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
-	svc := upolicies.NewService(pRepo, tokenizer, idProvider)
+	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), uclients.RegistrationConfig{})
+	svc := upolicies.NewService(pRepo, rRepo, tokenizer, idProvider)
 	ts := newUsersPolicyServer(svc)
 	defer ts.Close()
 
@@ -666,10 +678,11 @@ func TestListPolicies(t *testing.T) {
 func TestDeletePolicy(t *testing.T) {
 	cRepo := new(umocks.Repository)
 	pRepo := new(upmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(upmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
-	svc := upolicies.NewService(pRepo, tokenizer, idProvider)
+	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), uclients.RegistrationConfig{})
+	svc := upolicies.NewService(pRepo, rRepo, tokenizer, idProvider)
 	ts := newUsersPolicyServer(svc)
 	defer ts.Close()
 
@@ -708,10 +721,11 @@ func TestDeletePolicy(t *testing.T) {
 func TestUnassign(t *testing.T) {
 	cRepo := new(umocks.Repository)
 	pRepo := new(upmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(upmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
-	svc := upolicies.NewService(pRepo, tokenizer, idProvider)
+	csvc := uclients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), uclients.RegistrationConfig{})
+	svc := upolicies.NewService(pRepo, rRepo, tokenizer, idProvider)
 	ts := newUsersPolicyServer(svc)
 	defer ts.Close()
 
@@ -757,7 +771,7 @@ func TestConnect(t *testing.T) {
 	pRepo := new(tpmocks.Repository)
 	psvc := tpolicies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsPolicyServer(svc, psvc)
 	defer ts.Close()
 
@@ -885,6 +899,92 @@ func TestConnect(t *testing.T) {
 	}
 }
 
+func TestCreateThingPolicies(t *testing.T) {
+	cRepo := new(tmocks.Repository)
+	gRepo := new(tgmocks.Repository)
+	uauth := umocks.NewAuthService(users, map[string][]umocks.SubjectSet{adminID: {utadminPolicy}})
+	thingCache := tmocks.NewCache()
+	policiesCache := tpmocks.NewCache()
+
+	pRepo := new(tpmocks.Repository)
+	psvc := tpolicies.NewService(uauth, pRepo, policiesCache, idProvider)
+
+	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	ts := newThingsPolicyServer(svc, psvc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		ThingsURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	policy := tpolicies.Policy{Subject: subject, Object: object, Actions: []string{"m_read"}}
+	cases := []struct {
+		desc     string
+		policies []sdk.Policy
+		csv      string
+		token    string
+		saved    []tpolicies.PolicyResult
+		failed   int
+		err      errors.SDKError
+	}{
+		{
+			desc: "create thing policies",
+			policies: []sdk.Policy{
+				{Subject: subject, Object: object, Actions: []string{"m_read"}},
+				{Subject: subject},
+			},
+			token:  adminToken,
+			saved:  []tpolicies.PolicyResult{{Policy: policy}},
+			failed: 1,
+			err:    nil,
+		},
+		{
+			desc:     "create thing policies with invalid token",
+			policies: []sdk.Policy{{Subject: subject, Object: object}},
+			token:    invalidToken,
+			saved:    []tpolicies.PolicyResult{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthorization, errors.ErrAuthentication), http.StatusUnauthorized),
+		},
+		{
+			desc:     "create empty list of thing policies",
+			policies: []sdk.Policy{},
+			token:    adminToken,
+			saved:    []tpolicies.PolicyResult{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrEmptyList), http.StatusBadRequest),
+		},
+		{
+			desc:   "create thing policies from CSV",
+			csv:    "subject,object,actions\n" + subject + "," + object + ",\"m_read,m_write\"\n",
+			token:  adminToken,
+			saved:  []tpolicies.PolicyResult{{Policy: policy}},
+			failed: 0,
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateGroupAccess", mock.Anything, mock.Anything).Return(tpolicies.Policy{}, nil)
+		repoCall1 := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(tpolicies.Policy{}, nil)
+		repoCall2 := pRepo.On("SaveAll", mock.Anything, mock.Anything).Return(tc.saved, nil)
+		var report sdk.PoliciesReport
+		var err errors.SDKError
+		switch tc.csv {
+		case "":
+			report, err = mfsdk.CreateThingPolicies(tc.policies, false, tc.token)
+		default:
+			report, err = mfsdk.CreateThingPoliciesCSV([]byte(tc.csv), tc.token)
+		}
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.failed, report.Failed, fmt.Sprintf("%s: expected %d failed policies got %d\n", tc.desc, tc.failed, report.Failed))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
 func TestConnectThing(t *testing.T) {
 	cRepo := new(tmocks.Repository)
 	gRepo := new(tgmocks.Repository)
@@ -895,7 +995,7 @@ func TestConnectThing(t *testing.T) {
 	pRepo := new(tpmocks.Repository)
 	psvc := tpolicies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsPolicyServer(svc, psvc)
 	defer ts.Close()
 
@@ -1023,7 +1123,7 @@ func TestDisconnectThing(t *testing.T) {
 	pRepo := new(tpmocks.Repository)
 	psvc := tpolicies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsPolicyServer(svc, psvc)
 	defer ts.Close()
 
@@ -1060,7 +1160,7 @@ func TestDisconnect(t *testing.T) {
 	pRepo := new(tpmocks.Repository)
 	psvc := tpolicies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := tclients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsPolicyServer(svc, psvc)
 	defer ts.Close()
 
@@ -14,6 +14,10 @@ type resetPasswordRequestreq struct {
 	Host  string `json:"host"`
 }
 
+type verifyUserReq struct {
+	Token string `json:"token"`
+}
+
 type resetPasswordReq struct {
 	Token    string `json:"token"`
 	Password string `json:"password"`
@@ -24,6 +28,10 @@ type updateThingSecretReq struct {
 	Secret string `json:"secret,omitempty"`
 }
 
+type updateThingSecretExpiryReq struct {
+	Duration string `json:"duration,omitempty"`
+}
+
 // updateClientIdentityReq is used to update the client identity.
 type updateClientIdentityReq struct {
 	token    string
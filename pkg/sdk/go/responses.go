@@ -37,7 +37,8 @@ type ChannelsPage struct {
 
 // MessagesPage contains list of messages in a page with proper metadata.
 type MessagesPage struct {
-	Messages []senml.Message `json:"messages,omitempty"`
+	Messages   []senml.Message `json:"messages,omitempty"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 	pageRes
 }
 
@@ -70,6 +71,27 @@ type PolicyPage struct {
 	Policies []Policy `json:"policies"`
 }
 
+// KeysPage contains page related metadata as well as list
+// of API keys that belong to the page.
+type KeysPage struct {
+	pageRes
+	Keys []Key `json:"keys"`
+}
+
+// RolesPage contains page related metadata as well as list
+// of roles that belong to the page.
+type RolesPage struct {
+	pageRes
+	Roles []Role `json:"roles"`
+}
+
+// RoleAssignmentsPage contains page related metadata as well as list
+// of role assignments that belong to the page.
+type RoleAssignmentsPage struct {
+	pageRes
+	Assignments []RoleAssignment `json:"assignments"`
+}
+
 type revokeCertsRes struct {
 	RevocationTime time.Time `json:"revocation_time"`
 }
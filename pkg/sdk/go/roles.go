@@ -0,0 +1,166 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	rolesEndpoint       = "roles"
+	assignmentsEndpoint = "assignments"
+)
+
+// Role represents a named bundle of actions which can be assigned to users
+// on users groups and things groups.
+type Role struct {
+	ID          string    `json:"id,omitempty"`
+	OwnerID     string    `json:"owner_id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Actions     []string  `json:"actions,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+}
+
+// RoleAssignment represents the role assigned to the subject user on the
+// object group.
+type RoleAssignment struct {
+	OwnerID   string    `json:"owner_id,omitempty"`
+	RoleID    string    `json:"role_id,omitempty"`
+	Subject   string    `json:"subject,omitempty"`
+	Object    string    `json:"object,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func (sdk mfSDK) CreateRole(role Role, token string) (Role, errors.SDKError) {
+	data, err := json.Marshal(role)
+	if err != nil {
+		return Role{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.usersURL, rolesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Role{}, sdkerr
+	}
+
+	var r Role
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Role{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mfSDK) ViewRole(id, token string) (Role, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, rolesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Role{}, sdkerr
+	}
+
+	var r Role
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Role{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mfSDK) ListRoles(pm PageMetadata, token string) (RolesPage, errors.SDKError) {
+	url, err := sdk.withQueryParams(sdk.usersURL, rolesEndpoint, pm)
+	if err != nil {
+		return RolesPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RolesPage{}, sdkerr
+	}
+
+	var rp RolesPage
+	if err := json.Unmarshal(body, &rp); err != nil {
+		return RolesPage{}, errors.NewSDKError(err)
+	}
+
+	return rp, nil
+}
+
+func (sdk mfSDK) UpdateRole(role Role, token string) (Role, errors.SDKError) {
+	data, err := json.Marshal(role)
+	if err != nil {
+		return Role{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, rolesEndpoint, role.ID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Role{}, sdkerr
+	}
+
+	var r Role
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Role{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mfSDK) RemoveRole(id, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, rolesEndpoint, id)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mfSDK) AssignRole(a RoleAssignment, token string) errors.SDKError {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, rolesEndpoint, a.RoleID, assignmentsEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+
+	return sdkerr
+}
+
+func (sdk mfSDK) UnassignRole(a RoleAssignment, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s/%s/%s/%s", sdk.usersURL, rolesEndpoint, a.RoleID, assignmentsEndpoint, a.Subject, a.Object)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mfSDK) ListRoleAssignments(roleID string, pm PageMetadata, token string) (RoleAssignmentsPage, errors.SDKError) {
+	url, err := sdk.withQueryParams(sdk.usersURL, fmt.Sprintf("%s/%s/%s", rolesEndpoint, roleID, assignmentsEndpoint), pm)
+	if err != nil {
+		return RoleAssignmentsPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RoleAssignmentsPage{}, sdkerr
+	}
+
+	var ap RoleAssignmentsPage
+	if err := json.Unmarshal(body, &ap); err != nil {
+		return RoleAssignmentsPage{}, errors.NewSDKError(err)
+	}
+
+	return ap, nil
+}
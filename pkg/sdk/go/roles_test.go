@@ -0,0 +1,191 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	upolicies "github.com/mainflux/mainflux/users/policies"
+	upmocks "github.com/mainflux/mainflux/users/policies/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRolesSDK(t *testing.T) (sdk.SDK, *upmocks.Repository, *upmocks.RoleRepository, string, string, func()) {
+	pRepo := new(upmocks.Repository)
+	rRepo := new(upmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	svc := upolicies.NewService(pRepo, rRepo, tokenizer, idProvider)
+	ts := newUsersPolicyServer(svc)
+
+	userID := generateUUID(t)
+	tkn, err := tokenizer.Issue(context.Background(), jwt.Claims{ClientID: userID, Email: "user@example.com", Type: jwt.AccessToken})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	mfsdk := sdk.NewSDK(sdk.Config{UsersURL: ts.URL})
+
+	return mfsdk, pRepo, rRepo, userID, tkn.AccessToken, ts.Close
+}
+
+func TestCreateRole(t *testing.T) {
+	mfsdk, _, rRepo, userID, tkn, closeFn := newRolesSDK(t)
+	defer closeFn()
+
+	cases := []struct {
+		desc  string
+		role  sdk.Role
+		token string
+		err   errors.SDKError
+	}{
+		{
+			desc:  "create role",
+			role:  sdk.Role{Name: "operator", Actions: []string{"c_update", "g_list"}},
+			token: tkn,
+			err:   nil,
+		},
+		{
+			desc:  "create role without name",
+			role:  sdk.Role{Actions: []string{"c_update"}},
+			token: tkn,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrNameSize), http.StatusBadRequest),
+		},
+		{
+			desc:  "create role with invalid action",
+			role:  sdk.Role{Name: "operator", Actions: []string{"wrong"}},
+			token: tkn,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMalformedPolicyAct), http.StatusInternalServerError),
+		},
+		{
+			desc:  "create role with invalid token",
+			role:  sdk.Role{Name: "operator", Actions: []string{"c_update"}},
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, sdk.ErrInvalidJWT), http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := rRepo.On("Save", mock.Anything, mock.Anything).Return(upolicies.Role{ID: generateUUID(t), OwnerID: userID, Name: tc.role.Name, Actions: tc.role.Actions}, nil)
+		role, err := mfsdk.CreateRole(tc.role, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, role.ID, fmt.Sprintf("%s: expected role ID", tc.desc))
+			assert.Equal(t, userID, role.OwnerID, fmt.Sprintf("%s: expected owner %s, got %s", tc.desc, userID, role.OwnerID))
+		}
+		repoCall.Unset()
+	}
+}
+
+func TestListRoles(t *testing.T) {
+	mfsdk, pRepo, rRepo, userID, tkn, closeFn := newRolesSDK(t)
+	defer closeFn()
+
+	var rs []upolicies.Role
+	for i := 0; i < 3; i++ {
+		rs = append(rs, upolicies.Role{ID: generateUUID(t), OwnerID: userID, Name: fmt.Sprintf("role-%d", i), Actions: []string{"c_list"}})
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		page  upolicies.RolesPage
+		size  int
+		err   errors.SDKError
+	}{
+		{
+			desc:  "list roles",
+			token: tkn,
+			page:  upolicies.RolesPage{Page: upolicies.Page{Total: 3, Limit: 10}, Roles: rs},
+			size:  3,
+			err:   nil,
+		},
+		{
+			desc:  "list roles with invalid token",
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, sdk.ErrInvalidJWT), http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", mock.Anything, mock.Anything).Return(errors.ErrAuthorization)
+		repoCall1 := rRepo.On("RetrieveAll", mock.Anything, mock.Anything).Return(tc.page, nil)
+		page, err := mfsdk.ListRoles(sdk.PageMetadata{Limit: 10}, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Roles), fmt.Sprintf("%s: expected %d roles, got %d", tc.desc, tc.size, len(page.Roles)))
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestAssignRole(t *testing.T) {
+	mfsdk, pRepo, rRepo, userID, tkn, closeFn := newRolesSDK(t)
+	defer closeFn()
+
+	role := upolicies.Role{ID: generateUUID(t), OwnerID: userID, Name: "operator", Actions: []string{"c_update"}}
+
+	cases := []struct {
+		desc       string
+		assignment sdk.RoleAssignment
+		token      string
+		err        errors.SDKError
+	}{
+		{
+			desc:       "assign role",
+			assignment: sdk.RoleAssignment{RoleID: role.ID, Subject: generateUUID(t), Object: generateUUID(t)},
+			token:      tkn,
+			err:        nil,
+		},
+		{
+			desc:       "assign role without subject",
+			assignment: sdk.RoleAssignment{RoleID: role.ID, Object: generateUUID(t)},
+			token:      tkn,
+			err:        errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingPolicySub), http.StatusInternalServerError),
+		},
+		{
+			desc:       "assign role with invalid token",
+			assignment: sdk.RoleAssignment{RoleID: role.ID, Subject: generateUUID(t), Object: generateUUID(t)},
+			token:      invalidToken,
+			err:        errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, sdk.ErrInvalidJWT), http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", mock.Anything, mock.Anything).Return(errors.ErrAuthorization)
+		repoCall1 := rRepo.On("Retrieve", mock.Anything, role.ID).Return(role, nil)
+		repoCall2 := pRepo.On("EvaluateGroupAccess", mock.Anything, mock.Anything).Return(upolicies.Policy{}, nil)
+		repoCall3 := rRepo.On("Assign", mock.Anything, mock.Anything).Return(nil)
+		err := mfsdk.AssignRole(tc.assignment, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
+
+func TestUnassignRole(t *testing.T) {
+	mfsdk, pRepo, rRepo, userID, tkn, closeFn := newRolesSDK(t)
+	defer closeFn()
+
+	assignment := upolicies.Assignment{OwnerID: userID, RoleID: generateUUID(t), Subject: generateUUID(t), Object: generateUUID(t)}
+
+	repoCall := pRepo.On("CheckAdmin", mock.Anything, mock.Anything).Return(errors.ErrAuthorization)
+	repoCall1 := rRepo.On("RetrieveAssignments", mock.Anything, mock.Anything).Return(upolicies.AssignmentsPage{Assignments: []upolicies.Assignment{assignment}}, nil)
+	repoCall2 := rRepo.On("Unassign", mock.Anything, mock.Anything).Return(nil)
+	err := mfsdk.UnassignRole(sdk.RoleAssignment{RoleID: assignment.RoleID, Subject: assignment.Subject, Object: assignment.Object}, tkn)
+	assert.Nil(t, err, fmt.Sprintf("unassign role: unexpected error %s", err))
+	ok := repoCall2.Parent.AssertCalled(t, "Unassign", mock.Anything, mock.Anything)
+	assert.True(t, ok, "Unassign was not called on unassigning role")
+	repoCall.Unset()
+	repoCall1.Unset()
+	repoCall2.Unset()
+}
@@ -28,6 +28,12 @@ const (
 	// CTBinary represents binary content type.
 	CTBinary ContentType = "application/octet-stream"
 
+	// CTCSV represents CSV content type.
+	CTCSV ContentType = "text/csv"
+
+	// CTNDJSON represents newline-delimited JSON content type.
+	CTNDJSON ContentType = "application/x-ndjson"
+
 	// EnabledStatus represents enable status for a client.
 	EnabledStatus = "enabled"
 
@@ -90,6 +96,17 @@ type PageMetadata struct {
 	Topic      string   `json:"topic,omitempty"`
 	Contact    string   `json:"contact,omitempty"`
 	State      string   `json:"state,omitempty"`
+	ParentID   string   `json:"parent_id,omitempty"`
+	Filter     string   `json:"filter,omitempty"`
+}
+
+// MessagePageMetadata contains page metadata used to read messages. Cursor
+// and offset are mutually exclusive.
+type MessagePageMetadata struct {
+	Offset    uint64 `json:"offset,omitempty"`
+	Limit     uint64 `json:"limit,omitempty"`
+	Cursor    string `json:"cursor,omitempty"`
+	SkipTotal bool   `json:"skip_total,omitempty"`
 }
 
 // Credentials represent client credentials: it contains
@@ -216,6 +233,14 @@ type SDK interface {
 	//  fmt.Println(err)
 	ResetPassword(password, confPass, token string) errors.SDKError
 
+	// VerifyUser enables the self-registered user using the identity
+	// verification token sent to the user by e-mail.
+	//
+	// example:
+	//  user, _ := sdk.VerifyUser("verificationToken")
+	//  fmt.Println(user)
+	VerifyUser(token string) (User, errors.SDKError)
+
 	// UpdatePassword updates user password.
 	//
 	// example:
@@ -237,6 +262,14 @@ type SDK interface {
 	//  fmt.Println(user)
 	DisableUser(id, token string) (User, errors.SDKError)
 
+	// UnlockUser removes the lockout caused by too many failed login
+	// attempts of the user. Only admins are allowed to unlock users.
+	//
+	// example:
+	//  err := sdk.UnlockUser("userID", "token")
+	//  fmt.Println(err)
+	UnlockUser(id, token string) errors.SDKError
+
 	// CreateToken receives credentials and returns user token.
 	//
 	// example:
@@ -257,6 +290,112 @@ type SDK interface {
 	//  fmt.Println(token)
 	RefreshToken(token string) (Token, errors.SDKError)
 
+	// RevokeToken revokes the given access or refresh token, together
+	// with the token issued alongside it.
+	//
+	// example:
+	//  err := sdk.RevokeToken("token")
+	//  fmt.Println(err)
+	RevokeToken(token string) errors.SDKError
+
+	// RevokeAllTokens revokes all the tokens issued to the user,
+	// logging the user out everywhere.
+	//
+	// example:
+	//  err := sdk.RevokeAllTokens("token")
+	//  fmt.Println(err)
+	RevokeAllTokens(token string) errors.SDKError
+
+	// IssueKey issues a new long-lived API key for the user. The key value
+	// is returned only once and must be stored by the caller.
+	//
+	// example:
+	//  key := sdk.Key{
+	//    Name:     "ci",
+	//    Duration: "720h",
+	//    Scope: sdk.KeyScope{
+	//      Actions: []string{"m_read"},
+	//      Objects: []string{"channelID"},
+	//    },
+	//  }
+	//  key, _ := sdk.IssueKey(key, "token")
+	//  fmt.Println(key.Value)
+	IssueKey(key Key, token string) (Key, errors.SDKError)
+
+	// ViewKey retrieves the API key with the given ID.
+	//
+	// example:
+	//  key, _ := sdk.ViewKey("keyID", "token")
+	//  fmt.Println(key)
+	ViewKey(id, token string) (Key, errors.SDKError)
+
+	// ListKeys lists the API keys of the user.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  keys, _ := sdk.ListKeys(pm, "token")
+	//  fmt.Println(keys)
+	ListKeys(pm PageMetadata, token string) (KeysPage, errors.SDKError)
+
+	// RevokeKey revokes the API key with the given ID.
+	//
+	// example:
+	//  err := sdk.RevokeKey("keyID", "token")
+	//  fmt.Println(err)
+	RevokeKey(id, token string) errors.SDKError
+
+	// EnrollMFA starts the enrollment of TOTP two-factor authentication
+	// using the user token or the enrollment token sent by e-mail.
+	// The enrollment has to be confirmed with a generated one-time password.
+	//
+	// example:
+	//  p, _ := sdk.EnrollMFA("token")
+	//  fmt.Println(p.URI)
+	EnrollMFA(token string) (MFAProvisioning, errors.SDKError)
+
+	// RequestMFAEnrollment sends the enrollment token to the e-mail of the
+	// user who got the challenge token returned by CreateToken, but hasn't
+	// enrolled two-factor authentication yet.
+	//
+	// example:
+	//  err := sdk.RequestMFAEnrollment("challenge")
+	//  fmt.Println(err)
+	RequestMFAEnrollment(challenge string) errors.SDKError
+
+	// ConfirmMFA enables two-factor authentication by verifying the
+	// one-time password generated from the pending enrollment.
+	//
+	// example:
+	//  err := sdk.ConfirmMFA("123456", "token")
+	//  fmt.Println(err)
+	ConfirmMFA(code, token string) errors.SDKError
+
+	// VerifyMFA exchanges the challenge token returned by CreateToken and
+	// a one-time password or recovery code for the user token.
+	//
+	// example:
+	//  token, _ := sdk.VerifyMFA("123456", "challenge")
+	//  fmt.Println(token)
+	VerifyMFA(code, challenge string) (Token, errors.SDKError)
+
+	// DisableMFA disables two-factor authentication of the user.
+	//
+	// example:
+	//  err := sdk.DisableMFA("123456", "token")
+	//  fmt.Println(err)
+	DisableMFA(code, token string) errors.SDKError
+
+	// ResetMFA resets two-factor authentication of the user with the
+	// given ID. Only admins are allowed to reset it.
+	//
+	// example:
+	//  err := sdk.ResetMFA("userID", "token")
+	//  fmt.Println(err)
+	ResetMFA(id, token string) errors.SDKError
+
 	// CreateThing registers new thing and returns its id.
 	//
 	// example:
@@ -322,6 +461,15 @@ type SDK interface {
 	//  fmt.Println(thing)
 	Thing(id, token string) (Thing, errors.SDKError)
 
+	// ThingPresence returns the connectivity status of the thing: whether
+	// it's online, when it was last seen, and over which protocol and
+	// remote address.
+	//
+	// example:
+	//  presence, _ := sdk.ThingPresence("thingID", "token")
+	//  fmt.Println(presence.Online, presence.LastSeen)
+	ThingPresence(id, token string) (ThingPresence, errors.SDKError)
+
 	// UpdateThing updates existing thing.
 	//
 	// example:
@@ -379,6 +527,72 @@ type SDK interface {
 	//  fmt.Println(thing)
 	DisableThing(id, token string) (Thing, errors.SDKError)
 
+	// DeleteThing removes the thing together with its connections - hard delete.
+	//
+	// example:
+	//  err := sdk.DeleteThing("thingID", "token")
+	//  fmt.Println(err)
+	DeleteThing(id, token string) errors.SDKError
+
+	// AddThingSecret adds a named secret to the thing, which is accepted
+	// alongside the thing's primary secret. The secret is generated if not
+	// provided.
+	//
+	// example:
+	//  secret := sdk.ThingSecret{
+	//    Name:     "2024-rotation",
+	//    Duration: "720h",
+	//  }
+	//  secret, _ := sdk.AddThingSecret("thingID", secret, "token")
+	//  fmt.Println(secret.Secret)
+	AddThingSecret(id string, secret ThingSecret, token string) (ThingSecret, errors.SDKError)
+
+	// ThingSecrets lists the named secrets of the thing.
+	//
+	// example:
+	//  secrets, _ := sdk.ThingSecrets("thingID", "token")
+	//  fmt.Println(secrets)
+	ThingSecrets(id, token string) ([]ThingSecret, errors.SDKError)
+
+	// UpdateThingSecretExpiry lets the named thing secret expire after the
+	// given duration. Empty duration makes the secret never expire.
+	//
+	// example:
+	//  secret, _ := sdk.UpdateThingSecretExpiry("thingID", "2024-rotation", "24h", "token")
+	//  fmt.Println(secret.ExpiresAt)
+	UpdateThingSecretExpiry(id, name, duration, token string) (ThingSecret, errors.SDKError)
+
+	// RemoveThingSecret revokes the named thing secret immediately.
+	//
+	// example:
+	//  err := sdk.RemoveThingSecret("thingID", "2024-rotation", "token")
+	//  fmt.Println(err)
+	RemoveThingSecret(id, name, token string) errors.SDKError
+
+	// ExecuteThingOperations creates, updates, enables and disables things in
+	// bulk. A failing operation doesn't fail the request; the report holds
+	// the outcome of every operation, including the secrets of created things.
+	//
+	// example:
+	//  ops := []sdk.ThingOperation{
+	//    {Op: sdk.CreateOp, Name: "thing1"},
+	//    {Op: sdk.UpdateOp, ID: "thingID", Metadata: map[string]interface{}{"floor": 2}},
+	//    {Op: sdk.DisableOp, ID: "otherThingID"},
+	//  }
+	//  report, _ := sdk.ExecuteThingOperations(ops, "token")
+	//  fmt.Println(report.Failed)
+	ExecuteThingOperations(ops []ThingOperation, token string) (OperationsReport, errors.SDKError)
+
+	// ExecuteThingOperationsCSV executes the bulk thing operations given as
+	// CSV. The header row names the columns: op, id, name, secret, tags,
+	// metadata and status.
+	//
+	// example:
+	//  csv := []byte("op,name,tags\ncreate,thing1,\"a,b\"\n")
+	//  report, _ := sdk.ExecuteThingOperationsCSV(csv, "token")
+	//  fmt.Println(report.Results)
+	ExecuteThingOperationsCSV(csv []byte, token string) (OperationsReport, errors.SDKError)
+
 	// IdentifyThing validates thing's key and returns its ID
 	//
 	// example:
@@ -587,6 +801,14 @@ type SDK interface {
 	//  fmt.Println(channel)
 	DisableChannel(id, token string) (Channel, errors.SDKError)
 
+	// DeleteChannel removes the channel together with its connections - hard delete.
+	// Channels with child channels are not removed.
+	//
+	// example:
+	//  err := sdk.DeleteChannel("channelID", "token")
+	//  fmt.Println(err)
+	DeleteChannel(id, token string) errors.SDKError
+
 	// CreateUserPolicy creates a policy for the given subject, so that, after
 	// CreateUserPolicy, `subject` has a `relation` on `object`. Returns a non-nil
 	// error in case of failures.
@@ -642,6 +864,93 @@ type SDK interface {
 	//  fmt.Println(err)
 	DeleteUserPolicy(policy Policy, token string) errors.SDKError
 
+	// CreateRole creates a role, a named bundle of actions which can be
+	// assigned to users on users groups and things groups.
+	//
+	// example:
+	//  role := sdk.Role{
+	//    Name:    "operator",
+	//    Actions: []string{"c_update", "g_list"},
+	//  }
+	//  role, _ := sdk.CreateRole(role, "token")
+	//  fmt.Println(role)
+	CreateRole(role Role, token string) (Role, errors.SDKError)
+
+	// ViewRole retrieves the role with the given ID.
+	//
+	// example:
+	//  role, _ := sdk.ViewRole("roleID", "token")
+	//  fmt.Println(role)
+	ViewRole(id, token string) (Role, errors.SDKError)
+
+	// ListRoles lists the roles of the user, or all the roles for admins.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  roles, _ := sdk.ListRoles(pm, "token")
+	//  fmt.Println(roles)
+	ListRoles(pm PageMetadata, token string) (RolesPage, errors.SDKError)
+
+	// UpdateRole updates the role name, description and actions.
+	//
+	// example:
+	//  role := sdk.Role{
+	//    ID:      "roleID",
+	//    Name:    "operator",
+	//    Actions: []string{"c_update", "c_delete"},
+	//  }
+	//  role, _ := sdk.UpdateRole(role, "token")
+	//  fmt.Println(role)
+	UpdateRole(role Role, token string) (Role, errors.SDKError)
+
+	// RemoveRole removes the role together with its assignments.
+	//
+	// example:
+	//  err := sdk.RemoveRole("roleID", "token")
+	//  fmt.Println(err)
+	RemoveRole(id, token string) errors.SDKError
+
+	// AssignRole assigns the role to the subject user on the object group.
+	// The role applies to the descendants of the group as well.
+	//
+	// example:
+	//  assignment := sdk.RoleAssignment{
+	//    RoleID:  "roleID",
+	//    Subject: "userID",
+	//    Object:  "groupID",
+	//  }
+	//  err := sdk.AssignRole(assignment, "token")
+	//  fmt.Println(err)
+	AssignRole(assignment RoleAssignment, token string) errors.SDKError
+
+	// UnassignRole removes the role assignment.
+	//
+	// example:
+	//  assignment := sdk.RoleAssignment{
+	//    RoleID:  "roleID",
+	//    Subject: "userID",
+	//    Object:  "groupID",
+	//  }
+	//  err := sdk.UnassignRole(assignment, "token")
+	//  fmt.Println(err)
+	UnassignRole(assignment RoleAssignment, token string) errors.SDKError
+
+	// ListRoleAssignments lists the assignments of the role, optionally
+	// filtered by the subject and the object.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset:  0,
+	//    Limit:   10,
+	//    Subject: "userID",
+	//  }
+	//  assignments, _ := sdk.ListRoleAssignments("roleID", pm, "token")
+	//  fmt.Println(assignments)
+	ListRoleAssignments(roleID string, pm PageMetadata, token string) (RoleAssignmentsPage, errors.SDKError)
+
 	// CreateThingPolicy creates a policy for the given subject, so that, after
 	// CreateThingPolicy, `subject` has a `relation` on `object`. Returns a non-nil
 	// error in case of failures.
@@ -753,6 +1062,28 @@ type SDK interface {
 	//  fmt.Println(err)
 	Disconnect(connIDs ConnectionIDs, token string) errors.SDKError
 
+	// CreateThingPolicies connects things to channels in bulk. A failing
+	// connection doesn't fail the request; the report holds the outcome of
+	// every policy. Policies without actions get both messaging actions.
+	//
+	// example:
+	//  policies := []sdk.Policy{
+	//    {Subject: "thingID:1", Object: "channelID:1", Actions: []string{"m_write"}},
+	//    {Subject: "thingID:2", Object: "channelID:1"},
+	//  }
+	//  report, _ := sdk.CreateThingPolicies(policies, false, "token")
+	//  fmt.Println(report.Failed)
+	CreateThingPolicies(policies []Policy, external bool, token string) (PoliciesReport, errors.SDKError)
+
+	// CreateThingPoliciesCSV connects things to channels in bulk, given as
+	// CSV. The header row names the columns: subject, object and actions.
+	//
+	// example:
+	//  csv := []byte("subject,object,actions\nthingID,channelID,m_read\n")
+	//  report, _ := sdk.CreateThingPoliciesCSV(csv, "token")
+	//  fmt.Println(report.Results)
+	CreateThingPoliciesCSV(csv []byte, token string) (PoliciesReport, errors.SDKError)
+
 	// ConnectThing connects thing to specified channel by id.
 	//
 	// The `ConnectThing` method calls the `CreateThingPolicy` method under the hood.
@@ -792,12 +1123,32 @@ type SDK interface {
 	//  fmt.Println(err)
 	SendMessage(chanID, msg, key string) errors.SDKError
 
-	// ReadMessages read messages of specified channel.
+	// ReadMessages read messages of specified channel. Pages are iterated
+	// using the next cursor of the previous page.
 	//
 	// example:
-	//  msgs, _ := sdk.ReadMessages("channelID", "token")
-	//  fmt.Println(msgs)
-	ReadMessages(chanID, token string) (MessagesPage, errors.SDKError)
+	//  pm := sdk.MessagePageMetadata{
+	//    Limit:     100,
+	//    SkipTotal: true,
+	//  }
+	//  for {
+	//    msgs, _ := sdk.ReadMessages("channelID", pm, "token")
+	//    fmt.Println(msgs)
+	//    if msgs.NextCursor == "" {
+	//      break
+	//    }
+	//    pm.Cursor = msgs.NextCursor
+	//  }
+	ReadMessages(chanID string, pm MessagePageMetadata, token string) (MessagesPage, errors.SDKError)
+
+	// ExportMessages streams all messages of specified channel to the given
+	// writer, encoded as CSV or newline-delimited JSON.
+	//
+	// example:
+	//  file, _ := os.Create("messages.csv")
+	//  err := sdk.ExportMessages("channelID", sdk.CTCSV, file, "token")
+	//  fmt.Println(err)
+	ExportMessages(chanID string, ct ContentType, w io.Writer, token string) errors.SDKError
 
 	// SetContentType sets message content type.
 	//
@@ -973,6 +1324,7 @@ type mfSDK struct {
 	bootstrapURL   string
 	certsURL       string
 	httpAdapterURL string
+	notifierURL    string
 	readerURL      string
 	thingsURL      string
 	usersURL       string
@@ -987,6 +1339,7 @@ type Config struct {
 	BootstrapURL   string
 	CertsURL       string
 	HTTPAdapterURL string
+	NotifierURL    string
 	ReaderURL      string
 	ThingsURL      string
 	UsersURL       string
@@ -1002,6 +1355,7 @@ func NewSDK(conf Config) SDK {
 		bootstrapURL:   conf.BootstrapURL,
 		certsURL:       conf.CertsURL,
 		httpAdapterURL: conf.HTTPAdapterURL,
+		notifierURL:    conf.NotifierURL,
 		readerURL:      conf.ReaderURL,
 		thingsURL:      conf.ThingsURL,
 		usersURL:       conf.UsersURL,
@@ -1028,10 +1382,10 @@ func (sdk mfSDK) processRequest(method, url, token string, data []byte, headers
 
 	// Sets a default value for the Content-Type.
 	// Overridden if Content-Type is passed in the headers arguments.
-	req.Header.Add("Content-Type", string(CTJSON))
+	req.Header.Set("Content-Type", string(CTJSON))
 
 	for key, value := range headers {
-		req.Header.Add(key, value)
+		req.Header.Set(key, value)
 	}
 
 	if token != "" {
@@ -1132,6 +1486,12 @@ func (pm PageMetadata) query() (string, error) {
 	if pm.State != "" {
 		q.Add("state", pm.State)
 	}
+	if pm.ParentID != "" {
+		q.Add("parent_id", pm.ParentID)
+	}
+	if pm.Filter != "" {
+		q.Add("filter", pm.Filter)
+	}
 
 	return q.Encode(), nil
 }
@@ -16,6 +16,14 @@ const (
 	identifyEndpoint   = "identify"
 )
 
+// Kinds of bulk thing operations.
+const (
+	CreateOp  = "create"
+	UpdateOp  = "update"
+	EnableOp  = "enable"
+	DisableOp = "disable"
+)
+
 // Thing represents mainflux thing.
 type Thing struct {
 	ID          string                 `json:"id"`
@@ -29,6 +37,57 @@ type Thing struct {
 	Status      string                 `json:"status,omitempty"`
 }
 
+// ThingSecret represents a named thing secret accepted in addition to the
+// thing's primary one. Duration is only used when adding a secret; a secret
+// without duration never expires.
+type ThingSecret struct {
+	ThingID   string    `json:"thing_id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Secret    string    `json:"secret,omitempty"`
+	Duration  string    `json:"duration,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// ThingPresence represents the connectivity status of a thing. LastSeen is
+// zero for the things which have never been seen.
+type ThingPresence struct {
+	ThingID    string    `json:"thing_id"`
+	Online     bool      `json:"online"`
+	LastSeen   time.Time `json:"last_seen,omitempty"`
+	Protocol   string    `json:"protocol,omitempty"`
+	RemoteAddr string    `json:"remote_addr,omitempty"`
+}
+
+// ThingOperation represents a single operation of a bulk request. Update
+// operations change the name, tags and metadata, whichever are set.
+type ThingOperation struct {
+	Op       string                 `json:"op"`
+	ID       string                 `json:"id,omitempty"`
+	Name     string                 `json:"name,omitempty"`
+	Secret   string                 `json:"secret,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status,omitempty"`
+}
+
+// OperationResult represents the outcome of a single bulk operation. Rows
+// are numbered from 1 in the order of the request, without the CSV header.
+type OperationResult struct {
+	Row    int    `json:"row"`
+	Op     string `json:"op"`
+	ID     string `json:"id,omitempty"`
+	Secret string `json:"secret,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// OperationsReport contains the outcome of every operation of a bulk request.
+type OperationsReport struct {
+	Total   int               `json:"total"`
+	Failed  int               `json:"failed"`
+	Results []OperationResult `json:"results"`
+}
+
 func (sdk mfSDK) CreateThing(thing Thing, token string) (Thing, errors.SDKError) {
 	data, err := json.Marshal(thing)
 	if err != nil {
@@ -125,6 +184,22 @@ func (sdk mfSDK) Thing(id, token string) (Thing, errors.SDKError) {
 	return t, nil
 }
 
+func (sdk mfSDK) ThingPresence(id, token string) (ThingPresence, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/presence", sdk.thingsURL, thingsEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return ThingPresence{}, sdkerr
+	}
+
+	var p ThingPresence
+	if err := json.Unmarshal(body, &p); err != nil {
+		return ThingPresence{}, errors.NewSDKError(err)
+	}
+
+	return p, nil
+}
+
 func (sdk mfSDK) UpdateThing(t Thing, token string) (Thing, errors.SDKError) {
 	data, err := json.Marshal(t)
 	if err != nil {
@@ -219,6 +294,82 @@ func (sdk mfSDK) DisableThing(id, token string) (Thing, errors.SDKError) {
 	return sdk.changeThingStatus(id, disableEndpoint, token)
 }
 
+func (sdk mfSDK) DeleteThing(id, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.thingsURL, thingsEndpoint, id)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mfSDK) AddThingSecret(id string, secret ThingSecret, token string) (ThingSecret, errors.SDKError) {
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return ThingSecret{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/secrets", sdk.thingsURL, thingsEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return ThingSecret{}, sdkerr
+	}
+
+	var ts ThingSecret
+	if err := json.Unmarshal(body, &ts); err != nil {
+		return ThingSecret{}, errors.NewSDKError(err)
+	}
+
+	return ts, nil
+}
+
+func (sdk mfSDK) ThingSecrets(id, token string) ([]ThingSecret, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/secrets", sdk.thingsURL, thingsEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return []ThingSecret{}, sdkerr
+	}
+
+	var res struct {
+		Secrets []ThingSecret `json:"secrets"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return []ThingSecret{}, errors.NewSDKError(err)
+	}
+
+	return res.Secrets, nil
+}
+
+func (sdk mfSDK) UpdateThingSecretExpiry(id, name, duration, token string) (ThingSecret, errors.SDKError) {
+	data, err := json.Marshal(updateThingSecretExpiryReq{Duration: duration})
+	if err != nil {
+		return ThingSecret{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/secrets/%s", sdk.thingsURL, thingsEndpoint, id, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPatch, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return ThingSecret{}, sdkerr
+	}
+
+	var ts ThingSecret
+	if err := json.Unmarshal(body, &ts); err != nil {
+		return ThingSecret{}, errors.NewSDKError(err)
+	}
+
+	return ts, nil
+}
+
+func (sdk mfSDK) RemoveThingSecret(id, name, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s/secrets/%s", sdk.thingsURL, thingsEndpoint, id, name)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
 func (sdk mfSDK) changeThingStatus(id, status, token string) (Thing, errors.SDKError) {
 	url := fmt.Sprintf("%s/%s/%s/%s", sdk.thingsURL, thingsEndpoint, id, status)
 
@@ -261,3 +412,35 @@ func (sdk mfSDK) ShareThing(groupID, userID string, actions []string, token stri
 
 	return sdk.CreateThingPolicy(policy, token)
 }
+
+func (sdk mfSDK) ExecuteThingOperations(ops []ThingOperation, token string) (OperationsReport, errors.SDKError) {
+	data, err := json.Marshal(struct {
+		Operations []ThingOperation `json:"operations"`
+	}{ops})
+	if err != nil {
+		return OperationsReport{}, errors.NewSDKError(err)
+	}
+
+	return sdk.executeThingOperations(data, CTJSON, token)
+}
+
+func (sdk mfSDK) ExecuteThingOperationsCSV(csv []byte, token string) (OperationsReport, errors.SDKError) {
+	return sdk.executeThingOperations(csv, CTCSV, token)
+}
+
+func (sdk mfSDK) executeThingOperations(data []byte, ct ContentType, token string) (OperationsReport, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/bulk/operations", sdk.thingsURL, thingsEndpoint)
+	headers := map[string]string{"Content-Type": string(ct)}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, headers, http.StatusOK)
+	if sdkerr != nil {
+		return OperationsReport{}, sdkerr
+	}
+
+	var report OperationsReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return OperationsReport{}, errors.NewSDKError(err)
+	}
+
+	return report, nil
+}
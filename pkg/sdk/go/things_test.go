@@ -17,10 +17,14 @@ import (
 	"github.com/mainflux/mainflux/things/clients"
 	"github.com/mainflux/mainflux/things/clients/api"
 	"github.com/mainflux/mainflux/things/clients/mocks"
+	cpostgres "github.com/mainflux/mainflux/things/clients/postgres"
 	gmocks "github.com/mainflux/mainflux/things/groups/mocks"
 	"github.com/mainflux/mainflux/things/policies"
 	papi "github.com/mainflux/mainflux/things/policies/api/http"
 	pmocks "github.com/mainflux/mainflux/things/policies/mocks"
+	"github.com/mainflux/mainflux/things/presence"
+	prapi "github.com/mainflux/mainflux/things/presence/api"
+	prmocks "github.com/mainflux/mainflux/things/presence/mocks"
 	cmocks "github.com/mainflux/mainflux/users/clients/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -54,7 +58,7 @@ func TestCreateThing(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -207,7 +211,7 @@ func TestCreateThings(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -300,7 +304,7 @@ func TestListThings(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -342,6 +346,7 @@ func TestListThings(t *testing.T) {
 		ownerID    string
 		tag        string
 		metadata   sdk.Metadata
+		filter     string
 		err        errors.SDKError
 		response   []sdk.Thing
 	}{
@@ -455,6 +460,33 @@ func TestListThings(t *testing.T) {
 			response: []sdk.Thing{ths[50]},
 			err:      nil,
 		},
+		{
+			desc:     "list things with given filter",
+			token:    adminToken,
+			offset:   0,
+			limit:    1,
+			filter:   "metadata.name = 'thing_50' and name prefix 'thing' order by created_at desc",
+			response: []sdk.Thing{ths[40]},
+			err:      nil,
+		},
+		{
+			desc:     "list things offline for over an hour",
+			token:    adminToken,
+			offset:   0,
+			limit:    1,
+			filter:   "online = false and last_seen < 'now-1h'",
+			response: []sdk.Thing{ths[40]},
+			err:      nil,
+		},
+		{
+			desc:     "list things with invalid filter",
+			token:    adminToken,
+			offset:   0,
+			limit:    1,
+			filter:   "metadata.name ~ 'thing_50'",
+			response: nil,
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidFilter), http.StatusBadRequest),
+		},
 	}
 
 	for _, tc := range cases {
@@ -467,6 +499,7 @@ func TestListThings(t *testing.T) {
 			OwnerID:  tc.ownerID,
 			Metadata: tc.metadata,
 			Tag:      tc.tag,
+			Filter:   tc.filter,
 		}
 
 		repoCall := cRepo.On("RetrieveAll", mock.Anything, mock.Anything).Return(mfclients.ClientsPage{Page: convertClientPage(pm), Clients: convertThings(tc.response)}, tc.err)
@@ -487,7 +520,7 @@ func TestListThingsByChannel(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -629,7 +662,7 @@ func TestThing(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -697,6 +730,99 @@ func TestThing(t *testing.T) {
 	}
 }
 
+func TestThingPresence(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	gRepo := new(gmocks.Repository)
+	uauth := cmocks.NewAuthService(users, map[string][]cmocks.SubjectSet{adminID: {uadminPolicy}})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+
+	pRepo := new(pmocks.Repository)
+	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
+
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	prRepo := new(prmocks.Repository)
+	prsvc := presence.NewService(svc, prRepo, prmocks.NewCache(), time.Minute)
+
+	mux := bone.New()
+	prapi.MakeHandler(prsvc, mux, mflog.NewMock())
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		ThingsURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	thingID := generateUUID(t)
+	seen := presence.Presence{
+		ThingID:    thingID,
+		Online:     true,
+		LastSeen:   time.Now().UTC().Truncate(time.Microsecond),
+		Protocol:   "http",
+		RemoteAddr: "10.0.0.1:5000",
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		thingID  string
+		presence presence.Presence
+		repoErr  error
+		response sdk.ThingPresence
+		err      errors.SDKError
+	}{
+		{
+			desc:     "view presence of seen thing",
+			token:    adminToken,
+			thingID:  thingID,
+			presence: seen,
+			response: sdk.ThingPresence{
+				ThingID:    seen.ThingID,
+				Online:     seen.Online,
+				LastSeen:   seen.LastSeen,
+				Protocol:   seen.Protocol,
+				RemoteAddr: seen.RemoteAddr,
+			},
+			err: nil,
+		},
+		{
+			desc:     "view presence of never seen thing",
+			token:    adminToken,
+			thingID:  thingID,
+			repoErr:  errors.ErrNotFound,
+			response: sdk.ThingPresence{ThingID: thingID},
+			err:      nil,
+		},
+		{
+			desc:     "view presence with an invalid token",
+			token:    invalidToken,
+			thingID:  thingID,
+			response: sdk.ThingPresence{},
+			err:      errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "view presence with an empty token",
+			token:    "",
+			thingID:  thingID,
+			response: sdk.ThingPresence{},
+			err:      errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("RetrieveByID", mock.Anything, tc.thingID).Return(mfclients.Client{ID: tc.thingID}, nil)
+		repoCall2 := prRepo.On("Retrieve", mock.Anything, tc.thingID).Return(tc.presence, tc.repoErr)
+		p, err := mfsdk.ThingPresence(tc.thingID, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, p))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
 func TestUpdateThing(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	gRepo := new(gmocks.Repository)
@@ -707,7 +833,7 @@ func TestUpdateThing(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -800,7 +926,7 @@ func TestUpdateThingTags(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -892,7 +1018,7 @@ func TestUpdateThingSecret(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -967,7 +1093,7 @@ func TestUpdateThingOwner(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -1058,7 +1184,7 @@ func TestEnableThing(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -1193,7 +1319,7 @@ func TestDisableThing(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -1318,6 +1444,214 @@ func TestDisableThing(t *testing.T) {
 	}
 }
 
+func TestDeleteThing(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	gRepo := new(gmocks.Repository)
+	uauth := cmocks.NewAuthService(users, map[string][]cmocks.SubjectSet{adminID: {uadminPolicy}})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+
+	pRepo := new(pmocks.Repository)
+	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
+
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	ts := newThingsServer(svc, psvc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		ThingsURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	thing := sdk.Thing{ID: testsutil.GenerateUUID(t, idProvider), Credentials: sdk.Credentials{Identity: "client1@example.com", Secret: generateUUID(t)}, Status: mfclients.EnabledStatus.String()}
+
+	cases := []struct {
+		desc  string
+		id    string
+		token string
+		err   errors.SDKError
+	}{
+		{
+			desc:  "delete existing thing",
+			id:    thing.ID,
+			token: adminToken,
+			err:   nil,
+		},
+		{
+			desc:  "delete thing with invalid token",
+			id:    thing.ID,
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "delete non-existing thing",
+			id:    mocks.WrongID,
+			token: adminToken,
+			err:   errors.NewSDKErrorWithStatus(errors.ErrNotFound, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("RetrieveByID", mock.Anything, tc.id).Return(convertThing(thing), nil)
+		repoCall2 := cRepo.On("Delete", mock.Anything, tc.id).Return(nil)
+		repoCall3 := cRepo.On("RetrieveSecrets", mock.Anything, tc.id).Return([]cpostgres.Secret{}, nil)
+		err := mfsdk.DeleteThing(tc.id, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		if tc.err == nil {
+			ok := repoCall2.Parent.AssertCalled(t, "Delete", mock.Anything, tc.id)
+			assert.True(t, ok, fmt.Sprintf("Delete was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
+
+func TestAddThingSecret(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	gRepo := new(gmocks.Repository)
+	uauth := cmocks.NewAuthService(users, map[string][]cmocks.SubjectSet{adminID: {uadminPolicy}})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+
+	pRepo := new(pmocks.Repository)
+	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
+
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	ts := newThingsServer(svc, psvc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		ThingsURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	thingID := generateUUID(t)
+	named := cpostgres.Secret{ClientID: thingID, Name: "backup", Secret: generateUUID(t)}
+
+	cases := []struct {
+		desc     string
+		secret   sdk.ThingSecret
+		token    string
+		response sdk.ThingSecret
+		repoErr  error
+		err      errors.SDKError
+	}{
+		{
+			desc:     "add thing secret",
+			secret:   sdk.ThingSecret{Name: named.Name, Secret: named.Secret},
+			token:    adminToken,
+			response: sdk.ThingSecret{ThingID: thingID, Name: named.Name, Secret: named.Secret},
+			err:      nil,
+		},
+		{
+			desc:     "add thing secret with invalid token",
+			secret:   sdk.ThingSecret{Name: named.Name},
+			token:    invalidToken,
+			response: sdk.ThingSecret{},
+			err:      errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "add thing secret without name",
+			secret:   sdk.ThingSecret{},
+			token:    adminToken,
+			response: sdk.ThingSecret{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrNameSize), http.StatusBadRequest),
+		},
+		{
+			desc:     "add thing secret with invalid duration",
+			secret:   sdk.ThingSecret{Name: named.Name, Duration: "-1h"},
+			token:    adminToken,
+			response: sdk.ThingSecret{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, errors.ErrMalformedEntity), http.StatusBadRequest),
+		},
+		{
+			desc:     "add already existing thing secret",
+			secret:   sdk.ThingSecret{Name: named.Name},
+			token:    adminToken,
+			response: sdk.ThingSecret{},
+			repoErr:  errors.ErrConflict,
+			err:      errors.NewSDKErrorWithStatus(errors.ErrConflict, http.StatusConflict),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("SaveSecret", mock.Anything, mock.Anything).Return(named, tc.repoErr)
+		secret, err := mfsdk.AddThingSecret(thingID, tc.secret, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, secret, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, secret))
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestRemoveThingSecret(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	gRepo := new(gmocks.Repository)
+	uauth := cmocks.NewAuthService(users, map[string][]cmocks.SubjectSet{adminID: {uadminPolicy}})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+
+	pRepo := new(pmocks.Repository)
+	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
+
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	ts := newThingsServer(svc, psvc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		ThingsURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	thingID := generateUUID(t)
+	named := cpostgres.Secret{ClientID: thingID, Name: "backup", Secret: generateUUID(t)}
+
+	cases := []struct {
+		desc    string
+		name    string
+		token   string
+		repoErr error
+		err     errors.SDKError
+	}{
+		{
+			desc:  "remove thing secret",
+			name:  named.Name,
+			token: adminToken,
+			err:   nil,
+		},
+		{
+			desc:  "remove thing secret with invalid token",
+			name:  named.Name,
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:    "remove non-existing thing secret",
+			name:    "unknown",
+			token:   adminToken,
+			repoErr: errors.ErrNotFound,
+			err:     errors.NewSDKErrorWithStatus(errors.ErrNotFound, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("RemoveSecret", mock.Anything, thingID, tc.name).Return(named, tc.repoErr)
+		err := mfsdk.RemoveThingSecret(thingID, tc.name, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		if tc.err == nil {
+			ok := repoCall1.Parent.AssertCalled(t, "RemoveSecret", mock.Anything, thingID, tc.name)
+			assert.True(t, ok, fmt.Sprintf("RemoveSecret was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
 func TestIdentify(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	gRepo := new(gmocks.Repository)
@@ -1328,7 +1662,7 @@ func TestIdentify(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -1394,7 +1728,7 @@ func TestShareThing(t *testing.T) {
 	pRepo := new(pmocks.Repository)
 	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
 
-	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, idProvider)
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
 	ts := newThingsServer(svc, psvc)
 	defer ts.Close()
 
@@ -1452,3 +1786,107 @@ func TestShareThing(t *testing.T) {
 		repoCall3.Unset()
 	}
 }
+
+func TestExecuteThingOperations(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	gRepo := new(gmocks.Repository)
+	uauth := cmocks.NewAuthService(users, map[string][]cmocks.SubjectSet{adminID: {uadminPolicy}})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+
+	pRepo := new(pmocks.Repository)
+	psvc := policies.NewService(uauth, pRepo, policiesCache, idProvider)
+
+	svc := clients.NewService(uauth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider)
+	ts := newThingsServer(svc, psvc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		ThingsURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	thingID := generateUUID(t)
+	cases := []struct {
+		desc   string
+		ops    []sdk.ThingOperation
+		csv    string
+		token  string
+		failed int
+		err    errors.SDKError
+	}{
+		{
+			desc: "execute thing operations",
+			ops: []sdk.ThingOperation{
+				{Op: sdk.CreateOp, Name: "thing1", Tags: []string{"tag1"}},
+				{Op: sdk.UpdateOp, ID: thingID, Metadata: map[string]interface{}{"floor": 2}},
+				{Op: sdk.DisableOp, ID: thingID},
+			},
+			token:  adminToken,
+			failed: 0,
+			err:    nil,
+		},
+		{
+			desc: "execute thing operations with failing rows",
+			ops: []sdk.ThingOperation{
+				{Op: sdk.CreateOp, Name: "thing1"},
+				{Op: sdk.UpdateOp, ID: mocks.WrongID, Name: "updated"},
+				{Op: sdk.EnableOp},
+				{Op: "unknown", ID: thingID},
+			},
+			token:  adminToken,
+			failed: 3,
+			err:    nil,
+		},
+		{
+			desc:  "execute thing operations with invalid token",
+			ops:   []sdk.ThingOperation{{Op: sdk.CreateOp, Name: "thing1"}},
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "execute empty list of thing operations",
+			ops:   []sdk.ThingOperation{},
+			token: adminToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrEmptyList), http.StatusBadRequest),
+		},
+		{
+			desc:   "execute thing operations from CSV",
+			csv:    "op,id,name,tags,metadata\ncreate,,thing1,\"tag1,tag2\",\"{\"\"floor\"\": 2}\"\ndisable," + thingID + ",,,\n",
+			token:  adminToken,
+			failed: 0,
+			err:    nil,
+		},
+		{
+			desc:  "execute thing operations from CSV with unknown column",
+			csv:   "op,unknown\ncreate,value\n",
+			token: adminToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, errors.ErrMalformedEntity), http.StatusBadRequest),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("Execute", mock.Anything, mock.Anything).Return([]cpostgres.OperationResult{}, nil)
+		var report sdk.OperationsReport
+		var err errors.SDKError
+		switch tc.csv {
+		case "":
+			report, err = mfsdk.ExecuteThingOperations(tc.ops, tc.token)
+		default:
+			report, err = mfsdk.ExecuteThingOperationsCSV([]byte(tc.csv), tc.token)
+		}
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.failed, report.Failed, fmt.Sprintf("%s: expected %d failed operations got %d\n", tc.desc, tc.failed, report.Failed))
+			for i, res := range report.Results {
+				assert.Equal(t, i+1, res.Row, fmt.Sprintf("%s: expected row %d got %d\n", tc.desc, i+1, res.Row))
+				if res.Op == sdk.CreateOp && res.Error == "" {
+					assert.NotEmpty(t, res.Secret, fmt.Sprintf("%s: expected secret of created thing", tc.desc))
+				}
+			}
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
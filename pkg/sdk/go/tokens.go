@@ -55,3 +55,17 @@ func (sdk mfSDK) RefreshToken(token string) (Token, errors.SDKError) {
 
 	return t, nil
 }
+
+func (sdk mfSDK) RevokeToken(token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, revokeTokenEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, []byte{}, nil, http.StatusNoContent)
+	return sdkerr
+}
+
+func (sdk mfSDK) RevokeAllTokens(token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, revokeTokensEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, []byte{}, nil, http.StatusNoContent)
+	return sdkerr
+}
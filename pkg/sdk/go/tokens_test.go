@@ -12,6 +12,13 @@ import (
 	"github.com/mainflux/mainflux/users/clients"
 	"github.com/mainflux/mainflux/users/clients/mocks"
 	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwmocks "github.com/mainflux/mainflux/users/passwords/mocks"
 	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,9 +27,9 @@ import (
 func TestIssueToken(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -92,9 +99,9 @@ func TestIssueToken(t *testing.T) {
 func TestRefreshToken(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -127,15 +134,20 @@ func TestRefreshToken(t *testing.T) {
 		token string
 		err   errors.SDKError
 	}{
+		{
+			desc:  "refresh token for a valid access token",
+			token: token.AccessToken,
+			err:   errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+		},
 		{
 			desc:  "refresh token for a valid refresh token",
 			token: token.RefreshToken,
 			err:   nil,
 		},
 		{
-			desc:  "refresh token for a valid access token",
-			token: token.AccessToken,
-			err:   errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+			desc:  "refresh token for an already used refresh token",
+			token: token.RefreshToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, errors.ErrAuthentication), http.StatusUnauthorized),
 		},
 		{
 			desc:  "refresh token for an empty token",
@@ -155,3 +167,124 @@ func TestRefreshToken(t *testing.T) {
 		repoCall.Unset()
 	}
 }
+
+func TestRevokeToken(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	ts := newClientServer(svc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	user := sdk.User{
+		ID:   generateUUID(t),
+		Name: "revoketoken",
+		Credentials: sdk.Credentials{
+			Identity: "revoketoken",
+			Secret:   "secret",
+		},
+		Status: sdk.EnabledStatus,
+	}
+	rUser := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), user.Credentials.Identity).Return(convertClient(rUser), nil)
+	token, err := svc.IssueToken(context.Background(), user.Credentials.Identity, user.Credentials.Secret)
+	assert.True(t, errors.Contains(err, nil), fmt.Sprintf("Create token expected nil got %s\n", err))
+	repoCall.Unset()
+
+	cases := []struct {
+		desc  string
+		token string
+		err   errors.SDKError
+	}{
+		{
+			desc:  "revoke valid token",
+			token: token.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "revoke already revoked token",
+			token: token.RefreshToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, errors.ErrAuthentication), http.StatusUnauthorized),
+		},
+		{
+			desc:  "revoke empty token",
+			token: "",
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrBearerToken), http.StatusInternalServerError),
+		},
+	}
+	for _, tc := range cases {
+		err := mfsdk.RevokeToken(tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestRevokeAllTokens(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	ts := newClientServer(svc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	user := sdk.User{
+		ID:   generateUUID(t),
+		Name: "revokealltokens",
+		Credentials: sdk.Credentials{
+			Identity: "revokealltokens",
+			Secret:   "secret",
+		},
+		Status: sdk.EnabledStatus,
+	}
+	rUser := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), user.Credentials.Identity).Return(convertClient(rUser), nil)
+	token, err := svc.IssueToken(context.Background(), user.Credentials.Identity, user.Credentials.Secret)
+	assert.True(t, errors.Contains(err, nil), fmt.Sprintf("Create token expected nil got %s\n", err))
+	repoCall.Unset()
+
+	cases := []struct {
+		desc  string
+		token string
+		err   errors.SDKError
+	}{
+		{
+			desc:  "revoke all tokens with refresh token",
+			token: token.RefreshToken,
+			err:   errors.NewSDKErrorWithStatus(errors.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "revoke all tokens with invalid token",
+			token: invalidToken,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, sdk.ErrInvalidJWT), http.StatusUnauthorized),
+		},
+		{
+			desc:  "revoke all tokens with empty token",
+			token: "",
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrBearerToken), http.StatusInternalServerError),
+		},
+		{
+			desc:  "revoke all tokens with valid token",
+			token: token.AccessToken,
+			err:   nil,
+		},
+	}
+	for _, tc := range cases {
+		err := mfsdk.RevokeAllTokens(tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
@@ -13,8 +13,12 @@ const (
 	usersEndpoint         = "users"
 	enableEndpoint        = "enable"
 	disableEndpoint       = "disable"
+	unlockEndpoint        = "unlock"
+	verifyEndpoint        = "verify"
 	issueTokenEndpoint    = "tokens/issue"
 	refreshTokenEndpoint  = "tokens/refresh"
+	revokeTokenEndpoint   = "tokens/revoke"
+	revokeTokensEndpoint  = "tokens/revoke-all"
 	membersEndpoint       = "members"
 	PasswordResetEndpoint = "password"
 )
@@ -220,6 +224,28 @@ func (sdk mfSDK) ResetPassword(password, confPass, token string) errors.SDKError
 	return sdkerr
 }
 
+func (sdk mfSDK) VerifyUser(token string) (User, errors.SDKError) {
+	vur := verifyUserReq{Token: token}
+
+	data, err := json.Marshal(vur)
+	if err != nil {
+		return User{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, verifyEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, "", data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return User{}, sdkerr
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return User{}, errors.NewSDKError(err)
+	}
+
+	return user, nil
+}
+
 func (sdk mfSDK) UpdatePassword(oldPass, newPass, token string) (User, errors.SDKError) {
 	ucsr := updateClientSecretReq{OldSecret: oldPass, NewSecret: newPass}
 
@@ -272,6 +298,14 @@ func (sdk mfSDK) DisableUser(id, token string) (User, errors.SDKError) {
 	return sdk.changeClientStatus(token, id, disableEndpoint)
 }
 
+func (sdk mfSDK) UnlockUser(id, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, usersEndpoint, id, unlockEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
 func (sdk mfSDK) changeClientStatus(token, id, status string) (User, errors.SDKError) {
 	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, usersEndpoint, id, status)
 
@@ -19,10 +19,18 @@ import (
 	"github.com/mainflux/mainflux/users/clients/api"
 	"github.com/mainflux/mainflux/users/clients/mocks"
 	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwmocks "github.com/mainflux/mainflux/users/passwords/mocks"
 	"github.com/mainflux/mainflux/users/policies"
 	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var id = generateUUID(&testing.T{})
@@ -30,7 +38,7 @@ var id = generateUUID(&testing.T{})
 func newClientServer(svc clients.Service) *httptest.Server {
 	logger := mflog.NewMock()
 	mux := bone.New()
-	api.MakeHandler(svc, mux, logger, instanceID)
+	api.MakeHandler(svc, mux, logger, instanceID, nil)
 
 	return httptest.NewServer(mux)
 }
@@ -38,14 +46,14 @@ func newClientServer(svc clients.Service) *httptest.Server {
 func TestCreateClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
 	user := sdk.User{
-		Credentials: sdk.Credentials{Identity: "admin@example.com", Secret: "secret"},
+		Credentials: sdk.Credentials{Identity: "admin@example.com", Secret: "strongsecret"},
 		Status:      mfclients.EnabledStatus.String(),
 	}
 	conf := sdk.Config{
@@ -189,9 +197,9 @@ func TestCreateClient(t *testing.T) {
 func TestListClients(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -374,9 +382,9 @@ func TestListClients(t *testing.T) {
 func TestListMembers(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -516,9 +524,9 @@ func TestListMembers(t *testing.T) {
 func TestClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -594,9 +602,9 @@ func TestClient(t *testing.T) {
 func TestProfile(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -649,9 +657,9 @@ func TestProfile(t *testing.T) {
 func TestUpdateClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -741,9 +749,9 @@ func TestUpdateClient(t *testing.T) {
 func TestUpdateClientTags(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -833,9 +841,9 @@ func TestUpdateClientTags(t *testing.T) {
 func TestUpdateClientIdentity(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -923,9 +931,9 @@ func TestUpdateClientIdentity(t *testing.T) {
 func TestUpdateClientSecret(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -934,14 +942,22 @@ func TestUpdateClientSecret(t *testing.T) {
 	}
 	mfsdk := sdk.NewSDK(conf)
 
-	user.ID = generateUUID(t)
-	rclient := user
-	rclient.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	// Updating the secret revokes all the tokens of the client, so each
+	// case is run by a different client with its own token.
+	newClient := func() (sdk.User, string) {
+		rclient := user
+		rclient.ID = generateUUID(t)
+		rclient.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
 
-	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), user.Credentials.Identity).Return(convertClient(rclient), nil)
-	token, err := svc.IssueToken(context.Background(), user.Credentials.Identity, user.Credentials.Secret)
-	assert.Nil(t, err, fmt.Sprintf("Issue token expected nil got %s\n", err))
-	repoCall.Unset()
+		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), user.Credentials.Identity).Return(convertClient(rclient), nil)
+		defer repoCall.Unset()
+		token, err := svc.IssueToken(context.Background(), user.Credentials.Identity, user.Credentials.Secret)
+		assert.Nil(t, err, fmt.Sprintf("Issue token expected nil got %s\n", err))
+
+		return rclient, token.AccessToken
+	}
+	rclient, token := newClient()
+	_, wrongSecretToken := newClient()
 
 	cases := []struct {
 		desc      string
@@ -956,7 +972,7 @@ func TestUpdateClientSecret(t *testing.T) {
 			desc:      "update client secret with valid token",
 			oldSecret: user.Credentials.Secret,
 			newSecret: "newSecret",
-			token:     token.AccessToken,
+			token:     token,
 			response:  rclient,
 			repoErr:   nil,
 			err:       nil,
@@ -974,7 +990,7 @@ func TestUpdateClientSecret(t *testing.T) {
 			desc:      "update client secret with wrong old secret",
 			oldSecret: "oldSecret",
 			newSecret: "newSecret",
-			token:     token.AccessToken,
+			token:     wrongSecretToken,
 			response:  sdk.User{},
 			repoErr:   apiutil.ErrInvalidSecret,
 			err:       errors.NewSDKErrorWithStatus(apiutil.ErrInvalidSecret, http.StatusBadRequest),
@@ -982,14 +998,14 @@ func TestUpdateClientSecret(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		repoCall := cRepo.On("RetrieveByID", mock.Anything, user.ID).Return(convertClient(tc.response), tc.repoErr)
+		repoCall := cRepo.On("RetrieveByID", mock.Anything, mock.Anything).Return(convertClient(tc.response), tc.repoErr)
 		repoCall1 := cRepo.On("RetrieveByIdentity", mock.Anything, user.Credentials.Identity).Return(convertClient(tc.response), tc.repoErr)
 		repoCall2 := cRepo.On("UpdateSecret", mock.Anything, mock.Anything).Return(convertClient(tc.response), tc.repoErr)
 		uClient, err := mfsdk.UpdatePassword(tc.oldSecret, tc.newSecret, tc.token)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 		assert.Equal(t, tc.response, uClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, uClient))
 		if tc.err == nil {
-			ok := repoCall.Parent.AssertCalled(t, "RetrieveByID", mock.Anything, user.ID)
+			ok := repoCall.Parent.AssertCalled(t, "RetrieveByID", mock.Anything, tc.response.ID)
 			assert.True(t, ok, fmt.Sprintf("RetrieveByID was not called on %s", tc.desc))
 			ok = repoCall1.Parent.AssertCalled(t, "RetrieveByIdentity", mock.Anything, user.Credentials.Identity)
 			assert.True(t, ok, fmt.Sprintf("RetrieveByIdentity was not called on %s", tc.desc))
@@ -1005,9 +1021,9 @@ func TestUpdateClientSecret(t *testing.T) {
 func TestUpdateClientOwner(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -1094,9 +1110,9 @@ func TestUpdateClientOwner(t *testing.T) {
 func TestEnableClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -1224,9 +1240,9 @@ func TestEnableClient(t *testing.T) {
 func TestDisableClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 
-	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	ts := newClientServer(svc)
 	defer ts.Close()
 
@@ -1350,3 +1366,144 @@ func TestDisableClient(t *testing.T) {
 		repoCall1.Unset()
 	}
 }
+
+func TestUnlockClient(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	ts := newClientServer(svc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	client := sdk.User{ID: testsutil.GenerateUUID(t, idProvider), Credentials: sdk.Credentials{Identity: "client1@example.com", Secret: "password"}, Status: mfclients.EnabledStatus.String()}
+
+	cases := []struct {
+		desc     string
+		id       string
+		token    string
+		client   sdk.User
+		adminErr error
+		repoErr  error
+		err      errors.SDKError
+	}{
+		{
+			desc:   "unlock client",
+			id:     client.ID,
+			token:  generateValidToken(t, svc, cRepo),
+			client: client,
+			err:    nil,
+		},
+		{
+			desc:     "unlock client by non-admin",
+			id:       client.ID,
+			token:    generateValidToken(t, svc, cRepo),
+			client:   client,
+			adminErr: errors.ErrAuthorization,
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthorization, errors.ErrAuthorization), http.StatusForbidden),
+		},
+		{
+			desc:    "unlock non-existing client",
+			id:      mocks.WrongID,
+			token:   generateValidToken(t, svc, cRepo),
+			client:  sdk.User{},
+			repoErr: errors.ErrNotFound,
+			err:     errors.NewSDKErrorWithStatus(errors.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:   "unlock client with invalid token",
+			id:     client.ID,
+			token:  invalidToken,
+			client: client,
+			err:    errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, sdk.ErrInvalidJWT), http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", mock.Anything, mock.Anything).Return(tc.adminErr)
+		repoCall1 := cRepo.On("RetrieveByID", mock.Anything, tc.id).Return(convertClient(tc.client), tc.repoErr)
+		err := mfsdk.UnlockUser(tc.id, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestVerifyUser(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	rc := clients.RegistrationConfig{Open: true, Expiry: time.Hour}
+
+	svc := clients.NewService(cRepo, pRepo, tokenizer, emailer, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), rc)
+	ts := newClientServer(svc)
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mfsdk := sdk.NewSDK(conf)
+
+	pending := sdk.User{ID: testsutil.GenerateUUID(t, idProvider), Credentials: sdk.Credentials{Identity: "pending@example.com"}, Status: mfclients.PendingStatus.String()}
+	enabled := pending
+	enabled.Status = mfclients.EnabledStatus.String()
+	claims := jwt.Claims{ClientID: pending.ID, Email: pending.Credentials.Identity}
+	token, err := tokenizer.Verification(context.Background(), claims, rc.Expiry)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		client   sdk.User
+		response sdk.User
+		err      errors.SDKError
+	}{
+		{
+			desc:     "verify pending user",
+			token:    token,
+			client:   pending,
+			response: enabled,
+			err:      nil,
+		},
+		{
+			desc:     "verify enabled user",
+			token:    token,
+			client:   enabled,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrConflict, mfclients.ErrStatusAlreadyAssigned), http.StatusConflict),
+		},
+		{
+			desc:     "verify user with invalid token",
+			token:    invalidToken,
+			client:   pending,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(errors.ErrAuthentication, errors.ErrAuthentication), http.StatusUnauthorized),
+		},
+		{
+			desc:     "verify user with empty token",
+			token:    "",
+			client:   pending,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrBearerToken), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByID", mock.Anything, pending.ID).Return(convertClient(tc.client), nil)
+		repoCall1 := cRepo.On("ChangeStatus", mock.Anything, mock.Anything).Return(convertClient(tc.response), nil)
+		vClient, err := mfsdk.VerifyUser(tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, vClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, vClient))
+		if tc.err == nil {
+			ok := repoCall1.Parent.AssertCalled(t, "ChangeStatus", mock.Anything, mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("ChangeStatus was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"math"
+	"sort"
+)
+
+type bucketKey struct {
+	time      float64
+	name      string
+	publisher string
+}
+
+type bucket struct {
+	min   float64
+	max   float64
+	sum   float64
+	count uint64
+}
+
+// Aggregator folds SenML values into time buckets. It is used by the
+// repositories which are not able to group values by time buckets natively.
+type Aggregator struct {
+	am       AggregationMetadata
+	interval float64
+	buckets  map[bucketKey]*bucket
+}
+
+// NewAggregator returns new aggregator for the given aggregation metadata.
+func NewAggregator(am AggregationMetadata) (*Aggregator, error) {
+	interval, err := ParseInterval(am.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Aggregator{
+		am:       am,
+		interval: float64(interval),
+		buckets:  make(map[bucketKey]*bucket),
+	}, nil
+}
+
+// Add adds the value measured at the given time to the matching bucket.
+func (a *Aggregator) Add(name, publisher string, t, v float64) {
+	key := bucketKey{
+		time: math.Floor(t/a.interval) * a.interval,
+		name: name,
+	}
+	if a.am.ByPublisher {
+		key.publisher = publisher
+	}
+
+	b, ok := a.buckets[key]
+	if !ok {
+		a.buckets[key] = &bucket{min: v, max: v, sum: v, count: 1}
+		return
+	}
+	b.min = math.Min(b.min, v)
+	b.max = math.Max(b.max, v)
+	b.sum += v
+	b.count++
+}
+
+// Page returns aggregates ordered by the bucket time in descending order,
+// paginated using the aggregation metadata offset and limit.
+func (a *Aggregator) Page() AggregatesPage {
+	aggs := make([]Aggregate, 0, len(a.buckets))
+	for key, b := range a.buckets {
+		agg := Aggregate{
+			Time:      key.time,
+			Name:      key.name,
+			Publisher: key.publisher,
+		}
+		switch a.am.Aggregation {
+		case MinAggregation:
+			agg.Value = b.min
+		case MaxAggregation:
+			agg.Value = b.max
+		case SumAggregation:
+			agg.Value = b.sum
+		case CountAggregation:
+			agg.Value = float64(b.count)
+		default:
+			agg.Value = b.sum / float64(b.count)
+		}
+		aggs = append(aggs, agg)
+	}
+
+	sort.Slice(aggs, func(i, j int) bool {
+		if aggs[i].Time != aggs[j].Time {
+			return aggs[i].Time > aggs[j].Time
+		}
+		if aggs[i].Name != aggs[j].Name {
+			return aggs[i].Name < aggs[j].Name
+		}
+		return aggs[i].Publisher < aggs[j].Publisher
+	})
+
+	page := AggregatesPage{
+		AggregationMetadata: a.am,
+		Aggregates:          []Aggregate{},
+	}
+
+	total := uint64(len(aggs))
+	if a.am.Offset >= total {
+		return page
+	}
+	end := a.am.Offset + a.am.Limit
+	if end > total {
+		end = total
+	}
+	page.Aggregates = aggs[a.am.Offset:end]
+
+	return page
+}
@@ -21,7 +21,7 @@ func listMessagesEndpoint(svc readers.MessageRepository, tc tpolicies.AuthServic
 		if err := req.validate(); err != nil {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
-		if err := authorize(ctx, req, tc, ac); err != nil {
+		if err := authorize(ctx, req.token, req.key, req.chanID, tc, ac); err != nil {
 			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrAuthorization, err))
 		}
 		page, err := svc.ReadAll(req.chanID, req.pageMeta)
@@ -32,7 +32,109 @@ func listMessagesEndpoint(svc readers.MessageRepository, tc tpolicies.AuthServic
 		return pageRes{
 			PageMetadata: page.PageMetadata,
 			Total:        page.Total,
+			NextCursor:   page.NextCursor,
 			Messages:     page.Messages,
 		}, nil
 	}
 }
+
+func listChannelsMessagesEndpoint(svc readers.MessageRepository, groups readers.ChannelGroups, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listChannelsMessagesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		chanIDs := req.chanIDs
+		if req.groupID != "" {
+			ids, err := groups.Channels(req.token, req.groupID)
+			if err != nil {
+				return nil, err
+			}
+			chanIDs = unique(append(chanIDs, ids...))
+		}
+		// Access is checked per channel, so the expanded group is
+		// limited the same way as the listed channels are.
+		if len(chanIDs) > maxChannelsSize {
+			return nil, errors.Wrap(apiutil.ErrValidation, errTooManyChannels)
+		}
+		if len(chanIDs) == 0 {
+			return pageRes{
+				PageMetadata: req.pageMeta,
+				Messages:     []readers.Message{},
+			}, nil
+		}
+
+		if err := authorizeChannels(ctx, req.token, req.key, chanIDs, tc, ac); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrAuthorization, err))
+		}
+		page, err := svc.ReadChannels(chanIDs, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return pageRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			NextCursor:   page.NextCursor,
+			Messages:     page.Messages,
+		}, nil
+	}
+}
+
+func listAggregatesEndpoint(svc readers.MessageRepository, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAggregatesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := authorize(ctx, req.token, req.key, req.chanID, tc, ac); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrAuthorization, err))
+		}
+		page, err := svc.ReadAggregates(req.chanID, req.aggMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return aggregatesPageRes{
+			AggregationMetadata: page.AggregationMetadata,
+			Aggregates:          page.Aggregates,
+		}, nil
+	}
+}
+
+func exportMessagesEndpoint(svc readers.MessageRepository, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportMessagesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := authorize(ctx, req.token, req.key, req.chanID, tc, ac); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrAuthorization, err))
+		}
+
+		return exportRes{
+			contentType: req.contentType,
+			senml:       req.pageMeta.Format == "" || req.pageMeta.Format == defFormat,
+			walk: func(fn func([]readers.Message) error) error {
+				return readers.Walk(svc, req.chanID, req.pageMeta, fn)
+			},
+		}, nil
+	}
+}
+
+func unique(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	var ret []string
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			ret = append(ret, id)
+		}
+	}
+
+	return ret
+}
@@ -4,10 +4,13 @@
 package api_test
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -50,7 +53,11 @@ var (
 )
 
 func newServer(repo readers.MessageRepository, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient) *httptest.Server {
-	mux := api.MakeHandler(repo, tc, ac, svcName, instanceID)
+	return newGroupsServer(repo, mocks.NewChannelGroups(map[string][]string{}), tc, ac)
+}
+
+func newGroupsServer(repo readers.MessageRepository, groups readers.ChannelGroups, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient) *httptest.Server {
+	mux := api.MakeHandler(repo, groups, tc, ac, svcName, instanceID)
 	return httptest.NewServer(mux)
 }
 
@@ -60,6 +67,7 @@ type testRequest struct {
 	url    string
 	token  string
 	key    string
+	accept string
 }
 
 func (tr testRequest) make() (*http.Response, error) {
@@ -67,6 +75,9 @@ func (tr testRequest) make() (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
+	if tr.accept != "" {
+		req.Header.Set("Accept", tr.accept)
+	}
 	if tr.token != "" {
 		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
 	}
@@ -745,10 +756,735 @@ func TestReadAll(t *testing.T) {
 	}
 }
 
+func TestReadAllWithCursor(t *testing.T) {
+	chanID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+		})
+	}
+
+	thSvc := mocks.NewThingsService(map[string]string{email: chanID})
+	mockAuthzDB := map[string][]authmocks.SubjectSet{}
+	mockAuthzDB["token"] = append(mockAuthzDB[email], authmocks.SubjectSet{Subject: "token", Relation: adminRelationKeys})
+
+	usrSvc := authmocks.NewAuthService(map[string]string{userToken: email}, mockAuthzDB)
+
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, thSvc, usrSvc)
+	defer ts.Close()
+
+	// Walk all the pages following the next cursor.
+	var read []senml.Message
+	cursor := ""
+	for pages := 0; pages <= numOfMessages/30+1; pages++ {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=30&skip_total=true&cursor=%s", ts.URL, chanID, cursor),
+			token:  userToken,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected %d got %d", http.StatusOK, res.StatusCode))
+
+		var page pageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error while decoding response body: %s", err))
+		assert.Equal(t, uint64(0), page.Total, "expected total to be skipped")
+
+		read = append(read, page.Messages...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.ElementsMatch(t, messages, read, "got incorrect messages walking the pages by cursor")
+
+	cases := []struct {
+		desc   string
+		url    string
+		status int
+	}{
+		{
+			desc:   "read page with invalid cursor",
+			url:    fmt.Sprintf("%s/channels/%s/messages?cursor=invalid", ts.URL, chanID),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with both cursor and offset",
+			url:    fmt.Sprintf("%s/channels/%s/messages?cursor=%s&offset=10", ts.URL, chanID, cursor),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with invalid skip total",
+			url:    fmt.Sprintf("%s/channels/%s/messages?skip_total=invalid", ts.URL, chanID),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  userToken,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestReadChannels(t *testing.T) {
+	chanIDs := make([]string, 3)
+	for i := range chanIDs {
+		id, err := idProvider.ID()
+		assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		chanIDs[i] = id
+	}
+	pubID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	groupID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	emptyGroupID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	largeGroupID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	messages := map[string][]readers.Message{}
+	var all []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		chanID := chanIDs[i%len(chanIDs)]
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+		}
+		messages[chanID] = append(messages[chanID], msg)
+		all = append(all, msg)
+	}
+	var firstTwo []senml.Message
+	for _, msg := range all {
+		if msg.Channel != chanIDs[2] {
+			firstTwo = append(firstTwo, msg)
+		}
+	}
+
+	thSvc := mocks.NewThingsService(map[string]string{email: chanIDs[0]})
+	mockAuthzDB := map[string][]authmocks.SubjectSet{}
+	mockAuthzDB["token"] = append(mockAuthzDB[email], authmocks.SubjectSet{Subject: "token", Relation: adminRelationKeys})
+	usrSvc := authmocks.NewAuthService(map[string]string{userToken: email}, mockAuthzDB)
+
+	repo := mocks.NewMultiChannelRepository(messages)
+	largeGroup := make([]string, 101)
+	for i := range largeGroup {
+		largeGroup[i], err = idProvider.ID()
+		assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+	groups := mocks.NewChannelGroups(map[string][]string{
+		groupID:      chanIDs,
+		emptyGroupID: {},
+		largeGroupID: largeGroup,
+	})
+	ts := newGroupsServer(repo, groups, thSvc, usrSvc)
+	defer ts.Close()
+
+	manyChannels := make([]string, 101)
+	for i := range manyChannels {
+		manyChannels[i] = chanIDs[0]
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		key    string
+		status int
+		res    pageRes
+	}{
+		{
+			desc:   "read messages of multiple channels",
+			url:    fmt.Sprintf("%s/messages?channels=%s,%s&limit=%d", ts.URL, chanIDs[0], chanIDs[1], len(firstTwo)),
+			token:  userToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(firstTwo)),
+				Messages: firstTwo,
+			},
+		},
+		{
+			desc:   "read messages of multiple channels using thing key",
+			url:    fmt.Sprintf("%s/messages?channels=%s,%s&limit=10", ts.URL, chanIDs[0], chanIDs[1]),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(firstTwo)),
+				Messages: firstTwo[0:10],
+			},
+		},
+		{
+			desc:   "read messages of channel group",
+			url:    fmt.Sprintf("%s/messages?group=%s&limit=%d", ts.URL, groupID, numOfMessages),
+			token:  userToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(all)),
+				Messages: all,
+			},
+		},
+		{
+			desc:   "read messages of channel group and channels",
+			url:    fmt.Sprintf("%s/messages?group=%s&channels=%s&offset=10&limit=10", ts.URL, groupID, chanIDs[0]),
+			token:  userToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(all)),
+				Messages: all[10:20],
+			},
+		},
+		{
+			desc:   "read messages of empty channel group",
+			url:    fmt.Sprintf("%s/messages?group=%s", ts.URL, emptyGroupID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total: 0,
+			},
+		},
+		{
+			desc:   "read messages of channel group using thing key",
+			url:    fmt.Sprintf("%s/messages?group=%s", ts.URL, groupID),
+			key:    thingToken,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read messages of channel group with too many channels",
+			url:    fmt.Sprintf("%s/messages?group=%s", ts.URL, largeGroupID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages of unknown channel group",
+			url:    fmt.Sprintf("%s/messages?group=%s", ts.URL, invalid),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read messages without channels",
+			url:    fmt.Sprintf("%s/messages", ts.URL),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages of too many channels",
+			url:    fmt.Sprintf("%s/messages?channels=%s", ts.URL, strings.Join(manyChannels, ",")),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages of multiple channels with invalid token",
+			url:    fmt.Sprintf("%s/messages?channels=%s,%s", ts.URL, chanIDs[0], chanIDs[1]),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read messages of multiple channels without token",
+			url:    fmt.Sprintf("%s/messages?channels=%s,%s", ts.URL, chanIDs[0], chanIDs[1]),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page pageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		assert.Equal(t, tc.res.Total, page.Total, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.res.Total, page.Total))
+		assert.Equal(t, tc.res.Messages, page.Messages, fmt.Sprintf("%s: got incorrect list of messages", tc.desc))
+	}
+}
+
+func TestReadAggregates(t *testing.T) {
+	chanID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Align start to the hour so that every 10 minutes bucket holds 10 messages.
+	start := float64(time.Now().Unix() / 3600 * 3600)
+	var messages []senml.Message
+	for i := 0; i < 20; i++ {
+		val := float64(i)
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      start + float64(i*60),
+			Value:     &val,
+		}
+		if i%2 == 1 {
+			msg.Publisher = pubID2
+		}
+		messages = append(messages, msg)
+	}
+	// Messages without numeric value are not aggregated.
+	messages = append(messages, senml.Message{
+		Channel:     chanID,
+		Publisher:   pubID,
+		Name:        msgName,
+		Time:        start,
+		StringValue: &vs,
+	})
+
+	thSvc := mocks.NewThingsService(map[string]string{email: chanID})
+	mockAuthzDB := map[string][]authmocks.SubjectSet{}
+	mockAuthzDB["token"] = append(mockAuthzDB[email], authmocks.SubjectSet{Subject: "token", Relation: adminRelationKeys})
+
+	usrSvc := authmocks.NewAuthService(map[string]string{userToken: email}, mockAuthzDB)
+
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, thSvc, usrSvc)
+	defer ts.Close()
+
+	first, second := start, start+600
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		key    string
+		status int
+		res    []readers.Aggregate
+	}{
+		{
+			desc:   "read average aggregates as thing",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=avg&interval=10m", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 14.5},
+				{Time: first, Name: msgName, Value: 4.5},
+			},
+		},
+		{
+			desc:   "read min aggregates as user",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=min&interval=10m", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 10},
+				{Time: first, Name: msgName, Value: 0},
+			},
+		},
+		{
+			desc:   "read max aggregates as user",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=max&interval=10m", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 19},
+				{Time: first, Name: msgName, Value: 9},
+			},
+		},
+		{
+			desc:   "read sum aggregates as user",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=sum&interval=10m", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 145},
+				{Time: first, Name: msgName, Value: 45},
+			},
+		},
+		{
+			desc:   "read count aggregates as user",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=count&interval=10m", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 10},
+				{Time: first, Name: msgName, Value: 10},
+			},
+		},
+		{
+			desc:   "read aggregates grouped by publisher as user",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=count&interval=10m&group_by=publisher", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Publisher: pubID, Value: 5},
+				{Time: second, Name: msgName, Publisher: pubID2, Value: 5},
+				{Time: first, Name: msgName, Publisher: pubID, Value: 5},
+				{Time: first, Name: msgName, Publisher: pubID2, Value: 5},
+			},
+		},
+		{
+			desc:   "read aggregates filtered by publisher as user",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=count&interval=10m&publisher=%s", ts.URL, chanID, pubID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 5},
+				{Time: first, Name: msgName, Value: 5},
+			},
+		},
+		{
+			desc:   "read aggregates with from/to as user",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=count&interval=10m&from=%f&to=%f", ts.URL, chanID, start+300, start+900),
+			token:  userToken,
+			status: http.StatusOK,
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 5},
+				{Time: first, Name: msgName, Value: 5},
+			},
+		},
+		{
+			desc:   "read aggregates with limit and offset as user",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=avg&interval=10m&offset=1&limit=1", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: []readers.Aggregate{
+				{Time: first, Name: msgName, Value: 4.5},
+			},
+		},
+		{
+			desc:   "read aggregates with invalid aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=median&interval=10m", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read aggregates with invalid interval",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=avg&interval=ten", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read aggregates with sub-second interval",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=avg&interval=500ms", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read aggregates with invalid group by",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=avg&interval=10m&group_by=subtopic", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read aggregates with invalid limit",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=avg&interval=10m&limit=0", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read aggregates with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=avg&interval=10m", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read aggregates with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/aggregates?aggregation=avg&interval=10m", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var page aggregatesPageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.ElementsMatch(t, tc.res, page.Aggregates, fmt.Sprintf("%s: got incorrect body from response", tc.desc))
+	}
+}
+
+func TestExportMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	// Export more than a single chunk to make sure all the pages are walked.
+	var messages []senml.Message
+	for i := 0; i < 2500; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+		}
+		if i%2 == 0 {
+			msg.Subtopic = subtopic
+		}
+		messages = append(messages, msg)
+	}
+
+	thSvc := mocks.NewThingsService(map[string]string{email: chanID})
+	mockAuthzDB := map[string][]authmocks.SubjectSet{}
+	mockAuthzDB["token"] = append(mockAuthzDB[email], authmocks.SubjectSet{Subject: "token", Relation: adminRelationKeys})
+
+	usrSvc := authmocks.NewAuthService(map[string]string{userToken: email}, mockAuthzDB)
+
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, thSvc, usrSvc)
+	defer ts.Close()
+
+	var subtopicMsgs []senml.Message
+	for _, m := range messages {
+		if m.Subtopic == subtopic {
+			subtopicMsgs = append(subtopicMsgs, m)
+		}
+	}
+
+	cases := []struct {
+		desc        string
+		url         string
+		token       string
+		key         string
+		accept      string
+		status      int
+		contentType string
+		res         []senml.Message
+	}{
+		{
+			desc:        "export messages as NDJSON by default",
+			url:         fmt.Sprintf("%s/channels/%s/export", ts.URL, chanID),
+			token:       userToken,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			res:         messages,
+		},
+		{
+			desc:        "export messages as NDJSON as thing",
+			url:         fmt.Sprintf("%s/channels/%s/export", ts.URL, chanID),
+			key:         thingToken,
+			accept:      "application/x-ndjson",
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			res:         messages,
+		},
+		{
+			desc:        "export messages as CSV",
+			url:         fmt.Sprintf("%s/channels/%s/export", ts.URL, chanID),
+			token:       userToken,
+			accept:      "text/csv",
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			res:         messages,
+		},
+		{
+			desc:        "export filtered messages as CSV",
+			url:         fmt.Sprintf("%s/channels/%s/export?subtopic=%s", ts.URL, chanID, subtopic),
+			token:       userToken,
+			accept:      "text/csv",
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			res:         subtopicMsgs,
+		},
+		{
+			desc:   "export messages with unsupported content type",
+			url:    fmt.Sprintf("%s/channels/%s/export", ts.URL, chanID),
+			token:  userToken,
+			accept: "application/xml",
+			status: http.StatusNotAcceptable,
+		},
+		{
+			desc:   "export messages with invalid comparator",
+			url:    fmt.Sprintf("%s/channels/%s/export?v=5&comparator=invalid", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid cursor",
+			url:    fmt.Sprintf("%s/channels/%s/export?cursor=invalid", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/export", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "export messages with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/export", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+			accept: tc.accept,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		assert.Equal(t, tc.contentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: got unexpected content type", tc.desc))
+
+		var msgs []senml.Message
+		switch tc.contentType {
+		case "text/csv":
+			rows, err := csv.NewReader(res.Body).ReadAll()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while reading CSV: %s", tc.desc, err))
+			assert.Equal(t, len(tc.res)+1, len(rows), fmt.Sprintf("%s: expected %d rows got %d", tc.desc, len(tc.res)+1, len(rows)))
+			assert.Equal(t, "channel", rows[0][0], fmt.Sprintf("%s: expected CSV header", tc.desc))
+			for _, row := range rows[1:] {
+				msgs = append(msgs, fromCSV(t, row))
+			}
+		default:
+			dec := json.NewDecoder(res.Body)
+			for dec.More() {
+				var msg senml.Message
+				err := dec.Decode(&msg)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding NDJSON: %s", tc.desc, err))
+				msgs = append(msgs, msg)
+			}
+		}
+		assert.ElementsMatch(t, tc.res, msgs, fmt.Sprintf("%s: got incorrect exported messages", tc.desc))
+	}
+}
+
+// failingRepository fails to read any page but the first one.
+type failingRepository struct {
+	readers.MessageRepository
+}
+
+func (repo failingRepository) ReadAll(chanID string, pm readers.PageMetadata) (readers.MessagesPage, error) {
+	if pm.Cursor != "" {
+		return readers.MessagesPage{}, readers.ErrReadMessages
+	}
+	return repo.MessageRepository.ReadAll(chanID, pm)
+}
+
+func TestExportMessagesInterrupted(t *testing.T) {
+	chanID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var messages []senml.Message
+	for i := 0; i < 1500; i++ {
+		messages = append(messages, senml.Message{
+			Channel:  chanID,
+			Protocol: mqttProt,
+			Name:     msgName,
+			Time:     float64(now - int64(i)),
+			Value:    &v,
+		})
+	}
+
+	thSvc := mocks.NewThingsService(map[string]string{email: chanID})
+	repo := failingRepository{mocks.NewMessageRepository(chanID, fromSenml(messages))}
+	ts := newServer(repo, thSvc, authmocks.NewAuthService(map[string]string{userToken: email}, nil))
+	defer ts.Close()
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/channels/%s/export", ts.URL, chanID),
+		key:    thingToken,
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("export interrupted messages: unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("export interrupted messages: expected %d got %d", http.StatusOK, res.StatusCode))
+	assert.Empty(t, res.Trailer.Get("X-Export-Error"), "export interrupted messages: expected no error before reading the body")
+
+	dec := json.NewDecoder(res.Body)
+	var count int
+	for dec.More() {
+		var msg senml.Message
+		err := dec.Decode(&msg)
+		assert.Nil(t, err, fmt.Sprintf("export interrupted messages: unexpected error while decoding NDJSON: %s", err))
+		count++
+	}
+	assert.Less(t, count, len(messages), "export interrupted messages: expected partial export")
+	assert.Contains(t, res.Trailer.Get("X-Export-Error"), readers.ErrReadMessages.Error(), "export interrupted messages: expected error trailer")
+}
+
+func fromCSV(t *testing.T, row []string) senml.Message {
+	tm, err := strconv.ParseFloat(row[6], 64)
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	msg := senml.Message{
+		Channel:   row[0],
+		Subtopic:  row[1],
+		Publisher: row[2],
+		Protocol:  row[3],
+		Name:      row[4],
+		Unit:      row[5],
+		Time:      tm,
+	}
+	if row[8] != "" {
+		val, err := strconv.ParseFloat(row[8], 64)
+		assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		msg.Value = &val
+	}
+	return msg
+}
+
+type aggregatesPageRes struct {
+	readers.AggregationMetadata
+	Aggregates []readers.Aggregate `json:"aggregates"`
+}
+
 type pageRes struct {
 	readers.PageMetadata
-	Total    uint64          `json:"total"`
-	Messages []senml.Message `json:"messages,omitempty"`
+	Total      uint64          `json:"total"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	Messages   []senml.Message `json:"messages,omitempty"`
 }
 
 func fromSenml(in []senml.Message) []readers.Message {
@@ -40,3 +40,29 @@ func (lm *loggingMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (p
 
 	return lm.svc.ReadAll(chanID, rpm)
 }
+
+func (lm *loggingMiddleware) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (page readers.MessagesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method read_channels for channels %v with query %v took %s to complete", chanIDs, rpm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ReadChannels(chanIDs, rpm)
+}
+
+func (lm *loggingMiddleware) ReadAggregates(chanID string, ram readers.AggregationMetadata) (page readers.AggregatesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method read_aggregates for channel %s with query %v took %s to complete", chanID, ram, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ReadAggregates(chanID, ram)
+}
@@ -37,3 +37,21 @@ func (mm *metricsMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (r
 
 	return mm.svc.ReadAll(chanID, rpm)
 }
+
+func (mm *metricsMiddleware) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "read_channels").Add(1)
+		mm.latency.With("method", "read_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ReadChannels(chanIDs, rpm)
+}
+
+func (mm *metricsMiddleware) ReadAggregates(chanID string, ram readers.AggregationMetadata) (readers.AggregatesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "read_aggregates").Add(1)
+		mm.latency.With("method", "read_aggregates").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ReadAggregates(chanID, ram)
+}
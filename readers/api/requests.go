@@ -8,7 +8,10 @@ import (
 	"github.com/mainflux/mainflux/readers"
 )
 
-const maxLimitSize = 1000
+const (
+	maxLimitSize    = 1000
+	maxChannelsSize = 100
+)
 
 type listMessagesReq struct {
 	chanID   string
@@ -30,12 +33,118 @@ func (req listMessagesReq) validate() error {
 		return apiutil.ErrLimitSize
 	}
 
-	if req.pageMeta.Comparator != "" &&
-		req.pageMeta.Comparator != readers.EqualKey &&
-		req.pageMeta.Comparator != readers.LowerThanKey &&
-		req.pageMeta.Comparator != readers.LowerThanEqualKey &&
-		req.pageMeta.Comparator != readers.GreaterThanKey &&
-		req.pageMeta.Comparator != readers.GreaterThanEqualKey {
+	if req.pageMeta.Cursor != "" && req.pageMeta.Offset != 0 {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return validateComparator(req.pageMeta.Comparator)
+}
+
+type listChannelsMessagesReq struct {
+	token    string
+	key      string
+	chanIDs  []string
+	groupID  string
+	pageMeta readers.PageMetadata
+}
+
+func (req listChannelsMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	// Channel group is resolved on behalf of the user.
+	if req.groupID != "" && req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.chanIDs) == 0 && req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.chanIDs) > maxChannelsSize {
+		return errTooManyChannels
+	}
+
+	if req.pageMeta.Limit < 1 || req.pageMeta.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	if req.pageMeta.Cursor != "" && req.pageMeta.Offset != 0 {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return validateComparator(req.pageMeta.Comparator)
+}
+
+type listAggregatesReq struct {
+	chanID  string
+	token   string
+	key     string
+	aggMeta readers.AggregationMetadata
+}
+
+func (req listAggregatesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.aggMeta.Limit < 1 || req.aggMeta.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	switch req.aggMeta.Aggregation {
+	case readers.MinAggregation,
+		readers.MaxAggregation,
+		readers.AvgAggregation,
+		readers.SumAggregation,
+		readers.CountAggregation:
+	default:
+		return apiutil.ErrInvalidAggregation
+	}
+
+	if _, err := readers.ParseInterval(req.aggMeta.Interval); err != nil {
+		return err
+	}
+
+	return validateComparator(req.aggMeta.Comparator)
+}
+
+type exportMessagesReq struct {
+	chanID      string
+	token       string
+	key         string
+	contentType string
+	pageMeta    readers.PageMetadata
+}
+
+func (req exportMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.contentType != csvContentType && req.contentType != ndjsonContentType {
+		return apiutil.ErrUnsupportedContentType
+	}
+
+	return validateComparator(req.pageMeta.Comparator)
+}
+
+func validateComparator(comparator string) error {
+	if comparator != "" &&
+		comparator != readers.EqualKey &&
+		comparator != readers.LowerThanKey &&
+		comparator != readers.LowerThanEqualKey &&
+		comparator != readers.GreaterThanKey &&
+		comparator != readers.GreaterThanEqualKey {
 		return apiutil.ErrInvalidComparator
 	}
 
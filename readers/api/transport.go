@@ -5,14 +5,19 @@ package api
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/internal/apiutil"
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
 	tpolicies "github.com/mainflux/mainflux/things/policies"
 	upolicies "github.com/mainflux/mainflux/users/policies"
@@ -37,18 +42,40 @@ const (
 	comparatorKey  = "comparator"
 	fromKey        = "from"
 	toKey          = "to"
+	cursorKey      = "cursor"
+	skipTotalKey   = "skip_total"
+	channelsKey    = "channels"
+	groupKey       = "group"
+	aggregationKey = "aggregation"
+	intervalKey    = "interval"
+	groupByKey     = "group_by"
 	defLimit       = 10
 	defOffset      = 0
 	defFormat      = "messages"
+	defAggregation = readers.AvgAggregation
+	defInterval    = "1h"
+	publisherGroup = "publisher"
 )
 
+// Content types supported by messages export.
+const (
+	csvContentType    = "text/csv"
+	ndjsonContentType = "application/x-ndjson"
+)
+
+// exportErrorTrailer reports the error which interrupted messages export
+// after the response status has already been sent.
+const exportErrorTrailer = "X-Export-Error"
+
 var (
 	errThingAccess = errors.New("thing has no permission")
 	errUserAccess  = errors.New("user has no permission")
+
+	errTooManyChannels = errors.New("too many channels requested")
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc readers.MessageRepository, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient, svcName, instanceID string) http.Handler {
+func MakeHandler(svc readers.MessageRepository, groups readers.ChannelGroups, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient, svcName, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
 	}
@@ -61,6 +88,27 @@ func MakeHandler(svc readers.MessageRepository, tc tpolicies.AuthServiceClient,
 		opts...,
 	))
 
+	mux.Get("/messages", kithttp.NewServer(
+		listChannelsMessagesEndpoint(svc, groups, tc, ac),
+		decodeListChannels,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/channels/:chanID/aggregates", kithttp.NewServer(
+		listAggregatesEndpoint(svc, tc, ac),
+		decodeAggregates,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/channels/:chanID/export", kithttp.NewServer(
+		exportMessagesEndpoint(svc, tc, ac),
+		decodeExport,
+		encodeExport,
+		opts...,
+	))
+
 	mux.GetFunc("/health", mainflux.Health(svcName, instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -68,99 +116,228 @@ func MakeHandler(svc readers.MessageRepository, tc tpolicies.AuthServiceClient,
 }
 
 func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
-	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	pm, err := decodePageMetadata(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listMessagesReq{
+		chanID:   bone.GetValue(r, "chanID"),
+		token:    apiutil.ExtractBearerToken(r),
+		key:      apiutil.ExtractThingKey(r),
+		pageMeta: pm,
+	}
+
+	return req, nil
+}
+
+func decodeListChannels(_ context.Context, r *http.Request) (interface{}, error) {
+	pm, err := decodePageMetadata(r)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := apiutil.ReadStringQuery(r, groupKey, "")
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	limit, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
+	req := listChannelsMessagesReq{
+		token:    apiutil.ExtractBearerToken(r),
+		key:      apiutil.ExtractThingKey(r),
+		groupID:  group,
+		pageMeta: pm,
+	}
+	// Channel IDs are passed either as a comma separated list or as
+	// multiple query parameters.
+	for _, id := range bone.GetQuery(r, channelsKey) {
+		if id = strings.TrimSpace(id); id != "" {
+			req.chanIDs = append(req.chanIDs, id)
+		}
+	}
+
+	return req, nil
+}
+
+func decodeAggregates(_ context.Context, r *http.Request) (interface{}, error) {
+	pm, err := decodePageMetadata(r)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregation, err := apiutil.ReadStringQuery(r, aggregationKey, defAggregation)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	format, err := apiutil.ReadStringQuery(r, formatKey, defFormat)
+	interval, err := apiutil.ReadStringQuery(r, intervalKey, defInterval)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
+	groupBy, err := apiutil.ReadStringQuery(r, groupByKey, "")
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	if groupBy != "" && groupBy != publisherGroup {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidQueryParams)
+	}
 
-	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
+	req := listAggregatesReq{
+		chanID: bone.GetValue(r, "chanID"),
+		token:  apiutil.ExtractBearerToken(r),
+		key:    apiutil.ExtractThingKey(r),
+		aggMeta: readers.AggregationMetadata{
+			PageMetadata: pm,
+			Aggregation:  aggregation,
+			Interval:     interval,
+			ByPublisher:  groupBy == publisherGroup,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeExport(_ context.Context, r *http.Request) (interface{}, error) {
+	pm, err := decodePageMetadata(r)
+	if err != nil {
+		return nil, err
+	}
+	// Messages are exported in chunks of the maximal page size.
+	pm.Limit = maxLimitSize
+
+	ct, err := exportContentType(r.Header.Get("Accept"))
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
+	req := exportMessagesReq{
+		chanID:      bone.GetValue(r, "chanID"),
+		token:       apiutil.ExtractBearerToken(r),
+		key:         apiutil.ExtractThingKey(r),
+		contentType: ct,
+		pageMeta:    pm,
+	}
+
+	return req, nil
+}
+
+// exportContentType negotiates export content type using Accept header value.
+// NDJSON is used unless CSV is explicitly requested.
+func exportContentType(accept string) (string, error) {
+	switch {
+	case strings.Contains(accept, csvContentType):
+		return csvContentType, nil
+	case accept == "",
+		strings.Contains(accept, ndjsonContentType),
+		strings.Contains(accept, "*/*"):
+		return ndjsonContentType, nil
+	default:
+		return "", apiutil.ErrUnsupportedContentType
+	}
+}
+
+func decodePageMetadata(r *http.Request) (readers.PageMetadata, error) {
+	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	limit, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	format, err := apiutil.ReadStringQuery(r, formatKey, defFormat)
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	protocol, err := apiutil.ReadStringQuery(r, protocolKey, "")
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	name, err := apiutil.ReadStringQuery(r, nameKey, "")
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	v, err := apiutil.ReadFloatQuery(r, valueKey, 0)
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	comparator, err := apiutil.ReadStringQuery(r, comparatorKey, "")
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	vs, err := apiutil.ReadStringQuery(r, stringValueKey, "")
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	vd, err := apiutil.ReadStringQuery(r, dataValueKey, "")
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	vb, err := apiutil.ReadBoolQuery(r, boolValueKey, false)
 	if err != nil && err != apiutil.ErrNotFoundParam {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	from, err := apiutil.ReadFloatQuery(r, fromKey, 0)
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	to, err := apiutil.ReadFloatQuery(r, toKey, 0)
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	req := listMessagesReq{
-		chanID: bone.GetValue(r, "chanID"),
-		token:  apiutil.ExtractBearerToken(r),
-		key:    apiutil.ExtractThingKey(r),
-		pageMeta: readers.PageMetadata{
-			Offset:      offset,
-			Limit:       limit,
-			Format:      format,
-			Subtopic:    subtopic,
-			Publisher:   publisher,
-			Protocol:    protocol,
-			Name:        name,
-			Value:       v,
-			Comparator:  comparator,
-			StringValue: vs,
-			DataValue:   vd,
-			BoolValue:   vb,
-			From:        from,
-			To:          to,
-		},
+	cursor, err := apiutil.ReadStringQuery(r, cursorKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	return req, nil
+	skipTotal, err := apiutil.ReadBoolQuery(r, skipTotalKey, false)
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	pm := readers.PageMetadata{
+		Offset:      offset,
+		Limit:       limit,
+		Format:      format,
+		Subtopic:    subtopic,
+		Publisher:   publisher,
+		Protocol:    protocol,
+		Name:        name,
+		Value:       v,
+		Comparator:  comparator,
+		StringValue: vs,
+		DataValue:   vd,
+		BoolValue:   vb,
+		From:        from,
+		To:          to,
+		Cursor:      cursor,
+		SkipTotal:   skipTotal,
+	}
+
+	return pm, nil
 }
 
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
@@ -181,6 +358,166 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	return json.NewEncoder(w).Encode(response)
 }
 
+func encodeExport(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(exportRes)
+
+	flusher, _ := w.(http.Flusher)
+	var enc messageEncoder
+	cw := csv.NewWriter(w)
+	switch res.contentType {
+	case csvContentType:
+		enc = csvEncoder{cw}
+	default:
+		enc = ndjsonEncoder{json.NewEncoder(w)}
+	}
+
+	// The status is sent once the first page is read, so that a failure
+	// to read any messages ends in a regular error response.
+	started := false
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		w.Header().Set("Content-Type", res.contentType)
+		w.Header().Set("Trailer", exportErrorTrailer)
+		w.WriteHeader(http.StatusOK)
+		if res.contentType != csvContentType {
+			return nil
+		}
+		header := senmlHeader
+		if !res.senml {
+			header = jsonHeader
+		}
+		return cw.Write(header)
+	}
+
+	err := res.walk(func(msgs []readers.Message) error {
+		if err := start(); err != nil {
+			return err
+		}
+		for _, msg := range msgs {
+			if err := enc.encode(msg); err != nil {
+				return err
+			}
+		}
+		if err := enc.flush(); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	switch {
+	case err != nil && started:
+		// The body is already partially sent, so the failure is
+		// reported in the trailer instead of the status.
+		w.Header().Set(exportErrorTrailer, err.Error())
+		return nil
+	case err != nil:
+		return err
+	}
+	if err := start(); err != nil {
+		return err
+	}
+
+	return enc.flush()
+}
+
+var (
+	senmlHeader = []string{"channel", "subtopic", "publisher", "protocol", "name", "unit", "time", "update_time", "value", "string_value", "bool_value", "data_value", "sum"}
+	jsonHeader  = []string{"channel", "subtopic", "publisher", "protocol", "created", "payload"}
+)
+
+type messageEncoder interface {
+	encode(msg readers.Message) error
+	flush() error
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e ndjsonEncoder) encode(msg readers.Message) error {
+	return e.enc.Encode(msg)
+}
+
+func (e ndjsonEncoder) flush() error {
+	return nil
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e csvEncoder) encode(msg readers.Message) error {
+	switch m := msg.(type) {
+	case senml.Message:
+		return e.w.Write([]string{
+			m.Channel,
+			m.Subtopic,
+			m.Publisher,
+			m.Protocol,
+			m.Name,
+			m.Unit,
+			formatFloat(m.Time),
+			formatFloat(m.UpdateTime),
+			formatFloatPtr(m.Value),
+			formatStringPtr(m.StringValue),
+			formatBoolPtr(m.BoolValue),
+			formatStringPtr(m.DataValue),
+			formatFloatPtr(m.Sum),
+		})
+	case map[string]interface{}:
+		pld, err := json.Marshal(m["payload"])
+		if err != nil {
+			return err
+		}
+		row := make([]string, 0, len(jsonHeader))
+		for _, key := range jsonHeader[:len(jsonHeader)-1] {
+			val := ""
+			if v, ok := m[key]; ok && v != nil {
+				val = fmt.Sprint(v)
+			}
+			row = append(row, val)
+		}
+		return e.w.Write(append(row, string(pld)))
+	default:
+		return errors.ErrMalformedEntity
+	}
+}
+
+func (e csvEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatFloatPtr(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return formatFloat(*v)
+}
+
+func formatStringPtr(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func formatBoolPtr(v *bool) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatBool(*v)
+}
+
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	var wrapper error
 	if errors.Contains(err, apiutil.ErrValidation) {
@@ -194,11 +531,19 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrMissingID),
 		errors.Contains(err, apiutil.ErrLimitSize),
 		errors.Contains(err, apiutil.ErrOffsetSize),
-		errors.Contains(err, apiutil.ErrInvalidComparator):
+		errors.Contains(err, apiutil.ErrInvalidComparator),
+		errors.Contains(err, apiutil.ErrInvalidAggregation),
+		errors.Contains(err, readers.ErrInvalidInterval),
+		errors.Contains(err, readers.ErrInvalidCursor),
+		errors.Contains(err, errTooManyChannels):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrAuthentication),
 		errors.Contains(err, apiutil.ErrBearerToken):
 		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, readers.ErrChannelGroup):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusNotAcceptable)
 	case errors.Contains(err, readers.ErrReadMessages):
 		w.WriteHeader(http.StatusInternalServerError)
 	default:
@@ -216,28 +561,39 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	}
 }
 
-func authorize(ctx context.Context, req listMessagesReq, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient) (err error) {
+func authorize(ctx context.Context, token, key, chanID string, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient) (err error) {
+	return authorizeChannels(ctx, token, key, []string{chanID}, tc, ac)
+}
+
+// authorizeChannels checks the read access of the user or thing to each of
+// the given channels. Users are identified per channel, so that API keys
+// scoped to a subset of channels are honored.
+func authorizeChannels(ctx context.Context, token, key string, chanIDs []string, tc tpolicies.AuthServiceClient, ac upolicies.AuthServiceClient) error {
 	switch {
-	case req.token != "":
-		user, err := ac.Identify(ctx, &upolicies.IdentifyReq{Token: req.token})
-		if err != nil {
-			e, ok := status.FromError(err)
-			if ok && e.Code() == codes.PermissionDenied {
-				return errors.Wrap(errUserAccess, err)
+	case token != "":
+		for _, chanID := range chanIDs {
+			user, err := ac.Identify(ctx, &upolicies.IdentifyReq{Token: token, Action: tpolicies.ReadAction, Object: chanID})
+			if err != nil {
+				e, ok := status.FromError(err)
+				if ok && e.Code() == codes.PermissionDenied {
+					return errors.Wrap(errUserAccess, err)
+				}
+				return err
 			}
-			return err
-		}
-		if _, err = tc.Authorize(ctx, &tpolicies.AuthorizeReq{Subject: user.GetId(), Object: req.chanID, Action: tpolicies.ReadAction, EntityType: tpolicies.GroupEntityType}); err != nil {
-			e, ok := status.FromError(err)
-			if ok && e.Code() == codes.PermissionDenied {
-				return errors.Wrap(errUserAccess, err)
+			if _, err = tc.Authorize(ctx, &tpolicies.AuthorizeReq{Subject: user.GetOwner(), Object: chanID, Action: tpolicies.ReadAction, EntityType: tpolicies.GroupEntityType}); err != nil {
+				e, ok := status.FromError(err)
+				if ok && e.Code() == codes.PermissionDenied {
+					return errors.Wrap(errUserAccess, err)
+				}
+				return err
 			}
-			return err
 		}
 		return nil
 	default:
-		if _, err := tc.Authorize(ctx, &tpolicies.AuthorizeReq{Subject: req.key, Object: req.chanID, Action: tpolicies.ReadAction, EntityType: tpolicies.GroupEntityType}); err != nil {
-			return errors.Wrap(errThingAccess, err)
+		for _, chanID := range chanIDs {
+			if _, err := tc.Authorize(ctx, &tpolicies.AuthorizeReq{Subject: key, Object: chanID, Action: tpolicies.ReadAction, EntityType: tpolicies.GroupEntityType}); err != nil {
+				return errors.Wrap(errThingAccess, err)
+			}
 		}
 		return nil
 	}
@@ -6,6 +6,7 @@ package cassandra
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/gocql/gocql"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -41,85 +42,320 @@ func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 	}
 
 	q, vals := buildQuery(chanID, rpm)
+	countCQL := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? %s ALLOW FILTERING`, format, q)
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	switch rpm.Offset {
+	case 0:
+		var k *keyset
+		if rpm.Cursor != "" {
+			k = &keyset{}
+			if err := readers.DecodeCursor(rpm.Cursor, k); err != nil {
+				return readers.MessagesPage{}, err
+			}
+		}
+		msgs, keys, err := cr.readChannel(chanID, rpm, k, rpm.Limit)
+		if err != nil {
+			if undefinedTable(err) {
+				return readers.MessagesPage{}, nil
+			}
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Messages = append(page.Messages, msgs...)
+		if rpm.Limit > 0 && uint64(len(msgs)) == rpm.Limit {
+			cursor, err := readers.EncodeCursor(keys[len(keys)-1])
+			if err != nil {
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			page.NextCursor = cursor
+		}
+	default:
+		msgs, _, err := cr.read(format, fmt.Sprintf(`WHERE channel = ? %s LIMIT ?`, q), vals, rpm.Offset)
+		if err != nil {
+			if undefinedTable(err) {
+				return readers.MessagesPage{}, nil
+			}
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Messages = append(page.Messages, msgs...)
+	}
+
+	if rpm.SkipTotal {
+		return page, nil
+	}
+
+	if err := cr.session.Query(countCQL, vals[:len(vals)-1]...).Scan(&page.Total); err != nil {
+		if undefinedTable(err) {
+			return readers.MessagesPage{}, nil
+		}
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
 
+	return page, nil
+}
+
+// readChannel reads up to limit messages of the channel partition which
+// follow the keyset, or the first ones if there is no keyset. Messages of the
+// partition are ordered by time and ID, so the ones sharing the time of the
+// keyset are read first, followed by the older ones.
+func (cr cassandraRepository) readChannel(chanID string, rpm readers.PageMetadata, k *keyset, limit uint64) ([]readers.Message, []keyset, error) {
+	format := defTable
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	var msgs []readers.Message
+	var keys []keyset
+	var cond string
+	var condVals []interface{}
+	if k != nil {
+		if chanID >= k.Channel {
+			// Time can't be restricted by both a range and an equality.
+			tie := rpm
+			tie.From, tie.To = 0, 0
+			q, vals := buildQuery(chanID, tie)
+			vals = append(vals[:len(vals)-1], k.position(format))
+			q = fmt.Sprintf(`%s AND %s = ?`, q, k.column(format))
+			if chanID == k.Channel {
+				id, err := gocql.ParseUUID(k.ID)
+				if err != nil {
+					return nil, nil, readers.ErrInvalidCursor
+				}
+				vals = append(vals, id)
+				q = fmt.Sprintf(`%s AND id > ?`, q)
+			}
+			vals = append(vals, limit)
+			var err error
+			if msgs, keys, err = cr.read(format, fmt.Sprintf(`WHERE channel = ? %s LIMIT ?`, q), vals, 0); err != nil {
+				return nil, nil, err
+			}
+		}
+		switch format {
+		case defTable:
+			if rpm.To == 0 || k.Time < rpm.To {
+				rpm.To = k.Time
+			}
+		default:
+			cond = ` AND created < ?`
+			condVals = append(condVals, k.Created)
+		}
+	}
+
+	remaining := limit - uint64(len(msgs))
+	if remaining == 0 {
+		return msgs, keys, nil
+	}
+	q, vals := buildQuery(chanID, rpm)
+	vals = append(append(vals[:len(vals)-1], condVals...), remaining)
+	older, olderKeys, err := cr.read(format, fmt.Sprintf(`WHERE channel = ? %s%s LIMIT ?`, q, cond), vals, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(msgs, older...), append(keys, olderKeys...), nil
+}
+
+// read reads the messages matching the condition, skipping the first skip
+// ones, and returns them together with their keysets.
+func (cr cassandraRepository) read(format, cond string, vals []interface{}, skip uint64) ([]readers.Message, []keyset, error) {
 	selectCQL := fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, name, unit,
 		value, string_value, bool_value, data_value, sum, time,
-		update_time FROM messages WHERE channel = ? %s LIMIT ?
-		ALLOW FILTERING`, q)
-	countCQL := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? %s ALLOW FILTERING`, format, q)
-
+		update_time, id FROM messages %s ALLOW FILTERING`, cond)
 	if format != defTable {
-		selectCQL = fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, created, payload FROM %s WHERE channel = ? %s LIMIT ?
-			ALLOW FILTERING`, format, q)
-		countCQL = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? %s ALLOW FILTERING`, format, q)
+		selectCQL = fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, created, payload, id FROM %s %s ALLOW FILTERING`, format, cond)
 	}
 
 	iter := cr.session.Query(selectCQL, vals...).Iter()
-	defer iter.Close()
 	scanner := iter.Scanner()
 
 	// skip first OFFSET rows
-	for i := uint64(0); i < rpm.Offset; i++ {
+	for i := uint64(0); i < skip; i++ {
 		if !scanner.Next() {
 			break
 		}
 	}
 
-	page := readers.MessagesPage{
-		PageMetadata: rpm,
-		Messages:     []readers.Message{},
-	}
-
+	var msgs []readers.Message
+	var keys []keyset
+	var id gocql.UUID
 	switch format {
 	case defTable:
 		for scanner.Next() {
 			var msg senml.Message
 			if err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol,
 				&msg.Name, &msg.Unit, &msg.Value, &msg.StringValue, &msg.BoolValue,
-				&msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime); err != nil {
-				if e, ok := err.(gocql.RequestError); ok {
-					if e.Code() == undefinedTableCode {
-						return readers.MessagesPage{}, nil
-					}
-				}
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+				&msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime, &id); err != nil {
+				iter.Close()
+				return nil, nil, err
 			}
-			page.Messages = append(page.Messages, msg)
+			msgs = append(msgs, msg)
+			keys = append(keys, keyset{Time: msg.Time, Channel: msg.Channel, ID: id.String()})
 		}
 	default:
 		for scanner.Next() {
 			var msg jsonMessage
-			if err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Created, &msg.Payload); err != nil {
-				if e, ok := err.(gocql.RequestError); ok {
-					if e.Code() == undefinedTableCode {
-						return readers.MessagesPage{}, nil
-					}
+			if err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Created, &msg.Payload, &id); err != nil {
+				iter.Close()
+				return nil, nil, err
+			}
+			m, err := msg.toMap()
+			if err != nil {
+				iter.Close()
+				return nil, nil, err
+			}
+			msgs = append(msgs, m)
+			keys = append(keys, keyset{Created: msg.Created, Channel: msg.Channel, ID: id.String()})
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	return msgs, keys, nil
+}
+
+// ReadChannels merges the messages of each channel partition, since Cassandra
+// returns IN query results partition by partition. Pages following a cursor
+// are read by keyset queries of each partition, so that only a page of
+// messages per channel is read regardless of the page position.
+func (cr cassandraRepository) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if len(chanIDs) == 1 {
+		return cr.ReadAll(chanIDs[0], rpm)
+	}
+
+	format := defTable
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	q, vals := buildQuery(chanIDs, rpm)
+	countCQL := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel IN ? %s ALLOW FILTERING`, format, q)
+
+	var msgs []readers.Message
+	var keys []keyset
+	switch rpm.Offset {
+	case 0:
+		var k *keyset
+		if rpm.Cursor != "" {
+			k = &keyset{}
+			if err := readers.DecodeCursor(rpm.Cursor, k); err != nil {
+				return readers.MessagesPage{}, err
+			}
+		}
+		for _, chanID := range chanIDs {
+			chMsgs, chKeys, err := cr.readChannel(chanID, rpm, k, rpm.Limit)
+			if err != nil {
+				if undefinedTable(err) {
+					return readers.MessagesPage{}, nil
 				}
 				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 			}
-			m, err := msg.toMap()
+			msgs = append(msgs, chMsgs...)
+			keys = append(keys, chKeys...)
+		}
+	default:
+		// Offset pages need the first offset+limit messages of each partition.
+		var err error
+		msgs, keys, err = cr.read(format, fmt.Sprintf(`WHERE channel IN ? %s PER PARTITION LIMIT ?`, q), vals, 0)
+		if err != nil {
+			if undefinedTable(err) {
+				return readers.MessagesPage{}, nil
+			}
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
+	// Messages of each partition are already ordered, so the stable sort
+	// merges them.
+	sort.Stable(byKeyset{msgs: msgs, keys: keys})
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	if rpm.Offset < uint64(len(msgs)) {
+		end := rpm.Offset + rpm.Limit
+		if end > uint64(len(msgs)) {
+			end = uint64(len(msgs))
+		}
+		page.Messages = msgs[rpm.Offset:end]
+
+		if rpm.Offset == 0 && rpm.Limit > 0 && uint64(len(page.Messages)) == rpm.Limit {
+			cursor, err := readers.EncodeCursor(keys[end-1])
 			if err != nil {
 				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 			}
-			page.Messages = append(page.Messages, m)
+			page.NextCursor = cursor
 		}
 	}
 
+	if rpm.SkipTotal {
+		return page, nil
+	}
+
 	if err := cr.session.Query(countCQL, vals[:len(vals)-1]...).Scan(&page.Total); err != nil {
+		if undefinedTable(err) {
+			return readers.MessagesPage{}, nil
+		}
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return page, nil
+}
+
+// ReadAggregates folds values into time buckets while iterating over the
+// channel partition, since Cassandra can group only by primary key columns.
+func (cr cassandraRepository) ReadAggregates(chanID string, ram readers.AggregationMetadata) (readers.AggregatesPage, error) {
+	agg, err := readers.NewAggregator(ram)
+	if err != nil {
+		return readers.AggregatesPage{}, err
+	}
+
+	q, vals := buildQuery(chanID, ram.PageMetadata)
+	selectCQL := fmt.Sprintf(`SELECT name, publisher, time, value FROM %s
+		WHERE channel = ? %s ALLOW FILTERING`, defTable, q)
+
+	iter := cr.session.Query(selectCQL, vals[:len(vals)-1]...).Iter()
+	scanner := iter.Scanner()
+	for scanner.Next() {
+		var name, publisher string
+		var t float64
+		var value *float64
+		if err := scanner.Scan(&name, &publisher, &t, &value); err != nil {
+			iter.Close()
+			if e, ok := err.(gocql.RequestError); ok {
+				if e.Code() == undefinedTableCode {
+					return readers.AggregatesPage{}, nil
+				}
+			}
+			return readers.AggregatesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		if value == nil {
+			continue
+		}
+		agg.Add(name, publisher, t, *value)
+	}
+	if err := iter.Close(); err != nil {
 		if e, ok := err.(gocql.RequestError); ok {
 			if e.Code() == undefinedTableCode {
-				return readers.MessagesPage{}, nil
+				return readers.AggregatesPage{}, nil
 			}
 		}
-		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		return readers.AggregatesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
 
-	return page, nil
+	return agg.Page(), nil
 }
 
-func buildQuery(chanID string, rpm readers.PageMetadata) (string, []interface{}) {
+// buildQuery returns the query conditions and values, starting with the
+// channel, which is either a single ID or a list of IDs, and ending with the
+// limit.
+func buildQuery(channel interface{}, rpm readers.PageMetadata) (string, []interface{}) {
 	var condCQL string
-	vals := []interface{}{chanID}
+	vals := []interface{}{channel}
 
 	var query map[string]interface{}
 	meta, err := json.Marshal(rpm)
@@ -166,6 +402,65 @@ func buildQuery(chanID string, rpm readers.PageMetadata) (string, []interface{})
 	return condCQL, vals
 }
 
+// keyset represents the position of the last message on the page. Messages
+// are ordered by time, channel and ID, which is unique within the channel.
+type keyset struct {
+	Time    float64 `json:"time,omitempty"`
+	Created int64   `json:"created,omitempty"`
+	Channel string  `json:"channel"`
+	ID      string  `json:"id"`
+}
+
+// column returns the time column of the messages of the given format.
+func (k keyset) column(format string) string {
+	if format != defTable {
+		return "created"
+	}
+
+	return "time"
+}
+
+// position returns the time of the keyset in the messages of the given format.
+func (k keyset) position(format string) interface{} {
+	if format != defTable {
+		return k.Created
+	}
+
+	return k.Time
+}
+
+func undefinedTable(err error) bool {
+	e, ok := err.(gocql.RequestError)
+	return ok && e.Code() == undefinedTableCode
+}
+
+// byKeyset sorts messages by the time in descending order and by the channel.
+type byKeyset struct {
+	msgs []readers.Message
+	keys []keyset
+}
+
+func (bk byKeyset) Len() int {
+	return len(bk.msgs)
+}
+
+func (bk byKeyset) Less(i, j int) bool {
+	ki, kj := bk.keys[i], bk.keys[j]
+	switch {
+	case ki.Time != kj.Time:
+		return ki.Time > kj.Time
+	case ki.Created != kj.Created:
+		return ki.Created > kj.Created
+	default:
+		return ki.Channel < kj.Channel
+	}
+}
+
+func (bk byKeyset) Swap(i, j int) {
+	bk.msgs[i], bk.msgs[j] = bk.msgs[j], bk.msgs[i]
+	bk.keys[i], bk.keys[j] = bk.keys[j], bk.keys[i]
+}
+
 type jsonMessage struct {
 	ID        string
 	Channel   string
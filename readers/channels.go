@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"errors"
+
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+)
+
+// channelsPageSize is the maximum page size supported by things service.
+const channelsPageSize = 100
+
+// ErrChannelGroup indicates failure occurred while resolving channel group.
+var ErrChannelGroup = errors.New("failed to resolve channel group")
+
+// ChannelGroups resolves channels which belong to the channel group.
+type ChannelGroups interface {
+	// Channels returns IDs of the channel group and all of its descendant
+	// channels which are visible to the user identified by the token.
+	Channels(token, groupID string) ([]string, error)
+}
+
+type channelGroups struct {
+	sdk mfsdk.SDK
+}
+
+// NewChannelGroups returns channel groups resolver which uses things service
+// to list descendants of the channel group.
+func NewChannelGroups(sdk mfsdk.SDK) ChannelGroups {
+	return channelGroups{
+		sdk: sdk,
+	}
+}
+
+func (cg channelGroups) Channels(token, groupID string) ([]string, error) {
+	pm := mfsdk.PageMetadata{
+		Limit:    channelsPageSize,
+		ParentID: groupID,
+	}
+
+	var ids []string
+	for {
+		page, err := cg.sdk.Channels(pm, token)
+		if err != nil {
+			return nil, ErrChannelGroup
+		}
+		for _, ch := range page.Channels {
+			ids = append(ids, ch.ID)
+		}
+		if uint64(len(page.Channels)) < pm.Limit {
+			return ids, nil
+		}
+		pm.Offset += pm.Limit
+	}
+}
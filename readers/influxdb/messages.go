@@ -45,13 +45,37 @@ func New(client influxdb2.Client, repoCfg RepoConfig) readers.MessageRepository
 }
 
 func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return repo.ReadChannels([]string{chanID}, rpm)
+}
+
+func (repo *influxRepository) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	format := defMeasurement
 	if rpm.Format != "" {
 		format = rpm.Format
 	}
 
 	queryAPI := repo.client.QueryAPI(repo.cfg.Org)
-	condition, timeRange := fmtCondition(chanID, rpm)
+	condition, timeRange := fmtCondition(chanIDs, rpm)
+
+	// Points are identified by the time and the tags, so sorting by all of
+	// them orders the messages totally. Missing tags are sorted as empty.
+	keyColumns := `|> map(fn: (r) => ({r with _name: if exists r.name then r.name else "", _subtopic: if exists r.subtopic then r.subtopic else ""}))`
+	sortColumns := `"_time", "publisher", "_name", "channel", "_subtopic"`
+	if format != defMeasurement {
+		keyColumns = `|> map(fn: (r) => ({r with _subtopic: if exists r.subtopic then r.subtopic else ""}))`
+		sortColumns = `"_time", "publisher", "channel", "_subtopic"`
+	}
+
+	pageCondition := keyColumns
+	offset := rpm.Offset
+	if rpm.Cursor != "" {
+		var k keyset
+		if err := readers.DecodeCursor(rpm.Cursor, &k); err != nil {
+			return readers.MessagesPage{}, err
+		}
+		pageCondition += k.filter(format)
+		offset = 0
+	}
 
 	query := fmt.Sprintf(`
 	import "influxdata/influxdb/v1"
@@ -62,14 +86,18 @@ func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 	|> group()
 	|> filter(fn: (r) => r._measurement == "%s")
 	%s
-	|> sort(columns: ["_time"], desc: true)
+	%s
+	|> sort(columns: [%s], desc: true)
 	|> limit(n:%d,offset:%d)
+	|> drop(fn: (column) => column == "_name" or column == "_subtopic")
 	|> yield(name: "sort")`,
 		repo.cfg.Bucket,
 		timeRange,
 		format,
 		condition,
-		rpm.Limit, rpm.Offset,
+		pageCondition,
+		sortColumns,
+		rpm.Limit, offset,
 	)
 
 	resp, err := queryAPI.Query(context.Background(), query)
@@ -91,20 +119,106 @@ func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, resp.Err())
 	}
 
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     messages,
+	}
+
+	if rpm.Limit > 0 && uint64(len(messages)) == rpm.Limit {
+		if page.NextCursor, err = readers.EncodeCursor(newKeyset(valueMap)); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
+	if rpm.SkipTotal {
+		return page, nil
+	}
+
 	total, err := repo.count(format, condition, timeRange)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
+	page.Total = total
 
-	page := readers.MessagesPage{
-		PageMetadata: rpm,
-		Total:        total,
-		Messages:     messages,
+	return page, nil
+}
+
+func (repo *influxRepository) ReadAggregates(chanID string, ram readers.AggregationMetadata) (readers.AggregatesPage, error) {
+	interval, err := readers.ParseInterval(ram.Interval)
+	if err != nil {
+		return readers.AggregatesPage{}, err
+	}
+
+	groupBy := `"name"`
+	if ram.ByPublisher {
+		groupBy = `"name", "publisher"`
+	}
+
+	queryAPI := repo.client.QueryAPI(repo.cfg.Org)
+	condition, timeRange := fmtCondition([]string{chanID}, ram.PageMetadata)
+
+	query := fmt.Sprintf(`
+	import "influxdata/influxdb/v1"
+	import "strings"
+	from(bucket: "%s")
+	%s
+	|> v1.fieldsAsCols()
+	|> filter(fn: (r) => r._measurement == "%s")
+	%s
+	|> filter(fn: (r) => exists r.value)
+	|> group(columns: [%s])
+	|> aggregateWindow(every: %ds, fn: %s, column: "value", timeSrc: "_start", createEmpty: false)
+	|> group()
+	|> sort(columns: ["_time"], desc: true)
+	|> limit(n:%d,offset:%d)
+	|> yield(name: "aggregates")`,
+		repo.cfg.Bucket,
+		timeRange,
+		defMeasurement,
+		condition,
+		groupBy,
+		interval, aggFunction(ram.Aggregation),
+		ram.Limit, ram.Offset,
+	)
+
+	resp, err := queryAPI.Query(context.Background(), query)
+	if err != nil {
+		return readers.AggregatesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	page := readers.AggregatesPage{
+		AggregationMetadata: ram,
+		Aggregates:          []readers.Aggregate{},
+	}
+	for resp.Next() {
+		agg, err := parseAggregate(resp.Record().Values())
+		if err != nil {
+			return readers.AggregatesPage{}, err
+		}
+		page.Aggregates = append(page.Aggregates, agg)
+	}
+	if resp.Err() != nil {
+		return readers.AggregatesPage{}, errors.Wrap(readers.ErrReadMessages, resp.Err())
 	}
 
 	return page, nil
 }
 
+func aggFunction(aggregation string) string {
+	switch aggregation {
+	case readers.MinAggregation:
+		return "min"
+	case readers.MaxAggregation:
+		return "max"
+	case readers.SumAggregation:
+		return "sum"
+	case readers.CountAggregation:
+		return "count"
+	default:
+		return "mean"
+	}
+}
+
 func (repo *influxRepository) count(measurement, condition string, timeRange string) (uint64, error) {
 	cmd := fmt.Sprintf(`
 	import "influxdata/influxdb/v1"
@@ -144,10 +258,19 @@ func (repo *influxRepository) count(measurement, condition string, timeRange str
 	}
 }
 
-func fmtCondition(chanID string, rpm readers.PageMetadata) (string, string) {
+func fmtCondition(chanIDs []string, rpm readers.PageMetadata) (string, string) {
 	var timeRange string
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r["channel"] == "%s" )`, chanID))
+	switch len(chanIDs) {
+	case 1:
+		sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r["channel"] == "%s" )`, chanIDs[0]))
+	default:
+		channels := make([]string, len(chanIDs))
+		for i, id := range chanIDs {
+			channels[i] = fmt.Sprintf("%q", id)
+		}
+		sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => contains(value: r["channel"], set: [%s]))`, strings.Join(channels, ", ")))
+	}
 
 	var query map[string]interface{}
 	meta, err := json.Marshal(rpm)
@@ -226,6 +349,64 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) (string, string) {
 	return sb.String(), timeRange
 }
 
+// keyset represents the sort key of the last message on the page. Time is
+// kept in nanoseconds so that no precision is lost.
+type keyset struct {
+	Time      int64  `json:"time"`
+	Publisher string `json:"publisher"`
+	Name      string `json:"name,omitempty"`
+	Channel   string `json:"channel"`
+	Subtopic  string `json:"subtopic,omitempty"`
+}
+
+func newKeyset(valueMap map[string]interface{}) keyset {
+	var k keyset
+	if t, ok := valueMap["_time"].(time.Time); ok {
+		k.Time = t.UnixNano()
+	}
+	k.Publisher, _ = valueMap["publisher"].(string)
+	k.Name, _ = valueMap["name"].(string)
+	k.Channel, _ = valueMap["channel"].(string)
+	k.Subtopic, _ = valueMap["subtopic"].(string)
+
+	return k
+}
+
+// filter returns the condition which matches messages sorted after the keyset.
+func (k keyset) filter(measurement string) string {
+	if measurement != defMeasurement {
+		return fmt.Sprintf(`|> filter(fn: (r) => r._time < time(v: %d) or (r._time == time(v: %d) and (r.publisher < %q or (r.publisher == %q and (r.channel < %q or (r.channel == %q and r._subtopic < %q))))))`,
+			k.Time, k.Time, k.Publisher, k.Publisher, k.Channel, k.Channel, k.Subtopic)
+	}
+
+	return fmt.Sprintf(`|> filter(fn: (r) => r._time < time(v: %d) or (r._time == time(v: %d) and (r.publisher < %q or (r.publisher == %q and (r._name < %q or (r._name == %q and (r.channel < %q or (r.channel == %q and r._subtopic < %q))))))))`,
+		k.Time, k.Time, k.Publisher, k.Publisher, k.Name, k.Name, k.Channel, k.Channel, k.Subtopic)
+}
+
+func parseAggregate(valueMap map[string]interface{}) (readers.Aggregate, error) {
+	t, ok := valueMap["_time"].(time.Time)
+	if !ok {
+		return readers.Aggregate{}, errResultTime
+	}
+
+	agg := readers.Aggregate{
+		Time: float64(t.UnixNano()) / 1e9,
+	}
+	agg.Name, _ = valueMap["name"].(string)
+	agg.Publisher, _ = valueMap["publisher"].(string)
+
+	switch v := valueMap["value"].(type) {
+	case float64:
+		agg.Value = v
+	case int64:
+		agg.Value = float64(v)
+	case uint64:
+		agg.Value = float64(v)
+	}
+
+	return agg, nil
+}
+
 func parseMessage(measurement string, valueMap map[string]interface{}) (interface{}, error) {
 	switch measurement {
 	case defMeasurement:
@@ -3,7 +3,12 @@
 
 package readers
 
-import "errors"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 const (
 	// EqualKey represents the equal comparison operator key.
@@ -18,25 +23,57 @@ const (
 	GreaterThanEqualKey = "ge"
 )
 
-// ErrReadMessages indicates failure occurred while reading messages from database.
-var ErrReadMessages = errors.New("failed to read messages from database")
+const (
+	// MinAggregation represents the minimum value aggregation.
+	MinAggregation = "min"
+	// MaxAggregation represents the maximum value aggregation.
+	MaxAggregation = "max"
+	// AvgAggregation represents the average value aggregation.
+	AvgAggregation = "avg"
+	// SumAggregation represents the value sum aggregation.
+	SumAggregation = "sum"
+	// CountAggregation represents the value count aggregation.
+	CountAggregation = "count"
+)
+
+var (
+	// ErrReadMessages indicates failure occurred while reading messages from database.
+	ErrReadMessages = errors.New("failed to read messages from database")
+
+	// ErrInvalidInterval indicates an invalid aggregation interval.
+	ErrInvalidInterval = errors.New("invalid aggregation interval")
+
+	// ErrInvalidCursor indicates a malformed page cursor.
+	ErrInvalidCursor = errors.New("invalid page cursor")
+)
 
 // MessageRepository specifies message reader API.
 type MessageRepository interface {
 	// ReadAll skips given number of messages for given channel and returns next
-	// limited number of messages.
+	// limited number of messages. If the page metadata contains a cursor, the
+	// page starts right after the message the cursor points to instead.
 	ReadAll(chanID string, pm PageMetadata) (MessagesPage, error)
+
+	// ReadChannels works like ReadAll, but it returns messages of all the
+	// given channels merged into a single page ordered by time.
+	ReadChannels(chanIDs []string, pm PageMetadata) (MessagesPage, error)
+
+	// ReadAggregates aggregates SenML values of the given channel over time
+	// buckets and returns the page of aggregated values.
+	ReadAggregates(chanID string, am AggregationMetadata) (AggregatesPage, error)
 }
 
 // Message represents any message format.
 type Message interface{}
 
 // MessagesPage contains page related metadata as well as list of messages that
-// belong to this page.
+// belong to this page. NextCursor is set only when the page is full, so there
+// might be more messages to read.
 type MessagesPage struct {
 	PageMetadata
-	Total    uint64
-	Messages []Message
+	Total      uint64
+	NextCursor string
+	Messages   []Message
 }
 
 // PageMetadata represents the parameters used to create database queries.
@@ -55,6 +92,72 @@ type PageMetadata struct {
 	From        float64 `json:"from,omitempty"`
 	To          float64 `json:"to,omitempty"`
 	Format      string  `json:"format,omitempty"`
+	Cursor      string  `json:"cursor,omitempty"`
+	SkipTotal   bool    `json:"skip_total,omitempty"`
+}
+
+// AggregationMetadata represents the parameters used to create aggregation
+// queries. Embedded PageMetadata filters the aggregated messages, while its
+// offset and limit apply to the resulting aggregates.
+type AggregationMetadata struct {
+	PageMetadata
+	Aggregation string `json:"aggregation"`
+	Interval    string `json:"interval"`
+	ByPublisher bool   `json:"by_publisher,omitempty"`
+}
+
+// Aggregate represents the value aggregated over a single time bucket for
+// the given message name and, optionally, publisher.
+type Aggregate struct {
+	Time      float64 `json:"time"`
+	Name      string  `json:"name"`
+	Publisher string  `json:"publisher,omitempty"`
+	Value     float64 `json:"value"`
+}
+
+// AggregatesPage contains aggregation related metadata as well as list of
+// aggregates that belong to this page.
+type AggregatesPage struct {
+	AggregationMetadata
+	Aggregates []Aggregate
+}
+
+// EncodeCursor encodes the position of the last message on the page into
+// an opaque page cursor.
+func EncodeCursor(position interface{}) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes the opaque page cursor into the given position.
+func DecodeCursor(cursor string, position interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, position); err != nil {
+		return ErrInvalidCursor
+	}
+
+	return nil
+}
+
+// ParseInterval parses the aggregation interval (e.g. "5m") and returns its
+// length in whole seconds.
+func ParseInterval(interval string) (int64, error) {
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, ErrInvalidInterval
+	}
+	if d < time.Second || d%time.Second != 0 {
+		return 0, ErrInvalidInterval
+	}
+
+	return int64(d / time.Second), nil
 }
 
 // ParseValueComparator convert comparison operator keys into mathematic anotation.
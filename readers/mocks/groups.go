@@ -0,0 +1,27 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import "github.com/mainflux/mainflux/readers"
+
+var _ readers.ChannelGroups = (*channelGroupsMock)(nil)
+
+type channelGroupsMock struct {
+	groups map[string][]string
+}
+
+// NewChannelGroups returns mock implementation of channel groups resolver
+// with channel IDs mapped by the group ID.
+func NewChannelGroups(groups map[string][]string) readers.ChannelGroups {
+	return channelGroupsMock{groups}
+}
+
+func (cg channelGroupsMock) Channels(_, groupID string) ([]string, error) {
+	ids, ok := cg.groups[groupID]
+	if !ok {
+		return nil, readers.ErrChannelGroup
+	}
+
+	return ids, nil
+}
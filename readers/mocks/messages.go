@@ -5,6 +5,7 @@ package mocks
 
 import (
 	"encoding/json"
+	"sort"
 	"sync"
 
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
@@ -30,7 +31,20 @@ func NewMessageRepository(chanID string, messages []readers.Message) readers.Mes
 	}
 }
 
+// NewMultiChannelRepository returns mock implementation of message repository
+// holding messages of multiple channels, mapped by channel ID.
+func NewMultiChannelRepository(messages map[string][]readers.Message) readers.MessageRepository {
+	return &messageRepositoryMock{
+		mutex:    sync.Mutex{},
+		messages: messages,
+	}
+}
+
 func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return repo.ReadChannels([]string{chanID}, rpm)
+}
+
+func (repo *messageRepositoryMock) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	repo.mutex.Lock()
 	defer repo.mutex.Unlock()
 
@@ -38,13 +52,93 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 		return readers.MessagesPage{}, nil
 	}
 
+	var msgs []readers.Message
+	for _, chanID := range chanIDs {
+		chMsgs, err := repo.filter(chanID, rpm)
+		if err != nil {
+			return readers.MessagesPage{}, err
+		}
+		msgs = append(msgs, chMsgs...)
+	}
+	if len(chanIDs) > 1 {
+		sort.SliceStable(msgs, func(i, j int) bool {
+			return msgs[i].(senml.Message).Time > msgs[j].(senml.Message).Time
+		})
+	}
+
+	// Mocked cursor holds the offset of the following page.
+	if rpm.Cursor != "" {
+		if err := readers.DecodeCursor(rpm.Cursor, &rpm.Offset); err != nil {
+			return readers.MessagesPage{}, err
+		}
+	}
+
+	numOfMessages := uint64(len(msgs))
+
+	if rpm.Offset >= numOfMessages {
+		return readers.MessagesPage{}, nil
+	}
+
+	if rpm.Limit < 1 {
+		return readers.MessagesPage{}, nil
+	}
+
+	end := rpm.Offset + rpm.Limit
+	if rpm.Offset+rpm.Limit > numOfMessages {
+		end = numOfMessages
+	}
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Total:        uint64(len(msgs)),
+		Messages:     msgs[rpm.Offset:end],
+	}
+	if rpm.SkipTotal {
+		page.Total = 0
+	}
+	if end-rpm.Offset == rpm.Limit {
+		cursor, err := readers.EncodeCursor(end)
+		if err != nil {
+			return readers.MessagesPage{}, err
+		}
+		page.NextCursor = cursor
+	}
+
+	return page, nil
+}
+
+func (repo *messageRepositoryMock) ReadAggregates(chanID string, ram readers.AggregationMetadata) (readers.AggregatesPage, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	agg, err := readers.NewAggregator(ram)
+	if err != nil {
+		return readers.AggregatesPage{}, err
+	}
+
+	msgs, err := repo.filter(chanID, ram.PageMetadata)
+	if err != nil {
+		return readers.AggregatesPage{}, err
+	}
+	for _, m := range msgs {
+		msg := m.(senml.Message)
+		if msg.Value == nil {
+			continue
+		}
+		agg.Add(msg.Name, msg.Publisher, msg.Time, *msg.Value)
+	}
+
+	return agg.Page(), nil
+}
+
+func (repo *messageRepositoryMock) filter(chanID string, rpm readers.PageMetadata) ([]readers.Message, error) {
 	var query map[string]interface{}
 	meta, err := json.Marshal(rpm)
 	if err != nil {
-		return readers.MessagesPage{}, err
+		return nil, err
 	}
 	if err := json.Unmarshal(meta, &query); err != nil {
-		return readers.MessagesPage{}, err
+		return nil, err
 	}
 
 	var msgs []readers.Message
@@ -145,24 +239,5 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 		}
 	}
 
-	numOfMessages := uint64(len(msgs))
-
-	if rpm.Offset >= numOfMessages {
-		return readers.MessagesPage{}, nil
-	}
-
-	if rpm.Limit < 1 {
-		return readers.MessagesPage{}, nil
-	}
-
-	end := rpm.Offset + rpm.Limit
-	if rpm.Offset+rpm.Limit > numOfMessages {
-		end = numOfMessages
-	}
-
-	return readers.MessagesPage{
-		PageMetadata: rpm,
-		Total:        uint64(len(msgs)),
-		Messages:     msgs[rpm.Offset:end],
-	}, nil
+	return msgs, nil
 }
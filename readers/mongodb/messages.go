@@ -16,7 +16,10 @@ import (
 )
 
 // Collection for SenML messages.
-const defCollection = "messages"
+const (
+	defCollection = "messages"
+	expireAtKey   = "expire_at"
+)
 
 var _ readers.MessageRepository = (*mongoRepository)(nil)
 
@@ -32,27 +35,54 @@ func New(db *mongo.Database) readers.MessageRepository {
 }
 
 func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return repo.ReadChannels([]string{chanID}, rpm)
+}
+
+func (repo mongoRepository) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	format := defCollection
 	order := "time"
+	senmlFormat := true
 	if rpm.Format != "" && rpm.Format != defCollection {
 		order = "created"
 		format = rpm.Format
+		senmlFormat = false
 	}
 
 	col := repo.db.Collection(format)
 
-	sortMap := map[string]interface{}{
-		order: -1,
+	sortMap := bson.D{
+		{Key: order, Value: -1},
+		{Key: "publisher", Value: -1},
+	}
+	if senmlFormat {
+		sortMap = append(sortMap, bson.E{Key: "name", Value: -1})
 	}
+	sortMap = append(sortMap, bson.E{Key: "channel", Value: -1})
 	// Remove format filter and format the rest properly.
-	filter := fmtCondition(chanID, rpm)
-	cursor, err := col.Find(context.Background(), filter, options.Find().SetSort(sortMap).SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
+	filter := fmtCondition(chanIDs, rpm)
+
+	// Expiration time set by the writer is not a part of the message.
+	opts := options.Find().SetSort(sortMap).SetLimit(int64(rpm.Limit)).SetProjection(bson.M{expireAtKey: 0})
+	pageFilter := filter
+	switch rpm.Cursor {
+	case "":
+		opts = opts.SetSkip(int64(rpm.Offset))
+	default:
+		var k keyset
+		if err := readers.DecodeCursor(rpm.Cursor, &k); err != nil {
+			return readers.MessagesPage{}, err
+		}
+		pageFilter = bson.D{{Key: "$and", Value: bson.A{filter, k.filter(senmlFormat)}}}
+	}
+
+	cursor, err := col.Find(context.Background(), pageFilter, opts)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
 	defer cursor.Close(context.Background())
 
 	var messages []readers.Message
+	var last keyset
 	switch format {
 	case defCollection:
 		for cursor.Next(context.Background()) {
@@ -62,6 +92,7 @@ func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (re
 			}
 
 			messages = append(messages, m)
+			last = keyset{Time: m.Time, Publisher: m.Publisher, Name: m.Name, Channel: m.Channel}
 		}
 	default:
 		for cursor.Next(context.Background()) {
@@ -71,28 +102,171 @@ func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (re
 			}
 
 			messages = append(messages, m)
+			last = newKeyset(m)
 		}
 	}
 
+	mp := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     messages,
+	}
+
+	if rpm.Limit > 0 && uint64(len(messages)) == rpm.Limit {
+		if mp.NextCursor, err = readers.EncodeCursor(last); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
+	if rpm.SkipTotal {
+		return mp, nil
+	}
+
 	total, err := col.CountDocuments(context.Background(), filter)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
+	mp.Total = uint64(total)
 
-	mp := readers.MessagesPage{
-		PageMetadata: rpm,
-		Total:        uint64(total),
-		Messages:     messages,
+	return mp, nil
+}
+
+func (repo mongoRepository) ReadAggregates(chanID string, ram readers.AggregationMetadata) (readers.AggregatesPage, error) {
+	interval, err := readers.ParseInterval(ram.Interval)
+	if err != nil {
+		return readers.AggregatesPage{}, err
 	}
 
-	return mp, nil
+	filter := bson.D{{Key: "$and", Value: bson.A{
+		fmtCondition([]string{chanID}, ram.PageMetadata),
+		bson.M{"value": bson.M{"$type": "number"}},
+	}}}
+
+	group := bson.D{
+		{Key: "time", Value: bson.M{"$subtract": bson.A{"$time", bson.M{"$mod": bson.A{"$time", interval}}}}},
+		{Key: "name", Value: "$name"},
+	}
+	if ram.ByPublisher {
+		group = append(group, bson.E{Key: "publisher", Value: "$publisher"})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: group},
+			{Key: "value", Value: aggOperator(ram.Aggregation)},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "_id.time", Value: -1},
+			{Key: "_id.name", Value: 1},
+			{Key: "_id.publisher", Value: 1},
+		}}},
+		{{Key: "$skip", Value: int64(ram.Offset)}},
+		{{Key: "$limit", Value: int64(ram.Limit)}},
+	}
+
+	cursor, err := repo.db.Collection(defCollection).Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return readers.AggregatesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	page := readers.AggregatesPage{
+		AggregationMetadata: ram,
+		Aggregates:          []readers.Aggregate{},
+	}
+	for cursor.Next(context.Background()) {
+		var res aggregate
+		if err := cursor.Decode(&res); err != nil {
+			return readers.AggregatesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Aggregates = append(page.Aggregates, readers.Aggregate{
+			Time:      res.ID.Time,
+			Name:      res.ID.Name,
+			Publisher: res.ID.Publisher,
+			Value:     res.Value,
+		})
+	}
+
+	return page, nil
 }
 
-func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
+func aggOperator(aggregation string) bson.M {
+	switch aggregation {
+	case readers.MinAggregation:
+		return bson.M{"$min": "$value"}
+	case readers.MaxAggregation:
+		return bson.M{"$max": "$value"}
+	case readers.SumAggregation:
+		return bson.M{"$sum": "$value"}
+	case readers.CountAggregation:
+		return bson.M{"$sum": 1}
+	default:
+		return bson.M{"$avg": "$value"}
+	}
+}
+
+// keyset represents the sort key of the last message on the page.
+type keyset struct {
+	Time      float64 `json:"time,omitempty"`
+	Created   int64   `json:"created,omitempty"`
+	Publisher string  `json:"publisher"`
+	Name      string  `json:"name,omitempty"`
+	Channel   string  `json:"channel"`
+}
+
+func newKeyset(doc map[string]interface{}) keyset {
+	var k keyset
+	switch c := doc["created"].(type) {
+	case int64:
+		k.Created = c
+	case int32:
+		k.Created = int64(c)
+	case float64:
+		k.Created = int64(c)
+	}
+	k.Publisher, _ = doc["publisher"].(string)
+	k.Name, _ = doc["name"].(string)
+	k.Channel, _ = doc["channel"].(string)
+
+	return k
+}
+
+// filter returns the filter which matches messages sorted after the keyset.
+func (k keyset) filter(senmlFormat bool) bson.M {
+	if !senmlFormat {
+		return bson.M{"$or": bson.A{
+			bson.M{"created": bson.M{"$lt": k.Created}},
+			bson.M{"created": k.Created, "publisher": bson.M{"$lt": k.Publisher}},
+			bson.M{"created": k.Created, "publisher": k.Publisher, "channel": bson.M{"$lt": k.Channel}},
+		}}
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{"time": bson.M{"$lt": k.Time}},
+		bson.M{"time": k.Time, "publisher": bson.M{"$lt": k.Publisher}},
+		bson.M{"time": k.Time, "publisher": k.Publisher, "name": bson.M{"$lt": k.Name}},
+		bson.M{"time": k.Time, "publisher": k.Publisher, "name": k.Name, "channel": bson.M{"$lt": k.Channel}},
+	}}
+}
+
+type aggregate struct {
+	ID struct {
+		Time      float64 `bson:"time"`
+		Name      string  `bson:"name"`
+		Publisher string  `bson:"publisher"`
+	} `bson:"_id"`
+	Value float64 `bson:"value"`
+}
+
+func fmtCondition(chanIDs []string, rpm readers.PageMetadata) bson.D {
+	channel := interface{}(bson.M{"$in": chanIDs})
+	if len(chanIDs) == 1 {
+		channel = chanIDs[0]
+	}
 	filter := bson.D{
 		bson.E{
 			Key:   "channel",
-			Value: chanID,
+			Value: channel,
 		},
 	}
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	pwriter "github.com/mainflux/mainflux/consumers/writers/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -667,6 +668,260 @@ func TestReadJSON(t *testing.T) {
 	}
 }
 
+func TestReadAggregates(t *testing.T) {
+	writer := pwriter.New(db)
+
+	chanID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Align start to the hour so that every 10 minutes bucket holds 10 messages.
+	start := float64(time.Now().Unix() / 3600 * 3600)
+	messages := []senml.Message{}
+	for i := 0; i < 20; i++ {
+		val := float64(i)
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      start + float64(i*60),
+			Value:     &val,
+		}
+		if i%2 == 1 {
+			msg.Publisher = pubID2
+		}
+		messages = append(messages, msg)
+	}
+
+	err = writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+
+	first, second := start, start+600
+
+	cases := []struct {
+		desc    string
+		aggMeta readers.AggregationMetadata
+		res     []readers.Aggregate
+	}{
+		{
+			desc: "read average aggregates",
+			aggMeta: readers.AggregationMetadata{
+				PageMetadata: readers.PageMetadata{Limit: limit},
+				Aggregation:  readers.AvgAggregation,
+				Interval:     "10m",
+			},
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 14.5},
+				{Time: first, Name: msgName, Value: 4.5},
+			},
+		},
+		{
+			desc: "read min aggregates",
+			aggMeta: readers.AggregationMetadata{
+				PageMetadata: readers.PageMetadata{Limit: limit},
+				Aggregation:  readers.MinAggregation,
+				Interval:     "10m",
+			},
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 10},
+				{Time: first, Name: msgName, Value: 0},
+			},
+		},
+		{
+			desc: "read max aggregates",
+			aggMeta: readers.AggregationMetadata{
+				PageMetadata: readers.PageMetadata{Limit: limit},
+				Aggregation:  readers.MaxAggregation,
+				Interval:     "10m",
+			},
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 19},
+				{Time: first, Name: msgName, Value: 9},
+			},
+		},
+		{
+			desc: "read sum aggregates",
+			aggMeta: readers.AggregationMetadata{
+				PageMetadata: readers.PageMetadata{Limit: limit},
+				Aggregation:  readers.SumAggregation,
+				Interval:     "10m",
+			},
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 145},
+				{Time: first, Name: msgName, Value: 45},
+			},
+		},
+		{
+			desc: "read count aggregates grouped by publisher",
+			aggMeta: readers.AggregationMetadata{
+				PageMetadata: readers.PageMetadata{Limit: limit},
+				Aggregation:  readers.CountAggregation,
+				Interval:     "10m",
+				ByPublisher:  true,
+			},
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Publisher: pubID, Value: 5},
+				{Time: second, Name: msgName, Publisher: pubID2, Value: 5},
+				{Time: first, Name: msgName, Publisher: pubID, Value: 5},
+				{Time: first, Name: msgName, Publisher: pubID2, Value: 5},
+			},
+		},
+		{
+			desc: "read count aggregates with time range",
+			aggMeta: readers.AggregationMetadata{
+				PageMetadata: readers.PageMetadata{
+					Limit: limit,
+					From:  start + 300,
+					To:    start + 900,
+				},
+				Aggregation: readers.CountAggregation,
+				Interval:    "10m",
+			},
+			res: []readers.Aggregate{
+				{Time: second, Name: msgName, Value: 5},
+				{Time: first, Name: msgName, Value: 5},
+			},
+		},
+		{
+			desc: "read aggregates with offset and limit",
+			aggMeta: readers.AggregationMetadata{
+				PageMetadata: readers.PageMetadata{
+					Offset: 1,
+					Limit:  1,
+				},
+				Aggregation: readers.AvgAggregation,
+				Interval:    "10m",
+			},
+			res: []readers.Aggregate{
+				{Time: first, Name: msgName, Value: 4.5},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := reader.ReadAggregates(chanID, tc.aggMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.res, page.Aggregates, fmt.Sprintf("%s: got incorrect aggregates from ReadAggregates()", tc.desc))
+	}
+}
+
+func TestReadSenmlWithCursor(t *testing.T) {
+	writer := pwriter.New(db)
+
+	chanID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      now - float64(i),
+			Value:     &v,
+		})
+	}
+
+	err = writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+
+	// Walk all the pages following the next cursor.
+	var read []readers.Message
+	pm := readers.PageMetadata{
+		Limit:     limit,
+		SkipTotal: true,
+	}
+	for pages := 0; pages <= msgsNum/limit; pages++ {
+		page, err := reader.ReadAll(chanID, pm)
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+		assert.Equal(t, uint64(0), page.Total, "expected total to be skipped")
+
+		read = append(read, page.Messages...)
+		if page.NextCursor == "" {
+			break
+		}
+		pm.Cursor = page.NextCursor
+	}
+	assert.ElementsMatch(t, fromSenml(messages), read, "got incorrect messages walking the pages by cursor")
+
+	_, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: limit, Cursor: "invalid"})
+	assert.True(t, errors.Contains(err, readers.ErrInvalidCursor), fmt.Sprintf("expected %s got %s", readers.ErrInvalidCursor, err))
+}
+
+func TestReadChannels(t *testing.T) {
+	writer := pwriter.New(db)
+
+	var err error
+	chanIDs := make([]string, 2)
+	for i := range chanIDs {
+		chanIDs[i], err = idProvider.ID()
+		assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+	pubID, err := idProvider.ID()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanIDs[i%len(chanIDs)],
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      now - float64(i),
+			Value:     &v,
+		})
+	}
+
+	err = writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+
+	page, err := reader.ReadChannels(chanIDs, readers.PageMetadata{Limit: msgsNum})
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, uint64(msgsNum), page.Total, fmt.Sprintf("expected total %d got %d", msgsNum, page.Total))
+	assert.ElementsMatch(t, fromSenml(messages), page.Messages, "got incorrect messages of multiple channels")
+	for i := 1; i < len(page.Messages); i++ {
+		prev, cur := page.Messages[i-1].(senml.Message), page.Messages[i].(senml.Message)
+		assert.GreaterOrEqual(t, prev.Time, cur.Time, "expected messages of multiple channels to be ordered by time")
+	}
+
+	// Walk all the pages following the next cursor.
+	var read []readers.Message
+	pm := readers.PageMetadata{
+		Limit:     limit,
+		SkipTotal: true,
+	}
+	for pages := 0; pages <= msgsNum/limit; pages++ {
+		page, err := reader.ReadChannels(chanIDs, pm)
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+		read = append(read, page.Messages...)
+		if page.NextCursor == "" {
+			break
+		}
+		pm.Cursor = page.NextCursor
+	}
+	assert.ElementsMatch(t, fromSenml(messages), read, "got incorrect messages walking the pages of multiple channels by cursor")
+
+	page, err = reader.ReadChannels(chanIDs[:1], readers.PageMetadata{Limit: msgsNum})
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, uint64(msgsNum/2), page.Total, fmt.Sprintf("expected total %d got %d", msgsNum/2, page.Total))
+}
+
 func fromSenml(msg []senml.Message) []readers.Message {
 	var ret []readers.Message
 	for _, m := range msg {
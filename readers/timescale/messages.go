@@ -6,6 +6,7 @@ package timescale
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -29,32 +30,42 @@ func New(db *sqlx.DB) readers.MessageRepository {
 }
 
 func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	order := "time"
+	return tr.ReadChannels([]string{chanID}, rpm)
+}
+
+func (tr timescaleRepository) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	order := "time DESC, publisher DESC, name DESC, channel DESC"
+	keyset := "(time, publisher, name, channel) < (:cursor_time, :cursor_publisher, :cursor_name, :cursor_channel)"
 	format := defTable
 
 	if rpm.Format != "" && rpm.Format != defTable {
-		order = "created"
+		order = "created DESC, publisher DESC, channel DESC"
+		keyset = "(created, publisher, channel) < (:cursor_created, :cursor_publisher, :cursor_channel)"
 		format = rpm.Format
 	}
+	cond := fmtCondition(chanIDs, rpm)
+	params := queryParams(chanIDs, rpm)
 
-	q := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY %s DESC LIMIT :limit OFFSET :offset;`, format, fmtCondition(chanID, rpm), order)
-
-	params := map[string]interface{}{
-		"channel":      chanID,
-		"limit":        rpm.Limit,
-		"offset":       rpm.Offset,
-		"subtopic":     rpm.Subtopic,
-		"publisher":    rpm.Publisher,
-		"name":         rpm.Name,
-		"protocol":     rpm.Protocol,
-		"value":        rpm.Value,
-		"bool_value":   rpm.BoolValue,
-		"string_value": rpm.StringValue,
-		"data_value":   rpm.DataValue,
-		"from":         rpm.From,
-		"to":           rpm.To,
+	pageCond := cond
+	pagination := "LIMIT :limit OFFSET :offset"
+	if rpm.Cursor != "" {
+		var c cursor
+		if err := readers.DecodeCursor(rpm.Cursor, &c); err != nil {
+			return readers.MessagesPage{}, err
+		}
+		pageCond = fmt.Sprintf("%s AND %s", cond, keyset)
+		pagination = "LIMIT :limit"
+		params["cursor_time"] = c.Time
+		params["cursor_created"] = c.Created
+		params["cursor_publisher"] = c.Publisher
+		params["cursor_name"] = c.Name
+		params["cursor_channel"] = c.Channel
 	}
 
+	q := fmt.Sprintf(`SELECT * FROM %s
+    WHERE %s ORDER BY %s
+	%s;`, format, pageCond, order, pagination)
+
 	rows, err := tr.db.NamedQuery(q, params)
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
@@ -70,6 +81,7 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		PageMetadata: rpm,
 		Messages:     []readers.Message{},
 	}
+	var last cursor
 	switch format {
 	case defTable:
 		for rows.Next() {
@@ -79,6 +91,7 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 			}
 
 			page.Messages = append(page.Messages, msg.Message)
+			last = cursor{Time: msg.Time, Publisher: msg.Publisher, Name: msg.Name, Channel: msg.Channel}
 		}
 	default:
 		for rows.Next() {
@@ -91,11 +104,22 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 			}
 			page.Messages = append(page.Messages, m)
+			last = cursor{Created: msg.Created, Publisher: msg.Publisher, Channel: msg.Channel}
 		}
 
 	}
 
-	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, fmtCondition(chanID, rpm))
+	if rpm.Limit > 0 && uint64(len(page.Messages)) == rpm.Limit {
+		if page.NextCursor, err = readers.EncodeCursor(last); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
+	if rpm.SkipTotal {
+		return page, nil
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, cond)
 	rows, err = tr.db.NamedQuery(q, params)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
@@ -113,8 +137,96 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 	return page, nil
 }
 
-func fmtCondition(chanID string, rpm readers.PageMetadata) string {
-	condition := `channel = :channel`
+func (tr timescaleRepository) ReadAggregates(chanID string, ram readers.AggregationMetadata) (readers.AggregatesPage, error) {
+	interval, err := readers.ParseInterval(ram.Interval)
+	if err != nil {
+		return readers.AggregatesPage{}, err
+	}
+
+	groupBy := "bucket, name"
+	publisher := "''"
+	if ram.ByPublisher {
+		groupBy = "bucket, name, publisher"
+		publisher = "publisher"
+	}
+
+	q := fmt.Sprintf(`SELECT time_bucket(CAST(:interval AS BIGINT), time) AS bucket, name, %s AS publisher, %s(value) AS value
+	FROM %s WHERE %s AND value IS NOT NULL
+	GROUP BY %s ORDER BY bucket DESC, name, publisher
+	LIMIT :limit OFFSET :offset;`, publisher, aggFunction(ram.Aggregation), defTable, fmtCondition([]string{chanID}, ram.PageMetadata), groupBy)
+
+	params := queryParams([]string{chanID}, ram.PageMetadata)
+	params["interval"] = interval
+
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return readers.AggregatesPage{}, nil
+			}
+		}
+		return readers.AggregatesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.AggregatesPage{
+		AggregationMetadata: ram,
+		Aggregates:          []readers.Aggregate{},
+	}
+	for rows.Next() {
+		var agg readers.Aggregate
+		if err := rows.Scan(&agg.Time, &agg.Name, &agg.Publisher, &agg.Value); err != nil {
+			return readers.AggregatesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Aggregates = append(page.Aggregates, agg)
+	}
+
+	return page, nil
+}
+
+func aggFunction(aggregation string) string {
+	switch aggregation {
+	case readers.MinAggregation:
+		return "MIN"
+	case readers.MaxAggregation:
+		return "MAX"
+	case readers.SumAggregation:
+		return "SUM"
+	case readers.CountAggregation:
+		return "COUNT"
+	default:
+		return "AVG"
+	}
+}
+
+func queryParams(chanIDs []string, rpm readers.PageMetadata) map[string]interface{} {
+	params := map[string]interface{}{
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+	for i, id := range chanIDs {
+		params[fmt.Sprintf("channel_%d", i)] = id
+	}
+
+	return params
+}
+
+func fmtCondition(chanIDs []string, rpm readers.PageMetadata) string {
+	channels := make([]string, len(chanIDs))
+	for i := range chanIDs {
+		channels[i] = fmt.Sprintf(":channel_%d", i)
+	}
+	condition := fmt.Sprintf(`channel IN (%s)`, strings.Join(channels, ", "))
 
 	var query map[string]interface{}
 	meta, err := json.Marshal(rpm)
@@ -164,6 +276,15 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	return condition
 }
 
+// cursor represents the keyset of the last message on the page.
+type cursor struct {
+	Time      float64 `json:"time,omitempty"`
+	Created   int64   `json:"created,omitempty"`
+	Publisher string  `json:"publisher"`
+	Name      string  `json:"name,omitempty"`
+	Channel   string  `json:"channel"`
+}
+
 type senmlMessage struct {
 	ID string `db:"id"`
 	senml.Message
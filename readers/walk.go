@@ -0,0 +1,33 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+// Walk reads all the messages of the given channel that match the page
+// metadata, in chunks of the page metadata limit, and passes every chunk to
+// the given function. Pages are followed using cursors and the total count
+// is skipped. Walking stops on the first error.
+func Walk(repo MessageRepository, chanID string, pm PageMetadata, fn func([]Message) error) error {
+	if pm.Limit == 0 {
+		return nil
+	}
+	pm.SkipTotal = true
+
+	for {
+		page, err := repo.ReadAll(chanID, pm)
+		if err != nil {
+			return err
+		}
+		if len(page.Messages) > 0 {
+			if err := fn(page.Messages); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+		pm.Offset = 0
+		pm.Cursor = page.NextCursor
+	}
+}
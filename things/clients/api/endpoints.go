@@ -11,6 +11,7 @@ import (
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things/clients"
+	"github.com/mainflux/mainflux/things/clients/postgres"
 )
 
 func createClientEndpoint(svc clients.Service) endpoint.Endpoint {
@@ -86,6 +87,7 @@ func listClientsEndpoint(svc clients.Service) endpoint.Endpoint {
 			Name:     req.name,
 			Tag:      req.tag,
 			Metadata: req.metadata,
+			Filter:   req.filter,
 		}
 		page, err := svc.ListClients(ctx, req.token, pm)
 		if err != nil {
@@ -223,6 +225,126 @@ func disableClientEndpoint(svc clients.Service) endpoint.Endpoint {
 	}
 }
 
+func deleteClientEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteClientReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := svc.DeleteClient(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+		return removeClientRes{}, nil
+	}
+}
+
+func addClientSecretEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(addClientSecretReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		secret, err := svc.AddClientSecret(ctx, req.token, req.id, req.secret())
+		if err != nil {
+			return nil, err
+		}
+		return addClientSecretRes{Secret: secret}, nil
+	}
+}
+
+func listClientSecretsEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(clientSecretReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		secrets, err := svc.ListClientSecrets(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+		res := clientSecretsRes{Secrets: []viewClientSecretRes{}}
+		for _, s := range secrets {
+			res.Secrets = append(res.Secrets, viewClientSecretRes{Secret: s})
+		}
+		return res, nil
+	}
+}
+
+func updateClientSecretExpiryEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateClientSecretExpiryReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		secret, err := svc.UpdateClientSecretExpiry(ctx, req.token, req.id, req.name, expiresAt(req.Duration))
+		if err != nil {
+			return nil, err
+		}
+		return viewClientSecretRes{Secret: secret}, nil
+	}
+}
+
+func removeClientSecretEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(clientSecretReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := svc.RemoveClientSecret(ctx, req.token, req.id, req.name); err != nil {
+			return nil, err
+		}
+		return removeClientSecretRes{}, nil
+	}
+}
+
+func executeOperationsEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(executeOperationsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		// Invalid rows are reported without being sent to the service.
+		res := operationsRes{Total: len(req.Operations), Results: make([]operationRes, len(req.Operations))}
+		var ops []postgres.Operation
+		var rows []int
+		for i, o := range req.Operations {
+			res.Results[i] = operationRes{Row: i + 1, Op: o.Op, ID: o.ID}
+			op, err := o.operation()
+			if err != nil {
+				res.Results[i].Error = err.Error()
+				continue
+			}
+			ops = append(ops, op)
+			rows = append(rows, i)
+		}
+		if len(ops) > 0 {
+			results, err := svc.ExecuteOperations(ctx, req.token, ops...)
+			if err != nil {
+				return nil, err
+			}
+			for i, r := range results {
+				row := &res.Results[rows[i]]
+				if r.Err != nil {
+					row.Error = r.Err.Error()
+					continue
+				}
+				row.ID = r.Client.ID
+				if ops[i].Kind == postgres.CreateOp {
+					row.Secret = r.Client.Credentials.Secret
+				}
+			}
+		}
+		for _, r := range res.Results {
+			if r.Error != "" {
+				res.Failed++
+			}
+		}
+
+		return res, nil
+	}
+}
+
 func buildMembersResponse(cp mfclients.MembersPage) memberPageRes {
 	res := memberPageRes{
 		pageRes: pageRes{
@@ -11,6 +11,7 @@ import (
 	mflog "github.com/mainflux/mainflux/logger"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/things/clients"
+	"github.com/mainflux/mainflux/things/clients/postgres"
 )
 
 var _ clients.Service = (*loggingMiddleware)(nil)
@@ -132,6 +133,78 @@ func (lm *loggingMiddleware) DisableClient(ctx context.Context, token string, id
 	return lm.svc.DisableClient(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) DeleteClient(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method delete_thing for thing with id %s using token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.DeleteClient(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) AddClientSecret(ctx context.Context, token, id string, secret postgres.Secret) (s postgres.Secret, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_thing_secret %s for thing with id %s using token %s took %s to complete", secret.Name, id, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.AddClientSecret(ctx, token, id, secret)
+}
+
+func (lm *loggingMiddleware) ListClientSecrets(ctx context.Context, token, id string) (secrets []postgres.Secret, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_thing_secrets for thing with id %s using token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ListClientSecrets(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) UpdateClientSecretExpiry(ctx context.Context, token, id, name string, expiresAt time.Time) (s postgres.Secret, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_thing_secret_expiry %s for thing with id %s using token %s took %s to complete", name, id, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.UpdateClientSecretExpiry(ctx, token, id, name, expiresAt)
+}
+
+func (lm *loggingMiddleware) RemoveClientSecret(ctx context.Context, token, id, name string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_thing_secret %s for thing with id %s using token %s took %s to complete", name, id, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.RemoveClientSecret(ctx, token, id, name)
+}
+
+func (lm *loggingMiddleware) ExecuteOperations(ctx context.Context, token string, ops ...postgres.Operation) (res []postgres.OperationResult, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method execute_thing_operations of %d operations using token %s took %s to complete", len(ops), token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ExecuteOperations(ctx, token, ops...)
+}
+
 func (lm *loggingMiddleware) ListClientsByGroup(ctx context.Context, token, channelID string, cp mfclients.Page) (mp mfclients.MembersPage, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method list_things_by_channel for channel with id %s using token %s took %s to complete", channelID, token, time.Since(begin))
@@ -10,6 +10,7 @@ import (
 	"github.com/go-kit/kit/metrics"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/things/clients"
+	"github.com/mainflux/mainflux/things/clients/postgres"
 )
 
 var _ clients.Service = (*metricsMiddleware)(nil)
@@ -101,6 +102,54 @@ func (ms *metricsMiddleware) DisableClient(ctx context.Context, token string, id
 	return ms.svc.DisableClient(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) DeleteClient(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_thing").Add(1)
+		ms.latency.With("method", "delete_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteClient(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) AddClientSecret(ctx context.Context, token, id string, secret postgres.Secret) (postgres.Secret, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_thing_secret").Add(1)
+		ms.latency.With("method", "add_thing_secret").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.AddClientSecret(ctx, token, id, secret)
+}
+
+func (ms *metricsMiddleware) ListClientSecrets(ctx context.Context, token, id string) ([]postgres.Secret, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_thing_secrets").Add(1)
+		ms.latency.With("method", "list_thing_secrets").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListClientSecrets(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) UpdateClientSecretExpiry(ctx context.Context, token, id, name string, expiresAt time.Time) (postgres.Secret, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_thing_secret_expiry").Add(1)
+		ms.latency.With("method", "update_thing_secret_expiry").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UpdateClientSecretExpiry(ctx, token, id, name, expiresAt)
+}
+
+func (ms *metricsMiddleware) RemoveClientSecret(ctx context.Context, token, id, name string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_thing_secret").Add(1)
+		ms.latency.With("method", "remove_thing_secret").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RemoveClientSecret(ctx, token, id, name)
+}
+
+func (ms *metricsMiddleware) ExecuteOperations(ctx context.Context, token string, ops ...postgres.Operation) ([]postgres.OperationResult, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "execute_thing_operations").Add(1)
+		ms.latency.With("method", "execute_thing_operations").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ExecuteOperations(ctx, token, ops...)
+}
+
 func (ms *metricsMiddleware) ListClientsByGroup(ctx context.Context, token, groupID string, pm mfclients.Page) (mp mfclients.MembersPage, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_things_by_channel").Add(1)
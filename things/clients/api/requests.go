@@ -4,9 +4,14 @@
 package api
 
 import (
+	"time"
+
 	"github.com/mainflux/mainflux/internal/api"
 	"github.com/mainflux/mainflux/internal/apiutil"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things/clients"
+	"github.com/mainflux/mainflux/things/clients/postgres"
 )
 
 type createClientReq struct {
@@ -77,6 +82,7 @@ type listClientsReq struct {
 	sharedBy   string
 	visibility string
 	metadata   mfclients.Metadata
+	filter     mfclients.Filter
 }
 
 func (req listClientsReq) validate() error {
@@ -197,6 +203,108 @@ func (req updateClientCredentialsReq) validate() error {
 	return nil
 }
 
+type addClientSecretReq struct {
+	token    string
+	id       string
+	Name     string `json:"name,omitempty"`
+	Secret   string `json:"secret,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+func (req addClientSecretReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Name == "" || len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return validateDuration(req.Duration)
+}
+
+// secret returns the secret to be added. The duration is expected to be validated.
+func (req addClientSecretReq) secret() postgres.Secret {
+	return postgres.Secret{
+		Name:      req.Name,
+		Secret:    req.Secret,
+		ExpiresAt: expiresAt(req.Duration),
+	}
+}
+
+type updateClientSecretExpiryReq struct {
+	token    string
+	id       string
+	name     string
+	Duration string `json:"duration,omitempty"`
+}
+
+func (req updateClientSecretExpiryReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.name == "" {
+		return apiutil.ErrNameSize
+	}
+
+	return validateDuration(req.Duration)
+}
+
+type clientSecretReq struct {
+	token string
+	id    string
+	name  string
+}
+
+func (req clientSecretReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+// validateDuration accepts an empty duration, which means the secret
+// never expires, or a positive one.
+func validateDuration(duration string) error {
+	if duration == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(duration); err != nil || d <= 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, clients.ErrInvalidExpiry)
+	}
+
+	return nil
+}
+
+func expiresAt(duration string) time.Time {
+	if d, err := time.ParseDuration(duration); err == nil {
+		return time.Now().Add(d)
+	}
+
+	return time.Time{}
+}
+
+type deleteClientReq struct {
+	token string
+	id    string
+}
+
+func (req deleteClientReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	return nil
+}
+
 type changeClientStatusReq struct {
 	token string
 	id    string
@@ -208,3 +316,74 @@ func (req changeClientStatusReq) validate() error {
 	}
 	return nil
 }
+
+type operationReq struct {
+	Op       string                 `json:"op"`
+	ID       string                 `json:"id,omitempty"`
+	Name     string                 `json:"name,omitempty"`
+	Secret   string                 `json:"secret,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status,omitempty"`
+}
+
+// operation validates the requested operation and converts it to the one
+// executed by the service.
+func (req operationReq) operation() (postgres.Operation, error) {
+	switch req.Op {
+	case postgres.CreateOp:
+		if req.ID != "" {
+			if err := api.ValidateUUID(req.ID); err != nil {
+				return postgres.Operation{}, err
+			}
+		}
+	case postgres.UpdateOp, postgres.EnableOp, postgres.DisableOp:
+		if req.ID == "" {
+			return postgres.Operation{}, apiutil.ErrMissingID
+		}
+	default:
+		return postgres.Operation{}, postgres.ErrInvalidOperation
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return postgres.Operation{}, apiutil.ErrNameSize
+	}
+	status := mfclients.EnabledStatus
+	if req.Status != "" {
+		st, err := mfclients.ToStatus(req.Status)
+		if err != nil {
+			return postgres.Operation{}, err
+		}
+		status = st
+	}
+
+	return postgres.Operation{
+		Kind: req.Op,
+		Client: mfclients.Client{
+			ID:          req.ID,
+			Name:        req.Name,
+			Tags:        req.Tags,
+			Metadata:    req.Metadata,
+			Credentials: mfclients.Credentials{Secret: req.Secret},
+			Status:      status,
+		},
+	}, nil
+}
+
+type executeOperationsReq struct {
+	token      string
+	Operations []operationReq `json:"operations"`
+}
+
+func (req executeOperationsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.Operations) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	if len(req.Operations) > api.MaxOperations {
+		return errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrTooManyOperations)
+	}
+
+	return nil
+}
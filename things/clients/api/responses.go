@@ -9,15 +9,22 @@ import (
 
 	"github.com/mainflux/mainflux"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/things/clients/postgres"
 )
 
 var (
 	_ mainflux.Response = (*viewClientRes)(nil)
 	_ mainflux.Response = (*createClientRes)(nil)
 	_ mainflux.Response = (*deleteClientRes)(nil)
+	_ mainflux.Response = (*removeClientRes)(nil)
 	_ mainflux.Response = (*clientsPageRes)(nil)
 	_ mainflux.Response = (*viewMembersRes)(nil)
 	_ mainflux.Response = (*memberPageRes)(nil)
+	_ mainflux.Response = (*addClientSecretRes)(nil)
+	_ mainflux.Response = (*viewClientSecretRes)(nil)
+	_ mainflux.Response = (*clientSecretsRes)(nil)
+	_ mainflux.Response = (*removeClientSecretRes)(nil)
+	_ mainflux.Response = (*operationsRes)(nil)
 )
 
 type pageRes struct {
@@ -150,3 +157,105 @@ func (res deleteClientRes) Headers() map[string]string {
 func (res deleteClientRes) Empty() bool {
 	return false
 }
+
+type removeClientRes struct{}
+
+func (res removeClientRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeClientRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeClientRes) Empty() bool {
+	return true
+}
+
+type addClientSecretRes struct {
+	postgres.Secret `json:",inline"`
+}
+
+func (res addClientSecretRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res addClientSecretRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res addClientSecretRes) Empty() bool {
+	return false
+}
+
+type viewClientSecretRes struct {
+	postgres.Secret `json:",inline"`
+}
+
+func (res viewClientSecretRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewClientSecretRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewClientSecretRes) Empty() bool {
+	return false
+}
+
+type clientSecretsRes struct {
+	Secrets []viewClientSecretRes `json:"secrets"`
+}
+
+func (res clientSecretsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res clientSecretsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res clientSecretsRes) Empty() bool {
+	return false
+}
+
+type removeClientSecretRes struct{}
+
+func (res removeClientSecretRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeClientSecretRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeClientSecretRes) Empty() bool {
+	return true
+}
+
+type operationRes struct {
+	Row    int    `json:"row"`
+	Op     string `json:"op"`
+	ID     string `json:"id,omitempty"`
+	Secret string `json:"secret,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type operationsRes struct {
+	Total   int            `json:"total"`
+	Failed  int            `json:"failed"`
+	Results []operationRes `json:"results"`
+}
+
+func (res operationsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res operationsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res operationsRes) Empty() bool {
+	return false
+}
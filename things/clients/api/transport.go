@@ -42,6 +42,13 @@ func MakeHandler(svc clients.Service, mux *bone.Mux, logger mflog.Logger, instan
 		opts...,
 	), "create_things"))
 
+	mux.Post("/things/bulk/operations", otelhttp.NewHandler(kithttp.NewServer(
+		executeOperationsEndpoint(svc),
+		decodeExecuteOperations,
+		api.EncodeResponse,
+		opts...,
+	), "execute_thing_operations"))
+
 	mux.Get("/things/:thingID", otelhttp.NewHandler(kithttp.NewServer(
 		viewClientEndpoint(svc),
 		decodeViewClient,
@@ -105,6 +112,41 @@ func MakeHandler(svc clients.Service, mux *bone.Mux, logger mflog.Logger, instan
 		opts...,
 	), "disable_thing"))
 
+	mux.Delete("/things/:thingID", otelhttp.NewHandler(kithttp.NewServer(
+		deleteClientEndpoint(svc),
+		decodeDeleteClient,
+		api.EncodeResponse,
+		opts...,
+	), "delete_thing"))
+
+	mux.Post("/things/:thingID/secrets", otelhttp.NewHandler(kithttp.NewServer(
+		addClientSecretEndpoint(svc),
+		decodeAddClientSecret,
+		api.EncodeResponse,
+		opts...,
+	), "add_thing_secret"))
+
+	mux.Get("/things/:thingID/secrets", otelhttp.NewHandler(kithttp.NewServer(
+		listClientSecretsEndpoint(svc),
+		decodeClientSecret,
+		api.EncodeResponse,
+		opts...,
+	), "list_thing_secrets"))
+
+	mux.Patch("/things/:thingID/secrets/:name", otelhttp.NewHandler(kithttp.NewServer(
+		updateClientSecretExpiryEndpoint(svc),
+		decodeUpdateClientSecretExpiry,
+		api.EncodeResponse,
+		opts...,
+	), "update_thing_secret_expiry"))
+
+	mux.Delete("/things/:thingID/secrets/:name", otelhttp.NewHandler(kithttp.NewServer(
+		removeClientSecretEndpoint(svc),
+		decodeClientSecret,
+		api.EncodeResponse,
+		opts...,
+	), "remove_thing_secret"))
+
 	mux.GetFunc("/health", mainflux.Health("things", instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
 	return mux
@@ -149,6 +191,14 @@ func decodeListClients(_ context.Context, r *http.Request) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
+	f, err := apiutil.ReadRawStringQuery(r, api.FilterKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	filter, err := mfclients.ParseFilter(f)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	visibility, err := apiutil.ReadStringQuery(r, api.VisibilityKey, "")
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
@@ -179,6 +229,7 @@ func decodeListClients(_ context.Context, r *http.Request) (interface{}, error)
 		tag:      t,
 		sharedBy: sharedID,
 		owner:    ownerID,
+		filter:   filter,
 	}
 	return req, nil
 }
@@ -273,6 +324,45 @@ func decodeCreateClientsReq(_ context.Context, r *http.Request) (interface{}, er
 	return c, nil
 }
 
+// decodeExecuteOperations decodes operations given either as JSON or as CSV
+// with a header row. CSV tags are comma separated and metadata is JSON.
+func decodeExecuteOperations(_ context.Context, r *http.Request) (interface{}, error) {
+	req := executeOperationsReq{token: apiutil.ExtractBearerToken(r)}
+	switch ct := r.Header.Get("Content-Type"); {
+	case strings.Contains(ct, api.ContentType):
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+	case strings.Contains(ct, api.CSVContentType):
+		records, err := api.ReadCSV(r.Body, "op", "id", "name", "secret", "tags", "metadata", "status")
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		for _, rec := range records {
+			op := operationReq{
+				Op:     rec["op"],
+				ID:     rec["id"],
+				Name:   rec["name"],
+				Secret: rec["secret"],
+				Status: rec["status"],
+			}
+			if tags, ok := rec["tags"]; ok {
+				op.Tags = strings.Split(tags, ",")
+			}
+			if m, ok := rec["metadata"]; ok {
+				if err := json.Unmarshal([]byte(m), &op.Metadata); err != nil {
+					return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+				}
+			}
+			req.Operations = append(req.Operations, op)
+		}
+	default:
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	return req, nil
+}
+
 func decodeChangeClientStatus(_ context.Context, r *http.Request) (interface{}, error) {
 	req := changeClientStatusReq{
 		token: apiutil.ExtractBearerToken(r),
@@ -282,6 +372,56 @@ func decodeChangeClientStatus(_ context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
+func decodeDeleteClient(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deleteClientReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "thingID"),
+	}
+
+	return req, nil
+}
+
+func decodeAddClientSecret(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+	req := addClientSecretReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "thingID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeUpdateClientSecretExpiry(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+	req := updateClientSecretExpiryReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "thingID"),
+		name:  bone.GetValue(r, "name"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeClientSecret(_ context.Context, r *http.Request) (interface{}, error) {
+	req := clientSecretReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "thingID"),
+		name:  bone.GetValue(r, "name"),
+	}
+
+	return req, nil
+}
+
 func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	s, err := apiutil.ReadStringQuery(r, api.StatusKey, api.DefClientStatus)
 	if err != nil {
@@ -299,6 +439,14 @@ func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{},
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	f, err := apiutil.ReadRawStringQuery(r, api.FilterKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	filter, err := mfclients.ParseFilter(f)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	st, err := mfclients.ToStatus(s)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
@@ -310,6 +458,7 @@ func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{},
 			Offset:   o,
 			Limit:    l,
 			Metadata: m,
+			Filter:   filter,
 		},
 		groupID: bone.GetValue(r, "chanID"),
 	}
@@ -15,7 +15,9 @@ import (
 
 const (
 	keyPrefix = "thing_key"
-	idPrefix  = "thing_id"
+	// idPrefix keys hold the set of all cached secrets of the thing,
+	// since a thing can hold several active secrets at once.
+	idPrefix = "thing_keys"
 )
 
 var _ clients.Cache = (*thingCache)(nil)
@@ -40,7 +42,10 @@ func (tc *thingCache) Save(ctx context.Context, thingKey string, thingID string)
 	}
 
 	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
-	if err := tc.client.Set(ctx, tid, thingKey, tc.keyDuration).Err(); err != nil {
+	if err := tc.client.SAdd(ctx, tid, thingKey).Err(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	if err := tc.client.Expire(ctx, tid, tc.keyDuration).Err(); err != nil {
 		return errors.Wrap(errors.ErrCreateEntity, err)
 	}
 	return nil
@@ -60,17 +65,16 @@ func (tc *thingCache) ID(ctx context.Context, thingKey string) (string, error) {
 
 func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
 	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
-	key, err := tc.client.Get(ctx, tid).Result()
-	// Redis returns Nil Reply when key does not exist.
-	if err == redis.Nil {
-		return nil
-	}
+	keys, err := tc.client.SMembers(ctx, tid).Result()
 	if err != nil {
 		return errors.Wrap(errors.ErrRemoveEntity, err)
 	}
 
-	tkey := fmt.Sprintf("%s:%s", keyPrefix, key)
-	if err := tc.client.Del(ctx, tkey, tid).Err(); err != nil {
+	rkeys := []string{tid}
+	for _, key := range keys {
+		rkeys = append(rkeys, fmt.Sprintf("%s:%s", keyPrefix, key))
+	}
+	if err := tc.client.Del(ctx, rkeys...).Err(); err != nil {
 		return errors.Wrap(errors.ErrRemoveEntity, err)
 	}
 	return nil
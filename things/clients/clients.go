@@ -5,10 +5,16 @@ package clients
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things/clients/postgres"
 )
 
+// ErrInvalidExpiry indicates that the secret expiry is not in the future.
+var ErrInvalidExpiry = errors.New("secret expiry must be in the future")
+
 // Service specifies an API that must be fullfiled by the domain service
 // implementation, and all of its decorators (e.g. logging & metrics).
 type Service interface {
@@ -45,6 +51,31 @@ type Service interface {
 	// DisableClient logically disables the client identified with the provided ID
 	DisableClient(ctx context.Context, token, id string) (clients.Client, error)
 
+	// DeleteClient removes the client identified with the provided ID together
+	// with its policies and cached keys.
+	DeleteClient(ctx context.Context, token, id string) error
+
+	// AddClientSecret adds a named secret to the client. The secret is
+	// generated if not provided and accepted until it expires, alongside
+	// the client's primary secret.
+	AddClientSecret(ctx context.Context, token, id string, secret postgres.Secret) (postgres.Secret, error)
+
+	// ListClientSecrets retrieves all named secrets of the client.
+	ListClientSecrets(ctx context.Context, token, id string) ([]postgres.Secret, error)
+
+	// UpdateClientSecretExpiry sets the expiry of the named client secret,
+	// so that devices still using it have a grace period to switch over.
+	UpdateClientSecretExpiry(ctx context.Context, token, id, name string, expiresAt time.Time) (postgres.Secret, error)
+
+	// RemoveClientSecret revokes the named client secret immediately.
+	RemoveClientSecret(ctx context.Context, token, id, name string) error
+
+	// ExecuteOperations creates, updates, enables and disables clients in
+	// chunks, each chunk in a single transaction. The result of every
+	// operation is returned at the same index as the operation, so that a
+	// failing operation doesn't fail the whole request.
+	ExecuteOperations(ctx context.Context, token string, ops ...postgres.Operation) ([]postgres.OperationResult, error)
+
 	// Identify returns thing ID for given thing key.
 	Identify(ctx context.Context, key string) (string, error)
 }
@@ -57,6 +88,6 @@ type Cache interface {
 	// ID returns thing ID for given thing secret.
 	ID(ctx context.Context, thingSecret string) (string, error)
 
-	// Removes thing from cache.
+	// Removes thing and all of its cached secrets from cache.
 	Remove(ctx context.Context, thingID string) error
 }
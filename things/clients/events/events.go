@@ -11,6 +11,7 @@ import (
 
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/pkg/events"
+	"github.com/mainflux/mainflux/things/clients/postgres"
 )
 
 const (
@@ -22,6 +23,14 @@ const (
 	clientList        = clientPrefix + "list"
 	clientListByGroup = clientPrefix + "list_by_group"
 	clientIdentify    = clientPrefix + "identify"
+
+	clientAddSecret          = clientPrefix + "add_secret"
+	clientUpdateSecretExpiry = clientPrefix + "update_secret_expiry"
+	clientRemoveSecret       = clientPrefix + "remove_secret"
+
+	// deletedStatus marks the remove event of the deleted thing, as
+	// opposed to the disabled one.
+	deletedStatus = "deleted"
 )
 
 var (
@@ -32,6 +41,7 @@ var (
 	_ events.Event = (*listClientEvent)(nil)
 	_ events.Event = (*listClientByGroupEvent)(nil)
 	_ events.Event = (*identifyClientEvent)(nil)
+	_ events.Event = (*clientSecretEvent)(nil)
 )
 
 type createClientEvent struct {
@@ -308,3 +318,27 @@ func (ice identifyClientEvent) Encode() (map[string]interface{}, error) {
 		"thing_id":  ice.thingID,
 	}, nil
 }
+
+// clientSecretEvent describes a change of the thing's named secrets. The
+// secret value itself is never published.
+type clientSecretEvent struct {
+	postgres.Secret
+	operation string
+}
+
+func (cse clientSecretEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": cse.operation,
+		"id":        cse.ClientID,
+		"name":      cse.Name,
+	}
+
+	if !cse.ExpiresAt.IsZero() {
+		val["expires_at"] = cse.ExpiresAt
+	}
+	if !cse.CreatedAt.IsZero() {
+		val["created_at"] = cse.CreatedAt
+	}
+
+	return val, nil
+}
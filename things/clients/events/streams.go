@@ -5,11 +5,13 @@ package events
 
 import (
 	"context"
+	"time"
 
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/pkg/events"
 	"github.com/mainflux/mainflux/pkg/events/redis"
 	"github.com/mainflux/mainflux/things/clients"
+	"github.com/mainflux/mainflux/things/clients/postgres"
 )
 
 const streamID = "mainflux.things"
@@ -165,6 +167,96 @@ func (es *eventStore) DisableClient(ctx context.Context, token, id string) (mfcl
 	return es.delete(ctx, cli)
 }
 
+func (es *eventStore) DeleteClient(ctx context.Context, token, id string) error {
+	if err := es.svc.DeleteClient(ctx, token, id); err != nil {
+		return err
+	}
+	event := removeClientEvent{
+		id:        id,
+		status:    deletedStatus,
+		updatedAt: time.Now(),
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) AddClientSecret(ctx context.Context, token, id string, secret postgres.Secret) (postgres.Secret, error) {
+	secret, err := es.svc.AddClientSecret(ctx, token, id, secret)
+	if err != nil {
+		return secret, err
+	}
+	event := clientSecretEvent{
+		secret, clientAddSecret,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return secret, err
+	}
+
+	return secret, nil
+}
+
+func (es *eventStore) ListClientSecrets(ctx context.Context, token, id string) ([]postgres.Secret, error) {
+	return es.svc.ListClientSecrets(ctx, token, id)
+}
+
+func (es *eventStore) UpdateClientSecretExpiry(ctx context.Context, token, id, name string, expiresAt time.Time) (postgres.Secret, error) {
+	secret, err := es.svc.UpdateClientSecretExpiry(ctx, token, id, name, expiresAt)
+	if err != nil {
+		return secret, err
+	}
+	event := clientSecretEvent{
+		secret, clientUpdateSecretExpiry,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return secret, err
+	}
+
+	return secret, nil
+}
+
+func (es *eventStore) RemoveClientSecret(ctx context.Context, token, id, name string) error {
+	if err := es.svc.RemoveClientSecret(ctx, token, id, name); err != nil {
+		return err
+	}
+	event := clientSecretEvent{
+		postgres.Secret{ClientID: id, Name: name}, clientRemoveSecret,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) ExecuteOperations(ctx context.Context, token string, ops ...postgres.Operation) ([]postgres.OperationResult, error) {
+	results, err := es.svc.ExecuteOperations(ctx, token, ops...)
+	if err != nil {
+		return results, err
+	}
+
+	for i, res := range results {
+		if res.Err != nil {
+			continue
+		}
+		var event events.Event
+		switch ops[i].Kind {
+		case postgres.CreateOp:
+			event = createClientEvent{res.Client}
+		case postgres.UpdateOp:
+			event = updateClientEvent{res.Client, ""}
+		default:
+			event = removeClientEvent{
+				id:        res.Client.ID,
+				updatedAt: res.Client.UpdatedAt,
+				updatedBy: res.Client.UpdatedBy,
+				status:    res.Client.Status.String(),
+			}
+		}
+		if err := es.Publish(ctx, event); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
 func (es *eventStore) delete(ctx context.Context, cli mfclients.Client) (mfclients.Client, error) {
 	event := removeClientEvent{
 		id:        cli.ID,
@@ -8,6 +8,7 @@ import (
 
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/pkg/errors"
+	cpostgres "github.com/mainflux/mainflux/things/clients/postgres"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -38,6 +39,16 @@ func (m *Repository) ChangeStatus(ctx context.Context, client mfclients.Client)
 	return ret.Get(0).(mfclients.Client), ret.Error(1)
 }
 
+func (m *Repository) Delete(ctx context.Context, id string) error {
+	ret := m.Called(ctx, id)
+
+	if id == WrongID {
+		return errors.ErrNotFound
+	}
+
+	return ret.Error(0)
+}
+
 func (m *Repository) Members(ctx context.Context, groupID string, pm mfclients.Page) (mfclients.MembersPage, error) {
 	ret := m.Called(ctx, groupID, pm)
 	if groupID == WrongID {
@@ -83,6 +94,12 @@ func (m *Repository) Save(ctx context.Context, clis ...mfclients.Client) ([]mfcl
 	return clis, ret.Error(1)
 }
 
+func (m *Repository) SaveWithQuota(ctx context.Context, owner string, quota uint64, clis ...mfclients.Client) ([]mfclients.Client, error) {
+	ret := m.Called(ctx, owner, quota, clis)
+
+	return clis, ret.Error(1)
+}
+
 func (m *Repository) Update(ctx context.Context, client mfclients.Client) (mfclients.Client, error) {
 	ret := m.Called(ctx, client)
 
@@ -137,3 +154,68 @@ func (m *Repository) UpdateOwner(ctx context.Context, client mfclients.Client) (
 
 	return ret.Get(0).(mfclients.Client), ret.Error(1)
 }
+
+func (m *Repository) SaveSecret(ctx context.Context, s cpostgres.Secret) (cpostgres.Secret, error) {
+	ret := m.Called(ctx, s)
+
+	if s.ClientID == WrongID {
+		return cpostgres.Secret{}, errors.ErrNotFound
+	}
+
+	return ret.Get(0).(cpostgres.Secret), ret.Error(1)
+}
+
+func (m *Repository) RetrieveSecret(ctx context.Context, secret string) (cpostgres.Secret, error) {
+	ret := m.Called(ctx, secret)
+
+	if secret == "" {
+		return cpostgres.Secret{}, errors.ErrMalformedEntity
+	}
+
+	return ret.Get(0).(cpostgres.Secret), ret.Error(1)
+}
+
+func (m *Repository) RetrieveSecrets(ctx context.Context, clientID string) ([]cpostgres.Secret, error) {
+	ret := m.Called(ctx, clientID)
+
+	if clientID == WrongID {
+		return []cpostgres.Secret{}, errors.ErrNotFound
+	}
+
+	return ret.Get(0).([]cpostgres.Secret), ret.Error(1)
+}
+
+func (m *Repository) UpdateSecretExpiry(ctx context.Context, s cpostgres.Secret) (cpostgres.Secret, error) {
+	ret := m.Called(ctx, s)
+
+	if s.ClientID == WrongID {
+		return cpostgres.Secret{}, errors.ErrNotFound
+	}
+
+	return ret.Get(0).(cpostgres.Secret), ret.Error(1)
+}
+
+func (m *Repository) RemoveSecret(ctx context.Context, clientID, name string) (cpostgres.Secret, error) {
+	ret := m.Called(ctx, clientID, name)
+
+	if clientID == WrongID {
+		return cpostgres.Secret{}, errors.ErrNotFound
+	}
+
+	return ret.Get(0).(cpostgres.Secret), ret.Error(1)
+}
+
+func (m *Repository) Execute(ctx context.Context, owner string, quota uint64, ops ...cpostgres.Operation) ([]cpostgres.OperationResult, error) {
+	ret := m.Called(ctx, ops)
+
+	results := make([]cpostgres.OperationResult, len(ops))
+	for i, op := range ops {
+		if op.Client.ID == WrongID {
+			results[i] = cpostgres.OperationResult{Err: errors.ErrNotFound}
+			continue
+		}
+		results[i] = cpostgres.OperationResult{Client: op.Client}
+	}
+
+	return results, ret.Error(1)
+}
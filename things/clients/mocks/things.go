@@ -50,7 +50,6 @@ func (tcm *clientCacheMock) Remove(_ context.Context, id string) error {
 	for key, val := range tcm.things {
 		if val == id {
 			delete(tcm.things, key)
-			return nil
 		}
 	}
 
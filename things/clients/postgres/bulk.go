@@ -0,0 +1,173 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux/internal/postgres"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	pgclients "github.com/mainflux/mainflux/pkg/clients/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// Kinds of bulk operations.
+const (
+	CreateOp  = "create"
+	UpdateOp  = "update"
+	EnableOp  = "enable"
+	DisableOp = "disable"
+)
+
+// ErrInvalidOperation indicates an unknown kind of bulk operation.
+var ErrInvalidOperation = errors.New("invalid bulk operation")
+
+// Operation represents a single operation of a bulk request. Update
+// operations change the client's name, tags and metadata, whichever are set.
+type Operation struct {
+	Kind   string
+	Client mfclients.Client
+}
+
+// OperationResult holds the outcome of a single bulk operation.
+type OperationResult struct {
+	Client mfclients.Client
+	Err    error
+}
+
+const bulkSavepoint = "bulk_op"
+
+func (repo clientRepo) Execute(ctx context.Context, owner string, quota uint64, ops ...Operation) ([]OperationResult, error) {
+	tx, err := repo.ClientRepository.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return []OperationResult{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	remaining, err := lockQuota(ctx, tx, owner, quota)
+	if err != nil {
+		return []OperationResult{}, rollback(tx, err)
+	}
+	var cs []mfclients.Client
+	for _, op := range ops {
+		if op.Kind == CreateOp {
+			cs = append(cs, op.Client)
+		}
+	}
+	if err := lockSecrets(ctx, tx, secrets(cs)...); err != nil {
+		return []OperationResult{}, rollback(tx, err)
+	}
+
+	// Each operation runs under a savepoint, so a failing one is rolled back
+	// without aborting the rest of the transaction.
+	results := make([]OperationResult, len(ops))
+	for i, op := range ops {
+		creates := op.Kind == CreateOp && quota > 0
+		if creates && remaining == 0 {
+			results[i] = OperationResult{Err: errors.Wrap(errors.ErrAuthorization, errors.ErrQuotaExceeded)}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT "+bulkSavepoint); err != nil {
+			return []OperationResult{}, rollback(tx, err)
+		}
+		client, err := execute(ctx, tx, op)
+		if err != nil {
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+bulkSavepoint); err != nil {
+				return []OperationResult{}, rollback(tx, err)
+			}
+			results[i] = OperationResult{Err: err}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+bulkSavepoint); err != nil {
+			return []OperationResult{}, rollback(tx, err)
+		}
+		if creates {
+			remaining--
+		}
+		results[i] = OperationResult{Client: client}
+	}
+	if err := tx.Commit(); err != nil {
+		return []OperationResult{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return results, nil
+}
+
+func execute(ctx context.Context, tx *sqlx.Tx, op Operation) (mfclients.Client, error) {
+	switch op.Kind {
+	case CreateOp:
+		return insert(ctx, tx, op.Client)
+	case UpdateOp:
+		var set []string
+		if op.Client.Name != "" {
+			set = append(set, "name = :name,")
+		}
+		if op.Client.Tags != nil {
+			set = append(set, "tags = :tags,")
+		}
+		if op.Client.Metadata != nil {
+			set = append(set, "metadata = :metadata,")
+		}
+		op.Client.Status = mfclients.EnabledStatus
+		q := fmt.Sprintf(`UPDATE clients SET %s updated_at = :updated_at, updated_by = :updated_by
+			WHERE id = :id AND status = :status
+			RETURNING id, name, tags, identity, secret, metadata, COALESCE(owner_id, '') AS owner_id, status, created_at, updated_at, updated_by`,
+			strings.Join(set, " "))
+
+		return namedQuery(ctx, tx, q, op.Client, errors.ErrUpdateEntity)
+	case EnableOp, DisableOp:
+		var status mfclients.Status
+		if err := tx.QueryRowxContext(ctx, `SELECT status FROM clients WHERE id = $1 FOR UPDATE`, op.Client.ID).Scan(&status); err != nil {
+			if err == sql.ErrNoRows {
+				return mfclients.Client{}, errors.Wrap(errors.ErrNotFound, err)
+			}
+			return mfclients.Client{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		if status == op.Client.Status {
+			return mfclients.Client{}, mfclients.ErrStatusAlreadyAssigned
+		}
+		q := `UPDATE clients SET status = :status, updated_at = :updated_at, updated_by = :updated_by WHERE id = :id
+			RETURNING id, name, tags, identity, secret, metadata, COALESCE(owner_id, '') AS owner_id, status, created_at, updated_at, updated_by`
+
+		return namedQuery(ctx, tx, q, op.Client, errors.ErrUpdateEntity)
+	default:
+		return mfclients.Client{}, ErrInvalidOperation
+	}
+}
+
+func namedQuery(ctx context.Context, tx *sqlx.Tx, query string, client mfclients.Client, wrapper error) (mfclients.Client, error) {
+	dbc, err := pgclients.ToDBClient(client)
+	if err != nil {
+		return mfclients.Client{}, errors.Wrap(wrapper, err)
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, tx, query, dbc)
+	if err != nil {
+		return mfclients.Client{}, postgres.HandleError(err, wrapper)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		if err := row.Err(); err != nil {
+			return mfclients.Client{}, postgres.HandleError(err, wrapper)
+		}
+		return mfclients.Client{}, errors.ErrNotFound
+	}
+	dbc = pgclients.DBClient{}
+	if err := row.StructScan(&dbc); err != nil {
+		return mfclients.Client{}, errors.Wrap(wrapper, err)
+	}
+
+	return pgclients.ToClient(dbc)
+}
+
+func rollback(tx *sqlx.Tx, err error) error {
+	if err := tx.Rollback(); err != nil {
+		return postgres.HandleError(err, errors.ErrUpdateEntity)
+	}
+
+	return errors.Wrap(errors.ErrUpdateEntity, err)
+}
@@ -7,7 +7,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/mainflux/mainflux/internal/postgres"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	pgclients "github.com/mainflux/mainflux/pkg/clients/postgres"
@@ -24,11 +26,45 @@ type Repository interface {
 	mfclients.Repository
 
 	// Save persists the client account. A non-nil error is returned to indicate
-	// operation failure.
+	// operation failure. Secrets must not collide with a named secret of any
+	// client.
 	Save(ctx context.Context, client ...mfclients.Client) ([]mfclients.Client, error)
 
+	// SaveWithQuota persists the clients of the owner unless the owner would
+	// exceed the quota of clients. The quota is checked in the transaction
+	// the clients are saved in. Zero quota leaves the number unlimited.
+	SaveWithQuota(ctx context.Context, owner string, quota uint64, client ...mfclients.Client) ([]mfclients.Client, error)
+
 	// RetrieveBySecret retrieves a client based on the secret (key).
 	RetrieveBySecret(ctx context.Context, key string) (mfclients.Client, error)
+
+	// Delete removes the client together with its policies.
+	Delete(ctx context.Context, id string) error
+
+	// UpdateSecret updates the primary secret of the client. The secret
+	// must not collide with a named secret of any client.
+	UpdateSecret(ctx context.Context, client mfclients.Client) (mfclients.Client, error)
+
+	// SaveSecret persists a named client secret.
+	SaveSecret(ctx context.Context, s Secret) (Secret, error)
+
+	// RetrieveSecret retrieves an active named secret of an enabled client.
+	RetrieveSecret(ctx context.Context, secret string) (Secret, error)
+
+	// RetrieveSecrets retrieves all named secrets of the client.
+	RetrieveSecrets(ctx context.Context, clientID string) ([]Secret, error)
+
+	// UpdateSecretExpiry updates the expiry of the named client secret.
+	UpdateSecretExpiry(ctx context.Context, s Secret) (Secret, error)
+
+	// RemoveSecret removes the named client secret and returns it.
+	RemoveSecret(ctx context.Context, clientID, name string) (Secret, error)
+
+	// Execute executes the operations in a single transaction. A failing
+	// operation doesn't affect the others; its error is reported in the
+	// result at the same index as the operation. Create operations fail once
+	// the owner reaches the quota of clients, unless the quota is zero.
+	Execute(ctx context.Context, owner string, quota uint64, ops ...Operation) ([]OperationResult, error)
 }
 
 // NewRepository instantiates a PostgreSQL
@@ -49,35 +85,53 @@ func (repo clientRepo) Save(ctx context.Context, cs ...mfclients.Client) ([]mfcl
 	if err != nil {
 		return []mfclients.Client{}, errors.Wrap(errors.ErrCreateEntity, err)
 	}
+	if err := lockSecrets(ctx, tx, secrets(cs)...); err != nil {
+		return []mfclients.Client{}, rollback(tx, err)
+	}
 	var clients []mfclients.Client
-
 	for _, cli := range cs {
-		q := `INSERT INTO clients (id, name, tags, owner_id, identity, secret, metadata, created_at, updated_at, updated_by, status)
-        VALUES (:id, :name, :tags, :owner_id, :identity, :secret, :metadata, :created_at, :updated_at, :updated_by, :status)
-        RETURNING id, name, tags, identity, secret, metadata, COALESCE(owner_id, '') AS owner_id, status, created_at, updated_at, updated_by`
-
-		dbcli, err := pgclients.ToDBClient(cli)
-		if err != nil {
-			return []mfclients.Client{}, errors.Wrap(errors.ErrCreateEntity, err)
-		}
-
-		row, err := repo.ClientRepository.DB.NamedQueryContext(ctx, q, dbcli)
+		client, err := insert(ctx, tx, cli)
 		if err != nil {
 			if err := tx.Rollback(); err != nil {
 				return []mfclients.Client{}, postgres.HandleError(err, errors.ErrCreateEntity)
 			}
-			return []mfclients.Client{}, errors.Wrap(errors.ErrCreateEntity, err)
+			return []mfclients.Client{}, err
 		}
+		clients = append(clients, client)
+	}
+	if err = tx.Commit(); err != nil {
+		return []mfclients.Client{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
 
-		defer row.Close()
-		row.Next()
-		dbcli = pgclients.DBClient{}
-		if err := row.StructScan(&dbcli); err != nil {
-			return []mfclients.Client{}, err
+	return clients, nil
+}
+
+func (repo clientRepo) SaveWithQuota(ctx context.Context, owner string, quota uint64, cs ...mfclients.Client) ([]mfclients.Client, error) {
+	tx, err := repo.ClientRepository.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return []mfclients.Client{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	remaining, err := lockQuota(ctx, tx, owner, quota)
+	if err != nil {
+		return []mfclients.Client{}, rollback(tx, err)
+	}
+	if quota > 0 && uint64(len(cs)) > remaining {
+		if err := tx.Rollback(); err != nil {
+			return []mfclients.Client{}, postgres.HandleError(err, errors.ErrCreateEntity)
 		}
+		return []mfclients.Client{}, errors.Wrap(errors.ErrAuthorization, errors.ErrQuotaExceeded)
+	}
 
-		client, err := pgclients.ToClient(dbcli)
+	if err := lockSecrets(ctx, tx, secrets(cs)...); err != nil {
+		return []mfclients.Client{}, rollback(tx, err)
+	}
+	var clients []mfclients.Client
+	for _, cli := range cs {
+		client, err := insert(ctx, tx, cli)
 		if err != nil {
+			if err := tx.Rollback(); err != nil {
+				return []mfclients.Client{}, postgres.HandleError(err, errors.ErrCreateEntity)
+			}
 			return []mfclients.Client{}, err
 		}
 		clients = append(clients, client)
@@ -89,6 +143,74 @@ func (repo clientRepo) Save(ctx context.Context, cs ...mfclients.Client) ([]mfcl
 	return clients, nil
 }
 
+// lockQuota locks the clients of the owner until the end of the transaction
+// and returns the number of clients the owner can still create. Concurrent
+// transactions creating clients of the same owner wait for the lock, so the
+// count can't change before the transaction ends.
+func lockQuota(ctx context.Context, tx *sqlx.Tx, owner string, quota uint64) (uint64, error) {
+	if quota == 0 {
+		return 0, nil
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('clients'), hashtext($1))`, owner); err != nil {
+		return 0, err
+	}
+	var total uint64
+	if err := tx.GetContext(ctx, &total, `SELECT COUNT(*) FROM clients WHERE owner_id = $1`, owner); err != nil {
+		return 0, err
+	}
+	if total >= quota {
+		return 0, nil
+	}
+
+	return quota - total, nil
+}
+
+// insert saves the client unless its secret collides with a named secret
+// of any client. The secret has to be locked by the transaction.
+func insert(ctx context.Context, tx *sqlx.Tx, client mfclients.Client) (mfclients.Client, error) {
+	q := `INSERT INTO clients (id, name, tags, owner_id, identity, secret, metadata, created_at, updated_at, updated_by, status)
+		SELECT CAST(:id AS VARCHAR), CAST(:name AS VARCHAR), CAST(:tags AS TEXT[]), CAST(:owner_id AS VARCHAR),
+			CAST(:identity AS VARCHAR), CAST(:secret AS VARCHAR), CAST(:metadata AS JSONB), CAST(:created_at AS TIMESTAMP),
+			CAST(:updated_at AS TIMESTAMP), CAST(:updated_by AS VARCHAR), CAST(:status AS SMALLINT)
+		WHERE NOT EXISTS (SELECT 1 FROM client_secrets WHERE secret = :secret)
+		RETURNING id, name, tags, identity, secret, metadata, COALESCE(owner_id, '') AS owner_id, status, created_at, updated_at, updated_by`
+
+	client, err := namedQuery(ctx, tx, q, client, errors.ErrCreateEntity)
+	if err == errors.ErrNotFound {
+		return mfclients.Client{}, errors.ErrConflict
+	}
+
+	return client, err
+}
+
+// lockSecrets locks the secrets until the end of the transaction. Primary
+// and named secrets live in different tables, so transactions checking one
+// table for a secret being saved to the other wait for the lock instead of
+// relying on unique constraints. Secrets are locked in order to avoid
+// deadlocks between transactions saving several of them.
+func lockSecrets(ctx context.Context, tx *sqlx.Tx, secrets ...string) error {
+	sort.Strings(secrets)
+	for i, secret := range secrets {
+		if i > 0 && secret == secrets[i-1] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('client_secrets'), hashtext($1))`, secret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func secrets(cs []mfclients.Client) []string {
+	var secrets []string
+	for _, c := range cs {
+		secrets = append(secrets, c.Credentials.Secret)
+	}
+
+	return secrets
+}
+
 func (repo clientRepo) RetrieveBySecret(ctx context.Context, key string) (mfclients.Client, error) {
 	q := fmt.Sprintf(`SELECT id, name, tags, COALESCE(owner_id, '') AS owner_id, identity, secret, metadata, created_at, updated_at, updated_by, status
         FROM clients
@@ -107,3 +229,36 @@ func (repo clientRepo) RetrieveBySecret(ctx context.Context, key string) (mfclie
 
 	return pgclients.ToClient(dbc)
 }
+
+func (repo clientRepo) Delete(ctx context.Context, id string) error {
+	tx, err := repo.ClientRepository.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	// Policies have no foreign key on the subject, since the subject
+	// is either a thing or a user the channel is shared with.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM policies WHERE subject = $1`, id); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return postgres.HandleError(err, errors.ErrRemoveEntity)
+		}
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM clients WHERE id = $1`, id)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			return postgres.HandleError(err, errors.ErrRemoveEntity)
+		}
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		if err := tx.Rollback(); err != nil {
+			return postgres.HandleError(err, errors.ErrRemoveEntity)
+		}
+		return errors.ErrNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
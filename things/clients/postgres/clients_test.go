@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/internal/testsutil"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
@@ -15,6 +16,7 @@ import (
 	"github.com/mainflux/mainflux/pkg/uuid"
 	cpostgres "github.com/mainflux/mainflux/things/clients/postgres"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const maxNameSize = 1024
@@ -156,3 +158,251 @@ func TestClientsSave(t *testing.T) {
 		}
 	}
 }
+
+func TestClientsDelete(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := cpostgres.NewRepository(database)
+
+	client := mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: clientName,
+		Credentials: mfclients.Credentials{
+			Identity: clientIdentity,
+			Secret:   testsutil.GenerateUUID(t, idProvider),
+		},
+		Metadata: mfclients.Metadata{},
+		Status:   mfclients.EnabledStatus,
+	}
+	_, err := repo.Save(context.Background(), client)
+	assert.Nil(t, err, fmt.Sprintf("add new client: expected nil got %s\n", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "delete existing client",
+			id:   client.ID,
+			err:  nil,
+		},
+		{
+			desc: "delete already deleted client",
+			id:   client.ID,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Delete(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestClientSecrets(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := cpostgres.NewRepository(database)
+
+	client := mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: clientName,
+		Credentials: mfclients.Credentials{
+			Identity: clientIdentity,
+			Secret:   testsutil.GenerateUUID(t, idProvider),
+		},
+		Metadata: mfclients.Metadata{},
+		Status:   mfclients.EnabledStatus,
+	}
+	_, err := repo.Save(context.Background(), client)
+	assert.Nil(t, err, fmt.Sprintf("add new client: expected nil got %s\n", err))
+
+	backup := cpostgres.Secret{
+		ClientID:  client.ID,
+		Name:      "backup",
+		Secret:    testsutil.GenerateUUID(t, idProvider),
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	saveCases := []struct {
+		desc   string
+		secret cpostgres.Secret
+		err    error
+	}{
+		{
+			desc:   "save named secret",
+			secret: backup,
+			err:    nil,
+		},
+		{
+			desc:   "save named secret with existing name",
+			secret: cpostgres.Secret{ClientID: client.ID, Name: backup.Name, Secret: testsutil.GenerateUUID(t, idProvider)},
+			err:    errors.ErrConflict,
+		},
+		{
+			desc:   "save named secret equal to primary secret",
+			secret: cpostgres.Secret{ClientID: client.ID, Name: "primary", Secret: client.Credentials.Secret},
+			err:    errors.ErrConflict,
+		},
+		{
+			desc:   "save named secret of non-existing client",
+			secret: cpostgres.Secret{ClientID: testsutil.GenerateUUID(t, idProvider), Name: "backup", Secret: testsutil.GenerateUUID(t, idProvider)},
+			err:    errors.ErrNotFound,
+		},
+	}
+	for _, tc := range saveCases {
+		_, err := repo.SaveSecret(context.Background(), tc.secret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	s, err := repo.RetrieveSecret(context.Background(), backup.Secret)
+	assert.Nil(t, err, fmt.Sprintf("retrieve active secret: expected nil got %s\n", err))
+	assert.Equal(t, client.ID, s.ClientID, fmt.Sprintf("retrieve active secret: expected %s got %s\n", client.ID, s.ClientID))
+
+	updated := client
+	updated.Credentials.Secret = backup.Secret
+	_, err = repo.UpdateSecret(context.Background(), updated)
+	assert.True(t, errors.Contains(err, errors.ErrConflict), fmt.Sprintf("update primary secret to named secret: expected %s got %s\n", errors.ErrConflict, err))
+
+	colliding := client
+	colliding.ID = testsutil.GenerateUUID(t, idProvider)
+	colliding.Credentials.Secret = backup.Secret
+	_, err = repo.Save(context.Background(), colliding)
+	assert.True(t, errors.Contains(err, errors.ErrConflict), fmt.Sprintf("save client with named secret: expected %s got %s\n", errors.ErrConflict, err))
+	_, err = repo.SaveWithQuota(context.Background(), colliding.Owner, 10, colliding)
+	assert.True(t, errors.Contains(err, errors.ErrConflict), fmt.Sprintf("save client with named secret under quota: expected %s got %s\n", errors.ErrConflict, err))
+	results, err := repo.Execute(context.Background(), colliding.Owner, 0, cpostgres.Operation{Kind: cpostgres.CreateOp, Client: colliding})
+	assert.Nil(t, err, fmt.Sprintf("execute create with named secret: expected nil got %s\n", err))
+	assert.True(t, errors.Contains(results[0].Err, errors.ErrConflict), fmt.Sprintf("execute create with named secret: expected %s got %s\n", errors.ErrConflict, results[0].Err))
+
+	updated.Credentials.Secret = testsutil.GenerateUUID(t, idProvider)
+	_, err = repo.UpdateSecret(context.Background(), updated)
+	assert.Nil(t, err, fmt.Sprintf("update primary secret: expected nil got %s\n", err))
+
+	backup.ExpiresAt = time.Now().Add(-time.Minute)
+	_, err = repo.UpdateSecretExpiry(context.Background(), backup)
+	assert.Nil(t, err, fmt.Sprintf("expire secret: expected nil got %s\n", err))
+	_, err = repo.RetrieveSecret(context.Background(), backup.Secret)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve expired secret: expected %s got %s\n", errors.ErrNotFound, err))
+
+	secrets, err := repo.RetrieveSecrets(context.Background(), client.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve secrets: expected nil got %s\n", err))
+	assert.Equal(t, 1, len(secrets), fmt.Sprintf("retrieve secrets: expected 1 secret got %d\n", len(secrets)))
+
+	_, err = repo.RemoveSecret(context.Background(), client.ID, backup.Name)
+	assert.Nil(t, err, fmt.Sprintf("remove secret: expected nil got %s\n", err))
+	_, err = repo.RemoveSecret(context.Background(), client.ID, backup.Name)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("remove removed secret: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+func TestClientsExecute(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := cpostgres.NewRepository(database)
+
+	client := mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: clientName,
+		Credentials: mfclients.Credentials{
+			Identity: clientIdentity,
+			Secret:   testsutil.GenerateUUID(t, idProvider),
+		},
+		Metadata: mfclients.Metadata{},
+		Status:   mfclients.EnabledStatus,
+	}
+	created := mfclients.Client{
+		ID:          testsutil.GenerateUUID(t, idProvider),
+		Name:        "created",
+		Credentials: mfclients.Credentials{Secret: testsutil.GenerateUUID(t, idProvider)},
+		Metadata:    mfclients.Metadata{},
+		Status:      mfclients.EnabledStatus,
+	}
+	duplicate := created
+	duplicate.ID = testsutil.GenerateUUID(t, idProvider)
+
+	ops := []cpostgres.Operation{
+		{Kind: cpostgres.CreateOp, Client: client},
+		{Kind: cpostgres.CreateOp, Client: created},
+		{Kind: cpostgres.CreateOp, Client: duplicate},
+		{Kind: cpostgres.UpdateOp, Client: mfclients.Client{ID: client.ID, Name: "updated", Metadata: mfclients.Metadata{"key": "value"}}},
+		{Kind: cpostgres.UpdateOp, Client: mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Name: "updated"}},
+		{Kind: cpostgres.DisableOp, Client: mfclients.Client{ID: created.ID, Status: mfclients.DisabledStatus}},
+		{Kind: cpostgres.DisableOp, Client: mfclients.Client{ID: created.ID, Status: mfclients.DisabledStatus}},
+		{Kind: cpostgres.EnableOp, Client: mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Status: mfclients.EnabledStatus}},
+	}
+	errs := []error{nil, nil, errors.ErrConflict, nil, errors.ErrNotFound, nil, mfclients.ErrStatusAlreadyAssigned, errors.ErrNotFound}
+
+	results, err := repo.Execute(context.Background(), "", 0, ops...)
+	require.Nil(t, err, fmt.Sprintf("execute operations: expected nil got %s\n", err))
+	require.Len(t, results, len(ops), fmt.Sprintf("execute operations: expected %d results got %d\n", len(ops), len(results)))
+	for i, expected := range errs {
+		assert.True(t, errors.Contains(results[i].Err, expected), fmt.Sprintf("operation %d: expected %s got %s\n", i, expected, results[i].Err))
+	}
+
+	// Failing operations must not affect the ones executed in the same transaction.
+	cli, err := repo.RetrieveByID(context.Background(), client.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve client: expected nil got %s\n", err))
+	assert.Equal(t, "updated", cli.Name, fmt.Sprintf("retrieve client: expected name updated got %s\n", cli.Name))
+	cli, err = repo.RetrieveByID(context.Background(), created.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve client: expected nil got %s\n", err))
+	assert.Equal(t, mfclients.DisabledStatus, cli.Status, fmt.Sprintf("retrieve client: expected disabled status got %s\n", cli.Status))
+}
+
+func TestClientsSaveWithQuota(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := cpostgres.NewRepository(database)
+
+	owner := testsutil.GenerateUUID(t, idProvider)
+	newClient := func() mfclients.Client {
+		return mfclients.Client{
+			ID:          testsutil.GenerateUUID(t, idProvider),
+			Owner:       owner,
+			Credentials: mfclients.Credentials{Secret: testsutil.GenerateUUID(t, idProvider)},
+			Metadata:    mfclients.Metadata{},
+			Status:      mfclients.EnabledStatus,
+		}
+	}
+
+	cases := []struct {
+		desc    string
+		clients []mfclients.Client
+		quota   uint64
+		err     error
+	}{
+		{
+			desc:    "save clients within quota",
+			clients: []mfclients.Client{newClient(), newClient()},
+			quota:   3,
+			err:     nil,
+		},
+		{
+			desc:    "save clients exceeding quota",
+			clients: []mfclients.Client{newClient(), newClient()},
+			quota:   3,
+			err:     errors.ErrQuotaExceeded,
+		},
+		{
+			desc:    "save client reaching quota",
+			clients: []mfclients.Client{newClient()},
+			quota:   3,
+			err:     nil,
+		},
+		{
+			desc:    "save clients without quota",
+			clients: []mfclients.Client{newClient(), newClient()},
+			quota:   0,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.SaveWithQuota(context.Background(), owner, tc.quota, tc.clients...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	ops := []cpostgres.Operation{
+		{Kind: cpostgres.CreateOp, Client: newClient()},
+		{Kind: cpostgres.CreateOp, Client: newClient()},
+	}
+	results, err := repo.Execute(context.Background(), owner, 6, ops...)
+	require.Nil(t, err, fmt.Sprintf("execute operations: expected nil got %s\n", err))
+	assert.Nil(t, results[0].Err, fmt.Sprintf("create client within quota: expected nil got %s\n", results[0].Err))
+	assert.True(t, errors.Contains(results[1].Err, errors.ErrQuotaExceeded), fmt.Sprintf("create client exceeding quota: expected %s got %s\n", errors.ErrQuotaExceeded, results[1].Err))
+}
@@ -0,0 +1,242 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux/internal/postgres"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// Secret represents a named thing secret which is accepted in addition to
+// the thing's primary one. Zero ExpiresAt means the secret never expires.
+type Secret struct {
+	ClientID  string    `json:"thing_id"`
+	Name      string    `json:"name"`
+	Secret    string    `json:"secret"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type dbSecret struct {
+	ClientID  string       `db:"client_id"`
+	Name      string       `db:"name"`
+	Secret    string       `db:"secret"`
+	ExpiresAt sql.NullTime `db:"expires_at"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
+func (repo clientRepo) SaveSecret(ctx context.Context, s Secret) (Secret, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return Secret{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	if err := lockSecrets(ctx, tx, s.Secret); err != nil {
+		return Secret{}, rollback(tx, err)
+	}
+
+	// Expired secrets are dropped so that their names can be reused.
+	q := `DELETE FROM client_secrets WHERE client_id = $1 AND expires_at <= NOW()`
+	if _, err := tx.ExecContext(ctx, q, s.ClientID); err != nil {
+		return Secret{}, rollback(tx, err)
+	}
+
+	// The secret must not collide with any thing's primary secret either.
+	q = `INSERT INTO client_secrets (client_id, name, secret, expires_at, created_at)
+		SELECT CAST(:client_id AS VARCHAR), CAST(:name AS VARCHAR), CAST(:secret AS VARCHAR),
+			CAST(:expires_at AS TIMESTAMP), CAST(:created_at AS TIMESTAMP)
+		WHERE EXISTS (SELECT 1 FROM clients WHERE id = :client_id)
+		AND NOT EXISTS (SELECT 1 FROM clients WHERE secret = :secret)
+		RETURNING client_id, name, secret, expires_at, created_at`
+
+	dbs, ok, err := saveSecret(ctx, tx, q, toDBSecret(s))
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			return Secret{}, postgres.HandleError(err, errors.ErrCreateEntity)
+		}
+		return Secret{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Secret{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	if !ok {
+		if _, err := repo.RetrieveByID(ctx, s.ClientID); err != nil {
+			return Secret{}, err
+		}
+		return Secret{}, errors.ErrConflict
+	}
+
+	return toSecret(dbs), nil
+}
+
+// saveSecret runs the insert of the named secret and reports whether the
+// secret was inserted.
+func saveSecret(ctx context.Context, tx *sqlx.Tx, query string, s dbSecret) (dbSecret, bool, error) {
+	row, err := sqlx.NamedQueryContext(ctx, tx, query, s)
+	if err != nil {
+		return dbSecret{}, false, postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		if err := row.Err(); err != nil {
+			return dbSecret{}, false, postgres.HandleError(err, errors.ErrCreateEntity)
+		}
+		return dbSecret{}, false, nil
+	}
+	var dbs dbSecret
+	if err := row.StructScan(&dbs); err != nil {
+		return dbSecret{}, false, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return dbs, true, nil
+}
+
+func (repo clientRepo) UpdateSecret(ctx context.Context, client mfclients.Client) (mfclients.Client, error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return mfclients.Client{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if err := lockSecrets(ctx, tx, client.Credentials.Secret); err != nil {
+		return mfclients.Client{}, rollback(tx, err)
+	}
+
+	// The primary secret must not collide with any thing's named secret either.
+	q := `UPDATE clients SET secret = :secret, updated_at = :updated_at, updated_by = :updated_by
+		WHERE id = :id AND status = :status
+		AND NOT EXISTS (SELECT 1 FROM client_secrets WHERE secret = :secret)
+		RETURNING id, name, tags, identity, metadata, COALESCE(owner_id, '') AS owner_id, status, created_at, updated_at, updated_by`
+
+	updated, err := namedQuery(ctx, tx, q, client, errors.ErrUpdateEntity)
+	if err != nil && err != errors.ErrNotFound {
+		if err := tx.Rollback(); err != nil {
+			return mfclients.Client{}, postgres.HandleError(err, errors.ErrUpdateEntity)
+		}
+		return mfclients.Client{}, err
+	}
+	if err == errors.ErrNotFound {
+		var collides bool
+		q = `SELECT EXISTS (SELECT 1 FROM client_secrets WHERE secret = $1)`
+		if err := tx.QueryRowxContext(ctx, q, client.Credentials.Secret).Scan(&collides); err != nil {
+			return mfclients.Client{}, rollback(tx, err)
+		}
+		if err := tx.Rollback(); err != nil {
+			return mfclients.Client{}, postgres.HandleError(err, errors.ErrUpdateEntity)
+		}
+		if collides {
+			return mfclients.Client{}, errors.ErrConflict
+		}
+		return mfclients.Client{}, errors.ErrNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		return mfclients.Client{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+func (repo clientRepo) RetrieveSecret(ctx context.Context, secret string) (Secret, error) {
+	q := fmt.Sprintf(`SELECT s.client_id, s.name, s.secret, s.expires_at, s.created_at
+		FROM client_secrets s INNER JOIN clients c ON c.id = s.client_id
+		WHERE s.secret = $1 AND (s.expires_at IS NULL OR s.expires_at > NOW()) AND c.status = %d`, mfclients.EnabledStatus)
+
+	var dbs dbSecret
+	if err := repo.DB.QueryRowxContext(ctx, q, secret).StructScan(&dbs); err != nil {
+		if err == sql.ErrNoRows {
+			return Secret{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return Secret{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toSecret(dbs), nil
+}
+
+func (repo clientRepo) RetrieveSecrets(ctx context.Context, clientID string) ([]Secret, error) {
+	q := `SELECT client_id, name, secret, expires_at, created_at FROM client_secrets
+		WHERE client_id = $1 ORDER BY created_at`
+
+	rows, err := repo.DB.QueryxContext(ctx, q, clientID)
+	if err != nil {
+		return []Secret{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	secrets := []Secret{}
+	for rows.Next() {
+		var dbs dbSecret
+		if err := rows.StructScan(&dbs); err != nil {
+			return []Secret{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		secrets = append(secrets, toSecret(dbs))
+	}
+
+	return secrets, nil
+}
+
+func (repo clientRepo) UpdateSecretExpiry(ctx context.Context, s Secret) (Secret, error) {
+	q := `UPDATE client_secrets SET expires_at = :expires_at
+		WHERE client_id = :client_id AND name = :name
+		RETURNING client_id, name, secret, expires_at, created_at`
+
+	row, err := repo.DB.NamedQueryContext(ctx, q, toDBSecret(s))
+	if err != nil {
+		return Secret{}, postgres.HandleError(err, errors.ErrUpdateEntity)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return Secret{}, errors.ErrNotFound
+	}
+	var dbs dbSecret
+	if err := row.StructScan(&dbs); err != nil {
+		return Secret{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return toSecret(dbs), nil
+}
+
+func (repo clientRepo) RemoveSecret(ctx context.Context, clientID, name string) (Secret, error) {
+	q := `DELETE FROM client_secrets WHERE client_id = $1 AND name = $2
+		RETURNING client_id, name, secret, expires_at, created_at`
+
+	var dbs dbSecret
+	if err := repo.DB.QueryRowxContext(ctx, q, clientID, name).StructScan(&dbs); err != nil {
+		if err == sql.ErrNoRows {
+			return Secret{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return Secret{}, errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return toSecret(dbs), nil
+}
+
+func toDBSecret(s Secret) dbSecret {
+	return dbSecret{
+		ClientID:  s.ClientID,
+		Name:      s.Name,
+		Secret:    s.Secret,
+		ExpiresAt: sql.NullTime{Time: s.ExpiresAt, Valid: !s.ExpiresAt.IsZero()},
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+func toSecret(dbs dbSecret) Secret {
+	s := Secret{
+		ClientID:  dbs.ClientID,
+		Name:      dbs.Name,
+		Secret:    dbs.Secret,
+		CreatedAt: dbs.CreatedAt,
+	}
+	if dbs.ExpiresAt.Valid {
+		s.ExpiresAt = dbs.ExpiresAt.Time
+	}
+
+	return s
+}
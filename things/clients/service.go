@@ -27,6 +27,10 @@ const (
 	deleteRelationKey = "c_delete"
 
 	clientEntityType = "client"
+
+	// operationsChunkSize is the number of bulk operations executed in a
+	// single transaction.
+	operationsChunkSize = 100
 )
 
 type service struct {
@@ -34,27 +38,30 @@ type service struct {
 	policies    tpolicies.Service
 	clients     postgres.Repository
 	clientCache Cache
+	policyCache tpolicies.Cache
 	idProvider  mainflux.IDProvider
 	grepo       mfgroups.Repository
 }
 
 // NewService returns a new Clients service implementation.
-func NewService(uauth upolicies.AuthServiceClient, policies tpolicies.Service, c postgres.Repository, grepo mfgroups.Repository, tcache Cache, idp mainflux.IDProvider) Service {
+func NewService(uauth upolicies.AuthServiceClient, policies tpolicies.Service, c postgres.Repository, grepo mfgroups.Repository, tcache Cache, pcache tpolicies.Cache, idp mainflux.IDProvider) Service {
 	return service{
 		uauth:       uauth,
 		policies:    policies,
 		clients:     c,
 		grepo:       grepo,
 		clientCache: tcache,
+		policyCache: pcache,
 		idProvider:  idp,
 	}
 }
 
 func (svc service) CreateThings(ctx context.Context, token string, clis ...mfclients.Client) ([]mfclients.Client, error) {
-	userID, err := svc.identify(ctx, token)
+	res, err := svc.uauth.Identify(ctx, &upolicies.IdentifyReq{Token: token, Action: updateRelationKey, Object: thingsObjectKey})
 	if err != nil {
 		return []mfclients.Client{}, err
 	}
+	userID := res.GetOwner()
 	var clients []mfclients.Client
 	for _, cli := range clis {
 		if cli.ID == "" {
@@ -71,7 +78,9 @@ func (svc service) CreateThings(ctx context.Context, token string, clis ...mfcli
 			}
 			cli.Credentials.Secret = key
 		}
-		if cli.Owner == "" {
+		// Things created using the organization scoped token are always
+		// owned by the organization, so that its quota applies to them.
+		if cli.Owner == "" || res.GetOrgId() != "" {
 			cli.Owner = userID
 		}
 		if cli.Status != mfclients.DisabledStatus && cli.Status != mfclients.EnabledStatus {
@@ -80,12 +89,15 @@ func (svc service) CreateThings(ctx context.Context, token string, clis ...mfcli
 		cli.CreatedAt = time.Now()
 		clients = append(clients, cli)
 	}
+	if quota := res.GetThingsQuota(); quota > 0 {
+		return svc.clients.SaveWithQuota(ctx, userID, quota, clients...)
+	}
 
 	return svc.clients.Save(ctx, clients...)
 }
 
 func (svc service) ViewClient(ctx context.Context, token string, id string) (mfclients.Client, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, listRelationKey, id)
 	if err != nil {
 		return mfclients.Client{}, err
 	}
@@ -96,7 +108,7 @@ func (svc service) ViewClient(ctx context.Context, token string, id string) (mfc
 }
 
 func (svc service) ListClients(ctx context.Context, token string, pm mfclients.Page) (mfclients.ClientsPage, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, listRelationKey, thingsObjectKey)
 	if err != nil {
 		return mfclients.ClientsPage{}, err
 	}
@@ -146,7 +158,7 @@ func (svc service) ListClients(ctx context.Context, token string, pm mfclients.P
 }
 
 func (svc service) UpdateClient(ctx context.Context, token string, cli mfclients.Client) (mfclients.Client, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, updateRelationKey, cli.ID)
 	if err != nil {
 		return mfclients.Client{}, err
 	}
@@ -166,7 +178,7 @@ func (svc service) UpdateClient(ctx context.Context, token string, cli mfclients
 }
 
 func (svc service) UpdateClientTags(ctx context.Context, token string, cli mfclients.Client) (mfclients.Client, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, updateRelationKey, cli.ID)
 	if err != nil {
 		return mfclients.Client{}, err
 	}
@@ -185,7 +197,7 @@ func (svc service) UpdateClientTags(ctx context.Context, token string, cli mfcli
 }
 
 func (svc service) UpdateClientSecret(ctx context.Context, token, id, key string) (mfclients.Client, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, updateRelationKey, id)
 	if err != nil {
 		return mfclients.Client{}, err
 	}
@@ -207,7 +219,7 @@ func (svc service) UpdateClientSecret(ctx context.Context, token, id, key string
 }
 
 func (svc service) UpdateClientOwner(ctx context.Context, token string, cli mfclients.Client) (mfclients.Client, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, updateRelationKey, cli.ID)
 	if err != nil {
 		return mfclients.Client{}, err
 	}
@@ -258,8 +270,286 @@ func (svc service) DisableClient(ctx context.Context, token, id string) (mfclien
 	return client, nil
 }
 
+func (svc service) DeleteClient(ctx context.Context, token, id string) error {
+	userID, err := svc.identify(ctx, token, deleteRelationKey, id)
+	if err != nil {
+		return err
+	}
+	if err := svc.authorize(ctx, userID, id, deleteRelationKey); err != nil {
+		return err
+	}
+	client, err := svc.clients.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	secrets, err := svc.clients.RetrieveSecrets(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := svc.clients.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := svc.clientCache.Remove(ctx, id); err != nil {
+		return err
+	}
+	for _, secret := range secrets {
+		if err := svc.policyCache.Remove(ctx, tpolicies.CachedPolicy{ThingKey: secret.Secret}); err != nil {
+			return err
+		}
+	}
+
+	return svc.policyCache.Remove(ctx, tpolicies.CachedPolicy{ThingKey: client.Credentials.Secret})
+}
+
+func (svc service) AddClientSecret(ctx context.Context, token, id string, secret postgres.Secret) (postgres.Secret, error) {
+	userID, err := svc.identify(ctx, token, updateRelationKey, id)
+	if err != nil {
+		return postgres.Secret{}, err
+	}
+	if err := svc.authorize(ctx, userID, id, updateRelationKey); err != nil {
+		return postgres.Secret{}, err
+	}
+	if secret.Secret == "" {
+		key, err := svc.idProvider.ID()
+		if err != nil {
+			return postgres.Secret{}, err
+		}
+		secret.Secret = key
+	}
+	secret.ClientID = id
+	secret.CreatedAt = time.Now()
+
+	return svc.clients.SaveSecret(ctx, secret)
+}
+
+func (svc service) ListClientSecrets(ctx context.Context, token, id string) ([]postgres.Secret, error) {
+	userID, err := svc.identify(ctx, token, listRelationKey, id)
+	if err != nil {
+		return []postgres.Secret{}, err
+	}
+	if err := svc.authorize(ctx, userID, id, listRelationKey); err != nil {
+		return []postgres.Secret{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	return svc.clients.RetrieveSecrets(ctx, id)
+}
+
+func (svc service) UpdateClientSecretExpiry(ctx context.Context, token, id, name string, expiresAt time.Time) (postgres.Secret, error) {
+	userID, err := svc.identify(ctx, token, updateRelationKey, id)
+	if err != nil {
+		return postgres.Secret{}, err
+	}
+	if err := svc.authorize(ctx, userID, id, updateRelationKey); err != nil {
+		return postgres.Secret{}, err
+	}
+	secret := postgres.Secret{
+		ClientID:  id,
+		Name:      name,
+		ExpiresAt: expiresAt,
+	}
+	secret, err = svc.clients.UpdateSecretExpiry(ctx, secret)
+	if err != nil {
+		return postgres.Secret{}, err
+	}
+	if err := svc.evictSecret(ctx, secret); err != nil {
+		return secret, err
+	}
+
+	return secret, nil
+}
+
+func (svc service) RemoveClientSecret(ctx context.Context, token, id, name string) error {
+	userID, err := svc.identify(ctx, token, updateRelationKey, id)
+	if err != nil {
+		return err
+	}
+	if err := svc.authorize(ctx, userID, id, updateRelationKey); err != nil {
+		return err
+	}
+	secret, err := svc.clients.RemoveSecret(ctx, id, name)
+	if err != nil {
+		return err
+	}
+
+	return svc.evictSecret(ctx, secret)
+}
+
+// evictSecret removes the named secret from the thing and policies caches,
+// so that a revoked or expiring secret is not served from cache.
+func (svc service) evictSecret(ctx context.Context, secret postgres.Secret) error {
+	if err := svc.clientCache.Remove(ctx, secret.ClientID); err != nil {
+		return err
+	}
+
+	return svc.policyCache.Remove(ctx, tpolicies.CachedPolicy{ThingKey: secret.Secret})
+}
+
+func (svc service) ExecuteOperations(ctx context.Context, token string, ops ...postgres.Operation) ([]postgres.OperationResult, error) {
+	results := make([]postgres.OperationResult, len(ops))
+
+	// The token is identified for each operation with its own action and
+	// object, so that the scoped API key executes only the operations
+	// within its scope. Operations sharing the action and object are
+	// identified once.
+	var res *upolicies.IdentifyRes
+	var identifyErr error
+	identified := make(map[string]error)
+	for i, op := range ops {
+		action, object, ok := operationScope(op)
+		if !ok {
+			results[i] = postgres.OperationResult{Err: postgres.ErrInvalidOperation}
+			continue
+		}
+		key := action + ":" + object
+		err, ok := identified[key]
+		if !ok {
+			var r *upolicies.IdentifyRes
+			r, err = svc.uauth.Identify(ctx, &upolicies.IdentifyReq{Token: token, Action: action, Object: object})
+			if err == nil && res == nil {
+				res = r
+			}
+			if err != nil && identifyErr == nil {
+				identifyErr = err
+			}
+			identified[key] = err
+		}
+		if err != nil {
+			results[i] = postgres.OperationResult{Err: err}
+		}
+	}
+	// The token which is not accepted for any of the operations fails the
+	// whole request, same as the invalid one.
+	if res == nil {
+		if identifyErr == nil {
+			return results, nil
+		}
+		return []postgres.OperationResult{}, identifyErr
+	}
+	userID := res.GetOwner()
+	admin := svc.checkAdmin(ctx, userID, thingsObjectKey, updateRelationKey) == nil
+
+	// Operations which are not authorized are reported right away, the
+	// rest are executed in chunks and keep track of their position.
+	var pending []postgres.Operation
+	var idx []int
+	for i, op := range ops {
+		if results[i].Err != nil {
+			continue
+		}
+		op, err := svc.prepareOperation(ctx, userID, admin, res.GetOrgId() != "", op)
+		if err != nil {
+			results[i] = postgres.OperationResult{Err: err}
+			continue
+		}
+		pending = append(pending, op)
+		idx = append(idx, i)
+	}
+
+	for start := 0; start < len(pending); start += operationsChunkSize {
+		end := start + operationsChunkSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		chunk, err := svc.clients.Execute(ctx, userID, res.GetThingsQuota(), pending[start:end]...)
+		for i := start; i < end; i++ {
+			if err != nil {
+				results[idx[i]] = postgres.OperationResult{Err: err}
+				continue
+			}
+			results[idx[i]] = chunk[i-start]
+		}
+	}
+
+	for i, op := range ops {
+		if op.Kind != postgres.DisableOp || results[i].Err != nil {
+			continue
+		}
+		if err := svc.clientCache.Remove(ctx, results[i].Client.ID); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// prepareOperation authorizes the operation and fills in the fields which
+// are set by the service, such as generated IDs and timestamps. The owner
+// of the created thing is forced only for the organization scoped token.
+func (svc service) prepareOperation(ctx context.Context, userID string, admin, scoped bool, op postgres.Operation) (postgres.Operation, error) {
+	cli := op.Client
+	switch op.Kind {
+	case postgres.CreateOp:
+		if cli.ID == "" {
+			id, err := svc.idProvider.ID()
+			if err != nil {
+				return postgres.Operation{}, err
+			}
+			cli.ID = id
+		}
+		if cli.Credentials.Secret == "" {
+			key, err := svc.idProvider.ID()
+			if err != nil {
+				return postgres.Operation{}, err
+			}
+			cli.Credentials.Secret = key
+		}
+		if cli.Owner == "" || scoped {
+			cli.Owner = userID
+		}
+		if cli.Status != mfclients.DisabledStatus && cli.Status != mfclients.EnabledStatus {
+			return postgres.Operation{}, apiutil.ErrInvalidStatus
+		}
+		cli.CreatedAt = time.Now()
+	case postgres.UpdateOp, postgres.EnableOp, postgres.DisableOp:
+		action, _, _ := operationScope(op)
+		if !admin {
+			policy := tpolicies.AccessRequest{Subject: userID, Object: cli.ID, Action: action, Entity: clientEntityType}
+			if _, err := svc.policies.Authorize(ctx, policy); err != nil {
+				return postgres.Operation{}, err
+			}
+		}
+		client := mfclients.Client{
+			ID:        cli.ID,
+			UpdatedAt: time.Now(),
+			UpdatedBy: userID,
+		}
+		switch op.Kind {
+		case postgres.UpdateOp:
+			client.Name = cli.Name
+			client.Tags = cli.Tags
+			client.Metadata = cli.Metadata
+		case postgres.EnableOp:
+			client.Status = mfclients.EnabledStatus
+		case postgres.DisableOp:
+			client.Status = mfclients.DisabledStatus
+		}
+		cli = client
+	default:
+		return postgres.Operation{}, postgres.ErrInvalidOperation
+	}
+	op.Client = cli
+
+	return op, nil
+}
+
+// operationScope returns the action and object the operation requires. Things
+// are created on the things collection, while the rest of the operations act
+// on the thing itself, and enabling or disabling it requires the delete action.
+func operationScope(op postgres.Operation) (string, string, bool) {
+	switch op.Kind {
+	case postgres.CreateOp:
+		return updateRelationKey, thingsObjectKey, true
+	case postgres.UpdateOp:
+		return updateRelationKey, op.Client.ID, true
+	case postgres.EnableOp, postgres.DisableOp:
+		return deleteRelationKey, op.Client.ID, true
+	default:
+		return "", "", false
+	}
+}
+
 func (svc service) changeClientStatus(ctx context.Context, token string, client mfclients.Client) (mfclients.Client, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, deleteRelationKey, client.ID)
 	if err != nil {
 		return mfclients.Client{}, err
 	}
@@ -278,7 +568,7 @@ func (svc service) changeClientStatus(ctx context.Context, token string, client
 }
 
 func (svc service) ListClientsByGroup(ctx context.Context, token, groupID string, pm mfclients.Page) (mfclients.MembersPage, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, listRelationKey, groupID)
 	if err != nil {
 		return mfclients.MembersPage{}, err
 	}
@@ -297,22 +587,38 @@ func (svc service) Identify(ctx context.Context, key string) (string, error) {
 		return id, nil
 	}
 	client, err := svc.clients.RetrieveBySecret(ctx, key)
-	if err != nil {
+	switch {
+	case err == nil:
+		id = client.ID
+	case errors.Contains(err, errors.ErrNotFound):
+		secret, err := svc.clients.RetrieveSecret(ctx, key)
+		if err != nil {
+			return "", err
+		}
+		// Expiring secrets are not cached, so they stop being
+		// accepted as soon as they expire.
+		if !secret.ExpiresAt.IsZero() {
+			return secret.ClientID, nil
+		}
+		id = secret.ClientID
+	default:
 		return "", err
 	}
-	if err := svc.clientCache.Save(ctx, key, client.ID); err != nil {
+	if err := svc.clientCache.Save(ctx, key, id); err != nil {
 		return "", err
 	}
-	return client.ID, nil
+	return id, nil
 }
 
-func (svc service) identify(ctx context.Context, token string) (string, error) {
-	req := &upolicies.IdentifyReq{Token: token}
+// identify identifies the user. The action and object are checked against
+// the scope of the API key, if one is used instead of an access token.
+func (svc service) identify(ctx context.Context, token, action, object string) (string, error) {
+	req := &upolicies.IdentifyReq{Token: token, Action: action, Object: object}
 	res, err := svc.uauth.Identify(ctx, req)
 	if err != nil {
 		return "", err
 	}
-	return res.GetId(), nil
+	return res.GetOwner(), nil
 }
 
 func (svc service) authorize(ctx context.Context, subject, object, action string) error {
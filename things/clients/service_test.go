@@ -16,12 +16,15 @@ import (
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things/clients"
 	"github.com/mainflux/mainflux/things/clients/mocks"
+	cpostgres "github.com/mainflux/mainflux/things/clients/postgres"
 	gmocks "github.com/mainflux/mainflux/things/groups/mocks"
 	"github.com/mainflux/mainflux/things/policies"
 	pmocks "github.com/mainflux/mainflux/things/policies/mocks"
+	upolicies "github.com/mainflux/mainflux/users/policies"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 var (
@@ -40,6 +43,7 @@ var (
 	inValidToken      = "invalidToken"
 	withinDuration    = 5 * time.Second
 	adminEmail        = "admin@example.com"
+	orgID             = testsutil.GenerateUUID(&testing.T{}, idProvider)
 	token             = "token"
 	myKey             = "mine"
 	adminRelationKeys = []string{"c_update", "c_list", "c_delete", "c_share"}
@@ -56,7 +60,7 @@ func newService(tokens map[string]string) (clients.Service, *mocks.Repository, *
 	pRepo := new(pmocks.Repository)
 
 	psvc := policies.NewService(auth, pRepo, policiesCache, idProvider)
-	return clients.NewService(auth, psvc, cRepo, gRepo, thingCache, idProvider), cRepo, pRepo
+	return clients.NewService(auth, psvc, cRepo, gRepo, thingCache, policiesCache, idProvider), cRepo, pRepo
 }
 
 func TestRegisterClient(t *testing.T) {
@@ -264,6 +268,101 @@ func TestRegisterClient(t *testing.T) {
 	}
 }
 
+func TestRegisterClientOwner(t *testing.T) {
+	svc, cRepo, _ := newService(map[string]string{token: adminEmail})
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+
+	cases := []struct {
+		desc  string
+		owner string
+		want  string
+	}{
+		{
+			desc:  "register thing without owner",
+			owner: "",
+			want:  adminEmail,
+		},
+		{
+			desc:  "register thing owned by another user",
+			owner: ownerID,
+			want:  ownerID,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("Save", context.Background(), mock.Anything).Return([]mfclients.Client{}, nil)
+		_, err := svc.CreateThings(context.Background(), token, mfclients.Client{Owner: tc.owner})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		ok := repoCall.Parent.AssertCalled(t, "Save", context.Background(), mock.MatchedBy(func(cs []mfclients.Client) bool {
+			return len(cs) == 1 && cs[0].Owner == tc.want
+		}))
+		assert.True(t, ok, fmt.Sprintf("%s: expected thing owned by %s\n", tc.desc, tc.want))
+		repoCall.Unset()
+		cRepo.Calls = nil
+	}
+}
+
+// orgAuth identifies every token as scoped to the organization with the
+// given things quota.
+type orgAuth struct {
+	upolicies.AuthServiceClient
+	quota uint64
+}
+
+func (oa orgAuth) Identify(_ context.Context, _ *upolicies.IdentifyReq, _ ...grpc.CallOption) (*upolicies.IdentifyRes, error) {
+	return &upolicies.IdentifyRes{Id: adminEmail, OrgId: orgID, ThingsQuota: oa.quota}, nil
+}
+
+func TestRegisterClientQuota(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	auth := orgAuth{AuthServiceClient: mocks.NewAuthService(map[string]string{}, nil), quota: 2}
+	psvc := policies.NewService(auth, new(pmocks.Repository), pmocks.NewCache(), uuid.NewMock())
+	svc := clients.NewService(auth, psvc, cRepo, new(gmocks.Repository), mocks.NewCache(), pmocks.NewCache(), uuid.NewMock())
+
+	cases := []struct {
+		desc    string
+		owner   string
+		count   int
+		saveErr error
+		err     error
+	}{
+		{
+			desc:  "register things within the quota",
+			count: 2,
+			err:   nil,
+		},
+		{
+			desc:  "register things owned by another user",
+			owner: testsutil.GenerateUUID(t, idProvider),
+			count: 2,
+			err:   nil,
+		},
+		{
+			desc:    "register things exceeding the quota",
+			count:   2,
+			saveErr: errors.Wrap(errors.ErrAuthorization, errors.ErrQuotaExceeded),
+			err:     errors.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("SaveWithQuota", context.Background(), orgID, uint64(2), mock.Anything).Return([]mfclients.Client{}, tc.saveErr)
+		clis := make([]mfclients.Client, tc.count)
+		for i := range clis {
+			clis[i].Owner = tc.owner
+		}
+		saved, err := svc.CreateThings(context.Background(), token, clis...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Len(t, saved, tc.count, fmt.Sprintf("%s: expected %d things got %d\n", tc.desc, tc.count, len(saved)))
+			for _, cli := range saved {
+				assert.Equal(t, orgID, cli.Owner, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, orgID, cli.Owner))
+			}
+		}
+		repoCall.Unset()
+	}
+}
+
 func TestViewClient(t *testing.T) {
 	svc, cRepo, pRepo := newService(map[string]string{token: adminEmail})
 
@@ -1031,6 +1130,527 @@ func TestDisableClient(t *testing.T) {
 	}
 }
 
+func TestDeleteClient(t *testing.T) {
+	adminPolicy := mocks.MockSubjectSet{Object: ID, Relation: adminRelationKeys}
+	auth := mocks.NewAuthService(map[string]string{token: adminEmail}, map[string][]mocks.MockSubjectSet{adminEmail: {adminPolicy}})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	psvc := policies.NewService(auth, pRepo, policiesCache, uuid.NewMock())
+	svc := clients.NewService(auth, psvc, cRepo, new(gmocks.Repository), thingCache, policiesCache, uuid.NewMock())
+
+	chanID := testsutil.GenerateUUID(t, idProvider)
+	named := cpostgres.Secret{ClientID: client.ID, Name: "backup", Secret: "backupsecret"}
+	cases := []struct {
+		desc  string
+		id    string
+		token string
+		err   error
+	}{
+		{
+			desc:  "delete existing client",
+			id:    client.ID,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "delete client with invalid token",
+			id:    client.ID,
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "delete non-existing client",
+			id:    mocks.WrongID,
+			token: token,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingCache.Save(context.Background(), secret, client.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error while caching thing: %s", tc.desc, err))
+		cp := policies.CachedPolicy{ThingID: client.ID, ThingKey: secret, ChannelID: chanID, Actions: []string{"m_write"}}
+		err = policiesCache.Put(context.Background(), cp)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error while caching policy: %s", tc.desc, err))
+		ncp := policies.CachedPolicy{ThingID: client.ID, ThingKey: named.Secret, ChannelID: chanID, Actions: []string{"m_write"}}
+		err = policiesCache.Put(context.Background(), ncp)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error while caching policy: %s", tc.desc, err))
+
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), tc.id).Return(client, nil)
+		repoCall2 := cRepo.On("Delete", context.Background(), tc.id).Return(nil)
+		repoCall3 := cRepo.On("RetrieveSecrets", context.Background(), tc.id).Return([]cpostgres.Secret{named}, nil)
+		err = svc.DeleteClient(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			_, err := thingCache.ID(context.Background(), secret)
+			assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("%s: expected thing to be removed from cache", tc.desc))
+			_, err = policiesCache.Get(context.Background(), cp)
+			assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("%s: expected policy to be removed from cache", tc.desc))
+			_, err = policiesCache.Get(context.Background(), ncp)
+			assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("%s: expected named secret policy to be removed from cache", tc.desc))
+			ok := cRepo.AssertCalled(t, "Delete", context.Background(), tc.id)
+			assert.True(t, ok, fmt.Sprintf("%s: Delete was not called on %s", tc.desc, tc.id))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
+
+func TestAddClientSecret(t *testing.T) {
+	svc, cRepo, pRepo := newService(map[string]string{token: adminEmail})
+
+	named := cpostgres.Secret{ClientID: client.ID, Name: "backup", Secret: "backupsecret"}
+	cases := []struct {
+		desc     string
+		id       string
+		token    string
+		secret   cpostgres.Secret
+		response cpostgres.Secret
+		err      error
+	}{
+		{
+			desc:     "add client secret with valid token",
+			id:       client.ID,
+			token:    token,
+			secret:   cpostgres.Secret{Name: named.Name, Secret: named.Secret},
+			response: named,
+			err:      nil,
+		},
+		{
+			desc:     "add generated client secret with valid token",
+			id:       client.ID,
+			token:    token,
+			secret:   cpostgres.Secret{Name: named.Name},
+			response: named,
+			err:      nil,
+		},
+		{
+			desc:     "add client secret with invalid token",
+			id:       client.ID,
+			token:    inValidToken,
+			secret:   cpostgres.Secret{Name: named.Name},
+			response: cpostgres.Secret{},
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "add client secret to non-existing client",
+			id:       mocks.WrongID,
+			token:    token,
+			secret:   cpostgres.Secret{Name: named.Name},
+			response: cpostgres.Secret{},
+			err:      errors.ErrNotFound,
+		},
+		{
+			desc:     "add already existing client secret",
+			id:       client.ID,
+			token:    token,
+			secret:   cpostgres.Secret{Name: named.Name, Secret: secret},
+			response: cpostgres.Secret{},
+			err:      errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("SaveSecret", context.Background(), mock.Anything).Return(tc.response, tc.err)
+		saved, err := svc.AddClientSecret(context.Background(), tc.token, tc.id, tc.secret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, saved))
+		if tc.err == nil {
+			ok := repoCall1.Parent.AssertCalled(t, "SaveSecret", context.Background(), mock.MatchedBy(func(s cpostgres.Secret) bool {
+				return s.ClientID == tc.id && s.Name == tc.secret.Name && s.Secret != ""
+			}))
+			assert.True(t, ok, fmt.Sprintf("%s: SaveSecret was not called with the client secret", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestUpdateClientSecretExpiry(t *testing.T) {
+	adminPolicy := mocks.MockSubjectSet{Object: ID, Relation: adminRelationKeys}
+	auth := mocks.NewAuthService(map[string]string{token: adminEmail}, map[string][]mocks.MockSubjectSet{adminEmail: {adminPolicy}})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	psvc := policies.NewService(auth, pRepo, policiesCache, uuid.NewMock())
+	svc := clients.NewService(auth, psvc, cRepo, new(gmocks.Repository), thingCache, policiesCache, uuid.NewMock())
+
+	expiresAt := time.Now().Add(time.Hour)
+	named := cpostgres.Secret{ClientID: client.ID, Name: "backup", Secret: "backupsecret", ExpiresAt: expiresAt}
+	chanID := testsutil.GenerateUUID(t, idProvider)
+	cases := []struct {
+		desc     string
+		id       string
+		name     string
+		token    string
+		response cpostgres.Secret
+		err      error
+	}{
+		{
+			desc:     "update client secret expiry with valid token",
+			id:       client.ID,
+			name:     named.Name,
+			token:    token,
+			response: named,
+			err:      nil,
+		},
+		{
+			desc:     "update client secret expiry with invalid token",
+			id:       client.ID,
+			name:     named.Name,
+			token:    inValidToken,
+			response: cpostgres.Secret{},
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "update expiry of non-existing client secret",
+			id:       client.ID,
+			name:     "unknown",
+			token:    token,
+			response: cpostgres.Secret{},
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingCache.Save(context.Background(), named.Secret, client.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error while caching thing: %s", tc.desc, err))
+		cp := policies.CachedPolicy{ThingID: client.ID, ThingKey: named.Secret, ChannelID: chanID, Actions: []string{"m_write"}}
+		err = policiesCache.Put(context.Background(), cp)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error while caching policy: %s", tc.desc, err))
+
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("UpdateSecretExpiry", context.Background(), cpostgres.Secret{ClientID: tc.id, Name: tc.name, ExpiresAt: expiresAt}).Return(tc.response, tc.err)
+		updated, err := svc.UpdateClientSecretExpiry(context.Background(), tc.token, tc.id, tc.name, expiresAt)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, updated, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, updated))
+		if tc.err == nil {
+			_, err := thingCache.ID(context.Background(), named.Secret)
+			assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("%s: expected secret to be removed from cache", tc.desc))
+			_, err = policiesCache.Get(context.Background(), cp)
+			assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("%s: expected policy to be removed from cache", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestRemoveClientSecret(t *testing.T) {
+	adminPolicy := mocks.MockSubjectSet{Object: ID, Relation: adminRelationKeys}
+	auth := mocks.NewAuthService(map[string]string{token: adminEmail}, map[string][]mocks.MockSubjectSet{adminEmail: {adminPolicy}})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	psvc := policies.NewService(auth, pRepo, policiesCache, uuid.NewMock())
+	svc := clients.NewService(auth, psvc, cRepo, new(gmocks.Repository), thingCache, policiesCache, uuid.NewMock())
+
+	named := cpostgres.Secret{ClientID: client.ID, Name: "backup", Secret: "backupsecret"}
+	chanID := testsutil.GenerateUUID(t, idProvider)
+	cases := []struct {
+		desc     string
+		id       string
+		name     string
+		token    string
+		response cpostgres.Secret
+		err      error
+	}{
+		{
+			desc:     "remove client secret with valid token",
+			id:       client.ID,
+			name:     named.Name,
+			token:    token,
+			response: named,
+			err:      nil,
+		},
+		{
+			desc:     "remove client secret with invalid token",
+			id:       client.ID,
+			name:     named.Name,
+			token:    inValidToken,
+			response: cpostgres.Secret{},
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "remove non-existing client secret",
+			id:       client.ID,
+			name:     "unknown",
+			token:    token,
+			response: cpostgres.Secret{},
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingCache.Save(context.Background(), named.Secret, client.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error while caching thing: %s", tc.desc, err))
+		cp := policies.CachedPolicy{ThingID: client.ID, ThingKey: named.Secret, ChannelID: chanID, Actions: []string{"m_write"}}
+		err = policiesCache.Put(context.Background(), cp)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error while caching policy: %s", tc.desc, err))
+
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("RemoveSecret", context.Background(), tc.id, tc.name).Return(tc.response, tc.err)
+		err = svc.RemoveClientSecret(context.Background(), tc.token, tc.id, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			_, err := thingCache.ID(context.Background(), named.Secret)
+			assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("%s: expected secret to be removed from cache", tc.desc))
+			_, err = policiesCache.Get(context.Background(), cp)
+			assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("%s: expected policy to be removed from cache", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestExecuteOperations(t *testing.T) {
+	svc, cRepo, pRepo := newService(map[string]string{token: adminEmail})
+
+	many := make([]cpostgres.Operation, 250)
+	for i := range many {
+		many[i] = cpostgres.Operation{Kind: cpostgres.CreateOp}
+	}
+	cases := []struct {
+		desc    string
+		ops     []cpostgres.Operation
+		token   string
+		authErr error
+		chunks  int
+		errs    []error
+		err     error
+	}{
+		{
+			desc: "execute operations of every kind",
+			ops: []cpostgres.Operation{
+				{Kind: cpostgres.CreateOp, Client: mfclients.Client{Name: "new"}},
+				{Kind: cpostgres.UpdateOp, Client: mfclients.Client{ID: client.ID, Name: "updated"}},
+				{Kind: cpostgres.EnableOp, Client: mfclients.Client{ID: client.ID}},
+				{Kind: cpostgres.DisableOp, Client: mfclients.Client{ID: client.ID}},
+			},
+			token:  token,
+			chunks: 1,
+			errs:   []error{nil, nil, nil, nil},
+			err:    nil,
+		},
+		{
+			desc: "execute operations with failing rows",
+			ops: []cpostgres.Operation{
+				{Kind: cpostgres.CreateOp, Client: mfclients.Client{Name: "new"}},
+				{Kind: cpostgres.UpdateOp, Client: mfclients.Client{ID: mocks.WrongID, Name: "updated"}},
+				{Kind: "unknown", Client: mfclients.Client{ID: client.ID}},
+				{Kind: cpostgres.CreateOp, Client: mfclients.Client{Status: mfclients.AllStatus}},
+			},
+			token:  token,
+			chunks: 1,
+			errs:   []error{nil, errors.ErrNotFound, cpostgres.ErrInvalidOperation, apiutil.ErrInvalidStatus},
+			err:    nil,
+		},
+		{
+			desc: "execute unauthorized operations",
+			ops: []cpostgres.Operation{
+				{Kind: cpostgres.CreateOp, Client: mfclients.Client{Name: "new"}},
+				{Kind: cpostgres.DisableOp, Client: mfclients.Client{ID: client.ID}},
+			},
+			token:   token,
+			authErr: errors.ErrAuthorization,
+			chunks:  1,
+			errs:    []error{nil, errors.ErrAuthorization},
+			err:     nil,
+		},
+		{
+			desc:   "execute operations in chunks",
+			ops:    many,
+			token:  token,
+			chunks: 3,
+			err:    nil,
+		},
+		{
+			desc: "execute operations with invalid token",
+			ops: []cpostgres.Operation{
+				{Kind: cpostgres.CreateOp, Client: mfclients.Client{Name: "new"}},
+			},
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, tc.authErr)
+		repoCall1 := cRepo.On("Execute", context.Background(), mock.Anything).Return([]cpostgres.OperationResult{}, nil)
+		results, err := svc.ExecuteOperations(context.Background(), tc.token, tc.ops...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			require.Len(t, results, len(tc.ops), fmt.Sprintf("%s: expected %d results got %d\n", tc.desc, len(tc.ops), len(results)))
+			for i, expected := range tc.errs {
+				assert.True(t, errors.Contains(results[i].Err, expected), fmt.Sprintf("%s: row %d: expected %s got %s\n", tc.desc, i, expected, results[i].Err))
+			}
+			for i, op := range tc.ops {
+				if op.Kind == cpostgres.CreateOp && results[i].Err == nil {
+					assert.NotEmpty(t, results[i].Client.ID, fmt.Sprintf("%s: row %d: expected generated ID", tc.desc, i))
+					assert.NotEmpty(t, results[i].Client.Credentials.Secret, fmt.Sprintf("%s: row %d: expected generated secret", tc.desc, i))
+				}
+			}
+			cRepo.AssertNumberOfCalls(t, "Execute", tc.chunks)
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		cRepo.Calls = nil
+	}
+}
+
+// scopedAuth identifies the token as an API key scoped to the given
+// actions on the given objects.
+type scopedAuth struct {
+	upolicies.AuthServiceClient
+	scope map[string][]string
+}
+
+func (sa scopedAuth) Identify(_ context.Context, req *upolicies.IdentifyReq, _ ...grpc.CallOption) (*upolicies.IdentifyRes, error) {
+	for _, action := range sa.scope[req.GetObject()] {
+		if action == req.GetAction() {
+			return &upolicies.IdentifyRes{Id: adminEmail}, nil
+		}
+	}
+	return nil, errors.ErrAuthorization
+}
+
+func TestExecuteOperationsScope(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	auth := mocks.NewAuthService(map[string]string{}, map[string][]mocks.MockSubjectSet{})
+	other := testsutil.GenerateUUID(t, idProvider)
+
+	cases := []struct {
+		desc  string
+		scope map[string][]string
+		ops   []cpostgres.Operation
+		errs  []error
+		err   error
+	}{
+		{
+			desc:  "execute operations within the key scope",
+			scope: map[string][]string{client.ID: {"c_update", "c_delete"}},
+			ops: []cpostgres.Operation{
+				{Kind: cpostgres.UpdateOp, Client: mfclients.Client{ID: client.ID, Name: "updated"}},
+				{Kind: cpostgres.DisableOp, Client: mfclients.Client{ID: client.ID}},
+			},
+			errs: []error{nil, nil},
+			err:  nil,
+		},
+		{
+			desc:  "execute operations partly outside the key scope",
+			scope: map[string][]string{client.ID: {"c_update"}},
+			ops: []cpostgres.Operation{
+				{Kind: cpostgres.UpdateOp, Client: mfclients.Client{ID: client.ID, Name: "updated"}},
+				{Kind: cpostgres.EnableOp, Client: mfclients.Client{ID: client.ID}},
+				{Kind: cpostgres.UpdateOp, Client: mfclients.Client{ID: other, Name: "updated"}},
+				{Kind: cpostgres.CreateOp, Client: mfclients.Client{Name: "new"}},
+			},
+			errs: []error{nil, errors.ErrAuthorization, errors.ErrAuthorization, errors.ErrAuthorization},
+			err:  nil,
+		},
+		{
+			desc:  "execute operations outside the key scope",
+			scope: map[string][]string{other: {"c_update"}},
+			ops: []cpostgres.Operation{
+				{Kind: cpostgres.DisableOp, Client: mfclients.Client{ID: client.ID}},
+			},
+			err: errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		sa := scopedAuth{AuthServiceClient: auth, scope: tc.scope}
+		psvc := policies.NewService(sa, pRepo, pmocks.NewCache(), uuid.NewMock())
+		svc := clients.NewService(sa, psvc, cRepo, new(gmocks.Repository), mocks.NewCache(), pmocks.NewCache(), uuid.NewMock())
+		repoCall := cRepo.On("Execute", context.Background(), mock.Anything).Return([]cpostgres.OperationResult{}, nil)
+		repoCall1 := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		results, err := svc.ExecuteOperations(context.Background(), token, tc.ops...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		for i, expected := range tc.errs {
+			assert.True(t, errors.Contains(results[i].Err, expected), fmt.Sprintf("%s: row %d: expected %s got %s\n", tc.desc, i, expected, results[i].Err))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	auth := mocks.NewAuthService(map[string]string{token: adminEmail}, map[string][]mocks.MockSubjectSet{})
+	thingCache := mocks.NewCache()
+	policiesCache := pmocks.NewCache()
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	psvc := policies.NewService(auth, pRepo, policiesCache, uuid.NewMock())
+	svc := clients.NewService(auth, psvc, cRepo, new(gmocks.Repository), thingCache, policiesCache, uuid.NewMock())
+
+	cases := []struct {
+		desc      string
+		key       string
+		client    mfclients.Client
+		clientErr error
+		secret    cpostgres.Secret
+		secretErr error
+		response  string
+		cached    bool
+		err       error
+	}{
+		{
+			desc:     "identify client with primary secret",
+			key:      secret,
+			client:   client,
+			response: client.ID,
+			cached:   true,
+			err:      nil,
+		},
+		{
+			desc:      "identify client with named secret",
+			key:       "backupsecret",
+			clientErr: errors.ErrNotFound,
+			secret:    cpostgres.Secret{ClientID: client.ID, Name: "backup", Secret: "backupsecret"},
+			response:  client.ID,
+			cached:    true,
+			err:       nil,
+		},
+		{
+			desc:      "identify client with expiring named secret",
+			key:       "expiringsecret",
+			clientErr: errors.ErrNotFound,
+			secret:    cpostgres.Secret{ClientID: client.ID, Name: "old", Secret: "expiringsecret", ExpiresAt: time.Now().Add(time.Hour)},
+			response:  client.ID,
+			cached:    false,
+			err:       nil,
+		},
+		{
+			desc:      "identify client with expired or unknown secret",
+			key:       "unknownsecret",
+			clientErr: errors.ErrNotFound,
+			secretErr: errors.ErrNotFound,
+			response:  "",
+			err:       errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveBySecret", context.Background(), tc.key).Return(tc.client, tc.clientErr)
+		repoCall1 := cRepo.On("RetrieveSecret", context.Background(), tc.key).Return(tc.secret, tc.secretErr)
+		id, err := svc.Identify(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response, id))
+		_, err = thingCache.ID(context.Background(), tc.key)
+		assert.Equal(t, tc.cached, err == nil, fmt.Sprintf("%s: expected cached to be %t", tc.desc, tc.cached))
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
 func TestListMembers(t *testing.T) {
 	svc, cRepo, _ := newService(map[string]string{token: adminEmail})
 
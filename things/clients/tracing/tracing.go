@@ -5,9 +5,11 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/things/clients"
+	"github.com/mainflux/mainflux/things/clients/postgres"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -100,6 +102,54 @@ func (tm *tracingMiddleware) DisableClient(ctx context.Context, token, id string
 	return tm.svc.DisableClient(ctx, token, id)
 }
 
+// DeleteClient traces the "DeleteClient" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) DeleteClient(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_client", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.DeleteClient(ctx, token, id)
+}
+
+// AddClientSecret traces the "AddClientSecret" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) AddClientSecret(ctx context.Context, token, id string, secret postgres.Secret) (postgres.Secret, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_add_client_secret", trace.WithAttributes(attribute.String("id", id), attribute.String("name", secret.Name)))
+	defer span.End()
+
+	return tm.svc.AddClientSecret(ctx, token, id, secret)
+}
+
+// ListClientSecrets traces the "ListClientSecrets" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) ListClientSecrets(ctx context.Context, token, id string) ([]postgres.Secret, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_client_secrets", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ListClientSecrets(ctx, token, id)
+}
+
+// UpdateClientSecretExpiry traces the "UpdateClientSecretExpiry" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) UpdateClientSecretExpiry(ctx context.Context, token, id, name string, expiresAt time.Time) (postgres.Secret, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_update_client_secret_expiry", trace.WithAttributes(attribute.String("id", id), attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.UpdateClientSecretExpiry(ctx, token, id, name, expiresAt)
+}
+
+// RemoveClientSecret traces the "RemoveClientSecret" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) RemoveClientSecret(ctx context.Context, token, id, name string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_client_secret", trace.WithAttributes(attribute.String("id", id), attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.RemoveClientSecret(ctx, token, id, name)
+}
+
+// ExecuteOperations traces the "ExecuteOperations" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) ExecuteOperations(ctx context.Context, token string, ops ...postgres.Operation) ([]postgres.OperationResult, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_execute_client_operations", trace.WithAttributes(attribute.Int("operations", len(ops))))
+	defer span.End()
+
+	return tm.svc.ExecuteOperations(ctx, token, ops...)
+}
+
 // ListClientsByGroup traces the "ListClientsByGroup" operation of the wrapped policies.Service.
 func (tm *tracingMiddleware) ListClientsByGroup(ctx context.Context, token, groupID string, pm mfclients.Page) (mfclients.MembersPage, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_list_things_by_channel", trace.WithAttributes(attribute.String("groupID", groupID)))
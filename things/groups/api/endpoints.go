@@ -111,6 +111,19 @@ func disableGroupEndpoint(svc groups.Service) endpoint.Endpoint {
 	}
 }
 
+func deleteGroupEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteGroupReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := svc.DeleteGroup(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+		return deleteGroupRes{}, nil
+	}
+}
+
 func listGroupsEndpoint(svc groups.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listGroupsReq)
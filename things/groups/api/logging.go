@@ -96,6 +96,18 @@ func (lm *loggingMiddleware) DisableGroup(ctx context.Context, token string, id
 	return lm.svc.DisableGroup(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) DeleteGroup(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method delete_channel for channel with id %s using token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.DeleteGroup(ctx, token, id)
+}
+
 func (lm *loggingMiddleware) ListMemberships(ctx context.Context, token, thingID string, cp mfgroups.GroupsPage) (mp mfgroups.MembershipsPage, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method list_channels_by_thing for thing with id %s using token %s took %s to complete", thingID, token, time.Since(begin))
@@ -77,6 +77,14 @@ func (ms *metricsMiddleware) DisableGroup(ctx context.Context, token string, id
 	return ms.svc.DisableGroup(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) DeleteGroup(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_channel").Add(1)
+		ms.latency.With("method", "delete_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteGroup(ctx, token, id)
+}
+
 func (ms *metricsMiddleware) ListMemberships(ctx context.Context, token, clientID string, gp mfgroups.GroupsPage) (mp mfgroups.MembershipsPage, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_channels_by_thing").Add(1)
@@ -149,3 +149,15 @@ func (req changeGroupStatusReq) validate() error {
 	}
 	return nil
 }
+
+type deleteGroupReq struct {
+	token string
+	id    string
+}
+
+func (req deleteGroupReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ var (
 	_ mainflux.Response = (*createGroupRes)(nil)
 	_ mainflux.Response = (*groupPageRes)(nil)
 	_ mainflux.Response = (*changeStatusRes)(nil)
+	_ mainflux.Response = (*deleteGroupRes)(nil)
 	_ mainflux.Response = (*viewGroupRes)(nil)
 	_ mainflux.Response = (*updateGroupRes)(nil)
 )
@@ -151,3 +152,17 @@ func (res changeStatusRes) Headers() map[string]string {
 func (res changeStatusRes) Empty() bool {
 	return false
 }
+
+type deleteGroupRes struct{}
+
+func (res deleteGroupRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res deleteGroupRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteGroupRes) Empty() bool {
+	return true
+}
@@ -21,6 +21,10 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+// errPresenceFilter indicates that channels were filtered by presence, which
+// only things have.
+var errPresenceFilter = errors.New("channels can't be filtered by presence")
+
 // MakeHandler returns a HTTP handler for API endpoints.
 func MakeHandler(svc groups.Service, mux *bone.Mux, logger logger.Logger) http.Handler {
 	opts := []kithttp.ServerOption{
@@ -82,6 +86,13 @@ func MakeHandler(svc groups.Service, mux *bone.Mux, logger logger.Logger) http.H
 		opts...,
 	), "disable_channel"))
 
+	mux.Delete("/channels/:chanID", otelhttp.NewHandler(kithttp.NewServer(
+		deleteGroupEndpoint(svc),
+		decodeDeleteGroup,
+		api.EncodeResponse,
+		opts...,
+	), "delete_channel"))
+
 	return mux
 }
 
@@ -122,6 +133,17 @@ func decodeListMembershipRequest(_ context.Context, r *http.Request) (interface{
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	expr, err := apiutil.ReadRawStringQuery(r, api.FilterKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	filter, err := mfclients.ParseFilter(expr)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	if filter.Uses(mfclients.OnlineField, mfclients.LastSeenField) {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(apiutil.ErrInvalidFilter, errPresenceFilter))
+	}
 	st, err := mfclients.ToStatus(s)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
@@ -139,6 +161,7 @@ func decodeListMembershipRequest(_ context.Context, r *http.Request) (interface{
 				Name:     name,
 				Metadata: meta,
 				Status:   st,
+				Filter:   filter,
 			},
 			Direction: dir,
 		},
@@ -187,6 +210,17 @@ func decodeListGroupsRequest(_ context.Context, r *http.Request) (interface{}, e
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	expr, err := apiutil.ReadRawStringQuery(r, api.FilterKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	filter, err := mfclients.ParseFilter(expr)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	if filter.Uses(mfclients.OnlineField, mfclients.LastSeenField) {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(apiutil.ErrInvalidFilter, errPresenceFilter))
+	}
 	st, err := mfclients.ToStatus(s)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
@@ -205,6 +239,7 @@ func decodeListGroupsRequest(_ context.Context, r *http.Request) (interface{}, e
 				Name:     name,
 				Metadata: meta,
 				Status:   st,
+				Filter:   filter,
 			},
 			Direction: dir,
 		},
@@ -270,3 +305,12 @@ func decodeChangeGroupStatus(_ context.Context, r *http.Request) (interface{}, e
 
 	return req, nil
 }
+
+func decodeDeleteGroup(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deleteGroupReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "chanID"),
+	}
+
+	return req, nil
+}
@@ -19,6 +19,9 @@ const (
 	groupView            = groupPrefix + "view"
 	groupList            = groupPrefix + "list"
 	groupListMemberships = groupPrefix + "list_by_group"
+
+	// deletedStatus marks the remove event of the deleted channel.
+	deletedStatus = "deleted"
 )
 
 var (
@@ -5,6 +5,7 @@ package events
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/events"
 	"github.com/mainflux/mainflux/pkg/events/redis"
@@ -131,6 +132,19 @@ func (es *eventStore) DisableGroup(ctx context.Context, token, id string) (mfgro
 	return es.delete(ctx, group)
 }
 
+func (es *eventStore) DeleteGroup(ctx context.Context, token, id string) error {
+	if err := es.svc.DeleteGroup(ctx, token, id); err != nil {
+		return err
+	}
+	event := removeGroupEvent{
+		id:        id,
+		status:    deletedStatus,
+		updatedAt: time.Now(),
+	}
+
+	return es.Publish(ctx, event)
+}
+
 func (es *eventStore) delete(ctx context.Context, group mfgroups.Group) (mfgroups.Group, error) {
 	event := removeGroupEvent{
 		id:        group.ID,
@@ -32,4 +32,8 @@ type Service interface {
 
 	// DisableGroup logically disables the group identified with the provided ID.
 	DisableGroup(ctx context.Context, token, id string) (groups.Group, error)
+
+	// DeleteGroup removes the group identified with the provided ID together
+	// with its policies and descendants.
+	DeleteGroup(ctx context.Context, token, id string) error
 }
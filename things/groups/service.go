@@ -27,27 +27,30 @@ const (
 )
 
 type service struct {
-	uauth      upolicies.AuthServiceClient
-	policies   tpolicies.Service
-	groups     groups.Repository
-	idProvider mainflux.IDProvider
+	uauth       upolicies.AuthServiceClient
+	policies    tpolicies.Service
+	groups      groups.Repository
+	policyCache tpolicies.Cache
+	idProvider  mainflux.IDProvider
 }
 
 // NewService returns a new Clients service implementation.
-func NewService(uauth upolicies.AuthServiceClient, policies tpolicies.Service, g groups.Repository, idp mainflux.IDProvider) Service {
+func NewService(uauth upolicies.AuthServiceClient, policies tpolicies.Service, g groups.Repository, pcache tpolicies.Cache, idp mainflux.IDProvider) Service {
 	return service{
-		uauth:      uauth,
-		policies:   policies,
-		groups:     g,
-		idProvider: idp,
+		uauth:       uauth,
+		policies:    policies,
+		groups:      g,
+		policyCache: pcache,
+		idProvider:  idp,
 	}
 }
 
 func (svc service) CreateGroups(ctx context.Context, token string, gs ...groups.Group) ([]groups.Group, error) {
-	userID, err := svc.identify(ctx, token)
+	res, err := svc.uauth.Identify(ctx, &upolicies.IdentifyReq{Token: token, Action: updateRelationKey, Object: thingsObjectKey})
 	if err != nil {
-		return []groups.Group{}, err
+		return []groups.Group{}, errors.Wrap(errors.ErrAuthorization, err)
 	}
+	userID := res.GetOwner()
 
 	var grps []groups.Group
 	for _, g := range gs {
@@ -58,7 +61,9 @@ func (svc service) CreateGroups(ctx context.Context, token string, gs ...groups.
 			}
 			g.ID = groupID
 		}
-		if g.Owner == "" {
+		// Groups created using the organization scoped token are always
+		// owned by the organization, so that its quota applies to them.
+		if g.Owner == "" || res.GetOrgId() != "" {
 			g.Owner = userID
 		}
 
@@ -66,18 +71,24 @@ func (svc service) CreateGroups(ctx context.Context, token string, gs ...groups.
 			return []groups.Group{}, apiutil.ErrInvalidStatus
 		}
 		g.CreatedAt = time.Now()
+		grps = append(grps, g)
+	}
+	if quota := res.GetChannelsQuota(); quota > 0 {
+		return svc.groups.SaveWithQuota(ctx, userID, quota, grps...)
+	}
 
+	for i, g := range grps {
 		grp, err := svc.groups.Save(ctx, g)
 		if err != nil {
 			return []groups.Group{}, err
 		}
-		grps = append(grps, grp)
+		grps[i] = grp
 	}
 	return grps, nil
 }
 
 func (svc service) ViewGroup(ctx context.Context, token string, id string) (groups.Group, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, listRelationKey, id)
 	if err != nil {
 		return groups.Group{}, err
 	}
@@ -88,7 +99,7 @@ func (svc service) ViewGroup(ctx context.Context, token string, id string) (grou
 }
 
 func (svc service) ListGroups(ctx context.Context, token string, gm groups.GroupsPage) (groups.GroupsPage, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, listRelationKey, thingsObjectKey)
 	if err != nil {
 		return groups.GroupsPage{}, err
 	}
@@ -105,7 +116,7 @@ func (svc service) ListGroups(ctx context.Context, token string, gm groups.Group
 }
 
 func (svc service) ListMemberships(ctx context.Context, token, clientID string, gm groups.GroupsPage) (groups.MembershipsPage, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, listRelationKey, clientID)
 	if err != nil {
 		return groups.MembershipsPage{}, err
 	}
@@ -121,7 +132,7 @@ func (svc service) ListMemberships(ctx context.Context, token, clientID string,
 }
 
 func (svc service) UpdateGroup(ctx context.Context, token string, g groups.Group) (groups.Group, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, updateRelationKey, g.ID)
 	if err != nil {
 		return groups.Group{}, err
 	}
@@ -163,8 +174,23 @@ func (svc service) DisableGroup(ctx context.Context, token, id string) (groups.G
 	return group, nil
 }
 
+func (svc service) DeleteGroup(ctx context.Context, token, id string) error {
+	userID, err := svc.identify(ctx, token, deleteRelationKey, id)
+	if err != nil {
+		return err
+	}
+	if err := svc.authorize(ctx, userID, id, deleteRelationKey); err != nil {
+		return err
+	}
+	if err := svc.groups.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return svc.policyCache.Remove(ctx, tpolicies.CachedPolicy{ChannelID: id})
+}
+
 func (svc service) changeGroupStatus(ctx context.Context, token string, group groups.Group) (groups.Group, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, deleteRelationKey, group.ID)
 	if err != nil {
 		return groups.Group{}, err
 	}
@@ -183,13 +209,15 @@ func (svc service) changeGroupStatus(ctx context.Context, token string, group gr
 	return svc.groups.ChangeStatus(ctx, group)
 }
 
-func (svc service) identify(ctx context.Context, token string) (string, error) {
-	req := &upolicies.IdentifyReq{Token: token}
+// identify identifies the user. The action and object are checked against
+// the scope of the API key, if one is used instead of an access token.
+func (svc service) identify(ctx context.Context, token, action, object string) (string, error) {
+	req := &upolicies.IdentifyReq{Token: token, Action: action, Object: object}
 	res, err := svc.uauth.Identify(ctx, req)
 	if err != nil {
 		return "", errors.Wrap(errors.ErrAuthorization, err)
 	}
-	return res.GetId(), nil
+	return res.GetOwner(), nil
 }
 
 func (svc service) authorize(ctx context.Context, subject, object, action string) error {
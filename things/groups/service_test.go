@@ -19,9 +19,11 @@ import (
 	gmocks "github.com/mainflux/mainflux/things/groups/mocks"
 	"github.com/mainflux/mainflux/things/policies"
 	pmocks "github.com/mainflux/mainflux/things/policies/mocks"
+	upolicies "github.com/mainflux/mainflux/users/policies"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 var (
@@ -41,6 +43,7 @@ var (
 	withinDuration = 5 * time.Second
 	adminEmail     = "admin@example.com"
 	token          = "token"
+	orgID          = testsutil.GenerateUUID(&testing.T{}, idProvider)
 )
 
 func newService(tokens map[string]string) (groups.Service, *gmocks.Repository, *pmocks.Repository) {
@@ -54,7 +57,7 @@ func newService(tokens map[string]string) (groups.Service, *gmocks.Repository, *
 
 	psvc := policies.NewService(auth, pRepo, policiesCache, idProvider)
 
-	return groups.NewService(auth, psvc, gRepo, idProvider), gRepo, pRepo
+	return groups.NewService(auth, psvc, gRepo, policiesCache, idProvider), gRepo, pRepo
 }
 
 func TestCreateGroup(t *testing.T) {
@@ -126,6 +129,94 @@ func TestCreateGroup(t *testing.T) {
 	}
 }
 
+func TestCreateGroupOwner(t *testing.T) {
+	svc, gRepo, _ := newService(map[string]string{token: adminEmail})
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+
+	cases := []struct {
+		desc  string
+		owner string
+		want  string
+	}{
+		{
+			desc:  "create group without owner",
+			owner: "",
+			want:  adminEmail,
+		},
+		{
+			desc:  "create group owned by another user",
+			owner: ownerID,
+			want:  ownerID,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := gRepo.On("Save", context.Background(), mock.Anything).Return(mfgroups.Group{}, nil)
+		_, err := svc.CreateGroups(context.Background(), token, mfgroups.Group{Name: gName, Owner: tc.owner, Status: clients.EnabledStatus})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		ok := repoCall.Parent.AssertCalled(t, "Save", context.Background(), mock.MatchedBy(func(g mfgroups.Group) bool {
+			return g.Owner == tc.want
+		}))
+		assert.True(t, ok, fmt.Sprintf("%s: expected group owned by %s\n", tc.desc, tc.want))
+		repoCall.Unset()
+		gRepo.Calls = nil
+	}
+}
+
+// orgAuth identifies every token as scoped to the organization with the
+// given channels quota.
+type orgAuth struct {
+	upolicies.AuthServiceClient
+	quota uint64
+}
+
+func (oa orgAuth) Identify(_ context.Context, _ *upolicies.IdentifyReq, _ ...grpc.CallOption) (*upolicies.IdentifyRes, error) {
+	return &upolicies.IdentifyRes{Id: adminEmail, OrgId: orgID, ChannelsQuota: oa.quota}, nil
+}
+
+func TestCreateGroupQuota(t *testing.T) {
+	gRepo := new(gmocks.Repository)
+	auth := orgAuth{AuthServiceClient: mocks.NewAuthService(map[string]string{}, nil), quota: 2}
+	psvc := policies.NewService(auth, new(pmocks.Repository), pmocks.NewCache(), uuid.NewMock())
+	svc := groups.NewService(auth, psvc, gRepo, pmocks.NewCache(), uuid.NewMock())
+
+	cases := []struct {
+		desc    string
+		owner   string
+		saveErr error
+		err     error
+	}{
+		{
+			desc: "create groups within the quota",
+			err:  nil,
+		},
+		{
+			desc:  "create groups owned by another user",
+			owner: testsutil.GenerateUUID(t, idProvider),
+			err:   nil,
+		},
+		{
+			desc:    "create groups exceeding the quota",
+			saveErr: errors.Wrap(errors.ErrAuthorization, errors.ErrQuotaExceeded),
+			err:     errors.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := gRepo.On("SaveWithQuota", context.Background(), orgID, uint64(2), mock.Anything).Return([]mfgroups.Group{}, tc.saveErr)
+		g := mfgroups.Group{Name: gName, Owner: tc.owner, Status: clients.EnabledStatus}
+		saved, err := svc.CreateGroups(context.Background(), token, g, g)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Len(t, saved, 2, fmt.Sprintf("%s: expected %d groups got %d\n", tc.desc, 2, len(saved)))
+			for _, grp := range saved {
+				assert.Equal(t, orgID, grp.Owner, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, orgID, grp.Owner))
+			}
+		}
+		repoCall.Unset()
+	}
+}
+
 func TestUpdateGroup(t *testing.T) {
 	svc, gRepo, pRepo := newService(map[string]string{token: adminEmail})
 
@@ -623,6 +714,57 @@ func TestDisableGroup(t *testing.T) {
 	}
 }
 
+func TestDeleteGroup(t *testing.T) {
+	svc, gRepo, pRepo := newService(map[string]string{token: adminEmail})
+
+	cases := []struct {
+		desc    string
+		id      string
+		token   string
+		repoErr error
+		err     error
+	}{
+		{
+			desc:  "delete existing group",
+			id:    group.ID,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "delete group with invalid token",
+			id:    group.ID,
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "delete non-existing group",
+			id:    mocks.WrongID,
+			token: token,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:    "delete group with child groups",
+			id:      group.ID,
+			token:   token,
+			repoErr: errors.Wrap(errors.ErrConflict, mfgroups.ErrGroupNotEmpty),
+			err:     errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateGroupAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := gRepo.On("Delete", context.Background(), tc.id).Return(tc.repoErr)
+		err := svc.DeleteGroup(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			ok := gRepo.AssertCalled(t, "Delete", context.Background(), tc.id)
+			assert.True(t, ok, fmt.Sprintf("%s: Delete was not called on %s", tc.desc, tc.id))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
 func TestListMemberships(t *testing.T) {
 	svc, gRepo, pRepo := newService(map[string]string{token: adminEmail})
 
@@ -78,3 +78,11 @@ func (tm *tracingMiddleware) DisableGroup(ctx context.Context, token, id string)
 
 	return tm.gsvc.DisableGroup(ctx, token, id)
 }
+
+// DeleteGroup traces the "DeleteGroup" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) DeleteGroup(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_group", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.gsvc.DeleteGroup(ctx, token, id)
+}
@@ -10,10 +10,17 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"github.com/mainflux/mainflux/things/policies"
+	"github.com/mainflux/mainflux/things/presence"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
-const svcName = "mainflux.things.policies.AuthService"
+const (
+	svcName = "mainflux.things.policies.AuthService"
+
+	protocolKey   = "mf-protocol"
+	remoteAddrKey = "mf-remote-addr"
+)
 
 var _ policies.AuthServiceClient = (*grpcClient)(nil)
 
@@ -33,6 +40,7 @@ func NewClient(conn *grpc.ClientConn, timeout time.Duration) policies.AuthServic
 			encodeAuthorizeRequest,
 			decodeAuthorizeResponse,
 			policies.AuthorizeRes{},
+			kitgrpc.ClientBefore(encodeSource),
 		).Endpoint(),
 		identify: kitgrpc.NewClient(
 			conn,
@@ -79,6 +87,19 @@ func (client grpcClient) Identify(ctx context.Context, req *policies.IdentifyReq
 	return &policies.IdentifyRes{Id: ires.id}, nil
 }
 
+// encodeSource passes the source of the request on to the things service,
+// which tracks the presence of the authorized things.
+func encodeSource(ctx context.Context, md *metadata.MD) context.Context {
+	if s, ok := presence.FromContext(ctx); ok {
+		md.Set(protocolKey, s.Protocol)
+		if s.RemoteAddr != "" {
+			md.Set(remoteAddrKey, s.RemoteAddr)
+		}
+	}
+
+	return ctx
+}
+
 func encodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(authorizeReq)
 	return &policies.AuthorizeReq{Subject: req.subject, Object: req.object, Action: req.action, EntityType: req.entityType}, nil
@@ -5,15 +5,17 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/mainflux/mainflux/internal/apiutil"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things/clients"
 	"github.com/mainflux/mainflux/things/policies"
+	"github.com/mainflux/mainflux/things/presence"
 )
 
-func authorizeEndpoint(svc policies.Service) endpoint.Endpoint {
+func authorizeEndpoint(svc policies.Service, rec presence.Recorder) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(authorizeReq)
 		if err := req.validate(); err != nil {
@@ -29,6 +31,19 @@ func authorizeEndpoint(svc policies.Service) endpoint.Endpoint {
 		if err != nil {
 			return authorizeRes{}, err
 		}
+		// Only the adapters pass the request source, so the requests of
+		// the other services don't mark the things as seen.
+		if s, ok := presence.FromContext(ctx); ok && req.entityType == policies.ThingEntityType {
+			p := presence.Presence{
+				ThingID:    policy.Subject,
+				LastSeen:   time.Now(),
+				Protocol:   s.Protocol,
+				RemoteAddr: s.RemoteAddr,
+			}
+			// Presence tracking is best effort and must neither deny nor
+			// delay access, so it's recorded in the background.
+			rec.Record(p)
+		}
 
 		return authorizeRes{authorized: true, thingID: policy.Subject}, nil
 	}
@@ -11,7 +11,9 @@ import (
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things/clients"
 	"github.com/mainflux/mainflux/things/policies"
+	"github.com/mainflux/mainflux/things/presence"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -24,12 +26,13 @@ type grpcServer struct {
 }
 
 // NewServer returns new ThingsServiceServer instance.
-func NewServer(csvc clients.Service, psvc policies.Service) policies.AuthServiceServer {
+func NewServer(csvc clients.Service, psvc policies.Service, rec presence.Recorder) policies.AuthServiceServer {
 	return &grpcServer{
 		authorize: kitgrpc.NewServer(
-			authorizeEndpoint(psvc),
+			authorizeEndpoint(psvc, rec),
 			decodeAuthorizeRequest,
 			encodeAuthorizeResponse,
+			kitgrpc.ServerBefore(decodeSource),
 		),
 		identify: kitgrpc.NewServer(
 			identifyEndpoint(csvc),
@@ -57,6 +60,19 @@ func (gs *grpcServer) Identify(ctx context.Context, req *policies.IdentifyReq) (
 	return res.(*policies.IdentifyRes), nil
 }
 
+func decodeSource(ctx context.Context, md metadata.MD) context.Context {
+	protocol := md.Get(protocolKey)
+	if len(protocol) == 0 {
+		return ctx
+	}
+	s := presence.Source{Protocol: protocol[0]}
+	if addr := md.Get(remoteAddrKey); len(addr) > 0 {
+		s.RemoteAddr = addr[0]
+	}
+
+	return presence.NewContext(ctx, s)
+}
+
 func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*policies.AuthorizeReq)
 	return authorizeReq{subject: req.GetSubject(), object: req.GetObject(), action: req.GetAction(), entityType: req.GetEntityType()}, nil
@@ -105,6 +105,53 @@ func connectThingsEndpoint(svc policies.Service) endpoint.Endpoint {
 	}
 }
 
+func addPoliciesEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(addPoliciesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		// Invalid rows are reported without being sent to the service.
+		res := addPoliciesRes{Total: len(req.Policies), Results: make([]policyRowRes, len(req.Policies))}
+		var ps []policies.Policy
+		var rows []int
+		for i, p := range req.Policies {
+			res.Results[i] = policyRowRes{Row: i + 1, Subject: p.Subject, Object: p.Object, Actions: p.Actions}
+			if err := p.validate(); err != nil {
+				res.Results[i].Error = err.Error()
+				continue
+			}
+			if len(p.Actions) == 0 {
+				p.Actions = policies.PolicyTypes
+			}
+			ps = append(ps, policies.Policy{Subject: p.Subject, Object: p.Object, Actions: p.Actions})
+			rows = append(rows, i)
+		}
+		if len(ps) > 0 {
+			results, err := svc.AddPolicies(ctx, req.token, req.External, ps...)
+			if err != nil {
+				return nil, err
+			}
+			for i, r := range results {
+				row := &res.Results[rows[i]]
+				if r.Err != nil {
+					row.Error = r.Err.Error()
+					continue
+				}
+				row.Actions = r.Policy.Actions
+			}
+		}
+		for _, r := range res.Results {
+			if r.Error != "" {
+				res.Failed++
+			}
+		}
+
+		return res, nil
+	}
+}
+
 func updatePolicyEndpoint(svc policies.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		cr := request.(policyReq)
@@ -6,6 +6,7 @@ package http
 import (
 	"github.com/mainflux/mainflux/internal/api"
 	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
 )
 
 type createPolicyReq struct {
@@ -122,3 +123,37 @@ func (req listPoliciesReq) validate() error {
 
 	return nil
 }
+
+type policyRowReq struct {
+	Subject string   `json:"subject"`
+	Object  string   `json:"object"`
+	Actions []string `json:"actions,omitempty"`
+}
+
+func (req policyRowReq) validate() error {
+	if req.Subject == "" || req.Object == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type addPoliciesReq struct {
+	token    string
+	Policies []policyRowReq `json:"policies"`
+	External bool           `json:"external,omitempty"`
+}
+
+func (req addPoliciesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.Policies) == 0 {
+		return apiutil.ErrEmptyList
+	}
+	if len(req.Policies) > api.MaxOperations {
+		return errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrTooManyOperations)
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ var (
 	_ mainflux.Response = (*listPolicyRes)(nil)
 	_ mainflux.Response = (*updatePolicyRes)(nil)
 	_ mainflux.Response = (*deletePolicyRes)(nil)
+	_ mainflux.Response = (*addPoliciesRes)(nil)
 )
 
 type pageRes struct {
@@ -157,3 +158,29 @@ func (res deletePolicyRes) Headers() map[string]string {
 func (res deletePolicyRes) Empty() bool {
 	return true
 }
+
+type policyRowRes struct {
+	Row     int      `json:"row"`
+	Subject string   `json:"subject"`
+	Object  string   `json:"object"`
+	Actions []string `json:"actions,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type addPoliciesRes struct {
+	Total   int            `json:"total"`
+	Failed  int            `json:"failed"`
+	Results []policyRowRes `json:"results"`
+}
+
+func (res addPoliciesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res addPoliciesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res addPoliciesRes) Empty() bool {
+	return false
+}
@@ -46,6 +46,13 @@ func MakeHandler(csvc clients.Service, psvc policies.Service, mux *bone.Mux, log
 		opts...,
 	), "connect"))
 
+	mux.Post("/policies/bulk", otelhttp.NewHandler(kithttp.NewServer(
+		addPoliciesEndpoint(psvc),
+		decodeAddPolicies,
+		api.EncodeResponse,
+		opts...,
+	), "add_policies"))
+
 	mux.Put("/policies", otelhttp.NewHandler(kithttp.NewServer(
 		updatePolicyEndpoint(psvc),
 		decodeUpdatePolicy,
@@ -119,6 +126,34 @@ func decodeConnectList(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+// decodeAddPolicies decodes policies given either as JSON or as CSV with a
+// header row. CSV actions are comma separated.
+func decodeAddPolicies(_ context.Context, r *http.Request) (interface{}, error) {
+	req := addPoliciesReq{token: apiutil.ExtractBearerToken(r)}
+	switch ct := r.Header.Get("Content-Type"); {
+	case strings.Contains(ct, api.ContentType):
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+		}
+	case strings.Contains(ct, api.CSVContentType):
+		records, err := api.ReadCSV(r.Body, "subject", "object", "actions")
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		for _, rec := range records {
+			p := policyRowReq{Subject: rec["subject"], Object: rec["object"]}
+			if actions, ok := rec["actions"]; ok {
+				p.Actions = strings.Split(actions, ",")
+			}
+			req.Policies = append(req.Policies, p)
+		}
+	default:
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	return req, nil
+}
+
 func decodeIdentify(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
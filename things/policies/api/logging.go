@@ -48,6 +48,18 @@ func (lm *loggingMiddleware) AddPolicy(ctx context.Context, token string, extern
 	return lm.svc.AddPolicy(ctx, token, external, p)
 }
 
+func (lm *loggingMiddleware) AddPolicies(ctx context.Context, token string, external bool, ps ...policies.Policy) (res []policies.PolicyResult, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_policies of %d policies using token %s took %s to complete", len(ps), token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.AddPolicies(ctx, token, external, ps...)
+}
+
 func (lm *loggingMiddleware) UpdatePolicy(ctx context.Context, token string, p policies.Policy) (policy policies.Policy, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method update_policy for client with id %s using token %s took %s to complete", p.Subject, token, time.Since(begin))
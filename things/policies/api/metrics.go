@@ -36,6 +36,14 @@ func (ms *metricsMiddleware) AddPolicy(ctx context.Context, token string, extern
 	return ms.svc.AddPolicy(ctx, token, external, p)
 }
 
+func (ms *metricsMiddleware) AddPolicies(ctx context.Context, token string, external bool, ps ...policies.Policy) ([]policies.PolicyResult, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_policies").Add(1)
+		ms.latency.With("method", "add_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.AddPolicies(ctx, token, external, ps...)
+}
+
 func (ms *metricsMiddleware) UpdatePolicy(ctx context.Context, token string, p policies.Policy) (policy policies.Policy, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "update_policy").Add(1)
@@ -69,6 +69,13 @@ func (pc *pcache) Get(ctx context.Context, policy policies.CachedPolicy) (polici
 }
 
 func (pc *pcache) Remove(ctx context.Context, policy policies.CachedPolicy) error {
+	if policy.ThingKey == "" && policy.ChannelID == "" {
+		return errors.Wrap(errors.ErrRemoveEntity, errors.ErrMalformedEntity)
+	}
+	if policy.ThingKey == "" || policy.ChannelID == "" {
+		return pc.removeMatching(ctx, policy)
+	}
+
 	key, _ := kv(policy)
 	if err := pc.client.Del(ctx, key).Err(); err != nil {
 		return errors.Wrap(errors.ErrRemoveEntity, err)
@@ -77,6 +84,27 @@ func (pc *pcache) Remove(ctx context.Context, policy policies.CachedPolicy) erro
 	return nil
 }
 
+// removeMatching removes the cached policies of all the channels the thing
+// is connected to, or of all the things connected to the channel.
+func (pc *pcache) removeMatching(ctx context.Context, policy policies.CachedPolicy) error {
+	pattern := escape(policy.ThingKey) + separator + "*"
+	if policy.ThingKey == "" {
+		pattern = "*" + separator + escape(policy.ChannelID)
+	}
+
+	iter := pc.client.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		if err := pc.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return errors.Wrap(errors.ErrRemoveEntity, err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
 // kv is used to create a key-value pair for caching.
 func kv(p policies.CachedPolicy) (string, string) {
 	key := p.ThingKey + separator + p.ChannelID
@@ -89,6 +117,12 @@ func kv(p policies.CachedPolicy) (string, string) {
 	return key, val
 }
 
+// escape escapes the glob characters of the Redis key pattern.
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+	return r.Replace(s)
+}
+
 // separateActions is used to separate the actions from the cache values.
 func separateActions(actions string) []string {
 	return strings.Split(actions, separator)
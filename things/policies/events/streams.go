@@ -66,6 +66,27 @@ func (es eventStore) AddPolicy(ctx context.Context, token string, external bool,
 	return policy, nil
 }
 
+func (es *eventStore) AddPolicies(ctx context.Context, token string, external bool, ps ...policies.Policy) ([]policies.PolicyResult, error) {
+	results, err := es.svc.AddPolicies(ctx, token, external, ps...)
+	if err != nil {
+		return results, err
+	}
+
+	for _, res := range results {
+		if res.Err != nil {
+			continue
+		}
+		event := policyEvent{
+			res.Policy, policyAdd,
+		}
+		if err := es.Publish(ctx, event); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
 func (es *eventStore) UpdatePolicy(ctx context.Context, token string, policy policies.Policy) (policies.Policy, error) {
 	policy, err := es.svc.UpdatePolicy(ctx, token, policy)
 	if err != nil {
@@ -62,9 +62,25 @@ func (ccm *cacheMock) Remove(_ context.Context, policy policies.CachedPolicy) er
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	key, _ := kv(policy)
-
-	delete(ccm.policies, key)
+	switch {
+	case policy.ThingKey == "" && policy.ChannelID == "":
+		return errors.ErrMalformedEntity
+	case policy.ThingKey == "":
+		for key := range ccm.policies {
+			if strings.HasSuffix(key, separator+policy.ChannelID) {
+				delete(ccm.policies, key)
+			}
+		}
+	case policy.ChannelID == "":
+		for key := range ccm.policies {
+			if strings.HasPrefix(key, policy.ThingKey+separator) {
+				delete(ccm.policies, key)
+			}
+		}
+	default:
+		key, _ := kv(policy)
+		delete(ccm.policies, key)
+	}
 
 	return nil
 }
@@ -32,16 +32,22 @@ func (m *Repository) Save(ctx context.Context, p policies.Policy) (policies.Poli
 	return ret.Get(0).(policies.Policy), ret.Error(1)
 }
 
+func (m *Repository) SaveAll(ctx context.Context, ps ...policies.Policy) ([]policies.PolicyResult, error) {
+	ret := m.Called(ctx, ps)
+
+	return ret.Get(0).([]policies.PolicyResult), ret.Error(1)
+}
+
 func (m *Repository) Update(ctx context.Context, p policies.Policy) (policies.Policy, error) {
 	ret := m.Called(ctx, p)
 
 	return ret.Get(0).(policies.Policy), ret.Error(1)
 }
 
-func (m *Repository) EvaluateMessagingAccess(ctx context.Context, ar policies.AccessRequest) (policies.Policy, error) {
+func (m *Repository) EvaluateMessagingAccess(ctx context.Context, ar policies.AccessRequest) (policies.CachedPolicy, error) {
 	ret := m.Called(ctx, ar)
 
-	return ret.Get(0).(policies.Policy), ret.Error(1)
+	return ret.Get(0).(policies.CachedPolicy), ret.Error(1)
 }
 
 func (m *Repository) EvaluateThingAccess(ctx context.Context, ar policies.AccessRequest) (policies.Policy, error) {
@@ -55,3 +61,9 @@ func (m *Repository) EvaluateGroupAccess(ctx context.Context, ar policies.Access
 
 	return ret.Get(0).(policies.Policy), ret.Error(1)
 }
+
+func (m *Repository) RetrieveGroupAncestors(ctx context.Context, groupID string) ([]string, error) {
+	ret := m.Called(ctx, groupID)
+
+	return ret.Get(0).([]string), ret.Error(1)
+}
@@ -35,6 +35,12 @@ type AccessRequest struct {
 	Entity  string `json:"entity"`
 }
 
+// PolicyResult holds the outcome of adding a single policy of a bulk request.
+type PolicyResult struct {
+	Policy Policy
+	Err    error
+}
+
 // PolicyPage contains a page of policies.
 type PolicyPage struct {
 	Page
@@ -48,8 +54,14 @@ type Repository interface {
 	// error in case of failures.
 	Save(ctx context.Context, p Policy) (Policy, error)
 
+	// SaveAll saves the policies in a single transaction. A failing policy
+	// doesn't affect the others; its error is reported in the result at the
+	// same index as the policy.
+	SaveAll(ctx context.Context, ps ...Policy) ([]PolicyResult, error)
+
 	// EvaluateMessagingAccess is used to evaluate if thing has access to channel.
-	EvaluateMessagingAccess(ctx context.Context, ar AccessRequest) (Policy, error)
+	// The expiry is set if the thing presented an expiring named secret.
+	EvaluateMessagingAccess(ctx context.Context, ar AccessRequest) (CachedPolicy, error)
 
 	// EvaluateThingAccess is used to evaluate if user has access to a thing.
 	EvaluateThingAccess(ctx context.Context, ar AccessRequest) (Policy, error)
@@ -57,6 +69,10 @@ type Repository interface {
 	// EvaluateGroupAccess is used to evaluate if user has access to a group.
 	EvaluateGroupAccess(ctx context.Context, ar AccessRequest) (Policy, error)
 
+	// RetrieveGroupAncestors retrieves the IDs of the group and its ancestors,
+	// starting with the group itself.
+	RetrieveGroupAncestors(ctx context.Context, groupID string) ([]string, error)
+
 	// Update updates the policy type.
 	Update(ctx context.Context, p Policy) (Policy, error)
 
@@ -85,6 +101,11 @@ type Service interface {
 	// if it is true then the subject is `userID` else it is `thingID`.
 	AddPolicy(ctx context.Context, token string, external bool, p Policy) (Policy, error)
 
+	// AddPolicies adds the policies in chunks, each chunk in a single
+	// transaction, under the same rules as AddPolicy. The result of every
+	// policy is returned at the same index as the policy.
+	AddPolicies(ctx context.Context, token string, external bool, ps ...Policy) ([]PolicyResult, error)
+
 	// DeletePolicy removes a policy.
 	DeletePolicy(ctx context.Context, token string, p Policy) error
 
@@ -100,6 +121,8 @@ type CachedPolicy struct {
 	ThingKey  string
 	ChannelID string
 	Actions   []string
+	// ExpiresAt is the expiry of the named secret used as the thing key.
+	ExpiresAt time.Time
 }
 
 // Cache contains channel-thing connection caching interface.
@@ -110,7 +133,8 @@ type Cache interface {
 	// Get retrieves policy from cache.
 	Get(ctx context.Context, policy CachedPolicy) (CachedPolicy, error)
 
-	// Remove deletes a policy from cache.
+	// Remove deletes a policy from cache. Policy with an empty thing key or
+	// channel ID removes all the cached policies of the channel or the thing.
 	Remove(ctx context.Context, policy CachedPolicy) error
 }
 
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgtype"
+	"github.com/jmoiron/sqlx"
 	"github.com/mainflux/mainflux/internal/postgres"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things/policies"
@@ -29,19 +30,19 @@ func NewRepository(db postgres.Database) policies.Repository {
 	}
 }
 
-func (pr prepo) Save(ctx context.Context, policy policies.Policy) (policies.Policy, error) {
-	q := `INSERT INTO policies (owner_id, subject, object, actions, created_at)
-		VALUES (:owner_id, :subject, :object, :actions, :created_at)
-		ON CONFLICT (subject, object) DO UPDATE SET actions = :actions,
-		updated_at = :updated_at, updated_by = :updated_by
-		RETURNING owner_id, subject, object, actions, created_at, updated_at, updated_by;`
+const savePolicyQuery = `INSERT INTO policies (owner_id, subject, object, actions, created_at)
+	VALUES (:owner_id, :subject, :object, :actions, :created_at)
+	ON CONFLICT (subject, object) DO UPDATE SET actions = :actions,
+	updated_at = :updated_at, updated_by = :updated_by
+	RETURNING owner_id, subject, object, actions, created_at, updated_at, updated_by;`
 
+func (pr prepo) Save(ctx context.Context, policy policies.Policy) (policies.Policy, error) {
 	dbp, err := toDBPolicy(policy)
 	if err != nil {
 		return policies.Policy{}, errors.Wrap(errors.ErrCreateEntity, err)
 	}
 
-	row, err := pr.db.NamedQueryContext(ctx, q, dbp)
+	row, err := pr.db.NamedQueryContext(ctx, savePolicyQuery, dbp)
 	if err != nil {
 		return policies.Policy{}, postgres.HandleError(err, errors.ErrCreateEntity)
 	}
@@ -56,12 +57,110 @@ func (pr prepo) Save(ctx context.Context, policy policies.Policy) (policies.Poli
 	return toPolicy(dbp)
 }
 
-func (pr prepo) EvaluateMessagingAccess(ctx context.Context, ar policies.AccessRequest) (policies.Policy, error) {
-	query := fmt.Sprintf(`SELECT subject, object, actions 
+func (pr prepo) SaveAll(ctx context.Context, ps ...policies.Policy) ([]policies.PolicyResult, error) {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return []policies.PolicyResult{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	// Each policy is saved under a savepoint, so a failing one is rolled
+	// back without aborting the rest of the transaction.
+	results := make([]policies.PolicyResult, len(ps))
+	for i, p := range ps {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT policy"); err != nil {
+			return []policies.PolicyResult{}, rollback(tx, err)
+		}
+		policy, err := save(ctx, tx, p)
+		if err != nil {
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT policy"); err != nil {
+				return []policies.PolicyResult{}, rollback(tx, err)
+			}
+			results[i] = policies.PolicyResult{Err: err}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT policy"); err != nil {
+			return []policies.PolicyResult{}, rollback(tx, err)
+		}
+		results[i] = policies.PolicyResult{Policy: policy}
+	}
+	if err := tx.Commit(); err != nil {
+		return []policies.PolicyResult{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return results, nil
+}
+
+func save(ctx context.Context, tx *sqlx.Tx, policy policies.Policy) (policies.Policy, error) {
+	dbp, err := toDBPolicy(policy)
+	if err != nil {
+		return policies.Policy{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, tx, savePolicyQuery, dbp)
+	if err != nil {
+		return policies.Policy{}, postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return policies.Policy{}, postgres.HandleError(row.Err(), errors.ErrCreateEntity)
+	}
+	dbp = dbPolicy{}
+	if err := row.StructScan(&dbp); err != nil {
+		return policies.Policy{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return toPolicy(dbp)
+}
+
+func rollback(tx *sqlx.Tx, err error) error {
+	if err := tx.Rollback(); err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	return errors.Wrap(errors.ErrCreateEntity, err)
+}
+
+func (pr prepo) EvaluateMessagingAccess(ctx context.Context, ar policies.AccessRequest) (policies.CachedPolicy, error) {
+	// The thing may present either its primary secret or any of its active named secrets.
+	query := fmt.Sprintf(`SELECT p.subject, p.object, p.actions, s.expires_at
 	FROM policies p INNER JOIN clients c ON c.id = p.subject
-	WHERE c.secret = :subject AND p.object = :object AND '%s' = ANY(p.actions)`, ar.Action)
+	LEFT JOIN client_secrets s ON s.client_id = c.id AND s.secret = :subject AND c.secret <> :subject
+	WHERE (c.secret = :subject OR (s.secret IS NOT NULL AND (s.expires_at IS NULL OR s.expires_at > NOW())))
+	AND p.object = :object AND '%s' = ANY(p.actions)`, ar.Action)
 
-	return pr.evaluate(ctx, query, ar)
+	params := map[string]interface{}{
+		"subject": ar.Subject,
+		"object":  ar.Object,
+	}
+	row, err := pr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return policies.CachedPolicy{}, postgres.HandleError(err, errors.ErrAuthorization)
+	}
+	defer row.Close()
+
+	if ok := row.Next(); !ok {
+		return policies.CachedPolicy{}, errors.Wrap(errors.ErrAuthorization, row.Err())
+	}
+	var dbp dbMessagingPolicy
+	if err := row.StructScan(&dbp); err != nil {
+		return policies.CachedPolicy{}, err
+	}
+	var actions []string
+	for _, e := range dbp.Actions.Elements {
+		actions = append(actions, e.String)
+	}
+	cp := policies.CachedPolicy{
+		ThingID:   dbp.Subject,
+		ThingKey:  ar.Subject,
+		ChannelID: dbp.Object,
+		Actions:   actions,
+	}
+	if dbp.ExpiresAt.Valid {
+		cp.ExpiresAt = dbp.ExpiresAt.Time
+	}
+
+	return cp, nil
 }
 
 func (pr prepo) EvaluateThingAccess(ctx context.Context, ar policies.AccessRequest) (policies.Policy, error) {
@@ -84,6 +183,32 @@ func (pr prepo) EvaluateGroupAccess(ctx context.Context, ar policies.AccessReque
 	return pr.evaluate(ctx, query, ar)
 }
 
+func (pr prepo) RetrieveGroupAncestors(ctx context.Context, groupID string) ([]string, error) {
+	q := `WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, 0 AS level FROM groups WHERE id = $1
+			UNION
+			SELECT g.id, g.parent_id, a.level + 1 FROM groups g INNER JOIN ancestors a ON a.parent_id = g.id
+		)
+		SELECT id FROM ancestors ORDER BY level;`
+
+	rows, err := pr.db.QueryxContext(ctx, q, groupID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 func (pr prepo) evaluate(ctx context.Context, query string, aReq policies.AccessRequest) (policies.Policy, error) {
 	p := policies.Policy{
 		Subject: aReq.Subject,
@@ -228,6 +353,15 @@ type dbPolicy struct {
 	UpdatedBy *string          `db:"updated_by,omitempty"`
 }
 
+// dbMessagingPolicy is the policy of the thing together with the expiry of
+// the named secret the thing presented.
+type dbMessagingPolicy struct {
+	Subject   string           `db:"subject"`
+	Object    string           `db:"object"`
+	Actions   pgtype.TextArray `db:"actions"`
+	ExpiresAt sql.NullTime     `db:"expires_at"`
+}
+
 func toDBPolicy(p policies.Policy) (dbPolicy, error) {
 	var actions pgtype.TextArray
 	if err := actions.Set(p.Actions); err != nil {
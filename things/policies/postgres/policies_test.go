@@ -665,3 +665,36 @@ func TestPoliciesDelete(t *testing.T) {
 	assert.Equal(t, uint64(0), policyPage.Total, fmt.Sprintf("retrieve policies unexpected total %d\n", policyPage.Total))
 	require.Nil(t, err, fmt.Sprintf("retrieve policies unexpected error: %s", err))
 }
+
+func TestPoliciesSaveAll(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := ppostgres.NewRepository(database)
+	grepo := gpostgres.New(database)
+
+	group := mfgroups.Group{
+		ID:     testsutil.GenerateUUID(t, idProvider),
+		Name:   "policy-save-all@example.com",
+		Status: mfclients.EnabledStatus,
+	}
+	_, err := grepo.Save(context.Background(), group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	owner := testsutil.GenerateUUID(t, idProvider)
+	ps := []policies.Policy{
+		{OwnerID: owner, Subject: testsutil.GenerateUUID(t, idProvider), Object: group.ID, Actions: []string{"m_read"}},
+		{OwnerID: owner, Subject: testsutil.GenerateUUID(t, idProvider), Object: testsutil.GenerateUUID(t, idProvider), Actions: []string{"m_read"}},
+		{OwnerID: owner, Subject: testsutil.GenerateUUID(t, idProvider), Object: group.ID, Actions: []string{"m_write"}},
+	}
+	errs := []error{nil, errors.ErrCreateEntity, nil}
+
+	results, err := repo.SaveAll(context.Background(), ps...)
+	require.Nil(t, err, fmt.Sprintf("save policies: expected nil got %s\n", err))
+	require.Len(t, results, len(ps), fmt.Sprintf("save policies: expected %d results got %d\n", len(ps), len(results)))
+	for i, expected := range errs {
+		assert.True(t, errors.Contains(results[i].Err, expected), fmt.Sprintf("policy %d: expected %s got %s\n", i, expected, results[i].Err))
+	}
+
+	page, err := repo.Retrieve(context.Background(), policies.Page{Object: group.ID, Offset: 0, Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("retrieve policies: expected nil got %s\n", err))
+	assert.Equal(t, uint64(2), page.Total, fmt.Sprintf("retrieve policies: expected 2 policies got %d\n", page.Total))
+}
@@ -20,6 +20,8 @@ const (
 	// Add policy actions to check if the client is admin.
 	addPolicyAction = "g_add"
 
+	listPolicyAction = "g_list"
+
 	sharePolicyAction = "c_share"
 
 	// Entity types.
@@ -28,6 +30,10 @@ const (
 	ThingEntityType  = "thing"
 
 	thingsObjectKey = "things"
+
+	// policiesChunkSize is the number of policies of a bulk request saved
+	// in a single transaction.
+	policiesChunkSize = 100
 )
 
 // ErrInvalidEntityType indicates that the entity type is invalid.
@@ -82,7 +88,9 @@ func (svc service) Authorize(ctx context.Context, ar AccessRequest) (Policy, err
 	case GroupEntityType:
 		policy, err = svc.policies.EvaluateGroupAccess(ctx, ar)
 		if err != nil {
-			return Policy{}, err
+			if policy, err = svc.authorizeRole(ctx, ar); err != nil {
+				return Policy{}, err
+			}
 		}
 
 	case ClientEntityType:
@@ -92,19 +100,21 @@ func (svc service) Authorize(ctx context.Context, ar AccessRequest) (Policy, err
 		}
 
 	case ThingEntityType:
-		policy, err = svc.policies.EvaluateMessagingAccess(ctx, ar)
+		cpolicy, err = svc.policies.EvaluateMessagingAccess(ctx, ar)
 		if err != nil {
 			return Policy{}, err
 		}
-
-		cpolicy = CachedPolicy{
-			ThingID:   policy.Subject,
-			ThingKey:  ar.Subject,
-			ChannelID: ar.Object,
-			Actions:   policy.Actions,
+		policy = Policy{
+			Subject: cpolicy.ThingID,
+			Object:  cpolicy.ChannelID,
+			Actions: cpolicy.Actions,
 		}
-		if err := svc.policyCache.Put(ctx, cpolicy); err != nil {
-			return policy, err
+		// Access granted by expiring secrets is not cached, so it ends
+		// as soon as the secret expires.
+		if cpolicy.ExpiresAt.IsZero() {
+			if err := svc.policyCache.Put(ctx, cpolicy); err != nil {
+				return policy, err
+			}
 		}
 
 	default:
@@ -120,7 +130,7 @@ func (svc service) Authorize(ctx context.Context, ar AccessRequest) (Policy, err
 //
 //  2. The client has `g_add` action on the object or is the owner of the object.
 func (svc service) AddPolicy(ctx context.Context, token string, external bool, p Policy) (Policy, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, addPolicyAction, p.Object)
 	if err != nil {
 		return Policy{}, err
 	}
@@ -151,22 +161,123 @@ func (svc service) AddPolicy(ctx context.Context, token string, external bool, p
 		return svc.policies.Save(ctx, p)
 	}
 
-	// If the client has `g_add` action on the object or is the owner of the object, add the policy
-	areq := AccessRequest{Subject: userID, Object: p.Object, Action: "g_add"}
-	if pol, err := svc.policies.EvaluateGroupAccess(ctx, areq); err == nil {
-		if err := svc.checkSubject(ctx, userID, external, p); err != nil {
-			return Policy{}, err
+	if err := svc.checkAdd(ctx, userID, external, p); err != nil {
+		return Policy{}, err
+	}
+
+	return svc.policies.Save(ctx, p)
+}
+
+func (svc service) AddPolicies(ctx context.Context, token string, external bool, ps ...Policy) ([]PolicyResult, error) {
+	results := make([]PolicyResult, len(ps))
+
+	// The user is identified once per object, since API keys may be
+	// scoped to some of the objects only.
+	type identity struct {
+		userID string
+		err    error
+	}
+	identities := map[string]identity{}
+	var admin, adminChecked bool
+
+	var pending []Policy
+	var idx []int
+	for i, p := range ps {
+		id, ok := identities[p.Object]
+		if !ok {
+			id.userID, id.err = svc.identify(ctx, token, addPolicyAction, p.Object)
+			// Unlike a key scoped to other objects, an invalid token fails
+			// the whole request.
+			if errors.Contains(id.err, errors.ErrAuthentication) {
+				return []PolicyResult{}, id.err
+			}
+			identities[p.Object] = id
+		}
+		if id.err != nil {
+			results[i] = PolicyResult{Err: id.err}
+			continue
+		}
+		if !adminChecked {
+			admin = svc.checkAdmin(ctx, id.userID) == nil
+			adminChecked = true
+		}
+		if err := p.validate(); err != nil {
+			results[i] = PolicyResult{Err: err}
+			continue
 		}
+		if external {
+			p.Actions = upolicies.AddListAction(p.Actions)
+		}
+		if !admin {
+			if err := svc.checkAdd(ctx, id.userID, external, p); err != nil {
+				results[i] = PolicyResult{Err: err}
+				continue
+			}
+		}
+		p.OwnerID = id.userID
+		p.CreatedAt = time.Now()
+		p.UpdatedAt = p.CreatedAt
+		p.UpdatedBy = id.userID
 
-		// the client has `g_add` action on the object
-		if len(pol.Actions) > 0 {
-			if err := checkActions(pol.Actions, p.Actions); err != nil {
-				return Policy{}, err
+		if err := svc.policyCache.Remove(ctx, CachedPolicy{ThingKey: p.Subject, ChannelID: p.Object}); err != nil {
+			return []PolicyResult{}, err
+		}
+		pending = append(pending, p)
+		idx = append(idx, i)
+	}
+
+	for start := 0; start < len(pending); start += policiesChunkSize {
+		end := start + policiesChunkSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		chunk, err := svc.policies.SaveAll(ctx, pending[start:end]...)
+		for i := start; i < end; i++ {
+			if err != nil {
+				results[idx[i]] = PolicyResult{Err: err}
+				continue
 			}
+			results[idx[i]] = chunk[i-start]
 		}
+	}
 
-		// the client is the owner of the object
-		return svc.policies.Save(ctx, p)
+	return results, nil
+}
+
+// checkAdd checks if the non-admin user may add the policy, i.e. if the user
+// has `g_add` action on the object or is the owner of the object, and may
+// share the subject.
+func (svc service) checkAdd(ctx context.Context, userID string, external bool, p Policy) error {
+	areq := AccessRequest{Subject: userID, Object: p.Object, Action: addPolicyAction}
+	pol, err := svc.policies.EvaluateGroupAccess(ctx, areq)
+	if err != nil {
+		return errors.ErrAuthorization
+	}
+	if err := svc.checkSubject(ctx, userID, external, p); err != nil {
+		return err
+	}
+
+	// the client has `g_add` action on the object
+	if len(pol.Actions) > 0 {
+		return checkActions(pol.Actions, p.Actions)
+	}
+
+	// the client is the owner of the object
+	return nil
+}
+
+// authorizeRole checks the roles assigned to the user on the group or one of
+// its ancestors. Roles are kept by the users service, so each group in the
+// hierarchy is checked against it, starting with the group itself.
+func (svc service) authorizeRole(ctx context.Context, ar AccessRequest) (Policy, error) {
+	ids, err := svc.policies.RetrieveGroupAncestors(ctx, ar.Object)
+	if err != nil {
+		return Policy{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+	for _, id := range ids {
+		if err := svc.usersAuthorize(ctx, ar.Subject, id, ar.Action, GroupEntityType); err == nil {
+			return Policy{Subject: ar.Subject, Object: ar.Object, Actions: []string{ar.Action}}, nil
+		}
 	}
 
 	return Policy{}, errors.ErrAuthorization
@@ -195,7 +306,7 @@ func (svc service) checkSubject(ctx context.Context, userID string, external boo
 }
 
 func (svc service) UpdatePolicy(ctx context.Context, token string, p Policy) (Policy, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, addPolicyAction, p.Object)
 	if err != nil {
 		return Policy{}, err
 	}
@@ -221,7 +332,7 @@ func (svc service) UpdatePolicy(ctx context.Context, token string, p Policy) (Po
 }
 
 func (svc service) ListPolicies(ctx context.Context, token string, pm Page) (PolicyPage, error) {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, listPolicyAction, thingsObjectKey)
 	if err != nil {
 		return PolicyPage{}, err
 	}
@@ -240,7 +351,7 @@ func (svc service) ListPolicies(ctx context.Context, token string, pm Page) (Pol
 }
 
 func (svc service) DeletePolicy(ctx context.Context, token string, p Policy) error {
-	userID, err := svc.identify(ctx, token)
+	userID, err := svc.identify(ctx, token, addPolicyAction, p.Object)
 	if err != nil {
 		return err
 	}
@@ -280,13 +391,15 @@ func (svc service) checkPolicy(ctx context.Context, clientID string, p Policy) e
 	return errors.ErrAuthorization
 }
 
-func (svc service) identify(ctx context.Context, token string) (string, error) {
-	req := &upolicies.IdentifyReq{Token: token}
+// identify identifies the user. The action and object are checked against
+// the scope of the API key, if one is used instead of an access token.
+func (svc service) identify(ctx context.Context, token, action, object string) (string, error) {
+	req := &upolicies.IdentifyReq{Token: token, Action: action, Object: object}
 	res, err := svc.auth.Identify(ctx, req)
 	if err != nil {
 		return "", errors.Wrap(errors.ErrAuthorization, err)
 	}
-	return res.GetId(), nil
+	return res.GetOwner(), nil
 }
 
 func (svc service) checkAdmin(ctx context.Context, id string) error {
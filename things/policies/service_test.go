@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/internal/apiutil"
 	"github.com/mainflux/mainflux/internal/testsutil"
@@ -137,6 +138,79 @@ func TestAddPolicy(t *testing.T) {
 	}
 }
 
+func TestAddPolicies(t *testing.T) {
+	svc, pRepo, _ := newService(map[string]string{token: adminEmail})
+
+	policy := policies.Policy{Object: "obj1", Actions: []string{"m_read"}, Subject: "sub1"}
+	invalid := policies.Policy{Object: "obj1", Actions: []string{"invalid"}, Subject: "sub2"}
+
+	cases := []struct {
+		desc     string
+		policies []policies.Policy
+		token    string
+		authErr  error
+		saved    []policies.PolicyResult
+		saveErr  error
+		errs     []error
+		err      error
+	}{
+		{
+			desc:     "add new policies",
+			policies: []policies.Policy{policy, policy},
+			token:    token,
+			saved:    []policies.PolicyResult{{Policy: policy}, {Policy: policy}},
+			errs:     []error{nil, nil},
+		},
+		{
+			desc:     "add policies with invalid action",
+			policies: []policies.Policy{invalid, policy},
+			token:    token,
+			saved:    []policies.PolicyResult{{Policy: policy}},
+			errs:     []error{apiutil.ErrMalformedPolicyAct, nil},
+		},
+		{
+			desc:     "add policies without access to the object",
+			policies: []policies.Policy{policy},
+			token:    token,
+			authErr:  errors.ErrAuthorization,
+			saved:    []policies.PolicyResult{},
+			errs:     []error{errors.ErrAuthorization},
+		},
+		{
+			desc:     "add policies with invalid token",
+			policies: []policies.Policy{policy},
+			token:    inValidToken,
+			saved:    []policies.PolicyResult{},
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "add policies with failing transaction",
+			policies: []policies.Policy{policy},
+			token:    token,
+			saved:    []policies.PolicyResult{},
+			saveErr:  errors.ErrCreateEntity,
+			errs:     []error{errors.ErrCreateEntity},
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateGroupAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, tc.authErr)
+		repoCall1 := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall2 := pRepo.On("SaveAll", context.Background(), mock.Anything).Return(tc.saved, tc.saveErr)
+		results, err := svc.AddPolicies(context.Background(), tc.token, false, tc.policies...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			require.Len(t, results, len(tc.policies), fmt.Sprintf("%s: expected %d results got %d\n", tc.desc, len(tc.policies), len(results)))
+			for i, expected := range tc.errs {
+				assert.True(t, errors.Contains(results[i].Err, expected), fmt.Sprintf("%s: row %d: expected %s got %s\n", tc.desc, i, expected, results[i].Err))
+			}
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
 func TestAuthorize(t *testing.T) {
 	svc, pRepo, _ := newService(map[string]string{token: adminEmail})
 
@@ -182,6 +256,94 @@ func TestAuthorize(t *testing.T) {
 	}
 }
 
+func TestAuthorizeMessaging(t *testing.T) {
+	pRepo := new(pmocks.Repository)
+	auth := mocks.NewAuthService(map[string]string{token: adminEmail}, nil)
+	svc := policies.NewService(auth, pRepo, pmocks.NewCache(), uuid.NewMock())
+
+	thingID := testsutil.GenerateUUID(t, idProvider)
+	chanID := testsutil.GenerateUUID(t, idProvider)
+
+	cases := []struct {
+		desc      string
+		key       string
+		expiresAt time.Time
+		calls     int
+	}{
+		{
+			desc:  "authorize thing with primary secret",
+			key:   testsutil.GenerateUUID(t, idProvider),
+			calls: 1,
+		},
+		{
+			desc:      "authorize thing with expiring secret",
+			key:       testsutil.GenerateUUID(t, idProvider),
+			expiresAt: time.Now().Add(time.Hour),
+			calls:     2,
+		},
+	}
+
+	for _, tc := range cases {
+		ar := policies.AccessRequest{Subject: tc.key, Object: chanID, Action: "m_write", Entity: policies.ThingEntityType}
+		cp := policies.CachedPolicy{ThingID: thingID, ThingKey: tc.key, ChannelID: chanID, Actions: []string{"m_write"}, ExpiresAt: tc.expiresAt}
+		repoCall := pRepo.On("EvaluateMessagingAccess", context.Background(), ar).Return(cp, nil)
+		// The second request is served from cache unless the secret expires.
+		for i := 0; i < 2; i++ {
+			policy, err := svc.Authorize(context.Background(), ar)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s\n", tc.desc, err))
+			assert.Equal(t, thingID, policy.Subject, fmt.Sprintf("%s: expected thing %s got %s\n", tc.desc, thingID, policy.Subject))
+		}
+		pRepo.AssertNumberOfCalls(t, "EvaluateMessagingAccess", tc.calls)
+		repoCall.Unset()
+		pRepo.Calls = nil
+	}
+}
+
+func TestAuthorizeRole(t *testing.T) {
+	userID := testsutil.GenerateUUID(t, idProvider)
+	groupID := testsutil.GenerateUUID(t, idProvider)
+	parentID := testsutil.GenerateUUID(t, idProvider)
+	rolePolicy := mocks.MockSubjectSet{Object: parentID, Relation: []string{"g_update"}}
+	auth := mocks.NewAuthService(map[string]string{}, map[string][]mocks.MockSubjectSet{userID: {rolePolicy}})
+	pRepo := new(pmocks.Repository)
+	svc := policies.NewService(auth, pRepo, pmocks.NewCache(), uuid.NewMock())
+
+	cases := []struct {
+		desc      string
+		ar        policies.AccessRequest
+		ancestors []string
+		err       error
+	}{
+		{
+			desc:      "authorize with role on the parent group",
+			ar:        policies.AccessRequest{Subject: userID, Object: groupID, Action: "g_update", Entity: "group"},
+			ancestors: []string{groupID, parentID},
+			err:       nil,
+		},
+		{
+			desc:      "authorize with role missing the action",
+			ar:        policies.AccessRequest{Subject: userID, Object: groupID, Action: "g_delete", Entity: "group"},
+			ancestors: []string{groupID, parentID},
+			err:       errors.ErrAuthorization,
+		},
+		{
+			desc:      "authorize without role on the group hierarchy",
+			ar:        policies.AccessRequest{Subject: userID, Object: groupID, Action: "g_update", Entity: "group"},
+			ancestors: []string{groupID},
+			err:       errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateGroupAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, errors.ErrAuthorization)
+		repoCall1 := pRepo.On("RetrieveGroupAncestors", mock.Anything, tc.ar.Object).Return(tc.ancestors, nil)
+		_, err := svc.Authorize(context.Background(), tc.ar)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
 func TestDeletePolicy(t *testing.T) {
 	svc, pRepo, _ := newService(map[string]string{token: adminEmail})
 
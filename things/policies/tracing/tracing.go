@@ -49,6 +49,17 @@ func (tm *tracingMiddleware) AddPolicy(ctx context.Context, token string, extern
 	return tm.psvc.AddPolicy(ctx, token, external, p)
 }
 
+// AddPolicies traces the "AddPolicies" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) AddPolicies(ctx context.Context, token string, external bool, ps ...policies.Policy) ([]policies.PolicyResult, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_bulk_connect", trace.WithAttributes(
+		attribute.Bool("is_external", external),
+		attribute.Int("policies", len(ps)),
+	))
+	defer span.End()
+
+	return tm.psvc.AddPolicies(ctx, token, external, ps...)
+}
+
 // UpdatePolicy traces the "UpdatePolicy" operation of the wrapped policies.Service.
 func (tm *tracingMiddleware) UpdatePolicy(ctx context.Context, token string, p policies.Policy) (policies.Policy, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_update_policy", trace.WithAttributes(
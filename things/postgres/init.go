@@ -61,6 +61,56 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP TABLE IF EXISTS policies`,
 				},
 			},
+			{
+				Id: "clients_02",
+				// Named secrets let a thing hold more than one active key
+				// while the primary one is being rotated out.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS client_secrets (
+						client_id	VARCHAR(36) NOT NULL,
+						name		VARCHAR(254) NOT NULL,
+						secret		VARCHAR(4096) NOT NULL UNIQUE,
+						expires_at	TIMESTAMP,
+						created_at	TIMESTAMP,
+						FOREIGN KEY	(client_id) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY	(client_id, name)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS client_secrets`,
+				},
+			},
+			{
+				Id: "clients_03",
+				// Metadata filters of things and channels are served by
+				// containment queries, which GIN indexes support.
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS clients_metadata_idx ON clients USING GIN (metadata)`,
+					`CREATE INDEX IF NOT EXISTS groups_metadata_idx ON groups USING GIN (metadata)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS clients_metadata_idx`,
+					`DROP INDEX IF EXISTS groups_metadata_idx`,
+				},
+			},
+			{
+				Id: "clients_04",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS client_presence (
+						client_id	VARCHAR(36) PRIMARY KEY,
+						online		BOOLEAN NOT NULL DEFAULT false,
+						last_seen	TIMESTAMP NOT NULL,
+						protocol	VARCHAR(32) NOT NULL DEFAULT '',
+						remote_addr	VARCHAR(254) NOT NULL DEFAULT '',
+						FOREIGN KEY	(client_id) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+					// Expiry only scans the things which are online.
+					`CREATE INDEX IF NOT EXISTS client_presence_last_seen_idx ON client_presence (last_seen) WHERE online`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS client_presence`,
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/things/presence"
+)
+
+var _ presence.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger mflog.Logger
+	svc    presence.Service
+}
+
+// LoggingMiddleware returns a new logging middleware wrapper.
+func LoggingMiddleware(svc presence.Service, logger mflog.Logger) presence.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) Seen(ctx context.Context, p presence.Presence) (changed bool, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method seen for thing with id %s over %s took %s to complete", p.ThingID, p.Protocol, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		// Things are seen on every message, so only the changes are
+		// worth logging above debug level.
+		if changed {
+			lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+			return
+		}
+		lm.logger.Debug(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Seen(ctx, p)
+}
+
+func (lm *loggingMiddleware) Disconnect(ctx context.Context, thingID string, at time.Time) (changed bool, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method disconnect for thing with id %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Disconnect(ctx, thingID, at)
+}
+
+func (lm *loggingMiddleware) Expire(ctx context.Context, before time.Time) (ps []presence.Presence, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method expire of %d things not seen since %s took %s to complete", len(ps), before.Format(time.RFC3339), time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		if len(ps) > 0 {
+			lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+			return
+		}
+		lm.logger.Debug(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Expire(ctx, before)
+}
+
+func (lm *loggingMiddleware) ViewPresence(ctx context.Context, token, thingID string) (p presence.Presence, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_presence for thing with id %s using token %s took %s to complete", thingID, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ViewPresence(ctx, token, thingID)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/things/presence"
+)
+
+var _ presence.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     presence.Service
+}
+
+// MetricsMiddleware returns a new metrics middleware wrapper.
+func MetricsMiddleware(svc presence.Service, counter metrics.Counter, latency metrics.Histogram) presence.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) Seen(ctx context.Context, p presence.Presence) (bool, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "seen").Add(1)
+		ms.latency.With("method", "seen").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Seen(ctx, p)
+}
+
+func (ms *metricsMiddleware) Disconnect(ctx context.Context, thingID string, at time.Time) (bool, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disconnect").Add(1)
+		ms.latency.With("method", "disconnect").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Disconnect(ctx, thingID, at)
+}
+
+func (ms *metricsMiddleware) Expire(ctx context.Context, before time.Time) ([]presence.Presence, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "expire").Add(1)
+		ms.latency.With("method", "expire").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Expire(ctx, before)
+}
+
+func (ms *metricsMiddleware) ViewPresence(ctx context.Context, token, thingID string) (presence.Presence, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_presence").Add(1)
+		ms.latency.With("method", "view_presence").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ViewPresence(ctx, token, thingID)
+}
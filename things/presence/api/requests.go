@@ -0,0 +1,19 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import "github.com/mainflux/mainflux/internal/apiutil"
+
+type viewPresenceReq struct {
+	token string
+	id    string
+}
+
+func (req viewPresenceReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/things/presence"
+)
+
+var _ mainflux.Response = (*viewPresenceRes)(nil)
+
+type viewPresenceRes struct {
+	presence.Presence
+}
+
+func (res viewPresenceRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewPresenceRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewPresenceRes) Empty() bool {
+	return false
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/internal/api"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/things/presence"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc presence.Service, mux *bone.Mux, logger mflog.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux.Get("/things/:thingID/presence", otelhttp.NewHandler(kithttp.NewServer(
+		viewPresenceEndpoint(svc),
+		decodeViewPresence,
+		api.EncodeResponse,
+		opts...,
+	), "view_thing_presence"))
+
+	return mux
+}
+
+func decodeViewPresence(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewPresenceReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "thingID"),
+	}
+
+	return req, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things/presence"
+)
+
+const keyPrefix = "thing_presence"
+
+var _ presence.Cache = (*presenceCache)(nil)
+
+type presenceCache struct {
+	client      *redis.Client
+	keyDuration time.Duration
+}
+
+// NewCache returns redis presence cache implementation.
+func NewCache(client *redis.Client, duration time.Duration) presence.Cache {
+	return &presenceCache{
+		client:      client,
+		keyDuration: duration,
+	}
+}
+
+func (pc *presenceCache) Save(ctx context.Context, p presence.Presence) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	key := fmt.Sprintf("%s:%s", keyPrefix, p.ThingID)
+	if err := pc.client.Set(ctx, key, data, pc.keyDuration).Err(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pc *presenceCache) Retrieve(ctx context.Context, thingID string) (presence.Presence, error) {
+	key := fmt.Sprintf("%s:%s", keyPrefix, thingID)
+	data, err := pc.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return presence.Presence{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	var p presence.Presence
+	if err := json.Unmarshal(data, &p); err != nil {
+		return presence.Presence{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return p, nil
+}
+
+func (pc *presenceCache) Remove(ctx context.Context, thingID string) error {
+	key := fmt.Sprintf("%s:%s", keyPrefix, thingID)
+	if err := pc.client.Del(ctx, key).Err(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
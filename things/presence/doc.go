@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package presence contains the domain concept definitions needed to
+// support Mainflux things presence sub-service functionality.
+package presence
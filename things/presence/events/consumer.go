@@ -0,0 +1,78 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/events"
+	"github.com/mainflux/mainflux/things/presence"
+)
+
+// MQTTStream is the stream of the MQTT adapter connect and disconnect events.
+const MQTTStream = "mainflux.mqtt"
+
+const (
+	mqttConnect    = "connect"
+	mqttDisconnect = "disconnect"
+)
+
+type eventHandler struct {
+	svc presence.Service
+}
+
+// NewEventHandler returns new event store handler which tracks the presence
+// of the things connected to the MQTT adapter.
+func NewEventHandler(svc presence.Service) events.EventHandler {
+	return &eventHandler{
+		svc: svc,
+	}
+}
+
+func (eh *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	thingID := read(msg, "thing_id", "")
+	if thingID == "" {
+		return nil
+	}
+	at := readTime(msg, "occurred_at", time.Now())
+
+	switch read(msg, "event_type", "") {
+	case mqttConnect:
+		p := presence.Presence{
+			ThingID:  thingID,
+			LastSeen: at,
+			Protocol: presence.MQTTProtocol,
+		}
+		_, err = eh.svc.Seen(ctx, p)
+	case mqttDisconnect:
+		_, err = eh.svc.Disconnect(ctx, thingID, at)
+	}
+
+	return err
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
+
+// readTime reads the timestamp, which the event store holds as a string.
+func readTime(event map[string]interface{}, key string, def time.Time) time.Time {
+	val, err := time.Parse(time.RFC3339Nano, read(event, key, ""))
+	if err != nil {
+		return def
+	}
+
+	return val
+}
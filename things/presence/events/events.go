@@ -0,0 +1,42 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/mainflux/mainflux/pkg/events"
+	"github.com/mainflux/mainflux/things/presence"
+)
+
+const (
+	presenceChange = "thing.presence"
+
+	onlineStatus  = "online"
+	offlineStatus = "offline"
+)
+
+var _ events.Event = (*presenceEvent)(nil)
+
+type presenceEvent struct {
+	presence.Presence
+}
+
+func (pe presenceEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": presenceChange,
+		"thing_id":  pe.ThingID,
+		"status":    offlineStatus,
+		"last_seen": pe.LastSeen,
+	}
+	if pe.Online {
+		val["status"] = onlineStatus
+	}
+	if pe.Protocol != "" {
+		val["protocol"] = pe.Protocol
+	}
+	if pe.RemoteAddr != "" {
+		val["remote_addr"] = pe.RemoteAddr
+	}
+
+	return val, nil
+}
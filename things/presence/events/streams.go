@@ -0,0 +1,89 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/events"
+	"github.com/mainflux/mainflux/pkg/events/redis"
+	"github.com/mainflux/mainflux/things/presence"
+)
+
+const streamID = "mainflux.presence"
+
+var _ presence.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	events.Publisher
+	svc presence.Service
+}
+
+// NewEventStoreMiddleware returns wrapper around presence service that sends
+// the presence changes to event store.
+func NewEventStoreMiddleware(ctx context.Context, svc presence.Service, url string) (presence.Service, error) {
+	publisher, err := redis.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventStore{
+		svc:       svc,
+		Publisher: publisher,
+	}, nil
+}
+
+func (es *eventStore) Seen(ctx context.Context, p presence.Presence) (bool, error) {
+	changed, err := es.svc.Seen(ctx, p)
+	if err != nil || !changed {
+		return changed, err
+	}
+
+	p.Online = true
+	if p.LastSeen.IsZero() {
+		p.LastSeen = time.Now()
+	}
+	if err := es.Publish(ctx, presenceEvent{p}); err != nil {
+		return changed, err
+	}
+
+	return changed, nil
+}
+
+func (es *eventStore) Disconnect(ctx context.Context, thingID string, at time.Time) (bool, error) {
+	changed, err := es.svc.Disconnect(ctx, thingID, at)
+	if err != nil || !changed {
+		return changed, err
+	}
+
+	event := presenceEvent{presence.Presence{
+		ThingID:  thingID,
+		LastSeen: at,
+	}}
+	if err := es.Publish(ctx, event); err != nil {
+		return changed, err
+	}
+
+	return changed, nil
+}
+
+func (es *eventStore) Expire(ctx context.Context, before time.Time) ([]presence.Presence, error) {
+	ps, err := es.svc.Expire(ctx, before)
+	if err != nil {
+		return ps, err
+	}
+
+	for _, p := range ps {
+		if err := es.Publish(ctx, presenceEvent{p}); err != nil {
+			return ps, err
+		}
+	}
+
+	return ps, nil
+}
+
+func (es *eventStore) ViewPresence(ctx context.Context, token, thingID string) (presence.Presence, error) {
+	return es.svc.ViewPresence(ctx, token, thingID)
+}
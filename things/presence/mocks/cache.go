@@ -0,0 +1,52 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things/presence"
+)
+
+type presenceCacheMock struct {
+	mu       sync.Mutex
+	presence map[string]presence.Presence
+}
+
+// NewCache returns mock cache instance.
+func NewCache() presence.Cache {
+	return &presenceCacheMock{
+		presence: make(map[string]presence.Presence),
+	}
+}
+
+func (pcm *presenceCacheMock) Save(_ context.Context, p presence.Presence) error {
+	pcm.mu.Lock()
+	defer pcm.mu.Unlock()
+
+	pcm.presence[p.ThingID] = p
+	return nil
+}
+
+func (pcm *presenceCacheMock) Retrieve(_ context.Context, thingID string) (presence.Presence, error) {
+	pcm.mu.Lock()
+	defer pcm.mu.Unlock()
+
+	p, ok := pcm.presence[thingID]
+	if !ok {
+		return presence.Presence{}, errors.ErrNotFound
+	}
+
+	return p, nil
+}
+
+func (pcm *presenceCacheMock) Remove(_ context.Context, thingID string) error {
+	pcm.mu.Lock()
+	defer pcm.mu.Unlock()
+
+	delete(pcm.presence, thingID)
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/things/presence"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ presence.Repository = (*Repository)(nil)
+
+type Repository struct {
+	mock.Mock
+}
+
+func (m *Repository) Save(ctx context.Context, p presence.Presence) error {
+	ret := m.Called(ctx, p)
+
+	return ret.Error(0)
+}
+
+func (m *Repository) Retrieve(ctx context.Context, thingID string) (presence.Presence, error) {
+	ret := m.Called(ctx, thingID)
+
+	return ret.Get(0).(presence.Presence), ret.Error(1)
+}
+
+func (m *Repository) Expire(ctx context.Context, before time.Time, persistent string) ([]presence.Presence, error) {
+	ret := m.Called(ctx, before, persistent)
+
+	return ret.Get(0).([]presence.Presence), ret.Error(1)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things/presence"
+)
+
+var _ presence.Repository = (*presenceRepo)(nil)
+
+type presenceRepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of presence
+// repository.
+func NewRepository(db postgres.Database) presence.Repository {
+	return &presenceRepo{
+		db: db,
+	}
+}
+
+func (pr presenceRepo) Save(ctx context.Context, p presence.Presence) error {
+	q := `INSERT INTO client_presence (client_id, online, last_seen, protocol, remote_addr)
+		VALUES (:client_id, :online, :last_seen, :protocol, :remote_addr)
+		ON CONFLICT (client_id) DO UPDATE SET online = excluded.online, last_seen = excluded.last_seen,
+			protocol = excluded.protocol, remote_addr = excluded.remote_addr`
+
+	if _, err := pr.db.NamedExecContext(ctx, q, toDBPresence(p)); err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	return nil
+}
+
+func (pr presenceRepo) Retrieve(ctx context.Context, thingID string) (presence.Presence, error) {
+	q := `SELECT client_id, online, last_seen, protocol, remote_addr FROM client_presence WHERE client_id = $1`
+
+	var dbp dbPresence
+	if err := pr.db.QueryRowxContext(ctx, q, thingID).StructScan(&dbp); err != nil {
+		if err == sql.ErrNoRows {
+			return presence.Presence{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return presence.Presence{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toPresence(dbp), nil
+}
+
+func (pr presenceRepo) Expire(ctx context.Context, before time.Time, persistent string) ([]presence.Presence, error) {
+	q := `UPDATE client_presence SET online = false
+		WHERE online AND last_seen < $1 AND protocol <> $2
+		RETURNING client_id, online, last_seen, protocol, remote_addr`
+
+	rows, err := pr.db.QueryxContext(ctx, q, before.UTC(), persistent)
+	if err != nil {
+		return nil, postgres.HandleError(err, errors.ErrUpdateEntity)
+	}
+	defer rows.Close()
+
+	var ps []presence.Presence
+	for rows.Next() {
+		var dbp dbPresence
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+		ps = append(ps, toPresence(dbp))
+	}
+
+	return ps, nil
+}
+
+type dbPresence struct {
+	ClientID   string    `db:"client_id"`
+	Online     bool      `db:"online"`
+	LastSeen   time.Time `db:"last_seen"`
+	Protocol   string    `db:"protocol"`
+	RemoteAddr string    `db:"remote_addr"`
+}
+
+func toDBPresence(p presence.Presence) dbPresence {
+	return dbPresence{
+		ClientID:   p.ThingID,
+		Online:     p.Online,
+		LastSeen:   p.LastSeen.UTC(),
+		Protocol:   p.Protocol,
+		RemoteAddr: p.RemoteAddr,
+	}
+}
+
+func toPresence(dbp dbPresence) presence.Presence {
+	return presence.Presence{
+		ThingID:    dbp.ClientID,
+		Online:     dbp.Online,
+		LastSeen:   dbp.LastSeen,
+		Protocol:   dbp.Protocol,
+		RemoteAddr: dbp.RemoteAddr,
+	}
+}
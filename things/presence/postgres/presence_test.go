@@ -0,0 +1,146 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	cpostgres "github.com/mainflux/mainflux/things/clients/postgres"
+	"github.com/mainflux/mainflux/things/presence"
+	prpostgres "github.com/mainflux/mainflux/things/presence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idProvider = uuid.New()
+
+func createThings(t *testing.T, n int) []string {
+	repo := cpostgres.NewRepository(database)
+
+	var ids []string
+	for i := 0; i < n; i++ {
+		th := mfclients.Client{
+			ID:   testsutil.GenerateUUID(t, idProvider),
+			Name: fmt.Sprintf("thing-%d", i),
+			Credentials: mfclients.Credentials{
+				Identity: fmt.Sprintf("thing-%d@example.com", i),
+				Secret:   testsutil.GenerateUUID(t, idProvider),
+			},
+			Metadata: mfclients.Metadata{},
+			Status:   mfclients.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), th)
+		require.Nil(t, err, fmt.Sprintf("unexpected error creating thing: %s", err))
+		ids = append(ids, th.ID)
+	}
+
+	return ids
+}
+
+func TestPresenceSave(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := prpostgres.NewRepository(database)
+
+	ids := createThings(t, 1)
+	seen := time.Now().UTC().Truncate(time.Microsecond)
+
+	cases := []struct {
+		desc     string
+		presence presence.Presence
+		err      error
+	}{
+		{
+			desc:     "save presence of thing",
+			presence: presence.Presence{ThingID: ids[0], Online: true, LastSeen: seen, Protocol: "http", RemoteAddr: "10.0.0.1:5000"},
+		},
+		{
+			desc:     "save replaced presence of thing",
+			presence: presence.Presence{ThingID: ids[0], LastSeen: seen.Add(time.Minute), Protocol: presence.MQTTProtocol},
+		},
+		{
+			desc:     "save presence of non-existing thing",
+			presence: presence.Presence{ThingID: testsutil.GenerateUUID(t, idProvider), Online: true, LastSeen: seen, Protocol: "http"},
+			err:      errors.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.presence)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			p, err := repo.Retrieve(context.Background(), tc.presence.ThingID)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+			assert.Equal(t, tc.presence, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.presence, p))
+		}
+	}
+}
+
+func TestPresenceRetrieve(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := prpostgres.NewRepository(database)
+
+	ids := createThings(t, 2)
+	saved := presence.Presence{ThingID: ids[0], Online: true, LastSeen: time.Now().UTC().Truncate(time.Microsecond), Protocol: "coap", RemoteAddr: "10.0.0.1:5683"}
+	err := repo.Save(context.Background(), saved)
+	require.Nil(t, err, fmt.Sprintf("unexpected error saving presence: %s", err))
+
+	cases := []struct {
+		desc     string
+		thingID  string
+		response presence.Presence
+		err      error
+	}{
+		{
+			desc:     "retrieve presence of seen thing",
+			thingID:  ids[0],
+			response: saved,
+		},
+		{
+			desc:    "retrieve presence of never seen thing",
+			thingID: ids[1],
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		p, err := repo.Retrieve(context.Background(), tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, p))
+	}
+}
+
+func TestPresenceExpire(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := prpostgres.NewRepository(database)
+
+	ids := createThings(t, 4)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	stale := presence.Presence{ThingID: ids[0], Online: true, LastSeen: now.Add(-time.Hour), Protocol: "http"}
+	ps := []presence.Presence{
+		stale,
+		{ThingID: ids[1], Online: true, LastSeen: now, Protocol: "http"},
+		{ThingID: ids[2], Online: true, LastSeen: now.Add(-time.Hour), Protocol: presence.MQTTProtocol},
+		{ThingID: ids[3], LastSeen: now.Add(-time.Hour), Protocol: "ws"},
+	}
+	for _, p := range ps {
+		err := repo.Save(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("unexpected error saving presence: %s", err))
+	}
+
+	expired, err := repo.Expire(context.Background(), now.Add(-time.Minute), presence.MQTTProtocol)
+	assert.Nil(t, err, fmt.Sprintf("expire presence: expected no error got %s\n", err))
+	stale.Online = false
+	assert.Equal(t, []presence.Presence{stale}, expired, fmt.Sprintf("expire presence: expected %v got %v\n", []presence.Presence{stale}, expired))
+
+	p, err := repo.Retrieve(context.Background(), ids[0])
+	assert.Nil(t, err, fmt.Sprintf("retrieve expired presence: expected no error got %s\n", err))
+	assert.False(t, p.Online, "retrieve expired presence: expected thing to be offline")
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package presence
+
+import (
+	"context"
+	"time"
+)
+
+// MQTTProtocol is the protocol of the things whose presence is tracked by
+// the MQTT adapter connect and disconnect events, instead of by expiry.
+const MQTTProtocol = "mqtt"
+
+// Presence represents the connectivity status of a thing.
+type Presence struct {
+	ThingID    string    `json:"thing_id"`
+	Online     bool      `json:"online"`
+	LastSeen   time.Time `json:"last_seen,omitempty"`
+	Protocol   string    `json:"protocol,omitempty"`
+	RemoteAddr string    `json:"remote_addr,omitempty"`
+}
+
+// Source represents the adapter connection a thing request came from.
+type Source struct {
+	Protocol   string
+	RemoteAddr string
+}
+
+type sourceKey struct{}
+
+// NewContext returns a new context carrying the source of the request.
+func NewContext(ctx context.Context, s Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, s)
+}
+
+// FromContext returns the source of the request stored in the context, if any.
+func FromContext(ctx context.Context) (Source, bool) {
+	s, ok := ctx.Value(sourceKey{}).(Source)
+	return s, ok && s.Protocol != ""
+}
+
+// Repository specifies a presence persistence API.
+type Repository interface {
+	// Save creates or replaces the presence of the thing.
+	Save(ctx context.Context, p Presence) error
+
+	// Retrieve retrieves the presence of the thing.
+	Retrieve(ctx context.Context, thingID string) (Presence, error)
+
+	// Expire marks the online things last seen before the given time
+	// offline, except for the ones using the persistent protocol, and
+	// returns their presence.
+	Expire(ctx context.Context, before time.Time, persistent string) ([]Presence, error)
+}
+
+// Cache contains the recently persisted presence of things.
+type Cache interface {
+	// Save stores the presence of the thing.
+	Save(ctx context.Context, p Presence) error
+
+	// Retrieve retrieves the stored presence of the thing.
+	Retrieve(ctx context.Context, thingID string) (Presence, error)
+
+	// Remove removes the stored presence of the thing.
+	Remove(ctx context.Context, thingID string) error
+}
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// Seen marks the thing online as of the given presence last seen time.
+	// It reports whether the thing came online or switched its source.
+	Seen(ctx context.Context, p Presence) (bool, error)
+
+	// Disconnect marks the thing offline as of the given time. It reports
+	// whether the thing was online.
+	Disconnect(ctx context.Context, thingID string, at time.Time) (bool, error)
+
+	// Expire marks offline the things which haven't been seen since the
+	// given time, and returns their presence.
+	Expire(ctx context.Context, before time.Time) ([]Presence, error)
+
+	// ViewPresence retrieves the presence of the thing for an authorized token.
+	ViewPresence(ctx context.Context, token, thingID string) (Presence, error)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package presence
+
+import "context"
+
+// maxQueuedPresence is the number of presences waiting to be recorded,
+// beyond which new ones are dropped.
+const maxQueuedPresence = 1024
+
+// Recorder records the presence of things in the background, so that the
+// requests of things don't wait for their presence to be persisted.
+type Recorder interface {
+	// Record queues the presence to be marked seen. Presence tracking is
+	// best effort, so the presence is dropped if the queue is full.
+	Record(p Presence)
+}
+
+type recorder struct {
+	svc    Service
+	queued chan Presence
+}
+
+// NewRecorder returns a recorder which marks the queued presences seen by the
+// service, one at a time, until the context is canceled. Failures to mark
+// a thing seen are logged by the service.
+func NewRecorder(ctx context.Context, svc Service) Recorder {
+	r := &recorder{
+		svc:    svc,
+		queued: make(chan Presence, maxQueuedPresence),
+	}
+	go r.run(ctx)
+
+	return r
+}
+
+func (r *recorder) Record(p Presence) {
+	select {
+	case r.queued <- p:
+	default:
+	}
+}
+
+func (r *recorder) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p := <-r.queued:
+			_, _ = r.svc.Seen(ctx, p)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package presence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things/presence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecord(t *testing.T) {
+	svc, prRepo, _, _ := newService(map[string]string{token: adminEmail})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rec := presence.NewRecorder(ctx, svc)
+
+	p := presence.Presence{ThingID: thingID, LastSeen: time.Now(), Protocol: "http", RemoteAddr: "10.0.0.1:5000"}
+	saved := make(chan presence.Presence, 1)
+	repoCall := prRepo.On("Retrieve", mock.Anything, thingID).Return(presence.Presence{}, errors.ErrNotFound)
+	repoCall1 := prRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved <- args.Get(1).(presence.Presence)
+	}).Return(nil)
+	defer repoCall.Unset()
+	defer repoCall1.Unset()
+
+	rec.Record(p)
+	select {
+	case s := <-saved:
+		p.Online = true
+		assert.Equal(t, p, s, "recorded presence: got unexpected presence")
+	case <-time.After(time.Second):
+		assert.Fail(t, "recorded presence: expected presence to be saved")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package presence
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things/clients"
+)
+
+type service struct {
+	things       clients.Service
+	repo         Repository
+	cache        Cache
+	syncInterval time.Duration
+}
+
+// NewService returns a new presence service implementation. The presence of
+// a thing which stays online on the same source is persisted at most once
+// per sync interval.
+func NewService(things clients.Service, repo Repository, cache Cache, syncInterval time.Duration) Service {
+	return service{
+		things:       things,
+		repo:         repo,
+		cache:        cache,
+		syncInterval: syncInterval,
+	}
+}
+
+func (svc service) Seen(ctx context.Context, p Presence) (bool, error) {
+	if p.LastSeen.IsZero() {
+		p.LastSeen = time.Now()
+	}
+	p.Online = true
+
+	prev, err := svc.cache.Retrieve(ctx, p.ThingID)
+	if err != nil {
+		if prev, err = svc.repo.Retrieve(ctx, p.ThingID); err != nil && !errors.Contains(err, errors.ErrNotFound) {
+			return false, err
+		}
+	}
+	changed := !prev.Online || prev.Protocol != p.Protocol || prev.RemoteAddr != p.RemoteAddr
+	if !changed && p.LastSeen.Sub(prev.LastSeen) < svc.syncInterval {
+		return false, nil
+	}
+
+	if err := svc.repo.Save(ctx, p); err != nil {
+		return false, err
+	}
+	if err := svc.cache.Save(ctx, p); err != nil {
+		return false, err
+	}
+
+	return changed, nil
+}
+
+func (svc service) Disconnect(ctx context.Context, thingID string, at time.Time) (bool, error) {
+	p, err := svc.repo.Retrieve(ctx, thingID)
+	if err != nil {
+		if errors.Contains(err, errors.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !p.Online {
+		return false, nil
+	}
+
+	p.Online = false
+	p.LastSeen = at
+	if err := svc.repo.Save(ctx, p); err != nil {
+		return false, err
+	}
+	if err := svc.cache.Remove(ctx, thingID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (svc service) Expire(ctx context.Context, before time.Time) ([]Presence, error) {
+	ps, err := svc.repo.Expire(ctx, before, MQTTProtocol)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range ps {
+		if err := svc.cache.Remove(ctx, p.ThingID); err != nil {
+			return ps, err
+		}
+	}
+
+	return ps, nil
+}
+
+func (svc service) ViewPresence(ctx context.Context, token, thingID string) (Presence, error) {
+	if _, err := svc.things.ViewClient(ctx, token, thingID); err != nil {
+		return Presence{}, err
+	}
+
+	p, err := svc.repo.Retrieve(ctx, thingID)
+	if err != nil {
+		// The thing has never been seen.
+		if errors.Contains(err, errors.ErrNotFound) {
+			return Presence{ThingID: thingID}, nil
+		}
+		return Presence{}, err
+	}
+
+	return p, nil
+}
@@ -0,0 +1,239 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package presence_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/things/clients"
+	cmocks "github.com/mainflux/mainflux/things/clients/mocks"
+	gmocks "github.com/mainflux/mainflux/things/groups/mocks"
+	"github.com/mainflux/mainflux/things/policies"
+	pmocks "github.com/mainflux/mainflux/things/policies/mocks"
+	"github.com/mainflux/mainflux/things/presence"
+	"github.com/mainflux/mainflux/things/presence/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const syncInterval = time.Minute
+
+var (
+	idProvider        = uuid.New()
+	thingID           = testsutil.GenerateUUID(&testing.T{}, idProvider)
+	adminEmail        = "admin@example.com"
+	token             = "token"
+	inValidToken      = "invalidToken"
+	adminRelationKeys = []string{"c_update", "c_list", "c_delete", "c_share"}
+)
+
+func newService(tokens map[string]string) (presence.Service, *mocks.Repository, *cmocks.Repository, *pmocks.Repository) {
+	adminPolicy := cmocks.MockSubjectSet{Object: thingID, Relation: adminRelationKeys}
+	auth := cmocks.NewAuthService(tokens, map[string][]cmocks.MockSubjectSet{adminEmail: {adminPolicy}})
+	policiesCache := pmocks.NewCache()
+	idProvider := uuid.NewMock()
+	cRepo := new(cmocks.Repository)
+	gRepo := new(gmocks.Repository)
+	pRepo := new(pmocks.Repository)
+	prRepo := new(mocks.Repository)
+
+	psvc := policies.NewService(auth, pRepo, policiesCache, idProvider)
+	csvc := clients.NewService(auth, psvc, cRepo, gRepo, cmocks.NewCache(), policiesCache, idProvider)
+
+	return presence.NewService(csvc, prRepo, mocks.NewCache(), syncInterval), prRepo, cRepo, pRepo
+}
+
+func TestSeen(t *testing.T) {
+	svc, prRepo, _, _ := newService(map[string]string{token: adminEmail})
+
+	now := time.Now()
+	first := presence.Presence{ThingID: thingID, LastSeen: now, Protocol: "http", RemoteAddr: "10.0.0.1:5000"}
+
+	cases := []struct {
+		desc     string
+		presence presence.Presence
+		prev     presence.Presence
+		prevErr  error
+		saved    bool
+		changed  bool
+		saveErr  error
+		err      error
+	}{
+		{
+			desc:     "see thing for the first time",
+			presence: first,
+			prevErr:  errors.ErrNotFound,
+			saved:    true,
+			changed:  true,
+		},
+		{
+			desc:     "see thing again within sync interval",
+			presence: presence.Presence{ThingID: thingID, LastSeen: now.Add(time.Second), Protocol: "http", RemoteAddr: "10.0.0.1:5000"},
+		},
+		{
+			desc:     "see thing again after sync interval",
+			presence: presence.Presence{ThingID: thingID, LastSeen: now.Add(syncInterval), Protocol: "http", RemoteAddr: "10.0.0.1:5000"},
+			saved:    true,
+		},
+		{
+			desc:     "see thing over another protocol",
+			presence: presence.Presence{ThingID: thingID, LastSeen: now.Add(syncInterval + time.Second), Protocol: "coap", RemoteAddr: "10.0.0.1:5683"},
+			saved:    true,
+			changed:  true,
+		},
+		{
+			desc:     "see thing with failed save",
+			presence: presence.Presence{ThingID: thingID, LastSeen: now.Add(2 * syncInterval), Protocol: "ws", RemoteAddr: "10.0.0.1:8186"},
+			saved:    true,
+			saveErr:  errors.ErrCreateEntity,
+			err:      errors.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := prRepo.On("Retrieve", context.Background(), tc.presence.ThingID).Return(tc.prev, tc.prevErr)
+		repoCall1 := prRepo.On("Save", context.Background(), mock.Anything).Return(tc.saveErr)
+		changed, err := svc.Seen(context.Background(), tc.presence)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.changed, changed, fmt.Sprintf("%s: expected changed %t got %t\n", tc.desc, tc.changed, changed))
+		if tc.saved {
+			p := tc.presence
+			p.Online = true
+			ok := repoCall1.Parent.AssertCalled(t, "Save", context.Background(), p)
+			assert.True(t, ok, fmt.Sprintf("%s: expected presence to be saved", tc.desc))
+		}
+		if !tc.saved {
+			ok := repoCall1.Parent.AssertNotCalled(t, "Save", context.Background(), mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("%s: expected presence not to be saved", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		prRepo.Calls = nil
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	svc, prRepo, _, _ := newService(map[string]string{token: adminEmail})
+
+	at := time.Now()
+
+	cases := []struct {
+		desc    string
+		prev    presence.Presence
+		prevErr error
+		changed bool
+		err     error
+	}{
+		{
+			desc:    "disconnect online thing",
+			prev:    presence.Presence{ThingID: thingID, Online: true, LastSeen: at.Add(-time.Minute), Protocol: presence.MQTTProtocol},
+			changed: true,
+		},
+		{
+			desc: "disconnect offline thing",
+			prev: presence.Presence{ThingID: thingID, LastSeen: at.Add(-time.Minute), Protocol: presence.MQTTProtocol},
+		},
+		{
+			desc:    "disconnect never seen thing",
+			prevErr: errors.ErrNotFound,
+		},
+		{
+			desc:    "disconnect thing with failed retrieval",
+			prevErr: errors.ErrViewEntity,
+			err:     errors.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := prRepo.On("Retrieve", context.Background(), thingID).Return(tc.prev, tc.prevErr)
+		repoCall1 := prRepo.On("Save", context.Background(), mock.Anything).Return(nil)
+		changed, err := svc.Disconnect(context.Background(), thingID, at)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.changed, changed, fmt.Sprintf("%s: expected changed %t got %t\n", tc.desc, tc.changed, changed))
+		if tc.changed {
+			p := tc.prev
+			p.Online = false
+			p.LastSeen = at
+			ok := repoCall1.Parent.AssertCalled(t, "Save", context.Background(), p)
+			assert.True(t, ok, fmt.Sprintf("%s: expected presence to be saved", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		prRepo.Calls = nil
+	}
+}
+
+func TestExpire(t *testing.T) {
+	svc, prRepo, _, _ := newService(map[string]string{token: adminEmail})
+
+	before := time.Now().Add(-time.Hour)
+	expired := []presence.Presence{{ThingID: thingID, LastSeen: before.Add(-time.Minute), Protocol: "http"}}
+
+	repoCall := prRepo.On("Expire", context.Background(), before, presence.MQTTProtocol).Return(expired, nil)
+	ps, err := svc.Expire(context.Background(), before)
+	assert.Nil(t, err, fmt.Sprintf("expire things: expected no error got %s\n", err))
+	assert.Equal(t, expired, ps, fmt.Sprintf("expire things: expected %v got %v\n", expired, ps))
+	repoCall.Unset()
+}
+
+func TestViewPresence(t *testing.T) {
+	svc, prRepo, cRepo, pRepo := newService(map[string]string{token: adminEmail})
+
+	seen := presence.Presence{ThingID: thingID, Online: true, LastSeen: time.Now(), Protocol: "http", RemoteAddr: "10.0.0.1:5000"}
+
+	cases := []struct {
+		desc     string
+		token    string
+		thingErr error
+		prev     presence.Presence
+		prevErr  error
+		response presence.Presence
+		err      error
+	}{
+		{
+			desc:     "view presence of seen thing",
+			token:    token,
+			prev:     seen,
+			response: seen,
+		},
+		{
+			desc:     "view presence of never seen thing",
+			token:    token,
+			prevErr:  errors.ErrNotFound,
+			response: presence.Presence{ThingID: thingID},
+		},
+		{
+			desc:     "view presence with invalid token",
+			token:    inValidToken,
+			response: presence.Presence{},
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "view presence of non-existing thing",
+			token:    token,
+			thingErr: errors.ErrNotFound,
+			response: presence.Presence{},
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("EvaluateThingAccess", mock.Anything, mock.Anything).Return(policies.Policy{}, nil)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), thingID).Return(mfclients.Client{ID: thingID}, tc.thingErr)
+		repoCall2 := prRepo.On("Retrieve", context.Background(), thingID).Return(tc.prev, tc.prevErr)
+		p, err := svc.ViewPresence(context.Background(), tc.token, thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, p))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/things/presence"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ presence.Service = (*tracingMiddleware)(nil)
+
+type tracingMiddleware struct {
+	tracer trace.Tracer
+	svc    presence.Service
+}
+
+// New returns a new presence service with tracing capabilities.
+func New(svc presence.Service, tracer trace.Tracer) presence.Service {
+	return &tracingMiddleware{tracer, svc}
+}
+
+// Seen traces the "Seen" operation of the wrapped presence.Service.
+func (tm *tracingMiddleware) Seen(ctx context.Context, p presence.Presence) (bool, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_seen", trace.WithAttributes(
+		attribute.String("thing_id", p.ThingID),
+		attribute.String("protocol", p.Protocol),
+	))
+	defer span.End()
+
+	return tm.svc.Seen(ctx, p)
+}
+
+// Disconnect traces the "Disconnect" operation of the wrapped presence.Service.
+func (tm *tracingMiddleware) Disconnect(ctx context.Context, thingID string, at time.Time) (bool, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_disconnect", trace.WithAttributes(attribute.String("thing_id", thingID)))
+	defer span.End()
+
+	return tm.svc.Disconnect(ctx, thingID, at)
+}
+
+// Expire traces the "Expire" operation of the wrapped presence.Service.
+func (tm *tracingMiddleware) Expire(ctx context.Context, before time.Time) ([]presence.Presence, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_expire")
+	defer span.End()
+
+	return tm.svc.Expire(ctx, before)
+}
+
+// ViewPresence traces the "ViewPresence" operation of the wrapped presence.Service.
+func (tm *tracingMiddleware) ViewPresence(ctx context.Context, token, thingID string) (presence.Presence, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_presence", trace.WithAttributes(attribute.String("thing_id", thingID)))
+	defer span.End()
+
+	return tm.svc.ViewPresence(ctx, token, thingID)
+}
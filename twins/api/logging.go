@@ -92,6 +92,19 @@ func (lm *loggingMiddleware) SaveStates(ctx context.Context, msg *messaging.Mess
 	return lm.svc.SaveStates(ctx, msg)
 }
 
+func (lm *loggingMiddleware) RemoveChannelHandler(ctx context.Context, channelID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_channel_handler for channel %s took %s to complete", channelID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveChannelHandler(ctx, channelID)
+}
+
 func (lm *loggingMiddleware) ListStates(ctx context.Context, token string, offset uint64, limit uint64, twinID string) (page twins.StatesPage, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method list_states for token %s took %s to complete", token, time.Since(begin))
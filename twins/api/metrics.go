@@ -76,6 +76,15 @@ func (ms *metricsMiddleware) SaveStates(ctx context.Context, msg *messaging.Mess
 	return ms.svc.SaveStates(ctx, msg)
 }
 
+func (ms *metricsMiddleware) RemoveChannelHandler(ctx context.Context, channelID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_channel_handler").Add(1)
+		ms.latency.With("method", "remove_channel_handler").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveChannelHandler(ctx, channelID)
+}
+
 func (ms *metricsMiddleware) ListStates(ctx context.Context, token string, offset uint64, limit uint64, twinID string) (st twins.StatesPage, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_states").Add(1)
@@ -0,0 +1,41 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/pkg/events"
+	"github.com/mainflux/mainflux/twins"
+)
+
+const channelRemove = "channel.remove"
+
+type eventHandler struct {
+	svc twins.Service
+}
+
+// NewEventHandler returns new event store handler.
+func NewEventHandler(svc twins.Service) events.EventHandler {
+	return &eventHandler{
+		svc: svc,
+	}
+}
+
+func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	if msg["operation"] != channelRemove {
+		return nil
+	}
+	id, ok := msg["id"].(string)
+	if !ok || id == "" {
+		return nil
+	}
+
+	return es.svc.RemoveChannelHandler(ctx, id)
+}
@@ -153,3 +153,7 @@ func (es eventStore) SaveStates(ctx context.Context, msg *messaging.Message) err
 
 	return nil
 }
+
+func (es eventStore) RemoveChannelHandler(ctx context.Context, channelID string) error {
+	return es.svc.RemoveChannelHandler(ctx, channelID)
+}
@@ -86,6 +86,30 @@ func (tc *twinCache) Remove(ctx context.Context, twinID string) error {
 	return tc.remove(ctx, twinID)
 }
 
+func (tc *twinCache) RemoveChannel(ctx context.Context, channel string) error {
+	iter := tc.client.Scan(ctx, 0, attrKey(channel, "*"), 0).Iterator()
+	for iter.Next(ctx) {
+		attrKey := iter.Val()
+		ids, err := tc.client.SMembers(ctx, attrKey).Result()
+		if err != nil {
+			return errors.Wrap(ErrRedisTwinRemove, err)
+		}
+		for _, id := range ids {
+			if err := tc.client.SRem(ctx, twinKey(id), attrKey).Err(); err != nil {
+				return errors.Wrap(ErrRedisTwinRemove, err)
+			}
+		}
+		if err := tc.client.Del(ctx, attrKey).Err(); err != nil {
+			return errors.Wrap(ErrRedisTwinRemove, err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return errors.Wrap(ErrRedisTwinRemove, err)
+	}
+
+	return nil
+}
+
 func (tc *twinCache) save(ctx context.Context, twin twins.Twin) error {
 	if len(twin.Definitions) < 1 {
 		return nil
@@ -229,6 +229,23 @@ func (tcm *twinCacheMock) Remove(_ context.Context, twinID string) error {
 	return tcm.remove(twinID)
 }
 
+func (tcm *twinCacheMock) RemoveChannel(_ context.Context, channel string) error {
+	tcm.mu.Lock()
+	defer tcm.mu.Unlock()
+
+	for attrKey, ids := range tcm.attrIds {
+		if !strings.HasPrefix(attrKey, channel) {
+			continue
+		}
+		for id := range ids {
+			delete(tcm.idAttrs[id], attrKey)
+		}
+		delete(tcm.attrIds, attrKey)
+	}
+
+	return nil
+}
+
 func (tcm *twinCacheMock) remove(twinID string) error {
 	attrKeys, ok := tcm.idAttrs[twinID]
 	if !ok {
@@ -48,6 +48,10 @@ type Service interface {
 
 	// SaveStates persists states into database
 	SaveStates(ctx context.Context, msg *messaging.Message) error
+
+	// RemoveChannelHandler removes the routes of the channel with the ID
+	// received from an event.
+	RemoveChannelHandler(ctx context.Context, channelID string) error
 }
 
 const (
@@ -114,7 +118,7 @@ func (ts *twinsService) AddTwin(ctx context.Context, token string, twin Twin, de
 		return Twin{}, err
 	}
 
-	twin.Owner = res.GetId()
+	twin.Owner = res.GetOwner()
 
 	t := time.Now()
 	twin.Created = t
@@ -234,7 +238,7 @@ func (ts *twinsService) ListTwins(ctx context.Context, token string, offset uint
 		return Page{}, errors.ErrAuthentication
 	}
 
-	return ts.twins.RetrieveAll(ctx, res.GetId(), offset, limit, name, metadata)
+	return ts.twins.RetrieveAll(ctx, res.GetOwner(), offset, limit, name, metadata)
 }
 
 func (ts *twinsService) ListStates(ctx context.Context, token string, offset uint64, limit uint64, twinID string) (StatesPage, error) {
@@ -276,6 +280,10 @@ func (ts *twinsService) SaveStates(ctx context.Context, msg *messaging.Message)
 	return nil
 }
 
+func (ts *twinsService) RemoveChannelHandler(ctx context.Context, channelID string) error {
+	return ts.twinCache.RemoveChannel(ctx, channelID)
+}
+
 func (ts *twinsService) saveState(ctx context.Context, msg *messaging.Message, twinID string) error {
 	var b []byte
 	var err error
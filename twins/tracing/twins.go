@@ -18,6 +18,7 @@ const (
 	retrieveAllTwinsOp         = "retrieve_all_twins"
 	retrieveTwinsByAttributeOp = "retrieve_twins_by_attribute"
 	removeTwinOp               = "remove_twin"
+	removeChannelOp            = "remove_channel"
 )
 
 var _ twins.TwinRepository = (*twinRepositoryMiddleware)(nil)
@@ -125,6 +126,13 @@ func (tcm twinCacheMiddleware) Remove(ctx context.Context, twinID string) error
 	return tcm.cache.Remove(ctx, twinID)
 }
 
+func (tcm twinCacheMiddleware) RemoveChannel(ctx context.Context, channel string) error {
+	ctx, span := createSpan(ctx, tcm.tracer, removeChannelOp)
+	defer span.End()
+
+	return tcm.cache.RemoveChannel(ctx, channel)
+}
+
 func createSpan(ctx context.Context, tracer trace.Tracer, opName string) (context.Context, trace.Span) {
 	return tracer.Start(ctx, opName)
 }
@@ -93,4 +93,7 @@ type TwinCache interface {
 
 	// Removes twin from cache based on twin id.
 	Remove(ctx context.Context, twinID string) error
+
+	// RemoveChannel removes the channel-subtopic keyed sets of the channel.
+	RemoveChannel(ctx context.Context, channel string) error
 }
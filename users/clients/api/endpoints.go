@@ -11,6 +11,7 @@ import (
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users/clients"
+	"github.com/mainflux/mainflux/users/lockout"
 )
 
 func registrationEndpoint(svc clients.Service) endpoint.Endpoint {
@@ -32,6 +33,21 @@ func registrationEndpoint(svc clients.Service) endpoint.Endpoint {
 	}
 }
 
+func verifyClientEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(verifyClientReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		client, err := svc.VerifyClient(ctx, req.Token)
+		if err != nil {
+			return nil, err
+		}
+		return viewClientRes{Client: client}, nil
+	}
+}
+
 func viewClientEndpoint(svc clients.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(viewClientReq)
@@ -251,6 +267,7 @@ func issueTokenEndpoint(svc clients.Service) endpoint.Endpoint {
 			return nil, errors.Wrap(apiutil.ErrValidation, err)
 		}
 
+		ctx = lockout.WithSourceIP(ctx, req.sourceIP)
 		token, err := svc.IssueToken(ctx, req.Identity, req.Secret)
 		if err != nil {
 			return nil, err
@@ -283,6 +300,36 @@ func refreshTokenEndpoint(svc clients.Service) endpoint.Endpoint {
 	}
 }
 
+func revokeTokenEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeTokenReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RevokeToken(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return revokeTokenRes{}, nil
+	}
+}
+
+func revokeTokensEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeTokenReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RevokeTokens(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return revokeTokenRes{}, nil
+	}
+}
+
 func enableClientEndpoint(svc clients.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(changeClientStatusReq)
@@ -311,6 +358,19 @@ func disableClientEndpoint(svc clients.Service) endpoint.Endpoint {
 	}
 }
 
+func unlockClientEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(changeClientStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if err := svc.UnlockClient(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+		return unlockClientRes{}, nil
+	}
+}
+
 func buildMembersResponse(cp mfclients.MembersPage) memberPageRes {
 	res := memberPageRes{
 		pageRes: pageRes{
@@ -40,6 +40,34 @@ func (lm *loggingMiddleware) RegisterClient(ctx context.Context, token string, c
 	return lm.svc.RegisterClient(ctx, token, client)
 }
 
+// VerifyClient logs the verify_client request. It logs the client id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) VerifyClient(ctx context.Context, token string) (c mfclients.Client, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method verify_client with id %s took %s to complete", c.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.VerifyClient(ctx, token)
+}
+
+// RemoveUnverifiedClients logs the remove_unverified_clients request. It logs the number of removed clients and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveUnverifiedClients(ctx context.Context) (n uint64, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_unverified_clients removed %d clients and took %s to complete", n, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.RemoveUnverifiedClients(ctx)
+}
+
 // IssueToken logs the issue_token request. It logs the client identity and token type and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) IssueToken(ctx context.Context, identity, secret string) (t jwt.Token, err error) {
@@ -68,6 +96,34 @@ func (lm *loggingMiddleware) RefreshToken(ctx context.Context, refreshToken stri
 	return lm.svc.RefreshToken(ctx, refreshToken)
 }
 
+// RevokeToken logs the revoke_token request. It logs the token and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RevokeToken(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_token for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.RevokeToken(ctx, token)
+}
+
+// RevokeTokens logs the revoke_tokens request. It logs the token and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RevokeTokens(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_tokens using token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.RevokeTokens(ctx, token)
+}
+
 // ViewClient logs the view_client request. It logs the client id and token and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) ViewClient(ctx context.Context, token, id string) (c mfclients.Client, err error) {
@@ -250,6 +306,20 @@ func (lm *loggingMiddleware) DisableClient(ctx context.Context, token, id string
 	return lm.svc.DisableClient(ctx, token, id)
 }
 
+// UnlockClient logs the unlock_client request. It logs the client id and token and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) UnlockClient(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unlock_client for client with id %s using token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.UnlockClient(ctx, token, id)
+}
+
 // ListMembers logs the list_members request. It logs the group id, token and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) ListMembers(ctx context.Context, token, groupID string, cp mfclients.Page) (mp mfclients.MembersPage, err error) {
@@ -39,6 +39,24 @@ func (ms *metricsMiddleware) RegisterClient(ctx context.Context, token string, c
 	return ms.svc.RegisterClient(ctx, token, client)
 }
 
+// VerifyClient instruments VerifyClient method with metrics.
+func (ms *metricsMiddleware) VerifyClient(ctx context.Context, token string) (mfclients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_client").Add(1)
+		ms.latency.With("method", "verify_client").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.VerifyClient(ctx, token)
+}
+
+// RemoveUnverifiedClients instruments RemoveUnverifiedClients method with metrics.
+func (ms *metricsMiddleware) RemoveUnverifiedClients(ctx context.Context) (uint64, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_unverified_clients").Add(1)
+		ms.latency.With("method", "remove_unverified_clients").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RemoveUnverifiedClients(ctx)
+}
+
 // IssueToken instruments IssueToken method with metrics.
 func (ms *metricsMiddleware) IssueToken(ctx context.Context, identity, secret string) (jwt.Token, error) {
 	defer func(begin time.Time) {
@@ -57,6 +75,24 @@ func (ms *metricsMiddleware) RefreshToken(ctx context.Context, accessToken strin
 	return ms.svc.RefreshToken(ctx, accessToken)
 }
 
+// RevokeToken instruments RevokeToken method with metrics.
+func (ms *metricsMiddleware) RevokeToken(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_token").Add(1)
+		ms.latency.With("method", "revoke_token").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RevokeToken(ctx, token)
+}
+
+// RevokeTokens instruments RevokeTokens method with metrics.
+func (ms *metricsMiddleware) RevokeTokens(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_tokens").Add(1)
+		ms.latency.With("method", "revoke_tokens").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RevokeTokens(ctx, token)
+}
+
 // ViewClient instruments ViewClient method with metrics.
 func (ms *metricsMiddleware) ViewClient(ctx context.Context, token, id string) (mfclients.Client, error) {
 	defer func(begin time.Time) {
@@ -174,6 +210,15 @@ func (ms *metricsMiddleware) DisableClient(ctx context.Context, token string, id
 	return ms.svc.DisableClient(ctx, token, id)
 }
 
+// UnlockClient instruments UnlockClient method with metrics.
+func (ms *metricsMiddleware) UnlockClient(ctx context.Context, token string, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unlock_client").Add(1)
+		ms.latency.With("method", "unlock_client").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UnlockClient(ctx, token, id)
+}
+
 // ListMembers instruments ListMembers method with metrics.
 func (ms *metricsMiddleware) ListMembers(ctx context.Context, token, groupID string, pm mfclients.Page) (mp mfclients.MembersPage, err error) {
 	defer func(begin time.Time) {
@@ -23,6 +23,18 @@ func (req createClientReq) validate() error {
 	return req.client.Validate()
 }
 
+type verifyClientReq struct {
+	Token string `json:"token"`
+}
+
+func (req verifyClientReq) validate() error {
+	if req.Token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
 type viewClientReq struct {
 	token string
 	id    string
@@ -193,6 +205,7 @@ func (req changeClientStatusReq) validate() error {
 }
 
 type loginClientReq struct {
+	sourceIP string
 	Identity string `json:"identity,omitempty"`
 	Secret   string `json:"secret,omitempty"`
 }
@@ -218,6 +231,17 @@ func (req tokenReq) validate() error {
 	return nil
 }
 
+type revokeTokenReq struct {
+	token string
+}
+
+func (req revokeTokenReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	return nil
+}
+
 type passwResetReq struct {
 	Email string `json:"email"`
 	Host  string `json:"host"`
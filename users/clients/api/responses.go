@@ -9,6 +9,7 @@ import (
 
 	"github.com/mainflux/mainflux"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/users/jwt"
 )
 
 // MailSent message response when link is sent.
@@ -71,8 +72,10 @@ func (res tokenRes) Headers() map[string]string {
 	return map[string]string{}
 }
 
+// Empty reports whether the token is missing. MFA challenge token is
+// issued without the refresh token.
 func (res tokenRes) Empty() bool {
-	return res.AccessToken == "" || res.RefreshToken == ""
+	return res.AccessToken == "" || (res.RefreshToken == "" && res.AccessType != jwt.MFAType)
 }
 
 type updateClientRes struct {
@@ -202,3 +205,31 @@ func (res passwChangeRes) Headers() map[string]string {
 func (res passwChangeRes) Empty() bool {
 	return false
 }
+
+type unlockClientRes struct{}
+
+func (res unlockClientRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unlockClientRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unlockClientRes) Empty() bool {
+	return true
+}
+
+type revokeTokenRes struct{}
+
+func (res revokeTokenRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res revokeTokenRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revokeTokenRes) Empty() bool {
+	return true
+}
@@ -6,7 +6,9 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
+	"net/netip"
 	"strings"
 
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -22,8 +24,54 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-// MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc clients.Service, mux *bone.Mux, logger mflog.Logger, instanceID string) http.Handler {
+const realIPHeader = "X-Real-IP"
+
+// TrustedProxies holds the address ranges of the reverse proxies, which are
+// trusted to set the client IP header of the requests.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses the IP addresses and CIDR ranges of the trusted
+// reverse proxies.
+func ParseTrustedProxies(values []string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if strings.Contains(v, "/") {
+			prefix, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, err
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, err
+		}
+		addr = addr.Unmap()
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return proxies, nil
+}
+
+func (tp TrustedProxies) contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range tp {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MakeHandler returns a HTTP handler for API endpoints. The client IP header
+// is honored only for the requests coming from the trusted proxies.
+func MakeHandler(svc clients.Service, mux *bone.Mux, logger mflog.Logger, instanceID string, proxies TrustedProxies) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
 	}
@@ -35,6 +83,13 @@ func MakeHandler(svc clients.Service, mux *bone.Mux, logger mflog.Logger, instan
 		opts...,
 	), "register_client"))
 
+	mux.Post("/users/verify", otelhttp.NewHandler(kithttp.NewServer(
+		verifyClientEndpoint(svc),
+		decodeVerifyClient,
+		api.EncodeResponse,
+		opts...,
+	), "verify_client"))
+
 	mux.Get("/users/profile", otelhttp.NewHandler(kithttp.NewServer(
 		viewProfileEndpoint(svc),
 		decodeViewProfile,
@@ -114,7 +169,7 @@ func MakeHandler(svc clients.Service, mux *bone.Mux, logger mflog.Logger, instan
 
 	mux.Post("/users/tokens/issue", otelhttp.NewHandler(kithttp.NewServer(
 		issueTokenEndpoint(svc),
-		decodeCredentials,
+		decodeCredentials(proxies),
 		api.EncodeResponse,
 		opts...,
 	), "issue_token"))
@@ -126,6 +181,20 @@ func MakeHandler(svc clients.Service, mux *bone.Mux, logger mflog.Logger, instan
 		opts...,
 	), "refresh_token"))
 
+	mux.Post("/users/tokens/revoke", otelhttp.NewHandler(kithttp.NewServer(
+		revokeTokenEndpoint(svc),
+		decodeRevokeToken,
+		api.EncodeResponse,
+		opts...,
+	), "revoke_token"))
+
+	mux.Post("/users/tokens/revoke-all", otelhttp.NewHandler(kithttp.NewServer(
+		revokeTokensEndpoint(svc),
+		decodeRevokeToken,
+		api.EncodeResponse,
+		opts...,
+	), "revoke_tokens"))
+
 	mux.Post("/users/:id/enable", otelhttp.NewHandler(kithttp.NewServer(
 		enableClientEndpoint(svc),
 		decodeChangeClientStatus,
@@ -140,6 +209,13 @@ func MakeHandler(svc clients.Service, mux *bone.Mux, logger mflog.Logger, instan
 		opts...,
 	), "disable_client"))
 
+	mux.Post("/users/:id/unlock", otelhttp.NewHandler(kithttp.NewServer(
+		unlockClientEndpoint(svc),
+		decodeChangeClientStatus,
+		api.EncodeResponse,
+		opts...,
+	), "unlock_client"))
+
 	mux.GetFunc("/health", mainflux.Health("users", instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -318,6 +394,19 @@ func decodePasswordReset(_ context.Context, r *http.Request) (interface{}, error
 	return req, nil
 }
 
+func decodeVerifyClient(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req verifyClientReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodeUpdateClientOwner(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -333,14 +422,40 @@ func decodeUpdateClientOwner(_ context.Context, r *http.Request) (interface{}, e
 	return req, nil
 }
 
-func decodeCredentials(_ context.Context, r *http.Request) (interface{}, error) {
-	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
-		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+func decodeCredentials(proxies TrustedProxies) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+		}
+		req := loginClientReq{sourceIP: sourceIP(r, proxies)}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+		}
+
+		return req, nil
 	}
-	req := loginClientReq{}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+}
+
+// sourceIP returns the address of the connection peer or, if the peer is a
+// trusted proxy, the client IP it has set.
+func sourceIP(r *http.Request, proxies TrustedProxies) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
 	}
+	peer, err := netip.ParseAddr(host)
+	if err != nil || !proxies.contains(peer) {
+		return host
+	}
+	if ip, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get(realIPHeader))); err == nil {
+		return ip.Unmap().String()
+	}
+
+	return host
+}
+
+func decodeRevokeToken(_ context.Context, r *http.Request) (interface{}, error) {
+	req := revokeTokenReq{token: apiutil.ExtractBearerToken(r)}
 
 	return req, nil
 }
@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	cases := []struct {
+		desc    string
+		values  []string
+		proxies int
+		err     bool
+	}{
+		{
+			desc:    "parse addresses and ranges",
+			values:  []string{"10.0.0.1", " 172.16.0.0/12", "::1", ""},
+			proxies: 3,
+		},
+		{
+			desc:   "parse invalid address",
+			values: []string{"proxy"},
+			err:    true,
+		},
+		{
+			desc:   "parse invalid range",
+			values: []string{"10.0.0.0/33"},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		proxies, err := ParseTrustedProxies(tc.values)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+		assert.Len(t, proxies, tc.proxies, fmt.Sprintf("%s: expected %d proxies got %d", tc.desc, tc.proxies, len(proxies)))
+	}
+}
+
+func TestSourceIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.1", "172.16.0.0/12"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc       string
+		remoteAddr string
+		realIP     string
+		ip         string
+	}{
+		{
+			desc:       "source IP of direct request",
+			remoteAddr: "192.168.0.1:5000",
+			ip:         "192.168.0.1",
+		},
+		{
+			desc:       "source IP of direct request with client IP header",
+			remoteAddr: "192.168.0.1:5000",
+			realIP:     "192.168.0.2",
+			ip:         "192.168.0.1",
+		},
+		{
+			desc:       "source IP of request from trusted proxy",
+			remoteAddr: "10.0.0.1:5000",
+			realIP:     "192.168.0.2",
+			ip:         "192.168.0.2",
+		},
+		{
+			desc:       "source IP of request from trusted proxy range",
+			remoteAddr: "[::ffff:172.18.0.5]:5000",
+			realIP:     "192.168.0.2",
+			ip:         "192.168.0.2",
+		},
+		{
+			desc:       "source IP of request from trusted proxy without client IP header",
+			remoteAddr: "10.0.0.1:5000",
+			ip:         "10.0.0.1",
+		},
+		{
+			desc:       "source IP of request from trusted proxy with invalid client IP header",
+			remoteAddr: "10.0.0.1:5000",
+			realIP:     "unknown",
+			ip:         "10.0.0.1",
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := http.NewRequest(http.MethodPost, "/users/tokens/issue", nil)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		r.RemoteAddr = tc.remoteAddr
+		if tc.realIP != "" {
+			r.Header.Set(realIPHeader, tc.realIP)
+		}
+		ip := sourceIP(r, proxies)
+		assert.Equal(t, tc.ip, ip, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.ip, ip))
+	}
+}
@@ -13,9 +13,20 @@ import (
 // implementation, and all of its decorators (e.g. logging & metrics).
 type ClientService interface {
 	// RegisterClient creates new client. In case of the failed registration, a
-	// non-nil error value is returned.
+	// non-nil error value is returned. If the open registration is enabled,
+	// the client can register itself without the token. Such client is
+	// pending until it verifies its identity using the link sent by e-mail.
 	RegisterClient(ctx context.Context, token string, client clients.Client) (clients.Client, error)
 
+	// VerifyClient enables the self-registered client identified by the
+	// identity verification token.
+	VerifyClient(ctx context.Context, token string) (clients.Client, error)
+
+	// RemoveUnverifiedClients removes the self-registered clients which haven't
+	// verified their identity before the verification expiry, and returns the
+	// number of removed clients.
+	RemoveUnverifiedClients(ctx context.Context) (uint64, error)
+
 	// ViewClient retrieves client info for a given client ID and an authorized token.
 	ViewClient(ctx context.Context, token, id string) (clients.Client, error)
 
@@ -45,7 +56,7 @@ type ClientService interface {
 	UpdateClientSecret(ctx context.Context, token, oldSecret, newSecret string) (clients.Client, error)
 
 	// ResetSecret change users secret in reset flow.
-	// token can be authentication token or secret reset token.
+	// The token has to be the secret reset token sent by GenerateResetToken.
 	ResetSecret(ctx context.Context, resetToken, secret string) error
 
 	// SendPasswordReset sends reset password link to email.
@@ -60,6 +71,10 @@ type ClientService interface {
 	// DisableClient logically disables the client identified with the provided ID.
 	DisableClient(ctx context.Context, token, id string) (clients.Client, error)
 
+	// UnlockClient removes the failed login attempts lockout of the client
+	// identified with the provided ID. Only admins are allowed to unlock clients.
+	UnlockClient(ctx context.Context, token, id string) error
+
 	// Identify returns the client id from the given token.
 	Identify(ctx context.Context, tkn string) (string, error)
 }
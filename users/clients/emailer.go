@@ -7,4 +7,8 @@ package clients
 type Emailer interface {
 	// SendPasswordReset sends an email to the user with a link to reset the password.
 	SendPasswordReset(To []string, host, user, token string) error
+
+	// SendVerification sends an email to the self-registered user with a link
+	// to verify the identity.
+	SendVerification(To []string, user, token string) error
 }
@@ -7,23 +7,62 @@ import (
 	"fmt"
 
 	"github.com/mainflux/mainflux/internal/email"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users/clients"
+	"github.com/mainflux/mainflux/users/mfa"
 )
 
-var _ clients.Emailer = (*emailer)(nil)
+var errMissingVerificationTemplate = errors.New("missing verification e-mail template")
+
+var (
+	_ clients.Emailer = (*emailer)(nil)
+	_ mfa.Emailer     = (*emailer)(nil)
+)
 
 type emailer struct {
-	resetURL string
-	agent    *email.Agent
+	resetURL          string
+	verificationURL   string
+	enrollURL         string
+	agent             *email.Agent
+	verificationAgent *email.Agent
 }
 
-// New creates new emailer utility.
-func New(url string, c *email.Config) (clients.Emailer, error) {
+// Emailer sends the clients and MFA e-mails.
+type Emailer interface {
+	clients.Emailer
+	mfa.Emailer
+}
+
+// New creates new emailer utility. Verification e-mails are rendered using
+// the verification template instead of the configured one, and can't be sent
+// if the verification template is empty.
+func New(url, verificationURL, enrollURL, verificationTemplate string, c *email.Config) (Emailer, error) {
 	e, err := email.New(c)
-	return &emailer{resetURL: url, agent: e}, err
+	em := &emailer{resetURL: url, verificationURL: verificationURL, enrollURL: enrollURL, agent: e}
+	if err != nil || verificationTemplate == "" {
+		return em, err
+	}
+	vc := *c
+	vc.Template = verificationTemplate
+	em.verificationAgent, err = email.New(&vc)
+
+	return em, err
 }
 
 func (e *emailer) SendPasswordReset(to []string, host, user, token string) error {
 	url := fmt.Sprintf("%s%s?token=%s", host, e.resetURL, token)
 	return e.agent.Send(to, "", "Password Reset Request", "", user, url, "")
 }
+
+func (e *emailer) SendVerification(to []string, user, token string) error {
+	if e.verificationAgent == nil {
+		return errMissingVerificationTemplate
+	}
+	url := fmt.Sprintf("%s?token=%s", e.verificationURL, token)
+	return e.verificationAgent.Send(to, "", "Verify Your Account", "", user, url, "")
+}
+
+func (e *emailer) SendEnrollment(to []string, user, token string) error {
+	url := fmt.Sprintf("%s?token=%s", e.enrollURL, token)
+	return e.agent.Send(to, "", "Enroll Two-Factor Authentication", "", user, url, "")
+}
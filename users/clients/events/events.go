@@ -26,8 +26,13 @@ const (
 	generateResetToken = clientPrefix + "generate_reset_token"
 	issueToken         = clientPrefix + "issue_token"
 	refreshToken       = clientPrefix + "refresh_token"
+	revokeToken        = clientPrefix + "revoke_token"
+	revokeTokens       = clientPrefix + "revoke_tokens"
 	resetSecret        = clientPrefix + "reset_secret"
 	sendPasswordReset  = clientPrefix + "send_password_reset"
+	clientLockout      = clientPrefix + "lockout"
+	clientUnlock       = clientPrefix + "unlock"
+	clientVerify       = clientPrefix + "verify"
 )
 
 var (
@@ -42,8 +47,13 @@ var (
 	_ events.Event = (*generateResetTokenEvent)(nil)
 	_ events.Event = (*issueTokenEvent)(nil)
 	_ events.Event = (*refreshTokenEvent)(nil)
+	_ events.Event = (*revokeTokenEvent)(nil)
+	_ events.Event = (*revokeTokensEvent)(nil)
 	_ events.Event = (*resetSecretEvent)(nil)
 	_ events.Event = (*sendPasswordResetEvent)(nil)
+	_ events.Event = (*lockoutClientEvent)(nil)
+	_ events.Event = (*unlockClientEvent)(nil)
+	_ events.Event = (*verifyClientEvent)(nil)
 )
 
 type createClientEvent struct {
@@ -393,6 +403,22 @@ func (rte refreshTokenEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type revokeTokenEvent struct{}
+
+func (rte revokeTokenEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": revokeToken,
+	}, nil
+}
+
+type revokeTokensEvent struct{}
+
+func (rte revokeTokensEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": revokeTokens,
+	}, nil
+}
+
 type resetSecretEvent struct{}
 
 func (rse resetSecretEvent) Encode() (map[string]interface{}, error) {
@@ -415,3 +441,44 @@ func (spre sendPasswordResetEvent) Encode() (map[string]interface{}, error) {
 		"user":      spre.user,
 	}, nil
 }
+
+type lockoutClientEvent struct {
+	identity string
+	sourceIP string
+}
+
+func (lce lockoutClientEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": clientLockout,
+		"identity":  lce.identity,
+	}
+	if lce.sourceIP != "" {
+		val["source_ip"] = lce.sourceIP
+	}
+
+	return val, nil
+}
+
+type unlockClientEvent struct {
+	id string
+}
+
+func (uce unlockClientEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": clientUnlock,
+		"id":        uce.id,
+	}, nil
+}
+
+type verifyClientEvent struct {
+	id        string
+	updatedAt time.Time
+}
+
+func (vce verifyClientEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  clientVerify,
+		"id":         vce.id,
+		"updated_at": vce.updatedAt,
+	}, nil
+}
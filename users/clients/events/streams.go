@@ -7,10 +7,12 @@ import (
 	"context"
 
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/events"
 	"github.com/mainflux/mainflux/pkg/events/redis"
 	"github.com/mainflux/mainflux/users/clients"
 	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/lockout"
 )
 
 const streamID = "mainflux.users"
@@ -194,6 +196,37 @@ func (es *eventStore) DisableClient(ctx context.Context, token, id string) (mfcl
 	return es.delete(ctx, user)
 }
 
+func (es *eventStore) UnlockClient(ctx context.Context, token, id string) error {
+	if err := es.svc.UnlockClient(ctx, token, id); err != nil {
+		return err
+	}
+	event := unlockClientEvent{
+		id: id,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) VerifyClient(ctx context.Context, token string) (mfclients.Client, error) {
+	user, err := es.svc.VerifyClient(ctx, token)
+	if err != nil {
+		return user, err
+	}
+	event := verifyClientEvent{
+		id:        user.ID,
+		updatedAt: user.UpdatedAt,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
+func (es *eventStore) RemoveUnverifiedClients(ctx context.Context) (uint64, error) {
+	return es.svc.RemoveUnverifiedClients(ctx)
+}
+
 func (es *eventStore) delete(ctx context.Context, user mfclients.Client) (mfclients.Client, error) {
 	event := removeClientEvent{
 		id:        user.ID,
@@ -239,6 +272,14 @@ func (es *eventStore) GenerateResetToken(ctx context.Context, email, host string
 
 func (es *eventStore) IssueToken(ctx context.Context, identity, secret string) (jwt.Token, error) {
 	token, err := es.svc.IssueToken(ctx, identity, secret)
+	if errors.Contains(err, lockout.ErrLockedOut) {
+		event := lockoutClientEvent{
+			identity: identity,
+			sourceIP: lockout.SourceIP(ctx),
+		}
+		// Failure to publish the event must not mask the lockout.
+		_ = es.Publish(ctx, event)
+	}
 	if err != nil {
 		return token, err
 	}
@@ -267,6 +308,24 @@ func (es *eventStore) RefreshToken(ctx context.Context, refreshToken string) (jw
 	return token, nil
 }
 
+func (es *eventStore) RevokeToken(ctx context.Context, token string) error {
+	if err := es.svc.RevokeToken(ctx, token); err != nil {
+		return err
+	}
+	event := revokeTokenEvent{}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) RevokeTokens(ctx context.Context, token string) error {
+	if err := es.svc.RevokeTokens(ctx, token); err != nil {
+		return err
+	}
+	event := revokeTokensEvent{}
+
+	return es.Publish(ctx, event)
+}
+
 func (es *eventStore) ResetSecret(ctx context.Context, resetToken, secret string) error {
 	if err := es.svc.ResetSecret(ctx, resetToken, secret); err != nil {
 		return err
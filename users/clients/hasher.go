@@ -12,4 +12,8 @@ type Hasher interface {
 	// Compare compares plain-text version to the hashed one. An error should
 	// indicate failed comparison.
 	Compare(string, string) error
+
+	// NeedsRehash reports whether the hash was generated by a different
+	// algorithm or with different parameters than the configured ones.
+	NeedsRehash(string) bool
 }
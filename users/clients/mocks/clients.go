@@ -5,6 +5,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -27,7 +28,7 @@ func (m *Repository) ChangeStatus(ctx context.Context, client mfclients.Client)
 		return mfclients.Client{}, errors.ErrNotFound
 	}
 
-	if client.Status != mfclients.EnabledStatus && client.Status != mfclients.DisabledStatus {
+	if client.Status != mfclients.EnabledStatus && client.Status != mfclients.DisabledStatus && client.Status != mfclients.PendingStatus {
 		return mfclients.Client{}, errors.ErrMalformedEntity
 	}
 
@@ -81,6 +82,15 @@ func (m *Repository) Save(ctx context.Context, client mfclients.Client) (mfclien
 	return client, ret.Error(1)
 }
 
+func (m *Repository) UpdateRole(ctx context.Context, client mfclients.Client) (mfclients.Client, error) {
+	ret := m.Called(ctx, client)
+
+	if client.ID == WrongID {
+		return mfclients.Client{}, errors.ErrNotFound
+	}
+	return ret.Get(0).(mfclients.Client), ret.Error(1)
+}
+
 func (m *Repository) Update(ctx context.Context, client mfclients.Client) (mfclients.Client, error) {
 	ret := m.Called(ctx, client)
 
@@ -139,3 +149,15 @@ func (m *Repository) UpdateOwner(ctx context.Context, client mfclients.Client) (
 func (*Repository) RetrieveBySecret(ctx context.Context, key string) (mfclients.Client, error) {
 	return mfclients.Client{}, nil
 }
+
+func (m *Repository) RemoveUnverified(ctx context.Context, createdBefore time.Time) (uint64, error) {
+	ret := m.Called(ctx, createdBefore)
+
+	return ret.Get(0).(uint64), ret.Error(1)
+}
+
+func (m *Repository) RemovePending(ctx context.Context, id string) error {
+	ret := m.Called(ctx, id)
+
+	return ret.Error(0)
+}
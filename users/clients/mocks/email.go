@@ -7,13 +7,25 @@ import (
 	"github.com/mainflux/mainflux/users/clients"
 )
 
-type emailerMock struct{}
+type emailerMock struct {
+	err error
+}
 
 // NewEmailer provides emailer instance for  the test.
 func NewEmailer() clients.Emailer {
 	return &emailerMock{}
 }
 
+// NewFailingEmailer provides emailer instance for the test, which fails to
+// send verification emails with the given error.
+func NewFailingEmailer(err error) clients.Emailer {
+	return &emailerMock{err: err}
+}
+
 func (e *emailerMock) SendPasswordReset([]string, string, string, string) error {
 	return nil
 }
+
+func (e *emailerMock) SendVerification([]string, string, string) error {
+	return e.err
+}
@@ -32,3 +32,7 @@ func (hm *hasherMock) Compare(plain, hashed string) error {
 
 	return nil
 }
+
+func (hm *hasherMock) NeedsRehash(_ string) bool {
+	return false
+}
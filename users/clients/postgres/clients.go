@@ -5,6 +5,7 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/internal/postgres"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
@@ -24,6 +25,16 @@ type Repository interface {
 	// Save persists the client account. A non-nil error is returned to indicate
 	// operation failure.
 	Save(ctx context.Context, client mfclients.Client) (mfclients.Client, error)
+
+	// UpdateRole updates the client role.
+	UpdateRole(ctx context.Context, client mfclients.Client) (mfclients.Client, error)
+
+	// RemoveUnverified removes the pending clients created before the given
+	// time and returns the number of removed clients.
+	RemoveUnverified(ctx context.Context, createdBefore time.Time) (uint64, error)
+
+	// RemovePending removes the client unless it has already been verified.
+	RemovePending(ctx context.Context, id string) error
 }
 
 // NewRepository instantiates a PostgreSQL
@@ -62,3 +73,62 @@ func (repo clientRepo) Save(ctx context.Context, c mfclients.Client) (mfclients.
 
 	return client, nil
 }
+
+func (repo clientRepo) UpdateRole(ctx context.Context, c mfclients.Client) (mfclients.Client, error) {
+	q := `UPDATE clients SET role = :role, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id
+        RETURNING id, name, tags, identity, metadata, COALESCE(owner_id, '') AS owner_id, status, created_at, updated_at, updated_by`
+	dbc, err := pgclients.ToDBClient(c)
+	if err != nil {
+		return mfclients.Client{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	row, err := repo.ClientRepository.DB.NamedQueryContext(ctx, q, dbc)
+	if err != nil {
+		return mfclients.Client{}, postgres.HandleError(err, errors.ErrUpdateEntity)
+	}
+
+	defer row.Close()
+	if ok := row.Next(); !ok {
+		return mfclients.Client{}, errors.Wrap(errors.ErrNotFound, row.Err())
+	}
+	dbc = pgclients.DBClient{}
+	if err := row.StructScan(&dbc); err != nil {
+		return mfclients.Client{}, err
+	}
+
+	return pgclients.ToClient(dbc)
+}
+
+func (repo clientRepo) RemoveUnverified(ctx context.Context, createdBefore time.Time) (uint64, error) {
+	q := `DELETE FROM clients WHERE status = :status AND created_at < :created_at`
+	dbc := pgclients.DBClient{
+		Status:    mfclients.PendingStatus,
+		CreatedAt: createdBefore,
+	}
+
+	res, err := repo.ClientRepository.DB.NamedExecContext(ctx, q, dbc)
+	if err != nil {
+		return 0, postgres.HandleError(err, errors.ErrRemoveEntity)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return uint64(n), nil
+}
+
+func (repo clientRepo) RemovePending(ctx context.Context, id string) error {
+	q := `DELETE FROM clients WHERE id = :id AND status = :status`
+	dbc := pgclients.DBClient{
+		ID:     id,
+		Status: mfclients.PendingStatus,
+	}
+
+	if _, err := repo.ClientRepository.DB.NamedExecContext(ctx, q, dbc); err != nil {
+		return postgres.HandleError(err, errors.ErrRemoveEntity)
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/internal/testsutil"
 	mfclients "github.com/mainflux/mainflux/pkg/clients"
@@ -173,3 +174,135 @@ func TestClientsSave(t *testing.T) {
 		}
 	}
 }
+
+func TestClientsUpdateRole(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := cpostgres.NewRepository(database)
+
+	client, err := repo.Save(context.Background(), mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: clientName,
+		Credentials: mfclients.Credentials{
+			Identity: "update-role@example.com",
+			Secret:   password,
+		},
+		Metadata: mfclients.Metadata{},
+		Status:   mfclients.EnabledStatus,
+		Role:     mfclients.UserRole,
+	})
+	assert.Nil(t, err, fmt.Sprintf("save client unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		client mfclients.Client
+		err    error
+	}{
+		{
+			desc:   "update role of an existing client",
+			client: mfclients.Client{ID: client.ID, Role: mfclients.AdminRole, UpdatedAt: time.Now()},
+			err:    nil,
+		},
+		{
+			desc:   "update role of a non-existing client",
+			client: mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Role: mfclients.AdminRole, UpdatedAt: time.Now()},
+			err:    errors.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		_, err := repo.UpdateRole(context.Background(), tc.client)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestClientsRemoveUnverified(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := cpostgres.NewRepository(database)
+
+	now := time.Now()
+	clients := []struct {
+		identity  string
+		status    mfclients.Status
+		createdAt time.Time
+	}{
+		{"expired-pending@example.com", mfclients.PendingStatus, now.Add(-2 * time.Hour)},
+		{"pending@example.com", mfclients.PendingStatus, now},
+		{"expired-enabled@example.com", mfclients.EnabledStatus, now.Add(-2 * time.Hour)},
+	}
+	for _, c := range clients {
+		_, err := repo.Save(context.Background(), mfclients.Client{
+			ID:   testsutil.GenerateUUID(t, idProvider),
+			Name: clientName,
+			Credentials: mfclients.Credentials{
+				Identity: c.identity,
+				Secret:   password,
+			},
+			Metadata:  mfclients.Metadata{},
+			Status:    c.status,
+			CreatedAt: c.createdAt,
+		})
+		assert.Nil(t, err, fmt.Sprintf("save client unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc    string
+		removed uint64
+	}{
+		{
+			desc:    "remove expired pending clients",
+			removed: 1,
+		},
+		{
+			desc:    "remove expired pending clients once they are removed",
+			removed: 0,
+		},
+	}
+	for _, tc := range cases {
+		removed, err := repo.RemoveUnverified(context.Background(), now.Add(-time.Hour))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.removed, removed, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.removed, removed))
+	}
+}
+
+func TestClientsRemovePending(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := cpostgres.NewRepository(database)
+
+	var ids []string
+	for _, status := range []mfclients.Status{mfclients.PendingStatus, mfclients.EnabledStatus} {
+		client, err := repo.Save(context.Background(), mfclients.Client{
+			ID:   testsutil.GenerateUUID(t, idProvider),
+			Name: clientName,
+			Credentials: mfclients.Credentials{
+				Identity: fmt.Sprintf("%s@example.com", status),
+				Secret:   password,
+			},
+			Metadata: mfclients.Metadata{},
+			Status:   status,
+		})
+		assert.Nil(t, err, fmt.Sprintf("save client unexpected error: %s", err))
+		ids = append(ids, client.ID)
+	}
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove pending client",
+			id:   ids[0],
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "remove verified client",
+			id:   ids[1],
+			err:  nil,
+		},
+	}
+	for _, tc := range cases {
+		err := repo.RemovePending(context.Background(), tc.id)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		_, err = repo.RetrieveByID(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// ErrVerificationToken indicates error in generating identity verification token.
+var ErrVerificationToken = errors.New("failed to generate identity verification token")
+
+// RegistrationConfig defines the clients self-registration. Unless the open
+// registration is enabled, only the authenticated clients can register new
+// ones. Self-registered clients are pending until they verify their
+// identity, and are removed if they don't verify it before the expiry.
+type RegistrationConfig struct {
+	Open            bool          `env:"OPEN"             envDefault:"false"`
+	VerificationURL string        `env:"VERIFICATION_URL" envDefault:"http://localhost/verify-email"`
+	EmailTemplate   string        `env:"EMAIL_TEMPLATE"   envDefault:"verification.tmpl"`
+	Expiry          time.Duration `env:"EXPIRY"           envDefault:"24h"`
+	CleanupInterval time.Duration `env:"CLEANUP_INTERVAL" envDefault:"1h"`
+}
+
+// RunCleanup periodically removes the unverified clients until the context is
+// canceled. Removal errors don't stop the loop, so they should be reported by
+// wrapping the service with LoggingMiddleware.
+func RunCleanup(ctx context.Context, svc ClientService, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			_, _ = svc.RemoveUnverifiedClients(ctx)
+		}
+	}
+}
@@ -5,7 +5,6 @@ package clients
 
 import (
 	"context"
-	"regexp"
 	"time"
 
 	"github.com/mainflux/mainflux"
@@ -14,6 +13,9 @@ import (
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users/clients/postgres"
 	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/lockout"
+	"github.com/mainflux/mainflux/users/mfa"
+	"github.com/mainflux/mainflux/users/passwords"
 	"github.com/mainflux/mainflux/users/policies"
 )
 
@@ -51,31 +53,46 @@ type Service interface {
 }
 
 type service struct {
-	clients    postgres.Repository
-	policies   policies.Repository
-	idProvider mainflux.IDProvider
-	hasher     Hasher
-	tokens     jwt.Repository
-	email      Emailer
-	passRegex  *regexp.Regexp
+	clients      postgres.Repository
+	policies     policies.Repository
+	idProvider   mainflux.IDProvider
+	hasher       Hasher
+	tokens       jwt.Repository
+	email        Emailer
+	passwords    passwords.Policy
+	mfa          mfa.Authenticator
+	lockout      lockout.Limiter
+	registration RegistrationConfig
 }
 
 // NewService returns a new Clients service implementation.
-func NewService(c postgres.Repository, p policies.Repository, t jwt.Repository, e Emailer, h Hasher, idp mainflux.IDProvider, pr *regexp.Regexp) Service {
+func NewService(c postgres.Repository, p policies.Repository, t jwt.Repository, e Emailer, h Hasher, idp mainflux.IDProvider, pp passwords.Policy, m mfa.Authenticator, l lockout.Limiter, rc RegistrationConfig) Service {
 	return service{
-		clients:    c,
-		policies:   p,
-		hasher:     h,
-		tokens:     t,
-		email:      e,
-		idProvider: idp,
-		passRegex:  pr,
+		clients:      c,
+		policies:     p,
+		hasher:       h,
+		tokens:       t,
+		email:        e,
+		idProvider:   idp,
+		passwords:    pp,
+		mfa:          m,
+		lockout:      l,
+		registration: rc,
 	}
 }
 
 func (svc service) RegisterClient(ctx context.Context, token string, cli mfclients.Client) (mfclients.Client, error) {
-	// We don't check the error currently since we can register client with empty token
-	ownerID, _ := svc.Identify(ctx, token)
+	var ownerID string
+	switch {
+	case token != "":
+		id, err := svc.Identify(ctx, token)
+		if err != nil {
+			return mfclients.Client{}, err
+		}
+		ownerID = id
+	case !svc.registration.Open:
+		return mfclients.Client{}, errors.ErrAuthentication
+	}
 
 	clientID, err := svc.idProvider.ID()
 	if err != nil {
@@ -87,6 +104,10 @@ func (svc service) RegisterClient(ctx context.Context, token string, cli mfclien
 	if cli.Credentials.Secret == "" {
 		return mfclients.Client{}, apiutil.ErrMissingSecret
 	}
+	identity := mfclients.Client{Credentials: mfclients.Credentials{Identity: cli.Credentials.Identity}}
+	if err := svc.validateSecret(ctx, identity, cli.Credentials.Secret); err != nil {
+		return mfclients.Client{}, err
+	}
 	hash, err := svc.hasher.Hash(cli.Credentials.Secret)
 	if err != nil {
 		return mfclients.Client{}, errors.Wrap(errors.ErrMalformedEntity, err)
@@ -98,6 +119,13 @@ func (svc service) RegisterClient(ctx context.Context, token string, cli mfclien
 	if cli.Role != mfclients.UserRole && cli.Role != mfclients.AdminRole {
 		return mfclients.Client{}, apiutil.ErrInvalidRole
 	}
+	// Self-registered clients are regular users, which can't log in
+	// until they verify their identity.
+	if ownerID == "" {
+		cli.Owner = ""
+		cli.Role = mfclients.UserRole
+		cli.Status = mfclients.PendingStatus
+	}
 	cli.ID = clientID
 	cli.CreatedAt = time.Now()
 
@@ -105,25 +133,114 @@ func (svc service) RegisterClient(ctx context.Context, token string, cli mfclien
 	if err != nil {
 		return mfclients.Client{}, err
 	}
+	if client.Status == mfclients.PendingStatus {
+		// The client can't verify its identity without the e-mail, so it's
+		// removed to let the registration be retried with the same identity.
+		if err := svc.sendVerification(ctx, client); err != nil {
+			if rerr := svc.clients.RemovePending(ctx, client.ID); rerr != nil {
+				return mfclients.Client{}, errors.Wrap(err, rerr)
+			}
+			return mfclients.Client{}, err
+		}
+	}
 
 	return client, nil
 }
 
+func (svc service) sendVerification(ctx context.Context, client mfclients.Client) error {
+	claims := jwt.Claims{
+		ClientID: client.ID,
+		Email:    client.Credentials.Identity,
+	}
+	token, err := svc.tokens.Verification(ctx, claims, svc.registration.Expiry)
+	if err != nil {
+		return errors.Wrap(ErrVerificationToken, err)
+	}
+
+	return svc.email.SendVerification([]string{client.Credentials.Identity}, client.Name, token)
+}
+
+func (svc service) VerifyClient(ctx context.Context, token string) (mfclients.Client, error) {
+	claims, err := svc.tokens.Parse(ctx, token)
+	if err != nil {
+		return mfclients.Client{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	if claims.Type != jwt.VerificationToken {
+		return mfclients.Client{}, errors.ErrAuthentication
+	}
+	dbClient, err := svc.clients.RetrieveByID(ctx, claims.ClientID)
+	if err != nil {
+		return mfclients.Client{}, err
+	}
+	if dbClient.Status != mfclients.PendingStatus {
+		return mfclients.Client{}, errors.Wrap(errors.ErrConflict, mfclients.ErrStatusAlreadyAssigned)
+	}
+	client := mfclients.Client{
+		ID:        dbClient.ID,
+		Status:    mfclients.EnabledStatus,
+		UpdatedAt: time.Now(),
+		UpdatedBy: dbClient.ID,
+	}
+
+	return svc.clients.ChangeStatus(ctx, client)
+}
+
+func (svc service) RemoveUnverifiedClients(ctx context.Context) (uint64, error) {
+	return svc.clients.RemoveUnverified(ctx, time.Now().Add(-svc.registration.Expiry))
+}
+
 func (svc service) IssueToken(ctx context.Context, identity, secret string) (jwt.Token, error) {
+	ip := lockout.SourceIP(ctx)
+	if err := svc.lockout.Allow(ctx, identity, ip); err != nil {
+		return jwt.Token{}, errors.Wrap(errors.ErrTooManyRequests, err)
+	}
 	dbUser, err := svc.clients.RetrieveByIdentity(ctx, identity)
 	if err != nil {
+		// Unknown identities are counted as well, so that they can't be
+		// told apart from the existing ones by the lockout.
+		if errors.Contains(err, errors.ErrNotFound) {
+			return jwt.Token{}, svc.failLogin(ctx, identity, ip, err)
+		}
 		return jwt.Token{}, err
 	}
 	if err := svc.hasher.Compare(secret, dbUser.Credentials.Secret); err != nil {
-		return jwt.Token{}, errors.Wrap(errors.ErrLogin, err)
+		return jwt.Token{}, svc.failLogin(ctx, identity, ip, errors.Wrap(errors.ErrLogin, err))
+	}
+	svc.rehash(ctx, dbUser, secret)
+	if err := svc.lockout.Succeed(ctx, identity); err != nil {
+		return jwt.Token{}, err
 	}
 
-	claims := jwt.Claims{
-		ClientID: dbUser.ID,
-		Email:    dbUser.Credentials.Identity,
+	return mfa.Login(ctx, svc.mfa, svc.tokens, dbUser)
+}
+
+// rehash replaces the outdated secret hash, so that the hashes are migrated
+// to the configured algorithm without forcing secret resets. A failed
+// re-hash doesn't fail the login, since it's retried on the next one.
+func (svc service) rehash(ctx context.Context, client mfclients.Client, secret string) {
+	if !svc.hasher.NeedsRehash(client.Credentials.Secret) {
+		return
+	}
+	hash, err := svc.hasher.Hash(secret)
+	if err != nil {
+		return
 	}
+	client.Credentials.Secret = hash
+	client.UpdatedAt = time.Now()
+	client.UpdatedBy = client.ID
+	_, _ = svc.clients.UpdateSecret(ctx, client)
+}
 
-	return svc.tokens.Issue(ctx, claims)
+func (svc service) failLogin(ctx context.Context, identity, ip string, err error) error {
+	locked, lerr := svc.lockout.Fail(ctx, identity, ip)
+	if lerr != nil {
+		return lerr
+	}
+	if locked {
+		return errors.Wrap(errors.ErrTooManyRequests, lockout.ErrLockedOut)
+	}
+
+	return err
 }
 
 func (svc service) RefreshToken(ctx context.Context, refreshToken string) (jwt.Token, error) {
@@ -134,11 +251,42 @@ func (svc service) RefreshToken(ctx context.Context, refreshToken string) (jwt.T
 	if claims.Type != jwt.RefreshToken {
 		return jwt.Token{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
-	if _, err := svc.clients.RetrieveByID(ctx, claims.ClientID); err != nil {
+	dbClient, err := svc.clients.RetrieveByID(ctx, claims.ClientID)
+	if err != nil {
 		return jwt.Token{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
+	if dbClient.Status != mfclients.EnabledStatus {
+		return jwt.Token{}, errors.ErrAuthentication
+	}
+
+	token, err := svc.tokens.Issue(ctx, claims)
+	if err != nil {
+		return jwt.Token{}, err
+	}
+	// Refresh tokens are rotated, so the used one can't be replayed.
+	if err := svc.tokens.Revoke(ctx, claims); err != nil {
+		return jwt.Token{}, err
+	}
+
+	return token, nil
+}
+
+func (svc service) RevokeToken(ctx context.Context, token string) error {
+	claims, err := svc.tokens.Parse(ctx, token)
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return svc.tokens.Revoke(ctx, claims)
+}
+
+func (svc service) RevokeTokens(ctx context.Context, token string) error {
+	id, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
 
-	return svc.tokens.Issue(ctx, claims)
+	return svc.tokens.RevokeAll(ctx, id)
 }
 
 func (svc service) ViewClient(ctx context.Context, token string, id string) (mfclients.Client, error) {
@@ -293,18 +441,22 @@ func (svc service) GenerateResetToken(ctx context.Context, email, host string) e
 		ClientID: client.ID,
 		Email:    client.Credentials.Identity,
 	}
-	t, err := svc.tokens.Issue(ctx, claims)
+	t, err := svc.tokens.Reset(ctx, claims)
 	if err != nil {
 		return errors.Wrap(ErrRecoveryToken, err)
 	}
-	return svc.SendPasswordReset(ctx, host, email, client.Name, t.AccessToken)
+	return svc.SendPasswordReset(ctx, host, email, client.Name, t)
 }
 
 func (svc service) ResetSecret(ctx context.Context, resetToken, secret string) error {
-	id, err := svc.Identify(ctx, resetToken)
+	claims, err := svc.tokens.Parse(ctx, resetToken)
 	if err != nil {
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
+	if claims.Type != jwt.ResetToken {
+		return errors.ErrAuthentication
+	}
+	id := claims.ClientID
 	c, err := svc.clients.RetrieveByID(ctx, id)
 	if err != nil {
 		return err
@@ -312,9 +464,10 @@ func (svc service) ResetSecret(ctx context.Context, resetToken, secret string) e
 	if c.Credentials.Identity == "" {
 		return errors.ErrNotFound
 	}
-	if !svc.passRegex.MatchString(secret) {
-		return ErrPasswordFormat
+	if err := svc.validateSecret(ctx, c, secret); err != nil {
+		return err
 	}
+	oldSecret := c.Credentials.Secret
 	secret, err = svc.hasher.Hash(secret)
 	if err != nil {
 		return err
@@ -330,7 +483,10 @@ func (svc service) ResetSecret(ctx context.Context, resetToken, secret string) e
 	if _, err := svc.clients.UpdateSecret(ctx, c); err != nil {
 		return err
 	}
-	return nil
+	if err := svc.passwords.Remember(ctx, id, oldSecret); err != nil {
+		return err
+	}
+	return svc.tokens.RevokeAll(ctx, id)
 }
 
 func (svc service) UpdateClientSecret(ctx context.Context, token, oldSecret, newSecret string) (mfclients.Client, error) {
@@ -338,9 +494,6 @@ func (svc service) UpdateClientSecret(ctx context.Context, token, oldSecret, new
 	if err != nil {
 		return mfclients.Client{}, err
 	}
-	if !svc.passRegex.MatchString(newSecret) {
-		return mfclients.Client{}, ErrPasswordFormat
-	}
 	dbClient, err := svc.clients.RetrieveByID(ctx, id)
 	if err != nil {
 		return mfclients.Client{}, err
@@ -348,6 +501,10 @@ func (svc service) UpdateClientSecret(ctx context.Context, token, oldSecret, new
 	if _, err := svc.IssueToken(ctx, dbClient.Credentials.Identity, oldSecret); err != nil {
 		return mfclients.Client{}, err
 	}
+	if err := svc.validateSecret(ctx, dbClient, newSecret); err != nil {
+		return mfclients.Client{}, err
+	}
+	oldHash := dbClient.Credentials.Secret
 	newSecret, err = svc.hasher.Hash(newSecret)
 	if err != nil {
 		return mfclients.Client{}, err
@@ -356,7 +513,32 @@ func (svc service) UpdateClientSecret(ctx context.Context, token, oldSecret, new
 	dbClient.UpdatedAt = time.Now()
 	dbClient.UpdatedBy = id
 
-	return svc.clients.UpdateSecret(ctx, dbClient)
+	dbClient, err = svc.clients.UpdateSecret(ctx, dbClient)
+	if err != nil {
+		return mfclients.Client{}, err
+	}
+	if err := svc.passwords.Remember(ctx, id, oldHash); err != nil {
+		return mfclients.Client{}, err
+	}
+	// Tokens issued with the old secret must not outlive it.
+	if err := svc.tokens.RevokeAll(ctx, id); err != nil {
+		return mfclients.Client{}, err
+	}
+
+	return dbClient, nil
+}
+
+// validateSecret reports the password policy violations as malformed entity.
+func (svc service) validateSecret(ctx context.Context, client mfclients.Client, secret string) error {
+	err := svc.passwords.Validate(ctx, client, secret)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Contains(err, errors.ErrViewEntity):
+		return err
+	default:
+		return errors.Wrap(errors.ErrMalformedEntity, errors.Wrap(ErrPasswordFormat, err))
+	}
 }
 
 func (svc service) SendPasswordReset(_ context.Context, host, email, user, token string) error {
@@ -407,10 +589,29 @@ func (svc service) DisableClient(ctx context.Context, token, id string) (mfclien
 	if err != nil {
 		return mfclients.Client{}, errors.Wrap(mfclients.ErrDisableClient, err)
 	}
+	if err := svc.tokens.RevokeAll(ctx, id); err != nil {
+		return mfclients.Client{}, errors.Wrap(mfclients.ErrDisableClient, err)
+	}
 
 	return client, nil
 }
 
+func (svc service) UnlockClient(ctx context.Context, token, id string) error {
+	adminID, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.policies.CheckAdmin(ctx, adminID); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+	client, err := svc.clients.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return svc.lockout.Unlock(ctx, client.Credentials.Identity)
+}
+
 func (svc service) changeClientStatus(ctx context.Context, token string, client mfclients.Client) (mfclients.Client, error) {
 	id, err := svc.Identify(ctx, token)
 	if err != nil {
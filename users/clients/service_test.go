@@ -19,6 +19,13 @@ import (
 	"github.com/mainflux/mainflux/users/clients/mocks"
 	"github.com/mainflux/mainflux/users/hasher"
 	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwmocks "github.com/mainflux/mainflux/users/passwords/mocks"
 	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -49,9 +56,9 @@ var (
 func TestRegisterClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	cases := []struct {
 		desc   string
@@ -233,6 +240,17 @@ func TestRegisterClient(t *testing.T) {
 			err:   apiutil.ErrInvalidStatus,
 			token: testsutil.GenerateValidToken(t, testsutil.GenerateUUID(t, idProvider), svc, cRepo, phasher),
 		},
+		{
+			desc: "register a new client without token",
+			client: mfclients.Client{
+				Credentials: mfclients.Credentials{
+					Identity: "newclientwithouttoken@example.com",
+					Secret:   secret,
+				},
+			},
+			err:   errors.ErrAuthentication,
+			token: "",
+		},
 	}
 
 	for _, tc := range cases {
@@ -257,12 +275,202 @@ func TestRegisterClient(t *testing.T) {
 	}
 }
 
+func TestSelfRegisterClient(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	rc := clients.RegistrationConfig{Open: true, Expiry: time.Hour}
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), rc)
+
+	cases := []struct {
+		desc   string
+		client mfclients.Client
+		token  string
+		status mfclients.Status
+		role   mfclients.Role
+		owner  string
+	}{
+		{
+			desc: "register a new client without token",
+			client: mfclients.Client{
+				Credentials: mfclients.Credentials{
+					Identity: "selfregistered@example.com",
+					Secret:   secret,
+				},
+			},
+			status: mfclients.PendingStatus,
+			role:   mfclients.UserRole,
+		},
+		{
+			desc: "register a new admin client with owner without token",
+			client: mfclients.Client{
+				Owner: testsutil.GenerateUUID(t, idProvider),
+				Credentials: mfclients.Credentials{
+					Identity: "selfregisteredadmin@example.com",
+					Secret:   secret,
+				},
+				Role: mfclients.AdminRole,
+			},
+			status: mfclients.PendingStatus,
+			role:   mfclients.UserRole,
+		},
+		{
+			desc: "register a new client with token",
+			client: mfclients.Client{
+				Owner: client.ID,
+				Credentials: mfclients.Credentials{
+					Identity: "registered@example.com",
+					Secret:   secret,
+				},
+			},
+			token:  testsutil.GenerateValidToken(t, testsutil.GenerateUUID(t, idProvider), svc, cRepo, phasher),
+			status: mfclients.EnabledStatus,
+			role:   mfclients.UserRole,
+			owner:  client.ID,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("Save", context.Background(), mock.Anything).Return(&mfclients.Client{}, nil)
+		rClient, err := svc.RegisterClient(context.Background(), tc.token, tc.client)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, rClient.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, rClient.Status))
+		assert.Equal(t, tc.role, rClient.Role, fmt.Sprintf("%s: expected role %s got %s\n", tc.desc, tc.role, rClient.Role))
+		assert.Equal(t, tc.owner, rClient.Owner, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, tc.owner, rClient.Owner))
+		repoCall.Unset()
+	}
+}
+
+func TestSelfRegisterClientEmailFailure(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	errSend := errors.New("failed to send e-mail")
+	e := mocks.NewFailingEmailer(errSend)
+	rc := clients.RegistrationConfig{Open: true, Expiry: time.Hour}
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), rc)
+
+	registered := mfclients.Client{
+		Credentials: mfclients.Credentials{
+			Identity: "selfregistered@example.com",
+			Secret:   secret,
+		},
+	}
+
+	var saved string
+	repoCall := cRepo.On("Save", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(mfclients.Client).ID
+	}).Return(&mfclients.Client{}, nil)
+	repoCall1 := cRepo.On("RemovePending", context.Background(), mock.Anything).Return(nil)
+	_, err := svc.RegisterClient(context.Background(), "", registered)
+	assert.True(t, errors.Contains(err, errSend), fmt.Sprintf("register client failing to send e-mail: expected %s got %s\n", errSend, err))
+	ok := repoCall1.Parent.AssertCalled(t, "RemovePending", context.Background(), saved)
+	assert.True(t, ok, "RemovePending was not called on failing to send verification e-mail")
+	repoCall.Unset()
+	repoCall1.Unset()
+}
+
+func TestVerifyClient(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	rc := clients.RegistrationConfig{Open: true, Expiry: time.Hour}
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), rc)
+
+	pending := client
+	pending.Status = mfclients.PendingStatus
+	enabled := client
+	enabled.Status = mfclients.EnabledStatus
+	claims := jwt.Claims{ClientID: client.ID, Email: client.Credentials.Identity}
+	verificationToken, err := tokenizer.Verification(context.Background(), claims, rc.Expiry)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	expiredToken, err := tokenizer.Verification(context.Background(), claims, -time.Minute)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	accessToken, err := tokenizer.Issue(context.Background(), claims)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		client   mfclients.Client
+		response mfclients.Client
+		err      error
+	}{
+		{
+			desc:     "verify pending client",
+			token:    verificationToken,
+			client:   pending,
+			response: enabled,
+			err:      nil,
+		},
+		{
+			desc:   "verify already verified client",
+			token:  verificationToken,
+			client: enabled,
+			err:    errors.ErrConflict,
+		},
+		{
+			desc:   "verify client with expired token",
+			token:  expiredToken,
+			client: pending,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "verify client with access token",
+			token:  accessToken.AccessToken,
+			client: pending,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "verify client with invalid token",
+			token:  inValidToken,
+			client: pending,
+			err:    errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByID", context.Background(), client.ID).Return(tc.client, nil)
+		repoCall1 := cRepo.On("ChangeStatus", context.Background(), mock.Anything).Return(tc.response, nil)
+		rClient, err := svc.VerifyClient(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.response, rClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, rClient))
+		if tc.err == nil {
+			ok := repoCall1.Parent.AssertCalled(t, "ChangeStatus", context.Background(), mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("ChangeStatus was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestRemoveUnverifiedClients(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	rc := clients.RegistrationConfig{Open: true, Expiry: time.Hour}
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), rc)
+
+	expired := mock.MatchedBy(func(createdBefore time.Time) bool {
+		return time.Since(createdBefore) >= rc.Expiry && time.Since(createdBefore) < rc.Expiry+withinDuration
+	})
+	repoCall := cRepo.On("RemoveUnverified", context.Background(), expired).Return(uint64(2), nil)
+	removed, err := svc.RemoveUnverifiedClients(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("remove unverified clients: unexpected error: %s", err))
+	assert.Equal(t, uint64(2), removed, fmt.Sprintf("remove unverified clients: expected 2 got %d\n", removed))
+	ok := repoCall.Parent.AssertCalled(t, "RemoveUnverified", context.Background(), expired)
+	assert.True(t, ok, "RemoveUnverified was not called on remove unverified clients")
+}
+
 func TestViewClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	cases := []struct {
 		desc     string
@@ -324,9 +532,9 @@ func TestViewClient(t *testing.T) {
 func TestListClients(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	nClients := uint64(200)
 	aClients := []mfclients.Client{}
@@ -610,9 +818,9 @@ func TestListClients(t *testing.T) {
 func TestUpdateClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	client1 := client
 	client2 := client
@@ -686,9 +894,9 @@ func TestUpdateClient(t *testing.T) {
 func TestUpdateClientTags(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	client.Tags = []string{"updated"}
 
@@ -745,9 +953,9 @@ func TestUpdateClientTags(t *testing.T) {
 func TestUpdateClientIdentity(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	client2 := client
 	client2.Credentials.Identity = "updated@example.com"
@@ -806,9 +1014,9 @@ func TestUpdateClientIdentity(t *testing.T) {
 func TestUpdateClientOwner(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	client.Owner = "newowner@mail.com"
 
@@ -865,9 +1073,10 @@ func TestUpdateClientOwner(t *testing.T) {
 func TestUpdateClientSecret(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	revocations := jmocks.NewRevocations()
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, revocations)
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	rClient := client
 	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
@@ -885,14 +1094,6 @@ func TestUpdateClientSecret(t *testing.T) {
 		response  mfclients.Client
 		err       error
 	}{
-		{
-			desc:      "update client secret with valid token",
-			oldSecret: client.Credentials.Secret,
-			newSecret: "newSecret",
-			token:     token.AccessToken,
-			response:  rClient,
-			err:       nil,
-		},
 		{
 			desc:      "update client secret with invalid token",
 			oldSecret: client.Credentials.Secret,
@@ -909,6 +1110,14 @@ func TestUpdateClientSecret(t *testing.T) {
 			response:  mfclients.Client{},
 			err:       apiutil.ErrInvalidSecret,
 		},
+		{
+			desc:      "update client secret with valid token",
+			oldSecret: client.Credentials.Secret,
+			newSecret: "newSecret",
+			token:     token.AccessToken,
+			response:  rClient,
+			err:       nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -925,6 +1134,9 @@ func TestUpdateClientSecret(t *testing.T) {
 			assert.True(t, ok, fmt.Sprintf("RetrieveByIdentity was not called on %s", tc.desc))
 			ok = repoCall2.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
 			assert.True(t, ok, fmt.Sprintf("UpdateSecret was not called on %s", tc.desc))
+			before, err := revocations.RevokedBefore(context.Background(), client.ID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.False(t, before.IsZero(), fmt.Sprintf("%s: expected client tokens to be revoked", tc.desc))
 		}
 		repoCall.Unset()
 		repoCall1.Unset()
@@ -932,12 +1144,151 @@ func TestUpdateClientSecret(t *testing.T) {
 	}
 }
 
+func TestResetSecret(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	claims := jwt.Claims{ClientID: client.ID, Email: client.Credentials.Identity}
+	resetToken, err := tokenizer.Reset(context.Background(), claims)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	accessToken, err := tokenizer.Issue(context.Background(), claims)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	verificationToken, err := tokenizer.Verification(context.Background(), claims, time.Hour)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "reset secret with access token",
+			token: accessToken.AccessToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "reset secret with verification token",
+			token: verificationToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "reset secret with invalid token",
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "reset secret with reset token",
+			token: resetToken,
+			err:   nil,
+		},
+		{
+			desc:  "reset secret with used reset token",
+			token: resetToken,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByID", context.Background(), client.ID).Return(rClient, nil)
+		repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(rClient, nil)
+		err := svc.ResetSecret(context.Background(), tc.token, "newSecret")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			ok := repoCall1.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("UpdateSecret was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestUpdateClientSecretWithPolicy(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	pwRepo := pwmocks.NewRepository()
+	pc := passwords.Config{
+		MinLength:      10,
+		RequireDigit:   true,
+		RejectIdentity: true,
+		History:        2,
+	}
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(pc, passRegex, pwRepo, phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+
+	oldSecret := "strongsecret1"
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(oldSecret)
+
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, nil)
+	token, err := svc.IssueToken(context.Background(), client.Credentials.Identity, oldSecret)
+	require.Nil(t, err, fmt.Sprintf("Issue token expected nil got %s\n", err))
+	repoCall.Unset()
+
+	cases := []struct {
+		desc      string
+		newSecret string
+		err       error
+	}{
+		{
+			desc:      "update client secret with short secret",
+			newSecret: "short1",
+			err:       passwords.ErrTooShort,
+		},
+		{
+			desc:      "update client secret with secret without digits",
+			newSecret: "longsecretwithoutdigits",
+			err:       passwords.ErrCharacterClasses,
+		},
+		{
+			desc:      "update client secret with secret containing identity",
+			newSecret: "clientidentity123",
+			err:       passwords.ErrContainsIdentity,
+		},
+		{
+			desc:      "update client secret with the current secret",
+			newSecret: oldSecret,
+			err:       passwords.ErrReused,
+		},
+		// Updating the secret revokes the tokens, so it goes last.
+		{
+			desc:      "update client secret with valid secret",
+			newSecret: "newstrongsecret1",
+			err:       nil,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByID", context.Background(), client.ID).Return(rClient, nil)
+		repoCall1 := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, nil)
+		repoCall2 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(rClient, nil)
+		_, err := svc.UpdateClientSecret(context.Background(), token.AccessToken, oldSecret, tc.newSecret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			assert.True(t, errors.Contains(err, clients.ErrPasswordFormat), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, clients.ErrPasswordFormat, err))
+			repoCall2.Parent.AssertNotCalled(t, "UpdateSecret", context.Background(), mock.Anything)
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+
+	history, err := pwRepo.Retrieve(context.Background(), client.ID, pc.History)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, []string{rClient.Credentials.Secret}, history, fmt.Sprintf("expected the replaced secret in history got %v\n", history))
+}
+
 func TestEnableClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	enabledClient1 := mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Credentials: mfclients.Credentials{Identity: "client1@example.com", Secret: "password"}, Status: mfclients.EnabledStatus}
 	disabledClient1 := mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Credentials: mfclients.Credentials{Identity: "client3@example.com", Secret: "password"}, Status: mfclients.DisabledStatus}
@@ -1065,9 +1416,9 @@ func TestEnableClient(t *testing.T) {
 func TestDisableClient(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	enabledClient1 := mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Credentials: mfclients.Credentials{Identity: "client1@example.com", Secret: "password"}, Status: mfclients.EnabledStatus}
 	disabledClient1 := mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Credentials: mfclients.Credentials{Identity: "client3@example.com", Secret: "password"}, Status: mfclients.DisabledStatus}
@@ -1195,9 +1546,9 @@ func TestDisableClient(t *testing.T) {
 func TestListMembers(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	nClients := uint64(10)
 	aClients := []mfclients.Client{}
@@ -1338,9 +1689,9 @@ func TestListMembers(t *testing.T) {
 func TestIssueToken(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	rClient := client
 	rClient2 := client
@@ -1389,12 +1740,127 @@ func TestIssueToken(t *testing.T) {
 	}
 }
 
+func TestIssueTokenRehash(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	argon2id := hasher.NewArgon2id(1, 1024, 1)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, argon2id, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), argon2id), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+
+	bcryptClient := client
+	bcryptClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	argon2idClient := client
+	argon2idClient.Credentials.Secret, _ = argon2id.Hash(client.Credentials.Secret)
+
+	cases := []struct {
+		desc      string
+		rClient   mfclients.Client
+		updateErr error
+		rehashed  bool
+	}{
+		{
+			desc:     "issue token for a client with bcrypt hash",
+			rClient:  bcryptClient,
+			rehashed: true,
+		},
+		{
+			desc:      "issue token for a client with bcrypt hash failing to re-hash",
+			rClient:   bcryptClient,
+			updateErr: errors.ErrUpdateEntity,
+			rehashed:  true,
+		},
+		{
+			desc:     "issue token for a client with argon2id hash",
+			rClient:  argon2idClient,
+			rehashed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		var updated mfclients.Client
+		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(tc.rClient, nil)
+		repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+			updated = args.Get(1).(mfclients.Client)
+		}).Return(tc.rClient, tc.updateErr)
+		token, err := svc.IssueToken(context.Background(), client.Credentials.Identity, client.Credentials.Secret)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s\n", tc.desc, err))
+		assert.NotEmpty(t, token.AccessToken, fmt.Sprintf("%s: expected access token not to be empty\n", tc.desc))
+		switch tc.rehashed {
+		case true:
+			assert.False(t, argon2id.NeedsRehash(updated.Credentials.Secret), fmt.Sprintf("%s: expected secret to be re-hashed using argon2id\n", tc.desc))
+			assert.Nil(t, argon2id.Compare(client.Credentials.Secret, updated.Credentials.Secret), fmt.Sprintf("%s: expected re-hashed secret to match\n", tc.desc))
+		default:
+			assert.Empty(t, updated.Credentials.Secret, fmt.Sprintf("%s: expected secret not to be re-hashed\n", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestIssueTokenWithMFA(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	mRepo := mmocks.NewRepository()
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mRepo, true), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+
+	enrolled := client
+	enrolled.ID = testsutil.GenerateUUID(t, idProvider)
+	enrolled.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	err := mRepo.Save(context.Background(), mfa.Enrollment{ClientID: enrolled.ID, Secret: "secret", Confirmed: true})
+	require.Nil(t, err, fmt.Sprintf("save enrollment unexpected error: %s", err))
+	pending := enrolled
+	pending.ID = testsutil.GenerateUUID(t, idProvider)
+	err = mRepo.Save(context.Background(), mfa.Enrollment{ClientID: pending.ID, Secret: "secret"})
+	require.Nil(t, err, fmt.Sprintf("save enrollment unexpected error: %s", err))
+	admin := pending
+	admin.ID = testsutil.GenerateUUID(t, idProvider)
+	admin.Role = mfclients.AdminRole
+
+	cases := []struct {
+		desc       string
+		rClient    mfclients.Client
+		accessType string
+	}{
+		{
+			desc:       "issue token for a client with confirmed MFA",
+			rClient:    enrolled,
+			accessType: jwt.MFAType,
+		},
+		{
+			desc:       "issue token for a client with pending MFA",
+			rClient:    pending,
+			accessType: "Bearer",
+		},
+		{
+			desc:       "issue token for an admin with enforced MFA",
+			rClient:    admin,
+			accessType: jwt.MFAType,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(tc.rClient, nil)
+		token, err := svc.IssueToken(context.Background(), client.Credentials.Identity, client.Credentials.Secret)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.accessType, token.AccessType, fmt.Sprintf("%s: expected access type %s got %s\n", tc.desc, tc.accessType, token.AccessType))
+		if tc.accessType == jwt.MFAType {
+			assert.Empty(t, token.RefreshToken, fmt.Sprintf("%s: expected empty refresh token\n", tc.desc))
+			_, err := svc.Identify(context.Background(), token.AccessToken)
+			assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("%s: expected challenge token to be rejected got %s\n", tc.desc, err))
+		}
+		repoCall.Unset()
+	}
+}
+
 func TestRefreshToken(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 
 	rClient := client
 	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
@@ -1440,6 +1906,12 @@ func TestRefreshToken(t *testing.T) {
 			client: client,
 			err:    errors.ErrAuthentication,
 		},
+		{
+			desc:   "refresh token with already used refresh token",
+			token:  token.RefreshToken,
+			client: client,
+			err:    jwt.ErrRevoked,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1459,3 +1931,295 @@ func TestRefreshToken(t *testing.T) {
 		repoCall2.Unset()
 	}
 }
+
+func TestRevokeToken(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, nil)
+	token, err := svc.IssueToken(context.Background(), client.Credentials.Identity, client.Credentials.Secret)
+	assert.Nil(t, err, fmt.Sprintf("Issue token expected nil got %s\n", err))
+	other, err := svc.IssueToken(context.Background(), client.Credentials.Identity, client.Credentials.Secret)
+	assert.Nil(t, err, fmt.Sprintf("Issue token expected nil got %s\n", err))
+	repoCall.Unset()
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "revoke valid access token",
+			token: token.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "revoke already revoked access token",
+			token: token.AccessToken,
+			err:   jwt.ErrRevoked,
+		},
+		{
+			desc:  "revoke refresh token issued with revoked access token",
+			token: token.RefreshToken,
+			err:   jwt.ErrRevoked,
+		},
+		{
+			desc:  "revoke valid refresh token",
+			token: other.RefreshToken,
+			err:   nil,
+		},
+		{
+			desc:  "revoke invalid token",
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeToken(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Identify(context.Background(), other.AccessToken)
+	assert.True(t, errors.Contains(err, jwt.ErrRevoked), fmt.Sprintf("identify with revoked token: expected %s got %s\n", jwt.ErrRevoked, err))
+}
+
+func TestRevokeTokens(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	revocations := jmocks.NewRevocations()
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, revocations)
+	e := mocks.NewEmailer()
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, nil)
+	token, err := svc.IssueToken(context.Background(), client.Credentials.Identity, client.Credentials.Secret)
+	assert.Nil(t, err, fmt.Sprintf("Issue token expected nil got %s\n", err))
+	repoCall.Unset()
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "revoke all tokens with invalid token",
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "revoke all tokens with refresh token",
+			token: token.RefreshToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "revoke all tokens with valid token",
+			token: token.AccessToken,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeTokens(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			before, err := revocations.RevokedBefore(context.Background(), client.ID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.False(t, before.IsZero(), fmt.Sprintf("%s: expected client tokens to be revoked", tc.desc))
+		}
+	}
+}
+
+func TestIssueTokenWithLockout(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	lc := lockout.Config{MaxAttempts: 3, IPMaxAttempts: 6, Window: time.Minute, Duration: time.Minute}
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lc), clients.RegistrationConfig{})
+
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	other := client
+	other.Credentials.Identity = "otheridentity"
+	rOther := other
+	rOther.Credentials.Secret = rClient.Credentials.Secret
+	ctx := lockout.WithSourceIP(context.Background(), "192.168.0.1")
+
+	cases := []struct {
+		desc   string
+		ctx    context.Context
+		client mfclients.Client
+		secret string
+		err    error
+	}{
+		{
+			desc:   "issue token with wrong secret",
+			ctx:    ctx,
+			client: client,
+			secret: "wrongsecret",
+			err:    errors.ErrLogin,
+		},
+		{
+			desc:   "issue token after a successful login",
+			ctx:    ctx,
+			client: client,
+			secret: secret,
+		},
+		{
+			desc:   "issue token with wrong secret after a successful login",
+			ctx:    ctx,
+			client: client,
+			secret: "wrongsecret",
+			err:    errors.ErrLogin,
+		},
+		{
+			desc:   "issue token with wrong secret for the second time",
+			ctx:    ctx,
+			client: client,
+			secret: "wrongsecret",
+			err:    errors.ErrLogin,
+		},
+		{
+			desc:   "issue token with wrong secret locking the identity out",
+			ctx:    ctx,
+			client: client,
+			secret: "wrongsecret",
+			err:    lockout.ErrLockedOut,
+		},
+		{
+			desc:   "issue token with valid secret for the locked out identity",
+			ctx:    context.Background(),
+			client: client,
+			secret: secret,
+			err:    lockout.ErrLocked,
+		},
+		{
+			desc:   "issue token with wrong secret for the other identity",
+			ctx:    ctx,
+			client: other,
+			secret: "wrongsecret",
+			err:    errors.ErrLogin,
+		},
+		{
+			desc:   "issue token with wrong secret locking the source IP out",
+			ctx:    ctx,
+			client: other,
+			secret: "wrongsecret",
+			err:    lockout.ErrLockedOut,
+		},
+		{
+			desc:   "issue token with valid secret from the locked out source IP",
+			ctx:    ctx,
+			client: other,
+			secret: secret,
+			err:    lockout.ErrLocked,
+		},
+		{
+			desc:   "issue token with valid secret from the other source IP",
+			ctx:    lockout.WithSourceIP(context.Background(), "192.168.0.2"),
+			client: other,
+			secret: secret,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByIdentity", tc.ctx, client.Credentials.Identity).Return(rClient, nil)
+		repoCall1 := cRepo.On("RetrieveByIdentity", tc.ctx, other.Credentials.Identity).Return(rOther, nil)
+		_, err := svc.IssueToken(tc.ctx, tc.client.Credentials.Identity, tc.secret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if errors.Contains(err, lockout.ErrLocked) || errors.Contains(err, lockout.ErrLockedOut) {
+			assert.True(t, errors.Contains(err, errors.ErrTooManyRequests), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, errors.ErrTooManyRequests, err))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func TestIssueTokenWithDelay(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	lc := lockout.Config{Window: time.Minute, Delay: 100 * time.Millisecond, MaxDelay: time.Second}
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lc), clients.RegistrationConfig{})
+
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, nil)
+	defer repoCall.Unset()
+
+	_, err := svc.IssueToken(context.Background(), client.Credentials.Identity, "wrongsecret")
+	assert.True(t, errors.Contains(err, errors.ErrLogin), fmt.Sprintf("issue token with wrong secret: expected %s got %s\n", errors.ErrLogin, err))
+	_, err = svc.IssueToken(context.Background(), client.Credentials.Identity, secret)
+	assert.True(t, errors.Contains(err, lockout.ErrThrottled), fmt.Sprintf("issue token right after the failed attempt: expected %s got %s\n", lockout.ErrThrottled, err))
+
+	time.Sleep(lc.Delay)
+	_, err = svc.IssueToken(context.Background(), client.Credentials.Identity, secret)
+	assert.Nil(t, err, fmt.Sprintf("issue token after the delay: expected nil got %s\n", err))
+}
+
+func TestUnlockClient(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	lc := lockout.Config{MaxAttempts: 1, Window: time.Minute, Duration: time.Minute}
+	svc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lc), clients.RegistrationConfig{})
+
+	rClient := client
+	rClient.Credentials.Secret, _ = phasher.Hash(client.Credentials.Secret)
+	adminToken := testsutil.GenerateValidToken(t, testsutil.GenerateUUID(t, idProvider), svc, cRepo, phasher)
+
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, nil)
+	_, err := svc.IssueToken(context.Background(), client.Credentials.Identity, "wrongsecret")
+	assert.True(t, errors.Contains(err, lockout.ErrLockedOut), fmt.Sprintf("lock client out: expected %s got %s\n", lockout.ErrLockedOut, err))
+	repoCall.Unset()
+
+	cases := []struct {
+		desc     string
+		token    string
+		adminErr error
+		err      error
+	}{
+		{
+			desc:  "unlock client with invalid token",
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:     "unlock client as non-admin",
+			token:    adminToken,
+			adminErr: errors.ErrAuthorization,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:  "unlock client as admin",
+			token: adminToken,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(tc.adminErr)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), client.ID).Return(rClient, nil)
+		err := svc.UnlockClient(context.Background(), tc.token, client.ID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+
+	repoCall = cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(rClient, nil)
+	_, err = svc.IssueToken(context.Background(), client.Credentials.Identity, secret)
+	assert.Nil(t, err, fmt.Sprintf("issue token for unlocked client: expected nil got %s\n", err))
+	repoCall.Unset()
+}
@@ -33,6 +33,22 @@ func (tm *tracingMiddleware) RegisterClient(ctx context.Context, token string, c
 	return tm.svc.RegisterClient(ctx, token, client)
 }
 
+// VerifyClient traces the "VerifyClient" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) VerifyClient(ctx context.Context, token string) (mfclients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_verify_client")
+	defer span.End()
+
+	return tm.svc.VerifyClient(ctx, token)
+}
+
+// RemoveUnverifiedClients traces the "RemoveUnverifiedClients" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) RemoveUnverifiedClients(ctx context.Context) (uint64, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_unverified_clients")
+	defer span.End()
+
+	return tm.svc.RemoveUnverifiedClients(ctx)
+}
+
 // IssueToken traces the "IssueToken" operation of the wrapped clients.Service.
 func (tm *tracingMiddleware) IssueToken(ctx context.Context, identity, secret string) (jwt.Token, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_issue_token", trace.WithAttributes(attribute.String("identity", identity)))
@@ -49,6 +65,22 @@ func (tm *tracingMiddleware) RefreshToken(ctx context.Context, accessToken strin
 	return tm.svc.RefreshToken(ctx, accessToken)
 }
 
+// RevokeToken traces the "RevokeToken" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) RevokeToken(ctx context.Context, token string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_revoke_token")
+	defer span.End()
+
+	return tm.svc.RevokeToken(ctx, token)
+}
+
+// RevokeTokens traces the "RevokeTokens" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) RevokeTokens(ctx context.Context, token string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_revoke_tokens")
+	defer span.End()
+
+	return tm.svc.RevokeTokens(ctx, token)
+}
+
 // ViewClient traces the "ViewClient" operation of the wrapped clients.Service.
 func (tm *tracingMiddleware) ViewClient(ctx context.Context, token string, id string) (mfclients.Client, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_view_client", trace.WithAttributes(attribute.String("id", id)))
@@ -171,6 +203,14 @@ func (tm *tracingMiddleware) DisableClient(ctx context.Context, token, id string
 	return tm.svc.DisableClient(ctx, token, id)
 }
 
+// UnlockClient traces the "UnlockClient" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) UnlockClient(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_unlock_client", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.UnlockClient(ctx, token, id)
+}
+
 // ListMembers traces the "ListMembers" operation of the wrapped clients.Service.
 func (tm *tracingMiddleware) ListMembers(ctx context.Context, token, groupID string, pm mfclients.Page) (mfclients.MembersPage, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_list_members", trace.WithAttributes(attribute.String("group_id", groupID)))
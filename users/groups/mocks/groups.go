@@ -33,6 +33,15 @@ func (m *Repository) ChangeStatus(ctx context.Context, group mfgroups.Group) (mf
 	return ret.Get(0).(mfgroups.Group), ret.Error(1)
 }
 
+func (m *Repository) Delete(ctx context.Context, id string) error {
+	ret := m.Called(ctx, id)
+	if id == WrongID {
+		return errors.ErrNotFound
+	}
+
+	return ret.Error(0)
+}
+
 func (m *Repository) Memberships(ctx context.Context, clientID string, gm mfgroups.GroupsPage) (mfgroups.MembershipsPage, error) {
 	ret := m.Called(ctx, clientID, gm)
 
@@ -70,6 +79,12 @@ func (m *Repository) Save(ctx context.Context, g mfgroups.Group) (mfgroups.Group
 	return g, ret.Error(1)
 }
 
+func (m *Repository) SaveWithQuota(ctx context.Context, owner string, quota uint64, gs ...mfgroups.Group) ([]mfgroups.Group, error) {
+	ret := m.Called(ctx, owner, quota, gs)
+
+	return gs, ret.Error(1)
+}
+
 func (m *Repository) Update(ctx context.Context, g mfgroups.Group) (mfgroups.Group, error) {
 	ret := m.Called(ctx, g)
 	if g.ID == WrongID {
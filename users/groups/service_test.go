@@ -21,6 +21,13 @@ import (
 	"github.com/mainflux/mainflux/users/groups/mocks"
 	"github.com/mainflux/mainflux/users/hasher"
 	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwmocks "github.com/mainflux/mainflux/users/passwords/mocks"
 	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -51,9 +58,9 @@ func TestCreateGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := cmocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 
 	cases := []struct {
@@ -128,9 +135,9 @@ func TestUpdateGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := cmocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 
 	group.ID = testsutil.GenerateUUID(t, idProvider)
@@ -272,9 +279,9 @@ func TestViewGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := cmocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 
 	group.ID = testsutil.GenerateUUID(t, idProvider)
@@ -330,9 +337,9 @@ func TestListGroups(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := cmocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 
 	nGroups := uint64(200)
@@ -423,9 +430,9 @@ func TestEnableGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := cmocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 
 	enabledGroup1 := mfgroups.Group{ID: testsutil.GenerateUUID(t, idProvider), Name: "group1", Status: mfclients.EnabledStatus}
@@ -554,9 +561,9 @@ func TestDisableGroup(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := cmocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 
 	enabledGroup1 := mfgroups.Group{ID: testsutil.GenerateUUID(t, idProvider), Name: "group1", Status: mfclients.EnabledStatus}
@@ -685,9 +692,9 @@ func TestListMemberships(t *testing.T) {
 	cRepo := new(cmocks.Repository)
 	gRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := cmocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
 	svc := groups.NewService(gRepo, pRepo, tokenizer, idProvider)
 
 	nGroups := uint64(100)
@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package hasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/clients"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idPrefix = "$" + Argon2id + "$"
+	saltLen        = 16
+	keyLen         = 32
+)
+
+var (
+	errInvalidArgon2Params = errors.New("invalid argon2id parameters")
+	errInvalidArgon2Hash   = errors.New("invalid argon2id hash")
+)
+
+var _ clients.Hasher = (*argon2idHasher)(nil)
+
+type argon2Params struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+type argon2idHasher struct {
+	params argon2Params
+}
+
+// NewArgon2id instantiates an argon2id-based hasher implementation with the
+// given number of iterations, memory in KiB and degree of parallelism.
+func NewArgon2id(time, memory uint32, threads uint8) clients.Hasher {
+	return &argon2idHasher{
+		params: argon2Params{
+			time:    time,
+			memory:  memory,
+			threads: threads,
+		},
+	}
+}
+
+func (ah *argon2idHasher) Hash(pwd string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(errHashPassword, err)
+	}
+	key := argon2.IDKey([]byte(pwd), salt, ah.params.time, ah.params.memory, ah.params.threads, keyLen)
+
+	// The hash is encoded in the PHC string format, the same one used by
+	// the reference implementation.
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		ah.params.memory, ah.params.time, ah.params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (ah *argon2idHasher) Compare(plain, hashed string) error {
+	return compare(plain, hashed)
+}
+
+func (ah *argon2idHasher) NeedsRehash(hashed string) bool {
+	p, _, _, err := decodeArgon2id(hashed)
+	return err != nil || p != ah.params
+}
+
+func compareArgon2id(plain, hashed string) error {
+	p, salt, key, err := decodeArgon2id(hashed)
+	if err != nil {
+		return errors.Wrap(errComparePassword, err)
+	}
+	other := argon2.IDKey([]byte(plain), salt, p.time, p.memory, p.threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return errComparePassword
+	}
+
+	return nil
+}
+
+func decodeArgon2id(hashed string) (argon2Params, []byte, []byte, error) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 || parts[1] != Argon2id {
+		return argon2Params{}, nil, nil, errInvalidArgon2Hash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return argon2Params{}, nil, nil, errInvalidArgon2Hash
+	}
+	var p argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return argon2Params{}, nil, nil, errors.Wrap(errInvalidArgon2Hash, err)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2Params{}, nil, nil, errors.Wrap(errInvalidArgon2Hash, err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2Params{}, nil, nil, errInvalidArgon2Hash
+	}
+
+	return p, salt, key, nil
+}
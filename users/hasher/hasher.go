@@ -4,29 +4,77 @@
 package hasher
 
 import (
+	"strings"
+
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users/clients"
 	"golang.org/x/crypto/bcrypt"
 )
 
-const cost int = 10
+const (
+	// Bcrypt is the name of the bcrypt hashing algorithm.
+	Bcrypt = "bcrypt"
+
+	// Argon2id is the name of the argon2id hashing algorithm.
+	Argon2id = "argon2id"
+
+	cost int = 10
+)
 
 var (
+	// ErrUnsupportedAlgorithm indicates that the configured hashing algorithm is not supported.
+	ErrUnsupportedAlgorithm = errors.New("unsupported hashing algorithm")
+
 	errHashPassword    = errors.New("generate hash from password failed")
 	errComparePassword = errors.New("compare hash and password failed")
 )
 
+// Config defines the hashing algorithm and its parameters. Changing them
+// doesn't invalidate the existing hashes, which are re-hashed on login.
+type Config struct {
+	Algorithm     string `env:"ALGORITHM"      envDefault:"bcrypt"`
+	BcryptCost    int    `env:"BCRYPT_COST"    envDefault:"10"`
+	Argon2Time    uint32 `env:"ARGON2_TIME"    envDefault:"1"`
+	Argon2Memory  uint32 `env:"ARGON2_MEMORY"  envDefault:"65536"`
+	Argon2Threads uint8  `env:"ARGON2_THREADS" envDefault:"4"`
+}
+
+// NewFromConfig instantiates the hasher implementation of the configured algorithm.
+func NewFromConfig(c Config) (clients.Hasher, error) {
+	switch c.Algorithm {
+	case Bcrypt:
+		if c.BcryptCost < bcrypt.MinCost || c.BcryptCost > bcrypt.MaxCost {
+			return nil, errors.Wrap(ErrUnsupportedAlgorithm, bcrypt.InvalidCostError(c.BcryptCost))
+		}
+		return NewBcrypt(c.BcryptCost), nil
+	case Argon2id:
+		if c.Argon2Time == 0 || c.Argon2Threads == 0 {
+			return nil, errors.Wrap(ErrUnsupportedAlgorithm, errInvalidArgon2Params)
+		}
+		return NewArgon2id(c.Argon2Time, c.Argon2Memory, c.Argon2Threads), nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
 var _ clients.Hasher = (*bcryptHasher)(nil)
 
-type bcryptHasher struct{}
+type bcryptHasher struct {
+	cost int
+}
 
-// New instantiates a bcrypt-based hasher implementation.
+// New instantiates a bcrypt-based hasher implementation with the default cost.
 func New() clients.Hasher {
-	return &bcryptHasher{}
+	return NewBcrypt(cost)
+}
+
+// NewBcrypt instantiates a bcrypt-based hasher implementation with the given cost.
+func NewBcrypt(cost int) clients.Hasher {
+	return &bcryptHasher{cost: cost}
 }
 
 func (bh *bcryptHasher) Hash(pwd string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), cost)
+	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), bh.cost)
 	if err != nil {
 		return "", errors.Wrap(errHashPassword, err)
 	}
@@ -35,8 +83,24 @@ func (bh *bcryptHasher) Hash(pwd string) (string, error) {
 }
 
 func (bh *bcryptHasher) Compare(plain, hashed string) error {
+	return compare(plain, hashed)
+}
+
+func (bh *bcryptHasher) NeedsRehash(hashed string) bool {
+	c, err := bcrypt.Cost([]byte(hashed))
+	return err != nil || c != bh.cost
+}
+
+// compare compares the plain-text to the hash of any of the supported
+// algorithms, so that the hashes made before switching the algorithm
+// remain valid.
+func compare(plain, hashed string) error {
+	if strings.HasPrefix(hashed, argon2idPrefix) {
+		return compareArgon2id(plain, hashed)
+	}
 	if err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(plain)); err != nil {
 		return errors.Wrap(errComparePassword, err)
 	}
+
 	return nil
 }
@@ -0,0 +1,131 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package hasher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/hasher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "strongsecret"
+
+func TestNewFromConfig(t *testing.T) {
+	cases := []struct {
+		desc   string
+		config hasher.Config
+		err    error
+	}{
+		{
+			desc:   "create bcrypt hasher",
+			config: hasher.Config{Algorithm: hasher.Bcrypt, BcryptCost: 10},
+			err:    nil,
+		},
+		{
+			desc:   "create bcrypt hasher with invalid cost",
+			config: hasher.Config{Algorithm: hasher.Bcrypt, BcryptCost: 100},
+			err:    hasher.ErrUnsupportedAlgorithm,
+		},
+		{
+			desc:   "create argon2id hasher",
+			config: hasher.Config{Algorithm: hasher.Argon2id, Argon2Time: 1, Argon2Memory: 1024, Argon2Threads: 1},
+			err:    nil,
+		},
+		{
+			desc:   "create argon2id hasher with invalid parameters",
+			config: hasher.Config{Algorithm: hasher.Argon2id, Argon2Memory: 1024, Argon2Threads: 1},
+			err:    hasher.ErrUnsupportedAlgorithm,
+		},
+		{
+			desc:   "create hasher with unsupported algorithm",
+			config: hasher.Config{Algorithm: "md5"},
+			err:    hasher.ErrUnsupportedAlgorithm,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := hasher.NewFromConfig(tc.config)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCompare(t *testing.T) {
+	bcrypt := hasher.NewBcrypt(4)
+	argon2id := hasher.NewArgon2id(1, 1024, 1)
+
+	bcryptHash, err := bcrypt.Hash(secret)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	argon2idHash, err := argon2id.Hash(secret)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		plain  string
+		hashed string
+		valid  bool
+	}{
+		{
+			desc:   "compare bcrypt hash",
+			plain:  secret,
+			hashed: bcryptHash,
+			valid:  true,
+		},
+		{
+			desc:   "compare bcrypt hash with wrong secret",
+			plain:  "wrongsecret",
+			hashed: bcryptHash,
+			valid:  false,
+		},
+		{
+			desc:   "compare argon2id hash",
+			plain:  secret,
+			hashed: argon2idHash,
+			valid:  true,
+		},
+		{
+			desc:   "compare argon2id hash with wrong secret",
+			plain:  "wrongsecret",
+			hashed: argon2idHash,
+			valid:  false,
+		},
+		{
+			desc:   "compare malformed argon2id hash",
+			plain:  secret,
+			hashed: "$argon2id$v=19$m=1024$salt$key",
+			valid:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		for _, h := range []string{hasher.Bcrypt, hasher.Argon2id} {
+			hsr := bcrypt
+			if h == hasher.Argon2id {
+				hsr = argon2id
+			}
+			err := hsr.Compare(tc.plain, tc.hashed)
+			assert.Equal(t, tc.valid, err == nil, fmt.Sprintf("%s using %s hasher: expected valid %t got error %s\n", tc.desc, h, tc.valid, err))
+		}
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	bcrypt := hasher.NewBcrypt(4)
+	argon2id := hasher.NewArgon2id(1, 1024, 1)
+
+	bcryptHash, err := bcrypt.Hash(secret)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	argon2idHash, err := argon2id.Hash(secret)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	assert.False(t, bcrypt.NeedsRehash(bcryptHash), "expected bcrypt hash with the same cost not to need re-hashing")
+	assert.True(t, hasher.NewBcrypt(5).NeedsRehash(bcryptHash), "expected bcrypt hash with different cost to need re-hashing")
+	assert.True(t, bcrypt.NeedsRehash(argon2idHash), "expected argon2id hash to need re-hashing by bcrypt hasher")
+	assert.False(t, argon2id.NeedsRehash(argon2idHash), "expected argon2id hash with the same parameters not to need re-hashing")
+	assert.True(t, hasher.NewArgon2id(2, 1024, 1).NeedsRehash(argon2idHash), "expected argon2id hash with different parameters to need re-hashing")
+	assert.True(t, argon2id.NeedsRehash(bcryptHash), "expected bcrypt hash to need re-hashing by argon2id hasher")
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package api contains the HTTP endpoint publishing the public keys
+// users service tokens are verified with.
+package api
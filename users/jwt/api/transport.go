@@ -0,0 +1,38 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-zoo/bone"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+const (
+	contentType = "application/jwk-set+json"
+	// Keys change only on restart, so verifiers can cache them for a
+	// while and refetch the set when they encounter an unknown key ID.
+	cacheControl = "public, max-age=300"
+)
+
+// MakeHandler returns a HTTP handler serving the public keys in JWK Set
+// format at the well-known location.
+func MakeHandler(keys jwk.Set, mux *bone.Mux) http.Handler {
+	mux.GetFunc("/.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(keys)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", cacheControl)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	})
+
+	return mux
+}
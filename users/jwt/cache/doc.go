@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache contains the Redis cache of revoked users tokens
+// placed in front of the revoked tokens repository.
+package cache
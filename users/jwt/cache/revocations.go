@@ -0,0 +1,127 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/jwt"
+)
+
+const (
+	tokenPrefix  = "revoked_token"
+	clientPrefix = "revoked_client"
+
+	revoked    = "1"
+	notRevoked = "0"
+)
+
+var _ jwt.RevocationRepository = (*revocationCache)(nil)
+
+type revocationCache struct {
+	client   *redis.Client
+	repo     jwt.RevocationRepository
+	duration time.Duration
+}
+
+// NewRevocations returns redis cache of the revoked tokens repository.
+// Revocations are written through to the repository, while lookups,
+// including the negative ones, are cached for the given duration.
+func NewRevocations(client *redis.Client, repo jwt.RevocationRepository, duration time.Duration) jwt.RevocationRepository {
+	return &revocationCache{
+		client:   client,
+		repo:     repo,
+		duration: duration,
+	}
+}
+
+func (rc *revocationCache) Revoke(ctx context.Context, id string, expiresAt time.Time) error {
+	if err := rc.repo.Revoke(ctx, id, expiresAt); err != nil {
+		return err
+	}
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	tkey := fmt.Sprintf("%s:%s", tokenPrefix, id)
+	if err := rc.client.Set(ctx, tkey, revoked, ttl).Err(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	return nil
+}
+
+func (rc *revocationCache) Revoked(ctx context.Context, id string) (bool, error) {
+	tkey := fmt.Sprintf("%s:%s", tokenPrefix, id)
+	val, err := rc.client.Get(ctx, tkey).Result()
+	switch err {
+	case nil:
+		return val == revoked, nil
+	case redis.Nil:
+	default:
+		return false, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	ok, err := rc.repo.Revoked(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	val = notRevoked
+	if ok {
+		val = revoked
+	}
+	if err := rc.client.Set(ctx, tkey, val, rc.duration).Err(); err != nil {
+		return false, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	return ok, nil
+}
+
+func (rc *revocationCache) RevokeAll(ctx context.Context, clientID string, before time.Time) error {
+	if err := rc.repo.RevokeAll(ctx, clientID, before); err != nil {
+		return err
+	}
+
+	ckey := fmt.Sprintf("%s:%s", clientPrefix, clientID)
+	if err := rc.client.Set(ctx, ckey, before.UnixNano(), rc.duration).Err(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	return nil
+}
+
+func (rc *revocationCache) RevokedBefore(ctx context.Context, clientID string) (time.Time, error) {
+	ckey := fmt.Sprintf("%s:%s", clientPrefix, clientID)
+	val, err := rc.client.Get(ctx, ckey).Result()
+	switch err {
+	case nil:
+		nsec, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return time.Time{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		if nsec == 0 {
+			return time.Time{}, nil
+		}
+		return time.Unix(0, nsec), nil
+	case redis.Nil:
+	default:
+		return time.Time{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	before, err := rc.repo.RevokedBefore(ctx, clientID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var nsec int64
+	if !before.IsZero() {
+		nsec = before.UnixNano()
+	}
+	if err := rc.client.Set(ctx, ckey, nsec, rc.duration).Err(); err != nil {
+		return time.Time{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	return before, nil
+}
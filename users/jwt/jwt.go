@@ -5,14 +5,29 @@ package jwt
 
 import (
 	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
 )
 
-// Possible token types are access and refresh tokens.
+// Possible token types are access, refresh, MFA challenge, MFA enrollment,
+// identity verification and secret reset tokens.
 const (
-	RefreshToken = "refresh"
-	AccessToken  = "access"
+	RefreshToken      = "refresh"
+	AccessToken       = "access"
+	MFAToken          = "mfa"
+	EnrollmentToken   = "enrollment"
+	VerificationToken = "verification"
+	ResetToken        = "reset"
 )
 
+// MFAType is the access type of the challenge token issued to the clients
+// that have to pass the second authentication factor.
+const MFAType = "MFA"
+
+// ErrRevoked indicates that the token has been revoked.
+var ErrRevoked = errors.New("token has been revoked")
+
 // Token is used for authentication purposes.
 // It contains AccessToken, RefreshToken, Type and AccessExpiry.
 type Token struct {
@@ -23,9 +38,13 @@ type Token struct {
 
 // Claims are the Client's internal JWT Claims.
 type Claims struct {
-	ClientID string // ClientID is the client unique identifier.
-	Email    string // Email is the client identity
-	Type     string // Type denotes the type of claim. Either AccessToken or RefreshToken.
+	ID        string    // ID is the token identifier (jti). Access and refresh tokens issued together share it.
+	ClientID  string    // ClientID is the client unique identifier.
+	OrgID     string    // OrgID is the organization the token is scoped to, if any.
+	Email     string    // Email is the client identity
+	Type      string    // Type denotes the type of claim. Either AccessToken or RefreshToken.
+	IssuedAt  time.Time // IssuedAt is the time the token was issued at.
+	ExpiresAt time.Time // ExpiresAt is the time the token expires at.
 }
 
 // Service specifies an API that must be fulfilled by the domain service
@@ -38,6 +57,14 @@ type Service interface {
 	// After an access token expires, the refresh token is used to get
 	// a new pair of access and refresh tokens.
 	RefreshToken(ctx context.Context, accessToken string) (Token, error)
+
+	// RevokeToken revokes the given token together with the token
+	// issued alongside it, which logs the client out of that session.
+	RevokeToken(ctx context.Context, token string) error
+
+	// RevokeTokens revokes all the tokens issued so far to the client
+	// identified by the given token, logging it out everywhere.
+	RevokeTokens(ctx context.Context, token string) error
 }
 
 // Repository specifies an account persistence API.
@@ -45,6 +72,47 @@ type Repository interface {
 	// Issue issues a new access and refresh token.
 	Issue(ctx context.Context, claim Claims) (Token, error)
 
-	// Parse checks the validity of a token.
+	// Challenge issues a short-lived MFA challenge token, which can only be
+	// exchanged for the access and refresh token after the second
+	// authentication factor is verified.
+	Challenge(ctx context.Context, claim Claims) (Token, error)
+
+	// Enrollment issues a short-lived MFA enrollment token, which can only
+	// be used to enroll the second authentication factor.
+	Enrollment(ctx context.Context, claim Claims) (string, error)
+
+	// Verification issues an identity verification token, which is valid
+	// for the given duration and can only be used to verify the identity of
+	// the self-registered client.
+	Verification(ctx context.Context, claim Claims, duration time.Duration) (string, error)
+
+	// Reset issues a short-lived secret reset token, which can only be used
+	// to reset the client secret.
+	Reset(ctx context.Context, claim Claims) (string, error)
+
+	// Parse checks the validity of a token, including whether it has been revoked.
 	Parse(ctx context.Context, token string) (Claims, error)
+
+	// Revoke revokes the token identified by the claims, together with
+	// the token issued alongside it.
+	Revoke(ctx context.Context, claims Claims) error
+
+	// RevokeAll revokes all the tokens issued to the client so far.
+	RevokeAll(ctx context.Context, clientID string) error
+}
+
+// RevocationRepository specifies revoked tokens persistence API.
+type RevocationRepository interface {
+	// Revoke marks the token ID as revoked until the given expiry time.
+	Revoke(ctx context.Context, id string, expiresAt time.Time) error
+
+	// Revoked reports whether the token ID has been revoked.
+	Revoked(ctx context.Context, id string) (bool, error)
+
+	// RevokeAll revokes all the client tokens issued before the given time.
+	RevokeAll(ctx context.Context, clientID string, before time.Time) error
+
+	// RevokedBefore returns the time before which all the client tokens
+	// are revoked. Zero time is returned if there is no such revocation.
+	RevokedBefore(ctx context.Context, clientID string) (time.Time, error)
 }
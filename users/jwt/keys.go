@@ -0,0 +1,122 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	// ErrUnsupportedKey indicates that the key type can't be used to sign tokens.
+	ErrUnsupportedKey = errors.New("unsupported token signing key, expected RSA, P-256 EC or Ed25519 key")
+
+	// ErrMissingSigningKey indicates that the signing key is missing or is a public key.
+	ErrMissingSigningKey = errors.New("missing private token signing key")
+)
+
+// KeySet contains the private key tokens are signed with, and the public
+// keys tokens are verified with. Public keys include the keys previously
+// used for signing, so the tokens issued before key rotation stay valid
+// until they expire.
+type KeySet struct {
+	signing jwk.Key
+	public  jwk.Set
+}
+
+// ParseKeySet parses PEM encoded signing key and the keys previously used
+// for signing, which are either private or public keys. Signing algorithm
+// is determined by the key type: RS256 for RSA, ES256 for P-256 EC and
+// EdDSA for Ed25519 keys. Key IDs are the keys' JWK thumbprints.
+func ParseKeySet(signing []byte, previous ...[]byte) (KeySet, error) {
+	key, err := parseKey(signing)
+	if err != nil {
+		return KeySet{}, err
+	}
+	switch key.(type) {
+	case jwk.RSAPrivateKey, jwk.ECDSAPrivateKey, jwk.OKPPrivateKey:
+	default:
+		return KeySet{}, ErrMissingSigningKey
+	}
+
+	set := jwk.NewSet()
+	for _, pem := range append([][]byte{signing}, previous...) {
+		k, err := parseKey(pem)
+		if err != nil {
+			return KeySet{}, err
+		}
+		pub, err := jwk.PublicKeyOf(k)
+		if err != nil {
+			return KeySet{}, errors.Wrap(ErrUnsupportedKey, err)
+		}
+		if _, ok := set.LookupKeyID(pub.KeyID()); ok {
+			continue
+		}
+		if err := set.AddKey(pub); err != nil {
+			return KeySet{}, err
+		}
+	}
+
+	return KeySet{signing: key, public: set}, nil
+}
+
+// Public returns the public keys tokens are verified with.
+func (ks KeySet) Public() jwk.Set {
+	return ks.public
+}
+
+// KeyID returns the ID of the signing key.
+func (ks KeySet) KeyID() string {
+	return ks.signing.KeyID()
+}
+
+func parseKey(pem []byte) (jwk.Key, error) {
+	key, err := jwk.ParseKey(pem, jwk.WithPEM(true))
+	if err != nil {
+		return nil, errors.Wrap(ErrUnsupportedKey, err)
+	}
+	alg, err := algorithm(key)
+	if err != nil {
+		return nil, err
+	}
+	// Thumbprint is computed from the public key parameters, so the
+	// private key and its public key get the same ID.
+	if err := jwk.AssignKeyID(key); err != nil {
+		return nil, errors.Wrap(ErrUnsupportedKey, err)
+	}
+	if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+		return nil, err
+	}
+	if err := key.Set(jwk.KeyUsageKey, jwk.ForSignature); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func algorithm(key jwk.Key) (jwa.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case jwk.RSAPrivateKey, jwk.RSAPublicKey:
+		return jwa.RS256, nil
+	case jwk.ECDSAPrivateKey:
+		if k.Crv() == jwa.P256 {
+			return jwa.ES256, nil
+		}
+	case jwk.ECDSAPublicKey:
+		if k.Crv() == jwa.P256 {
+			return jwa.ES256, nil
+		}
+	case jwk.OKPPrivateKey:
+		if k.Crv() == jwa.Ed25519 {
+			return jwa.EdDSA, nil
+		}
+	case jwk.OKPPublicKey:
+		if k.Crv() == jwa.Ed25519 {
+			return jwa.EdDSA, nil
+		}
+	}
+
+	return "", ErrUnsupportedKey
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/users/jwt"
+)
+
+var _ jwt.RevocationRepository = (*revocationsMock)(nil)
+
+type revocationsMock struct {
+	mu      sync.Mutex
+	tokens  map[string]time.Time
+	clients map[string]time.Time
+}
+
+// NewRevocations creates in-memory revoked tokens repository.
+func NewRevocations() jwt.RevocationRepository {
+	return &revocationsMock{
+		tokens:  make(map[string]time.Time),
+		clients: make(map[string]time.Time),
+	}
+}
+
+func (rm *revocationsMock) Revoke(_ context.Context, id string, expiresAt time.Time) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.tokens[id] = expiresAt
+	return nil
+}
+
+func (rm *revocationsMock) Revoked(_ context.Context, id string) (bool, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	_, ok := rm.tokens[id]
+	return ok, nil
+}
+
+func (rm *revocationsMock) RevokeAll(_ context.Context, clientID string, before time.Time) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.clients[clientID] = before
+	return nil
+}
+
+func (rm *revocationsMock) RevokedBefore(_ context.Context, clientID string) (time.Time, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	return rm.clients[clientID], nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/jwt"
+)
+
+var _ jwt.RevocationRepository = (*revocationRepo)(nil)
+
+type revocationRepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of revoked tokens repository.
+func NewRepository(db postgres.Database) jwt.RevocationRepository {
+	return &revocationRepo{
+		db: db,
+	}
+}
+
+func (rr revocationRepo) Revoke(ctx context.Context, id string, expiresAt time.Time) error {
+	// Revoked tokens are of no interest once they expire, so the
+	// expired entries are cleaned up on each revocation.
+	if _, err := rr.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at < $1`, time.Now().UTC()); err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	q := `INSERT INTO revoked_tokens (id, expires_at) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING`
+	if _, err := rr.db.ExecContext(ctx, q, id, expiresAt.UTC()); err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	return nil
+}
+
+func (rr revocationRepo) Revoked(ctx context.Context, id string) (bool, error) {
+	q := `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE id = $1)`
+
+	var revoked bool
+	if err := rr.db.QueryRowxContext(ctx, q, id).Scan(&revoked); err != nil {
+		return false, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return revoked, nil
+}
+
+func (rr revocationRepo) RevokeAll(ctx context.Context, clientID string, before time.Time) error {
+	q := `INSERT INTO client_revocations (client_id, revoked_at) VALUES ($1, $2)
+		ON CONFLICT (client_id) DO UPDATE SET revoked_at = EXCLUDED.revoked_at`
+
+	if _, err := rr.db.ExecContext(ctx, q, clientID, before.UTC()); err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	return nil
+}
+
+func (rr revocationRepo) RevokedBefore(ctx context.Context, clientID string) (time.Time, error) {
+	q := `SELECT revoked_at FROM client_revocations WHERE client_id = $1`
+
+	var before time.Time
+	if err := rr.db.QueryRowxContext(ctx, q, clientID).Scan(&before); err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return before, nil
+}
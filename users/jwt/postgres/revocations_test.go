@@ -0,0 +1,136 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	cpostgres "github.com/mainflux/mainflux/users/clients/postgres"
+	jpostgres "github.com/mainflux/mainflux/users/jwt/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idProvider = uuid.New()
+
+func saveClient(t *testing.T, name string) mfclients.Client {
+	crepo := cpostgres.NewRepository(database)
+	client := mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: name,
+		Credentials: mfclients.Credentials{
+			Identity: name,
+			Secret:   "pass",
+		},
+		Status: mfclients.EnabledStatus,
+	}
+	client, err := crepo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return client
+}
+
+func TestRevoke(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := jpostgres.NewRepository(database)
+
+	id := testsutil.GenerateUUID(t, idProvider)
+	expired := testsutil.GenerateUUID(t, idProvider)
+
+	err := repo.Revoke(context.Background(), expired, time.Now().Add(-time.Minute))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		id      string
+		revoked bool
+	}{
+		{
+			desc:    "check not revoked token",
+			id:      id,
+			revoked: false,
+		},
+		{
+			desc:    "revoke token",
+			id:      id,
+			revoked: true,
+		},
+		{
+			desc:    "revoke already revoked token",
+			id:      id,
+			revoked: true,
+		},
+	}
+
+	for _, tc := range cases {
+		if tc.revoked {
+			err := repo.Revoke(context.Background(), tc.id, time.Now().Add(time.Hour))
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		}
+		revoked, err := repo.Revoked(context.Background(), tc.id)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.revoked, revoked, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.revoked, revoked))
+	}
+
+	revoked, err := repo.Revoked(context.Background(), expired)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, revoked, "expected expired revocation to be cleaned up")
+}
+
+func TestRevokeAll(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := jpostgres.NewRepository(database)
+	client := saveClient(t, "revoke-all@example.com")
+
+	first := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	second := time.Now().UTC().Truncate(time.Second)
+
+	cases := []struct {
+		desc     string
+		clientID string
+		before   time.Time
+		err      error
+	}{
+		{
+			desc:     "revoke all tokens",
+			clientID: client.ID,
+			before:   first,
+			err:      nil,
+		},
+		{
+			desc:     "revoke all tokens again",
+			clientID: client.ID,
+			before:   second,
+			err:      nil,
+		},
+		{
+			desc:     "revoke all tokens of non-existing client",
+			clientID: testsutil.GenerateUUID(t, idProvider),
+			before:   second,
+			err:      errors.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.RevokeAll(context.Background(), tc.clientID, tc.before)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		before, err := repo.RevokedBefore(context.Background(), tc.clientID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.True(t, tc.before.Equal(before), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.before, before))
+	}
+
+	before, err := repo.RevokedBefore(context.Background(), testsutil.GenerateUUID(t, idProvider))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, before.IsZero(), fmt.Sprintf("expected zero time got %s", before))
+}
@@ -5,37 +5,77 @@ package jwt
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
 )
 
-const issuerName = "clients.auth"
+const (
+	issuerName        = "clients.auth"
+	orgClaim          = "org"
+	issuedAtClaim     = "iat_ns"
+	challengeDuration = 5 * time.Minute
+	resetDuration     = 15 * time.Minute
+	enrollDuration    = 15 * time.Minute
+)
 
 var _ Repository = (*tokenRepo)(nil)
 
 type tokenRepo struct {
-	secret          []byte
+	signKey         jwt.SignOption
+	verifyKey       jwt.ParseOption
 	accessDuration  time.Duration
 	refreshDuration time.Duration
+	revocations     RevocationRepository
+	idProvider      mainflux.IDProvider
+}
+
+// NewRepository instantiates an implementation of Token repository, which
+// signs tokens using HS512 with the provided secret. Revoked tokens are
+// looked up in the provided revocation repository.
+func NewRepository(secret []byte, aduration, rduration time.Duration, revocations RevocationRepository) Repository {
+	key := jwt.WithKey(jwa.HS512, secret)
+	return &tokenRepo{
+		signKey:         key,
+		verifyKey:       key,
+		accessDuration:  aduration,
+		refreshDuration: rduration,
+		revocations:     revocations,
+		idProvider:      uuid.New(),
+	}
 }
 
-// NewRepository instantiates an implementation of Token repository.
-func NewRepository(secret []byte, aduration, rduration time.Duration) Repository {
+// NewKeySetRepository instantiates an implementation of Token repository,
+// which signs tokens with the key set signing key. Issued tokens carry the
+// signing key ID, so they can be verified against the key set public keys.
+func NewKeySetRepository(keys KeySet, aduration, rduration time.Duration, revocations RevocationRepository) Repository {
 	return &tokenRepo{
-		secret:          secret,
+		signKey:         jwt.WithKey(keys.signing.Algorithm(), keys.signing),
+		verifyKey:       jwt.WithKeySet(keys.public),
 		accessDuration:  aduration,
 		refreshDuration: rduration,
+		revocations:     revocations,
+		idProvider:      uuid.New(),
 	}
 }
 
 func (repo tokenRepo) Issue(ctx context.Context, claim Claims) (Token, error) {
-	aexpiry := time.Now().Add(repo.accessDuration)
+	id, err := repo.idProvider.ID()
+	if err != nil {
+		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	now := time.Now()
+	aexpiry := now.Add(repo.accessDuration)
 	accessToken, err := jwt.NewBuilder().
+		JwtID(id).
 		Issuer(issuerName).
-		IssuedAt(time.Now()).
+		IssuedAt(now).
+		Claim(issuedAtClaim, strconv.FormatInt(now.UnixNano(), 10)).
 		Subject(claim.ClientID).
 		Claim("identity", claim.Email).
 		Claim("type", AccessToken).
@@ -44,22 +84,30 @@ func (repo tokenRepo) Issue(ctx context.Context, claim Claims) (Token, error) {
 	if err != nil {
 		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
-	signedAccessToken, err := jwt.Sign(accessToken, jwt.WithKey(jwa.HS512, repo.secret))
+	if err := scope(accessToken, claim.OrgID); err != nil {
+		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	signedAccessToken, err := jwt.Sign(accessToken, repo.signKey)
 	if err != nil {
 		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 	refreshToken, err := jwt.NewBuilder().
+		JwtID(id).
 		Issuer(issuerName).
-		IssuedAt(time.Now()).
+		IssuedAt(now).
+		Claim(issuedAtClaim, strconv.FormatInt(now.UnixNano(), 10)).
 		Subject(claim.ClientID).
 		Claim("identity", claim.Email).
 		Claim("type", RefreshToken).
-		Expiration(time.Now().Add(repo.refreshDuration)).
+		Expiration(now.Add(repo.refreshDuration)).
 		Build()
 	if err != nil {
 		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
-	signedRefreshToken, err := jwt.Sign(refreshToken, jwt.WithKey(jwa.HS512, repo.secret))
+	if err := scope(refreshToken, claim.OrgID); err != nil {
+		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	signedRefreshToken, err := jwt.Sign(refreshToken, repo.signKey)
 	if err != nil {
 		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
@@ -71,11 +119,82 @@ func (repo tokenRepo) Issue(ctx context.Context, claim Claims) (Token, error) {
 	}, nil
 }
 
+func (repo tokenRepo) Challenge(ctx context.Context, claim Claims) (Token, error) {
+	id, err := repo.idProvider.ID()
+	if err != nil {
+		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	now := time.Now()
+	challenge, err := jwt.NewBuilder().
+		JwtID(id).
+		Issuer(issuerName).
+		IssuedAt(now).
+		Claim(issuedAtClaim, strconv.FormatInt(now.UnixNano(), 10)).
+		Subject(claim.ClientID).
+		Claim("identity", claim.Email).
+		Claim("type", MFAToken).
+		Expiration(now.Add(challengeDuration)).
+		Build()
+	if err != nil {
+		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	signedChallenge, err := jwt.Sign(challenge, repo.signKey)
+	if err != nil {
+		return Token{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return Token{
+		AccessToken: string(signedChallenge),
+		AccessType:  MFAType,
+	}, nil
+}
+
+func (repo tokenRepo) Enrollment(ctx context.Context, claim Claims) (string, error) {
+	return repo.single(claim, EnrollmentToken, enrollDuration)
+}
+
+func (repo tokenRepo) Verification(ctx context.Context, claim Claims, duration time.Duration) (string, error) {
+	return repo.single(claim, VerificationToken, duration)
+}
+
+func (repo tokenRepo) Reset(ctx context.Context, claim Claims) (string, error) {
+	return repo.single(claim, ResetToken, resetDuration)
+}
+
+// single issues the token of the given type, which isn't accompanied by the
+// refresh token.
+func (repo tokenRepo) single(claim Claims, tType string, duration time.Duration) (string, error) {
+	id, err := repo.idProvider.ID()
+	if err != nil {
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+	now := time.Now()
+	token, err := jwt.NewBuilder().
+		JwtID(id).
+		Issuer(issuerName).
+		IssuedAt(now).
+		Claim(issuedAtClaim, strconv.FormatInt(now.UnixNano(), 10)).
+		Subject(claim.ClientID).
+		Claim("identity", claim.Email).
+		Claim("type", tType).
+		Expiration(now.Add(duration)).
+		Build()
+	if err != nil {
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+	signed, err := jwt.Sign(token, repo.signKey)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return string(signed), nil
+}
+
 func (repo tokenRepo) Parse(ctx context.Context, accessToken string) (Claims, error) {
 	token, err := jwt.Parse(
 		[]byte(accessToken),
 		jwt.WithValidate(true),
-		jwt.WithKey(jwa.HS512, repo.secret),
+		repo.verifyKey,
 	)
 	if err != nil {
 		return Claims{}, errors.Wrap(errors.ErrAuthentication, err)
@@ -88,10 +207,86 @@ func (repo tokenRepo) Parse(ctx context.Context, accessToken string) (Claims, er
 	if !ok {
 		return Claims{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
+	// Only organization scoped tokens carry the organization claim.
+	var orgID string
+	if org, ok := token.Get(orgClaim); ok {
+		orgID, _ = org.(string)
+	}
 	claim := Claims{
-		ClientID: token.Subject(),
-		Email:    identity.(string),
-		Type:     tType.(string),
+		ID:        token.JwtID(),
+		ClientID:  token.Subject(),
+		OrgID:     orgID,
+		Email:     identity.(string),
+		Type:      tType.(string),
+		IssuedAt:  issuedAt(token),
+		ExpiresAt: token.Expiration(),
+	}
+	if err := repo.checkRevoked(ctx, claim); err != nil {
+		return Claims{}, err
 	}
 	return claim, nil
 }
+
+func (repo tokenRepo) Revoke(ctx context.Context, claims Claims) error {
+	if claims.ID == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, ErrRevoked)
+	}
+	issuedAt := claims.IssuedAt
+	if issuedAt.IsZero() {
+		issuedAt = time.Now()
+	}
+	// The refresh token outlives the access token issued alongside it,
+	// so the revocation has to be kept until the refresh token expires.
+	return repo.revocations.Revoke(ctx, claims.ID, issuedAt.Add(repo.refreshDuration))
+}
+
+func (repo tokenRepo) RevokeAll(ctx context.Context, clientID string) error {
+	// Revocations are stored with a precision of one microsecond, so the
+	// cutoff is rounded up to the next one to cover the tokens issued
+	// earlier within the current one.
+	return repo.revocations.RevokeAll(ctx, clientID, time.Now().Truncate(time.Microsecond).Add(time.Microsecond))
+}
+
+// scope scopes the token to the organization, if there is one.
+func scope(token jwt.Token, orgID string) error {
+	if orgID == "" {
+		return nil
+	}
+
+	return token.Set(orgClaim, orgID)
+}
+
+// issuedAt returns the issue time of the token. The registered issue time
+// claim has a precision of one second only, so tokens carry the issue time
+// in nanoseconds as well. Tokens issued without it fall back to the former.
+func issuedAt(token jwt.Token) time.Time {
+	if v, ok := token.Get(issuedAtClaim); ok {
+		if s, ok := v.(string); ok {
+			if nsec, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return time.Unix(0, nsec)
+			}
+		}
+	}
+
+	return token.IssuedAt()
+}
+
+func (repo tokenRepo) checkRevoked(ctx context.Context, claims Claims) error {
+	if claims.ID != "" {
+		revoked, err := repo.revocations.Revoked(ctx, claims.ID)
+		if err != nil {
+			return errors.Wrap(errors.ErrAuthentication, err)
+		}
+		if revoked {
+			return errors.Wrap(errors.ErrAuthentication, ErrRevoked)
+		}
+	}
+	before, err := repo.revocations.RevokedBefore(ctx, claims.ClientID)
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+	if claims.IssuedAt.Before(before) {
+		return errors.Wrap(errors.ErrAuthentication, ErrRevoked)
+	}
+	return nil
+}
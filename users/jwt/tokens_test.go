@@ -0,0 +1,275 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	accessDuration  = time.Minute
+	refreshDuration = time.Hour
+)
+
+var claims = jwt.Claims{
+	ClientID: "clientID",
+	Email:    "user@example.com",
+}
+
+func privateKeyPEM(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func publicKeyPEM(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func generateKeys(t *testing.T) map[string]crypto.Signer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return map[string]crypto.Signer{
+		"RS256": rsaKey,
+		"ES256": ecKey,
+		"EdDSA": edKey,
+	}
+}
+
+func TestParseKeySet(t *testing.T) {
+	keys := generateKeys(t)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		signing  []byte
+		previous [][]byte
+		size     int
+		err      error
+	}{
+		{
+			desc:    "parse RSA signing key",
+			signing: privateKeyPEM(t, keys["RS256"]),
+			size:    1,
+		},
+		{
+			desc:     "parse signing key with previous private and public keys",
+			signing:  privateKeyPEM(t, keys["EdDSA"]),
+			previous: [][]byte{privateKeyPEM(t, keys["RS256"]), publicKeyPEM(t, keys["ES256"])},
+			size:     3,
+		},
+		{
+			desc:     "parse signing key with its public key among previous keys",
+			signing:  privateKeyPEM(t, keys["ES256"]),
+			previous: [][]byte{publicKeyPEM(t, keys["ES256"])},
+			size:     1,
+		},
+		{
+			desc:    "parse public signing key",
+			signing: publicKeyPEM(t, keys["RS256"]),
+			err:     jwt.ErrMissingSigningKey,
+		},
+		{
+			desc:    "parse P-384 EC signing key",
+			signing: privateKeyPEM(t, p384Key),
+			err:     jwt.ErrUnsupportedKey,
+		},
+		{
+			desc:     "parse signing key with invalid previous key",
+			signing:  privateKeyPEM(t, keys["RS256"]),
+			previous: [][]byte{[]byte("invalid")},
+			err:      jwt.ErrUnsupportedKey,
+		},
+		{
+			desc:    "parse invalid signing key",
+			signing: []byte("invalid"),
+			err:     jwt.ErrUnsupportedKey,
+		},
+	}
+
+	for _, tc := range cases {
+		ks, err := jwt.ParseKeySet(tc.signing, tc.previous...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.size, ks.Public().Len(), fmt.Sprintf("%s: expected %d public keys got %d\n", tc.desc, tc.size, ks.Public().Len()))
+			_, ok := ks.Public().LookupKeyID(ks.KeyID())
+			assert.True(t, ok, fmt.Sprintf("%s: expected signing key to be among public keys\n", tc.desc))
+		}
+	}
+}
+
+func TestKeySetIssue(t *testing.T) {
+	for alg, key := range generateKeys(t) {
+		ks, err := jwt.ParseKeySet(privateKeyPEM(t, key))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		repo := jwt.NewKeySetRepository(ks, accessDuration, refreshDuration, mocks.NewRevocations())
+
+		token, err := repo.Issue(context.Background(), claims)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", alg, err))
+
+		msg, err := jws.Parse([]byte(token.AccessToken))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", alg, err))
+		headers := msg.Signatures()[0].ProtectedHeaders()
+		assert.Equal(t, alg, headers.Algorithm().String(), fmt.Sprintf("%s: expected algorithm %s got %s\n", alg, alg, headers.Algorithm()))
+		assert.Equal(t, ks.KeyID(), headers.KeyID(), fmt.Sprintf("%s: expected key ID %s got %s\n", alg, ks.KeyID(), headers.KeyID()))
+
+		c, err := repo.Parse(context.Background(), token.AccessToken)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", alg, err))
+		assert.Equal(t, claims.ClientID, c.ClientID, fmt.Sprintf("%s: expected client ID %s got %s\n", alg, claims.ClientID, c.ClientID))
+		assert.Equal(t, jwt.AccessToken, c.Type, fmt.Sprintf("%s: expected token type %s got %s\n", alg, jwt.AccessToken, c.Type))
+	}
+}
+
+func TestIssueOrgScoped(t *testing.T) {
+	repo := jwt.NewRepository([]byte("secret"), accessDuration, refreshDuration, mocks.NewRevocations())
+
+	orgClaims := claims
+	orgClaims.OrgID = "orgID"
+
+	cases := []struct {
+		desc   string
+		claims jwt.Claims
+		orgID  string
+	}{
+		{
+			desc:   "issue token not scoped to organization",
+			claims: claims,
+			orgID:  "",
+		},
+		{
+			desc:   "issue organization scoped token",
+			claims: orgClaims,
+			orgID:  orgClaims.OrgID,
+		},
+	}
+
+	for _, tc := range cases {
+		token, err := repo.Issue(context.Background(), tc.claims)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		for _, tkn := range []string{token.AccessToken, token.RefreshToken} {
+			c, err := repo.Parse(context.Background(), tkn)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.orgID, c.OrgID, fmt.Sprintf("%s: expected organization %s got %s\n", tc.desc, tc.orgID, c.OrgID))
+		}
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	keys := generateKeys(t)
+	revocations := mocks.NewRevocations()
+
+	oldKeys, err := jwt.ParseKeySet(privateKeyPEM(t, keys["RS256"]))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	oldRepo := jwt.NewKeySetRepository(oldKeys, accessDuration, refreshDuration, revocations)
+	oldToken, err := oldRepo.Issue(context.Background(), claims)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	rotated, err := jwt.ParseKeySet(privateKeyPEM(t, keys["EdDSA"]), publicKeyPEM(t, keys["RS256"]))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	rotatedRepo := jwt.NewKeySetRepository(rotated, accessDuration, refreshDuration, revocations)
+	newToken, err := rotatedRepo.Issue(context.Background(), claims)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	retired, err := jwt.ParseKeySet(privateKeyPEM(t, keys["EdDSA"]))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	retiredRepo := jwt.NewKeySetRepository(retired, accessDuration, refreshDuration, revocations)
+
+	secretRepo := jwt.NewRepository([]byte("secret"), accessDuration, refreshDuration, revocations)
+	secretToken, err := secretRepo.Issue(context.Background(), claims)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		repo  jwt.Repository
+		token string
+		err   error
+	}{
+		{
+			desc:  "parse token signed with the current key",
+			repo:  rotatedRepo,
+			token: newToken.AccessToken,
+		},
+		{
+			desc:  "parse token signed with the previous key",
+			repo:  rotatedRepo,
+			token: oldToken.AccessToken,
+		},
+		{
+			desc:  "parse token signed with the removed key",
+			repo:  retiredRepo,
+			token: oldToken.AccessToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "parse token signed with the shared secret",
+			repo:  rotatedRepo,
+			token: secretToken.AccessToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "parse token signed with the key using the shared secret",
+			repo:  secretRepo,
+			token: newToken.AccessToken,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := tc.repo.Parse(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRevokeAll(t *testing.T) {
+	repo := jwt.NewRepository([]byte("secret"), accessDuration, refreshDuration, mocks.NewRevocations())
+
+	token, err := repo.Issue(context.Background(), claims)
+	require.Nil(t, err, fmt.Sprintf("issue token: unexpected error: %s", err))
+
+	err = repo.RevokeAll(context.Background(), claims.ClientID)
+	require.Nil(t, err, fmt.Sprintf("revoke all tokens: unexpected error: %s", err))
+
+	// The token is issued within the same second as the revocation.
+	_, err = repo.Parse(context.Background(), token.AccessToken)
+	assert.True(t, errors.Contains(err, jwt.ErrRevoked), fmt.Sprintf("parse access token: expected %s got %s", jwt.ErrRevoked, err))
+	_, err = repo.Parse(context.Background(), token.RefreshToken)
+	assert.True(t, errors.Contains(err, jwt.ErrRevoked), fmt.Sprintf("parse refresh token: expected %s got %s", jwt.ErrRevoked, err))
+
+	// Tokens issued after the revocation stay valid, even within the same second.
+	time.Sleep(time.Millisecond)
+	token, err = repo.Issue(context.Background(), claims)
+	require.Nil(t, err, fmt.Sprintf("issue token: unexpected error: %s", err))
+	_, err = repo.Parse(context.Background(), token.AccessToken)
+	assert.Nil(t, err, fmt.Sprintf("parse access token issued after revocation: expected nil got %s", err))
+	_, err = repo.Parse(context.Background(), token.RefreshToken)
+	assert.Nil(t, err, fmt.Sprintf("parse refresh token issued after revocation: expected nil got %s", err))
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package http contains API-related concerns: endpoint definitions
+// and all resource representations.
+package http
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/keys"
+)
+
+func issueKeyEndpoint(svc keys.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(issueKeyReq)
+		if err := req.validate(); err != nil {
+			return issueKeyRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		key, err := svc.Issue(ctx, req.token, req.key())
+		if err != nil {
+			return issueKeyRes{}, err
+		}
+
+		return issueKeyRes{Key: key}, nil
+	}
+}
+
+func viewKeyEndpoint(svc keys.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(keyReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		key, err := svc.View(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewKeyRes{Key: key}, nil
+	}
+}
+
+func listKeysEndpoint(svc keys.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listKeysReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		pm := keys.Page{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.List(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listKeysRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Keys: []viewKeyRes{},
+		}
+		for _, key := range page.Keys {
+			res.Keys = append(res.Keys, viewKeyRes{Key: key})
+		}
+
+		return res, nil
+	}
+}
+
+func revokeKeyEndpoint(svc keys.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(keyReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.Revoke(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return revokeKeyRes{}, nil
+	}
+}
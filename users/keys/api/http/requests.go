@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/internal/api"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/keys"
+)
+
+type issueKeyReq struct {
+	token    string
+	Name     string     `json:"name,omitempty"`
+	Scope    keys.Scope `json:"scope,omitempty"`
+	Duration string     `json:"duration,omitempty"`
+}
+
+func (req issueKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" || len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if req.Duration != "" {
+		d, err := time.ParseDuration(req.Duration)
+		if err != nil || d <= 0 {
+			return errors.Wrap(errors.ErrMalformedEntity, keys.ErrInvalidExpiry)
+		}
+	}
+
+	return nil
+}
+
+// key returns the key to be issued. The duration is expected to be validated.
+func (req issueKeyReq) key() keys.Key {
+	key := keys.Key{
+		Name:  req.Name,
+		Scope: req.Scope,
+	}
+	if d, err := time.ParseDuration(req.Duration); err == nil {
+		key.ExpiresAt = time.Now().Add(d)
+	}
+
+	return key
+}
+
+type keyReq struct {
+	token string
+	id    string
+}
+
+func (req keyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listKeysReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listKeysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/users/keys"
+)
+
+var (
+	_ mainflux.Response = (*issueKeyRes)(nil)
+	_ mainflux.Response = (*viewKeyRes)(nil)
+	_ mainflux.Response = (*listKeysRes)(nil)
+	_ mainflux.Response = (*revokeKeyRes)(nil)
+)
+
+type pageRes struct {
+	Limit  uint64 `json:"limit"`
+	Offset uint64 `json:"offset"`
+	Total  uint64 `json:"total"`
+}
+
+type issueKeyRes struct {
+	keys.Key `json:",inline"`
+}
+
+func (res issueKeyRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res issueKeyRes) Headers() map[string]string {
+	return map[string]string{
+		"Location": fmt.Sprintf("/keys/%s", res.ID),
+	}
+}
+
+func (res issueKeyRes) Empty() bool {
+	return false
+}
+
+type viewKeyRes struct {
+	keys.Key `json:",inline"`
+}
+
+func (res viewKeyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewKeyRes) Empty() bool {
+	return false
+}
+
+type listKeysRes struct {
+	pageRes
+	Keys []viewKeyRes `json:"keys"`
+}
+
+func (res listKeysRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listKeysRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listKeysRes) Empty() bool {
+	return false
+}
+
+type revokeKeyRes struct{}
+
+func (res revokeKeyRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res revokeKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revokeKeyRes) Empty() bool {
+	return true
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/internal/api"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/keys"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc keys.Service, mux *bone.Mux, logger logger.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux.Post("/keys", otelhttp.NewHandler(kithttp.NewServer(
+		issueKeyEndpoint(svc),
+		decodeIssueKey,
+		api.EncodeResponse,
+		opts...,
+	), "issue_key"))
+
+	mux.Get("/keys", otelhttp.NewHandler(kithttp.NewServer(
+		listKeysEndpoint(svc),
+		decodeListKeys,
+		api.EncodeResponse,
+		opts...,
+	), "list_keys"))
+
+	mux.Get("/keys/:id", otelhttp.NewHandler(kithttp.NewServer(
+		viewKeyEndpoint(svc),
+		decodeKeyRequest,
+		api.EncodeResponse,
+		opts...,
+	), "view_key"))
+
+	mux.Delete("/keys/:id", otelhttp.NewHandler(kithttp.NewServer(
+		revokeKeyEndpoint(svc),
+		decodeKeyRequest,
+		api.EncodeResponse,
+		opts...,
+	), "revoke_key"))
+
+	return mux
+}
+
+func decodeIssueKey(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := issueKeyReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListKeys(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listKeysReq{
+		token:  apiutil.ExtractBearerToken(r),
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+func decodeKeyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := keyReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/users/keys"
+)
+
+var _ keys.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger mflog.Logger
+	svc    keys.Service
+}
+
+// LoggingMiddleware adds logging facilities to the API keys service.
+func LoggingMiddleware(svc keys.Service, logger mflog.Logger) keys.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// Issue logs the issue_key request. It logs the key name and ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Issue(ctx context.Context, token string, key keys.Key) (k keys.Key, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method issue_key with name %s and id %s took %s to complete", key.Name, k.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Issue(ctx, token, key)
+}
+
+// View logs the view_key request. It logs the key ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) View(ctx context.Context, token, id string) (k keys.Key, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_key with id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.View(ctx, token, id)
+}
+
+// List logs the list_keys request. It logs the page offset and limit and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) List(ctx context.Context, token string, pm keys.Page) (kp keys.KeysPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_keys with offset %d and limit %d took %s to complete", pm.Offset, pm.Limit, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.List(ctx, token, pm)
+}
+
+// Revoke logs the revoke_key request. It logs the key ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Revoke(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_key with id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Revoke(ctx, token, id)
+}
+
+// Identify logs the identify_key request. It logs the action, object and the time it took to complete the request.
+// The key value is never logged. If the request fails, it logs the error.
+func (lm *loggingMiddleware) Identify(ctx context.Context, value, action, object string) (key keys.Key, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method identify_key for action %s on object %s took %s to complete", action, object, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Identify(ctx, value, action, object)
+}
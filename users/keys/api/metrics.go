@@ -0,0 +1,74 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/users/keys"
+)
+
+var _ keys.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     keys.Service
+}
+
+// MetricsMiddleware instruments API keys service by tracking request count and latency.
+func MetricsMiddleware(svc keys.Service, counter metrics.Counter, latency metrics.Histogram) keys.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+// Issue instruments Issue method with metrics.
+func (ms *metricsMiddleware) Issue(ctx context.Context, token string, key keys.Key) (keys.Key, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "issue_key").Add(1)
+		ms.latency.With("method", "issue_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Issue(ctx, token, key)
+}
+
+// View instruments View method with metrics.
+func (ms *metricsMiddleware) View(ctx context.Context, token, id string) (keys.Key, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_key").Add(1)
+		ms.latency.With("method", "view_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.View(ctx, token, id)
+}
+
+// List instruments List method with metrics.
+func (ms *metricsMiddleware) List(ctx context.Context, token string, pm keys.Page) (keys.KeysPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_keys").Add(1)
+		ms.latency.With("method", "list_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.List(ctx, token, pm)
+}
+
+// Revoke instruments Revoke method with metrics.
+func (ms *metricsMiddleware) Revoke(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_key").Add(1)
+		ms.latency.With("method", "revoke_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Revoke(ctx, token, id)
+}
+
+// Identify instruments Identify method with metrics.
+func (ms *metricsMiddleware) Identify(ctx context.Context, value, action, object string) (keys.Key, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "identify_key").Add(1)
+		ms.latency.With("method", "identify_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Identify(ctx, value, action, object)
+}
@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package keys contains the domain concept definitions needed to
+// support Mainflux users API keys sub-service functionality.
+//
+// API keys are long-lived credentials owned by a user. Unlike the access
+// tokens issued by the users service, a key can be restricted to a set of
+// actions and objects and is only stored in its hashed form.
+package keys
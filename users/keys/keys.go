@@ -0,0 +1,159 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package keys
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/policies"
+)
+
+// Prefix is prepended to every API key value so that keys can be told apart
+// from access tokens.
+const Prefix = "mfk_"
+
+const maxNameSize = 254
+
+var (
+	// ErrKeyExpired indicates that the API key has expired.
+	ErrKeyExpired = errors.New("api key expired")
+
+	// ErrInvalidExpiry indicates that the API key expiry is in the past.
+	ErrInvalidExpiry = errors.New("api key expiry must be in the future")
+)
+
+// Scope restricts what an API key may be used for. An empty list of actions
+// or objects leaves that dimension unrestricted.
+type Scope struct {
+	Actions []string `json:"actions,omitempty"`
+	Objects []string `json:"objects,omitempty"`
+}
+
+// Key represents a user API key. The key issued with the organization
+// scoped token is scoped to the same organization.
+type Key struct {
+	ID        string    `json:"id"`
+	OwnerID   string    `json:"owner_id"`
+	OrgID     string    `json:"org_id,omitempty"`
+	Name      string    `json:"name"`
+	Secret    string    `json:"-"`
+	Value     string    `json:"value,omitempty"`
+	Scope     Scope     `json:"scope"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Page contains page metadata that helps navigation.
+type Page struct {
+	Total   uint64 `json:"total"`
+	Offset  uint64 `json:"offset"`
+	Limit   uint64 `json:"limit"`
+	OwnerID string `json:"owner_id,omitempty"`
+}
+
+// KeysPage contains a page of API keys.
+type KeysPage struct {
+	Page
+	Keys []Key
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// Issue creates a new API key owned by the user identified by the
+	// given access token. The returned key carries its value, which is
+	// not retrievable afterwards.
+	Issue(ctx context.Context, token string, key Key) (Key, error)
+
+	// View retrieves the API key with the given ID.
+	View(ctx context.Context, token, id string) (Key, error)
+
+	// List retrieves the API keys owned by the user.
+	List(ctx context.Context, token string, pm Page) (KeysPage, error)
+
+	// Revoke removes the API key with the given ID.
+	Revoke(ctx context.Context, token, id string) error
+
+	// Identify validates the API key value and returns the key. Keys with
+	// a restricted scope are only accepted when the action and object are
+	// within the scope, and only while the owner is enabled.
+	Identify(ctx context.Context, value, action, object string) (Key, error)
+}
+
+// Repository specifies an API key persistence API.
+type Repository interface {
+	// Save persists the API key.
+	Save(ctx context.Context, key Key) error
+
+	// Retrieve retrieves the API key with the given ID.
+	Retrieve(ctx context.Context, id string) (Key, error)
+
+	// RetrieveAll retrieves the API keys owned by the page owner.
+	RetrieveAll(ctx context.Context, pm Page) (KeysPage, error)
+
+	// Remove removes the API key with the given ID owned by the given user.
+	Remove(ctx context.Context, ownerID, id string) error
+}
+
+// IsKey reports whether the value has the shape of an API key.
+func IsKey(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Validate returns an error if the key representation is invalid.
+func (k Key) Validate() error {
+	if k.Name == "" || len(k.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+	for _, a := range k.Scope.Actions {
+		if !policies.ValidateAction(a) {
+			return apiutil.ErrMalformedPolicyAct
+		}
+	}
+	for _, o := range k.Scope.Objects {
+		if o == "" {
+			return apiutil.ErrMissingPolicyObj
+		}
+	}
+	if !k.ExpiresAt.IsZero() && !k.ExpiresAt.After(time.Now()) {
+		return ErrInvalidExpiry
+	}
+
+	return nil
+}
+
+// Expired reports whether the key has expired at the given time. Keys
+// without an expiry never expire.
+func (k Key) Expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// Allows reports whether the scope permits the action on the object.
+func (s Scope) Allows(action, object string) bool {
+	if len(s.Actions) > 0 && !contains(s.Actions, action) {
+		return false
+	}
+	if len(s.Objects) > 0 && !contains(s.Objects, object) {
+		return false
+	}
+
+	return true
+}
+
+func contains(vals []string, val string) bool {
+	if val == "" {
+		return false
+	}
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+
+	return false
+}
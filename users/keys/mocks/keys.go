@@ -0,0 +1,41 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/users/keys"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ keys.Repository = (*Repository)(nil)
+
+type Repository struct {
+	mock.Mock
+}
+
+func (m *Repository) Save(ctx context.Context, key keys.Key) error {
+	ret := m.Called(ctx, key)
+
+	return ret.Error(0)
+}
+
+func (m *Repository) Retrieve(ctx context.Context, id string) (keys.Key, error) {
+	ret := m.Called(ctx, id)
+
+	return ret.Get(0).(keys.Key), ret.Error(1)
+}
+
+func (m *Repository) RetrieveAll(ctx context.Context, pm keys.Page) (keys.KeysPage, error) {
+	ret := m.Called(ctx, pm)
+
+	return ret.Get(0).(keys.KeysPage), ret.Error(1)
+}
+
+func (m *Repository) Remove(ctx context.Context, ownerID, id string) error {
+	ret := m.Called(ctx, ownerID, id)
+
+	return ret.Error(0)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/keys"
+)
+
+var _ keys.Repository = (*krepo)(nil)
+
+type krepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of API keys repository.
+func NewRepository(db postgres.Database) keys.Repository {
+	return &krepo{
+		db: db,
+	}
+}
+
+func (kr krepo) Save(ctx context.Context, key keys.Key) error {
+	q := `INSERT INTO keys (id, owner_id, org_id, name, secret, actions, objects, expires_at, created_at)
+		VALUES (:id, :owner_id, NULLIF(:org_id, ''), :name, :secret, :actions, :objects, :expires_at, :created_at)`
+
+	dbk, err := toDBKey(key)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	row, err := kr.db.NamedQueryContext(ctx, q, dbk)
+	if err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	defer row.Close()
+
+	return nil
+}
+
+func (kr krepo) Retrieve(ctx context.Context, id string) (keys.Key, error) {
+	q := `SELECT id, owner_id, COALESCE(org_id, '') AS org_id, name, secret, actions, objects, expires_at, created_at
+		FROM keys WHERE id = $1`
+
+	dbk := dbKey{}
+	if err := kr.db.QueryRowxContext(ctx, q, id).StructScan(&dbk); err != nil {
+		if err == sql.ErrNoRows {
+			return keys.Key{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return keys.Key{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toKey(dbk), nil
+}
+
+func (kr krepo) RetrieveAll(ctx context.Context, pm keys.Page) (keys.KeysPage, error) {
+	q := `SELECT id, owner_id, COALESCE(org_id, '') AS org_id, name, actions, objects, expires_at, created_at
+		FROM keys WHERE owner_id = :owner_id ORDER BY created_at LIMIT :limit OFFSET :offset;`
+
+	params := dbKeysPage{
+		OwnerID: pm.OwnerID,
+		Offset:  pm.Offset,
+		Limit:   pm.Limit,
+	}
+	rows, err := kr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return keys.KeysPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []keys.Key
+	for rows.Next() {
+		dbk := dbKey{}
+		if err := rows.StructScan(&dbk); err != nil {
+			return keys.KeysPage{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		items = append(items, toKey(dbk))
+	}
+
+	cq := `SELECT COUNT(*) FROM keys WHERE owner_id = :owner_id;`
+
+	total, err := postgres.Total(ctx, kr.db, cq, params)
+	if err != nil {
+		return keys.KeysPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	page := keys.KeysPage{
+		Keys: items,
+		Page: keys.Page{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (kr krepo) Remove(ctx context.Context, ownerID, id string) error {
+	q := `DELETE FROM keys WHERE owner_id = $1 AND id = $2`
+
+	res, err := kr.db.ExecContext(ctx, q, ownerID, id)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbKey struct {
+	ID        string           `db:"id"`
+	OwnerID   string           `db:"owner_id"`
+	OrgID     string           `db:"org_id"`
+	Name      string           `db:"name"`
+	Secret    string           `db:"secret"`
+	Actions   pgtype.TextArray `db:"actions"`
+	Objects   pgtype.TextArray `db:"objects"`
+	ExpiresAt sql.NullTime     `db:"expires_at"`
+	CreatedAt time.Time        `db:"created_at"`
+}
+
+type dbKeysPage struct {
+	OwnerID string `db:"owner_id"`
+	Offset  uint64 `db:"offset"`
+	Limit   uint64 `db:"limit"`
+}
+
+func toDBKey(k keys.Key) (dbKey, error) {
+	var actions, objects pgtype.TextArray
+	if err := actions.Set(k.Scope.Actions); err != nil {
+		return dbKey{}, err
+	}
+	if err := objects.Set(k.Scope.Objects); err != nil {
+		return dbKey{}, err
+	}
+	var expiresAt sql.NullTime
+	if !k.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: k.ExpiresAt, Valid: true}
+	}
+
+	return dbKey{
+		ID:        k.ID,
+		OwnerID:   k.OwnerID,
+		OrgID:     k.OrgID,
+		Name:      k.Name,
+		Secret:    k.Secret,
+		Actions:   actions,
+		Objects:   objects,
+		ExpiresAt: expiresAt,
+		CreatedAt: k.CreatedAt,
+	}, nil
+}
+
+func toKey(dbk dbKey) keys.Key {
+	var actions, objects []string
+	for _, e := range dbk.Actions.Elements {
+		actions = append(actions, e.String)
+	}
+	for _, e := range dbk.Objects.Elements {
+		objects = append(objects, e.String)
+	}
+	var expiresAt time.Time
+	if dbk.ExpiresAt.Valid {
+		expiresAt = dbk.ExpiresAt.Time
+	}
+
+	return keys.Key{
+		ID:        dbk.ID,
+		OwnerID:   dbk.OwnerID,
+		OrgID:     dbk.OrgID,
+		Name:      dbk.Name,
+		Secret:    dbk.Secret,
+		Scope:     keys.Scope{Actions: actions, Objects: objects},
+		ExpiresAt: expiresAt,
+		CreatedAt: dbk.CreatedAt,
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	cpostgres "github.com/mainflux/mainflux/users/clients/postgres"
+	"github.com/mainflux/mainflux/users/keys"
+	kpostgres "github.com/mainflux/mainflux/users/keys/postgres"
+	"github.com/mainflux/mainflux/users/orgs"
+	opostgres "github.com/mainflux/mainflux/users/orgs/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idProvider = uuid.New()
+
+func saveClient(t *testing.T, name string) mfclients.Client {
+	crepo := cpostgres.NewRepository(database)
+	client := mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: name,
+		Credentials: mfclients.Credentials{
+			Identity: name,
+			Secret:   "pass",
+		},
+		Status: mfclients.EnabledStatus,
+	}
+	client, err := crepo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return client
+}
+
+func TestKeySave(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := kpostgres.NewRepository(database)
+	client := saveClient(t, "key-save@example.com")
+
+	key := keys.Key{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OwnerID:   client.ID,
+		Name:      "ci",
+		Secret:    "hash",
+		Scope:     keys.Scope{Actions: []string{"m_read"}, Objects: []string{"channel"}},
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}
+
+	cases := []struct {
+		desc string
+		key  keys.Key
+		err  error
+	}{
+		{
+			desc: "save new key",
+			key:  key,
+			err:  nil,
+		},
+		{
+			desc: "save key with duplicate name",
+			key: keys.Key{
+				ID:      testsutil.GenerateUUID(t, idProvider),
+				OwnerID: client.ID,
+				Name:    key.Name,
+				Secret:  "hash",
+			},
+			err: errors.ErrConflict,
+		},
+		{
+			desc: "save key without expiry",
+			key: keys.Key{
+				ID:      testsutil.GenerateUUID(t, idProvider),
+				OwnerID: client.ID,
+				Name:    "no-expiry",
+				Secret:  "hash",
+			},
+			err: nil,
+		},
+		{
+			desc: "save key for non-existing owner",
+			key: keys.Key{
+				ID:      testsutil.GenerateUUID(t, idProvider),
+				OwnerID: testsutil.GenerateUUID(t, idProvider),
+				Name:    "orphan",
+				Secret:  "hash",
+			},
+			err: errors.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestKeyRetrieve(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := kpostgres.NewRepository(database)
+	client := saveClient(t, "key-retrieve@example.com")
+
+	key := keys.Key{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OwnerID:   client.ID,
+		Name:      "ci",
+		Secret:    "hash",
+		Scope:     keys.Scope{Actions: []string{"m_read", "m_write"}},
+		CreatedAt: time.Now(),
+	}
+	err := repo.Save(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	org := orgs.Organization{ID: testsutil.GenerateUUID(t, idProvider), OwnerID: client.ID, Name: "keys", CreatedAt: time.Now()}
+	_, err = opostgres.NewRepository(database).Save(context.Background(), org, orgs.Member{OrgID: org.ID, MemberID: client.ID, Role: orgs.AdminRole, CreatedAt: org.CreatedAt})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	orgKey := keys.Key{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OwnerID:   client.ID,
+		OrgID:     org.ID,
+		Name:      "org",
+		Secret:    "hash",
+		CreatedAt: time.Now(),
+	}
+	err = repo.Save(context.Background(), orgKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		key  keys.Key
+		err  error
+	}{
+		{
+			desc: "retrieve existing key",
+			id:   key.ID,
+			key:  key,
+			err:  nil,
+		},
+		{
+			desc: "retrieve existing org key",
+			id:   orgKey.ID,
+			key:  orgKey,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing key",
+			id:   testsutil.GenerateUUID(t, idProvider),
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		k, err := repo.Retrieve(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.key.Secret, k.Secret, fmt.Sprintf("%s: expected secret %s got %s\n", tc.desc, tc.key.Secret, k.Secret))
+			assert.Equal(t, tc.key.Scope, k.Scope, fmt.Sprintf("%s: expected scope %v got %v\n", tc.desc, tc.key.Scope, k.Scope))
+			assert.Equal(t, tc.key.OrgID, k.OrgID, fmt.Sprintf("%s: expected org %s got %s\n", tc.desc, tc.key.OrgID, k.OrgID))
+			assert.True(t, k.ExpiresAt.IsZero(), fmt.Sprintf("%s: expected no expiry got %s\n", tc.desc, k.ExpiresAt))
+		}
+	}
+}
+
+func TestKeyRetrieveAll(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := kpostgres.NewRepository(database)
+	client := saveClient(t, "key-retrieve-all@example.com")
+	other := saveClient(t, "key-retrieve-all-other@example.com")
+
+	num := 10
+	for i := 0; i < num; i++ {
+		key := keys.Key{
+			ID:        testsutil.GenerateUUID(t, idProvider),
+			OwnerID:   client.ID,
+			Name:      fmt.Sprintf("key-%d", i),
+			Secret:    "hash",
+			CreatedAt: time.Now(),
+		}
+		err := repo.Save(context.Background(), key)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		page  keys.Page
+		size  int
+		total uint64
+	}{
+		{
+			desc:  "retrieve all keys",
+			page:  keys.Page{OwnerID: client.ID, Offset: 0, Limit: uint64(num)},
+			size:  num,
+			total: uint64(num),
+		},
+		{
+			desc:  "retrieve a page of keys",
+			page:  keys.Page{OwnerID: client.ID, Offset: 5, Limit: 3},
+			size:  3,
+			total: uint64(num),
+		},
+		{
+			desc:  "retrieve keys of another owner",
+			page:  keys.Page{OwnerID: other.ID, Offset: 0, Limit: uint64(num)},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Keys), fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, len(page.Keys)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestKeyRemove(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := kpostgres.NewRepository(database)
+	client := saveClient(t, "key-remove@example.com")
+
+	key := keys.Key{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OwnerID:   client.ID,
+		Name:      "ci",
+		Secret:    "hash",
+		CreatedAt: time.Now(),
+	}
+	err := repo.Save(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		ownerID string
+		id      string
+		err     error
+	}{
+		{
+			desc:    "remove key of another owner",
+			ownerID: testsutil.GenerateUUID(t, idProvider),
+			id:      key.ID,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "remove existing key",
+			ownerID: client.ID,
+			id:      key.ID,
+			err:     nil,
+		},
+		{
+			desc:    "remove removed key",
+			ownerID: client.ID,
+			id:      key.ID,
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(context.Background(), tc.ownerID, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
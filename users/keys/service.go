@@ -0,0 +1,176 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package keys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/clients/postgres"
+	"github.com/mainflux/mainflux/users/jwt"
+)
+
+const secretSize = 32
+
+type service struct {
+	keys       Repository
+	clients    postgres.Repository
+	tokens     jwt.Repository
+	idProvider mainflux.IDProvider
+}
+
+// NewService returns a new API keys service implementation.
+func NewService(k Repository, c postgres.Repository, t jwt.Repository, idp mainflux.IDProvider) Service {
+	return service{
+		keys:       k,
+		clients:    c,
+		tokens:     t,
+		idProvider: idp,
+	}
+}
+
+func (svc service) Issue(ctx context.Context, token string, key Key) (Key, error) {
+	claims, err := svc.tokens.Parse(ctx, token)
+	if err != nil {
+		return Key{}, err
+	}
+	if claims.Type != jwt.AccessToken {
+		return Key{}, errors.ErrAuthentication
+	}
+	if err := key.Validate(); err != nil {
+		return Key{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if key.ID, err = svc.idProvider.ID(); err != nil {
+		return Key{}, err
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		return Key{}, err
+	}
+	key.OwnerID = claims.ClientID
+	key.OrgID = claims.OrgID
+	key.Secret = hash(secret)
+	key.CreatedAt = time.Now()
+	if err := svc.keys.Save(ctx, key); err != nil {
+		return Key{}, err
+	}
+	key.Value = Prefix + key.ID + "." + secret
+
+	return key, nil
+}
+
+func (svc service) View(ctx context.Context, token, id string) (Key, error) {
+	ownerID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Key{}, err
+	}
+	key, err := svc.keys.Retrieve(ctx, id)
+	if err != nil {
+		return Key{}, err
+	}
+	if key.OwnerID != ownerID {
+		return Key{}, errors.ErrNotFound
+	}
+
+	return key, nil
+}
+
+func (svc service) List(ctx context.Context, token string, pm Page) (KeysPage, error) {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return KeysPage{}, err
+	}
+	pm.OwnerID = id
+
+	return svc.keys.RetrieveAll(ctx, pm)
+}
+
+func (svc service) Revoke(ctx context.Context, token, id string) error {
+	ownerID, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return svc.keys.Remove(ctx, ownerID, id)
+}
+
+func (svc service) Identify(ctx context.Context, value, action, object string) (Key, error) {
+	id, secret, ok := split(value)
+	if !ok {
+		return Key{}, errors.ErrAuthentication
+	}
+	key, err := svc.keys.Retrieve(ctx, id)
+	if err != nil {
+		return Key{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(hash(secret)), []byte(key.Secret)) != 1 {
+		return Key{}, errors.ErrAuthentication
+	}
+	if key.Expired(time.Now()) {
+		return Key{}, errors.Wrap(errors.ErrAuthentication, ErrKeyExpired)
+	}
+	if !key.Scope.Allows(action, object) {
+		return Key{}, errors.ErrAuthorization
+	}
+	// Keys outlive the tokens of their owner, which are revoked once the
+	// owner is disabled, so the owner status is checked on every use.
+	owner, err := svc.clients.RetrieveByID(ctx, key.OwnerID)
+	if err != nil {
+		return Key{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	if owner.Status != mfclients.EnabledStatus {
+		return Key{}, errors.Wrap(errors.ErrAuthentication, mfclients.ErrDisableClient)
+	}
+	key.Secret = ""
+
+	return key, nil
+}
+
+func (svc service) identify(ctx context.Context, token string) (string, error) {
+	claims, err := svc.tokens.Parse(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if claims.Type != jwt.AccessToken {
+		return "", errors.ErrAuthentication
+	}
+
+	return claims.ClientID, nil
+}
+
+// split extracts the key ID and secret from the API key value.
+func split(value string) (string, string, bool) {
+	if !IsKey(value) {
+		return "", "", false
+	}
+	id, secret, ok := strings.Cut(strings.TrimPrefix(value, Prefix), ".")
+	if !ok || id == "" || secret == "" {
+		return "", "", false
+	}
+
+	return id, secret, true
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,334 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package keys_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	cmocks "github.com/mainflux/mainflux/users/clients/mocks"
+	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/keys"
+	"github.com/mainflux/mainflux/users/keys/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	idProvider      = uuid.New()
+	secret          = "strongsecret"
+	inValidToken    = "invalidToken"
+	accessDuration  = time.Minute * 1
+	refreshDuration = time.Minute * 10
+)
+
+func newService() (keys.Service, *mocks.Repository, *cmocks.Repository, jwt.Repository) {
+	kRepo := new(mocks.Repository)
+	cRepo := new(cmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+
+	return keys.NewService(kRepo, cRepo, tokenizer, idProvider), kRepo, cRepo, tokenizer
+}
+
+func issueToken(t *testing.T, tokenizer jwt.Repository, id string) jwt.Token {
+	token, err := tokenizer.Issue(context.Background(), jwt.Claims{ClientID: id, Email: "user@example.com"})
+	require.Nil(t, err, fmt.Sprintf("issue token unexpected error: %s", err))
+
+	return token
+}
+
+func TestIssue(t *testing.T) {
+	svc, kRepo, _, tokenizer := newService()
+	token := issueToken(t, tokenizer, testsutil.GenerateUUID(t, idProvider))
+	orgID := testsutil.GenerateUUID(t, idProvider)
+	orgToken, err := tokenizer.Issue(context.Background(), jwt.Claims{ClientID: testsutil.GenerateUUID(t, idProvider), OrgID: orgID})
+	require.Nil(t, err, fmt.Sprintf("issue org token unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		key   keys.Key
+		token string
+		orgID string
+		err   error
+	}{
+		{
+			desc:  "issue key",
+			key:   keys.Key{Name: "ci", ExpiresAt: time.Now().Add(time.Hour)},
+			token: token.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "issue key with scope",
+			key:   keys.Key{Name: "reader", Scope: keys.Scope{Actions: []string{"m_read"}, Objects: []string{"channel"}}},
+			token: token.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "issue key with org token",
+			key:   keys.Key{Name: "org"},
+			token: orgToken.AccessToken,
+			orgID: orgID,
+			err:   nil,
+		},
+		{
+			desc:  "issue key with invalid token",
+			key:   keys.Key{Name: "ci"},
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "issue key with refresh token",
+			key:   keys.Key{Name: "ci"},
+			token: token.RefreshToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "issue key without name",
+			key:   keys.Key{},
+			token: token.AccessToken,
+			err:   apiutil.ErrNameSize,
+		},
+		{
+			desc:  "issue key with invalid action",
+			key:   keys.Key{Name: "ci", Scope: keys.Scope{Actions: []string{"wrong"}}},
+			token: token.AccessToken,
+			err:   apiutil.ErrMalformedPolicyAct,
+		},
+		{
+			desc:  "issue key with expiry in the past",
+			key:   keys.Key{Name: "ci", ExpiresAt: time.Now().Add(-time.Hour)},
+			token: token.AccessToken,
+			err:   keys.ErrInvalidExpiry,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := kRepo.On("Save", context.Background(), mock.Anything).Return(nil)
+		key, err := svc.Issue(context.Background(), tc.token, tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.True(t, keys.IsKey(key.Value), fmt.Sprintf("%s: expected key value got %s\n", tc.desc, key.Value))
+			assert.NotContains(t, key.Value, key.Secret, fmt.Sprintf("%s: expected hashed secret\n", tc.desc))
+			assert.Equal(t, tc.orgID, key.OrgID, fmt.Sprintf("%s: expected org %s got %s\n", tc.desc, tc.orgID, key.OrgID))
+			ok := repoCall.Parent.AssertCalled(t, "Save", context.Background(), mock.Anything)
+			assert.True(t, ok, fmt.Sprintf("Save was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	svc, kRepo, cRepo, tokenizer := newService()
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, ownerID)
+
+	stored := map[string]keys.Key{}
+	repoCall := kRepo.On("Save", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+		k := args.Get(1).(keys.Key)
+		stored[k.ID] = k
+	}).Return(nil)
+	issue := func(k keys.Key) keys.Key {
+		key, err := svc.Issue(context.Background(), token.AccessToken, k)
+		require.Nil(t, err, fmt.Sprintf("issue key unexpected error: %s", err))
+		return key
+	}
+	unrestricted := issue(keys.Key{Name: "ci"})
+	scoped := issue(keys.Key{Name: "reader", Scope: keys.Scope{Actions: []string{"m_read"}, Objects: []string{"channel"}}})
+	expiring := issue(keys.Key{Name: "expiring", ExpiresAt: time.Now().Add(time.Hour)})
+	repoCall.Unset()
+
+	expired := stored[expiring.ID]
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	stored[expiring.ID] = expired
+
+	cases := []struct {
+		desc   string
+		value  string
+		action string
+		object string
+		status mfclients.Status
+		id     string
+		err    error
+	}{
+		{
+			desc:  "identify unrestricted key",
+			value: unrestricted.Value,
+			id:    ownerID,
+			err:   nil,
+		},
+		{
+			desc:   "identify scoped key within scope",
+			value:  scoped.Value,
+			action: "m_read",
+			object: "channel",
+			id:     ownerID,
+			err:    nil,
+		},
+		{
+			desc:   "identify scoped key with action outside scope",
+			value:  scoped.Value,
+			action: "m_write",
+			object: "channel",
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "identify scoped key with object outside scope",
+			value:  scoped.Value,
+			action: "m_read",
+			object: "other",
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:  "identify scoped key without action and object",
+			value: scoped.Value,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:   "identify key of disabled owner",
+			value:  unrestricted.Value,
+			status: mfclients.DisabledStatus,
+			err:    mfclients.ErrDisableClient,
+		},
+		{
+			desc:  "identify expired key",
+			value: expiring.Value,
+			err:   keys.ErrKeyExpired,
+		},
+		{
+			desc:  "identify key with wrong secret",
+			value: keys.Prefix + unrestricted.ID + ".wrong",
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "identify non-existing key",
+			value: keys.Prefix + testsutil.GenerateUUID(t, idProvider) + ".secret",
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "identify malformed key",
+			value: keys.Prefix + unrestricted.ID,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "identify access token",
+			value: token.AccessToken,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoErr := errors.ErrNotFound
+		key, ok := stored[keyID(tc.value)]
+		if ok {
+			repoErr = nil
+		}
+		repoCall := kRepo.On("Retrieve", context.Background(), mock.Anything).Return(key, repoErr)
+		repoCall1 := cRepo.On("RetrieveByID", context.Background(), ownerID).Return(mfclients.Client{ID: ownerID, Status: tc.status}, nil)
+		identified, err := svc.Identify(context.Background(), tc.value, tc.action, tc.object)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.id, identified.OwnerID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, identified.OwnerID))
+		assert.Empty(t, identified.Secret, fmt.Sprintf("%s: expected no secret hash\n", tc.desc))
+		repoCall.Unset()
+		repoCall1.Unset()
+	}
+}
+
+func keyID(value string) string {
+	id, _, _ := strings.Cut(strings.TrimPrefix(value, keys.Prefix), ".")
+	return id
+}
+
+func TestView(t *testing.T) {
+	svc, kRepo, _, tokenizer := newService()
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, ownerID)
+	other := issueToken(t, tokenizer, testsutil.GenerateUUID(t, idProvider))
+	key := keys.Key{ID: testsutil.GenerateUUID(t, idProvider), OwnerID: ownerID, Name: "ci"}
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "view own key",
+			token: token.AccessToken,
+			id:    key.ID,
+			err:   nil,
+		},
+		{
+			desc:  "view key of another user",
+			token: other.AccessToken,
+			id:    key.ID,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "view key with invalid token",
+			token: inValidToken,
+			id:    key.ID,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := kRepo.On("Retrieve", context.Background(), key.ID).Return(key, nil)
+		k, err := svc.View(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, key, k, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, key, k))
+		}
+		repoCall.Unset()
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	svc, kRepo, _, tokenizer := newService()
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, ownerID)
+	id := testsutil.GenerateUUID(t, idProvider)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "revoke key",
+			token: token.AccessToken,
+			id:    id,
+			err:   nil,
+		},
+		{
+			desc:  "revoke non-existing key",
+			token: token.AccessToken,
+			id:    testsutil.GenerateUUID(t, idProvider),
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "revoke key with invalid token",
+			token: inValidToken,
+			id:    id,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := kRepo.On("Remove", context.Background(), ownerID, tc.id).Return(tc.err)
+		err := svc.Revoke(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+	}
+}
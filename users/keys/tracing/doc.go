@@ -0,0 +1,12 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing provides tracing instrumentation for Mainflux Users API keys service.
+//
+// This package provides tracing middleware for Mainflux Users API keys service.
+// It can be used to trace incoming requests and add tracing capabilities to
+// Mainflux Users API keys service.
+//
+// For more details about tracing instrumentation for Mainflux messaging refer
+// to the documentation at https://docs.mainflux.io/tracing/.
+package tracing
@@ -0,0 +1,74 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/users/keys"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ keys.Service = (*tracingMiddleware)(nil)
+
+type tracingMiddleware struct {
+	tracer trace.Tracer
+	ksvc   keys.Service
+}
+
+// New returns a new API keys service with tracing capabilities.
+func New(ksvc keys.Service, tracer trace.Tracer) keys.Service {
+	return &tracingMiddleware{tracer, ksvc}
+}
+
+// Issue traces the "Issue" operation of the wrapped keys.Service.
+func (tm *tracingMiddleware) Issue(ctx context.Context, token string, key keys.Key) (keys.Key, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_issue_key", trace.WithAttributes(
+		attribute.String("name", key.Name),
+		attribute.StringSlice("actions", key.Scope.Actions),
+		attribute.StringSlice("objects", key.Scope.Objects),
+	))
+	defer span.End()
+
+	return tm.ksvc.Issue(ctx, token, key)
+}
+
+// View traces the "View" operation of the wrapped keys.Service.
+func (tm *tracingMiddleware) View(ctx context.Context, token, id string) (keys.Key, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_key", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.ksvc.View(ctx, token, id)
+}
+
+// List traces the "List" operation of the wrapped keys.Service.
+func (tm *tracingMiddleware) List(ctx context.Context, token string, pm keys.Page) (keys.KeysPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_keys", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.ksvc.List(ctx, token, pm)
+}
+
+// Revoke traces the "Revoke" operation of the wrapped keys.Service.
+func (tm *tracingMiddleware) Revoke(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_revoke_key", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.ksvc.Revoke(ctx, token, id)
+}
+
+// Identify traces the "Identify" operation of the wrapped keys.Service.
+func (tm *tracingMiddleware) Identify(ctx context.Context, value, action, object string) (keys.Key, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_identify_key", trace.WithAttributes(
+		attribute.String("action", action),
+		attribute.String("object", object),
+	))
+	defer span.End()
+
+	return tm.ksvc.Identify(ctx, value, action, object)
+}
@@ -0,0 +1,12 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lockout contains the domain concept definitions needed to protect
+// Mainflux users login against password guessing.
+//
+// Failed login attempts are counted per user identity and per source IP.
+// Each failed attempt delays the next attempt for the same identity, with
+// the delay doubling on every failure, and too many failures lock the
+// identity or the source IP out for a while. Locked identities can be
+// unlocked by admins before the lockout expires.
+package lockout
@@ -0,0 +1,224 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	failPrefix  = "fail"
+	delayPrefix = "delay"
+	lockPrefix  = "lock"
+
+	identityKey = "identity"
+	ipKey       = "ip"
+)
+
+var (
+	// ErrLocked indicates that the identity or the source IP is locked out.
+	ErrLocked = errors.New("login is temporarily locked due to too many failed attempts")
+
+	// ErrThrottled indicates that the login is attempted too soon after the failed one.
+	ErrThrottled = errors.New("login attempted too soon after the failed attempt")
+
+	// ErrLockedOut indicates that the failed attempt has locked the identity or the source IP out.
+	ErrLockedOut = errors.New("too many failed login attempts")
+)
+
+// Config defines the failed login attempts limits. Zero attempts disable
+// the corresponding lockout and zero delay disables the delays.
+type Config struct {
+	MaxAttempts   uint64        `env:"MAX_ATTEMPTS"    envDefault:"5"`
+	IPMaxAttempts uint64        `env:"IP_MAX_ATTEMPTS" envDefault:"20"`
+	Window        time.Duration `env:"WINDOW"          envDefault:"15m"`
+	Duration      time.Duration `env:"DURATION"        envDefault:"15m"`
+	Delay         time.Duration `env:"DELAY"           envDefault:"1s"`
+	MaxDelay      time.Duration `env:"MAX_DELAY"       envDefault:"30s"`
+}
+
+// Repository specifies failed attempts counters and blocks persistence API.
+type Repository interface {
+	// Increment increments the counter with the given key and returns its
+	// value. The counter expires after the window since its first increment.
+	Increment(ctx context.Context, key string, window time.Duration) (uint64, error)
+
+	// Block blocks the key for the given duration.
+	Block(ctx context.Context, key string, duration time.Duration) error
+
+	// Blocked reports whether the key is blocked.
+	Blocked(ctx context.Context, key string) (bool, error)
+
+	// Remove removes the counters and blocks with the given keys.
+	Remove(ctx context.Context, keys ...string) error
+}
+
+// Limiter limits the failed login attempts per identity and source IP.
+// An empty source IP is not tracked.
+type Limiter interface {
+	// Allow checks whether the login attempt is allowed, and returns
+	// ErrLocked or ErrThrottled if it is not.
+	Allow(ctx context.Context, identity, ip string) error
+
+	// Fail records the failed login attempt and reports whether it has
+	// locked the identity or the source IP out.
+	Fail(ctx context.Context, identity, ip string) (bool, error)
+
+	// Succeed clears the failed login attempts of the identity.
+	Succeed(ctx context.Context, identity string) error
+
+	// Unlock removes the lockout and the failed login attempts of the identity.
+	Unlock(ctx context.Context, identity string) error
+}
+
+var _ Limiter = (*limiter)(nil)
+
+type limiter struct {
+	repo   Repository
+	config Config
+	scope  string
+}
+
+// NewLimiter returns a Limiter which delays the login attempts after a
+// failed one for the same identity, doubling the delay on every failure up
+// to the maximum delay, and locks the identity or the source IP out once
+// the failures within the window reach the configured number.
+func NewLimiter(repo Repository, config Config) Limiter {
+	return limiter{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// NewScopedLimiter returns a Limiter same as NewLimiter, which keeps its
+// counters and blocks apart from the ones of the other scopes, so that the
+// attempts of different kinds are limited separately.
+func NewScopedLimiter(repo Repository, config Config, scope string) Limiter {
+	return limiter{
+		repo:   repo,
+		config: config,
+		scope:  scope,
+	}
+}
+
+func (l limiter) Allow(ctx context.Context, identity, ip string) error {
+	for _, k := range l.subjects(identity, ip) {
+		locked, err := l.repo.Blocked(ctx, l.key(lockPrefix, k))
+		if err != nil {
+			return err
+		}
+		if locked {
+			return ErrLocked
+		}
+	}
+	delayed, err := l.repo.Blocked(ctx, l.key(delayPrefix, subject(identityKey, identity)))
+	if err != nil {
+		return err
+	}
+	if delayed {
+		return ErrThrottled
+	}
+
+	return nil
+}
+
+func (l limiter) Fail(ctx context.Context, identity, ip string) (bool, error) {
+	idLocked, err := l.fail(ctx, subject(identityKey, identity), l.config.MaxAttempts, true)
+	if err != nil {
+		return false, err
+	}
+	if ip == "" {
+		return idLocked, nil
+	}
+	ipLocked, err := l.fail(ctx, subject(ipKey, ip), l.config.IPMaxAttempts, false)
+	if err != nil {
+		return false, err
+	}
+
+	return idLocked || ipLocked, nil
+}
+
+func (l limiter) Succeed(ctx context.Context, identity string) error {
+	s := subject(identityKey, identity)
+	return l.repo.Remove(ctx, l.key(failPrefix, s), l.key(delayPrefix, s))
+}
+
+func (l limiter) Unlock(ctx context.Context, identity string) error {
+	s := subject(identityKey, identity)
+	return l.repo.Remove(ctx, l.key(failPrefix, s), l.key(delayPrefix, s), l.key(lockPrefix, s))
+}
+
+func (l limiter) fail(ctx context.Context, s string, max uint64, delay bool) (bool, error) {
+	failures, err := l.repo.Increment(ctx, l.key(failPrefix, s), l.config.Window)
+	if err != nil {
+		return false, err
+	}
+	if max > 0 && failures >= max {
+		if err := l.repo.Block(ctx, l.key(lockPrefix, s), l.config.Duration); err != nil {
+			return false, err
+		}
+		// Lockout starts a new window, so the counting starts over
+		// once the lockout expires or is removed.
+		return true, l.repo.Remove(ctx, l.key(failPrefix, s), l.key(delayPrefix, s))
+	}
+	if delay && l.config.Delay > 0 {
+		return false, l.repo.Block(ctx, l.key(delayPrefix, s), l.delay(failures))
+	}
+
+	return false, nil
+}
+
+func (l limiter) delay(failures uint64) time.Duration {
+	d := l.config.Delay
+	for i := uint64(1); i < failures; i++ {
+		if l.config.MaxDelay > 0 && d >= l.config.MaxDelay {
+			break
+		}
+		d *= 2
+	}
+	if l.config.MaxDelay > 0 && d > l.config.MaxDelay {
+		return l.config.MaxDelay
+	}
+
+	return d
+}
+
+func (l limiter) subjects(identity, ip string) []string {
+	subjects := []string{subject(identityKey, identity)}
+	if ip != "" {
+		subjects = append(subjects, subject(ipKey, ip))
+	}
+
+	return subjects
+}
+
+func subject(kind, value string) string {
+	return fmt.Sprintf("%s:%s", kind, value)
+}
+
+func (l limiter) key(prefix, subject string) string {
+	if l.scope == "" {
+		return fmt.Sprintf("%s:%s", prefix, subject)
+	}
+	return fmt.Sprintf("%s:%s:%s", l.scope, prefix, subject)
+}
+
+type sourceIPKey struct{}
+
+// WithSourceIP returns a copy of the context carrying the source IP of
+// the login attempt.
+func WithSourceIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, sourceIPKey{}, ip)
+}
+
+// SourceIP returns the source IP of the login attempt carried by the
+// context, or an empty string if there is none.
+func SourceIP(ctx context.Context) string {
+	ip, _ := ctx.Value(sourceIPKey{}).(string)
+	return ip
+}
@@ -0,0 +1,170 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/lockout"
+	"github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	identity = "user@example.com"
+	ip       = "192.168.0.1"
+)
+
+func TestFail(t *testing.T) {
+	l := lockout.NewLimiter(mocks.NewRepository(), lockout.Config{
+		MaxAttempts:   3,
+		IPMaxAttempts: 4,
+		Window:        time.Minute,
+		Duration:      time.Minute,
+	})
+
+	cases := []struct {
+		desc     string
+		identity string
+		ip       string
+		locked   bool
+		allowErr error
+	}{
+		{
+			desc:     "fail the first attempt",
+			identity: identity,
+			ip:       ip,
+		},
+		{
+			desc:     "fail the second attempt",
+			identity: identity,
+			ip:       ip,
+		},
+		{
+			desc:     "fail the attempt reaching the identity limit",
+			identity: identity,
+			ip:       ip,
+			locked:   true,
+			allowErr: lockout.ErrLocked,
+		},
+		{
+			desc:     "fail the attempt for the other identity without source IP",
+			identity: "other@example.com",
+		},
+		{
+			desc:     "fail the attempt reaching the source IP limit",
+			identity: "other@example.com",
+			ip:       ip,
+			locked:   true,
+			allowErr: lockout.ErrLocked,
+		},
+	}
+
+	for _, tc := range cases {
+		locked, err := l.Fail(context.Background(), tc.identity, tc.ip)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.locked, locked, fmt.Sprintf("%s: expected locked %t got %t\n", tc.desc, tc.locked, locked))
+		err = l.Allow(context.Background(), tc.identity, tc.ip)
+		assert.True(t, errors.Contains(err, tc.allowErr), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.allowErr, err))
+	}
+
+	err := l.Allow(context.Background(), "other@example.com", "")
+	assert.Nil(t, err, fmt.Sprintf("allow the other identity without source IP: expected nil got %s\n", err))
+}
+
+func TestDelay(t *testing.T) {
+	delay := 50 * time.Millisecond
+	l := lockout.NewLimiter(mocks.NewRepository(), lockout.Config{
+		Window:   time.Minute,
+		Delay:    delay,
+		MaxDelay: 2 * delay,
+	})
+
+	cases := []struct {
+		desc  string
+		delay time.Duration
+	}{
+		{
+			desc:  "delay after the first failed attempt",
+			delay: delay,
+		},
+		{
+			desc:  "double the delay after the second failed attempt",
+			delay: 2 * delay,
+		},
+		{
+			desc:  "limit the delay after the third failed attempt",
+			delay: 2 * delay,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := l.Fail(context.Background(), identity, ip)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		err = l.Allow(context.Background(), identity, ip)
+		assert.True(t, errors.Contains(err, lockout.ErrThrottled), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, lockout.ErrThrottled, err))
+		time.Sleep(tc.delay - delay/2)
+		err = l.Allow(context.Background(), identity, ip)
+		assert.True(t, errors.Contains(err, lockout.ErrThrottled), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, lockout.ErrThrottled, err))
+		time.Sleep(delay)
+		err = l.Allow(context.Background(), identity, ip)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s\n", tc.desc, err))
+	}
+
+	err := l.Succeed(context.Background(), identity)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = l.Fail(context.Background(), identity, ip)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	time.Sleep(delay)
+	err = l.Allow(context.Background(), identity, ip)
+	assert.Nil(t, err, fmt.Sprintf("reset the delay after the successful attempt: expected nil got %s\n", err))
+}
+
+func TestUnlock(t *testing.T) {
+	l := lockout.NewLimiter(mocks.NewRepository(), lockout.Config{
+		MaxAttempts: 1,
+		Window:      time.Minute,
+		Duration:    time.Minute,
+	})
+
+	locked, err := l.Fail(context.Background(), identity, ip)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, locked, "expected identity to be locked out")
+
+	err = l.Unlock(context.Background(), identity)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = l.Allow(context.Background(), identity, ip)
+	assert.Nil(t, err, fmt.Sprintf("allow unlocked identity: expected nil got %s\n", err))
+}
+
+func TestScopedLimiter(t *testing.T) {
+	repo := mocks.NewRepository()
+	config := lockout.Config{
+		MaxAttempts: 1,
+		Window:      time.Minute,
+		Duration:    time.Minute,
+	}
+	l := lockout.NewLimiter(repo, config)
+	sl := lockout.NewScopedLimiter(repo, config, "mfa")
+
+	locked, err := sl.Fail(context.Background(), identity, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, locked, "expected identity to be locked out in the scope")
+
+	err = sl.Allow(context.Background(), identity, "")
+	assert.True(t, errors.Contains(err, lockout.ErrLocked), fmt.Sprintf("allow identity locked out in the scope: expected %s got %s\n", lockout.ErrLocked, err))
+	err = l.Allow(context.Background(), identity, "")
+	assert.Nil(t, err, fmt.Sprintf("allow identity locked out in the other scope: expected nil got %s\n", err))
+}
+
+func TestSourceIP(t *testing.T) {
+	assert.Empty(t, lockout.SourceIP(context.Background()), "expected no source IP in empty context")
+	ctx := lockout.WithSourceIP(context.Background(), ip)
+	assert.Equal(t, ip, lockout.SourceIP(ctx), fmt.Sprintf("expected source IP %s got %s\n", ip, lockout.SourceIP(ctx)))
+}
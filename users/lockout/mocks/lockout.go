@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/users/lockout"
+)
+
+var _ lockout.Repository = (*lockoutRepositoryMock)(nil)
+
+type lockoutRepositoryMock struct {
+	mu       sync.Mutex
+	counters map[string]uint64
+	expiry   map[string]time.Time
+}
+
+// NewRepository creates in-memory failed login attempts and lockouts repository.
+func NewRepository() lockout.Repository {
+	return &lockoutRepositoryMock{
+		counters: make(map[string]uint64),
+		expiry:   make(map[string]time.Time),
+	}
+}
+
+func (lrm *lockoutRepositoryMock) Increment(_ context.Context, key string, window time.Duration) (uint64, error) {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	lrm.expire(key)
+	lrm.counters[key]++
+	if lrm.counters[key] == 1 && window > 0 {
+		lrm.expiry[key] = time.Now().Add(window)
+	}
+
+	return lrm.counters[key], nil
+}
+
+func (lrm *lockoutRepositoryMock) Block(_ context.Context, key string, duration time.Duration) error {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	lrm.counters[key] = 1
+	delete(lrm.expiry, key)
+	if duration > 0 {
+		lrm.expiry[key] = time.Now().Add(duration)
+	}
+
+	return nil
+}
+
+func (lrm *lockoutRepositoryMock) Blocked(_ context.Context, key string) (bool, error) {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	lrm.expire(key)
+	_, ok := lrm.counters[key]
+
+	return ok, nil
+}
+
+func (lrm *lockoutRepositoryMock) Remove(_ context.Context, keys ...string) error {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	for _, key := range keys {
+		delete(lrm.counters, key)
+		delete(lrm.expiry, key)
+	}
+
+	return nil
+}
+
+func (lrm *lockoutRepositoryMock) expire(key string) {
+	if exp, ok := lrm.expiry[key]; ok && time.Now().After(exp) {
+		delete(lrm.counters, key)
+		delete(lrm.expiry, key)
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the Redis implementation of the failed login
+// attempts and lockouts repository.
+package redis
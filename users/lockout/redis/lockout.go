@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/lockout"
+)
+
+const (
+	keyPrefix = "lockout"
+	blocked   = "1"
+)
+
+var _ lockout.Repository = (*lockoutRepository)(nil)
+
+type lockoutRepository struct {
+	client *redis.Client
+}
+
+// NewRepository returns redis failed login attempts and lockouts repository.
+func NewRepository(client *redis.Client) lockout.Repository {
+	return &lockoutRepository{
+		client: client,
+	}
+}
+
+func (lr *lockoutRepository) Increment(ctx context.Context, key string, window time.Duration) (uint64, error) {
+	k := fmt.Sprintf("%s:%s", keyPrefix, key)
+	val, err := lr.client.Incr(ctx, k).Result()
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	// The window starts with the first failure, so only the first
+	// increment sets the expiration.
+	if val == 1 && window > 0 {
+		if err := lr.client.Expire(ctx, k, window).Err(); err != nil {
+			return 0, errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+	}
+
+	return uint64(val), nil
+}
+
+func (lr *lockoutRepository) Block(ctx context.Context, key string, duration time.Duration) error {
+	k := fmt.Sprintf("%s:%s", keyPrefix, key)
+	if err := lr.client.Set(ctx, k, blocked, duration).Err(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (lr *lockoutRepository) Blocked(ctx context.Context, key string) (bool, error) {
+	k := fmt.Sprintf("%s:%s", keyPrefix, key)
+	n, err := lr.client.Exists(ctx, k).Result()
+	if err != nil {
+		return false, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return n > 0, nil
+}
+
+func (lr *lockoutRepository) Remove(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	ks := make([]string, len(keys))
+	for i, key := range keys {
+		ks[i] = fmt.Sprintf("%s:%s", keyPrefix, key)
+	}
+	if err := lr.client.Del(ctx, ks...).Err(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/mfa"
+)
+
+func enrollEndpoint(svc mfa.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(enrollReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		p, err := svc.Enroll(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return enrollRes{Provisioning: p}, nil
+	}
+}
+
+func requestEnrollmentEndpoint(svc mfa.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(enrollReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RequestEnrollment(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func confirmEndpoint(svc mfa.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(codeReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.Confirm(ctx, req.token, req.Code); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func verifyEndpoint(svc mfa.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(codeReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		token, err := svc.Verify(ctx, req.token, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{
+			AccessToken:  token.AccessToken,
+			RefreshToken: token.RefreshToken,
+			AccessType:   token.AccessType,
+		}, nil
+	}
+}
+
+func disableEndpoint(svc mfa.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(codeReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.Disable(ctx, req.token, req.Code); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func resetEndpoint(svc mfa.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(resetReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.Reset(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/mfa"
+)
+
+type enrollReq struct {
+	token string
+}
+
+func (req enrollReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type codeReq struct {
+	token string
+	Code  string `json:"code,omitempty"`
+}
+
+func (req codeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Code == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, mfa.ErrInvalidCode)
+	}
+
+	return nil
+}
+
+type resetReq struct {
+	token string
+	id    string
+}
+
+func (req resetReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/users/mfa"
+)
+
+var (
+	_ mainflux.Response = (*enrollRes)(nil)
+	_ mainflux.Response = (*tokenRes)(nil)
+	_ mainflux.Response = (*emptyRes)(nil)
+)
+
+type enrollRes struct {
+	mfa.Provisioning `json:",inline"`
+}
+
+func (res enrollRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res enrollRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res enrollRes) Empty() bool {
+	return false
+}
+
+type tokenRes struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	AccessType   string `json:"access_type,omitempty"`
+}
+
+func (res tokenRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res tokenRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res tokenRes) Empty() bool {
+	return false
+}
+
+type emptyRes struct{}
+
+func (res emptyRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res emptyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res emptyRes) Empty() bool {
+	return true
+}
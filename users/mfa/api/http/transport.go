@@ -0,0 +1,97 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/internal/api"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/mfa"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc mfa.Service, mux *bone.Mux, logger logger.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux.Post("/users/mfa/enroll", otelhttp.NewHandler(kithttp.NewServer(
+		enrollEndpoint(svc),
+		decodeEnroll,
+		api.EncodeResponse,
+		opts...,
+	), "mfa_enroll"))
+
+	mux.Post("/users/mfa/enroll/request", otelhttp.NewHandler(kithttp.NewServer(
+		requestEnrollmentEndpoint(svc),
+		decodeEnroll,
+		api.EncodeResponse,
+		opts...,
+	), "mfa_request_enrollment"))
+
+	mux.Post("/users/mfa/confirm", otelhttp.NewHandler(kithttp.NewServer(
+		confirmEndpoint(svc),
+		decodeCode,
+		api.EncodeResponse,
+		opts...,
+	), "mfa_confirm"))
+
+	mux.Post("/users/mfa/verify", otelhttp.NewHandler(kithttp.NewServer(
+		verifyEndpoint(svc),
+		decodeCode,
+		api.EncodeResponse,
+		opts...,
+	), "mfa_verify"))
+
+	mux.Post("/users/mfa/unenroll", otelhttp.NewHandler(kithttp.NewServer(
+		disableEndpoint(svc),
+		decodeCode,
+		api.EncodeResponse,
+		opts...,
+	), "mfa_disable"))
+
+	mux.Delete("/users/:id/mfa", otelhttp.NewHandler(kithttp.NewServer(
+		resetEndpoint(svc),
+		decodeReset,
+		api.EncodeResponse,
+		opts...,
+	), "mfa_reset"))
+
+	return mux
+}
+
+func decodeEnroll(_ context.Context, r *http.Request) (interface{}, error) {
+	return enrollReq{token: apiutil.ExtractBearerToken(r)}, nil
+}
+
+func decodeCode(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := codeReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeReset(_ context.Context, r *http.Request) (interface{}, error) {
+	req := resetReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/mfa"
+)
+
+var _ mfa.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger mflog.Logger
+	svc    mfa.Service
+}
+
+// LoggingMiddleware adds logging facilities to the MFA service.
+func LoggingMiddleware(svc mfa.Service, logger mflog.Logger) mfa.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// Enroll logs the mfa_enroll request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Enroll(ctx context.Context, token string) (p mfa.Provisioning, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method mfa_enroll took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Enroll(ctx, token)
+}
+
+// RequestEnrollment logs the mfa_request_enrollment request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RequestEnrollment(ctx context.Context, challenge string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method mfa_request_enrollment took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.RequestEnrollment(ctx, challenge)
+}
+
+// Confirm logs the mfa_confirm request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Confirm(ctx context.Context, token, code string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method mfa_confirm took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Confirm(ctx, token, code)
+}
+
+// Verify logs the mfa_verify request. It logs the token type and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Verify(ctx context.Context, challenge, code string) (t jwt.Token, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method mfa_verify of type %s took %s to complete", t.AccessType, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Verify(ctx, challenge, code)
+}
+
+// Disable logs the mfa_disable request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Disable(ctx context.Context, token, code string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method mfa_disable took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Disable(ctx, token, code)
+}
+
+// Reset logs the mfa_reset request. It logs the user ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Reset(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method mfa_reset for user %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Reset(ctx, token, id)
+}
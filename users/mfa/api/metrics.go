@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/mfa"
+)
+
+var _ mfa.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     mfa.Service
+}
+
+// MetricsMiddleware instruments MFA service by tracking request count and latency.
+func MetricsMiddleware(svc mfa.Service, counter metrics.Counter, latency metrics.Histogram) mfa.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+// Enroll instruments Enroll method with metrics.
+func (ms *metricsMiddleware) Enroll(ctx context.Context, token string) (mfa.Provisioning, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "mfa_enroll").Add(1)
+		ms.latency.With("method", "mfa_enroll").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Enroll(ctx, token)
+}
+
+// RequestEnrollment instruments RequestEnrollment method with metrics.
+func (ms *metricsMiddleware) RequestEnrollment(ctx context.Context, challenge string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "mfa_request_enrollment").Add(1)
+		ms.latency.With("method", "mfa_request_enrollment").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RequestEnrollment(ctx, challenge)
+}
+
+// Confirm instruments Confirm method with metrics.
+func (ms *metricsMiddleware) Confirm(ctx context.Context, token, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "mfa_confirm").Add(1)
+		ms.latency.With("method", "mfa_confirm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Confirm(ctx, token, code)
+}
+
+// Verify instruments Verify method with metrics.
+func (ms *metricsMiddleware) Verify(ctx context.Context, challenge, code string) (jwt.Token, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "mfa_verify").Add(1)
+		ms.latency.With("method", "mfa_verify").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Verify(ctx, challenge, code)
+}
+
+// Disable instruments Disable method with metrics.
+func (ms *metricsMiddleware) Disable(ctx context.Context, token, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "mfa_disable").Add(1)
+		ms.latency.With("method", "mfa_disable").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Disable(ctx, token, code)
+}
+
+// Reset instruments Reset method with metrics.
+func (ms *metricsMiddleware) Reset(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "mfa_reset").Add(1)
+		ms.latency.With("method", "mfa_reset").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Reset(ctx, token, id)
+}
@@ -0,0 +1,12 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mfa contains the domain concept definitions needed to support
+// Mainflux users two-factor authentication sub-service functionality.
+//
+// Users enroll a time-based one-time password (TOTP, RFC 6238) generator,
+// such as an authenticator app. Once the enrollment is confirmed, issuing
+// tokens takes two steps: the user credentials are exchanged for a
+// short-lived challenge token, which is exchanged for the access and
+// refresh token together with a one-time password or a recovery code.
+package mfa
@@ -0,0 +1,168 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mfa
+
+import (
+	"context"
+	"time"
+
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/jwt"
+)
+
+var (
+	// ErrInvalidCode indicates that the one-time password or recovery code is invalid.
+	ErrInvalidCode = errors.New("invalid one-time password or recovery code")
+
+	// ErrNotEnrolled indicates that the user has not enrolled MFA.
+	ErrNotEnrolled = errors.New("multi-factor authentication is not enrolled")
+
+	// ErrEnrolled indicates that the user has already confirmed MFA enrollment.
+	ErrEnrolled = errors.New("multi-factor authentication is already enrolled")
+)
+
+// Emailer sends the MFA enrollment e-mails.
+type Emailer interface {
+	// SendEnrollment sends an e-mail to the user with a link to enroll MFA.
+	SendEnrollment(to []string, user, token string) error
+}
+
+// Config defines the options that are used when enrolling MFA and issuing tokens.
+type Config struct {
+	Issuer        string `env:"ISSUER"         envDefault:"Mainflux"`
+	EnforceAdmins bool   `env:"ENFORCE_ADMINS" envDefault:"false"`
+	EnrollURL     string `env:"ENROLL_URL"     envDefault:"http://localhost/mfa-enroll"`
+}
+
+// Enrollment represents the user TOTP generator enrollment.
+type Enrollment struct {
+	ClientID      string
+	Secret        string
+	Confirmed     bool
+	RecoveryCodes []string // RecoveryCodes contains hashes of the unused recovery codes.
+	LastStep      int64    // LastStep is the time step of the last accepted one-time password.
+	CreatedAt     time.Time
+}
+
+// Provisioning contains the data needed to set up the TOTP generator.
+// The URI is meant to be rendered as a QR code, and the recovery codes
+// are shown only once.
+type Provisioning struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// Enroll starts the MFA enrollment of the user identified by the given
+	// access or MFA enrollment token. Enrolling again replaces the pending
+	// enrollment, while the confirmed one has to be disabled first.
+	Enroll(ctx context.Context, token string) (Provisioning, error)
+
+	// RequestEnrollment sends the MFA enrollment token to the e-mail of the
+	// user identified by the given MFA challenge token, so that the user
+	// which can't obtain the access token without the second factor can
+	// enroll it after confirming the e-mail ownership.
+	RequestEnrollment(ctx context.Context, challenge string) error
+
+	// Confirm enables MFA for the user by verifying the one-time password
+	// generated from the pending enrollment.
+	Confirm(ctx context.Context, token, code string) error
+
+	// Verify exchanges the MFA challenge token and a one-time password or
+	// recovery code for the access and refresh token. A pending enrollment
+	// is confirmed by the first valid one-time password. Too many failed
+	// verifications lock the user out and revoke the challenge.
+	Verify(ctx context.Context, challenge, code string) (jwt.Token, error)
+
+	// Disable disables MFA for the user, which requires a valid one-time
+	// password or recovery code.
+	Disable(ctx context.Context, token, code string) error
+
+	// Reset removes MFA enrollment of the user with the given ID. Only
+	// admins are allowed to reset MFA, which also removes the lockout of
+	// the failed verifications.
+	Reset(ctx context.Context, token, id string) error
+}
+
+// Repository specifies MFA enrollments persistence API.
+type Repository interface {
+	// Save persists the enrollment, replacing the pending one. Confirmed
+	// enrollment can't be replaced.
+	Save(ctx context.Context, e Enrollment) error
+
+	// Retrieve retrieves the enrollment of the client with the given ID.
+	Retrieve(ctx context.Context, clientID string) (Enrollment, error)
+
+	// Update updates the enrollment confirmation, recovery codes and last step.
+	Update(ctx context.Context, e Enrollment) error
+
+	// Remove removes the enrollment of the client with the given ID.
+	Remove(ctx context.Context, clientID string) error
+}
+
+// Authenticator decides whether the client has to pass the second
+// authentication factor before the tokens are issued to it.
+type Authenticator interface {
+	// Required reports whether the second factor is required for the client.
+	Required(ctx context.Context, client mfclients.Client) (bool, error)
+}
+
+var _ Authenticator = (*authenticator)(nil)
+
+type authenticator struct {
+	enrollments   Repository
+	enforceAdmins bool
+}
+
+// NewAuthenticator returns an Authenticator which requires the second factor
+// from the clients with confirmed enrollment. If enforceAdmins is set, it is
+// required from all the admins, which have to enroll MFA on their next login.
+func NewAuthenticator(enrollments Repository, enforceAdmins bool) Authenticator {
+	return authenticator{
+		enrollments:   enrollments,
+		enforceAdmins: enforceAdmins,
+	}
+}
+
+func (a authenticator) Required(ctx context.Context, client mfclients.Client) (bool, error) {
+	if a.enforceAdmins && client.Role == mfclients.AdminRole {
+		return true, nil
+	}
+	e, err := a.enrollments.Retrieve(ctx, client.ID)
+	switch {
+	case errors.Contains(err, errors.ErrNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return e.Confirmed, nil
+}
+
+// Login issues the tokens to the enabled client which passed the first
+// authentication factor. If the second factor is required, only the MFA
+// challenge token is issued, which is exchanged for the access and refresh
+// token once the second factor is verified.
+func Login(ctx context.Context, a Authenticator, tokens jwt.Repository, client mfclients.Client) (jwt.Token, error) {
+	if client.Status != mfclients.EnabledStatus {
+		return jwt.Token{}, errors.Wrap(errors.ErrAuthentication, mfclients.ErrDisableClient)
+	}
+	claims := jwt.Claims{
+		ClientID: client.ID,
+		Email:    client.Credentials.Identity,
+	}
+	required, err := a.Required(ctx, client)
+	if err != nil {
+		return jwt.Token{}, err
+	}
+	if required {
+		return tokens.Challenge(ctx, claims)
+	}
+
+	return tokens.Issue(ctx, claims)
+}
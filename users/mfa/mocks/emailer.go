@@ -0,0 +1,44 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/users/mfa"
+)
+
+var _ mfa.Emailer = (*Emailer)(nil)
+
+// Emailer is the MFA e-mails mock, which keeps the last enrollment token
+// sent to each address.
+type Emailer struct {
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// NewEmailer creates the MFA e-mails mock.
+func NewEmailer() *Emailer {
+	return &Emailer{
+		tokens: make(map[string]string),
+	}
+}
+
+func (e *Emailer) SendEnrollment(to []string, _, token string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, addr := range to {
+		e.tokens[addr] = token
+	}
+	return nil
+}
+
+// Token returns the last enrollment token sent to the address.
+func (e *Emailer) Token(addr string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.tokens[addr]
+}
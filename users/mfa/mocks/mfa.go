@@ -0,0 +1,74 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/mfa"
+)
+
+var _ mfa.Repository = (*enrollmentsMock)(nil)
+
+type enrollmentsMock struct {
+	mu          sync.Mutex
+	enrollments map[string]mfa.Enrollment
+}
+
+// NewRepository creates in-memory MFA enrollments repository.
+func NewRepository() mfa.Repository {
+	return &enrollmentsMock{
+		enrollments: make(map[string]mfa.Enrollment),
+	}
+}
+
+func (em *enrollmentsMock) Save(_ context.Context, e mfa.Enrollment) error {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	if cur, ok := em.enrollments[e.ClientID]; ok && cur.Confirmed {
+		return errors.ErrConflict
+	}
+	em.enrollments[e.ClientID] = e
+	return nil
+}
+
+func (em *enrollmentsMock) Retrieve(_ context.Context, clientID string) (mfa.Enrollment, error) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	e, ok := em.enrollments[clientID]
+	if !ok {
+		return mfa.Enrollment{}, errors.ErrNotFound
+	}
+	return e, nil
+}
+
+func (em *enrollmentsMock) Update(_ context.Context, e mfa.Enrollment) error {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	cur, ok := em.enrollments[e.ClientID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	cur.Confirmed = e.Confirmed
+	cur.RecoveryCodes = e.RecoveryCodes
+	cur.LastStep = e.LastStep
+	em.enrollments[e.ClientID] = cur
+	return nil
+}
+
+func (em *enrollmentsMock) Remove(_ context.Context, clientID string) error {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	if _, ok := em.enrollments[clientID]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(em.enrollments, clientID)
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/mfa"
+)
+
+var _ mfa.Repository = (*mrepo)(nil)
+
+type mrepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of MFA enrollments repository.
+func NewRepository(db postgres.Database) mfa.Repository {
+	return &mrepo{
+		db: db,
+	}
+}
+
+func (mr mrepo) Save(ctx context.Context, e mfa.Enrollment) error {
+	// The pending enrollment is replaced, while the confirmed one is left
+	// intact and reported as a conflict.
+	q := `INSERT INTO mfa (client_id, secret, confirmed, recovery_codes, last_step, created_at)
+		VALUES (:client_id, :secret, :confirmed, :recovery_codes, :last_step, :created_at)
+		ON CONFLICT (client_id) DO UPDATE SET secret = :secret, confirmed = :confirmed,
+		recovery_codes = :recovery_codes, last_step = :last_step, created_at = :created_at
+		WHERE mfa.confirmed = FALSE`
+
+	dbe, err := toDBEnrollment(e)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	res, err := mr.db.NamedExecContext(ctx, q, dbe)
+	if err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return errors.ErrConflict
+	}
+
+	return nil
+}
+
+func (mr mrepo) Retrieve(ctx context.Context, clientID string) (mfa.Enrollment, error) {
+	q := `SELECT client_id, secret, confirmed, recovery_codes, last_step, created_at
+		FROM mfa WHERE client_id = $1`
+
+	dbe := dbEnrollment{}
+	if err := mr.db.QueryRowxContext(ctx, q, clientID).StructScan(&dbe); err != nil {
+		if err == sql.ErrNoRows {
+			return mfa.Enrollment{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return mfa.Enrollment{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toEnrollment(dbe), nil
+}
+
+func (mr mrepo) Update(ctx context.Context, e mfa.Enrollment) error {
+	q := `UPDATE mfa SET confirmed = :confirmed, recovery_codes = :recovery_codes, last_step = :last_step
+		WHERE client_id = :client_id`
+
+	dbe, err := toDBEnrollment(e)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	res, err := mr.db.NamedExecContext(ctx, q, dbe)
+	if err != nil {
+		return postgres.HandleError(err, errors.ErrUpdateEntity)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (mr mrepo) Remove(ctx context.Context, clientID string) error {
+	q := `DELETE FROM mfa WHERE client_id = $1`
+
+	res, err := mr.db.ExecContext(ctx, q, clientID)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbEnrollment struct {
+	ClientID      string           `db:"client_id"`
+	Secret        string           `db:"secret"`
+	Confirmed     bool             `db:"confirmed"`
+	RecoveryCodes pgtype.TextArray `db:"recovery_codes"`
+	LastStep      int64            `db:"last_step"`
+	CreatedAt     time.Time        `db:"created_at"`
+}
+
+func toDBEnrollment(e mfa.Enrollment) (dbEnrollment, error) {
+	var codes pgtype.TextArray
+	if err := codes.Set(e.RecoveryCodes); err != nil {
+		return dbEnrollment{}, err
+	}
+
+	return dbEnrollment{
+		ClientID:      e.ClientID,
+		Secret:        e.Secret,
+		Confirmed:     e.Confirmed,
+		RecoveryCodes: codes,
+		LastStep:      e.LastStep,
+		CreatedAt:     e.CreatedAt,
+	}, nil
+}
+
+func toEnrollment(dbe dbEnrollment) mfa.Enrollment {
+	var codes []string
+	for _, e := range dbe.RecoveryCodes.Elements {
+		codes = append(codes, e.String)
+	}
+
+	return mfa.Enrollment{
+		ClientID:      dbe.ClientID,
+		Secret:        dbe.Secret,
+		Confirmed:     dbe.Confirmed,
+		RecoveryCodes: codes,
+		LastStep:      dbe.LastStep,
+		CreatedAt:     dbe.CreatedAt,
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	cpostgres "github.com/mainflux/mainflux/users/clients/postgres"
+	"github.com/mainflux/mainflux/users/mfa"
+	mpostgres "github.com/mainflux/mainflux/users/mfa/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idProvider = uuid.New()
+
+func saveClient(t *testing.T, name string) mfclients.Client {
+	crepo := cpostgres.NewRepository(database)
+	client := mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: name,
+		Credentials: mfclients.Credentials{
+			Identity: name,
+			Secret:   "pass",
+		},
+		Status: mfclients.EnabledStatus,
+	}
+	client, err := crepo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return client
+}
+
+func TestEnrollmentSave(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := mpostgres.NewRepository(database)
+	pending := saveClient(t, "mfa-pending@example.com")
+	confirmed := saveClient(t, "mfa-confirmed@example.com")
+
+	err := repo.Save(context.Background(), mfa.Enrollment{ClientID: confirmed.ID, Secret: "secret", CreatedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.Update(context.Background(), mfa.Enrollment{ClientID: confirmed.ID, Confirmed: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc       string
+		enrollment mfa.Enrollment
+		err        error
+	}{
+		{
+			desc:       "save enrollment",
+			enrollment: mfa.Enrollment{ClientID: pending.ID, Secret: "secret", RecoveryCodes: []string{"hash"}, CreatedAt: time.Now()},
+			err:        nil,
+		},
+		{
+			desc:       "replace pending enrollment",
+			enrollment: mfa.Enrollment{ClientID: pending.ID, Secret: "other", RecoveryCodes: []string{"other"}, CreatedAt: time.Now()},
+			err:        nil,
+		},
+		{
+			desc:       "replace confirmed enrollment",
+			enrollment: mfa.Enrollment{ClientID: confirmed.ID, Secret: "other", CreatedAt: time.Now()},
+			err:        errors.ErrConflict,
+		},
+		{
+			desc:       "save enrollment of non-existing client",
+			enrollment: mfa.Enrollment{ClientID: testsutil.GenerateUUID(t, idProvider), Secret: "secret", CreatedAt: time.Now()},
+			err:        errors.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.enrollment)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			e, err := repo.Retrieve(context.Background(), tc.enrollment.ClientID)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.enrollment.Secret, e.Secret, fmt.Sprintf("%s: expected secret %s got %s\n", tc.desc, tc.enrollment.Secret, e.Secret))
+			assert.Equal(t, tc.enrollment.RecoveryCodes, e.RecoveryCodes, fmt.Sprintf("%s: expected recovery codes %v got %v\n", tc.desc, tc.enrollment.RecoveryCodes, e.RecoveryCodes))
+		}
+	}
+}
+
+func TestEnrollmentRetrieve(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := mpostgres.NewRepository(database)
+	client := saveClient(t, "mfa-retrieve@example.com")
+
+	enrollment := mfa.Enrollment{
+		ClientID:      client.ID,
+		Secret:        "secret",
+		RecoveryCodes: []string{"first", "second"},
+		CreatedAt:     time.Now(),
+	}
+	err := repo.Save(context.Background(), enrollment)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		clientID string
+		err      error
+	}{
+		{
+			desc:     "retrieve existing enrollment",
+			clientID: client.ID,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve non-existing enrollment",
+			clientID: testsutil.GenerateUUID(t, idProvider),
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		e, err := repo.Retrieve(context.Background(), tc.clientID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, enrollment.Secret, e.Secret, fmt.Sprintf("%s: expected secret %s got %s\n", tc.desc, enrollment.Secret, e.Secret))
+			assert.False(t, e.Confirmed, fmt.Sprintf("%s: expected pending enrollment\n", tc.desc))
+		}
+	}
+}
+
+func TestEnrollmentUpdate(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := mpostgres.NewRepository(database)
+	client := saveClient(t, "mfa-update@example.com")
+
+	err := repo.Save(context.Background(), mfa.Enrollment{ClientID: client.ID, Secret: "secret", RecoveryCodes: []string{"first", "second"}, CreatedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc       string
+		enrollment mfa.Enrollment
+		err        error
+	}{
+		{
+			desc:       "update enrollment",
+			enrollment: mfa.Enrollment{ClientID: client.ID, Confirmed: true, RecoveryCodes: []string{"second"}, LastStep: 42},
+			err:        nil,
+		},
+		{
+			desc:       "update non-existing enrollment",
+			enrollment: mfa.Enrollment{ClientID: testsutil.GenerateUUID(t, idProvider), Confirmed: true},
+			err:        errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Update(context.Background(), tc.enrollment)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			e, err := repo.Retrieve(context.Background(), tc.enrollment.ClientID)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.True(t, e.Confirmed, fmt.Sprintf("%s: expected confirmed enrollment\n", tc.desc))
+			assert.Equal(t, tc.enrollment.RecoveryCodes, e.RecoveryCodes, fmt.Sprintf("%s: expected recovery codes %v got %v\n", tc.desc, tc.enrollment.RecoveryCodes, e.RecoveryCodes))
+			assert.Equal(t, tc.enrollment.LastStep, e.LastStep, fmt.Sprintf("%s: expected last step %d got %d\n", tc.desc, tc.enrollment.LastStep, e.LastStep))
+		}
+	}
+}
+
+func TestEnrollmentRemove(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := mpostgres.NewRepository(database)
+	client := saveClient(t, "mfa-remove@example.com")
+
+	err := repo.Save(context.Background(), mfa.Enrollment{ClientID: client.ID, Secret: "secret", CreatedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		clientID string
+		err      error
+	}{
+		{
+			desc:     "remove enrollment",
+			clientID: client.ID,
+			err:      nil,
+		},
+		{
+			desc:     "remove removed enrollment",
+			clientID: client.ID,
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(context.Background(), tc.clientID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
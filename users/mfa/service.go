@@ -0,0 +1,339 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/clients/postgres"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/lockout"
+	"github.com/mainflux/mainflux/users/policies"
+)
+
+const (
+	recoveryCodesCount = 10
+	recoveryCodeSize   = 10
+)
+
+type service struct {
+	enrollments Repository
+	clients     postgres.Repository
+	policies    policies.Repository
+	tokens      jwt.Repository
+	lockout     lockout.Limiter
+	emailer     Emailer
+	issuer      string
+}
+
+// NewService returns a new MFA service implementation. The limiter limits
+// the failed verifications per user.
+func NewService(e Repository, c postgres.Repository, p policies.Repository, t jwt.Repository, l lockout.Limiter, em Emailer, cfg Config) Service {
+	return service{
+		enrollments: e,
+		clients:     c,
+		policies:    p,
+		tokens:      t,
+		lockout:     l,
+		emailer:     em,
+		issuer:      cfg.Issuer,
+	}
+}
+
+func (svc service) Enroll(ctx context.Context, token string) (Provisioning, error) {
+	claims, err := svc.tokens.Parse(ctx, token)
+	if err != nil {
+		return Provisioning{}, err
+	}
+	// The challenge token proves only the password, so it can't be used to
+	// enroll the second factor, which would turn it into the full login.
+	if claims.Type != jwt.AccessToken && claims.Type != jwt.EnrollmentToken {
+		return Provisioning{}, errors.ErrAuthentication
+	}
+	e, err := svc.enrollments.Retrieve(ctx, claims.ClientID)
+	switch {
+	case err == nil && e.Confirmed:
+		return Provisioning{}, errors.Wrap(errors.ErrConflict, ErrEnrolled)
+	case err != nil && !errors.Contains(err, errors.ErrNotFound):
+		return Provisioning{}, err
+	}
+
+	secret, err := GenerateSecret()
+	if err != nil {
+		return Provisioning{}, err
+	}
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return Provisioning{}, err
+	}
+	e = Enrollment{
+		ClientID:      claims.ClientID,
+		Secret:        secret,
+		RecoveryCodes: hashes,
+		CreatedAt:     time.Now(),
+	}
+	if err := svc.enrollments.Save(ctx, e); err != nil {
+		return Provisioning{}, err
+	}
+	// Enrollment token is single-use, same as the enrollment link.
+	if claims.Type == jwt.EnrollmentToken {
+		if err := svc.tokens.Revoke(ctx, claims); err != nil {
+			return Provisioning{}, err
+		}
+	}
+
+	return Provisioning{
+		Secret:        secret,
+		URI:           provisioningURI(svc.issuer, claims.Email, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (svc service) RequestEnrollment(ctx context.Context, challenge string) error {
+	claims, err := svc.tokens.Parse(ctx, challenge)
+	if err != nil {
+		return err
+	}
+	if claims.Type != jwt.MFAToken {
+		return errors.ErrAuthentication
+	}
+	e, err := svc.enrollments.Retrieve(ctx, claims.ClientID)
+	switch {
+	case err == nil && e.Confirmed:
+		return errors.Wrap(errors.ErrConflict, ErrEnrolled)
+	case err != nil && !errors.Contains(err, errors.ErrNotFound):
+		return err
+	}
+	client, err := svc.clients.RetrieveByID(ctx, claims.ClientID)
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+	if client.Status != mfclients.EnabledStatus {
+		return errors.Wrap(errors.ErrAuthentication, mfclients.ErrDisableClient)
+	}
+	token, err := svc.tokens.Enrollment(ctx, jwt.Claims{
+		ClientID: client.ID,
+		Email:    client.Credentials.Identity,
+	})
+	if err != nil {
+		return err
+	}
+
+	return svc.emailer.SendEnrollment([]string{client.Credentials.Identity}, client.Name, token)
+}
+
+func (svc service) Confirm(ctx context.Context, token, code string) error {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.lockout.Allow(ctx, id, ""); err != nil {
+		return errors.Wrap(errors.ErrTooManyRequests, err)
+	}
+	e, err := svc.retrieve(ctx, id)
+	if err != nil {
+		return err
+	}
+	if e.Confirmed {
+		return errors.Wrap(errors.ErrConflict, ErrEnrolled)
+	}
+	if err := svc.check(&e, code); err != nil {
+		return svc.fail(ctx, id, err)
+	}
+	e.Confirmed = true
+	if err := svc.enrollments.Update(ctx, e); err != nil {
+		return err
+	}
+
+	return svc.lockout.Succeed(ctx, id)
+}
+
+func (svc service) Verify(ctx context.Context, challenge, code string) (jwt.Token, error) {
+	claims, err := svc.tokens.Parse(ctx, challenge)
+	if err != nil {
+		return jwt.Token{}, err
+	}
+	if claims.Type != jwt.MFAToken {
+		return jwt.Token{}, errors.ErrAuthentication
+	}
+	if err := svc.lockout.Allow(ctx, claims.ClientID, ""); err != nil {
+		return jwt.Token{}, errors.Wrap(errors.ErrTooManyRequests, err)
+	}
+	e, err := svc.retrieve(ctx, claims.ClientID)
+	if err != nil {
+		return jwt.Token{}, err
+	}
+	if err := svc.check(&e, code); err != nil {
+		return jwt.Token{}, svc.failVerify(ctx, claims, err)
+	}
+	e.Confirmed = true
+	if err := svc.enrollments.Update(ctx, e); err != nil {
+		return jwt.Token{}, err
+	}
+	if err := svc.lockout.Succeed(ctx, claims.ClientID); err != nil {
+		return jwt.Token{}, err
+	}
+
+	client, err := svc.clients.RetrieveByID(ctx, claims.ClientID)
+	if err != nil {
+		return jwt.Token{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	if client.Status != mfclients.EnabledStatus {
+		return jwt.Token{}, errors.Wrap(errors.ErrAuthentication, mfclients.ErrDisableClient)
+	}
+	// Challenge token is single-use, same as the one-time password.
+	if err := svc.tokens.Revoke(ctx, claims); err != nil {
+		return jwt.Token{}, err
+	}
+
+	return svc.tokens.Issue(ctx, jwt.Claims{
+		ClientID: client.ID,
+		Email:    client.Credentials.Identity,
+	})
+}
+
+func (svc service) Disable(ctx context.Context, token, code string) error {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.lockout.Allow(ctx, id, ""); err != nil {
+		return errors.Wrap(errors.ErrTooManyRequests, err)
+	}
+	e, err := svc.retrieve(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := svc.check(&e, code); err != nil {
+		return svc.fail(ctx, id, err)
+	}
+	if err := svc.enrollments.Remove(ctx, id); err != nil {
+		return err
+	}
+
+	return svc.lockout.Succeed(ctx, id)
+}
+
+func (svc service) Reset(ctx context.Context, token, id string) error {
+	adminID, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.policies.CheckAdmin(ctx, adminID); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+	if err := svc.enrollments.Remove(ctx, id); err != nil {
+		return err
+	}
+
+	return svc.lockout.Unlock(ctx, id)
+}
+
+// failVerify records the failed verification. Once the failures lock the
+// user out, the challenge is revoked, so that a new one can only be obtained
+// by logging in again.
+func (svc service) failVerify(ctx context.Context, claims jwt.Claims, err error) error {
+	err = svc.fail(ctx, claims.ClientID, err)
+	if !errors.Contains(err, lockout.ErrLockedOut) {
+		return err
+	}
+	if err := svc.tokens.Revoke(ctx, claims); err != nil {
+		return err
+	}
+
+	return err
+}
+
+// fail records the failed one-time password or recovery code check, which
+// is counted together for the verifications, confirmations and disabling.
+func (svc service) fail(ctx context.Context, clientID string, err error) error {
+	locked, lerr := svc.lockout.Fail(ctx, clientID, "")
+	if lerr != nil {
+		return lerr
+	}
+	if locked {
+		return errors.Wrap(errors.ErrTooManyRequests, lockout.ErrLockedOut)
+	}
+
+	return err
+}
+
+func (svc service) identify(ctx context.Context, token string) (string, error) {
+	claims, err := svc.tokens.Parse(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if claims.Type != jwt.AccessToken {
+		return "", errors.ErrAuthentication
+	}
+
+	return claims.ClientID, nil
+}
+
+func (svc service) retrieve(ctx context.Context, clientID string) (Enrollment, error) {
+	e, err := svc.enrollments.Retrieve(ctx, clientID)
+	if errors.Contains(err, errors.ErrNotFound) {
+		return Enrollment{}, errors.Wrap(errors.ErrNotFound, ErrNotEnrolled)
+	}
+
+	return e, err
+}
+
+// check verifies the one-time password, or the recovery code if the
+// enrollment is confirmed, and marks it as used.
+func (svc service) check(e *Enrollment, code string) error {
+	if s, ok := validate(e.Secret, code, time.Now(), e.LastStep); ok {
+		e.LastStep = s
+		return nil
+	}
+	if !e.Confirmed {
+		return errors.Wrap(errors.ErrAuthentication, ErrInvalidCode)
+	}
+	h := hash(normalize(code))
+	for i, rc := range e.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(rc), []byte(h)) == 1 {
+			e.RecoveryCodes = append(e.RecoveryCodes[:i:i], e.RecoveryCodes[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.Wrap(errors.ErrAuthentication, ErrInvalidCode)
+}
+
+// generateRecoveryCodes returns the recovery codes and their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodesCount)
+	hashes := make([]string, recoveryCodesCount)
+	for i := range codes {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		c := encoding.EncodeToString(b)
+		codes[i] = strings.Join([]string{c[:4], c[4:8], c[8:12], c[12:]}, "-")
+		hashes[i] = hash(c)
+	}
+
+	return codes, hashes, nil
+}
+
+// normalize removes the separators from the recovery code, so it can be
+// entered the same way it is shown or without them.
+func normalize(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func hash(code string) string {
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
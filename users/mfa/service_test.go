@@ -0,0 +1,693 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mfa_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	cmocks "github.com/mainflux/mainflux/users/clients/mocks"
+	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	"github.com/mainflux/mainflux/users/mfa/mocks"
+	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	idProvider      = uuid.New()
+	secret          = "strongsecret"
+	inValidToken    = "invalidToken"
+	email           = "user@example.com"
+	accessDuration  = time.Minute * 1
+	refreshDuration = time.Minute * 10
+	cfg             = mfa.Config{Issuer: "Mainflux"}
+)
+
+func newService() (mfa.Service, mfa.Repository, *cmocks.Repository, *pmocks.Repository, jwt.Repository) {
+	svc, mRepo, cRepo, pRepo, tokenizer, _ := newServiceWithEmailer()
+
+	return svc, mRepo, cRepo, pRepo, tokenizer
+}
+
+func newServiceWithEmailer() (mfa.Service, mfa.Repository, *cmocks.Repository, *pmocks.Repository, jwt.Repository, *mocks.Emailer) {
+	mRepo := mocks.NewRepository()
+	cRepo := new(cmocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+
+	limiter := lockout.NewScopedLimiter(lmocks.NewRepository(), lockout.Config{}, "mfa")
+
+	emailer := mocks.NewEmailer()
+
+	return mfa.NewService(mRepo, cRepo, pRepo, tokenizer, limiter, emailer, cfg), mRepo, cRepo, pRepo, tokenizer, emailer
+}
+
+func issueToken(t *testing.T, tokenizer jwt.Repository, id string) jwt.Token {
+	token, err := tokenizer.Issue(context.Background(), jwt.Claims{ClientID: id, Email: email})
+	require.Nil(t, err, fmt.Sprintf("issue token unexpected error: %s", err))
+
+	return token
+}
+
+func enrollment(t *testing.T, tokenizer jwt.Repository, id string) string {
+	token, err := tokenizer.Enrollment(context.Background(), jwt.Claims{ClientID: id, Email: email})
+	require.Nil(t, err, fmt.Sprintf("issue enrollment token unexpected error: %s", err))
+
+	return token
+}
+
+func challenge(t *testing.T, tokenizer jwt.Repository, id string) string {
+	token, err := tokenizer.Challenge(context.Background(), jwt.Claims{ClientID: id, Email: email})
+	require.Nil(t, err, fmt.Sprintf("issue challenge unexpected error: %s", err))
+
+	return token.AccessToken
+}
+
+func code(t *testing.T, secret string, offset time.Duration) string {
+	c, err := mfa.Code(secret, time.Now().Add(offset))
+	require.Nil(t, err, fmt.Sprintf("generate code unexpected error: %s", err))
+
+	return c
+}
+
+// enroll enrolls the client and confirms the enrollment if requested.
+func enroll(t *testing.T, svc mfa.Service, token string, confirm bool) mfa.Provisioning {
+	p, err := svc.Enroll(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("enroll unexpected error: %s", err))
+	if confirm {
+		err := svc.Confirm(context.Background(), token, code(t, p.Secret, 0))
+		require.Nil(t, err, fmt.Sprintf("confirm unexpected error: %s", err))
+	}
+
+	return p
+}
+
+func TestEnroll(t *testing.T) {
+	svc, _, _, _, tokenizer := newService()
+	token := issueToken(t, tokenizer, testsutil.GenerateUUID(t, idProvider))
+	confirmed := issueToken(t, tokenizer, testsutil.GenerateUUID(t, idProvider))
+	enroll(t, svc, confirmed.AccessToken, true)
+	enrolling := enrollment(t, tokenizer, testsutil.GenerateUUID(t, idProvider))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "enroll",
+			token: token.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "enroll again before confirmation",
+			token: token.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "enroll with enrollment token",
+			token: enrolling,
+			err:   nil,
+		},
+		{
+			desc:  "enroll with used enrollment token",
+			token: enrolling,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "enroll with challenge token",
+			token: challenge(t, tokenizer, testsutil.GenerateUUID(t, idProvider)),
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "enroll with confirmed enrollment",
+			token: confirmed.AccessToken,
+			err:   errors.ErrConflict,
+		},
+		{
+			desc:  "enroll with refresh token",
+			token: token.RefreshToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "enroll with invalid token",
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		p, err := svc.Enroll(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, p.Secret, fmt.Sprintf("%s: expected secret not to be empty\n", tc.desc))
+			prefix := "otpauth://totp/Mainflux:" + email + "?"
+			assert.True(t, strings.HasPrefix(p.URI, prefix), fmt.Sprintf("%s: expected URI prefix %s got %s\n", tc.desc, prefix, p.URI))
+			assert.Contains(t, p.URI, "secret="+p.Secret, fmt.Sprintf("%s: expected URI to contain secret\n", tc.desc))
+			assert.Len(t, p.RecoveryCodes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d\n", tc.desc, len(p.RecoveryCodes)))
+		}
+	}
+}
+
+func TestRequestEnrollment(t *testing.T) {
+	svc, _, cRepo, _, tokenizer, emailer := newServiceWithEmailer()
+
+	client := mfclients.Client{
+		ID:          testsutil.GenerateUUID(t, idProvider),
+		Credentials: mfclients.Credentials{Identity: email},
+		Status:      mfclients.EnabledStatus,
+	}
+	confirmed := client
+	confirmed.ID = testsutil.GenerateUUID(t, idProvider)
+	enroll(t, svc, issueToken(t, tokenizer, confirmed.ID).AccessToken, true)
+	disabled := client
+	disabled.ID = testsutil.GenerateUUID(t, idProvider)
+	disabled.Status = mfclients.DisabledStatus
+
+	cases := []struct {
+		desc      string
+		client    mfclients.Client
+		challenge string
+		err       error
+	}{
+		{
+			desc:      "request enrollment",
+			client:    client,
+			challenge: challenge(t, tokenizer, client.ID),
+			err:       nil,
+		},
+		{
+			desc:      "request enrollment with access token",
+			client:    client,
+			challenge: issueToken(t, tokenizer, client.ID).AccessToken,
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "request enrollment with invalid token",
+			client:    client,
+			challenge: inValidToken,
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "request enrollment with confirmed enrollment",
+			client:    confirmed,
+			challenge: challenge(t, tokenizer, confirmed.ID),
+			err:       errors.ErrConflict,
+		},
+		{
+			desc:      "request enrollment for disabled client",
+			client:    disabled,
+			challenge: challenge(t, tokenizer, disabled.ID),
+			err:       errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByID", context.Background(), tc.client.ID).Return(tc.client, nil)
+		err := svc.RequestEnrollment(context.Background(), tc.challenge)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			token := emailer.Token(tc.client.Credentials.Identity)
+			claims, err := tokenizer.Parse(context.Background(), token)
+			require.Nil(t, err, fmt.Sprintf("%s: parsing sent token unexpected error: %s", tc.desc, err))
+			assert.Equal(t, jwt.EnrollmentToken, claims.Type, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, jwt.EnrollmentToken, claims.Type))
+			_, err = svc.Enroll(context.Background(), token)
+			assert.Nil(t, err, fmt.Sprintf("%s: enroll with sent token unexpected error: %s", tc.desc, err))
+		}
+		repoCall.Unset()
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	svc, _, _, _, tokenizer := newService()
+	token := issueToken(t, tokenizer, testsutil.GenerateUUID(t, idProvider))
+	p := enroll(t, svc, token.AccessToken, false)
+	unenrolled := issueToken(t, tokenizer, testsutil.GenerateUUID(t, idProvider))
+
+	cases := []struct {
+		desc  string
+		token string
+		code  string
+		err   error
+	}{
+		{
+			desc:  "confirm with invalid code",
+			token: token.AccessToken,
+			code:  "000000",
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "confirm with recovery code",
+			token: token.AccessToken,
+			code:  p.RecoveryCodes[0],
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "confirm with invalid token",
+			token: inValidToken,
+			code:  code(t, p.Secret, 0),
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "confirm without enrollment",
+			token: unenrolled.AccessToken,
+			code:  code(t, p.Secret, 0),
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "confirm",
+			token: token.AccessToken,
+			code:  code(t, p.Secret, 0),
+			err:   nil,
+		},
+		{
+			desc:  "confirm confirmed enrollment",
+			token: token.AccessToken,
+			code:  code(t, p.Secret, 30*time.Second),
+			err:   errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Confirm(context.Background(), tc.token, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	svc, _, cRepo, _, tokenizer := newService()
+
+	client := mfclients.Client{
+		ID:          testsutil.GenerateUUID(t, idProvider),
+		Credentials: mfclients.Credentials{Identity: email},
+		Status:      mfclients.EnabledStatus,
+	}
+	p := enroll(t, svc, issueToken(t, tokenizer, client.ID).AccessToken, true)
+
+	admin := client
+	admin.ID = testsutil.GenerateUUID(t, idProvider)
+	adminChallenge := challenge(t, tokenizer, admin.ID)
+	ap := enroll(t, svc, enrollment(t, tokenizer, admin.ID), false)
+
+	disabled := client
+	disabled.ID = testsutil.GenerateUUID(t, idProvider)
+	disabled.Status = mfclients.DisabledStatus
+	dp := enroll(t, svc, issueToken(t, tokenizer, disabled.ID).AccessToken, true)
+
+	reused := challenge(t, tokenizer, client.ID)
+
+	cases := []struct {
+		desc      string
+		client    mfclients.Client
+		challenge string
+		code      string
+		err       error
+	}{
+		{
+			desc:      "verify with invalid code",
+			client:    client,
+			challenge: challenge(t, tokenizer, client.ID),
+			code:      "000000",
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "verify with already used code",
+			client:    client,
+			challenge: challenge(t, tokenizer, client.ID),
+			code:      code(t, p.Secret, 0),
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "verify with code",
+			client:    client,
+			challenge: reused,
+			code:      code(t, p.Secret, 30*time.Second),
+			err:       nil,
+		},
+		{
+			desc:      "verify with used challenge",
+			client:    client,
+			challenge: reused,
+			code:      p.RecoveryCodes[1],
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "verify with recovery code",
+			client:    client,
+			challenge: challenge(t, tokenizer, client.ID),
+			code:      p.RecoveryCodes[0],
+			err:       nil,
+		},
+		{
+			desc:      "verify with recovery code without separators",
+			client:    client,
+			challenge: challenge(t, tokenizer, client.ID),
+			code:      strings.ToLower(strings.ReplaceAll(p.RecoveryCodes[2], "-", "")),
+			err:       nil,
+		},
+		{
+			desc:      "verify with used recovery code",
+			client:    client,
+			challenge: challenge(t, tokenizer, client.ID),
+			code:      p.RecoveryCodes[0],
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "verify with access token",
+			client:    client,
+			challenge: issueToken(t, tokenizer, client.ID).AccessToken,
+			code:      p.RecoveryCodes[3],
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "verify with invalid challenge",
+			client:    client,
+			challenge: inValidToken,
+			code:      p.RecoveryCodes[3],
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "verify without enrollment",
+			client:    client,
+			challenge: challenge(t, tokenizer, testsutil.GenerateUUID(t, idProvider)),
+			code:      "000000",
+			err:       errors.ErrNotFound,
+		},
+		{
+			desc:      "verify pending enrollment with recovery code",
+			client:    admin,
+			challenge: adminChallenge,
+			code:      ap.RecoveryCodes[0],
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "verify pending enrollment with code",
+			client:    admin,
+			challenge: adminChallenge,
+			code:      code(t, ap.Secret, 0),
+			err:       nil,
+		},
+		{
+			desc:      "verify for disabled client",
+			client:    disabled,
+			challenge: challenge(t, tokenizer, disabled.ID),
+			code:      dp.RecoveryCodes[0],
+			err:       errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByID", context.Background(), tc.client.ID).Return(tc.client, nil)
+		token, err := svc.Verify(context.Background(), tc.challenge, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, "Bearer", token.AccessType, fmt.Sprintf("%s: expected access type Bearer got %s\n", tc.desc, token.AccessType))
+			claims, err := tokenizer.Parse(context.Background(), token.AccessToken)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.client.ID, claims.ClientID, fmt.Sprintf("%s: expected client %s got %s\n", tc.desc, tc.client.ID, claims.ClientID))
+		}
+		repoCall.Unset()
+	}
+}
+
+func TestVerifyLockout(t *testing.T) {
+	cRepo := new(cmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	limiter := lockout.NewScopedLimiter(lmocks.NewRepository(), lockout.Config{MaxAttempts: 3, Window: time.Minute, Duration: time.Minute}, "mfa")
+	svc := mfa.NewService(mocks.NewRepository(), cRepo, new(pmocks.Repository), tokenizer, limiter, mocks.NewEmailer(), cfg)
+
+	client := mfclients.Client{
+		ID:          testsutil.GenerateUUID(t, idProvider),
+		Credentials: mfclients.Credentials{Identity: email},
+		Status:      mfclients.EnabledStatus,
+	}
+	p := enroll(t, svc, issueToken(t, tokenizer, client.ID).AccessToken, true)
+	locked := challenge(t, tokenizer, client.ID)
+
+	cases := []struct {
+		desc      string
+		challenge string
+		code      string
+		err       error
+	}{
+		{
+			desc:      "verify with invalid code",
+			challenge: locked,
+			code:      "000000",
+			err:       mfa.ErrInvalidCode,
+		},
+		{
+			desc:      "verify with invalid code again",
+			challenge: locked,
+			code:      "000000",
+			err:       mfa.ErrInvalidCode,
+		},
+		{
+			desc:      "verify with invalid code reaching the limit",
+			challenge: locked,
+			code:      "000000",
+			err:       lockout.ErrLockedOut,
+		},
+		{
+			desc:      "verify with revoked challenge",
+			challenge: locked,
+			code:      p.RecoveryCodes[0],
+			err:       jwt.ErrRevoked,
+		},
+		{
+			desc:      "verify with new challenge while locked out",
+			challenge: challenge(t, tokenizer, client.ID),
+			code:      p.RecoveryCodes[0],
+			err:       lockout.ErrLocked,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := cRepo.On("RetrieveByID", context.Background(), client.ID).Return(client, nil)
+		_, err := svc.Verify(context.Background(), tc.challenge, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+	}
+}
+
+func TestDisable(t *testing.T) {
+	svc, mRepo, _, _, tokenizer := newService()
+	id := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, id)
+	p := enroll(t, svc, token.AccessToken, true)
+
+	cases := []struct {
+		desc  string
+		token string
+		code  string
+		err   error
+	}{
+		{
+			desc:  "disable with invalid code",
+			token: token.AccessToken,
+			code:  "000000",
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "disable with challenge token",
+			token: challenge(t, tokenizer, id),
+			code:  p.RecoveryCodes[0],
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "disable with recovery code",
+			token: token.AccessToken,
+			code:  p.RecoveryCodes[0],
+			err:   nil,
+		},
+		{
+			desc:  "disable disabled MFA",
+			token: token.AccessToken,
+			code:  p.RecoveryCodes[1],
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Disable(context.Background(), tc.token, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			_, err := mRepo.Retrieve(context.Background(), id)
+			assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("%s: expected enrollment to be removed got %s\n", tc.desc, err))
+		}
+	}
+}
+
+func TestConfirmLockout(t *testing.T) {
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	limiter := lockout.NewScopedLimiter(lmocks.NewRepository(), lockout.Config{MaxAttempts: 2, Window: time.Minute, Duration: time.Minute}, "mfa")
+	svc := mfa.NewService(mocks.NewRepository(), new(cmocks.Repository), new(pmocks.Repository), tokenizer, limiter, mocks.NewEmailer(), cfg)
+
+	token := issueToken(t, tokenizer, testsutil.GenerateUUID(t, idProvider))
+	p := enroll(t, svc, token.AccessToken, false)
+
+	cases := []struct {
+		desc string
+		code string
+		err  error
+	}{
+		{
+			desc: "confirm with invalid code",
+			code: "000000",
+			err:  mfa.ErrInvalidCode,
+		},
+		{
+			desc: "confirm with invalid code reaching the limit",
+			code: "000000",
+			err:  lockout.ErrLockedOut,
+		},
+		{
+			desc: "confirm with valid code while locked out",
+			code: code(t, p.Secret, 0),
+			err:  lockout.ErrLocked,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Confirm(context.Background(), token.AccessToken, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestDisableLockout(t *testing.T) {
+	mRepo := mocks.NewRepository()
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	limiter := lockout.NewScopedLimiter(lmocks.NewRepository(), lockout.Config{MaxAttempts: 2, Window: time.Minute, Duration: time.Minute}, "mfa")
+	svc := mfa.NewService(mRepo, new(cmocks.Repository), new(pmocks.Repository), tokenizer, limiter, mocks.NewEmailer(), cfg)
+
+	id := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, id)
+	p := enroll(t, svc, token.AccessToken, true)
+
+	cases := []struct {
+		desc string
+		code string
+		err  error
+	}{
+		{
+			desc: "disable with invalid code",
+			code: "000000",
+			err:  mfa.ErrInvalidCode,
+		},
+		{
+			desc: "disable with invalid code reaching the limit",
+			code: "000000",
+			err:  lockout.ErrLockedOut,
+		},
+		{
+			desc: "disable with recovery code while locked out",
+			code: p.RecoveryCodes[0],
+			err:  lockout.ErrLocked,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Disable(context.Background(), token.AccessToken, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	_, err := mRepo.Retrieve(context.Background(), id)
+	assert.Nil(t, err, fmt.Sprintf("expected enrollment to be kept got %s\n", err))
+}
+
+func TestReset(t *testing.T) {
+	svc, mRepo, _, pRepo, tokenizer := newService()
+	adminID := testsutil.GenerateUUID(t, idProvider)
+	userID := testsutil.GenerateUUID(t, idProvider)
+	admin := issueToken(t, tokenizer, adminID)
+	user := issueToken(t, tokenizer, userID)
+	enroll(t, svc, user.AccessToken, true)
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		adminErr error
+		err      error
+	}{
+		{
+			desc:     "reset by non-admin",
+			token:    user.AccessToken,
+			id:       userID,
+			adminErr: errors.ErrAuthorization,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:  "reset with invalid token",
+			token: inValidToken,
+			id:    userID,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "reset by admin",
+			token: admin.AccessToken,
+			id:    userID,
+			err:   nil,
+		},
+		{
+			desc:  "reset without enrollment",
+			token: admin.AccessToken,
+			id:    userID,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(tc.adminErr)
+		err := svc.Reset(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			_, err := mRepo.Retrieve(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("%s: expected enrollment to be removed got %s\n", tc.desc, err))
+		}
+		repoCall.Unset()
+	}
+}
+
+func TestRequired(t *testing.T) {
+	mRepo := mocks.NewRepository()
+	confirmed := mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Role: mfclients.UserRole}
+	pending := mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Role: mfclients.UserRole}
+	admin := mfclients.Client{ID: testsutil.GenerateUUID(t, idProvider), Role: mfclients.AdminRole}
+	err := mRepo.Save(context.Background(), mfa.Enrollment{ClientID: confirmed.ID, Confirmed: true})
+	require.Nil(t, err, fmt.Sprintf("save enrollment unexpected error: %s", err))
+	err = mRepo.Save(context.Background(), mfa.Enrollment{ClientID: pending.ID})
+	require.Nil(t, err, fmt.Sprintf("save enrollment unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		client   mfclients.Client
+		enforce  bool
+		required bool
+	}{
+		{desc: "client with confirmed enrollment", client: confirmed, required: true},
+		{desc: "client with pending enrollment", client: pending, required: false},
+		{desc: "admin without enrollment", client: admin, required: false},
+		{desc: "admin without enrollment with enforced MFA", client: admin, enforce: true, required: true},
+		{desc: "client with pending enrollment with enforced MFA", client: pending, enforce: true, required: false},
+	}
+
+	for _, tc := range cases {
+		required, err := mfa.NewAuthenticator(mRepo, tc.enforce).Required(context.Background(), tc.client)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.required, required, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.required, required))
+	}
+}
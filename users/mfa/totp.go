@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The parameters are the ones supported by the most of the authenticator
+// apps, which ignore the other values of the provisioning URI.
+const (
+	period     = 30
+	digits     = 6
+	skew       = 1
+	secretSize = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new base32 encoded TOTP secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// Code returns the one-time password generated from the secret at the given time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	return code(key, step(t)), nil
+}
+
+// validate checks the one-time password against the time steps around the
+// given time, allowing for clock skew. Steps up to the last accepted one are
+// rejected, so a one-time password can't be used twice. The step the code
+// was generated at is returned.
+func validate(secret, otp string, t time.Time, lastStep int64) (int64, bool) {
+	if len(otp) != digits {
+		return 0, false
+	}
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+	current := step(t)
+	for s := current - skew; s <= current+skew; s++ {
+		if s <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(code(key, s)), []byte(otp)) == 1 {
+			return s, true
+		}
+	}
+
+	return 0, false
+}
+
+// provisioningURI returns the Key URI of the secret, the de facto standard
+// format used to provision authenticator apps over QR codes.
+func provisioningURI(issuer, account, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(digits))
+	params.Set("period", fmt.Sprint(period))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", url.PathEscape(issuer+":"+account), params.Encode())
+}
+
+func step(t time.Time) int64 {
+	return t.Unix() / period
+}
+
+// code implements HOTP (RFC 4226) value truncated to the configured number of digits.
+func code(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", digits, value%uint32(math.Pow10(digits)))
+}
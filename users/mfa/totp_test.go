@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mfa_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/users/mfa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCode(t *testing.T) {
+	// Test vectors of RFC 6238 Appendix B for SHA-1, truncated to 6 digits.
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	cases := []struct {
+		desc string
+		time int64
+		code string
+	}{
+		{desc: "code at 59", time: 59, code: "287082"},
+		{desc: "code at 1111111109", time: 1111111109, code: "081804"},
+		{desc: "code at 1111111111", time: 1111111111, code: "050471"},
+		{desc: "code at 1234567890", time: 1234567890, code: "005924"},
+		{desc: "code at 2000000000", time: 2000000000, code: "279037"},
+		{desc: "code at 20000000000", time: 20000000000, code: "353130"},
+	}
+
+	for _, tc := range cases {
+		code, err := mfa.Code(secret, time.Unix(tc.time, 0))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.code, code, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.code, code))
+	}
+
+	_, err := mfa.Code("not base32!", time.Now())
+	assert.NotNil(t, err, "invalid secret: expected error got nil")
+}
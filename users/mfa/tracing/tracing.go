@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/mfa"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ mfa.Service = (*tracingMiddleware)(nil)
+
+type tracingMiddleware struct {
+	tracer trace.Tracer
+	svc    mfa.Service
+}
+
+// New returns a new MFA service with tracing capabilities.
+func New(svc mfa.Service, tracer trace.Tracer) mfa.Service {
+	return &tracingMiddleware{tracer, svc}
+}
+
+// Enroll traces the "Enroll" operation of the wrapped mfa.Service.
+func (tm *tracingMiddleware) Enroll(ctx context.Context, token string) (mfa.Provisioning, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_mfa_enroll")
+	defer span.End()
+
+	return tm.svc.Enroll(ctx, token)
+}
+
+// RequestEnrollment traces the "RequestEnrollment" operation of the wrapped mfa.Service.
+func (tm *tracingMiddleware) RequestEnrollment(ctx context.Context, challenge string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_mfa_request_enrollment")
+	defer span.End()
+
+	return tm.svc.RequestEnrollment(ctx, challenge)
+}
+
+// Confirm traces the "Confirm" operation of the wrapped mfa.Service.
+func (tm *tracingMiddleware) Confirm(ctx context.Context, token, code string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_mfa_confirm")
+	defer span.End()
+
+	return tm.svc.Confirm(ctx, token, code)
+}
+
+// Verify traces the "Verify" operation of the wrapped mfa.Service.
+func (tm *tracingMiddleware) Verify(ctx context.Context, challenge, code string) (jwt.Token, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_mfa_verify")
+	defer span.End()
+
+	return tm.svc.Verify(ctx, challenge, code)
+}
+
+// Disable traces the "Disable" operation of the wrapped mfa.Service.
+func (tm *tracingMiddleware) Disable(ctx context.Context, token, code string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_mfa_disable")
+	defer span.End()
+
+	return tm.svc.Disable(ctx, token, code)
+}
+
+// Reset traces the "Reset" operation of the wrapped mfa.Service.
+func (tm *tracingMiddleware) Reset(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_mfa_reset", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.Reset(ctx, token, id)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/oidc"
+)
+
+func loginEndpoint(svc oidc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(loginReq)
+
+		url, state, err := svc.Login(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return loginRes{
+			url:    url,
+			cookie: stateCookie(state, req.secure),
+		}, nil
+	}
+}
+
+func callbackEndpoint(svc oidc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(callbackReq)
+		if req.idpError != "" {
+			return nil, errors.Wrap(errors.ErrAuthentication, errors.New(req.idpError))
+		}
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		token, err := svc.Callback(ctx, req.code, req.state, req.sessionState)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{
+			AccessToken:  token.AccessToken,
+			RefreshToken: token.RefreshToken,
+			AccessType:   token.AccessType,
+			cookie:       stateCookie("", req.secure),
+		}, nil
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/oidc"
+)
+
+type loginReq struct {
+	secure bool
+}
+
+type callbackReq struct {
+	code         string
+	state        string
+	sessionState string
+	idpError     string
+	secure       bool
+}
+
+func (req callbackReq) validate() error {
+	if req.code == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, oidc.ErrMissingCode)
+	}
+	if req.state == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, oidc.ErrInvalidState)
+	}
+	return nil
+}
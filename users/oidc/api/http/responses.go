@@ -0,0 +1,56 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*loginRes)(nil)
+	_ mainflux.Response = (*tokenRes)(nil)
+)
+
+type loginRes struct {
+	url    string
+	cookie *http.Cookie
+}
+
+func (res loginRes) Code() int {
+	return http.StatusFound
+}
+
+func (res loginRes) Headers() map[string]string {
+	return map[string]string{
+		"Location":   res.url,
+		"Set-Cookie": res.cookie.String(),
+	}
+}
+
+func (res loginRes) Empty() bool {
+	return true
+}
+
+type tokenRes struct {
+	AccessToken  string       `json:"access_token,omitempty"`
+	RefreshToken string       `json:"refresh_token,omitempty"`
+	AccessType   string       `json:"access_type,omitempty"`
+	cookie       *http.Cookie `json:"-"`
+}
+
+func (res tokenRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res tokenRes) Headers() map[string]string {
+	return map[string]string{
+		"Set-Cookie": res.cookie.String(),
+	}
+}
+
+func (res tokenRes) Empty() bool {
+	return false
+}
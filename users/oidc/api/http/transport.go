@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/internal/api"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/users/oidc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	stateCookieName = "mf_oidc_state"
+	stateCookiePath = "/users/oidc"
+	// stateMaxAge is the time, in seconds, the user has to log in to the IdP.
+	stateMaxAge = 600
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc oidc.Service, mux *bone.Mux, logger logger.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux.Get("/users/oidc/login", otelhttp.NewHandler(kithttp.NewServer(
+		loginEndpoint(svc),
+		decodeLogin,
+		api.EncodeResponse,
+		opts...,
+	), "oidc_login"))
+
+	mux.Get("/users/oidc/callback", otelhttp.NewHandler(kithttp.NewServer(
+		callbackEndpoint(svc),
+		decodeCallback,
+		api.EncodeResponse,
+		opts...,
+	), "oidc_callback"))
+
+	return mux
+}
+
+func decodeLogin(_ context.Context, r *http.Request) (interface{}, error) {
+	return loginReq{secure: secure(r)}, nil
+}
+
+func decodeCallback(_ context.Context, r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	req := callbackReq{
+		code:   q.Get("code"),
+		state:  q.Get("state"),
+		secure: secure(r),
+	}
+	if e := q.Get("error"); e != "" {
+		req.idpError = e
+		if desc := q.Get("error_description"); desc != "" {
+			req.idpError = fmt.Sprintf("%s: %s", e, desc)
+		}
+	}
+	if c, err := r.Cookie(stateCookieName); err == nil {
+		req.sessionState = c.Value
+	}
+
+	return req, nil
+}
+
+// stateCookie binds the login flow to the user agent it was started from.
+// Empty state expires the cookie.
+func stateCookie(state string, secure bool) *http.Cookie {
+	maxAge := stateMaxAge
+	if state == "" {
+		maxAge = -1
+	}
+
+	return &http.Cookie{
+		Name:     stateCookieName,
+		Value:    state,
+		Path:     stateCookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func secure(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/oidc"
+)
+
+var _ oidc.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger mflog.Logger
+	svc    oidc.Service
+}
+
+// LoggingMiddleware adds logging facilities to the OpenID Connect login service.
+func LoggingMiddleware(svc oidc.Service, logger mflog.Logger) oidc.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// Login logs the oidc_login request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Login(ctx context.Context) (url, state string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oidc_login took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Login(ctx)
+}
+
+// Callback logs the oidc_callback request. It logs the token type and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Callback(ctx context.Context, code, state, sessionState string) (t jwt.Token, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oidc_callback of type %s took %s to complete", t.AccessType, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Callback(ctx, code, state, sessionState)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/oidc"
+)
+
+var _ oidc.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     oidc.Service
+}
+
+// MetricsMiddleware instruments OpenID Connect login service by tracking request count and latency.
+func MetricsMiddleware(svc oidc.Service, counter metrics.Counter, latency metrics.Histogram) oidc.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+// Login instruments Login method with metrics.
+func (ms *metricsMiddleware) Login(ctx context.Context) (string, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oidc_login").Add(1)
+		ms.latency.With("method", "oidc_login").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Login(ctx)
+}
+
+// Callback instruments Callback method with metrics.
+func (ms *metricsMiddleware) Callback(ctx context.Context, code, state, sessionState string) (jwt.Token, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oidc_callback").Add(1)
+		ms.latency.With("method", "oidc_callback").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Callback(ctx, code, state, sessionState)
+}
@@ -0,0 +1,11 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oidc contains the domain concept definitions needed to
+// support Mainflux users OpenID Connect login functionality.
+//
+// Users authenticate against an external identity provider (IdP) using the
+// authorization code flow. The verified ID token claims are mapped to a
+// Mainflux client, which is provisioned on the first login, and the regular
+// Mainflux access and refresh token pair is issued.
+package oidc
@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/oidc"
+)
+
+var _ oidc.Repository = (*identitiesMock)(nil)
+
+type identitiesMock struct {
+	mu         sync.Mutex
+	identities map[string]oidc.Identity
+}
+
+// NewRepository creates in-memory IdP identities repository.
+func NewRepository() oidc.Repository {
+	return &identitiesMock{
+		identities: make(map[string]oidc.Identity),
+	}
+}
+
+func (im *identitiesMock) Save(_ context.Context, i oidc.Identity) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	for _, cur := range im.identities {
+		if cur.ClientID == i.ClientID || (cur.Issuer == i.Issuer && cur.Subject == i.Subject) {
+			return errors.ErrConflict
+		}
+	}
+	im.identities[i.ClientID] = i
+	return nil
+}
+
+func (im *identitiesMock) Retrieve(_ context.Context, clientID string) (oidc.Identity, error) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	i, ok := im.identities[clientID]
+	if !ok {
+		return oidc.Identity{}, errors.ErrNotFound
+	}
+	return i, nil
+}
+
+func (im *identitiesMock) RetrieveBySubject(_ context.Context, issuer, subject string) (oidc.Identity, error) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	for _, i := range im.identities {
+		if i.Issuer == issuer && i.Subject == subject {
+			return i, nil
+		}
+	}
+	return oidc.Identity{}, errors.ErrNotFound
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/jwt"
+)
+
+var (
+	// ErrInvalidState indicates that the callback state doesn't match the
+	// state the login flow was started with.
+	ErrInvalidState = errors.New("invalid oidc state")
+
+	// ErrMissingCode indicates missing authorization code.
+	ErrMissingCode = errors.New("missing authorization code")
+
+	// ErrMissingEmail indicates that the ID token has no email claim.
+	ErrMissingEmail = errors.New("missing email claim")
+
+	// ErrUnverifiedEmail indicates that the IdP hasn't verified the email.
+	ErrUnverifiedEmail = errors.New("email is not verified")
+
+	// ErrSubjectMismatch indicates that the client is already bound to
+	// another IdP subject, or the IdP subject to another client.
+	ErrSubjectMismatch = errors.New("client or oidc subject is bound to another one")
+
+	// ErrProvisioningDisabled indicates that the client doesn't exist and
+	// auto-provisioning is disabled.
+	ErrProvisioningDisabled = errors.New("oidc auto-provisioning is disabled")
+
+	// ErrProvider indicates error in communication with the IdP.
+	ErrProvider = errors.New("failed to communicate with oidc provider")
+)
+
+// Config contains the IdP connection and the claims mapping configuration.
+type Config struct {
+	IssuerURL     string            `env:"ISSUER_URL"     envDefault:""`
+	ClientID      string            `env:"CLIENT_ID"      envDefault:""`
+	ClientSecret  string            `env:"CLIENT_SECRET"  envDefault:""`
+	RedirectURL   string            `env:"REDIRECT_URL"   envDefault:""`
+	Scopes        []string          `env:"SCOPES"         envDefault:"openid,email,profile"`
+	AutoProvision bool              `env:"AUTO_PROVISION" envDefault:"true"`
+	RoleClaim     string            `env:"ROLE_CLAIM"     envDefault:""`
+	AdminRoles    []string          `env:"ADMIN_ROLES"    envDefault:""`
+	GroupsClaim   string            `env:"GROUPS_CLAIM"   envDefault:""`
+	Groups        map[string]string `env:"GROUPS"         envDefault:""`
+	GroupActions  []string          `env:"GROUP_ACTIONS"  envDefault:"g_list"`
+}
+
+// Claims are the verified ID token claims.
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified *bool
+	Name          string
+	// Raw contains all the ID token claims, used for role and group mapping.
+	Raw map[string]interface{}
+}
+
+// Identity binds the client to the IdP subject. Unlike the client metadata,
+// it is written by the login flow only.
+type Identity struct {
+	ClientID  string
+	Issuer    string
+	Subject   string
+	CreatedAt time.Time
+}
+
+// Repository specifies IdP identities persistence API.
+type Repository interface {
+	// Save binds the client to the IdP subject. It returns a conflict if
+	// either the client or the subject is already bound.
+	Save(ctx context.Context, i Identity) error
+
+	// Retrieve retrieves the IdP identity the client is bound to.
+	Retrieve(ctx context.Context, clientID string) (Identity, error)
+
+	// RetrieveBySubject retrieves the IdP identity of the subject.
+	RetrieveBySubject(ctx context.Context, issuer, subject string) (Identity, error)
+}
+
+// Provider specifies an API of the OpenID Connect identity provider.
+type Provider interface {
+	// AuthCodeURL returns the URL of the IdP login page the user is
+	// redirected to in order to start the authorization code flow.
+	AuthCodeURL(ctx context.Context, state, nonce string) (string, error)
+
+	// Exchange exchanges the authorization code for the ID token and
+	// returns its claims once the token is verified.
+	Exchange(ctx context.Context, code, nonce string) (Claims, error)
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// Login starts the authorization code flow. It returns the IdP URL the
+	// user is redirected to and the state that has to be presented back
+	// to the callback.
+	Login(ctx context.Context) (string, string, error)
+
+	// Callback completes the authorization code flow. The state received
+	// from the IdP is checked against the session state the flow was
+	// started with. The IdP user is mapped to a client, provisioned on the
+	// first login, and the Mainflux token pair is issued.
+	Callback(ctx context.Context, code, state, sessionState string) (jwt.Token, error)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/oidc"
+)
+
+var _ oidc.Repository = (*irepo)(nil)
+
+type irepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of IdP identities repository.
+func NewRepository(db postgres.Database) oidc.Repository {
+	return &irepo{
+		db: db,
+	}
+}
+
+func (ir irepo) Save(ctx context.Context, i oidc.Identity) error {
+	q := `INSERT INTO oidc_identities (client_id, issuer, subject, created_at)
+		VALUES (:client_id, :issuer, :subject, :created_at)`
+
+	if _, err := ir.db.NamedExecContext(ctx, q, toDBIdentity(i)); err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	return nil
+}
+
+func (ir irepo) Retrieve(ctx context.Context, clientID string) (oidc.Identity, error) {
+	q := `SELECT client_id, issuer, subject, created_at FROM oidc_identities WHERE client_id = $1`
+
+	dbi := dbIdentity{}
+	if err := ir.db.QueryRowxContext(ctx, q, clientID).StructScan(&dbi); err != nil {
+		if err == sql.ErrNoRows {
+			return oidc.Identity{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return oidc.Identity{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toIdentity(dbi), nil
+}
+
+func (ir irepo) RetrieveBySubject(ctx context.Context, issuer, subject string) (oidc.Identity, error) {
+	q := `SELECT client_id, issuer, subject, created_at FROM oidc_identities WHERE issuer = $1 AND subject = $2`
+
+	dbi := dbIdentity{}
+	if err := ir.db.QueryRowxContext(ctx, q, issuer, subject).StructScan(&dbi); err != nil {
+		if err == sql.ErrNoRows {
+			return oidc.Identity{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return oidc.Identity{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toIdentity(dbi), nil
+}
+
+type dbIdentity struct {
+	ClientID  string    `db:"client_id"`
+	Issuer    string    `db:"issuer"`
+	Subject   string    `db:"subject"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBIdentity(i oidc.Identity) dbIdentity {
+	return dbIdentity{
+		ClientID:  i.ClientID,
+		Issuer:    i.Issuer,
+		Subject:   i.Subject,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+func toIdentity(dbi dbIdentity) oidc.Identity {
+	return oidc.Identity{
+		ClientID:  dbi.ClientID,
+		Issuer:    dbi.Issuer,
+		Subject:   dbi.Subject,
+		CreatedAt: dbi.CreatedAt,
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	cpostgres "github.com/mainflux/mainflux/users/clients/postgres"
+	"github.com/mainflux/mainflux/users/oidc"
+	opostgres "github.com/mainflux/mainflux/users/oidc/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issuer = "https://idp.example.com"
+
+var idProvider = uuid.New()
+
+func saveClient(t *testing.T, name string) mfclients.Client {
+	crepo := cpostgres.NewRepository(database)
+	client := mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: name,
+		Credentials: mfclients.Credentials{
+			Identity: name,
+			Secret:   "pass",
+		},
+		Status: mfclients.EnabledStatus,
+	}
+	client, err := crepo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return client
+}
+
+func TestIdentitySave(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := opostgres.NewRepository(database)
+	client := saveClient(t, "oidc-bound@example.com")
+	other := saveClient(t, "oidc-other@example.com")
+	subject := testsutil.GenerateUUID(t, idProvider)
+
+	cases := []struct {
+		desc     string
+		identity oidc.Identity
+		err      error
+	}{
+		{
+			desc:     "save identity",
+			identity: oidc.Identity{ClientID: client.ID, Issuer: issuer, Subject: subject, CreatedAt: time.Now()},
+			err:      nil,
+		},
+		{
+			desc:     "save identity of bound client",
+			identity: oidc.Identity{ClientID: client.ID, Issuer: issuer, Subject: testsutil.GenerateUUID(t, idProvider), CreatedAt: time.Now()},
+			err:      errors.ErrConflict,
+		},
+		{
+			desc:     "save identity with bound subject",
+			identity: oidc.Identity{ClientID: other.ID, Issuer: issuer, Subject: subject, CreatedAt: time.Now()},
+			err:      errors.ErrConflict,
+		},
+		{
+			desc:     "save identity with bound subject of another issuer",
+			identity: oidc.Identity{ClientID: other.ID, Issuer: "https://other.example.com", Subject: subject, CreatedAt: time.Now()},
+			err:      nil,
+		},
+		{
+			desc:     "save identity of non-existing client",
+			identity: oidc.Identity{ClientID: testsutil.GenerateUUID(t, idProvider), Issuer: issuer, Subject: testsutil.GenerateUUID(t, idProvider), CreatedAt: time.Now()},
+			err:      errors.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.identity)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestIdentityRetrieve(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := opostgres.NewRepository(database)
+	client := saveClient(t, "oidc-retrieve@example.com")
+	identity := oidc.Identity{ClientID: client.ID, Issuer: issuer, Subject: testsutil.GenerateUUID(t, idProvider), CreatedAt: time.Now()}
+	err := repo.Save(context.Background(), identity)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		clientID string
+		err      error
+	}{
+		{
+			desc:     "retrieve identity",
+			clientID: client.ID,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve identity of unbound client",
+			clientID: testsutil.GenerateUUID(t, idProvider),
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		i, err := repo.Retrieve(context.Background(), tc.clientID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, identity.Subject, i.Subject, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, identity.Subject, i.Subject))
+			assert.Equal(t, identity.Issuer, i.Issuer, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, identity.Issuer, i.Issuer))
+		}
+	}
+}
+
+func TestIdentityRetrieveBySubject(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := opostgres.NewRepository(database)
+	client := saveClient(t, "oidc-subject@example.com")
+	identity := oidc.Identity{ClientID: client.ID, Issuer: issuer, Subject: testsutil.GenerateUUID(t, idProvider), CreatedAt: time.Now()}
+	err := repo.Save(context.Background(), identity)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		issuer  string
+		subject string
+		err     error
+	}{
+		{
+			desc:    "retrieve identity by subject",
+			issuer:  issuer,
+			subject: identity.Subject,
+			err:     nil,
+		},
+		{
+			desc:    "retrieve identity by subject of another issuer",
+			issuer:  "https://other.example.com",
+			subject: identity.Subject,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "retrieve identity by unbound subject",
+			issuer:  issuer,
+			subject: testsutil.GenerateUUID(t, idProvider),
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		i, err := repo.RetrieveBySubject(context.Background(), tc.issuer, tc.subject)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, client.ID, i.ClientID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, client.ID, i.ClientID))
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	clockSkew     = time.Minute
+)
+
+var _ Provider = (*provider)(nil)
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenRes struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *discovery
+}
+
+// NewProvider returns the OpenID Connect provider of the given issuer.
+// The provider metadata is discovered on the first use, so the IdP
+// doesn't have to be available when the service starts.
+func NewProvider(cfg Config, client *http.Client) Provider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+
+	return &provider{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.Wrap(ErrProvider, err)
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code, nonce string) (Claims, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Claims{}, errors.Wrap(ErrProvider, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Claims{}, errors.Wrap(ErrProvider, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Claims{}, errors.Wrap(ErrProvider, err)
+	}
+	var tr tokenRes
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return Claims{}, errors.Wrap(ErrProvider, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Claims{}, errors.Wrap(errors.ErrAuthentication, fmt.Errorf("%s: %s", tr.Error, tr.ErrorDescription))
+	}
+	if tr.IDToken == "" {
+		return Claims{}, errors.Wrap(errors.ErrAuthentication, errors.New("missing id token"))
+	}
+
+	return p.verify(ctx, d, tr.IDToken, nonce)
+}
+
+func (p *provider) verify(ctx context.Context, d discovery, idToken, nonce string) (Claims, error) {
+	keys, err := jwk.Fetch(ctx, d.JWKSURI, jwk.WithHTTPClient(p.client))
+	if err != nil {
+		return Claims{}, errors.Wrap(ErrProvider, err)
+	}
+
+	token, err := jwt.Parse(
+		[]byte(idToken),
+		jwt.WithKeySet(keys, jws.WithInferAlgorithmFromKey(true)),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithClaimValue("nonce", nonce),
+		jwt.WithAcceptableSkew(clockSkew),
+	)
+	if err != nil {
+		return Claims{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	raw, err := token.AsMap(ctx)
+	if err != nil {
+		return Claims{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	claims := Claims{
+		Issuer:  token.Issuer(),
+		Subject: token.Subject(),
+		Raw:     raw,
+	}
+	if email, ok := raw["email"].(string); ok {
+		claims.Email = email
+	}
+	if name, ok := raw["name"].(string); ok {
+		claims.Name = name
+	}
+	if verified, ok := raw["email_verified"].(bool); ok {
+		claims.EmailVerified = &verified
+	}
+
+	return claims, nil
+}
+
+func (p *provider) discover(ctx context.Context) (discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return *p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.IssuerURL+discoveryPath, nil)
+	if err != nil {
+		return discovery{}, errors.Wrap(ErrProvider, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return discovery{}, errors.Wrap(ErrProvider, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return discovery{}, errors.Wrap(ErrProvider, fmt.Errorf("unexpected discovery response status %d", resp.StatusCode))
+	}
+
+	var d discovery
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return discovery{}, errors.Wrap(ErrProvider, err)
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != p.cfg.IssuerURL {
+		return discovery{}, errors.Wrap(ErrProvider, fmt.Errorf("issuer %s doesn't match %s", d.Issuer, p.cfg.IssuerURL))
+	}
+	p.discovery = &d
+
+	return d, nil
+}
@@ -0,0 +1,308 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/clients"
+	"github.com/mainflux/mainflux/users/clients/postgres"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/lockout"
+	"github.com/mainflux/mainflux/users/mfa"
+	"github.com/mainflux/mainflux/users/policies"
+)
+
+const randomSize = 32
+
+type service struct {
+	provider   Provider
+	clients    postgres.Repository
+	identities Repository
+	policies   policies.Repository
+	tokens     jwt.Repository
+	mfa        mfa.Authenticator
+	lockout    lockout.Limiter
+	hasher     clients.Hasher
+	idProvider mainflux.IDProvider
+	cfg        Config
+}
+
+// NewService returns a new OpenID Connect login service implementation. The
+// authenticator decides whether the logged in client has to pass the second
+// authentication factor, and the limiter whether the client is locked out,
+// same as for the password login.
+func NewService(p Provider, c postgres.Repository, i Repository, pr policies.Repository, t jwt.Repository, m mfa.Authenticator, l lockout.Limiter, h clients.Hasher, idp mainflux.IDProvider, cfg Config) Service {
+	return service{
+		provider:   p,
+		clients:    c,
+		identities: i,
+		policies:   pr,
+		tokens:     t,
+		mfa:        m,
+		lockout:    l,
+		hasher:     h,
+		idProvider: idp,
+		cfg:        cfg,
+	}
+}
+
+func (svc service) Login(ctx context.Context) (string, string, error) {
+	state, err := random()
+	if err != nil {
+		return "", "", err
+	}
+	url, err := svc.provider.AuthCodeURL(ctx, state, nonce(state))
+	if err != nil {
+		return "", "", err
+	}
+
+	return url, state, nil
+}
+
+func (svc service) Callback(ctx context.Context, code, state, sessionState string) (jwt.Token, error) {
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(sessionState)) != 1 {
+		return jwt.Token{}, errors.Wrap(errors.ErrAuthentication, ErrInvalidState)
+	}
+	claims, err := svc.provider.Exchange(ctx, code, nonce(state))
+	if err != nil {
+		return jwt.Token{}, err
+	}
+	// The identity locked out by the failed password logins can't log in
+	// over the IdP either, while the IdP login doesn't clear the failures.
+	if err := svc.lockout.Allow(ctx, claims.Email, lockout.SourceIP(ctx)); err != nil {
+		return jwt.Token{}, errors.Wrap(errors.ErrTooManyRequests, err)
+	}
+
+	client, err := svc.client(ctx, claims)
+	if err != nil {
+		return jwt.Token{}, err
+	}
+	if err := svc.assignGroups(ctx, client.ID, claims); err != nil {
+		return jwt.Token{}, err
+	}
+
+	return mfa.Login(ctx, svc.mfa, svc.tokens, client)
+}
+
+// client returns the client the IdP user is mapped to, provisioning
+// it on the first login.
+func (svc service) client(ctx context.Context, claims Claims) (mfclients.Client, error) {
+	if claims.Email == "" {
+		return mfclients.Client{}, errors.Wrap(errors.ErrAuthentication, ErrMissingEmail)
+	}
+	if claims.EmailVerified != nil && !*claims.EmailVerified {
+		return mfclients.Client{}, errors.Wrap(errors.ErrAuthentication, ErrUnverifiedEmail)
+	}
+
+	client, err := svc.clients.RetrieveByIdentity(ctx, claims.Email)
+	switch {
+	case err == nil:
+		return svc.link(ctx, client, claims)
+	case errors.Contains(err, errors.ErrNotFound):
+		return svc.provision(ctx, claims)
+	default:
+		return mfclients.Client{}, err
+	}
+}
+
+// link binds the existing client to the IdP subject and keeps its role
+// in sync with the IdP.
+func (svc service) link(ctx context.Context, client mfclients.Client, claims Claims) (mfclients.Client, error) {
+	identity, err := svc.identities.Retrieve(ctx, client.ID)
+	switch {
+	case err == nil:
+		if identity.Subject != claims.Subject || identity.Issuer != claims.Issuer {
+			return mfclients.Client{}, errors.Wrap(errors.ErrAuthentication, ErrSubjectMismatch)
+		}
+	case errors.Contains(err, errors.ErrNotFound):
+		// Only the email the IdP vouches for is trusted to take over
+		// an account which was created locally.
+		if claims.EmailVerified == nil {
+			return mfclients.Client{}, errors.Wrap(errors.ErrAuthentication, ErrUnverifiedEmail)
+		}
+		if err := svc.bind(ctx, client.ID, claims); err != nil {
+			return mfclients.Client{}, err
+		}
+	default:
+		return mfclients.Client{}, err
+	}
+
+	if svc.cfg.RoleClaim != "" {
+		client.Role = svc.role(claims)
+		client.UpdatedAt = time.Now()
+		client.UpdatedBy = client.ID
+		if _, err := svc.clients.UpdateRole(ctx, client); err != nil {
+			return mfclients.Client{}, err
+		}
+	}
+
+	return client, nil
+}
+
+func (svc service) provision(ctx context.Context, claims Claims) (mfclients.Client, error) {
+	if !svc.cfg.AutoProvision {
+		return mfclients.Client{}, errors.Wrap(errors.ErrAuthentication, ErrProvisioningDisabled)
+	}
+
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return mfclients.Client{}, err
+	}
+	// Provisioned clients log in over the IdP only, so the secret is
+	// random and never handed out.
+	secret, err := random()
+	if err != nil {
+		return mfclients.Client{}, err
+	}
+	hash, err := svc.hasher.Hash(secret)
+	if err != nil {
+		return mfclients.Client{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	// The subject bound to another client has changed its email at the
+	// IdP, and must not get a second client.
+	if _, err := svc.identities.RetrieveBySubject(ctx, claims.Issuer, claims.Subject); err == nil {
+		return mfclients.Client{}, errors.Wrap(errors.ErrAuthentication, ErrSubjectMismatch)
+	} else if !errors.Contains(err, errors.ErrNotFound) {
+		return mfclients.Client{}, err
+	}
+	name := claims.Name
+	if name == "" {
+		name = claims.Email
+	}
+
+	client := mfclients.Client{
+		ID:   id,
+		Name: name,
+		Credentials: mfclients.Credentials{
+			Identity: claims.Email,
+			Secret:   hash,
+		},
+		Role:      svc.role(claims),
+		Status:    mfclients.EnabledStatus,
+		CreatedAt: time.Now(),
+	}
+	client, err = svc.clients.Save(ctx, client)
+	if err != nil {
+		// Disabled clients are not retrieved by identity, so the
+		// conflict means the client exists but is disabled.
+		if errors.Contains(err, errors.ErrConflict) {
+			return mfclients.Client{}, errors.Wrap(errors.ErrAuthentication, err)
+		}
+		return mfclients.Client{}, err
+	}
+	if err := svc.bind(ctx, client.ID, claims); err != nil {
+		return mfclients.Client{}, err
+	}
+
+	return client, nil
+}
+
+// bind binds the client to the IdP subject. The conflict means that either
+// of them has been bound concurrently.
+func (svc service) bind(ctx context.Context, clientID string, claims Claims) error {
+	i := Identity{
+		ClientID:  clientID,
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		CreatedAt: time.Now(),
+	}
+	if err := svc.identities.Save(ctx, i); err != nil {
+		if errors.Contains(err, errors.ErrConflict) {
+			return errors.Wrap(errors.ErrAuthentication, ErrSubjectMismatch)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (svc service) role(claims Claims) mfclients.Role {
+	if svc.cfg.RoleClaim == "" {
+		return mfclients.UserRole
+	}
+	for _, v := range claimValues(claims.Raw[svc.cfg.RoleClaim]) {
+		for _, admin := range svc.cfg.AdminRoles {
+			if v == admin {
+				return mfclients.AdminRole
+			}
+		}
+	}
+
+	return mfclients.UserRole
+}
+
+// assignGroups adds the client to the groups mapped from its group claim.
+// Memberships the client already has are left untouched.
+func (svc service) assignGroups(ctx context.Context, clientID string, claims Claims) error {
+	if svc.cfg.GroupsClaim == "" {
+		return nil
+	}
+	for _, v := range claimValues(claims.Raw[svc.cfg.GroupsClaim]) {
+		groupID, ok := svc.cfg.Groups[v]
+		if !ok {
+			continue
+		}
+		p := policies.Policy{
+			OwnerID:   clientID,
+			Subject:   clientID,
+			Object:    groupID,
+			Actions:   policies.AddListAction(svc.cfg.GroupActions),
+			CreatedAt: time.Now(),
+		}
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if err := svc.policies.Save(ctx, p); err != nil && !errors.Contains(err, errors.ErrConflict) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// claimValues returns the string values of the claim, which can be either
+// a single string or an array of strings.
+func claimValues(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, val := range v {
+			if s, ok := val.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func random() (string, error) {
+	b := make([]byte, randomSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// nonce derives the ID token nonce from the state, so the flow needs no
+// server-side session storage.
+func nonce(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
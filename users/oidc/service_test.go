@@ -0,0 +1,425 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/users/clients/mocks"
+	"github.com/mainflux/mainflux/users/hasher"
+	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/oidc"
+	omocks "github.com/mainflux/mainflux/users/oidc/mocks"
+	"github.com/mainflux/mainflux/users/policies"
+	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	idProvider      = uuid.New()
+	secret          = "strongsecret"
+	accessDuration  = time.Minute
+	refreshDuration = 10 * time.Minute
+	adminsGroupID   = "1a0f3e52-5d2e-4f5c-9a47-5d1fd0c2c3a8"
+	lockoutConfig   = lockout.Config{MaxAttempts: 1, Window: time.Minute, Duration: time.Minute}
+)
+
+func newLimiter() lockout.Limiter {
+	return lockout.NewLimiter(lmocks.NewRepository(), lockoutConfig)
+}
+
+func newService(idp *stubIdP, cfg oidc.Config, enrollments mfa.Repository, limiter lockout.Limiter) (oidc.Service, jwt.Repository, *mocks.Repository, oidc.Repository, *pmocks.Repository) {
+	cRepo := new(mocks.Repository)
+	iRepo := omocks.NewRepository()
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+
+	cfg.IssuerURL = idp.URL
+	cfg.ClientID = clientID
+	cfg.ClientSecret = clientSecret
+	cfg.RedirectURL = redirectURL
+	cfg.Scopes = []string{"openid", "email"}
+	provider := oidc.NewProvider(cfg, http.DefaultClient)
+
+	return oidc.NewService(provider, cRepo, iRepo, pRepo, tokenizer, mfa.NewAuthenticator(enrollments, false), limiter, hasher.New(), idProvider, cfg), tokenizer, cRepo, iRepo, pRepo
+}
+
+func login(t *testing.T, svc oidc.Service) (string, string) {
+	authURL, state, err := svc.Login(context.Background())
+	require.Nil(t, err, fmt.Sprintf("login unexpected error: %s", err))
+	u, err := url.Parse(authURL)
+	require.Nil(t, err, fmt.Sprintf("parsing auth URL unexpected error: %s", err))
+
+	return state, u.Query().Get("nonce")
+}
+
+func TestLogin(t *testing.T) {
+	idp := newStubIdP(t)
+	svc, _, _, _, _ := newService(idp, oidc.Config{}, mmocks.NewRepository(), newLimiter())
+
+	authURL, state, err := svc.Login(context.Background())
+	require.Nil(t, err, fmt.Sprintf("login unexpected error: %s", err))
+	assert.NotEmpty(t, state, "expected state not to be empty")
+
+	u, err := url.Parse(authURL)
+	require.Nil(t, err, fmt.Sprintf("parsing auth URL unexpected error: %s", err))
+	assert.Equal(t, idp.URL+"/authorize", fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path))
+	q := u.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, clientID, q.Get("client_id"))
+	assert.Equal(t, redirectURL, q.Get("redirect_uri"))
+	assert.Equal(t, "openid email", q.Get("scope"))
+	assert.Equal(t, state, q.Get("state"))
+	assert.NotEmpty(t, q.Get("nonce"), "expected nonce not to be empty")
+	assert.NotEqual(t, state, q.Get("nonce"), "expected nonce to differ from state")
+
+	_, other, err := svc.Login(context.Background())
+	require.Nil(t, err, fmt.Sprintf("login unexpected error: %s", err))
+	assert.NotEqual(t, state, other, "expected a new state for each login")
+
+	idp.Close()
+	svc, _, _, _, _ = newService(idp, oidc.Config{}, mmocks.NewRepository(), newLimiter())
+	_, _, err = svc.Login(context.Background())
+	assert.True(t, errors.Contains(err, oidc.ErrProvider), fmt.Sprintf("login with unavailable IdP: expected %s got %s", oidc.ErrProvider, err))
+}
+
+func TestCallback(t *testing.T) {
+	idp := newStubIdP(t)
+	cfg := oidc.Config{
+		AutoProvision: true,
+		RoleClaim:     "roles",
+		AdminRoles:    []string{"mainflux-admin"},
+		GroupsClaim:   "groups",
+		Groups:        map[string]string{"admins": adminsGroupID},
+		GroupActions:  []string{"g_list"},
+	}
+	svc, tokenizer, cRepo, iRepo, pRepo := newService(idp, cfg, mmocks.NewRepository(), newLimiter())
+
+	subject := testsutil.GenerateUUID(t, idProvider)
+	bound := mfclients.Client{
+		ID:          testsutil.GenerateUUID(t, idProvider),
+		Credentials: mfclients.Credentials{Identity: "bound@example.com"},
+		Status:      mfclients.EnabledStatus,
+	}
+	err := iRepo.Save(context.Background(), oidc.Identity{ClientID: bound.ID, Issuer: idp.URL, Subject: subject})
+	require.Nil(t, err, fmt.Sprintf("saving identity unexpected error: %s", err))
+	// The binding is not taken from the metadata the users can edit.
+	forged := mfclients.Client{
+		ID:          testsutil.GenerateUUID(t, idProvider),
+		Credentials: mfclients.Credentials{Identity: "forged@example.com"},
+		Metadata:    mfclients.Metadata{"oidc_subject": subject, "oidc_issuer": idp.URL},
+		Status:      mfclients.EnabledStatus,
+	}
+	local := mfclients.Client{
+		ID:          testsutil.GenerateUUID(t, idProvider),
+		Credentials: mfclients.Credentials{Identity: "local@example.com"},
+		Metadata:    mfclients.Metadata{},
+		Status:      mfclients.EnabledStatus,
+	}
+
+	cases := []struct {
+		desc      string
+		claims    map[string]interface{}
+		code      string
+		state     string
+		nonce     string
+		client    mfclients.Client
+		retErr    error
+		saveErr   error
+		role      mfclients.Role
+		linked    bool
+		saved     bool
+		groupSave bool
+		err       error
+	}{
+		{
+			desc: "log in for the first time",
+			claims: map[string]interface{}{
+				"sub":    testsutil.GenerateUUID(t, idProvider),
+				"email":  "new@example.com",
+				"name":   "New User",
+				"roles":  []string{"mainflux-admin"},
+				"groups": []string{"admins", "unmapped"},
+			},
+			retErr:    errors.ErrNotFound,
+			role:      mfclients.AdminRole,
+			saved:     true,
+			groupSave: true,
+			err:       nil,
+		},
+		{
+			desc: "log in with client bound to the subject",
+			claims: map[string]interface{}{
+				"sub":   subject,
+				"email": bound.Credentials.Identity,
+				"roles": "viewer",
+			},
+			client: bound,
+			role:   mfclients.UserRole,
+			err:    nil,
+		},
+		{
+			desc: "log in with client bound to another subject",
+			claims: map[string]interface{}{
+				"sub":   testsutil.GenerateUUID(t, idProvider),
+				"email": bound.Credentials.Identity,
+			},
+			client: bound,
+			err:    oidc.ErrSubjectMismatch,
+		},
+		{
+			desc: "log in with local client and email without verification claim",
+			claims: map[string]interface{}{
+				"sub":   testsutil.GenerateUUID(t, idProvider),
+				"email": local.Credentials.Identity,
+			},
+			client: local,
+			err:    oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc: "log in with local client and verified email",
+			claims: map[string]interface{}{
+				"sub":            testsutil.GenerateUUID(t, idProvider),
+				"email":          local.Credentials.Identity,
+				"email_verified": true,
+			},
+			client: local,
+			role:   mfclients.UserRole,
+			linked: true,
+			err:    nil,
+		},
+		{
+			desc: "log in with linked client and another subject",
+			claims: map[string]interface{}{
+				"sub":            testsutil.GenerateUUID(t, idProvider),
+				"email":          local.Credentials.Identity,
+				"email_verified": true,
+			},
+			client: local,
+			err:    oidc.ErrSubjectMismatch,
+		},
+		{
+			desc: "log in with client whose metadata claims the subject",
+			claims: map[string]interface{}{
+				"sub":   subject,
+				"email": forged.Credentials.Identity,
+			},
+			client: forged,
+			err:    oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc: "log in for the first time with subject bound to another client",
+			claims: map[string]interface{}{
+				"sub":            subject,
+				"email":          "changed@example.com",
+				"email_verified": true,
+			},
+			retErr: errors.ErrNotFound,
+			err:    oidc.ErrSubjectMismatch,
+		},
+		{
+			desc: "log in with unverified email",
+			claims: map[string]interface{}{
+				"sub":            testsutil.GenerateUUID(t, idProvider),
+				"email":          "unverified@example.com",
+				"email_verified": false,
+			},
+			err: oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc: "log in without email",
+			claims: map[string]interface{}{
+				"sub": testsutil.GenerateUUID(t, idProvider),
+			},
+			err: oidc.ErrMissingEmail,
+		},
+		{
+			desc: "log in with disabled client",
+			claims: map[string]interface{}{
+				"sub":   testsutil.GenerateUUID(t, idProvider),
+				"email": "disabled@example.com",
+			},
+			retErr:  errors.ErrNotFound,
+			saveErr: errors.ErrConflict,
+			saved:   true,
+			err:     errors.ErrAuthentication,
+		},
+		{
+			desc: "log in with mismatched state",
+			claims: map[string]interface{}{
+				"sub":   testsutil.GenerateUUID(t, idProvider),
+				"email": "new@example.com",
+			},
+			state: "mismatched",
+			err:   oidc.ErrInvalidState,
+		},
+		{
+			desc: "log in with mismatched nonce",
+			claims: map[string]interface{}{
+				"sub":   testsutil.GenerateUUID(t, idProvider),
+				"email": "new@example.com",
+			},
+			nonce: "mismatched",
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc: "log in with invalid code",
+			code: "invalid",
+			err:  errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		state, nonce := login(t, svc)
+		if tc.nonce != "" {
+			nonce = tc.nonce
+		}
+		code := tc.code
+		if code == "" {
+			tc.claims["nonce"] = nonce
+			code = idp.code(t, tc.claims)
+		}
+		sessionState := state
+		if tc.state != "" {
+			sessionState = tc.state
+		}
+		repoCall := cRepo.On("RetrieveByIdentity", context.Background(), mock.Anything).Return(tc.client, tc.retErr)
+		repoCall1 := cRepo.On("Save", context.Background(), mock.Anything).Return(mfclients.Client{}, tc.saveErr)
+		repoCall2 := cRepo.On("UpdateRole", context.Background(), mock.Anything).Return(tc.client, nil)
+		repoCall3 := pRepo.On("Save", context.Background(), mock.Anything).Return(nil)
+
+		token, err := svc.Callback(context.Background(), code, state, sessionState)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.saved {
+			ok := repoCall1.Parent.AssertCalled(t, "Save", context.Background(), mock.MatchedBy(func(c mfclients.Client) bool {
+				return c.Credentials.Identity == tc.claims["email"] && c.Role == tc.role
+			}))
+			assert.True(t, ok, fmt.Sprintf("Save was not called on %s", tc.desc))
+		}
+		if err == nil {
+			_, err := iRepo.RetrieveBySubject(context.Background(), idp.URL, tc.claims["sub"].(string))
+			assert.Nil(t, err, fmt.Sprintf("%s: expected the subject to be bound got %s\n", tc.desc, err))
+		}
+		if tc.linked {
+			i, err := iRepo.Retrieve(context.Background(), tc.client.ID)
+			assert.Nil(t, err, fmt.Sprintf("%s: retrieving identity unexpected error: %s\n", tc.desc, err))
+			assert.Equal(t, tc.claims["sub"], i.Subject, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.claims["sub"], i.Subject))
+		}
+		if tc.groupSave {
+			ok := repoCall3.Parent.AssertCalled(t, "Save", context.Background(), mock.MatchedBy(func(p policies.Policy) bool {
+				return p.Object == adminsGroupID
+			}))
+			assert.True(t, ok, fmt.Sprintf("policy Save was not called on %s", tc.desc))
+			repoCall3.Parent.AssertNumberOfCalls(t, "Save", 1)
+		}
+		if err == nil {
+			claims, err := tokenizer.Parse(context.Background(), token.AccessToken)
+			assert.Nil(t, err, fmt.Sprintf("%s: parsing issued token unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.claims["email"], claims.Email, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.claims["email"], claims.Email))
+			if tc.client.ID != "" {
+				assert.Equal(t, tc.client.ID, claims.ClientID, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.client.ID, claims.ClientID))
+				ok := repoCall2.Parent.AssertCalled(t, "UpdateRole", context.Background(), mock.MatchedBy(func(c mfclients.Client) bool {
+					return c.ID == tc.client.ID && c.Role == tc.role
+				}))
+				assert.True(t, ok, fmt.Sprintf("UpdateRole was not called on %s", tc.desc))
+			}
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		cRepo.Calls = nil
+		pRepo.Calls = nil
+	}
+}
+
+func TestCallbackWithoutProvisioning(t *testing.T) {
+	idp := newStubIdP(t)
+	svc, _, cRepo, _, _ := newService(idp, oidc.Config{AutoProvision: false}, mmocks.NewRepository(), newLimiter())
+
+	state, nonce := login(t, svc)
+	code := idp.code(t, map[string]interface{}{
+		"sub":   testsutil.GenerateUUID(t, idProvider),
+		"email": "new@example.com",
+		"nonce": nonce,
+	})
+
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), "new@example.com").Return(mfclients.Client{}, errors.ErrNotFound)
+	_, err := svc.Callback(context.Background(), code, state, state)
+	assert.True(t, errors.Contains(err, oidc.ErrProvisioningDisabled), fmt.Sprintf("expected %s got %s\n", oidc.ErrProvisioningDisabled, err))
+	repoCall.Parent.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	repoCall.Unset()
+}
+
+func TestCallbackWithMFA(t *testing.T) {
+	idp := newStubIdP(t)
+	enrollments := mmocks.NewRepository()
+	svc, tokenizer, cRepo, iRepo, _ := newService(idp, oidc.Config{}, enrollments, newLimiter())
+
+	subject := testsutil.GenerateUUID(t, idProvider)
+	client := mfclients.Client{
+		ID:          testsutil.GenerateUUID(t, idProvider),
+		Credentials: mfclients.Credentials{Identity: "enrolled@example.com"},
+		Status:      mfclients.EnabledStatus,
+	}
+	err := iRepo.Save(context.Background(), oidc.Identity{ClientID: client.ID, Issuer: idp.URL, Subject: subject})
+	require.Nil(t, err, fmt.Sprintf("saving identity unexpected error: %s", err))
+	err = enrollments.Save(context.Background(), mfa.Enrollment{ClientID: client.ID, Secret: "secret", Confirmed: true})
+	require.Nil(t, err, fmt.Sprintf("saving enrollment unexpected error: %s", err))
+
+	state, nonce := login(t, svc)
+	code := idp.code(t, map[string]interface{}{
+		"sub":   subject,
+		"email": client.Credentials.Identity,
+		"nonce": nonce,
+	})
+
+	repoCall := cRepo.On("RetrieveByIdentity", context.Background(), client.Credentials.Identity).Return(client, nil)
+	token, err := svc.Callback(context.Background(), code, state, state)
+	require.Nil(t, err, fmt.Sprintf("callback unexpected error: %s", err))
+	assert.Equal(t, jwt.MFAType, token.AccessType, fmt.Sprintf("expected %s got %s\n", jwt.MFAType, token.AccessType))
+	assert.Empty(t, token.RefreshToken, "expected no refresh token before the second factor is verified")
+	claims, err := tokenizer.Parse(context.Background(), token.AccessToken)
+	require.Nil(t, err, fmt.Sprintf("parsing challenge unexpected error: %s", err))
+	assert.Equal(t, jwt.MFAToken, claims.Type, fmt.Sprintf("expected %s got %s\n", jwt.MFAToken, claims.Type))
+	repoCall.Unset()
+}
+
+func TestCallbackLockout(t *testing.T) {
+	idp := newStubIdP(t)
+	limiter := newLimiter()
+	svc, _, cRepo, _, _ := newService(idp, oidc.Config{AutoProvision: true}, mmocks.NewRepository(), limiter)
+
+	locked, err := limiter.Fail(context.Background(), "locked@example.com", "")
+	require.Nil(t, err, fmt.Sprintf("failing login unexpected error: %s", err))
+	require.True(t, locked, "expected the identity to be locked out")
+
+	state, nonce := login(t, svc)
+	code := idp.code(t, map[string]interface{}{
+		"sub":   testsutil.GenerateUUID(t, idProvider),
+		"email": "locked@example.com",
+		"nonce": nonce,
+	})
+
+	_, err = svc.Callback(context.Background(), code, state, state)
+	assert.True(t, errors.Contains(err, errors.ErrTooManyRequests), fmt.Sprintf("expected %s got %s\n", errors.ErrTooManyRequests, err))
+	assert.True(t, errors.Contains(err, lockout.ErrLocked), fmt.Sprintf("expected %s got %s\n", lockout.ErrLocked, err))
+	cRepo.AssertNotCalled(t, "RetrieveByIdentity", mock.Anything, mock.Anything)
+}
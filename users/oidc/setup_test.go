@@ -0,0 +1,143 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clientID     = "mainflux"
+	clientSecret = "mainflux-secret"
+	redirectURL  = "http://localhost/users/oidc/callback"
+)
+
+// stubIdP is a minimal OpenID Connect provider which issues ID tokens
+// with the claims registered for an authorization code.
+type stubIdP struct {
+	*httptest.Server
+	key   jwk.Key
+	keys  jwk.Set
+	mu    sync.Mutex
+	codes map[string]map[string]interface{}
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, "generating IdP key unexpected error")
+	key, err := jwk.FromRaw(raw)
+	require.Nil(t, err, "creating IdP JWK unexpected error")
+	require.Nil(t, key.Set(jwk.KeyIDKey, "stub"))
+	require.Nil(t, key.Set(jwk.AlgorithmKey, jwa.RS256))
+	pub, err := key.PublicKey()
+	require.Nil(t, err, "creating IdP public JWK unexpected error")
+	keys := jwk.NewSet()
+	require.Nil(t, keys.AddKey(pub))
+
+	idp := &stubIdP{
+		key:   key,
+		keys:  keys,
+		codes: make(map[string]map[string]interface{}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/jwks", idp.jwks)
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+
+	return idp
+}
+
+// code registers the ID token claims and returns the authorization code
+// they are issued for.
+func (idp *stubIdP) code(t *testing.T, claims map[string]interface{}) string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	require.Nil(t, err, "generating code unexpected error")
+	code := hex.EncodeToString(b)
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.codes[code] = claims
+
+	return code
+}
+
+func (idp *stubIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"issuer":                 idp.URL,
+		"authorization_endpoint": idp.URL + "/authorize",
+		"token_endpoint":         idp.URL + "/token",
+		"jwks_uri":               idp.URL + "/jwks",
+	})
+}
+
+func (idp *stubIdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(idp.keys)
+}
+
+func (idp *stubIdP) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != clientID || secret != clientSecret {
+		tokenError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("redirect_uri") != redirectURL {
+		tokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	idp.mu.Lock()
+	claims, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+	if !ok {
+		tokenError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	builder := jwt.NewBuilder().
+		Issuer(idp.URL).
+		Audience([]string{clientID}).
+		IssuedAt(time.Now()).
+		Expiration(time.Now().Add(time.Minute))
+	for k, v := range claims {
+		builder = builder.Claim(k, v)
+	}
+	token, err := builder.Build()
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, idp.key))
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "stub",
+		"token_type":   "Bearer",
+		"id_token":     string(signed),
+	})
+}
+
+func tokenError(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/oidc"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ oidc.Service = (*tracingMiddleware)(nil)
+
+type tracingMiddleware struct {
+	tracer trace.Tracer
+	svc    oidc.Service
+}
+
+// New returns a new OpenID Connect login service with tracing capabilities.
+func New(svc oidc.Service, tracer trace.Tracer) oidc.Service {
+	return &tracingMiddleware{tracer, svc}
+}
+
+// Login traces the "Login" operation of the wrapped oidc.Service.
+func (tm *tracingMiddleware) Login(ctx context.Context) (string, string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_oidc_login")
+	defer span.End()
+
+	return tm.svc.Login(ctx)
+}
+
+// Callback traces the "Callback" operation of the wrapped oidc.Service.
+func (tm *tracingMiddleware) Callback(ctx context.Context, code, state, sessionState string) (jwt.Token, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_oidc_callback")
+	defer span.End()
+
+	return tm.svc.Callback(ctx, code, state, sessionState)
+}
@@ -0,0 +1,255 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/orgs"
+)
+
+func createOrgEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(orgReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		org, err := svc.CreateOrg(ctx, req.token, req.org())
+		if err != nil {
+			return nil, err
+		}
+
+		return orgRes{Organization: org, created: true}, nil
+	}
+}
+
+func viewOrgEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewOrgReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		org, err := svc.ViewOrg(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return orgRes{Organization: org}, nil
+	}
+}
+
+func listOrgsEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListOrgs(ctx, req.token, orgs.Page{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+
+		res := listOrgsRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Orgs: []orgs.Organization{},
+		}
+		res.Orgs = append(res.Orgs, page.Orgs...)
+
+		return res, nil
+	}
+}
+
+func updateOrgEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(orgReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if req.id == "" {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingID)
+		}
+
+		org, err := svc.UpdateOrg(ctx, req.token, req.org())
+		if err != nil {
+			return nil, err
+		}
+
+		return orgRes{Organization: org}, nil
+	}
+}
+
+func removeOrgEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewOrgReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveOrg(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func listMembersEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListMembers(ctx, req.token, req.id, orgs.Page{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+
+		res := listMembersRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Members: []orgs.Member{},
+		}
+		res.Members = append(res.Members, page.Members...)
+
+		return res, nil
+	}
+}
+
+func updateMemberEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(memberReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		m, err := svc.UpdateMember(ctx, req.token, orgs.Member{OrgID: req.orgID, MemberID: req.memberID, Role: req.Role})
+		if err != nil {
+			return nil, err
+		}
+
+		return memberRes{Member: m}, nil
+	}
+}
+
+func removeMemberEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(memberReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveMember(ctx, req.token, req.orgID, req.memberID); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func inviteEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(inviteReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		inv, err := svc.Invite(ctx, req.token, orgs.Invitation{OrgID: req.orgID, Identity: req.Identity, Role: req.Role})
+		if err != nil {
+			return nil, err
+		}
+
+		return invitationRes{Invitation: inv}, nil
+	}
+}
+
+func listInvitationsEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListInvitations(ctx, req.token, req.id, orgs.Page{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+
+		res := listInvitationsRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Invitations: []orgs.Invitation{},
+		}
+		res.Invitations = append(res.Invitations, page.Invitations...)
+
+		return res, nil
+	}
+}
+
+func acceptInvitationEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewOrgReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		m, err := svc.AcceptInvitation(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return memberRes{Member: m}, nil
+	}
+}
+
+func removeInvitationEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewOrgReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveInvitation(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func issueTokenEndpoint(svc orgs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewOrgReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		token, err := svc.IssueToken(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{
+			AccessToken:  token.AccessToken,
+			RefreshToken: token.RefreshToken,
+			AccessType:   token.AccessType,
+		}, nil
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"github.com/mainflux/mainflux/internal/api"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/orgs"
+)
+
+type orgReq struct {
+	token       string
+	id          string
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Metadata    mfclients.Metadata `json:"metadata,omitempty"`
+	Quotas      orgs.Quotas        `json:"quotas,omitempty"`
+}
+
+func (req orgReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" || len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+func (req orgReq) org() orgs.Organization {
+	return orgs.Organization{
+		ID:          req.id,
+		Name:        req.Name,
+		Description: req.Description,
+		Metadata:    req.Metadata,
+		Quotas:      req.Quotas,
+	}
+}
+
+type viewOrgReq struct {
+	token string
+	id    string
+}
+
+func (req viewOrgReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token  string
+	id     string
+	offset uint64
+	limit  uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type memberReq struct {
+	token    string
+	orgID    string
+	memberID string
+	Role     string `json:"role,omitempty"`
+}
+
+func (req memberReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.orgID == "" || req.memberID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type inviteReq struct {
+	token    string
+	orgID    string
+	Identity string `json:"identity,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+func (req inviteReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.orgID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Identity == "" {
+		return apiutil.ErrMissingIdentity
+	}
+	if err := orgs.ValidateRole(req.Role); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
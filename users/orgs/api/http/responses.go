@@ -0,0 +1,171 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/users/orgs"
+)
+
+var (
+	_ mainflux.Response = (*orgRes)(nil)
+	_ mainflux.Response = (*listOrgsRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+	_ mainflux.Response = (*memberRes)(nil)
+	_ mainflux.Response = (*listMembersRes)(nil)
+	_ mainflux.Response = (*invitationRes)(nil)
+	_ mainflux.Response = (*listInvitationsRes)(nil)
+	_ mainflux.Response = (*tokenRes)(nil)
+)
+
+type pageRes struct {
+	Limit  uint64 `json:"limit"`
+	Offset uint64 `json:"offset"`
+	Total  uint64 `json:"total"`
+}
+
+type orgRes struct {
+	orgs.Organization `json:",inline"`
+	created           bool
+}
+
+func (res orgRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res orgRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/orgs/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res orgRes) Empty() bool {
+	return false
+}
+
+type listOrgsRes struct {
+	pageRes
+	Orgs []orgs.Organization `json:"orgs"`
+}
+
+func (res listOrgsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listOrgsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listOrgsRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type memberRes struct {
+	orgs.Member `json:",inline"`
+}
+
+func (res memberRes) Code() int {
+	return http.StatusOK
+}
+
+func (res memberRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res memberRes) Empty() bool {
+	return false
+}
+
+type listMembersRes struct {
+	pageRes
+	Members []orgs.Member `json:"members"`
+}
+
+func (res listMembersRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listMembersRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listMembersRes) Empty() bool {
+	return false
+}
+
+type invitationRes struct {
+	orgs.Invitation `json:",inline"`
+}
+
+func (res invitationRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res invitationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res invitationRes) Empty() bool {
+	return false
+}
+
+type listInvitationsRes struct {
+	pageRes
+	Invitations []orgs.Invitation `json:"invitations"`
+}
+
+func (res listInvitationsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listInvitationsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listInvitationsRes) Empty() bool {
+	return false
+}
+
+type tokenRes struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	AccessType   string `json:"access_type,omitempty"`
+}
+
+func (res tokenRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res tokenRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res tokenRes) Empty() bool {
+	return res.AccessToken == ""
+}
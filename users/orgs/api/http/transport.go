@@ -0,0 +1,207 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/internal/api"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/orgs"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc orgs.Service, mux *bone.Mux, logger logger.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux.Post("/orgs", otelhttp.NewHandler(kithttp.NewServer(
+		createOrgEndpoint(svc),
+		decodeOrgRequest,
+		api.EncodeResponse,
+		opts...,
+	), "create_org"))
+
+	mux.Get("/orgs", otelhttp.NewHandler(kithttp.NewServer(
+		listOrgsEndpoint(svc),
+		decodeListRequest,
+		api.EncodeResponse,
+		opts...,
+	), "list_orgs"))
+
+	mux.Get("/orgs/:id", otelhttp.NewHandler(kithttp.NewServer(
+		viewOrgEndpoint(svc),
+		decodeViewRequest,
+		api.EncodeResponse,
+		opts...,
+	), "view_org"))
+
+	mux.Put("/orgs/:id", otelhttp.NewHandler(kithttp.NewServer(
+		updateOrgEndpoint(svc),
+		decodeOrgRequest,
+		api.EncodeResponse,
+		opts...,
+	), "update_org"))
+
+	mux.Delete("/orgs/:id", otelhttp.NewHandler(kithttp.NewServer(
+		removeOrgEndpoint(svc),
+		decodeViewRequest,
+		api.EncodeResponse,
+		opts...,
+	), "remove_org"))
+
+	mux.Post("/orgs/:id/tokens", otelhttp.NewHandler(kithttp.NewServer(
+		issueTokenEndpoint(svc),
+		decodeViewRequest,
+		api.EncodeResponse,
+		opts...,
+	), "issue_org_token"))
+
+	mux.Get("/orgs/:id/members", otelhttp.NewHandler(kithttp.NewServer(
+		listMembersEndpoint(svc),
+		decodeListRequest,
+		api.EncodeResponse,
+		opts...,
+	), "list_org_members"))
+
+	mux.Put("/orgs/:id/members/:memberID", otelhttp.NewHandler(kithttp.NewServer(
+		updateMemberEndpoint(svc),
+		decodeMemberRequest,
+		api.EncodeResponse,
+		opts...,
+	), "update_org_member"))
+
+	mux.Delete("/orgs/:id/members/:memberID", otelhttp.NewHandler(kithttp.NewServer(
+		removeMemberEndpoint(svc),
+		decodeMemberRequest,
+		api.EncodeResponse,
+		opts...,
+	), "remove_org_member"))
+
+	mux.Post("/orgs/:id/invitations", otelhttp.NewHandler(kithttp.NewServer(
+		inviteEndpoint(svc),
+		decodeInviteRequest,
+		api.EncodeResponse,
+		opts...,
+	), "invite_org_member"))
+
+	mux.Get("/orgs/:id/invitations", otelhttp.NewHandler(kithttp.NewServer(
+		listInvitationsEndpoint(svc),
+		decodeListRequest,
+		api.EncodeResponse,
+		opts...,
+	), "list_org_invitations"))
+
+	mux.Get("/invitations", otelhttp.NewHandler(kithttp.NewServer(
+		listInvitationsEndpoint(svc),
+		decodeListRequest,
+		api.EncodeResponse,
+		opts...,
+	), "list_invitations"))
+
+	mux.Post("/invitations/:id/accept", otelhttp.NewHandler(kithttp.NewServer(
+		acceptInvitationEndpoint(svc),
+		decodeViewRequest,
+		api.EncodeResponse,
+		opts...,
+	), "accept_invitation"))
+
+	mux.Delete("/invitations/:id", otelhttp.NewHandler(kithttp.NewServer(
+		removeInvitationEndpoint(svc),
+		decodeViewRequest,
+		api.EncodeResponse,
+		opts...,
+	), "remove_invitation"))
+
+	return mux
+}
+
+func decodeOrgRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := orgReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeViewRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewOrgReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeListRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listReq{
+		token:  apiutil.ExtractBearerToken(r),
+		id:     bone.GetValue(r, "id"),
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+func decodeMemberRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := memberReq{
+		token:    apiutil.ExtractBearerToken(r),
+		orgID:    bone.GetValue(r, "id"),
+		memberID: bone.GetValue(r, "memberID"),
+	}
+	if r.Method != http.MethodPut {
+		return req, nil
+	}
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeInviteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := inviteReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
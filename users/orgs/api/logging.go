@@ -0,0 +1,236 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/orgs"
+)
+
+var _ orgs.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger mflog.Logger
+	svc    orgs.Service
+}
+
+// LoggingMiddleware adds logging facilities to the organizations service.
+func LoggingMiddleware(svc orgs.Service, logger mflog.Logger) orgs.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+// CreateOrg logs the create_org request. It logs the organization name and ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) CreateOrg(ctx context.Context, token string, org orgs.Organization) (o orgs.Organization, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_org with name %s and id %s took %s to complete", org.Name, o.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.CreateOrg(ctx, token, org)
+}
+
+// ViewOrg logs the view_org request. It logs the organization ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewOrg(ctx context.Context, token, id string) (o orgs.Organization, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_org with id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ViewOrg(ctx, token, id)
+}
+
+// ListOrgs logs the list_orgs request. It logs the page offset and limit and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListOrgs(ctx context.Context, token string, pm orgs.Page) (op orgs.OrgsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_orgs with offset %d and limit %d took %s to complete", pm.Offset, pm.Limit, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ListOrgs(ctx, token, pm)
+}
+
+// UpdateOrg logs the update_org request. It logs the organization ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) UpdateOrg(ctx context.Context, token string, org orgs.Organization) (o orgs.Organization, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_org with id %s took %s to complete", org.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.UpdateOrg(ctx, token, org)
+}
+
+// RemoveOrg logs the remove_org request. It logs the organization ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveOrg(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_org with id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.RemoveOrg(ctx, token, id)
+}
+
+// ListMembers logs the list_org_members request. It logs the organization ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListMembers(ctx context.Context, token, orgID string, pm orgs.Page) (mp orgs.MembersPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_org_members with org id %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ListMembers(ctx, token, orgID, pm)
+}
+
+// UpdateMember logs the update_org_member request. It logs the organization ID, member ID and role and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) UpdateMember(ctx context.Context, token string, m orgs.Member) (mem orgs.Member, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_org_member with org id %s, member id %s and role %s took %s to complete", m.OrgID, m.MemberID, m.Role, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.UpdateMember(ctx, token, m)
+}
+
+// RemoveMember logs the remove_org_member request. It logs the organization ID and member ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveMember(ctx context.Context, token, orgID, memberID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_org_member with org id %s and member id %s took %s to complete", orgID, memberID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.RemoveMember(ctx, token, orgID, memberID)
+}
+
+// Invite logs the invite_org_member request. It logs the organization ID and invitation ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Invite(ctx context.Context, token string, inv orgs.Invitation) (i orgs.Invitation, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method invite_org_member with org id %s and invitation id %s took %s to complete", inv.OrgID, i.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Invite(ctx, token, inv)
+}
+
+// ListInvitations logs the list_org_invitations request. It logs the organization ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListInvitations(ctx context.Context, token, orgID string, pm orgs.Page) (ip orgs.InvitationsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_org_invitations with org id %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ListInvitations(ctx, token, orgID, pm)
+}
+
+// AcceptInvitation logs the accept_org_invitation request. It logs the invitation ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) AcceptInvitation(ctx context.Context, token, id string) (mem orgs.Member, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method accept_org_invitation with id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.AcceptInvitation(ctx, token, id)
+}
+
+// RemoveInvitation logs the remove_org_invitation request. It logs the invitation ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveInvitation(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_org_invitation with id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.RemoveInvitation(ctx, token, id)
+}
+
+// IssueToken logs the issue_org_token request. It logs the organization ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) IssueToken(ctx context.Context, token, orgID string) (t jwt.Token, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method issue_org_token with org id %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.IssueToken(ctx, token, orgID)
+}
+
+// Identify logs the identify_org request. It logs the identified organization ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Identify(ctx context.Context, token string) (tn orgs.Tenant, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method identify_org for org id %s took %s to complete", tn.OrgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.Identify(ctx, token)
+}
+
+// IdentifyMember logs the identify_org_member request. It logs the member ID, the organization ID and the time it took
+// to complete the request. If the request fails, it logs the error.
+func (lm *loggingMiddleware) IdentifyMember(ctx context.Context, memberID, orgID string) (tn orgs.Tenant, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method identify_org_member for member id %s and org id %s took %s to complete", memberID, orgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.IdentifyMember(ctx, memberID, orgID)
+}
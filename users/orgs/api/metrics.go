@@ -0,0 +1,165 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/orgs"
+)
+
+var _ orgs.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     orgs.Service
+}
+
+// MetricsMiddleware instruments organizations service by tracking request count and latency.
+func MetricsMiddleware(svc orgs.Service, counter metrics.Counter, latency metrics.Histogram) orgs.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+// CreateOrg instruments CreateOrg method with metrics.
+func (ms *metricsMiddleware) CreateOrg(ctx context.Context, token string, org orgs.Organization) (orgs.Organization, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_org").Add(1)
+		ms.latency.With("method", "create_org").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.CreateOrg(ctx, token, org)
+}
+
+// ViewOrg instruments ViewOrg method with metrics.
+func (ms *metricsMiddleware) ViewOrg(ctx context.Context, token, id string) (orgs.Organization, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_org").Add(1)
+		ms.latency.With("method", "view_org").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ViewOrg(ctx, token, id)
+}
+
+// ListOrgs instruments ListOrgs method with metrics.
+func (ms *metricsMiddleware) ListOrgs(ctx context.Context, token string, pm orgs.Page) (orgs.OrgsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_orgs").Add(1)
+		ms.latency.With("method", "list_orgs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListOrgs(ctx, token, pm)
+}
+
+// UpdateOrg instruments UpdateOrg method with metrics.
+func (ms *metricsMiddleware) UpdateOrg(ctx context.Context, token string, org orgs.Organization) (orgs.Organization, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_org").Add(1)
+		ms.latency.With("method", "update_org").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UpdateOrg(ctx, token, org)
+}
+
+// RemoveOrg instruments RemoveOrg method with metrics.
+func (ms *metricsMiddleware) RemoveOrg(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_org").Add(1)
+		ms.latency.With("method", "remove_org").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RemoveOrg(ctx, token, id)
+}
+
+// ListMembers instruments ListMembers method with metrics.
+func (ms *metricsMiddleware) ListMembers(ctx context.Context, token, orgID string, pm orgs.Page) (orgs.MembersPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_org_members").Add(1)
+		ms.latency.With("method", "list_org_members").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListMembers(ctx, token, orgID, pm)
+}
+
+// UpdateMember instruments UpdateMember method with metrics.
+func (ms *metricsMiddleware) UpdateMember(ctx context.Context, token string, m orgs.Member) (orgs.Member, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_org_member").Add(1)
+		ms.latency.With("method", "update_org_member").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UpdateMember(ctx, token, m)
+}
+
+// RemoveMember instruments RemoveMember method with metrics.
+func (ms *metricsMiddleware) RemoveMember(ctx context.Context, token, orgID, memberID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_org_member").Add(1)
+		ms.latency.With("method", "remove_org_member").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RemoveMember(ctx, token, orgID, memberID)
+}
+
+// Invite instruments Invite method with metrics.
+func (ms *metricsMiddleware) Invite(ctx context.Context, token string, inv orgs.Invitation) (orgs.Invitation, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "invite_org_member").Add(1)
+		ms.latency.With("method", "invite_org_member").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Invite(ctx, token, inv)
+}
+
+// ListInvitations instruments ListInvitations method with metrics.
+func (ms *metricsMiddleware) ListInvitations(ctx context.Context, token, orgID string, pm orgs.Page) (orgs.InvitationsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_org_invitations").Add(1)
+		ms.latency.With("method", "list_org_invitations").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListInvitations(ctx, token, orgID, pm)
+}
+
+// AcceptInvitation instruments AcceptInvitation method with metrics.
+func (ms *metricsMiddleware) AcceptInvitation(ctx context.Context, token, id string) (orgs.Member, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "accept_org_invitation").Add(1)
+		ms.latency.With("method", "accept_org_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.AcceptInvitation(ctx, token, id)
+}
+
+// RemoveInvitation instruments RemoveInvitation method with metrics.
+func (ms *metricsMiddleware) RemoveInvitation(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_org_invitation").Add(1)
+		ms.latency.With("method", "remove_org_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RemoveInvitation(ctx, token, id)
+}
+
+// IssueToken instruments IssueToken method with metrics.
+func (ms *metricsMiddleware) IssueToken(ctx context.Context, token, orgID string) (jwt.Token, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "issue_org_token").Add(1)
+		ms.latency.With("method", "issue_org_token").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.IssueToken(ctx, token, orgID)
+}
+
+// Identify instruments Identify method with metrics.
+func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (orgs.Tenant, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "identify_org").Add(1)
+		ms.latency.With("method", "identify_org").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Identify(ctx, token)
+}
+
+// IdentifyMember instruments IdentifyMember method with metrics.
+func (ms *metricsMiddleware) IdentifyMember(ctx context.Context, memberID, orgID string) (orgs.Tenant, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "identify_org_member").Add(1)
+		ms.latency.With("method", "identify_org_member").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.IdentifyMember(ctx, memberID, orgID)
+}
@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package orgs contains the domain concept definitions needed to
+// support Mainflux users organizations sub-service functionality.
+//
+// An organization is a tenant with its own members, admins and quotas.
+// Tokens scoped to an organization make the organization the owner of the
+// entities created using them, so all of its members share them.
+package orgs
@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/users/orgs"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ orgs.Repository = (*Repository)(nil)
+
+type Repository struct {
+	mock.Mock
+}
+
+func (m *Repository) Save(ctx context.Context, org orgs.Organization, owner orgs.Member) (orgs.Organization, error) {
+	ret := m.Called(ctx, org, owner)
+
+	return ret.Get(0).(orgs.Organization), ret.Error(1)
+}
+
+func (m *Repository) Retrieve(ctx context.Context, id string) (orgs.Organization, error) {
+	ret := m.Called(ctx, id)
+
+	return ret.Get(0).(orgs.Organization), ret.Error(1)
+}
+
+func (m *Repository) RetrieveAll(ctx context.Context, pm orgs.Page) (orgs.OrgsPage, error) {
+	ret := m.Called(ctx, pm)
+
+	return ret.Get(0).(orgs.OrgsPage), ret.Error(1)
+}
+
+func (m *Repository) Update(ctx context.Context, org orgs.Organization) (orgs.Organization, error) {
+	ret := m.Called(ctx, org)
+
+	return ret.Get(0).(orgs.Organization), ret.Error(1)
+}
+
+func (m *Repository) Remove(ctx context.Context, id string) error {
+	ret := m.Called(ctx, id)
+
+	return ret.Error(0)
+}
+
+func (m *Repository) SaveMember(ctx context.Context, mem orgs.Member) error {
+	ret := m.Called(ctx, mem)
+
+	return ret.Error(0)
+}
+
+func (m *Repository) RetrieveMember(ctx context.Context, orgID, memberID string) (orgs.Member, error) {
+	ret := m.Called(ctx, orgID, memberID)
+
+	return ret.Get(0).(orgs.Member), ret.Error(1)
+}
+
+func (m *Repository) RetrieveMembers(ctx context.Context, pm orgs.Page) (orgs.MembersPage, error) {
+	ret := m.Called(ctx, pm)
+
+	return ret.Get(0).(orgs.MembersPage), ret.Error(1)
+}
+
+func (m *Repository) UpdateMember(ctx context.Context, mem orgs.Member) (orgs.Member, error) {
+	ret := m.Called(ctx, mem)
+
+	return ret.Get(0).(orgs.Member), ret.Error(1)
+}
+
+func (m *Repository) RemoveMember(ctx context.Context, orgID, memberID string) error {
+	ret := m.Called(ctx, orgID, memberID)
+
+	return ret.Error(0)
+}
+
+func (m *Repository) SaveInvitation(ctx context.Context, inv orgs.Invitation) error {
+	ret := m.Called(ctx, inv)
+
+	return ret.Error(0)
+}
+
+func (m *Repository) RetrieveInvitation(ctx context.Context, id string) (orgs.Invitation, error) {
+	ret := m.Called(ctx, id)
+
+	return ret.Get(0).(orgs.Invitation), ret.Error(1)
+}
+
+func (m *Repository) RetrieveInvitations(ctx context.Context, pm orgs.Page) (orgs.InvitationsPage, error) {
+	ret := m.Called(ctx, pm)
+
+	return ret.Get(0).(orgs.InvitationsPage), ret.Error(1)
+}
+
+func (m *Repository) RemoveInvitation(ctx context.Context, id string) error {
+	ret := m.Called(ctx, id)
+
+	return ret.Error(0)
+}
@@ -0,0 +1,248 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package orgs
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/apiutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/jwt"
+)
+
+// Possible organization member roles. Admins manage the organization and
+// its members, while all the members share the organization entities.
+const (
+	AdminRole  = "admin"
+	MemberRole = "member"
+)
+
+const maxNameSize = 254
+
+var (
+	// ErrInvalidRole indicates that the member role is neither admin nor member.
+	ErrInvalidRole = errors.New("invalid organization member role")
+
+	// ErrInvitationExpired indicates that the invitation has expired.
+	ErrInvitationExpired = errors.New("organization invitation expired")
+
+	// ErrOwnerMember indicates an attempt to remove or demote the organization owner.
+	ErrOwnerMember = errors.New("organization owner can't be removed or demoted")
+)
+
+// Config defines the organizations configuration. The quotas are applied to
+// the organizations created by the users other than the platform admins.
+type Config struct {
+	InvitationDuration time.Duration `env:"INVITATION_DURATION" envDefault:"168h"`
+	UsersQuota         uint64        `env:"USERS_QUOTA"         envDefault:"0"`
+	ThingsQuota        uint64        `env:"THINGS_QUOTA"        envDefault:"0"`
+	ChannelsQuota      uint64        `env:"CHANNELS_QUOTA"      envDefault:"0"`
+}
+
+// Quotas limit the number of the organization members, things and channels.
+// Zero leaves the number unlimited.
+type Quotas struct {
+	Users    uint64 `json:"users"`
+	Things   uint64 `json:"things"`
+	Channels uint64 `json:"channels"`
+}
+
+// Organization represents a tenant owning its members' things, channels,
+// groups, bootstrap configurations and twins.
+type Organization struct {
+	ID          string             `json:"id"`
+	OwnerID     string             `json:"owner_id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Metadata    mfclients.Metadata `json:"metadata,omitempty"`
+	Quotas      Quotas             `json:"quotas"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at,omitempty"`
+	UpdatedBy   string             `json:"updated_by,omitempty"`
+}
+
+// Member represents the user membership in the organization.
+type Member struct {
+	OrgID     string    `json:"org_id"`
+	MemberID  string    `json:"member_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Invitation represents the invitation of the user with the given identity
+// to join the organization.
+type Invitation struct {
+	ID        string    `json:"id"`
+	OrgID     string    `json:"org_id"`
+	Identity  string    `json:"identity"`
+	Role      string    `json:"role"`
+	InvitedBy string    `json:"invited_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Tenant represents the organization the token is scoped to.
+type Tenant struct {
+	OrgID  string
+	Quotas Quotas
+}
+
+// Page contains page metadata that helps navigation.
+type Page struct {
+	Total    uint64 `json:"total"`
+	Offset   uint64 `json:"offset"`
+	Limit    uint64 `json:"limit"`
+	MemberID string `json:"member_id,omitempty"`
+	OrgID    string `json:"org_id,omitempty"`
+	Identity string `json:"identity,omitempty"`
+}
+
+// OrgsPage contains a page of organizations.
+type OrgsPage struct {
+	Page
+	Orgs []Organization
+}
+
+// MembersPage contains a page of organization members.
+type MembersPage struct {
+	Page
+	Members []Member
+}
+
+// InvitationsPage contains a page of organization invitations.
+type InvitationsPage struct {
+	Page
+	Invitations []Invitation
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateOrg creates the organization owned by the user, who becomes its
+	// admin. Quotas are set only by the platform admins.
+	CreateOrg(ctx context.Context, token string, org Organization) (Organization, error)
+
+	// ViewOrg retrieves the organization the user is member of.
+	ViewOrg(ctx context.Context, token, id string) (Organization, error)
+
+	// ListOrgs retrieves the organizations the user is member of. Platform
+	// admins retrieve all the organizations.
+	ListOrgs(ctx context.Context, token string, pm Page) (OrgsPage, error)
+
+	// UpdateOrg updates the organization name, description and metadata.
+	// Quotas are updated only by the platform admins.
+	UpdateOrg(ctx context.Context, token string, org Organization) (Organization, error)
+
+	// RemoveOrg removes the organization with its members and invitations.
+	RemoveOrg(ctx context.Context, token, id string) error
+
+	// ListMembers retrieves the organization members.
+	ListMembers(ctx context.Context, token, orgID string, pm Page) (MembersPage, error)
+
+	// UpdateMember changes the organization member role.
+	UpdateMember(ctx context.Context, token string, m Member) (Member, error)
+
+	// RemoveMember removes the member from the organization. Members can
+	// also leave the organization themselves.
+	RemoveMember(ctx context.Context, token, orgID, memberID string) error
+
+	// Invite invites the user with the given identity to the organization.
+	Invite(ctx context.Context, token string, inv Invitation) (Invitation, error)
+
+	// ListInvitations retrieves the invitations of the organization with the
+	// given ID, or the invitations of the user if the ID is empty.
+	ListInvitations(ctx context.Context, token, orgID string, pm Page) (InvitationsPage, error)
+
+	// AcceptInvitation adds the invited user to the organization.
+	AcceptInvitation(ctx context.Context, token, id string) (Member, error)
+
+	// RemoveInvitation declines or revokes the invitation.
+	RemoveInvitation(ctx context.Context, token, id string) error
+
+	// IssueToken exchanges the access token for the access and refresh
+	// token scoped to the organization the user is member of.
+	IssueToken(ctx context.Context, token, orgID string) (jwt.Token, error)
+
+	// Identify returns the organization the token is scoped to. Tokens which
+	// are not scoped to an organization return an empty tenant.
+	Identify(ctx context.Context, token string) (Tenant, error)
+
+	// IdentifyMember returns the organization the credentials other than
+	// the token (e.g. API keys) of the member are scoped to, checking the
+	// membership same as Identify. An empty organization ID returns an
+	// empty tenant.
+	IdentifyMember(ctx context.Context, memberID, orgID string) (Tenant, error)
+}
+
+// Repository specifies organizations persistence API.
+type Repository interface {
+	// Save persists the organization together with its owner membership.
+	Save(ctx context.Context, org Organization, owner Member) (Organization, error)
+
+	// Retrieve retrieves the organization with the given ID.
+	Retrieve(ctx context.Context, id string) (Organization, error)
+
+	// RetrieveAll retrieves the organizations filtered by the page member.
+	RetrieveAll(ctx context.Context, pm Page) (OrgsPage, error)
+
+	// Update updates the organization name, description, metadata and quotas.
+	Update(ctx context.Context, org Organization) (Organization, error)
+
+	// Remove removes the organization with the given ID.
+	Remove(ctx context.Context, id string) error
+
+	// SaveMember persists the organization membership.
+	SaveMember(ctx context.Context, m Member) error
+
+	// RetrieveMember retrieves the organization membership.
+	RetrieveMember(ctx context.Context, orgID, memberID string) (Member, error)
+
+	// RetrieveMembers retrieves the organization members.
+	RetrieveMembers(ctx context.Context, pm Page) (MembersPage, error)
+
+	// UpdateMember updates the organization member role.
+	UpdateMember(ctx context.Context, m Member) (Member, error)
+
+	// RemoveMember removes the organization membership.
+	RemoveMember(ctx context.Context, orgID, memberID string) error
+
+	// SaveInvitation persists the invitation. Inviting the same identity
+	// again replaces the previous invitation.
+	SaveInvitation(ctx context.Context, inv Invitation) error
+
+	// RetrieveInvitation retrieves the invitation with the given ID.
+	RetrieveInvitation(ctx context.Context, id string) (Invitation, error)
+
+	// RetrieveInvitations retrieves the invitations filtered by the page
+	// organization and identity.
+	RetrieveInvitations(ctx context.Context, pm Page) (InvitationsPage, error)
+
+	// RemoveInvitation removes the invitation with the given ID.
+	RemoveInvitation(ctx context.Context, id string) error
+}
+
+// Validate returns an error if the organization representation is invalid.
+func (org Organization) Validate() error {
+	if org.Name == "" || len(org.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+// ValidateRole returns an error if the member role is invalid.
+func ValidateRole(role string) error {
+	if role != AdminRole && role != MemberRole {
+		return ErrInvalidRole
+	}
+
+	return nil
+}
+
+// Expired reports whether the invitation has expired at the given time.
+func (inv Invitation) Expired(now time.Time) bool {
+	return !now.Before(inv.ExpiresAt)
+}
@@ -0,0 +1,521 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/postgres"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/orgs"
+)
+
+var _ orgs.Repository = (*orepo)(nil)
+
+type orepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of organizations repository.
+func NewRepository(db postgres.Database) orgs.Repository {
+	return &orepo{
+		db: db,
+	}
+}
+
+func (or orepo) Save(ctx context.Context, org orgs.Organization, owner orgs.Member) (orgs.Organization, error) {
+	q := `INSERT INTO orgs (id, owner_id, name, description, metadata, users_quota, things_quota, channels_quota, created_at)
+		VALUES (:id, :owner_id, :name, :description, :metadata, :users_quota, :things_quota, :channels_quota, :created_at)`
+	mq := `INSERT INTO org_members (org_id, member_id, role, created_at)
+		VALUES (:org_id, :member_id, :role, :created_at)`
+
+	dbo, err := toDBOrg(org)
+	if err != nil {
+		return orgs.Organization{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	tx, err := or.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return orgs.Organization{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	if _, err := tx.NamedExecContext(ctx, q, dbo); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return orgs.Organization{}, errors.Wrap(errors.ErrCreateEntity, rerr)
+		}
+		return orgs.Organization{}, postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+	if _, err := tx.NamedExecContext(ctx, mq, toDBMember(owner)); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return orgs.Organization{}, errors.Wrap(errors.ErrCreateEntity, rerr)
+		}
+		return orgs.Organization{}, postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+	if err := tx.Commit(); err != nil {
+		return orgs.Organization{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return org, nil
+}
+
+func (or orepo) Retrieve(ctx context.Context, id string) (orgs.Organization, error) {
+	q := `SELECT id, owner_id, name, description, metadata, users_quota, things_quota, channels_quota,
+		created_at, updated_at, updated_by FROM orgs WHERE id = $1`
+
+	dbo := dbOrg{}
+	if err := or.db.QueryRowxContext(ctx, q, id).StructScan(&dbo); err != nil {
+		if err == sql.ErrNoRows {
+			return orgs.Organization{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return orgs.Organization{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toOrg(dbo)
+}
+
+func (or orepo) RetrieveAll(ctx context.Context, pm orgs.Page) (orgs.OrgsPage, error) {
+	var emq string
+	if pm.MemberID != "" {
+		emq = "WHERE id IN (SELECT org_id FROM org_members WHERE member_id = :member_id)"
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner_id, name, description, metadata, users_quota, things_quota, channels_quota,
+		created_at, updated_at, updated_by FROM orgs %s ORDER BY created_at LIMIT :limit OFFSET :offset;`, emq)
+
+	params := toDBPage(pm)
+	rows, err := or.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return orgs.OrgsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []orgs.Organization
+	for rows.Next() {
+		dbo := dbOrg{}
+		if err := rows.StructScan(&dbo); err != nil {
+			return orgs.OrgsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		org, err := toOrg(dbo)
+		if err != nil {
+			return orgs.OrgsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		items = append(items, org)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM orgs %s;`, emq)
+
+	total, err := postgres.Total(ctx, or.db, cq, params)
+	if err != nil {
+		return orgs.OrgsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	page := orgs.OrgsPage{
+		Orgs: items,
+		Page: orgs.Page{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (or orepo) Update(ctx context.Context, org orgs.Organization) (orgs.Organization, error) {
+	q := `UPDATE orgs SET name = :name, description = :description, metadata = :metadata,
+		users_quota = :users_quota, things_quota = :things_quota, channels_quota = :channels_quota,
+		updated_at = :updated_at, updated_by = :updated_by
+		WHERE id = :id
+		RETURNING id, owner_id, name, description, metadata, users_quota, things_quota, channels_quota,
+		created_at, updated_at, updated_by;`
+
+	dbo, err := toDBOrg(org)
+	if err != nil {
+		return orgs.Organization{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	row, err := or.db.NamedQueryContext(ctx, q, dbo)
+	if err != nil {
+		return orgs.Organization{}, postgres.HandleError(err, errors.ErrUpdateEntity)
+	}
+
+	defer row.Close()
+	if ok := row.Next(); !ok {
+		return orgs.Organization{}, errors.Wrap(errors.ErrNotFound, row.Err())
+	}
+	dbo = dbOrg{}
+	if err := row.StructScan(&dbo); err != nil {
+		return orgs.Organization{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return toOrg(dbo)
+}
+
+func (or orepo) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM orgs WHERE id = $1`
+
+	res, err := or.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (or orepo) SaveMember(ctx context.Context, m orgs.Member) error {
+	q := `INSERT INTO org_members (org_id, member_id, role, created_at)
+		VALUES (:org_id, :member_id, :role, :created_at)`
+
+	if _, err := or.db.NamedExecContext(ctx, q, toDBMember(m)); err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	return nil
+}
+
+func (or orepo) RetrieveMember(ctx context.Context, orgID, memberID string) (orgs.Member, error) {
+	q := `SELECT org_id, member_id, role, created_at FROM org_members WHERE org_id = $1 AND member_id = $2`
+
+	dbm := dbMember{}
+	if err := or.db.QueryRowxContext(ctx, q, orgID, memberID).StructScan(&dbm); err != nil {
+		if err == sql.ErrNoRows {
+			return orgs.Member{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return orgs.Member{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toMember(dbm), nil
+}
+
+func (or orepo) RetrieveMembers(ctx context.Context, pm orgs.Page) (orgs.MembersPage, error) {
+	q := `SELECT org_id, member_id, role, created_at FROM org_members
+		WHERE org_id = :org_id ORDER BY created_at LIMIT :limit OFFSET :offset;`
+
+	params := toDBPage(pm)
+	rows, err := or.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return orgs.MembersPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []orgs.Member
+	for rows.Next() {
+		dbm := dbMember{}
+		if err := rows.StructScan(&dbm); err != nil {
+			return orgs.MembersPage{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		items = append(items, toMember(dbm))
+	}
+
+	cq := `SELECT COUNT(*) FROM org_members WHERE org_id = :org_id;`
+
+	total, err := postgres.Total(ctx, or.db, cq, params)
+	if err != nil {
+		return orgs.MembersPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	page := orgs.MembersPage{
+		Members: items,
+		Page: orgs.Page{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (or orepo) UpdateMember(ctx context.Context, m orgs.Member) (orgs.Member, error) {
+	q := `UPDATE org_members SET role = :role WHERE org_id = :org_id AND member_id = :member_id
+		RETURNING org_id, member_id, role, created_at;`
+
+	row, err := or.db.NamedQueryContext(ctx, q, toDBMember(m))
+	if err != nil {
+		return orgs.Member{}, postgres.HandleError(err, errors.ErrUpdateEntity)
+	}
+
+	defer row.Close()
+	if ok := row.Next(); !ok {
+		return orgs.Member{}, errors.Wrap(errors.ErrNotFound, row.Err())
+	}
+	dbm := dbMember{}
+	if err := row.StructScan(&dbm); err != nil {
+		return orgs.Member{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return toMember(dbm), nil
+}
+
+func (or orepo) RemoveMember(ctx context.Context, orgID, memberID string) error {
+	q := `DELETE FROM org_members WHERE org_id = $1 AND member_id = $2`
+
+	res, err := or.db.ExecContext(ctx, q, orgID, memberID)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (or orepo) SaveInvitation(ctx context.Context, inv orgs.Invitation) error {
+	q := `INSERT INTO org_invitations (id, org_id, identity, role, invited_by, created_at, expires_at)
+		VALUES (:id, :org_id, :identity, :role, :invited_by, :created_at, :expires_at)
+		ON CONFLICT (org_id, identity) DO UPDATE SET id = :id, role = :role, invited_by = :invited_by,
+		created_at = :created_at, expires_at = :expires_at`
+
+	if _, err := or.db.NamedExecContext(ctx, q, toDBInvitation(inv)); err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	return nil
+}
+
+func (or orepo) RetrieveInvitation(ctx context.Context, id string) (orgs.Invitation, error) {
+	q := `SELECT id, org_id, identity, role, invited_by, created_at, expires_at FROM org_invitations WHERE id = $1`
+
+	dbi := dbInvitation{}
+	if err := or.db.QueryRowxContext(ctx, q, id).StructScan(&dbi); err != nil {
+		if err == sql.ErrNoRows {
+			return orgs.Invitation{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return orgs.Invitation{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toInvitation(dbi), nil
+}
+
+func (or orepo) RetrieveInvitations(ctx context.Context, pm orgs.Page) (orgs.InvitationsPage, error) {
+	var query []string
+	var emq string
+
+	if pm.OrgID != "" {
+		query = append(query, "org_id = :org_id")
+	}
+	if pm.Identity != "" {
+		query = append(query, "identity = :identity")
+	}
+	if len(query) > 0 {
+		emq = fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
+	}
+
+	q := fmt.Sprintf(`SELECT id, org_id, identity, role, invited_by, created_at, expires_at
+		FROM org_invitations %s ORDER BY created_at LIMIT :limit OFFSET :offset;`, emq)
+
+	params := toDBPage(pm)
+	rows, err := or.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return orgs.InvitationsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []orgs.Invitation
+	for rows.Next() {
+		dbi := dbInvitation{}
+		if err := rows.StructScan(&dbi); err != nil {
+			return orgs.InvitationsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		items = append(items, toInvitation(dbi))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM org_invitations %s;`, emq)
+
+	total, err := postgres.Total(ctx, or.db, cq, params)
+	if err != nil {
+		return orgs.InvitationsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	page := orgs.InvitationsPage{
+		Invitations: items,
+		Page: orgs.Page{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (or orepo) RemoveInvitation(ctx context.Context, id string) error {
+	q := `DELETE FROM org_invitations WHERE id = $1`
+
+	res, err := or.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbOrg struct {
+	ID            string         `db:"id"`
+	OwnerID       string         `db:"owner_id"`
+	Name          string         `db:"name"`
+	Description   sql.NullString `db:"description"`
+	Metadata      []byte         `db:"metadata"`
+	UsersQuota    uint64         `db:"users_quota"`
+	ThingsQuota   uint64         `db:"things_quota"`
+	ChannelsQuota uint64         `db:"channels_quota"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     sql.NullTime   `db:"updated_at,omitempty"`
+	UpdatedBy     *string        `db:"updated_by,omitempty"`
+}
+
+type dbMember struct {
+	OrgID     string    `db:"org_id"`
+	MemberID  string    `db:"member_id"`
+	Role      string    `db:"role"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type dbInvitation struct {
+	ID        string    `db:"id"`
+	OrgID     string    `db:"org_id"`
+	Identity  string    `db:"identity"`
+	Role      string    `db:"role"`
+	InvitedBy string    `db:"invited_by"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+type dbPage struct {
+	MemberID string `db:"member_id"`
+	OrgID    string `db:"org_id"`
+	Identity string `db:"identity"`
+	Offset   uint64 `db:"offset"`
+	Limit    uint64 `db:"limit"`
+}
+
+func toDBOrg(org orgs.Organization) (dbOrg, error) {
+	data := []byte("{}")
+	if len(org.Metadata) > 0 {
+		b, err := json.Marshal(org.Metadata)
+		if err != nil {
+			return dbOrg{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		data = b
+	}
+	var updatedAt sql.NullTime
+	if !org.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: org.UpdatedAt, Valid: true}
+	}
+	var updatedBy *string
+	if org.UpdatedBy != "" {
+		updatedBy = &org.UpdatedBy
+	}
+
+	return dbOrg{
+		ID:            org.ID,
+		OwnerID:       org.OwnerID,
+		Name:          org.Name,
+		Description:   sql.NullString{String: org.Description, Valid: org.Description != ""},
+		Metadata:      data,
+		UsersQuota:    org.Quotas.Users,
+		ThingsQuota:   org.Quotas.Things,
+		ChannelsQuota: org.Quotas.Channels,
+		CreatedAt:     org.CreatedAt,
+		UpdatedAt:     updatedAt,
+		UpdatedBy:     updatedBy,
+	}, nil
+}
+
+func toOrg(dbo dbOrg) (orgs.Organization, error) {
+	var metadata mfclients.Metadata
+	if dbo.Metadata != nil {
+		if err := json.Unmarshal(dbo.Metadata, &metadata); err != nil {
+			return orgs.Organization{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+	var updatedAt time.Time
+	if dbo.UpdatedAt.Valid {
+		updatedAt = dbo.UpdatedAt.Time
+	}
+	var updatedBy string
+	if dbo.UpdatedBy != nil {
+		updatedBy = *dbo.UpdatedBy
+	}
+
+	return orgs.Organization{
+		ID:          dbo.ID,
+		OwnerID:     dbo.OwnerID,
+		Name:        dbo.Name,
+		Description: dbo.Description.String,
+		Metadata:    metadata,
+		Quotas: orgs.Quotas{
+			Users:    dbo.UsersQuota,
+			Things:   dbo.ThingsQuota,
+			Channels: dbo.ChannelsQuota,
+		},
+		CreatedAt: dbo.CreatedAt,
+		UpdatedAt: updatedAt,
+		UpdatedBy: updatedBy,
+	}, nil
+}
+
+func toDBMember(m orgs.Member) dbMember {
+	return dbMember{
+		OrgID:     m.OrgID,
+		MemberID:  m.MemberID,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func toMember(dbm dbMember) orgs.Member {
+	return orgs.Member{
+		OrgID:     dbm.OrgID,
+		MemberID:  dbm.MemberID,
+		Role:      dbm.Role,
+		CreatedAt: dbm.CreatedAt,
+	}
+}
+
+func toDBInvitation(inv orgs.Invitation) dbInvitation {
+	return dbInvitation{
+		ID:        inv.ID,
+		OrgID:     inv.OrgID,
+		Identity:  inv.Identity,
+		Role:      inv.Role,
+		InvitedBy: inv.InvitedBy,
+		CreatedAt: inv.CreatedAt,
+		ExpiresAt: inv.ExpiresAt,
+	}
+}
+
+func toInvitation(dbi dbInvitation) orgs.Invitation {
+	return orgs.Invitation{
+		ID:        dbi.ID,
+		OrgID:     dbi.OrgID,
+		Identity:  dbi.Identity,
+		Role:      dbi.Role,
+		InvitedBy: dbi.InvitedBy,
+		CreatedAt: dbi.CreatedAt,
+		ExpiresAt: dbi.ExpiresAt,
+	}
+}
+
+func toDBPage(pm orgs.Page) dbPage {
+	return dbPage{
+		MemberID: pm.MemberID,
+		OrgID:    pm.OrgID,
+		Identity: pm.Identity,
+		Offset:   pm.Offset,
+		Limit:    pm.Limit,
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	cpostgres "github.com/mainflux/mainflux/users/clients/postgres"
+	"github.com/mainflux/mainflux/users/orgs"
+	opostgres "github.com/mainflux/mainflux/users/orgs/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idProvider = uuid.New()
+
+func saveClient(t *testing.T, name string) mfclients.Client {
+	crepo := cpostgres.NewRepository(database)
+	client := mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: name,
+		Credentials: mfclients.Credentials{
+			Identity: name,
+			Secret:   "pass",
+		},
+		Status: mfclients.EnabledStatus,
+	}
+	client, err := crepo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return client
+}
+
+func saveOrg(t *testing.T, repo orgs.Repository, ownerID, name string) orgs.Organization {
+	org := orgs.Organization{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OwnerID:   ownerID,
+		Name:      name,
+		Metadata:  mfclients.Metadata{"plan": "basic"},
+		Quotas:    orgs.Quotas{Users: 5, Things: 10, Channels: 5},
+		CreatedAt: time.Now(),
+	}
+	org, err := repo.Save(context.Background(), org, orgs.Member{OrgID: org.ID, MemberID: ownerID, Role: orgs.AdminRole, CreatedAt: org.CreatedAt})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return org
+}
+
+func TestOrgSave(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := opostgres.NewRepository(database)
+	client := saveClient(t, "org-save@example.com")
+	org := orgs.Organization{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OwnerID:   client.ID,
+		Name:      "acme",
+		Quotas:    orgs.Quotas{Things: 10},
+		CreatedAt: time.Now(),
+	}
+
+	cases := []struct {
+		desc string
+		org  orgs.Organization
+		err  error
+	}{
+		{
+			desc: "save new org",
+			org:  org,
+			err:  nil,
+		},
+		{
+			desc: "save org with duplicate name",
+			org: orgs.Organization{
+				ID:      testsutil.GenerateUUID(t, idProvider),
+				OwnerID: client.ID,
+				Name:    org.Name,
+			},
+			err: errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		owner := orgs.Member{OrgID: tc.org.ID, MemberID: client.ID, Role: orgs.AdminRole, CreatedAt: time.Now()}
+		_, err := repo.Save(context.Background(), tc.org, owner)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			m, err := repo.RetrieveMember(context.Background(), tc.org.ID, client.ID)
+			assert.Nil(t, err, fmt.Sprintf("%s: retrieve owner membership unexpected error: %s", tc.desc, err))
+			assert.Equal(t, orgs.AdminRole, m.Role, fmt.Sprintf("%s: expected role %s got %s\n", tc.desc, orgs.AdminRole, m.Role))
+		}
+	}
+}
+
+func TestOrgRetrieveAll(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := opostgres.NewRepository(database)
+	owner := saveClient(t, "org-owner@example.com")
+	member := saveClient(t, "org-member@example.com")
+	org := saveOrg(t, repo, owner.ID, "acme")
+	saveOrg(t, repo, owner.ID, "globex")
+	err := repo.SaveMember(context.Background(), orgs.Member{OrgID: org.ID, MemberID: member.ID, Role: orgs.MemberRole, CreatedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("save member unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		page  orgs.Page
+		total uint64
+	}{
+		{
+			desc:  "retrieve all orgs",
+			page:  orgs.Page{Limit: 10},
+			total: 2,
+		},
+		{
+			desc:  "retrieve orgs of the owner",
+			page:  orgs.Page{Limit: 10, MemberID: owner.ID},
+			total: 2,
+		},
+		{
+			desc:  "retrieve orgs of the member",
+			page:  orgs.Page{Limit: 10, MemberID: member.ID},
+			total: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.Len(t, page.Orgs, int(tc.total), fmt.Sprintf("%s: expected %d orgs got %d\n", tc.desc, tc.total, len(page.Orgs)))
+	}
+}
+
+func TestOrgRemove(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := opostgres.NewRepository(database)
+	owner := saveClient(t, "org-remove@example.com")
+	org := saveOrg(t, repo, owner.ID, "acme")
+	inv := orgs.Invitation{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OrgID:     org.ID,
+		Identity:  "invited@example.com",
+		Role:      orgs.MemberRole,
+		InvitedBy: owner.ID,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	err := repo.SaveInvitation(context.Background(), inv)
+	require.Nil(t, err, fmt.Sprintf("save invitation unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), org.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove org unexpected error: %s", err))
+
+	_, err = repo.Retrieve(context.Background(), org.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed org: expected %s got %s\n", errors.ErrNotFound, err))
+	_, err = repo.RetrieveMember(context.Background(), org.ID, owner.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed org member: expected %s got %s\n", errors.ErrNotFound, err))
+	_, err = repo.RetrieveInvitation(context.Background(), inv.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed org invitation: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+func TestInvitationSave(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := opostgres.NewRepository(database)
+	owner := saveClient(t, "org-invite@example.com")
+	org := saveOrg(t, repo, owner.ID, "acme")
+	inv := orgs.Invitation{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OrgID:     org.ID,
+		Identity:  "invited@example.com",
+		Role:      orgs.MemberRole,
+		InvitedBy: owner.ID,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	err := repo.SaveInvitation(context.Background(), inv)
+	require.Nil(t, err, fmt.Sprintf("save invitation unexpected error: %s", err))
+
+	// Inviting the same identity again replaces the previous invitation.
+	reinv := inv
+	reinv.ID = testsutil.GenerateUUID(t, idProvider)
+	reinv.Role = orgs.AdminRole
+	err = repo.SaveInvitation(context.Background(), reinv)
+	require.Nil(t, err, fmt.Sprintf("save invitation unexpected error: %s", err))
+
+	page, err := repo.RetrieveInvitations(context.Background(), orgs.Page{Limit: 10, Identity: inv.Identity})
+	require.Nil(t, err, fmt.Sprintf("retrieve invitations unexpected error: %s", err))
+	require.Len(t, page.Invitations, 1, fmt.Sprintf("expected 1 invitation got %d", len(page.Invitations)))
+	assert.Equal(t, reinv.ID, page.Invitations[0].ID, fmt.Sprintf("expected invitation %s got %s\n", reinv.ID, page.Invitations[0].ID))
+	assert.Equal(t, orgs.AdminRole, page.Invitations[0].Role, fmt.Sprintf("expected role %s got %s\n", orgs.AdminRole, page.Invitations[0].Role))
+}
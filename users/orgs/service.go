@@ -0,0 +1,391 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package orgs
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/policies"
+)
+
+type service struct {
+	orgs       Repository
+	policies   policies.Repository
+	tokens     jwt.Repository
+	idProvider mainflux.IDProvider
+	config     Config
+}
+
+// NewService returns a new organizations service implementation.
+func NewService(o Repository, p policies.Repository, t jwt.Repository, idp mainflux.IDProvider, c Config) Service {
+	return service{
+		orgs:       o,
+		policies:   p,
+		tokens:     t,
+		idProvider: idp,
+		config:     c,
+	}
+}
+
+func (svc service) CreateOrg(ctx context.Context, token string, org Organization) (Organization, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return Organization{}, err
+	}
+	if err := org.Validate(); err != nil {
+		return Organization{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if err := svc.policies.CheckAdmin(ctx, claims.ClientID); err != nil {
+		org.Quotas = Quotas{
+			Users:    svc.config.UsersQuota,
+			Things:   svc.config.ThingsQuota,
+			Channels: svc.config.ChannelsQuota,
+		}
+	}
+	if org.ID, err = svc.idProvider.ID(); err != nil {
+		return Organization{}, err
+	}
+	org.OwnerID = claims.ClientID
+	org.CreatedAt = time.Now()
+	owner := Member{
+		OrgID:     org.ID,
+		MemberID:  claims.ClientID,
+		Role:      AdminRole,
+		CreatedAt: org.CreatedAt,
+	}
+
+	return svc.orgs.Save(ctx, org, owner)
+}
+
+func (svc service) ViewOrg(ctx context.Context, token, id string) (Organization, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return Organization{}, err
+	}
+	if err := svc.checkMember(ctx, claims.ClientID, id); err != nil {
+		return Organization{}, err
+	}
+
+	return svc.orgs.Retrieve(ctx, id)
+}
+
+func (svc service) ListOrgs(ctx context.Context, token string, pm Page) (OrgsPage, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return OrgsPage{}, err
+	}
+	pm.MemberID = ""
+	if err := svc.policies.CheckAdmin(ctx, claims.ClientID); err != nil {
+		pm.MemberID = claims.ClientID
+	}
+
+	return svc.orgs.RetrieveAll(ctx, pm)
+}
+
+func (svc service) UpdateOrg(ctx context.Context, token string, org Organization) (Organization, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return Organization{}, err
+	}
+	if err := org.Validate(); err != nil {
+		return Organization{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if err := svc.checkOrgAdmin(ctx, claims.ClientID, org.ID); err != nil {
+		return Organization{}, err
+	}
+	// Organization admins can't raise their own quotas.
+	if err := svc.policies.CheckAdmin(ctx, claims.ClientID); err != nil {
+		current, err := svc.orgs.Retrieve(ctx, org.ID)
+		if err != nil {
+			return Organization{}, err
+		}
+		org.Quotas = current.Quotas
+	}
+	org.UpdatedAt = time.Now()
+	org.UpdatedBy = claims.ClientID
+
+	return svc.orgs.Update(ctx, org)
+}
+
+func (svc service) RemoveOrg(ctx context.Context, token, id string) error {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.policies.CheckAdmin(ctx, claims.ClientID); err != nil {
+		org, err := svc.orgs.Retrieve(ctx, id)
+		if err != nil {
+			return err
+		}
+		if org.OwnerID != claims.ClientID {
+			return errors.ErrAuthorization
+		}
+	}
+
+	return svc.orgs.Remove(ctx, id)
+}
+
+func (svc service) ListMembers(ctx context.Context, token, orgID string, pm Page) (MembersPage, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return MembersPage{}, err
+	}
+	if err := svc.checkMember(ctx, claims.ClientID, orgID); err != nil {
+		return MembersPage{}, err
+	}
+	pm.OrgID = orgID
+
+	return svc.orgs.RetrieveMembers(ctx, pm)
+}
+
+func (svc service) UpdateMember(ctx context.Context, token string, m Member) (Member, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return Member{}, err
+	}
+	if err := ValidateRole(m.Role); err != nil {
+		return Member{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if err := svc.checkOrgAdmin(ctx, claims.ClientID, m.OrgID); err != nil {
+		return Member{}, err
+	}
+	if err := svc.checkOwner(ctx, m.OrgID, m.MemberID); err != nil {
+		return Member{}, err
+	}
+
+	return svc.orgs.UpdateMember(ctx, m)
+}
+
+func (svc service) RemoveMember(ctx context.Context, token, orgID, memberID string) error {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if claims.ClientID != memberID {
+		if err := svc.checkOrgAdmin(ctx, claims.ClientID, orgID); err != nil {
+			return err
+		}
+	}
+	if err := svc.checkOwner(ctx, orgID, memberID); err != nil {
+		return err
+	}
+
+	return svc.orgs.RemoveMember(ctx, orgID, memberID)
+}
+
+func (svc service) Invite(ctx context.Context, token string, inv Invitation) (Invitation, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return Invitation{}, err
+	}
+	if inv.Identity == "" {
+		return Invitation{}, errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrMissingIdentity)
+	}
+	if err := ValidateRole(inv.Role); err != nil {
+		return Invitation{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if err := svc.checkOrgAdmin(ctx, claims.ClientID, inv.OrgID); err != nil {
+		return Invitation{}, err
+	}
+	if err := svc.checkUsersQuota(ctx, inv.OrgID); err != nil {
+		return Invitation{}, err
+	}
+	if inv.ID, err = svc.idProvider.ID(); err != nil {
+		return Invitation{}, err
+	}
+	inv.InvitedBy = claims.ClientID
+	inv.CreatedAt = time.Now()
+	inv.ExpiresAt = inv.CreatedAt.Add(svc.config.InvitationDuration)
+	if err := svc.orgs.SaveInvitation(ctx, inv); err != nil {
+		return Invitation{}, err
+	}
+
+	return inv, nil
+}
+
+func (svc service) ListInvitations(ctx context.Context, token, orgID string, pm Page) (InvitationsPage, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return InvitationsPage{}, err
+	}
+	pm.OrgID, pm.Identity = orgID, ""
+	switch orgID {
+	case "":
+		pm.Identity = claims.Email
+	default:
+		if err := svc.checkOrgAdmin(ctx, claims.ClientID, orgID); err != nil {
+			return InvitationsPage{}, err
+		}
+	}
+
+	return svc.orgs.RetrieveInvitations(ctx, pm)
+}
+
+func (svc service) AcceptInvitation(ctx context.Context, token, id string) (Member, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return Member{}, err
+	}
+	inv, err := svc.orgs.RetrieveInvitation(ctx, id)
+	if err != nil {
+		return Member{}, err
+	}
+	if inv.Identity != claims.Email {
+		return Member{}, errors.ErrNotFound
+	}
+	if inv.Expired(time.Now()) {
+		return Member{}, errors.Wrap(errors.ErrAuthorization, ErrInvitationExpired)
+	}
+	if err := svc.checkUsersQuota(ctx, inv.OrgID); err != nil {
+		return Member{}, err
+	}
+	m := Member{
+		OrgID:     inv.OrgID,
+		MemberID:  claims.ClientID,
+		Role:      inv.Role,
+		CreatedAt: time.Now(),
+	}
+	if err := svc.orgs.SaveMember(ctx, m); err != nil {
+		return Member{}, err
+	}
+	if err := svc.orgs.RemoveInvitation(ctx, id); err != nil {
+		return Member{}, err
+	}
+
+	return m, nil
+}
+
+func (svc service) RemoveInvitation(ctx context.Context, token, id string) error {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	inv, err := svc.orgs.RetrieveInvitation(ctx, id)
+	if err != nil {
+		return err
+	}
+	if inv.Identity != claims.Email {
+		if err := svc.checkOrgAdmin(ctx, claims.ClientID, inv.OrgID); err != nil {
+			return err
+		}
+	}
+
+	return svc.orgs.RemoveInvitation(ctx, id)
+}
+
+func (svc service) IssueToken(ctx context.Context, token, orgID string) (jwt.Token, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return jwt.Token{}, err
+	}
+	if err := svc.checkMember(ctx, claims.ClientID, orgID); err != nil {
+		return jwt.Token{}, err
+	}
+
+	return svc.tokens.Issue(ctx, jwt.Claims{ClientID: claims.ClientID, Email: claims.Email, OrgID: orgID})
+}
+
+func (svc service) Identify(ctx context.Context, token string) (Tenant, error) {
+	claims, err := svc.identify(ctx, token)
+	if err != nil {
+		return Tenant{}, err
+	}
+
+	return svc.IdentifyMember(ctx, claims.ClientID, claims.OrgID)
+}
+
+func (svc service) IdentifyMember(ctx context.Context, memberID, orgID string) (Tenant, error) {
+	if orgID == "" {
+		return Tenant{}, nil
+	}
+	// The membership is checked on every request, so the tokens of the
+	// removed members stop working right away.
+	if err := svc.checkMember(ctx, memberID, orgID); err != nil {
+		return Tenant{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	org, err := svc.orgs.Retrieve(ctx, orgID)
+	if err != nil {
+		return Tenant{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return Tenant{OrgID: org.ID, Quotas: org.Quotas}, nil
+}
+
+func (svc service) identify(ctx context.Context, token string) (jwt.Claims, error) {
+	claims, err := svc.tokens.Parse(ctx, token)
+	if err != nil {
+		return jwt.Claims{}, err
+	}
+	if claims.Type != jwt.AccessToken {
+		return jwt.Claims{}, errors.ErrAuthentication
+	}
+
+	return claims, nil
+}
+
+// checkMember checks if the user is the organization member or the platform admin.
+func (svc service) checkMember(ctx context.Context, userID, orgID string) error {
+	if err := svc.policies.CheckAdmin(ctx, userID); err == nil {
+		return nil
+	}
+	if _, err := svc.orgs.RetrieveMember(ctx, orgID, userID); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// checkOrgAdmin checks if the user is the organization admin or the platform admin.
+func (svc service) checkOrgAdmin(ctx context.Context, userID, orgID string) error {
+	if err := svc.policies.CheckAdmin(ctx, userID); err == nil {
+		return nil
+	}
+	m, err := svc.orgs.RetrieveMember(ctx, orgID, userID)
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+	if m.Role != AdminRole {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
+// checkOwner returns an error if the member is the organization owner.
+func (svc service) checkOwner(ctx context.Context, orgID, memberID string) error {
+	org, err := svc.orgs.Retrieve(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org.OwnerID == memberID {
+		return errors.Wrap(errors.ErrAuthorization, ErrOwnerMember)
+	}
+
+	return nil
+}
+
+// checkUsersQuota returns an error if the organization has no room for another member.
+func (svc service) checkUsersQuota(ctx context.Context, orgID string) error {
+	org, err := svc.orgs.Retrieve(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org.Quotas.Users == 0 {
+		return nil
+	}
+	mp, err := svc.orgs.RetrieveMembers(ctx, Page{OrgID: orgID, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if mp.Total >= org.Quotas.Users {
+		return errors.Wrap(errors.ErrAuthorization, errors.ErrQuotaExceeded)
+	}
+
+	return nil
+}
@@ -0,0 +1,474 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package orgs_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/apiutil"
+	"github.com/mainflux/mainflux/internal/testsutil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/orgs"
+	"github.com/mainflux/mainflux/users/orgs/mocks"
+	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	idProvider      = uuid.New()
+	secret          = "strongsecret"
+	inValidToken    = "invalidToken"
+	email           = "user@example.com"
+	accessDuration  = time.Minute * 1
+	refreshDuration = time.Minute * 10
+	config          = orgs.Config{InvitationDuration: time.Hour, UsersQuota: 2, ThingsQuota: 10, ChannelsQuota: 5}
+)
+
+func newService() (orgs.Service, *mocks.Repository, *pmocks.Repository, jwt.Repository) {
+	oRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+
+	return orgs.NewService(oRepo, pRepo, tokenizer, idProvider, config), oRepo, pRepo, tokenizer
+}
+
+func issueToken(t *testing.T, tokenizer jwt.Repository, id, orgID string) jwt.Token {
+	token, err := tokenizer.Issue(context.Background(), jwt.Claims{ClientID: id, Email: email, OrgID: orgID})
+	require.Nil(t, err, fmt.Sprintf("issue token unexpected error: %s", err))
+
+	return token
+}
+
+func TestCreateOrg(t *testing.T) {
+	svc, oRepo, pRepo, tokenizer := newService()
+	userID := testsutil.GenerateUUID(t, idProvider)
+	adminID := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, userID, "")
+	adminToken := issueToken(t, tokenizer, adminID, "")
+	quotas := orgs.Quotas{Users: 100}
+
+	cases := []struct {
+		desc   string
+		org    orgs.Organization
+		token  string
+		quotas orgs.Quotas
+		err    error
+	}{
+		{
+			desc:   "create org with the default quotas",
+			org:    orgs.Organization{Name: "acme", Quotas: quotas},
+			token:  token.AccessToken,
+			quotas: orgs.Quotas{Users: config.UsersQuota, Things: config.ThingsQuota, Channels: config.ChannelsQuota},
+			err:    nil,
+		},
+		{
+			desc:   "create org as platform admin",
+			org:    orgs.Organization{Name: "acme", Quotas: quotas},
+			token:  adminToken.AccessToken,
+			quotas: quotas,
+			err:    nil,
+		},
+		{
+			desc:  "create org without name",
+			org:   orgs.Organization{},
+			token: token.AccessToken,
+			err:   apiutil.ErrNameSize,
+		},
+		{
+			desc:  "create org with invalid token",
+			org:   orgs.Organization{Name: "acme"},
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "create org with refresh token",
+			org:   orgs.Organization{Name: "acme"},
+			token: token.RefreshToken,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), userID).Return(errors.ErrAuthorization)
+		repoCall1 := pRepo.On("CheckAdmin", context.Background(), adminID).Return(nil)
+		repoCall2 := oRepo.On("Save", context.Background(), mock.Anything, mock.Anything).Return(orgs.Organization{}, nil)
+		_, err := svc.CreateOrg(context.Background(), tc.token, tc.org)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			org := repoCall2.Parent.Calls[len(repoCall2.Parent.Calls)-1].Arguments.Get(1).(orgs.Organization)
+			owner := repoCall2.Parent.Calls[len(repoCall2.Parent.Calls)-1].Arguments.Get(2).(orgs.Member)
+			assert.Equal(t, tc.quotas, org.Quotas, fmt.Sprintf("%s: expected quotas %v got %v\n", tc.desc, tc.quotas, org.Quotas))
+			assert.Equal(t, org.OwnerID, owner.MemberID, fmt.Sprintf("%s: expected owner membership for %s got %s\n", tc.desc, org.OwnerID, owner.MemberID))
+			assert.Equal(t, orgs.AdminRole, owner.Role, fmt.Sprintf("%s: expected owner role %s got %s\n", tc.desc, orgs.AdminRole, owner.Role))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestUpdateOrg(t *testing.T) {
+	svc, oRepo, pRepo, tokenizer := newService()
+	userID := testsutil.GenerateUUID(t, idProvider)
+	memberID := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, userID, "")
+	memberToken := issueToken(t, tokenizer, memberID, "")
+	current := orgs.Organization{ID: testsutil.GenerateUUID(t, idProvider), OwnerID: userID, Name: "acme", Quotas: orgs.Quotas{Things: 10}}
+
+	cases := []struct {
+		desc  string
+		org   orgs.Organization
+		token string
+		err   error
+	}{
+		{
+			desc:  "update org as org admin",
+			org:   orgs.Organization{ID: current.ID, Name: "updated", Quotas: orgs.Quotas{Things: 1000}},
+			token: token.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "update org as org member",
+			org:   orgs.Organization{ID: current.ID, Name: "updated"},
+			token: memberToken.AccessToken,
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(errors.ErrAuthorization)
+		repoCall1 := oRepo.On("RetrieveMember", context.Background(), current.ID, userID).Return(orgs.Member{Role: orgs.AdminRole}, nil)
+		repoCall2 := oRepo.On("RetrieveMember", context.Background(), current.ID, memberID).Return(orgs.Member{Role: orgs.MemberRole}, nil)
+		repoCall3 := oRepo.On("Retrieve", context.Background(), current.ID).Return(current, nil)
+		repoCall4 := oRepo.On("Update", context.Background(), mock.Anything).Return(orgs.Organization{}, nil)
+		_, err := svc.UpdateOrg(context.Background(), tc.token, tc.org)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			org := repoCall4.Parent.Calls[len(repoCall4.Parent.Calls)-1].Arguments.Get(1).(orgs.Organization)
+			assert.Equal(t, current.Quotas, org.Quotas, fmt.Sprintf("%s: expected quotas %v got %v\n", tc.desc, current.Quotas, org.Quotas))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	svc, oRepo, pRepo, tokenizer := newService()
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	memberID := testsutil.GenerateUUID(t, idProvider)
+	ownerToken := issueToken(t, tokenizer, ownerID, "")
+	memberToken := issueToken(t, tokenizer, memberID, "")
+	org := orgs.Organization{ID: testsutil.GenerateUUID(t, idProvider), OwnerID: ownerID}
+
+	cases := []struct {
+		desc     string
+		token    string
+		memberID string
+		err      error
+	}{
+		{
+			desc:     "remove member as org admin",
+			token:    ownerToken.AccessToken,
+			memberID: memberID,
+			err:      nil,
+		},
+		{
+			desc:     "leave org as member",
+			token:    memberToken.AccessToken,
+			memberID: memberID,
+			err:      nil,
+		},
+		{
+			desc:     "remove owner as member",
+			token:    memberToken.AccessToken,
+			memberID: ownerID,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "remove owner as owner",
+			token:    ownerToken.AccessToken,
+			memberID: ownerID,
+			err:      orgs.ErrOwnerMember,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(errors.ErrAuthorization)
+		repoCall1 := oRepo.On("RetrieveMember", context.Background(), org.ID, ownerID).Return(orgs.Member{Role: orgs.AdminRole}, nil)
+		repoCall2 := oRepo.On("RetrieveMember", context.Background(), org.ID, memberID).Return(orgs.Member{Role: orgs.MemberRole}, nil)
+		repoCall3 := oRepo.On("Retrieve", context.Background(), org.ID).Return(org, nil)
+		repoCall4 := oRepo.On("RemoveMember", context.Background(), org.ID, tc.memberID).Return(nil)
+		err := svc.RemoveMember(context.Background(), tc.token, org.ID, tc.memberID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
+func TestInvite(t *testing.T) {
+	svc, oRepo, pRepo, tokenizer := newService()
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, ownerID, "")
+	org := orgs.Organization{ID: testsutil.GenerateUUID(t, idProvider), OwnerID: ownerID, Quotas: orgs.Quotas{Users: 2}}
+
+	cases := []struct {
+		desc    string
+		inv     orgs.Invitation
+		members uint64
+		err     error
+	}{
+		{
+			desc:    "invite user",
+			inv:     orgs.Invitation{OrgID: org.ID, Identity: "invited@example.com", Role: orgs.MemberRole},
+			members: 1,
+			err:     nil,
+		},
+		{
+			desc:    "invite user without identity",
+			inv:     orgs.Invitation{OrgID: org.ID, Role: orgs.MemberRole},
+			members: 1,
+			err:     apiutil.ErrMissingIdentity,
+		},
+		{
+			desc:    "invite user with invalid role",
+			inv:     orgs.Invitation{OrgID: org.ID, Identity: "invited@example.com", Role: "owner"},
+			members: 1,
+			err:     orgs.ErrInvalidRole,
+		},
+		{
+			desc:    "invite user exceeding the users quota",
+			inv:     orgs.Invitation{OrgID: org.ID, Identity: "invited@example.com", Role: orgs.MemberRole},
+			members: 2,
+			err:     errors.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(errors.ErrAuthorization)
+		repoCall1 := oRepo.On("RetrieveMember", context.Background(), org.ID, ownerID).Return(orgs.Member{Role: orgs.AdminRole}, nil)
+		repoCall2 := oRepo.On("Retrieve", context.Background(), org.ID).Return(org, nil)
+		repoCall3 := oRepo.On("RetrieveMembers", context.Background(), mock.Anything).Return(orgs.MembersPage{Page: orgs.Page{Total: tc.members}}, nil)
+		repoCall4 := oRepo.On("SaveInvitation", context.Background(), mock.Anything).Return(nil)
+		inv, err := svc.Invite(context.Background(), token.AccessToken, tc.inv)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, ownerID, inv.InvitedBy, fmt.Sprintf("%s: expected inviter %s got %s\n", tc.desc, ownerID, inv.InvitedBy))
+			assert.WithinDuration(t, inv.CreatedAt.Add(config.InvitationDuration), inv.ExpiresAt, time.Second, fmt.Sprintf("%s: unexpected expiry %v\n", tc.desc, inv.ExpiresAt))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	svc, oRepo, _, tokenizer := newService()
+	userID := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, userID, "")
+	org := orgs.Organization{ID: testsutil.GenerateUUID(t, idProvider)}
+	valid := orgs.Invitation{ID: "valid", OrgID: org.ID, Identity: email, Role: orgs.MemberRole, ExpiresAt: time.Now().Add(time.Hour)}
+	expired := orgs.Invitation{ID: "expired", OrgID: org.ID, Identity: email, Role: orgs.MemberRole, ExpiresAt: time.Now().Add(-time.Hour)}
+	other := orgs.Invitation{ID: "other", OrgID: org.ID, Identity: "other@example.com", Role: orgs.MemberRole, ExpiresAt: time.Now().Add(time.Hour)}
+
+	cases := []struct {
+		desc string
+		inv  orgs.Invitation
+		err  error
+	}{
+		{
+			desc: "accept invitation",
+			inv:  valid,
+			err:  nil,
+		},
+		{
+			desc: "accept expired invitation",
+			inv:  expired,
+			err:  orgs.ErrInvitationExpired,
+		},
+		{
+			desc: "accept invitation of another user",
+			inv:  other,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := oRepo.On("RetrieveInvitation", context.Background(), tc.inv.ID).Return(tc.inv, nil)
+		repoCall1 := oRepo.On("Retrieve", context.Background(), org.ID).Return(org, nil)
+		repoCall2 := oRepo.On("SaveMember", context.Background(), mock.Anything).Return(nil)
+		repoCall3 := oRepo.On("RemoveInvitation", context.Background(), tc.inv.ID).Return(nil)
+		m, err := svc.AcceptInvitation(context.Background(), token.AccessToken, tc.inv.ID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, orgs.Member{OrgID: org.ID, MemberID: userID, Role: tc.inv.Role, CreatedAt: m.CreatedAt}, m, fmt.Sprintf("%s: unexpected member %v\n", tc.desc, m))
+			ok := repoCall3.Parent.AssertCalled(t, "RemoveInvitation", context.Background(), tc.inv.ID)
+			assert.True(t, ok, fmt.Sprintf("RemoveInvitation was not called on %s", tc.desc))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	svc, oRepo, pRepo, tokenizer := newService()
+	userID := testsutil.GenerateUUID(t, idProvider)
+	token := issueToken(t, tokenizer, userID, "")
+	orgID := testsutil.GenerateUUID(t, idProvider)
+	otherID := testsutil.GenerateUUID(t, idProvider)
+
+	cases := []struct {
+		desc  string
+		orgID string
+		err   error
+	}{
+		{
+			desc:  "issue token as org member",
+			orgID: orgID,
+			err:   nil,
+		},
+		{
+			desc:  "issue token as non member",
+			orgID: otherID,
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), userID).Return(errors.ErrAuthorization)
+		repoCall1 := oRepo.On("RetrieveMember", context.Background(), orgID, userID).Return(orgs.Member{Role: orgs.MemberRole}, nil)
+		repoCall2 := oRepo.On("RetrieveMember", context.Background(), otherID, userID).Return(orgs.Member{}, errors.ErrNotFound)
+		tkn, err := svc.IssueToken(context.Background(), token.AccessToken, tc.orgID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			claims, err := tokenizer.Parse(context.Background(), tkn.AccessToken)
+			require.Nil(t, err, fmt.Sprintf("%s: parse token unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.orgID, claims.OrgID, fmt.Sprintf("%s: expected org %s got %s\n", tc.desc, tc.orgID, claims.OrgID))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	svc, oRepo, pRepo, tokenizer := newService()
+	userID := testsutil.GenerateUUID(t, idProvider)
+	org := orgs.Organization{ID: testsutil.GenerateUUID(t, idProvider), Quotas: orgs.Quotas{Things: 10, Channels: 5}}
+	removedID := testsutil.GenerateUUID(t, idProvider)
+	userToken := issueToken(t, tokenizer, userID, "")
+	orgToken := issueToken(t, tokenizer, userID, org.ID)
+	removedToken := issueToken(t, tokenizer, removedID, org.ID)
+
+	cases := []struct {
+		desc   string
+		token  string
+		tenant orgs.Tenant
+		err    error
+	}{
+		{
+			desc:   "identify user token",
+			token:  userToken.AccessToken,
+			tenant: orgs.Tenant{},
+			err:    nil,
+		},
+		{
+			desc:   "identify org token",
+			token:  orgToken.AccessToken,
+			tenant: orgs.Tenant{OrgID: org.ID, Quotas: org.Quotas},
+			err:    nil,
+		},
+		{
+			desc:  "identify org token of removed member",
+			token: removedToken.AccessToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "identify invalid token",
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(errors.ErrAuthorization)
+		repoCall1 := oRepo.On("RetrieveMember", context.Background(), org.ID, userID).Return(orgs.Member{Role: orgs.MemberRole}, nil)
+		repoCall2 := oRepo.On("RetrieveMember", context.Background(), org.ID, removedID).Return(orgs.Member{}, errors.ErrNotFound)
+		repoCall3 := oRepo.On("Retrieve", context.Background(), org.ID).Return(org, nil)
+		tenant, err := svc.Identify(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.tenant, tenant, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.tenant, tenant))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
+
+func TestIdentifyMember(t *testing.T) {
+	svc, oRepo, pRepo, _ := newService()
+	userID := testsutil.GenerateUUID(t, idProvider)
+	removedID := testsutil.GenerateUUID(t, idProvider)
+	org := orgs.Organization{ID: testsutil.GenerateUUID(t, idProvider), Quotas: orgs.Quotas{Things: 10, Channels: 5}}
+
+	cases := []struct {
+		desc     string
+		memberID string
+		orgID    string
+		tenant   orgs.Tenant
+		err      error
+	}{
+		{
+			desc:     "identify member without org",
+			memberID: userID,
+			orgID:    "",
+			tenant:   orgs.Tenant{},
+			err:      nil,
+		},
+		{
+			desc:     "identify org member",
+			memberID: userID,
+			orgID:    org.ID,
+			tenant:   orgs.Tenant{OrgID: org.ID, Quotas: org.Quotas},
+			err:      nil,
+		},
+		{
+			desc:     "identify removed org member",
+			memberID: removedID,
+			orgID:    org.ID,
+			err:      errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(errors.ErrAuthorization)
+		repoCall1 := oRepo.On("RetrieveMember", context.Background(), org.ID, userID).Return(orgs.Member{Role: orgs.MemberRole}, nil)
+		repoCall2 := oRepo.On("RetrieveMember", context.Background(), org.ID, removedID).Return(orgs.Member{}, errors.ErrNotFound)
+		repoCall3 := oRepo.On("Retrieve", context.Background(), org.ID).Return(org, nil)
+		tenant, err := svc.IdentifyMember(context.Background(), tc.memberID, tc.orgID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.tenant, tenant, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.tenant, tenant))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/users/jwt"
+	"github.com/mainflux/mainflux/users/orgs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ orgs.Service = (*tracingMiddleware)(nil)
+
+type tracingMiddleware struct {
+	tracer trace.Tracer
+	osvc   orgs.Service
+}
+
+// New returns a new organizations service with tracing capabilities.
+func New(osvc orgs.Service, tracer trace.Tracer) orgs.Service {
+	return &tracingMiddleware{tracer, osvc}
+}
+
+// CreateOrg traces the "CreateOrg" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) CreateOrg(ctx context.Context, token string, org orgs.Organization) (orgs.Organization, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_org", trace.WithAttributes(attribute.String("name", org.Name)))
+	defer span.End()
+
+	return tm.osvc.CreateOrg(ctx, token, org)
+}
+
+// ViewOrg traces the "ViewOrg" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) ViewOrg(ctx context.Context, token, id string) (orgs.Organization, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_org", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.osvc.ViewOrg(ctx, token, id)
+}
+
+// ListOrgs traces the "ListOrgs" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) ListOrgs(ctx context.Context, token string, pm orgs.Page) (orgs.OrgsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_orgs", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.osvc.ListOrgs(ctx, token, pm)
+}
+
+// UpdateOrg traces the "UpdateOrg" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) UpdateOrg(ctx context.Context, token string, org orgs.Organization) (orgs.Organization, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_update_org", trace.WithAttributes(
+		attribute.String("id", org.ID),
+		attribute.String("name", org.Name),
+	))
+	defer span.End()
+
+	return tm.osvc.UpdateOrg(ctx, token, org)
+}
+
+// RemoveOrg traces the "RemoveOrg" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) RemoveOrg(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_org", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.osvc.RemoveOrg(ctx, token, id)
+}
+
+// ListMembers traces the "ListMembers" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) ListMembers(ctx context.Context, token, orgID string, pm orgs.Page) (orgs.MembersPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_org_members", trace.WithAttributes(
+		attribute.String("org_id", orgID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.osvc.ListMembers(ctx, token, orgID, pm)
+}
+
+// UpdateMember traces the "UpdateMember" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) UpdateMember(ctx context.Context, token string, m orgs.Member) (orgs.Member, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_update_org_member", trace.WithAttributes(
+		attribute.String("org_id", m.OrgID),
+		attribute.String("member_id", m.MemberID),
+		attribute.String("role", m.Role),
+	))
+	defer span.End()
+
+	return tm.osvc.UpdateMember(ctx, token, m)
+}
+
+// RemoveMember traces the "RemoveMember" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) RemoveMember(ctx context.Context, token, orgID, memberID string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_org_member", trace.WithAttributes(
+		attribute.String("org_id", orgID),
+		attribute.String("member_id", memberID),
+	))
+	defer span.End()
+
+	return tm.osvc.RemoveMember(ctx, token, orgID, memberID)
+}
+
+// Invite traces the "Invite" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) Invite(ctx context.Context, token string, inv orgs.Invitation) (orgs.Invitation, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_invite_org_member", trace.WithAttributes(
+		attribute.String("org_id", inv.OrgID),
+		attribute.String("role", inv.Role),
+	))
+	defer span.End()
+
+	return tm.osvc.Invite(ctx, token, inv)
+}
+
+// ListInvitations traces the "ListInvitations" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) ListInvitations(ctx context.Context, token, orgID string, pm orgs.Page) (orgs.InvitationsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_org_invitations", trace.WithAttributes(
+		attribute.String("org_id", orgID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.osvc.ListInvitations(ctx, token, orgID, pm)
+}
+
+// AcceptInvitation traces the "AcceptInvitation" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) AcceptInvitation(ctx context.Context, token, id string) (orgs.Member, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_accept_org_invitation", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.osvc.AcceptInvitation(ctx, token, id)
+}
+
+// RemoveInvitation traces the "RemoveInvitation" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) RemoveInvitation(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_org_invitation", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.osvc.RemoveInvitation(ctx, token, id)
+}
+
+// IssueToken traces the "IssueToken" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) IssueToken(ctx context.Context, token, orgID string) (jwt.Token, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_issue_org_token", trace.WithAttributes(attribute.String("org_id", orgID)))
+	defer span.End()
+
+	return tm.osvc.IssueToken(ctx, token, orgID)
+}
+
+// Identify traces the "Identify" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) Identify(ctx context.Context, token string) (orgs.Tenant, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_identify_org")
+	defer span.End()
+
+	return tm.osvc.Identify(ctx, token)
+}
+
+// IdentifyMember traces the "IdentifyMember" operation of the wrapped orgs.Service.
+func (tm *tracingMiddleware) IdentifyMember(ctx context.Context, memberID, orgID string) (orgs.Tenant, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_identify_org_member", trace.WithAttributes(
+		attribute.String("member_id", memberID),
+		attribute.String("org_id", orgID),
+	))
+	defer span.End()
+
+	return tm.osvc.IdentifyMember(ctx, memberID, orgID)
+}
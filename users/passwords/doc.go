@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package passwords contains the password policy enforced by Mainflux users
+// service when user secrets are set or changed.
+//
+// Besides the configured pattern, the policy requires the minimum length and
+// character classes, rejects secrets containing the user identity, and keeps
+// the hashes of the previous secrets so that they can't be reused.
+package passwords
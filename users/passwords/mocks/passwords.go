@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mainflux/mainflux/users/passwords"
+)
+
+var _ passwords.Repository = (*historyMock)(nil)
+
+type historyMock struct {
+	mu      sync.Mutex
+	history map[string][]string
+}
+
+// NewRepository creates in-memory password history repository.
+func NewRepository() passwords.Repository {
+	return &historyMock{
+		history: make(map[string][]string),
+	}
+}
+
+func (hm *historyMock) Save(_ context.Context, clientID, hash string, keep uint64) error {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hashes := append([]string{hash}, hm.history[clientID]...)
+	if uint64(len(hashes)) > keep {
+		hashes = hashes[:keep]
+	}
+	hm.history[clientID] = hashes
+	return nil
+}
+
+func (hm *historyMock) Retrieve(_ context.Context, clientID string, limit uint64) ([]string, error) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hashes := hm.history[clientID]
+	if uint64(len(hashes)) > limit {
+		hashes = hashes[:limit]
+	}
+	return append([]string{}, hashes...), nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package passwords
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// minIdentityPart is the shortest identity local part rejected in the secret,
+// so that short local parts don't reject the common secrets.
+const minIdentityPart = 3
+
+var (
+	// ErrPattern indicates that the secret doesn't match the configured pattern.
+	ErrPattern = errors.New("password doesn't match the required pattern")
+
+	// ErrTooShort indicates that the secret is shorter than the minimum length.
+	ErrTooShort = errors.New("password is too short")
+
+	// ErrCharacterClasses indicates that the secret misses a required character class.
+	ErrCharacterClasses = errors.New("password doesn't contain the required character classes")
+
+	// ErrContainsIdentity indicates that the secret contains the user identity.
+	ErrContainsIdentity = errors.New("password contains the user identity")
+
+	// ErrReused indicates that the secret matches one of the previous user secrets.
+	ErrReused = errors.New("password has been used recently")
+)
+
+// Config defines the password policy. Zero history disables the reuse check.
+type Config struct {
+	MinLength      int    `env:"MIN_LENGTH"      envDefault:"8"`
+	RequireUpper   bool   `env:"REQUIRE_UPPER"   envDefault:"false"`
+	RequireLower   bool   `env:"REQUIRE_LOWER"   envDefault:"false"`
+	RequireDigit   bool   `env:"REQUIRE_DIGIT"   envDefault:"false"`
+	RequireSpecial bool   `env:"REQUIRE_SPECIAL" envDefault:"false"`
+	RejectIdentity bool   `env:"REJECT_IDENTITY" envDefault:"true"`
+	History        uint64 `env:"HISTORY"         envDefault:"0"`
+}
+
+// Comparer compares the plain-text secret to the hashed one. An error
+// indicates failed comparison.
+type Comparer interface {
+	Compare(plain, hashed string) error
+}
+
+// Repository specifies password history persistence API.
+type Repository interface {
+	// Save adds the secret hash to the client history, keeping only the
+	// given number of the most recent hashes.
+	Save(ctx context.Context, clientID, hash string, keep uint64) error
+
+	// Retrieve returns up to the given number of the most recent secret
+	// hashes of the client, starting with the newest one.
+	Retrieve(ctx context.Context, clientID string, limit uint64) ([]string, error)
+}
+
+// Policy specifies the password policy API.
+type Policy interface {
+	// Validate checks whether the secret is allowed for the client. The
+	// current client secret hash, if any, is treated as part of the history.
+	Validate(ctx context.Context, client mfclients.Client, secret string) error
+
+	// Remember adds the secret hash to the client password history.
+	Remember(ctx context.Context, clientID, hash string) error
+}
+
+var _ Policy = (*policy)(nil)
+
+type policy struct {
+	config   Config
+	pattern  *regexp.Regexp
+	repo     Repository
+	comparer Comparer
+}
+
+// NewPolicy returns a Policy which enforces the configuration and the pattern,
+// and uses the comparer to check the secret against the password history.
+func NewPolicy(config Config, pattern *regexp.Regexp, repo Repository, comparer Comparer) Policy {
+	return policy{
+		config:   config,
+		pattern:  pattern,
+		repo:     repo,
+		comparer: comparer,
+	}
+}
+
+func (p policy) Validate(ctx context.Context, client mfclients.Client, secret string) error {
+	if utf8.RuneCountInString(secret) < p.config.MinLength {
+		return ErrTooShort
+	}
+	if !p.hasClasses(secret) {
+		return ErrCharacterClasses
+	}
+	if p.pattern != nil && !p.pattern.MatchString(secret) {
+		return ErrPattern
+	}
+	if p.config.RejectIdentity && containsIdentity(secret, client.Credentials.Identity) {
+		return ErrContainsIdentity
+	}
+	if p.config.History == 0 {
+		return nil
+	}
+
+	hashes := []string{}
+	if client.Credentials.Secret != "" {
+		hashes = append(hashes, client.Credentials.Secret)
+	}
+	if client.ID != "" {
+		history, err := p.repo.Retrieve(ctx, client.ID, p.config.History)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, history...)
+	}
+	for _, h := range hashes {
+		if err := p.comparer.Compare(secret, h); err == nil {
+			return ErrReused
+		}
+	}
+
+	return nil
+}
+
+func (p policy) Remember(ctx context.Context, clientID, hash string) error {
+	if p.config.History == 0 {
+		return nil
+	}
+
+	return p.repo.Save(ctx, clientID, hash, p.config.History)
+}
+
+func (p policy) hasClasses(secret string) bool {
+	var upper, lower, digit, special bool
+	for _, r := range secret {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			special = true
+		}
+	}
+
+	return (upper || !p.config.RequireUpper) &&
+		(lower || !p.config.RequireLower) &&
+		(digit || !p.config.RequireDigit) &&
+		(special || !p.config.RequireSpecial)
+}
+
+// containsIdentity reports whether the secret contains the identity or,
+// for email identities, its local part, ignoring the case.
+func containsIdentity(secret, identity string) bool {
+	if identity == "" {
+		return false
+	}
+	secret = strings.ToLower(secret)
+	identity = strings.ToLower(identity)
+	if strings.Contains(secret, identity) {
+		return true
+	}
+	local, _, ok := strings.Cut(identity, "@")
+
+	return ok && utf8.RuneCountInString(local) >= minIdentityPart && strings.Contains(secret, local)
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package passwords_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/clients/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pmocks "github.com/mainflux/mainflux/users/passwords/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var client = mfclients.Client{
+	ID: "client",
+	Credentials: mfclients.Credentials{
+		Identity: "jane.doe@example.com",
+		Secret:   "Current-Secret1",
+	},
+}
+
+func TestValidate(t *testing.T) {
+	p := passwords.NewPolicy(passwords.Config{
+		MinLength:      10,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+		RejectIdentity: true,
+		History:        2,
+	}, regexp.MustCompile("^[^ ]*$"), pmocks.NewRepository(), mocks.NewHasher())
+
+	for _, hash := range []string{"Oldest-Secret1", "Older-Secret1", "Old-Secret1"} {
+		err := p.Remember(context.Background(), client.ID, hash)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		client mfclients.Client
+		secret string
+		err    error
+	}{
+		{
+			desc:   "validate strong secret",
+			client: client,
+			secret: "Strong-Secret1",
+			err:    nil,
+		},
+		{
+			desc:   "validate secret not matching the pattern",
+			client: client,
+			secret: "Strong Secret1",
+			err:    passwords.ErrPattern,
+		},
+		{
+			desc:   "validate short secret",
+			client: client,
+			secret: "Short-1",
+			err:    passwords.ErrTooShort,
+		},
+		{
+			desc:   "validate secret without upper case letters",
+			client: client,
+			secret: "strong-secret1",
+			err:    passwords.ErrCharacterClasses,
+		},
+		{
+			desc:   "validate secret without lower case letters",
+			client: client,
+			secret: "STRONG-SECRET1",
+			err:    passwords.ErrCharacterClasses,
+		},
+		{
+			desc:   "validate secret without digits",
+			client: client,
+			secret: "Strong-Secret",
+			err:    passwords.ErrCharacterClasses,
+		},
+		{
+			desc:   "validate secret without special characters",
+			client: client,
+			secret: "StrongSecret1",
+			err:    passwords.ErrCharacterClasses,
+		},
+		{
+			desc:   "validate secret containing the identity",
+			client: client,
+			secret: "Jane.Doe@Example.com1",
+			err:    passwords.ErrContainsIdentity,
+		},
+		{
+			desc:   "validate secret containing the identity local part",
+			client: client,
+			secret: "My-JANE.DOE-1",
+			err:    passwords.ErrContainsIdentity,
+		},
+		{
+			desc:   "validate the current secret",
+			client: client,
+			secret: "Current-Secret1",
+			err:    passwords.ErrReused,
+		},
+		{
+			desc:   "validate the recent secret",
+			client: client,
+			secret: "Older-Secret1",
+			err:    passwords.ErrReused,
+		},
+		{
+			desc:   "validate the secret beyond the history",
+			client: client,
+			secret: "Oldest-Secret1",
+			err:    nil,
+		},
+		{
+			desc:   "validate the secret of the new client",
+			client: mfclients.Client{Credentials: mfclients.Credentials{Identity: "new@example.com"}},
+			secret: "Old-Secret1",
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := p.Validate(context.Background(), tc.client, tc.secret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRemember(t *testing.T) {
+	repo := pmocks.NewRepository()
+	p := passwords.NewPolicy(passwords.Config{}, nil, repo, mocks.NewHasher())
+
+	err := p.Remember(context.Background(), client.ID, "Old-Secret1")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	history, err := repo.Retrieve(context.Background(), client.ID, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, history, "expected no history with the history disabled")
+
+	err = p.Validate(context.Background(), client, client.Credentials.Secret)
+	assert.Nil(t, err, fmt.Sprintf("validate the current secret with the history disabled: expected nil got %s\n", err))
+}
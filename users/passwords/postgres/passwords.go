@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/passwords"
+)
+
+var _ passwords.Repository = (*prepo)(nil)
+
+type prepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of password history repository.
+func NewRepository(db postgres.Database) passwords.Repository {
+	return &prepo{
+		db: db,
+	}
+}
+
+func (pr prepo) Save(ctx context.Context, clientID, hash string, keep uint64) error {
+	q := `INSERT INTO password_history (client_id, secret, created_at) VALUES ($1, $2, $3)`
+	if _, err := pr.db.ExecContext(ctx, q, clientID, hash, time.Now()); err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	// Older hashes are of no use to the policy, so they are removed.
+	q = `DELETE FROM password_history WHERE client_id = $1 AND id NOT IN
+		(SELECT id FROM password_history WHERE client_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2)`
+	if _, err := pr.db.ExecContext(ctx, q, clientID, keep); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (pr prepo) Retrieve(ctx context.Context, clientID string, limit uint64) ([]string, error) {
+	q := `SELECT secret FROM password_history WHERE client_id = $1
+		ORDER BY created_at DESC, id DESC LIMIT $2`
+
+	rows, err := pr.db.QueryxContext(ctx, q, clientID, limit)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	hashes := []string{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
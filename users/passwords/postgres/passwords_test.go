@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	cpostgres "github.com/mainflux/mainflux/users/clients/postgres"
+	ppostgres "github.com/mainflux/mainflux/users/passwords/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idProvider = uuid.New()
+
+func saveClient(t *testing.T, name string) mfclients.Client {
+	crepo := cpostgres.NewRepository(database)
+	client := mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: name,
+		Credentials: mfclients.Credentials{
+			Identity: name,
+			Secret:   "pass",
+		},
+		Status: mfclients.EnabledStatus,
+	}
+	client, err := crepo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return client
+}
+
+func TestHistorySave(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := ppostgres.NewRepository(database)
+	client := saveClient(t, "history-save@example.com")
+
+	cases := []struct {
+		desc     string
+		clientID string
+		hash     string
+		history  []string
+		err      error
+	}{
+		{
+			desc:     "save the first hash",
+			clientID: client.ID,
+			hash:     "first",
+			history:  []string{"first"},
+			err:      nil,
+		},
+		{
+			desc:     "save the second hash",
+			clientID: client.ID,
+			hash:     "second",
+			history:  []string{"second", "first"},
+			err:      nil,
+		},
+		{
+			desc:     "save the hash beyond the kept number",
+			clientID: client.ID,
+			hash:     "third",
+			history:  []string{"third", "second"},
+			err:      nil,
+		},
+		{
+			desc:     "save the hash of non-existing client",
+			clientID: testsutil.GenerateUUID(t, idProvider),
+			hash:     "first",
+			err:      errors.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.clientID, tc.hash, 2)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			history, err := repo.Retrieve(context.Background(), tc.clientID, 10)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.history, history, fmt.Sprintf("%s: expected history %v got %v\n", tc.desc, tc.history, history))
+		}
+	}
+}
+
+func TestHistoryRetrieve(t *testing.T) {
+	t.Cleanup(func() { testsutil.CleanUpDB(t, db) })
+	repo := ppostgres.NewRepository(database)
+	client := saveClient(t, "history-retrieve@example.com")
+
+	for _, hash := range []string{"first", "second", "third"} {
+		err := repo.Save(context.Background(), client.ID, hash, 3)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc     string
+		clientID string
+		limit    uint64
+		history  []string
+	}{
+		{
+			desc:     "retrieve the whole history",
+			clientID: client.ID,
+			limit:    3,
+			history:  []string{"third", "second", "first"},
+		},
+		{
+			desc:     "retrieve the most recent hashes",
+			clientID: client.ID,
+			limit:    2,
+			history:  []string{"third", "second"},
+		},
+		{
+			desc:     "retrieve the history of non-existing client",
+			clientID: testsutil.GenerateUUID(t, idProvider),
+			limit:    3,
+			history:  []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		history, err := repo.Retrieve(context.Background(), tc.clientID, tc.limit)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.history, history, fmt.Sprintf("%s: expected history %v got %v\n", tc.desc, tc.history, history))
+	}
+}
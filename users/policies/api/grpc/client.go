@@ -79,21 +79,21 @@ func (client grpcClient) Identify(ctx context.Context, req *policies.IdentifyReq
 	ctx, close := context.WithTimeout(ctx, client.timeout)
 	defer close()
 
-	ireq, err := client.identify(ctx, identifyReq{token: req.GetToken()})
+	ireq, err := client.identify(ctx, identifyReq{token: req.GetToken(), action: req.GetAction(), object: req.GetObject()})
 	if err != nil {
 		return nil, err
 	}
 
 	ires := ireq.(identifyRes)
-	return &policies.IdentifyRes{Id: ires.id}, nil
+	return &policies.IdentifyRes{Id: ires.id, OrgId: ires.orgID, ThingsQuota: ires.thingsQuota, ChannelsQuota: ires.channelsQuota}, nil
 }
 
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(identifyReq)
-	return &policies.IdentifyReq{Token: req.token}, nil
+	return &policies.IdentifyReq{Token: req.token, Action: req.action, Object: req.object}, nil
 }
 
 func decodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*policies.IdentifyRes)
-	return identifyRes{id: res.GetId()}, nil
+	return identifyRes{id: res.GetId(), orgID: res.GetOrgId(), thingsQuota: res.GetThingsQuota(), channelsQuota: res.GetChannelsQuota()}, nil
 }
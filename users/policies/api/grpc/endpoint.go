@@ -10,6 +10,8 @@ import (
 	"github.com/mainflux/mainflux/internal/apiutil"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users/clients"
+	"github.com/mainflux/mainflux/users/keys"
+	"github.com/mainflux/mainflux/users/orgs"
 	"github.com/mainflux/mainflux/users/policies"
 )
 
@@ -29,20 +31,42 @@ func authorizeEndpoint(svc policies.Service) endpoint.Endpoint {
 	}
 }
 
-func identifyEndpoint(svc clients.Service) endpoint.Endpoint {
+// identifyEndpoint identifies access tokens using the clients service and
+// API keys using the keys service. Organization scoped access tokens and
+// API keys are resolved to the organization using the organizations service.
+func identifyEndpoint(csvc clients.Service, ksvc keys.Service, osvc orgs.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(identifyReq)
 		if err := req.validate(); err != nil {
 			return identifyRes{}, err
 		}
 
-		id, err := svc.Identify(ctx, req.token)
-		if err != nil {
-			return identifyRes{}, err
+		var id string
+		var tenant orgs.Tenant
+		if keys.IsKey(req.token) {
+			key, err := ksvc.Identify(ctx, req.token, req.action, req.object)
+			if err != nil {
+				return identifyRes{}, err
+			}
+			if tenant, err = osvc.IdentifyMember(ctx, key.OwnerID, key.OrgID); err != nil {
+				return identifyRes{}, err
+			}
+			id = key.OwnerID
+		} else {
+			var err error
+			if id, err = csvc.Identify(ctx, req.token); err != nil {
+				return identifyRes{}, err
+			}
+			if tenant, err = osvc.Identify(ctx, req.token); err != nil {
+				return identifyRes{}, err
+			}
 		}
 
 		ret := identifyRes{
-			id: id,
+			id:            id,
+			orgID:         tenant.OrgID,
+			thingsQuota:   tenant.Quotas.Things,
+			channelsQuota: tenant.Quotas.Channels,
 		}
 		return ret, nil
 	}
@@ -36,8 +36,12 @@ func (req authReq) validate() error {
 	return nil
 }
 
+// identifyReq represents identification request. Action and object are
+// only taken into account for scoped API keys.
 type identifyReq struct {
-	token string
+	token  string
+	action string
+	object string
 }
 
 func (req identifyReq) validate() error {
@@ -8,5 +8,8 @@ type authorizeRes struct {
 }
 
 type identifyRes struct {
-	id string
+	id            string
+	orgID         string
+	thingsQuota   uint64
+	channelsQuota uint64
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/mainflux/mainflux/internal/apiutil"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users/clients"
+	"github.com/mainflux/mainflux/users/keys"
+	"github.com/mainflux/mainflux/users/orgs"
 	"github.com/mainflux/mainflux/users/policies"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -24,7 +26,7 @@ type grpcServer struct {
 }
 
 // NewServer returns new AuthServiceServer instance.
-func NewServer(csvc clients.Service, psvc policies.Service) policies.AuthServiceServer {
+func NewServer(csvc clients.Service, psvc policies.Service, ksvc keys.Service, osvc orgs.Service) policies.AuthServiceServer {
 	return &grpcServer{
 		authorize: kitgrpc.NewServer(
 			authorizeEndpoint(psvc),
@@ -32,7 +34,7 @@ func NewServer(csvc clients.Service, psvc policies.Service) policies.AuthService
 			encodeAuthorizeResponse,
 		),
 		identify: kitgrpc.NewServer(
-			identifyEndpoint(csvc),
+			identifyEndpoint(csvc, ksvc, osvc),
 			decodeIdentifyRequest,
 			encodeIdentifyResponse,
 		),
@@ -67,12 +69,12 @@ func encodeAuthorizeResponse(_ context.Context, grpcRes interface{}) (interface{
 
 func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*policies.IdentifyReq)
-	return identifyReq{token: req.GetToken()}, nil
+	return identifyReq{token: req.GetToken(), action: req.GetAction(), object: req.GetObject()}, nil
 }
 
 func encodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identifyRes)
-	return &policies.IdentifyRes{Id: res.id}, nil
+	return &policies.IdentifyRes{Id: res.id, OrgId: res.orgID, ThingsQuota: res.thingsQuota, ChannelsQuota: res.channelsQuota}, nil
 }
 
 func encodeError(err error) error {
@@ -117,6 +117,186 @@ func deletePolicyEndpoint(svc policies.Service) endpoint.Endpoint {
 	}
 }
 
+func createRoleEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(roleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		role := policies.Role{
+			Name:        req.Name,
+			Description: req.Description,
+			Actions:     req.Actions,
+		}
+		role, err := svc.CreateRole(ctx, req.token, role)
+		if err != nil {
+			return nil, err
+		}
+
+		return roleRes{Role: role, created: true}, nil
+	}
+}
+
+func viewRoleEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		role, err := svc.ViewRole(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return roleRes{Role: role}, nil
+	}
+}
+
+func listRolesEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listRolesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		pm := policies.Page{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListRoles(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listRolesRes{
+			pageRes: pageRes{
+				Limit:  page.Limit,
+				Offset: page.Offset,
+				Total:  page.Total,
+			},
+			Roles: []policies.Role{},
+		}
+		res.Roles = append(res.Roles, page.Roles...)
+
+		return res, nil
+	}
+}
+
+func updateRoleEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(roleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		if req.id == "" {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingID)
+		}
+
+		role := policies.Role{
+			ID:          req.id,
+			Name:        req.Name,
+			Description: req.Description,
+			Actions:     req.Actions,
+		}
+		role, err := svc.UpdateRole(ctx, req.token, role)
+		if err != nil {
+			return nil, err
+		}
+
+		return roleRes{Role: role}, nil
+	}
+}
+
+func removeRoleEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveRole(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRoleRes{}, nil
+	}
+}
+
+func assignRoleEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(assignmentReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		a := policies.Assignment{
+			RoleID:  req.roleID,
+			Subject: req.Subject,
+			Object:  req.Object,
+		}
+		if err := svc.AssignRole(ctx, req.token, a); err != nil {
+			return nil, err
+		}
+
+		return assignRoleRes{assigned: true}, nil
+	}
+}
+
+func unassignRoleEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(assignmentReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		a := policies.Assignment{
+			RoleID:  req.roleID,
+			Subject: req.Subject,
+			Object:  req.Object,
+		}
+		if err := svc.UnassignRole(ctx, req.token, a); err != nil {
+			return nil, err
+		}
+
+		return assignRoleRes{assigned: false}, nil
+	}
+}
+
+func listAssignmentsEndpoint(svc policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAssignmentsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		pm := policies.Page{
+			Offset:  req.offset,
+			Limit:   req.limit,
+			RoleID:  req.roleID,
+			Subject: req.subject,
+			Object:  req.object,
+		}
+		page, err := svc.ListAssignments(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listAssignmentsRes{
+			pageRes: pageRes{
+				Limit:  page.Limit,
+				Offset: page.Offset,
+				Total:  page.Total,
+			},
+			Assignments: []policies.Assignment{},
+		}
+		res.Assignments = append(res.Assignments, page.Assignments...)
+
+		return res, nil
+	}
+}
+
 func buildPoliciesResponse(page policies.PolicyPage) listPolicyRes {
 	res := listPolicyRes{
 		pageRes: pageRes{
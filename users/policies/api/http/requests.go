@@ -4,6 +4,7 @@
 package http
 
 import (
+	"github.com/mainflux/mainflux/internal/api"
 	"github.com/mainflux/mainflux/internal/apiutil"
 	"github.com/mainflux/mainflux/users/policies"
 )
@@ -110,3 +111,110 @@ func (req deletePolicyReq) validate() error {
 
 	return nil
 }
+
+type roleReq struct {
+	token       string
+	id          string
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Actions     []string `json:"actions,omitempty"`
+}
+
+func (req roleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" || len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if len(req.Actions) == 0 {
+		return apiutil.ErrMalformedPolicyAct
+	}
+	for _, a := range req.Actions {
+		if ok := policies.ValidateAction(a); !ok {
+			return apiutil.ErrMalformedPolicyAct
+		}
+	}
+
+	return nil
+}
+
+type viewRoleReq struct {
+	token string
+	id    string
+}
+
+func (req viewRoleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listRolesReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listRolesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type assignmentReq struct {
+	token   string
+	roleID  string
+	Subject string `json:"subject,omitempty"`
+	Object  string `json:"object,omitempty"`
+}
+
+func (req assignmentReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.roleID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Subject == "" {
+		return apiutil.ErrMissingPolicySub
+	}
+	if req.Object == "" {
+		return apiutil.ErrMissingPolicyObj
+	}
+
+	return nil
+}
+
+type listAssignmentsReq struct {
+	token   string
+	roleID  string
+	offset  uint64
+	limit   uint64
+	subject string
+	object  string
+}
+
+func (req listAssignmentsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.roleID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.limit > api.MaxLimitSize || req.limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/mainflux/mainflux"
@@ -17,6 +18,11 @@ var (
 	_ mainflux.Response = (*listPolicyRes)(nil)
 	_ mainflux.Response = (*updatePolicyRes)(nil)
 	_ mainflux.Response = (*deletePolicyRes)(nil)
+	_ mainflux.Response = (*roleRes)(nil)
+	_ mainflux.Response = (*listRolesRes)(nil)
+	_ mainflux.Response = (*removeRoleRes)(nil)
+	_ mainflux.Response = (*assignRoleRes)(nil)
+	_ mainflux.Response = (*listAssignmentsRes)(nil)
 )
 
 type pageRes struct {
@@ -137,3 +143,98 @@ func (res deletePolicyRes) Headers() map[string]string {
 func (res deletePolicyRes) Empty() bool {
 	return true
 }
+
+type roleRes struct {
+	policies.Role `json:",inline"`
+	created       bool
+}
+
+func (res roleRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res roleRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/roles/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res roleRes) Empty() bool {
+	return false
+}
+
+type listRolesRes struct {
+	pageRes
+	Roles []policies.Role `json:"roles"`
+}
+
+func (res listRolesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listRolesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listRolesRes) Empty() bool {
+	return false
+}
+
+type removeRoleRes struct{}
+
+func (res removeRoleRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRoleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRoleRes) Empty() bool {
+	return true
+}
+
+type assignRoleRes struct {
+	assigned bool
+}
+
+func (res assignRoleRes) Code() int {
+	if res.assigned {
+		return http.StatusCreated
+	}
+
+	return http.StatusNoContent
+}
+
+func (res assignRoleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res assignRoleRes) Empty() bool {
+	return true
+}
+
+type listAssignmentsRes struct {
+	pageRes
+	Assignments []policies.Assignment `json:"assignments"`
+}
+
+func (res listAssignmentsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listAssignmentsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listAssignmentsRes) Empty() bool {
+	return false
+}
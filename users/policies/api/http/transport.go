@@ -59,6 +59,62 @@ func MakeHandler(svc policies.Service, mux *bone.Mux, logger logger.Logger) http
 		opts...,
 	), "delete_policy"))
 
+	mux.Post("/roles", otelhttp.NewHandler(kithttp.NewServer(
+		createRoleEndpoint(svc),
+		decodeRoleCreate,
+		api.EncodeResponse,
+		opts...,
+	), "create_role"))
+
+	mux.Get("/roles", otelhttp.NewHandler(kithttp.NewServer(
+		listRolesEndpoint(svc),
+		decodeListRoles,
+		api.EncodeResponse,
+		opts...,
+	), "list_roles"))
+
+	mux.Get("/roles/:id", otelhttp.NewHandler(kithttp.NewServer(
+		viewRoleEndpoint(svc),
+		decodeRoleRequest,
+		api.EncodeResponse,
+		opts...,
+	), "view_role"))
+
+	mux.Put("/roles/:id", otelhttp.NewHandler(kithttp.NewServer(
+		updateRoleEndpoint(svc),
+		decodeRoleUpdate,
+		api.EncodeResponse,
+		opts...,
+	), "update_role"))
+
+	mux.Delete("/roles/:id", otelhttp.NewHandler(kithttp.NewServer(
+		removeRoleEndpoint(svc),
+		decodeRoleRequest,
+		api.EncodeResponse,
+		opts...,
+	), "remove_role"))
+
+	mux.Post("/roles/:id/assignments", otelhttp.NewHandler(kithttp.NewServer(
+		assignRoleEndpoint(svc),
+		decodeAssignRole,
+		api.EncodeResponse,
+		opts...,
+	), "assign_role"))
+
+	mux.Get("/roles/:id/assignments", otelhttp.NewHandler(kithttp.NewServer(
+		listAssignmentsEndpoint(svc),
+		decodeListAssignments,
+		api.EncodeResponse,
+		opts...,
+	), "list_assignments"))
+
+	mux.Delete("/roles/:id/assignments/:subject/:object", otelhttp.NewHandler(kithttp.NewServer(
+		unassignRoleEndpoint(svc),
+		decodeUnassignRole,
+		api.EncodeResponse,
+		opts...,
+	), "unassign_role"))
+
 	return mux
 }
 
@@ -165,3 +221,117 @@ func deletePolicyRequest(_ context.Context, r *http.Request) (interface{}, error
 
 	return req, nil
 }
+
+func decodeRoleCreate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := roleReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeRoleUpdate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := roleReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeRoleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewRoleReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeListRoles(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listRolesReq{
+		token:  apiutil.ExtractBearerToken(r),
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+func decodeAssignRole(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := assignmentReq{
+		token:  apiutil.ExtractBearerToken(r),
+		roleID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeUnassignRole(_ context.Context, r *http.Request) (interface{}, error) {
+	req := assignmentReq{
+		token:   apiutil.ExtractBearerToken(r),
+		roleID:  bone.GetValue(r, "id"),
+		Subject: bone.GetValue(r, api.SubjectKey),
+		Object:  bone.GetValue(r, api.ObjectKey),
+	}
+
+	return req, nil
+}
+
+func decodeListAssignments(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	subject, err := apiutil.ReadStringQuery(r, api.SubjectKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	object, err := apiutil.ReadStringQuery(r, api.ObjectKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listAssignmentsReq{
+		token:   apiutil.ExtractBearerToken(r),
+		roleID:  bone.GetValue(r, "id"),
+		offset:  offset,
+		limit:   limit,
+		subject: subject,
+		object:  object,
+	}
+
+	return req, nil
+}
@@ -93,3 +93,115 @@ func (lm *loggingMiddleware) DeletePolicy(ctx context.Context, token string, p p
 	}(time.Now())
 	return lm.svc.DeletePolicy(ctx, token, p)
 }
+
+// CreateRole logs the create_role request. It logs the role name and ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) CreateRole(ctx context.Context, token string, r policies.Role) (role policies.Role, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_role with name %s and id %s took %s to complete", r.Name, role.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.CreateRole(ctx, token, r)
+}
+
+// ViewRole logs the view_role request. It logs the role ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ViewRole(ctx context.Context, token, id string) (role policies.Role, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_role with id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ViewRole(ctx, token, id)
+}
+
+// ListRoles logs the list_roles request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListRoles(ctx context.Context, token string, pm policies.Page) (rp policies.RolesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_roles took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ListRoles(ctx, token, pm)
+}
+
+// UpdateRole logs the update_role request. It logs the role ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) UpdateRole(ctx context.Context, token string, r policies.Role) (role policies.Role, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_role with id %s took %s to complete", r.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.UpdateRole(ctx, token, r)
+}
+
+// RemoveRole logs the remove_role request. It logs the role ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RemoveRole(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_role with id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.RemoveRole(ctx, token, id)
+}
+
+// AssignRole logs the assign_role request. It logs the role, subject and object and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) AssignRole(ctx context.Context, token string, a policies.Assignment) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method assign_role with role %s for subject %s on object %s took %s to complete", a.RoleID, a.Subject, a.Object, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.AssignRole(ctx, token, a)
+}
+
+// UnassignRole logs the unassign_role request. It logs the role, subject and object and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) UnassignRole(ctx context.Context, token string, a policies.Assignment) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unassign_role with role %s for subject %s on object %s took %s to complete", a.RoleID, a.Subject, a.Object, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.UnassignRole(ctx, token, a)
+}
+
+// ListAssignments logs the list_assignments request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListAssignments(ctx context.Context, token string, pm policies.Page) (ap policies.AssignmentsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_assignments took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+	return lm.svc.ListAssignments(ctx, token, pm)
+}
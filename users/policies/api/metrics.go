@@ -72,3 +72,75 @@ func (ms *metricsMiddleware) DeletePolicy(ctx context.Context, token string, p p
 	}(time.Now())
 	return ms.svc.DeletePolicy(ctx, token, p)
 }
+
+// CreateRole instruments CreateRole method with metrics.
+func (ms *metricsMiddleware) CreateRole(ctx context.Context, token string, r policies.Role) (policies.Role, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_role").Add(1)
+		ms.latency.With("method", "create_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.CreateRole(ctx, token, r)
+}
+
+// ViewRole instruments ViewRole method with metrics.
+func (ms *metricsMiddleware) ViewRole(ctx context.Context, token, id string) (policies.Role, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_role").Add(1)
+		ms.latency.With("method", "view_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ViewRole(ctx, token, id)
+}
+
+// ListRoles instruments ListRoles method with metrics.
+func (ms *metricsMiddleware) ListRoles(ctx context.Context, token string, pm policies.Page) (policies.RolesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_roles").Add(1)
+		ms.latency.With("method", "list_roles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListRoles(ctx, token, pm)
+}
+
+// UpdateRole instruments UpdateRole method with metrics.
+func (ms *metricsMiddleware) UpdateRole(ctx context.Context, token string, r policies.Role) (policies.Role, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_role").Add(1)
+		ms.latency.With("method", "update_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UpdateRole(ctx, token, r)
+}
+
+// RemoveRole instruments RemoveRole method with metrics.
+func (ms *metricsMiddleware) RemoveRole(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_role").Add(1)
+		ms.latency.With("method", "remove_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RemoveRole(ctx, token, id)
+}
+
+// AssignRole instruments AssignRole method with metrics.
+func (ms *metricsMiddleware) AssignRole(ctx context.Context, token string, a policies.Assignment) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "assign_role").Add(1)
+		ms.latency.With("method", "assign_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.AssignRole(ctx, token, a)
+}
+
+// UnassignRole instruments UnassignRole method with metrics.
+func (ms *metricsMiddleware) UnassignRole(ctx context.Context, token string, a policies.Assignment) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unassign_role").Add(1)
+		ms.latency.With("method", "unassign_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UnassignRole(ctx, token, a)
+}
+
+// ListAssignments instruments ListAssignments method with metrics.
+func (ms *metricsMiddleware) ListAssignments(ctx context.Context, token string, pm policies.Page) (policies.AssignmentsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_assignments").Add(1)
+		ms.latency.With("method", "list_assignments").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListAssignments(ctx, token, pm)
+}
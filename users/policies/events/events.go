@@ -18,12 +18,21 @@ const (
 	policyUpdate = policyPrefix + "update"
 	policyList   = policyPrefix + "list"
 	policyDelete = policyPrefix + "delete"
+
+	rolePrefix   = "roles."
+	roleCreate   = rolePrefix + "create"
+	roleUpdate   = rolePrefix + "update"
+	roleRemove   = rolePrefix + "remove"
+	roleAssign   = rolePrefix + "assign"
+	roleUnassign = rolePrefix + "unassign"
 )
 
 var (
 	_ events.Event = (*policyEvent)(nil)
 	_ events.Event = (*authorizeEvent)(nil)
 	_ events.Event = (*listPoliciesEvent)(nil)
+	_ events.Event = (*roleEvent)(nil)
+	_ events.Event = (*assignmentEvent)(nil)
 )
 
 type policyEvent struct {
@@ -105,3 +114,50 @@ func (lpe listPoliciesEvent) Encode() (map[string]interface{}, error) {
 	}
 	return val, nil
 }
+
+type roleEvent struct {
+	policies.Role
+	operation string
+}
+
+func (re roleEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": re.operation,
+		"id":        re.ID,
+	}
+	if re.OwnerID != "" {
+		val["owner_id"] = re.OwnerID
+	}
+	if re.Name != "" {
+		val["name"] = re.Name
+	}
+	if len(re.Actions) > 0 {
+		actions := fmt.Sprintf("[%s]", strings.Join(re.Actions, ","))
+		val["actions"] = actions
+	}
+	if !re.CreatedAt.IsZero() {
+		val["created_at"] = re.CreatedAt
+	}
+	if !re.UpdatedAt.IsZero() {
+		val["updated_at"] = re.UpdatedAt
+	}
+	if re.UpdatedBy != "" {
+		val["updated_by"] = re.UpdatedBy
+	}
+	return val, nil
+}
+
+type assignmentEvent struct {
+	policies.Assignment
+	operation string
+}
+
+func (ae assignmentEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": ae.operation,
+		"role_id":   ae.RoleID,
+		"subject":   ae.Subject,
+		"object":    ae.Object,
+	}
+	return val, nil
+}
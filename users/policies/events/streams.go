@@ -98,3 +98,85 @@ func (es *eventStore) DeletePolicy(ctx context.Context, token string, policy pol
 
 	return es.Publish(ctx, event)
 }
+
+func (es *eventStore) CreateRole(ctx context.Context, token string, r policies.Role) (policies.Role, error) {
+	role, err := es.svc.CreateRole(ctx, token, r)
+	if err != nil {
+		return role, err
+	}
+
+	event := roleEvent{
+		role, roleCreate,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return role, err
+	}
+
+	return role, nil
+}
+
+func (es *eventStore) ViewRole(ctx context.Context, token, id string) (policies.Role, error) {
+	return es.svc.ViewRole(ctx, token, id)
+}
+
+func (es *eventStore) ListRoles(ctx context.Context, token string, pm policies.Page) (policies.RolesPage, error) {
+	return es.svc.ListRoles(ctx, token, pm)
+}
+
+func (es *eventStore) UpdateRole(ctx context.Context, token string, r policies.Role) (policies.Role, error) {
+	role, err := es.svc.UpdateRole(ctx, token, r)
+	if err != nil {
+		return role, err
+	}
+
+	event := roleEvent{
+		role, roleUpdate,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return role, err
+	}
+
+	return role, nil
+}
+
+func (es *eventStore) RemoveRole(ctx context.Context, token, id string) error {
+	if err := es.svc.RemoveRole(ctx, token, id); err != nil {
+		return err
+	}
+
+	event := roleEvent{
+		policies.Role{ID: id}, roleRemove,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) AssignRole(ctx context.Context, token string, a policies.Assignment) error {
+	if err := es.svc.AssignRole(ctx, token, a); err != nil {
+		return err
+	}
+
+	event := assignmentEvent{
+		a, roleAssign,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) UnassignRole(ctx context.Context, token string, a policies.Assignment) error {
+	if err := es.svc.UnassignRole(ctx, token, a); err != nil {
+		return err
+	}
+
+	event := assignmentEvent{
+		a, roleUnassign,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) ListAssignments(ctx context.Context, token string, pm policies.Page) (policies.AssignmentsPage, error) {
+	return es.svc.ListAssignments(ctx, token, pm)
+}
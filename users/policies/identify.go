@@ -0,0 +1,15 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+// GetOwner returns the ID of the entities owner. Entities created using the
+// organization scoped token are owned by the organization, while all the
+// other entities are owned by the user.
+func (x *IdentifyRes) GetOwner() string {
+	if orgID := x.GetOrgId(); orgID != "" {
+		return orgID
+	}
+
+	return x.GetId()
+}
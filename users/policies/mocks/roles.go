@@ -0,0 +1,63 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/users/policies"
+	"github.com/stretchr/testify/mock"
+)
+
+type RoleRepository struct {
+	mock.Mock
+}
+
+func (m *RoleRepository) Save(ctx context.Context, r policies.Role) (policies.Role, error) {
+	ret := m.Called(ctx, r)
+
+	return ret.Get(0).(policies.Role), ret.Error(1)
+}
+
+func (m *RoleRepository) Retrieve(ctx context.Context, id string) (policies.Role, error) {
+	ret := m.Called(ctx, id)
+
+	return ret.Get(0).(policies.Role), ret.Error(1)
+}
+
+func (m *RoleRepository) RetrieveAll(ctx context.Context, pm policies.Page) (policies.RolesPage, error) {
+	ret := m.Called(ctx, pm)
+
+	return ret.Get(0).(policies.RolesPage), ret.Error(1)
+}
+
+func (m *RoleRepository) Update(ctx context.Context, r policies.Role) (policies.Role, error) {
+	ret := m.Called(ctx, r)
+
+	return ret.Get(0).(policies.Role), ret.Error(1)
+}
+
+func (m *RoleRepository) Remove(ctx context.Context, id string) error {
+	ret := m.Called(ctx, id)
+
+	return ret.Error(0)
+}
+
+func (m *RoleRepository) Assign(ctx context.Context, a policies.Assignment) error {
+	ret := m.Called(ctx, a)
+
+	return ret.Error(0)
+}
+
+func (m *RoleRepository) Unassign(ctx context.Context, a policies.Assignment) error {
+	ret := m.Called(ctx, a)
+
+	return ret.Error(0)
+}
+
+func (m *RoleRepository) RetrieveAssignments(ctx context.Context, pm policies.Page) (policies.AssignmentsPage, error) {
+	ret := m.Called(ctx, pm)
+
+	return ret.Get(0).(policies.AssignmentsPage), ret.Error(1)
+}
@@ -17,6 +17,7 @@ type Page struct {
 	Subject string
 	Object  string
 	Action  string
+	RoleID  string
 	Tag     string
 }
 
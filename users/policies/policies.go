@@ -73,11 +73,15 @@ type Repository interface {
 	CheckAdmin(ctx context.Context, id string) error
 
 	// EvaluateUserAccess is used to evaluate if user has access to another user.
+	// The access is granted by the policies on the groups both users are
+	// members of, and by the roles assigned on those groups or their ancestors.
 	// It returns an error and an empty policy if the user does not have access
 	// otherwise it returns nil and the policy.
 	EvaluateUserAccess(ctx context.Context, ar AccessRequest) (Policy, error)
 
 	// EvaluateGroupAccess is used to evaluate if user has access to a group.
+	// The access is granted by the policy on the group, the group ownership,
+	// and by the roles assigned on the group or its ancestors.
 	// It returns an error and an empty policy if the user does not have access
 	// otherwise it returns nil and the policy.
 	EvaluateGroupAccess(ctx context.Context, ar AccessRequest) (Policy, error)
@@ -135,6 +139,43 @@ type Service interface {
 	//
 	//  2. The subject is the owner of the policy.
 	DeletePolicy(ctx context.Context, token string, p Policy) error
+
+	// CreateRole creates a role owned by the user.
+	CreateRole(ctx context.Context, token string, r Role) (Role, error)
+
+	// ViewRole retrieves the role. Only the role owner and admins are
+	// allowed to view the role.
+	ViewRole(ctx context.Context, token, id string) (Role, error)
+
+	// ListRoles lists the roles owned by the user, or all the roles if the
+	// user is admin.
+	ListRoles(ctx context.Context, token string, pm Page) (RolesPage, error)
+
+	// UpdateRole updates the role name, description and actions. Only the
+	// role owner and admins are allowed to update the role. Actions added to
+	// an assigned role must be held by the user on every assigned group.
+	UpdateRole(ctx context.Context, token string, r Role) (Role, error)
+
+	// RemoveRole removes the role together with its assignments. Only the
+	// role owner and admins are allowed to remove the role.
+	RemoveRole(ctx context.Context, token, id string) error
+
+	// AssignRole assigns the role to the subject on the object group.
+	// AssignRole assigns a role if:
+	//
+	//  1. The user is admin.
+	//
+	//  2. The user owns the role and has `g_add` action on the object with
+	//     all of the role actions, or is the owner of the object.
+	AssignRole(ctx context.Context, token string, a Assignment) error
+
+	// UnassignRole removes the role assignment. Only the user who made the
+	// assignment and admins are allowed to remove it.
+	UnassignRole(ctx context.Context, token string, a Assignment) error
+
+	// ListAssignments lists the role assignments made by the user, or all
+	// the assignments if the user is admin.
+	ListAssignments(ctx context.Context, token string, pm Page) (AssignmentsPage, error)
 }
 
 // Validate returns an error if policy representation is invalid.
@@ -222,3 +263,15 @@ func checkActions(currentActions, incomingActions []string) error {
 	}
 	return nil
 }
+
+// added returns the actions which are not among the current ones.
+func added(current, actions []string) []string {
+	var ret []string
+	for _, action := range actions {
+		if !slices.Contains(current, action) {
+			ret = append(ret, action)
+		}
+	}
+
+	return ret
+}
@@ -66,22 +66,43 @@ func (pr prepo) CheckAdmin(ctx context.Context, id string) error {
 }
 
 func (pr prepo) EvaluateUserAccess(ctx context.Context, ar policies.AccessRequest) (policies.Policy, error) {
-	// Evaluates if two clients are connected to the same group and the subject has the specified action
+	// Evaluates if two clients are connected to the same group and the subject has the specified action,
+	// or subject has a role with the specified action on a group of the object or its ancestor,
 	// or subject is the owner of the object
-	query := fmt.Sprintf(`(SELECT subject, object, actions FROM policies p 
+	query := fmt.Sprintf(`WITH RECURSIVE ancestors AS (
+		SELECT id, parent_id FROM groups WHERE id IN (SELECT object FROM policies WHERE subject = :object)
+		UNION
+		SELECT g.id, g.parent_id FROM groups g INNER JOIN ancestors a ON a.parent_id = g.id
+	)
+	(SELECT subject, object, actions FROM policies p 
 	WHERE p.subject = :subject AND '%s' = ANY(p.actions) AND object IN (SELECT object FROM policies WHERE subject = :object))
 	UNION
-	(SELECT owner_id as subject, id as object, '{}' as actions FROM clients c WHERE c.owner_id = :subject AND c.id = :object) LIMIT 1;`, ar.Action)
+	(SELECT ra.subject, ra.object, r.actions FROM role_assignments ra INNER JOIN roles r ON r.id = ra.role_id
+	WHERE ra.subject = :subject AND '%s' = ANY(r.actions) AND ra.object IN (SELECT id FROM ancestors))
+	UNION
+	(SELECT owner_id as subject, id as object, '{}' as actions FROM clients c WHERE c.owner_id = :subject AND c.id = :object) LIMIT 1;`, ar.Action, ar.Action)
 
 	return pr.evaluate(ctx, query, ar)
 }
 
 func (pr prepo) EvaluateGroupAccess(ctx context.Context, ar policies.AccessRequest) (policies.Policy, error) {
-	// Evaluates if client is a member to that group and has the specified action or is the owner of the group
-	query := fmt.Sprintf(`(SELECT subject, object, actions FROM policies p 
+	// Evaluates if client is a member to that group and has the specified action,
+	// or client has a role with the specified action on the group or its ancestor,
+	// or client is the owner of the group.
+	// The object may be a things group as well, which is not in the groups table,
+	// so the roles assigned on the object itself are checked explicitly.
+	query := fmt.Sprintf(`WITH RECURSIVE ancestors AS (
+		SELECT id, parent_id FROM groups WHERE id = :object
+		UNION
+		SELECT g.id, g.parent_id FROM groups g INNER JOIN ancestors a ON a.parent_id = g.id
+	)
+	(SELECT subject, object, actions FROM policies p 
 	WHERE p.subject = :subject AND p.object = :object AND '%s' = ANY(p.actions))
 	UNION
-	(SELECT owner_id as subject, id as object, '{}' as actions FROM groups g WHERE g.owner_id = :subject AND g.id = :object)`, ar.Action)
+	(SELECT ra.subject, ra.object, r.actions FROM role_assignments ra INNER JOIN roles r ON r.id = ra.role_id
+	WHERE ra.subject = :subject AND '%s' = ANY(r.actions) AND (ra.object = :object OR ra.object IN (SELECT id FROM ancestors)))
+	UNION
+	(SELECT owner_id as subject, id as object, '{}' as actions FROM groups g WHERE g.owner_id = :subject AND g.id = :object)`, ar.Action, ar.Action)
 
 	return pr.evaluate(ctx, query, ar)
 }
@@ -276,6 +297,7 @@ func toDBPoliciesPage(pm policies.Page) (dbPoliciesPage, error) {
 		Subject: pm.Subject,
 		Object:  pm.Object,
 		Action:  pm.Action,
+		RoleID:  pm.RoleID,
 	}, nil
 }
 
@@ -287,4 +309,5 @@ type dbPoliciesPage struct {
 	Subject string `db:"subject"`
 	Object  string `db:"object"`
 	Action  string `db:"action"`
+	RoleID  string `db:"role_id"`
 }
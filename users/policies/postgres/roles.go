@@ -0,0 +1,338 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/mainflux/mainflux/internal/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/policies"
+)
+
+var _ policies.RoleRepository = (*rrepo)(nil)
+
+type rrepo struct {
+	db postgres.Database
+}
+
+// NewRoleRepository instantiates a PostgreSQL implementation of roles repository.
+func NewRoleRepository(db postgres.Database) policies.RoleRepository {
+	return &rrepo{
+		db: db,
+	}
+}
+
+func (rr rrepo) Save(ctx context.Context, r policies.Role) (policies.Role, error) {
+	q := `INSERT INTO roles (id, owner_id, name, description, actions, created_at)
+		VALUES (:id, :owner_id, :name, :description, :actions, :created_at)
+		RETURNING id, owner_id, name, description, actions, created_at, updated_at, updated_by;`
+
+	dbr, err := toDBRole(r)
+	if err != nil {
+		return policies.Role{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	row, err := rr.db.NamedQueryContext(ctx, q, dbr)
+	if err != nil {
+		return policies.Role{}, postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	defer row.Close()
+	row.Next()
+	dbr = dbRole{}
+	if err := row.StructScan(&dbr); err != nil {
+		return policies.Role{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return toRole(dbr), nil
+}
+
+func (rr rrepo) Retrieve(ctx context.Context, id string) (policies.Role, error) {
+	q := `SELECT id, owner_id, name, description, actions, created_at, updated_at, updated_by
+		FROM roles WHERE id = $1`
+
+	dbr := dbRole{}
+	if err := rr.db.QueryRowxContext(ctx, q, id).StructScan(&dbr); err != nil {
+		if err == sql.ErrNoRows {
+			return policies.Role{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return policies.Role{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	return toRole(dbr), nil
+}
+
+func (rr rrepo) RetrieveAll(ctx context.Context, pm policies.Page) (policies.RolesPage, error) {
+	var emq string
+	if pm.OwnerID != "" {
+		emq = "WHERE owner_id = :owner_id"
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner_id, name, description, actions, created_at, updated_at, updated_by
+		FROM roles %s ORDER BY created_at LIMIT :limit OFFSET :offset;`, emq)
+
+	dbPage, err := toDBPoliciesPage(pm)
+	if err != nil {
+		return policies.RolesPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	rows, err := rr.db.NamedQueryContext(ctx, q, dbPage)
+	if err != nil {
+		return policies.RolesPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []policies.Role
+	for rows.Next() {
+		dbr := dbRole{}
+		if err := rows.StructScan(&dbr); err != nil {
+			return policies.RolesPage{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		items = append(items, toRole(dbr))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM roles %s;`, emq)
+
+	total, err := postgres.Total(ctx, rr.db, cq, dbPage)
+	if err != nil {
+		return policies.RolesPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	page := policies.RolesPage{
+		Roles: items,
+		Page: policies.Page{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (rr rrepo) Update(ctx context.Context, r policies.Role) (policies.Role, error) {
+	q := `UPDATE roles SET name = :name, description = :description, actions = :actions,
+		updated_at = :updated_at, updated_by = :updated_by
+		WHERE id = :id
+		RETURNING id, owner_id, name, description, actions, created_at, updated_at, updated_by;`
+
+	dbr, err := toDBRole(r)
+	if err != nil {
+		return policies.Role{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	row, err := rr.db.NamedQueryContext(ctx, q, dbr)
+	if err != nil {
+		return policies.Role{}, postgres.HandleError(err, errors.ErrUpdateEntity)
+	}
+
+	defer row.Close()
+	if ok := row.Next(); !ok {
+		return policies.Role{}, errors.Wrap(errors.ErrNotFound, row.Err())
+	}
+	dbr = dbRole{}
+	if err := row.StructScan(&dbr); err != nil {
+		return policies.Role{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return toRole(dbr), nil
+}
+
+func (rr rrepo) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM roles WHERE id = $1`
+
+	res, err := rr.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (rr rrepo) Assign(ctx context.Context, a policies.Assignment) error {
+	q := `INSERT INTO role_assignments (owner_id, role_id, subject, object, created_at)
+		VALUES (:owner_id, :role_id, :subject, :object, :created_at)`
+
+	row, err := rr.db.NamedQueryContext(ctx, q, toDBAssignment(a))
+	if err != nil {
+		return postgres.HandleError(err, errors.ErrCreateEntity)
+	}
+
+	defer row.Close()
+
+	return nil
+}
+
+func (rr rrepo) Unassign(ctx context.Context, a policies.Assignment) error {
+	q := `DELETE FROM role_assignments WHERE role_id = $1 AND subject = $2 AND object = $3`
+
+	res, err := rr.db.ExecContext(ctx, q, a.RoleID, a.Subject, a.Object)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt, err := res.RowsAffected(); err != nil || cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (rr rrepo) RetrieveAssignments(ctx context.Context, pm policies.Page) (policies.AssignmentsPage, error) {
+	var query []string
+	var emq string
+
+	if pm.OwnerID != "" {
+		query = append(query, "owner_id = :owner_id")
+	}
+	if pm.Subject != "" {
+		query = append(query, "subject = :subject")
+	}
+	if pm.Object != "" {
+		query = append(query, "object = :object")
+	}
+	if pm.RoleID != "" {
+		query = append(query, "role_id = :role_id")
+	}
+
+	if len(query) > 0 {
+		emq = fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
+	}
+
+	q := fmt.Sprintf(`SELECT owner_id, role_id, subject, object, created_at
+		FROM role_assignments %s ORDER BY created_at LIMIT :limit OFFSET :offset;`, emq)
+
+	dbPage, err := toDBPoliciesPage(pm)
+	if err != nil {
+		return policies.AssignmentsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	rows, err := rr.db.NamedQueryContext(ctx, q, dbPage)
+	if err != nil {
+		return policies.AssignmentsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []policies.Assignment
+	for rows.Next() {
+		dba := dbAssignment{}
+		if err := rows.StructScan(&dba); err != nil {
+			return policies.AssignmentsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+		}
+		items = append(items, toAssignment(dba))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM role_assignments %s;`, emq)
+
+	total, err := postgres.Total(ctx, rr.db, cq, dbPage)
+	if err != nil {
+		return policies.AssignmentsPage{}, errors.Wrap(errors.ErrViewEntity, err)
+	}
+
+	page := policies.AssignmentsPage{
+		Assignments: items,
+		Page: policies.Page{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+type dbRole struct {
+	ID          string           `db:"id"`
+	OwnerID     string           `db:"owner_id"`
+	Name        string           `db:"name"`
+	Description sql.NullString   `db:"description"`
+	Actions     pgtype.TextArray `db:"actions"`
+	CreatedAt   time.Time        `db:"created_at"`
+	UpdatedAt   sql.NullTime     `db:"updated_at,omitempty"`
+	UpdatedBy   *string          `db:"updated_by,omitempty"`
+}
+
+func toDBRole(r policies.Role) (dbRole, error) {
+	var actions pgtype.TextArray
+	if err := actions.Set(r.Actions); err != nil {
+		return dbRole{}, err
+	}
+	var updatedAt sql.NullTime
+	if !r.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: r.UpdatedAt, Valid: true}
+	}
+	var updatedBy *string
+	if r.UpdatedBy != "" {
+		updatedBy = &r.UpdatedBy
+	}
+	return dbRole{
+		ID:          r.ID,
+		OwnerID:     r.OwnerID,
+		Name:        r.Name,
+		Description: sql.NullString{String: r.Description, Valid: r.Description != ""},
+		Actions:     actions,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   updatedAt,
+		UpdatedBy:   updatedBy,
+	}, nil
+}
+
+func toRole(dbr dbRole) policies.Role {
+	var actions []string
+	for _, e := range dbr.Actions.Elements {
+		actions = append(actions, e.String)
+	}
+	var updatedAt time.Time
+	if dbr.UpdatedAt.Valid {
+		updatedAt = dbr.UpdatedAt.Time
+	}
+	var updatedBy string
+	if dbr.UpdatedBy != nil {
+		updatedBy = *dbr.UpdatedBy
+	}
+	return policies.Role{
+		ID:          dbr.ID,
+		OwnerID:     dbr.OwnerID,
+		Name:        dbr.Name,
+		Description: dbr.Description.String,
+		Actions:     actions,
+		CreatedAt:   dbr.CreatedAt,
+		UpdatedAt:   updatedAt,
+		UpdatedBy:   updatedBy,
+	}
+}
+
+type dbAssignment struct {
+	OwnerID   string    `db:"owner_id"`
+	RoleID    string    `db:"role_id"`
+	Subject   string    `db:"subject"`
+	Object    string    `db:"object"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBAssignment(a policies.Assignment) dbAssignment {
+	return dbAssignment{
+		OwnerID:   a.OwnerID,
+		RoleID:    a.RoleID,
+		Subject:   a.Subject,
+		Object:    a.Object,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func toAssignment(dba dbAssignment) policies.Assignment {
+	return policies.Assignment{
+		OwnerID:   dba.OwnerID,
+		RoleID:    dba.RoleID,
+		Subject:   dba.Subject,
+		Object:    dba.Object,
+		CreatedAt: dba.CreatedAt,
+	}
+}
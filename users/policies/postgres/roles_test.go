@@ -0,0 +1,218 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/testsutil"
+	mfclients "github.com/mainflux/mainflux/pkg/clients"
+	"github.com/mainflux/mainflux/pkg/errors"
+	mfgroups "github.com/mainflux/mainflux/pkg/groups"
+	gpostgres "github.com/mainflux/mainflux/pkg/groups/postgres"
+	cpostgres "github.com/mainflux/mainflux/users/clients/postgres"
+	"github.com/mainflux/mainflux/users/policies"
+	ppostgres "github.com/mainflux/mainflux/users/policies/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cleanUpRoles(t *testing.T) {
+	_, err := db.Exec("DELETE FROM roles")
+	require.Nil(t, err, fmt.Sprintf("clean roles unexpected error: %s", err))
+	testsutil.CleanUpDB(t, db)
+}
+
+func TestRolesSave(t *testing.T) {
+	t.Cleanup(func() { cleanUpRoles(t) })
+	repo := ppostgres.NewRoleRepository(database)
+
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	role := policies.Role{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OwnerID:   ownerID,
+		Name:      "viewer",
+		Actions:   []string{"c_list", "g_list"},
+		CreatedAt: time.Now(),
+	}
+
+	cases := []struct {
+		desc string
+		role policies.Role
+		err  error
+	}{
+		{
+			desc: "save new role",
+			role: role,
+			err:  nil,
+		},
+		{
+			desc: "save role with duplicate name",
+			role: policies.Role{
+				ID:        testsutil.GenerateUUID(t, idProvider),
+				OwnerID:   ownerID,
+				Name:      role.Name,
+				Actions:   role.Actions,
+				CreatedAt: time.Now(),
+			},
+			err: errors.ErrConflict,
+		},
+		{
+			desc: "save role with the same name for another owner",
+			role: policies.Role{
+				ID:        testsutil.GenerateUUID(t, idProvider),
+				OwnerID:   testsutil.GenerateUUID(t, idProvider),
+				Name:      role.Name,
+				Actions:   role.Actions,
+				CreatedAt: time.Now(),
+			},
+			err: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := repo.Save(context.Background(), tc.role)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.role.Actions, saved.Actions, fmt.Sprintf("%s: expected actions %v got %v\n", tc.desc, tc.role.Actions, saved.Actions))
+		}
+	}
+}
+
+func TestRolesUpdateAndRemove(t *testing.T) {
+	t.Cleanup(func() { cleanUpRoles(t) })
+	repo := ppostgres.NewRoleRepository(database)
+
+	role, err := repo.Save(context.Background(), policies.Role{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OwnerID:   testsutil.GenerateUUID(t, idProvider),
+		Name:      "operator",
+		Actions:   []string{"c_update"},
+		CreatedAt: time.Now(),
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	role.Actions = []string{"c_update", "c_delete"}
+	role.UpdatedAt = time.Now()
+	role.UpdatedBy = role.OwnerID
+	updated, err := repo.Update(context.Background(), role)
+	assert.Nil(t, err, fmt.Sprintf("update role: unexpected error: %s", err))
+	assert.Equal(t, role.Actions, updated.Actions, fmt.Sprintf("update role: expected actions %v got %v", role.Actions, updated.Actions))
+
+	_, err = repo.Update(context.Background(), policies.Role{ID: testsutil.GenerateUUID(t, idProvider), Name: "missing", Actions: []string{"c_list"}})
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("update missing role: expected %s got %s", errors.ErrNotFound, err))
+
+	err = repo.Remove(context.Background(), role.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove role: unexpected error: %s", err))
+	_, err = repo.Retrieve(context.Background(), role.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed role: expected %s got %s", errors.ErrNotFound, err))
+}
+
+func TestRolesEvaluate(t *testing.T) {
+	t.Cleanup(func() { cleanUpRoles(t) })
+	repo := ppostgres.NewRepository(database)
+	rrepo := ppostgres.NewRoleRepository(database)
+	crepo := cpostgres.NewRepository(database)
+	grepo := gpostgres.New(database)
+
+	operator, err := crepo.Save(context.Background(), mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: "roles-operator@example.com",
+		Credentials: mfclients.Credentials{
+			Identity: "roles-operator@example.com",
+			Secret:   "pass",
+		},
+		Status: mfclients.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	member, err := crepo.Save(context.Background(), mfclients.Client{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: "roles-member@example.com",
+		Credentials: mfclients.Credentials{
+			Identity: "roles-member@example.com",
+			Secret:   "pass",
+		},
+		Status: mfclients.EnabledStatus,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	parent, err := grepo.Save(context.Background(), mfgroups.Group{
+		ID:   testsutil.GenerateUUID(t, idProvider),
+		Name: "roles-parent",
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	child, err := grepo.Save(context.Background(), mfgroups.Group{
+		ID:     testsutil.GenerateUUID(t, idProvider),
+		Name:   "roles-child",
+		Parent: parent.ID,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = repo.Save(context.Background(), policies.Policy{
+		OwnerID: member.ID,
+		Subject: member.ID,
+		Object:  child.ID,
+		Actions: []string{"g_list"},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	role, err := rrepo.Save(context.Background(), policies.Role{
+		ID:        testsutil.GenerateUUID(t, idProvider),
+		OwnerID:   operator.ID,
+		Name:      "operator",
+		Actions:   []string{"c_update", "g_update"},
+		CreatedAt: time.Now(),
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	thingGroupID := testsutil.GenerateUUID(t, idProvider)
+	for _, object := range []string{parent.ID, thingGroupID} {
+		err = rrepo.Assign(context.Background(), policies.Assignment{
+			OwnerID:   operator.ID,
+			RoleID:    role.ID,
+			Subject:   operator.ID,
+			Object:    object,
+			CreatedAt: time.Now(),
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := map[string]struct {
+		Subject string
+		Object  string
+		Action  string
+		Domain  string
+		err     error
+	}{
+		"evaluate role on the group":                 {operator.ID, parent.ID, "g_update", "group", nil},
+		"evaluate role inherited by the child group": {operator.ID, child.ID, "g_update", "group", nil},
+		"evaluate role on a things group":            {operator.ID, thingGroupID, "g_update", "group", nil},
+		"evaluate action missing from the role":      {operator.ID, child.ID, "g_delete", "group", errors.ErrAuthorization},
+		"evaluate role on the child group member":    {operator.ID, member.ID, "c_update", "client", nil},
+		"evaluate member without role":               {member.ID, child.ID, "g_update", "group", errors.ErrAuthorization},
+	}
+
+	for desc, tc := range cases {
+		aReq := policies.AccessRequest{
+			Subject: tc.Subject,
+			Object:  tc.Object,
+			Action:  tc.Action,
+		}
+		var err error
+		switch tc.Domain {
+		case "client":
+			_, err = repo.EvaluateUserAccess(context.Background(), aReq)
+		case "group":
+			_, err = repo.EvaluateGroupAccess(context.Background(), aReq)
+		}
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+
+	err = rrepo.Unassign(context.Background(), policies.Assignment{RoleID: role.ID, Subject: operator.ID, Object: parent.ID})
+	assert.Nil(t, err, fmt.Sprintf("unassign role: unexpected error: %s", err))
+	_, err = repo.EvaluateGroupAccess(context.Background(), policies.AccessRequest{Subject: operator.ID, Object: child.ID, Action: "g_update"})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("evaluate unassigned role: expected %s got %s", errors.ErrAuthorization, err))
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/apiutil"
+)
+
+const maxRoleNameSize = 254
+
+// Role represents a named bundle of actions, such as "viewer" or "operator".
+// Roles are reusable, so the same role can be assigned to many users on many
+// groups, and changing the role actions changes the access of all of them.
+type Role struct {
+	ID          string    `json:"id"`
+	OwnerID     string    `json:"owner_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Actions     []string  `json:"actions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+}
+
+// RolesPage contains a page of roles.
+type RolesPage struct {
+	Page
+	Roles []Role `json:"roles"`
+}
+
+// Assignment represents the role assigned to the user on a group. The group
+// is either a users group or a things group, and the role applies to all of
+// its descendants as well.
+type Assignment struct {
+	OwnerID   string    `json:"owner_id"`
+	RoleID    string    `json:"role_id"`
+	Subject   string    `json:"subject"`
+	Object    string    `json:"object"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AssignmentsPage contains a page of role assignments.
+type AssignmentsPage struct {
+	Page
+	Assignments []Assignment `json:"assignments"`
+}
+
+// RoleRepository specifies roles and role assignments persistence API.
+// Role assignments are evaluated by the policies Repository together with
+// the policies.
+type RoleRepository interface {
+	// Save persists the role. Role names are unique per owner.
+	Save(ctx context.Context, r Role) (Role, error)
+
+	// Retrieve retrieves the role with the given ID.
+	Retrieve(ctx context.Context, id string) (Role, error)
+
+	// RetrieveAll retrieves the roles filtered by the page owner.
+	RetrieveAll(ctx context.Context, pm Page) (RolesPage, error)
+
+	// Update updates the role name, description and actions.
+	Update(ctx context.Context, r Role) (Role, error)
+
+	// Remove removes the role with the given ID together with its assignments.
+	Remove(ctx context.Context, id string) error
+
+	// Assign persists the role assignment.
+	Assign(ctx context.Context, a Assignment) error
+
+	// Unassign removes the role assignment.
+	Unassign(ctx context.Context, a Assignment) error
+
+	// RetrieveAssignments retrieves the role assignments filtered by the
+	// page owner, subject, object and role.
+	RetrieveAssignments(ctx context.Context, pm Page) (AssignmentsPage, error)
+}
+
+// Validate returns an error if role representation is invalid.
+func (r Role) Validate() error {
+	if r.Name == "" || len(r.Name) > maxRoleNameSize {
+		return apiutil.ErrNameSize
+	}
+	if len(r.Actions) == 0 {
+		return apiutil.ErrMalformedPolicyAct
+	}
+	for _, a := range r.Actions {
+		if ok := ValidateAction(a); !ok {
+			return apiutil.ErrMalformedPolicyAct
+		}
+	}
+
+	return nil
+}
+
+// Validate returns an error if role assignment representation is invalid.
+func (a Assignment) Validate() error {
+	if a.RoleID == "" {
+		return apiutil.ErrMissingID
+	}
+	if a.Subject == "" {
+		return apiutil.ErrMissingPolicySub
+	}
+	if a.Object == "" {
+		return apiutil.ErrMissingPolicyObj
+	}
+
+	return nil
+}
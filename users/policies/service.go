@@ -12,21 +12,28 @@ import (
 	"github.com/mainflux/mainflux/users/jwt"
 )
 
-const AccessToken = "access"
+const (
+	AccessToken = "access"
+
+	// assignmentsBatchSize is the number of role assignments checked at once.
+	assignmentsBatchSize = 100
+)
 
 // ErrInvalidEntityType indicates that the entity type is invalid.
 var ErrInvalidEntityType = errors.New("invalid entity type")
 
 type service struct {
 	policies   Repository
+	roles      RoleRepository
 	idProvider mainflux.IDProvider
 	tokens     jwt.Repository
 }
 
 // NewService returns a new Policies service implementation.
-func NewService(p Repository, t jwt.Repository, idp mainflux.IDProvider) Service {
+func NewService(p Repository, r RoleRepository, t jwt.Repository, idp mainflux.IDProvider) Service {
 	return service{
 		policies:   p,
+		roles:      r,
 		tokens:     t,
 		idProvider: idp,
 	}
@@ -142,6 +149,224 @@ func (svc service) DeletePolicy(ctx context.Context, token string, p Policy) err
 	return svc.policies.Delete(ctx, p)
 }
 
+func (svc service) CreateRole(ctx context.Context, token string, r Role) (Role, error) {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return Role{}, err
+	}
+	if err := r.Validate(); err != nil {
+		return Role{}, err
+	}
+	rid, err := svc.idProvider.ID()
+	if err != nil {
+		return Role{}, err
+	}
+	r.ID = rid
+	r.OwnerID = id
+	r.Actions = AddListAction(r.Actions)
+	r.CreatedAt = time.Now()
+
+	return svc.roles.Save(ctx, r)
+}
+
+func (svc service) ViewRole(ctx context.Context, token, id string) (Role, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Role{}, err
+	}
+
+	return svc.checkRole(ctx, userID, id)
+}
+
+func (svc service) ListRoles(ctx context.Context, token string, pm Page) (RolesPage, error) {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return RolesPage{}, err
+	}
+	// If the user is admin, return all roles
+	if err := svc.policies.CheckAdmin(ctx, id); err == nil {
+		return svc.roles.RetrieveAll(ctx, pm)
+	}
+
+	// If the user is not admin, return only the roles that they created
+	pm.OwnerID = id
+
+	return svc.roles.RetrieveAll(ctx, pm)
+}
+
+func (svc service) UpdateRole(ctx context.Context, token string, r Role) (Role, error) {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return Role{}, err
+	}
+	if err := r.Validate(); err != nil {
+		return Role{}, err
+	}
+	role, err := svc.checkRole(ctx, id, r.ID)
+	if err != nil {
+		return Role{}, err
+	}
+	r.Actions = AddListAction(r.Actions)
+	if err := svc.checkAssignments(ctx, id, r.ID, added(role.Actions, r.Actions)); err != nil {
+		return Role{}, err
+	}
+	r.UpdatedAt = time.Now()
+	r.UpdatedBy = id
+
+	return svc.roles.Update(ctx, r)
+}
+
+func (svc service) RemoveRole(ctx context.Context, token, id string) error {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if _, err := svc.checkRole(ctx, userID, id); err != nil {
+		return err
+	}
+
+	return svc.roles.Remove(ctx, id)
+}
+
+// AssignRole assigns a role if:
+//
+//  1. The client is admin
+//
+//  2. The client owns the role and has `g_add` action on the object or is the owner of the object.
+func (svc service) AssignRole(ctx context.Context, token string, a Assignment) error {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	a.OwnerID = id
+	a.CreatedAt = time.Now()
+
+	role, err := svc.checkRole(ctx, id, a.RoleID)
+	if err != nil {
+		return err
+	}
+
+	// check if the client is admin
+	if err = svc.policies.CheckAdmin(ctx, id); err == nil {
+		return svc.roles.Assign(ctx, a)
+	}
+
+	// check if the client has `g_add` action on the object or is the owner of the object
+	areq := AccessRequest{Subject: id, Object: a.Object, Action: "g_add", Entity: "group"}
+	if pol, err := svc.policies.EvaluateGroupAccess(ctx, areq); err == nil {
+		// the client has `g_add` action on the object, so it can't grant more than it has
+		if len(pol.Actions) > 0 {
+			if err := checkActions(pol.Actions, role.Actions); err != nil {
+				return err
+			}
+		}
+
+		return svc.roles.Assign(ctx, a)
+	}
+
+	return errors.ErrAuthorization
+}
+
+func (svc service) UnassignRole(ctx context.Context, token string, a Assignment) error {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := a.Validate(); err != nil {
+		return err
+	}
+
+	// Check if the client is admin
+	if err := svc.policies.CheckAdmin(ctx, id); err == nil {
+		return svc.roles.Unassign(ctx, a)
+	}
+
+	// Check if the client is the owner of the assignment
+	pm := Page{RoleID: a.RoleID, Subject: a.Subject, Object: a.Object, OwnerID: id, Offset: 0, Limit: 1}
+	page, err := svc.roles.RetrieveAssignments(ctx, pm)
+	if err != nil {
+		return err
+	}
+	if len(page.Assignments) == 1 && page.Assignments[0].OwnerID == id {
+		return svc.roles.Unassign(ctx, a)
+	}
+
+	return errors.ErrAuthorization
+}
+
+func (svc service) ListAssignments(ctx context.Context, token string, pm Page) (AssignmentsPage, error) {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return AssignmentsPage{}, err
+	}
+	// If the user is admin, return all assignments
+	if err := svc.policies.CheckAdmin(ctx, id); err == nil {
+		return svc.roles.RetrieveAssignments(ctx, pm)
+	}
+
+	// If the user is not admin, return only the assignments that they made
+	pm.OwnerID = id
+
+	return svc.roles.RetrieveAssignments(ctx, pm)
+}
+
+// checkAssignments checks whether the client is allowed to grant the actions
+// on every group the role is assigned on. Assignees get the actions added to
+// the role right away, so adding them is limited the same way as assigning
+// the role is.
+func (svc service) checkAssignments(ctx context.Context, clientID, roleID string, actions []string) error {
+	if len(actions) == 0 {
+		return nil
+	}
+	if err := svc.policies.CheckAdmin(ctx, clientID); err == nil {
+		return nil
+	}
+
+	pm := Page{RoleID: roleID, Offset: 0, Limit: assignmentsBatchSize}
+	for {
+		page, err := svc.roles.RetrieveAssignments(ctx, pm)
+		if err != nil {
+			return err
+		}
+		for _, a := range page.Assignments {
+			areq := AccessRequest{Subject: clientID, Object: a.Object, Action: "g_add", Entity: "group"}
+			pol, err := svc.policies.EvaluateGroupAccess(ctx, areq)
+			if err != nil {
+				return errors.ErrAuthorization
+			}
+			// the client has `g_add` action on the object, so it can't grant more than it has
+			if len(pol.Actions) > 0 {
+				if err := checkActions(pol.Actions, actions); err != nil {
+					return err
+				}
+			}
+		}
+		pm.Offset += uint64(len(page.Assignments))
+		if len(page.Assignments) == 0 || pm.Offset >= page.Total {
+			return nil
+		}
+	}
+}
+
+// checkRole returns the role if the client is admin or the owner of the role.
+func (svc service) checkRole(ctx context.Context, clientID, roleID string) (Role, error) {
+	role, err := svc.roles.Retrieve(ctx, roleID)
+	if err != nil {
+		return Role{}, err
+	}
+	if role.OwnerID == clientID {
+		return role, nil
+	}
+	if err := svc.policies.CheckAdmin(ctx, clientID); err == nil {
+		return role, nil
+	}
+
+	return Role{}, errors.ErrAuthorization
+}
+
 // checkPolicy checks for the following:
 //
 //  1. Check if the client is admin
@@ -18,6 +18,13 @@ import (
 	"github.com/mainflux/mainflux/users/clients/mocks"
 	"github.com/mainflux/mainflux/users/hasher"
 	"github.com/mainflux/mainflux/users/jwt"
+	jmocks "github.com/mainflux/mainflux/users/jwt/mocks"
+	"github.com/mainflux/mainflux/users/lockout"
+	lmocks "github.com/mainflux/mainflux/users/lockout/mocks"
+	"github.com/mainflux/mainflux/users/mfa"
+	mmocks "github.com/mainflux/mainflux/users/mfa/mocks"
+	"github.com/mainflux/mainflux/users/passwords"
+	pwmocks "github.com/mainflux/mainflux/users/passwords/mocks"
 	"github.com/mainflux/mainflux/users/policies"
 	pmocks "github.com/mainflux/mainflux/users/policies/mocks"
 	"github.com/stretchr/testify/assert"
@@ -40,10 +47,11 @@ var (
 func TestAddPolicy(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(pmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
-	svc := policies.NewService(pRepo, tokenizer, idProvider)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	svc := policies.NewService(pRepo, rRepo, tokenizer, idProvider)
 
 	policy := policies.Policy{Object: testsutil.GenerateUUID(t, idProvider), Subject: testsutil.GenerateUUID(t, idProvider), Actions: []string{"c_list"}}
 
@@ -186,10 +194,11 @@ func TestAddPolicy(t *testing.T) {
 func TestAuthorize(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(pmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
-	svc := policies.NewService(pRepo, tokenizer, idProvider)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	svc := policies.NewService(pRepo, rRepo, tokenizer, idProvider)
 
 	cases := []struct {
 		desc   string
@@ -261,10 +270,11 @@ func TestAuthorize(t *testing.T) {
 func TestDeletePolicy(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(pmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
-	svc := policies.NewService(pRepo, tokenizer, idProvider)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	svc := policies.NewService(pRepo, rRepo, tokenizer, idProvider)
 
 	pr := policies.Policy{Object: authoritiesObj, Actions: memberActions, Subject: testsutil.GenerateUUID(t, idProvider)}
 
@@ -281,10 +291,11 @@ func TestDeletePolicy(t *testing.T) {
 func TestListPolicies(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(pmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
-	svc := policies.NewService(pRepo, tokenizer, idProvider)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	svc := policies.NewService(pRepo, rRepo, tokenizer, idProvider)
 
 	id := testsutil.GenerateUUID(t, idProvider)
 
@@ -379,10 +390,11 @@ func TestListPolicies(t *testing.T) {
 func TestUpdatePolicies(t *testing.T) {
 	cRepo := new(mocks.Repository)
 	pRepo := new(pmocks.Repository)
-	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration)
+	rRepo := new(pmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
 	e := mocks.NewEmailer()
-	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passRegex)
-	svc := policies.NewService(pRepo, tokenizer, idProvider)
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	svc := policies.NewService(pRepo, rRepo, tokenizer, idProvider)
 
 	policy := policies.Policy{Object: "obj1", Actions: []string{"m_read"}, Subject: "sub1"}
 
@@ -428,3 +440,304 @@ func TestUpdatePolicies(t *testing.T) {
 		repoCall2.Unset()
 	}
 }
+
+func TestCreateRole(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	rRepo := new(pmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	svc := policies.NewService(pRepo, rRepo, tokenizer, idProvider)
+
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	token := testsutil.GenerateValidToken(t, ownerID, csvc, cRepo, phasher)
+
+	cases := []struct {
+		desc  string
+		role  policies.Role
+		token string
+		err   error
+	}{
+		{
+			desc:  "create role with valid token",
+			role:  policies.Role{Name: "viewer", Actions: []string{"c_list", "g_list"}},
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "create role with invalid token",
+			role:  policies.Role{Name: "viewer", Actions: []string{"c_list"}},
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "create role without name",
+			role:  policies.Role{Actions: []string{"c_list"}},
+			token: token,
+			err:   apiutil.ErrNameSize,
+		},
+		{
+			desc:  "create role without actions",
+			role:  policies.Role{Name: "empty"},
+			token: token,
+			err:   apiutil.ErrMalformedPolicyAct,
+		},
+		{
+			desc:  "create role with invalid action",
+			role:  policies.Role{Name: "invalid", Actions: []string{"wrong"}},
+			token: token,
+			err:   apiutil.ErrMalformedPolicyAct,
+		},
+	}
+
+	for _, tc := range cases {
+		var saved policies.Role
+		repoCall := rRepo.On("Save", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(policies.Role)
+		}).Return(policies.Role{}, nil)
+		_, err := svc.CreateRole(context.Background(), tc.token, tc.role)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, ownerID, saved.OwnerID, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, ownerID, saved.OwnerID))
+			assert.NotEmpty(t, saved.ID, fmt.Sprintf("%s: expected role ID to be generated", tc.desc))
+		}
+		repoCall.Unset()
+	}
+}
+
+func TestUpdateRole(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	rRepo := new(pmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	svc := policies.NewService(pRepo, rRepo, tokenizer, idProvider)
+
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	ownerToken := testsutil.GenerateValidToken(t, ownerID, csvc, cRepo, phasher)
+	role := policies.Role{ID: testsutil.GenerateUUID(t, idProvider), OwnerID: ownerID, Name: "operator", Actions: []string{"c_update", "c_list", "g_list"}}
+	widened := role
+	widened.Actions = []string{"c_update", "c_delete"}
+	assignments := policies.AssignmentsPage{
+		Page: policies.Page{Total: 1},
+		Assignments: []policies.Assignment{
+			{OwnerID: ownerID, RoleID: role.ID, Subject: testsutil.GenerateUUID(t, idProvider), Object: testsutil.GenerateUUID(t, idProvider)},
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		role        policies.Role
+		isAdmin     bool
+		assignments policies.AssignmentsPage
+		policy      policies.Policy
+		policyErr   error
+		err         error
+	}{
+		{
+			desc:  "update role as the role owner",
+			token: ownerToken,
+			role:  role,
+			err:   nil,
+		},
+		{
+			desc:    "update role as admin",
+			token:   testsutil.GenerateValidToken(t, testsutil.GenerateUUID(t, idProvider), csvc, cRepo, phasher),
+			role:    role,
+			isAdmin: true,
+			err:     nil,
+		},
+		{
+			desc:  "update role as another user",
+			token: testsutil.GenerateValidToken(t, testsutil.GenerateUUID(t, idProvider), csvc, cRepo, phasher),
+			role:  role,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "add actions to unassigned role",
+			token: ownerToken,
+			role:  widened,
+			err:   nil,
+		},
+		{
+			desc:        "add actions to assigned role within the owner's actions",
+			token:       ownerToken,
+			role:        widened,
+			assignments: assignments,
+			policy:      policies.Policy{Actions: []string{"g_add", "c_update", "c_delete"}},
+			err:         nil,
+		},
+		{
+			desc:        "add actions to assigned role beyond the owner's actions",
+			token:       ownerToken,
+			role:        widened,
+			assignments: assignments,
+			policy:      policies.Policy{Actions: []string{"g_add", "c_update"}},
+			err:         errors.ErrAuthorization,
+		},
+		{
+			desc:        "add actions to role assigned on an object the owner can't access",
+			token:       ownerToken,
+			role:        widened,
+			assignments: assignments,
+			policyErr:   errors.ErrAuthorization,
+			err:         errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		adminErr := errors.ErrAuthorization
+		if tc.isAdmin {
+			adminErr = nil
+		}
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(adminErr)
+		repoCall1 := rRepo.On("Retrieve", context.Background(), role.ID).Return(role, nil)
+		repoCall2 := rRepo.On("RetrieveAssignments", context.Background(), mock.Anything).Return(tc.assignments, nil)
+		repoCall3 := pRepo.On("EvaluateGroupAccess", context.Background(), mock.Anything).Return(tc.policy, tc.policyErr)
+		repoCall4 := rRepo.On("Update", context.Background(), mock.Anything).Return(tc.role, nil)
+		_, err := svc.UpdateRole(context.Background(), tc.token, tc.role)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+	}
+}
+
+func TestAssignRole(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	rRepo := new(pmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	svc := policies.NewService(pRepo, rRepo, tokenizer, idProvider)
+
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	token := testsutil.GenerateValidToken(t, ownerID, csvc, cRepo, phasher)
+	role := policies.Role{ID: testsutil.GenerateUUID(t, idProvider), OwnerID: ownerID, Name: "operator", Actions: []string{"c_update", "g_list"}}
+	foreignRole := policies.Role{ID: testsutil.GenerateUUID(t, idProvider), OwnerID: testsutil.GenerateUUID(t, idProvider), Name: "operator", Actions: []string{"c_update"}}
+	assignment := policies.Assignment{Subject: testsutil.GenerateUUID(t, idProvider), Object: testsutil.GenerateUUID(t, idProvider)}
+
+	cases := []struct {
+		desc      string
+		token     string
+		role      policies.Role
+		policy    policies.Policy
+		policyErr error
+		err       error
+	}{
+		{
+			desc:  "assign role as the owner of the object",
+			token: token,
+			role:  role,
+			err:   nil,
+		},
+		{
+			desc:   "assign role with g_add action on the object",
+			token:  token,
+			role:   role,
+			policy: policies.Policy{Actions: []string{"g_add", "g_list", "c_update"}},
+			err:    nil,
+		},
+		{
+			desc:   "assign role with more actions than the user has on the object",
+			token:  token,
+			role:   role,
+			policy: policies.Policy{Actions: []string{"g_add", "g_list"}},
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:      "assign role without access to the object",
+			token:     token,
+			role:      role,
+			policyErr: errors.ErrAuthorization,
+			err:       errors.ErrAuthorization,
+		},
+		{
+			desc:  "assign role owned by another user",
+			token: token,
+			role:  foreignRole,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "assign role with invalid token",
+			token: inValidToken,
+			role:  role,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		a := assignment
+		a.RoleID = tc.role.ID
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(errors.ErrAuthorization)
+		repoCall1 := rRepo.On("Retrieve", context.Background(), tc.role.ID).Return(tc.role, nil)
+		repoCall2 := pRepo.On("EvaluateGroupAccess", context.Background(), mock.Anything).Return(tc.policy, tc.policyErr)
+		repoCall3 := rRepo.On("Assign", context.Background(), mock.Anything).Return(nil)
+		err := svc.AssignRole(context.Background(), tc.token, a)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+	}
+}
+
+func TestUnassignRole(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	pRepo := new(pmocks.Repository)
+	rRepo := new(pmocks.RoleRepository)
+	tokenizer := jwt.NewRepository([]byte(secret), accessDuration, refreshDuration, jmocks.NewRevocations())
+	e := mocks.NewEmailer()
+	csvc := clients.NewService(cRepo, pRepo, tokenizer, e, phasher, idProvider, passwords.NewPolicy(passwords.Config{}, passRegex, pwmocks.NewRepository(), phasher), mfa.NewAuthenticator(mmocks.NewRepository(), false), lockout.NewLimiter(lmocks.NewRepository(), lockout.Config{}), clients.RegistrationConfig{})
+	svc := policies.NewService(pRepo, rRepo, tokenizer, idProvider)
+
+	ownerID := testsutil.GenerateUUID(t, idProvider)
+	assignment := policies.Assignment{
+		OwnerID: ownerID,
+		RoleID:  testsutil.GenerateUUID(t, idProvider),
+		Subject: testsutil.GenerateUUID(t, idProvider),
+		Object:  testsutil.GenerateUUID(t, idProvider),
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		page  policies.AssignmentsPage
+		err   error
+	}{
+		{
+			desc:  "unassign role as the assignment owner",
+			token: testsutil.GenerateValidToken(t, ownerID, csvc, cRepo, phasher),
+			page:  policies.AssignmentsPage{Assignments: []policies.Assignment{assignment}},
+			err:   nil,
+		},
+		{
+			desc:  "unassign role as another user",
+			token: testsutil.GenerateValidToken(t, testsutil.GenerateUUID(t, idProvider), csvc, cRepo, phasher),
+			page:  policies.AssignmentsPage{},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "unassign role with invalid token",
+			token: inValidToken,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := pRepo.On("CheckAdmin", context.Background(), mock.Anything).Return(errors.ErrAuthorization)
+		repoCall1 := rRepo.On("RetrieveAssignments", context.Background(), mock.Anything).Return(tc.page, nil)
+		repoCall2 := rRepo.On("Unassign", context.Background(), mock.Anything).Return(nil)
+		err := svc.UnassignRole(context.Background(), tc.token, assignment)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+	}
+}
@@ -79,3 +79,89 @@ func (tm *tracingMiddleware) ListPolicies(ctx context.Context, token string, pm
 
 	return tm.psvc.ListPolicies(ctx, token, pm)
 }
+
+// CreateRole traces the "CreateRole" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) CreateRole(ctx context.Context, token string, r policies.Role) (policies.Role, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_role", trace.WithAttributes(
+		attribute.String("name", r.Name),
+		attribute.StringSlice("actions", r.Actions),
+	))
+	defer span.End()
+
+	return tm.psvc.CreateRole(ctx, token, r)
+}
+
+// ViewRole traces the "ViewRole" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) ViewRole(ctx context.Context, token, id string) (policies.Role, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_role", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.psvc.ViewRole(ctx, token, id)
+}
+
+// ListRoles traces the "ListRoles" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) ListRoles(ctx context.Context, token string, pm policies.Page) (policies.RolesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_roles", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.psvc.ListRoles(ctx, token, pm)
+}
+
+// UpdateRole traces the "UpdateRole" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) UpdateRole(ctx context.Context, token string, r policies.Role) (policies.Role, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_update_role", trace.WithAttributes(
+		attribute.String("id", r.ID),
+		attribute.String("name", r.Name),
+		attribute.StringSlice("actions", r.Actions),
+	))
+	defer span.End()
+
+	return tm.psvc.UpdateRole(ctx, token, r)
+}
+
+// RemoveRole traces the "RemoveRole" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) RemoveRole(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_role", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.psvc.RemoveRole(ctx, token, id)
+}
+
+// AssignRole traces the "AssignRole" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) AssignRole(ctx context.Context, token string, a policies.Assignment) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_assign_role", trace.WithAttributes(
+		attribute.String("role_id", a.RoleID),
+		attribute.String("subject", a.Subject),
+		attribute.String("object", a.Object),
+	))
+	defer span.End()
+
+	return tm.psvc.AssignRole(ctx, token, a)
+}
+
+// UnassignRole traces the "UnassignRole" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) UnassignRole(ctx context.Context, token string, a policies.Assignment) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_unassign_role", trace.WithAttributes(
+		attribute.String("role_id", a.RoleID),
+		attribute.String("subject", a.Subject),
+		attribute.String("object", a.Object),
+	))
+	defer span.End()
+
+	return tm.psvc.UnassignRole(ctx, token, a)
+}
+
+// ListAssignments traces the "ListAssignments" operation of the wrapped policies.Service.
+func (tm *tracingMiddleware) ListAssignments(ctx context.Context, token string, pm policies.Page) (policies.AssignmentsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_assignments", trace.WithAttributes(
+		attribute.String("role_id", pm.RoleID),
+		attribute.String("subject", pm.Subject),
+		attribute.String("object", pm.Object),
+	))
+	defer span.End()
+
+	return tm.psvc.ListAssignments(ctx, token, pm)
+}
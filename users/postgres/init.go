@@ -65,6 +65,198 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP TABLE IF EXISTS policies`,
 				},
 			},
+			{
+				Id: "keys_01",
+				// Only the SHA-256 hash of the key secret is stored.
+				// NULL expires_at implies a key that never expires.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS keys (
+						id          VARCHAR(36) PRIMARY KEY,
+						owner_id    VARCHAR(36) NOT NULL,
+						name        VARCHAR(254) NOT NULL,
+						secret      TEXT NOT NULL,
+						actions     TEXT[],
+						objects     TEXT[],
+						expires_at  TIMESTAMP,
+						created_at  TIMESTAMP,
+						UNIQUE (owner_id, name),
+						FOREIGN KEY (owner_id) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS keys`,
+				},
+			},
+			{
+				Id: "tokens_01",
+				// Revoked token IDs are kept only until the tokens expire,
+				// while client revocations invalidate every token issued
+				// to the client before revoked_at.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS revoked_tokens (
+						id          VARCHAR(36) PRIMARY KEY,
+						expires_at  TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS revoked_tokens_expires_at_idx ON revoked_tokens (expires_at)`,
+					`CREATE TABLE IF NOT EXISTS client_revocations (
+						client_id   VARCHAR(36) PRIMARY KEY,
+						revoked_at  TIMESTAMP NOT NULL,
+						FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS client_revocations`,
+					`DROP TABLE IF EXISTS revoked_tokens`,
+				},
+			},
+			{
+				Id: "mfa_01",
+				// Only the SHA-256 hashes of the recovery codes are stored.
+				// last_step prevents one-time passwords from being reused.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS mfa (
+						client_id       VARCHAR(36) PRIMARY KEY,
+						secret          VARCHAR(64) NOT NULL,
+						confirmed       BOOLEAN NOT NULL DEFAULT FALSE,
+						recovery_codes  TEXT[],
+						last_step       BIGINT NOT NULL DEFAULT 0,
+						created_at      TIMESTAMP,
+						FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS mfa`,
+				},
+			},
+			{
+				Id: "passwords_01",
+				// Only the hashes of the previous client secrets are stored,
+				// and only as many of them as the password policy requires.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS password_history (
+						id          BIGSERIAL PRIMARY KEY,
+						client_id   VARCHAR(36) NOT NULL,
+						secret      TEXT NOT NULL,
+						created_at  TIMESTAMP NOT NULL,
+						FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+					`CREATE INDEX IF NOT EXISTS password_history_client_id_idx ON password_history (client_id, created_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS password_history`,
+				},
+			},
+			{
+				Id: "roles_01",
+				// Assignment objects have no foreign key since a role can be
+				// assigned on a things group as well as on a users group.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS roles (
+						id          VARCHAR(36) PRIMARY KEY,
+						owner_id    VARCHAR(36) NOT NULL,
+						name        VARCHAR(254) NOT NULL,
+						description VARCHAR(1024),
+						actions     TEXT[] NOT NULL,
+						created_at  TIMESTAMP,
+						updated_at  TIMESTAMP,
+						updated_by  VARCHAR(254),
+						UNIQUE (owner_id, name)
+					)`,
+					`CREATE TABLE IF NOT EXISTS role_assignments (
+						owner_id    VARCHAR(36) NOT NULL,
+						role_id     VARCHAR(36) NOT NULL,
+						subject     VARCHAR(36) NOT NULL,
+						object      VARCHAR(36) NOT NULL,
+						created_at  TIMESTAMP,
+						FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						FOREIGN KEY (subject) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (role_id, subject, object)
+					)`,
+					`CREATE INDEX IF NOT EXISTS role_assignments_subject_idx ON role_assignments (subject, object)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS role_assignments`,
+					`DROP TABLE IF EXISTS roles`,
+				},
+			},
+			{
+				Id: "orgs_01",
+				// Zero quota implies unlimited number of entities.
+				// Identity is kept for the invitations since the invited
+				// user might not be registered yet.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS orgs (
+						id              VARCHAR(36) PRIMARY KEY,
+						owner_id        VARCHAR(36) NOT NULL,
+						name            VARCHAR(254) NOT NULL,
+						description     VARCHAR(1024),
+						metadata        JSONB,
+						users_quota     BIGINT NOT NULL DEFAULT 0 CHECK (users_quota >= 0),
+						things_quota    BIGINT NOT NULL DEFAULT 0 CHECK (things_quota >= 0),
+						channels_quota  BIGINT NOT NULL DEFAULT 0 CHECK (channels_quota >= 0),
+						created_at      TIMESTAMP,
+						updated_at      TIMESTAMP,
+						updated_by      VARCHAR(254),
+						UNIQUE (owner_id, name),
+						FOREIGN KEY (owner_id) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+					`CREATE TABLE IF NOT EXISTS org_members (
+						org_id      VARCHAR(36) NOT NULL,
+						member_id   VARCHAR(36) NOT NULL,
+						role        VARCHAR(16) NOT NULL,
+						created_at  TIMESTAMP,
+						FOREIGN KEY (org_id) REFERENCES orgs (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						FOREIGN KEY (member_id) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (org_id, member_id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS org_members_member_id_idx ON org_members (member_id)`,
+					`CREATE TABLE IF NOT EXISTS org_invitations (
+						id          VARCHAR(36) PRIMARY KEY,
+						org_id      VARCHAR(36) NOT NULL,
+						identity    VARCHAR(254) NOT NULL,
+						role        VARCHAR(16) NOT NULL,
+						invited_by  VARCHAR(36) NOT NULL,
+						created_at  TIMESTAMP,
+						expires_at  TIMESTAMP NOT NULL,
+						UNIQUE (org_id, identity),
+						FOREIGN KEY (org_id) REFERENCES orgs (id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS org_invitations`,
+					`DROP TABLE IF EXISTS org_members`,
+					`DROP TABLE IF EXISTS orgs`,
+				},
+			},
+			{
+				Id: "oidc_01",
+				// Each client is bound to at most one IdP subject and each
+				// subject to at most one client.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS oidc_identities (
+						client_id   VARCHAR(36) PRIMARY KEY,
+						issuer      VARCHAR(1024) NOT NULL,
+						subject     VARCHAR(255) NOT NULL,
+						created_at  TIMESTAMP,
+						UNIQUE (issuer, subject),
+						FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS oidc_identities`,
+				},
+			},
+			{
+				Id: "keys_02",
+				// NULL org_id implies a key which is not scoped to an
+				// organization.
+				Up: []string{
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS org_id VARCHAR(36) REFERENCES orgs (id) ON DELETE CASCADE ON UPDATE CASCADE`,
+				},
+				Down: []string{
+					`ALTER TABLE keys DROP COLUMN IF EXISTS org_id`,
+				},
+			},
 		},
 	}
 }
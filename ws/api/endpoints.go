@@ -16,6 +16,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things/presence"
 	"github.com/mainflux/mainflux/ws"
 )
 
@@ -36,6 +37,8 @@ func handshake(ctx context.Context, svc ws.Service) http.HandlerFunc {
 		req.conn = conn
 		client := ws.NewClient(conn)
 
+		ctx := presence.NewContext(ctx, presence.Source{Protocol: protocol, RemoteAddr: r.RemoteAddr})
+
 		if err := svc.Subscribe(ctx, req.thingKey, req.chanID, req.subtopic, client); err != nil {
 			req.conn.Close()
 			return